@@ -54,7 +54,7 @@ func (avd AccountVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, s
 	evmDenom := sdk.DefaultBondDenom
 
 	// validate sender has enough funds to pay for gas cost
-	balance := acc.GetCoins().AmountOf(evmDenom)
+	balance := acc.SpendableCoins(ctx.BlockTime()).AmountOf(evmDenom)
 	if balance.BigInt().Cmp(msgEthTx.Cost()) < 0 {
 		return ctx, sdkerrors.Wrapf(
 			sdkerrors.ErrInsufficientFunds,
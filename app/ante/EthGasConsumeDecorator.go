@@ -1,11 +1,8 @@
 package ante
 
 import (
-	"math/big"
-
 	"github.com/ethereum/go-ethereum/common"
 	ethcore "github.com/ethereum/go-ethereum/core"
-	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
@@ -19,19 +16,23 @@ type EthGasConsumeDecorator struct {
 	ak        auth.AccountKeeper
 	sk        types.SupplyKeeper
 	evmKeeper EVMKeeper
+	fk        FeegrantKeeper
 }
 
-// NewEthGasConsumeDecorator creates a new EthGasConsumeDecorator
-func NewEthGasConsumeDecorator(ak auth.AccountKeeper, sk types.SupplyKeeper, ek EVMKeeper) EthGasConsumeDecorator {
+// NewEthGasConsumeDecorator creates a new EthGasConsumeDecorator.
+// The gas fees are never paid by the fee granters if the feegrant keeper is nil.
+func NewEthGasConsumeDecorator(ak auth.AccountKeeper, sk types.SupplyKeeper, ek EVMKeeper, fk FeegrantKeeper) EthGasConsumeDecorator {
 	return EthGasConsumeDecorator{
 		ak:        ak,
 		sk:        sk,
 		evmKeeper: ek,
+		fk:        fk,
 	}
 }
 
 // AnteHandle validates that the Ethereum tx message has enough to cover intrinsic gas
 // (during CheckTx only) and that the sender has enough balance to pay for the gas cost.
+// The gas cost is paid by the fee granter of the sender if the sender can only afford the value.
 //
 // Intrinsic gas for a transaction is the amount of gas
 // that the transaction uses before the transaction is executed. The gas is a
@@ -68,7 +69,7 @@ func (egcd EthGasConsumeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simula
 	}
 
 	gasLimit := msgEthTx.GetGas()
-	gas, err := ethcore.IntrinsicGas(msgEthTx.Data.Payload, []ethtypes.AccessTuple{}, msgEthTx.To() == nil, true, false)
+	gas, err := ethcore.IntrinsicGas(msgEthTx.Data.Payload, msgEthTx.Data.Accesses, msgEthTx.To() == nil, true, false)
 	if err != nil {
 		return ctx, sdkerrors.Wrap(err, "failed to compute intrinsic gas cost")
 	}
@@ -78,18 +79,15 @@ func (egcd EthGasConsumeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simula
 		return ctx, sdkerrors.Wrapf(sdkerrors.ErrOutOfGas, "intrinsic gas too low: %d < %d", gasLimit, gas)
 	}
 
-	// Charge sender for gas up to limit
+	// Charge sender, or the fee granter of sender, for gas up to limit
 	if gasLimit != 0 {
-		// Cost calculates the fees paid to validators based on gas limit and price
-		cost := new(big.Int).Mul(msgEthTx.Data.Price, new(big.Int).SetUint64(gasLimit))
-
-		evmDenom := sdk.DefaultBondDenom
+		feeAmt := gasFeeAmount(msgEthTx)
 
-		feeAmt := sdk.NewCoins(
-			sdk.NewCoin(evmDenom, sdk.NewDecFromBigIntWithPrec(cost, sdk.Precision)), // int2dec
-		)
-
-		err = auth.DeductFees(egcd.sk, ctx, senderAcc, feeAmt)
+		if granter, found := findGasFeeGranter(&ctx, egcd.fk, senderAcc, msgEthTx, feeAmt); found {
+			err = deductGrantedGasFees(&ctx, egcd.ak, egcd.sk, egcd.fk, granter, msgEthTx, feeAmt)
+		} else {
+			err = auth.DeductFees(egcd.sk, ctx, senderAcc, feeAmt)
+		}
 		if err != nil {
 			return ctx, err
 		}
@@ -4,6 +4,7 @@ import (
 	ethermint "github.com/okex/exchain/app/types"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	evmtypes "github.com/okex/exchain/x/evm/types"
 )
 
@@ -34,6 +35,17 @@ func (esvd EthSigVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, s
 		return ctx, err
 	}
 
+	// the typed txs are enabled along with the berlin rules since the Venus2 height
+	if msgEthTx.Data.IsTyped() && !tmtypes.HigherThanVenus2(ctx.BlockHeight()) {
+		return ctx, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "typed tx of type %d is not supported before the Venus2 height", msgEthTx.Data.Type)
+	}
+
+	// the typed tx is signed over the chain id in its tx data, which could have been
+	// cached by the signature cache regardless of the chain id of the context
+	if msgEthTx.Data.IsTyped() && msgEthTx.ChainID().Cmp(chainIDEpoch) != 0 {
+		return ctx, sdkerrors.Wrapf(sdkerrors.ErrInvalidChainID, "invalid chain id for typed tx: have %s want %s", msgEthTx.ChainID(), chainIDEpoch)
+	}
+
 	// validate sender/signature and cache the address
 	err = msgEthTx.VerifySig(chainIDEpoch, ctx.BlockHeight())
 	if err != nil {
@@ -8,7 +8,6 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	ethcore "github.com/ethereum/go-ethereum/core"
-	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/okex/exchain/libs/cosmos-sdk/baseapp"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
@@ -21,18 +20,21 @@ type AccountAnteDecorator struct {
 	ak        auth.AccountKeeper
 	sk        types.SupplyKeeper
 	evmKeeper EVMKeeper
+	fk        FeegrantKeeper
 }
 
-// NewAccountVerificationDecorator creates a new AccountVerificationDecorator
-func NewAccountAnteDecorator(ak auth.AccountKeeper, ek EVMKeeper, sk types.SupplyKeeper) AccountAnteDecorator {
+// NewAccountVerificationDecorator creates a new AccountVerificationDecorator.
+// The gas fees are never paid by the fee granters if the feegrant keeper is nil.
+func NewAccountAnteDecorator(ak auth.AccountKeeper, ek EVMKeeper, sk types.SupplyKeeper, fk FeegrantKeeper) AccountAnteDecorator {
 	return AccountAnteDecorator{
 		ak:        ak,
 		sk:        sk,
 		evmKeeper: ek,
+		fk:        fk,
 	}
 }
 
-func accountVerification(ctx *sdk.Context, acc exported.Account, tx *evmtypes.MsgEthereumTx) error {
+func accountVerification(ctx *sdk.Context, acc exported.Account, tx *evmtypes.MsgEthereumTx, fk FeegrantKeeper) error {
 	if ctx.BlockHeight() == 0 && acc.GetAccountNumber() != 0 {
 		return sdkerrors.Wrapf(
 			sdkerrors.ErrInvalidSequence,
@@ -43,8 +45,12 @@ func accountVerification(ctx *sdk.Context, acc exported.Account, tx *evmtypes.Ms
 	evmDenom := sdk.DefaultBondDenom
 
 	// validate sender has enough funds to pay for gas cost
-	balance := acc.GetCoins().AmountOf(evmDenom)
+	balance := acc.SpendableCoins(ctx.BlockTime()).AmountOf(evmDenom)
 	if balance.BigInt().Cmp(tx.Cost()) < 0 {
+		// the sender only needs to pay for the value if the gas cost is paid by a fee granter
+		if _, found := findGasFeeGranter(ctx, fk, acc, tx, gasFeeAmount(tx)); found {
+			return nil
+		}
 		return sdkerrors.Wrapf(
 			sdkerrors.ErrInsufficientFunds,
 			"sender balance < tx gas cost (%s%s < %s%s)", balance.String(), evmDenom, sdk.NewDecFromBigIntWithPrec(tx.Cost(), sdk.Precision).String(), evmDenom,
@@ -132,9 +138,10 @@ func nonceVerification(ctx sdk.Context, acc exported.Account, msgEthTx *evmtypes
 	return ctx, nil
 }
 
-func ethGasConsume(ctx *sdk.Context, acc exported.Account, accGetGas sdk.Gas, msgEthTx *evmtypes.MsgEthereumTx, simulate bool, sk types.SupplyKeeper) error {
+func ethGasConsume(ctx *sdk.Context, acc exported.Account, accGetGas sdk.Gas, msgEthTx *evmtypes.MsgEthereumTx, simulate bool,
+	ak auth.AccountKeeper, sk types.SupplyKeeper, fk FeegrantKeeper) error {
 	gasLimit := msgEthTx.GetGas()
-	gas, err := ethcore.IntrinsicGas(msgEthTx.Data.Payload, []ethtypes.AccessTuple{}, msgEthTx.To() == nil, true, false)
+	gas, err := ethcore.IntrinsicGas(msgEthTx.Data.Payload, msgEthTx.Data.Accesses, msgEthTx.To() == nil, true, false)
 	if err != nil {
 		return sdkerrors.Wrap(err, "failed to compute intrinsic gas cost")
 	}
@@ -144,20 +151,17 @@ func ethGasConsume(ctx *sdk.Context, acc exported.Account, accGetGas sdk.Gas, ms
 		return sdkerrors.Wrapf(sdkerrors.ErrOutOfGas, "intrinsic gas too low: %d < %d", gasLimit, gas)
 	}
 
-	// Charge sender for gas up to limit
+	// Charge sender, or the fee granter of sender, for gas up to limit
 	if gasLimit != 0 {
-		// Cost calculates the fees paid to validators based on gas limit and price
-		cost := new(big.Int).Mul(msgEthTx.Data.Price, new(big.Int).SetUint64(gasLimit))
-
-		evmDenom := sdk.DefaultBondDenom
-
-		feeAmt := sdk.NewCoins(
-			sdk.NewCoin(evmDenom, sdk.NewDecFromBigIntWithPrec(cost, sdk.Precision)), // int2dec
-		)
-
-		ctx.UpdateFromAccountCache(acc, accGetGas)
+		feeAmt := gasFeeAmount(msgEthTx)
 
-		err = auth.DeductFees(sk, *ctx, acc, feeAmt)
+		if granter, found := findGasFeeGranter(ctx, fk, acc, msgEthTx, feeAmt); found {
+			// sender account is not updated, so it's not cached
+			err = deductGrantedGasFees(ctx, ak, sk, fk, granter, msgEthTx, feeAmt)
+		} else {
+			ctx.UpdateFromAccountCache(acc, accGetGas)
+			err = auth.DeductFees(sk, *ctx, acc, feeAmt)
+		}
 		if err != nil {
 			return err
 		}
@@ -168,6 +172,14 @@ func ethGasConsume(ctx *sdk.Context, acc exported.Account, accGetGas sdk.Gas, ms
 	return nil
 }
 
+// gasFeeAmount calculates the fees paid to validators based on gas limit and price
+func gasFeeAmount(msgEthTx *evmtypes.MsgEthereumTx) sdk.Coins {
+	cost := new(big.Int).Mul(msgEthTx.Data.Price, new(big.Int).SetUint64(msgEthTx.GetGas()))
+	return sdk.NewCoins(
+		sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewDecFromBigIntWithPrec(cost, sdk.Precision)), // int2dec
+	)
+}
+
 func incrementSeq(ctx sdk.Context, msgEthTx *evmtypes.MsgEthereumTx, ak auth.AccountKeeper, acc exported.Account) {
 	if ctx.IsCheckTx() && !ctx.IsReCheckTx() && !baseapp.IsMempoolEnableRecheck() && !ctx.IsTraceTx() {
 		return
@@ -221,7 +233,7 @@ func (avd AccountAnteDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate
 				avd.ak.SetAccount(ctx, acc)
 			}
 			// on InitChain make sure account number == 0
-			err = accountVerification(&ctx, acc, msgEthTx)
+			err = accountVerification(&ctx, acc, msgEthTx, avd.fk)
 			if err != nil {
 				return ctx, err
 			}
@@ -246,7 +258,7 @@ func (avd AccountAnteDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate
 
 		ctx.EnableAccountCache()
 		// account would be updated
-		err = ethGasConsume(&ctx, acc, getAccGasUsed, msgEthTx, simulate, avd.sk)
+		err = ethGasConsume(&ctx, acc, getAccGasUsed, msgEthTx, simulate, avd.ak, avd.sk, avd.fk)
 		acc = nil
 		acc, _ = ctx.GetFromAccountCacheData().(exported.Account)
 		ctx.DisableAccountCache()
@@ -8,11 +8,14 @@ import (
 	"github.com/stretchr/testify/require"
 
 	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
 	tmcrypto "github.com/okex/exchain/libs/tendermint/crypto"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/feegrant"
 
 	"github.com/okex/exchain/app"
 	"github.com/okex/exchain/app/ante"
@@ -229,6 +232,48 @@ func (suite *AnteTestSuite) TestEthInsufficientBalance() {
 	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
 }
 
+func (suite *AnteTestSuite) TestEthGasPaidByFeeGranter() {
+	suite.ctx.SetBlockHeight(1)
+
+	addr1, priv1 := newTestAddrKey()
+	addr2, _ := newTestAddrKey()
+	granter, _ := newTestAddrKey()
+
+	// the sender can only afford the value of tx
+	amt := big.NewInt(32)
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr1)
+	_ = acc.SetCoins(sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewDecFromBigIntWithPrec(amt, sdk.Precision))))
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	granterAcc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, granter)
+	_ = granterAcc.SetCoins(newTestCoins())
+	suite.app.AccountKeeper.SetAccount(suite.ctx, granterAcc)
+
+	to := ethcmn.BytesToAddress(addr2.Bytes())
+	gas := big.NewInt(20)
+	ethMsg := evmtypes.NewMsgEthereumTx(0, &to, amt, 22000, gas, []byte("test"))
+	tx, err := newTestEthTx(suite.ctx, ethMsg, priv1)
+	suite.Require().NoError(err)
+	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
+
+	allowance := feegrant.NewBasicAllowance(newTestCoins(), time.Time{})
+	suite.Require().NoError(suite.app.FeeGrantKeeper.GrantAllowance(suite.ctx, granter, addr1, allowance))
+
+	// the fee grants are not enabled before the Venus2 height
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(2)
+	defer tmtypes.UnittestOnlySetMilestoneVenus2Height(0)
+	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
+
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(1)
+	newCtx, err := suite.anteHandler(suite.ctx, tx, false)
+	suite.Require().NoError(err)
+	suite.Require().Equal(granter, newCtx.FeeGranter())
+
+	gasFee := sdk.NewDecFromBigIntWithPrec(new(big.Int).Mul(gas, big.NewInt(22000)), sdk.Precision)
+	balance := suite.app.AccountKeeper.GetAccount(suite.ctx, granter).GetCoins().AmountOf(sdk.DefaultBondDenom)
+	suite.Require().Equal(newTestCoins().AmountOf(sdk.DefaultBondDenom).Sub(gasFee), balance)
+}
+
 func (suite *AnteTestSuite) TestEthInvalidIntrinsicGas() {
 	suite.ctx.SetBlockHeight(1)
 
@@ -257,7 +302,7 @@ func (suite *AnteTestSuite) TestEthInvalidMempoolFees() {
 	suite.ctx = suite.app.BaseApp.NewContext(true, abci.Header{Height: 1, ChainID: "ethermint-3", Time: time.Now().UTC()})
 	suite.app.EvmKeeper.SetParams(suite.ctx, evmtypes.DefaultParams())
 
-	suite.anteHandler = ante.NewAnteHandler(suite.app.AccountKeeper, suite.app.EvmKeeper, suite.app.SupplyKeeper, nil)
+	suite.anteHandler = ante.NewAnteHandler(suite.app.AccountKeeper, suite.app.EvmKeeper, suite.app.SupplyKeeper, suite.app.FeeGrantKeeper, nil)
 	suite.ctx.SetMinGasPrices(sdk.NewDecCoins(sdk.NewDecCoinFromDec(types.NativeToken, sdk.NewDecFromBigIntWithPrec(big.NewInt(500000), sdk.Precision))))
 	addr1, priv1 := newTestAddrKey()
 	addr2, _ := newTestAddrKey()
@@ -276,3 +321,33 @@ func (suite *AnteTestSuite) TestEthInvalidMempoolFees() {
 	suite.Require().NoError(err)
 	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
 }
+
+func (suite *AnteTestSuite) TestEthTypedTxVenus2() {
+	suite.ctx.SetBlockHeight(10)
+
+	addr1, priv1 := newTestAddrKey()
+	addr2, _ := newTestAddrKey()
+
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr1)
+	_ = acc.SetCoins(newTestCoins())
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	to := ethcmn.BytesToAddress(addr2.Bytes())
+	ethMsg := evmtypes.NewMsgEthereumTx(0, &to, big.NewInt(32), 30000, big.NewInt(20), []byte("test"))
+	chainID, err := types.ParseChainID(suite.ctx.ChainID())
+	suite.Require().NoError(err)
+	ethMsg.Data.Type = ethtypes.AccessListTxType
+	ethMsg.Data.ChainID = chainID
+	ethMsg.Data.Accesses = ethtypes.AccessList{{Address: to, StorageKeys: []ethcmn.Hash{{1}}}}
+
+	tx, err := newTestEthTx(suite.ctx, ethMsg, priv1)
+	suite.Require().NoError(err)
+
+	// the access list tx is rejected before the Venus2 height
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(11)
+	defer tmtypes.UnittestOnlySetMilestoneVenus2Height(0)
+	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
+
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(10)
+	requireValidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
+}
@@ -0,0 +1,61 @@
+package ante
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
+	authante "github.com/okex/exchain/libs/cosmos-sdk/x/auth/ante"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth/exported"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+)
+
+// FeegrantKeeper defines the expected fee grant keeper used on the AnteHandler to let the
+// fee granters pay the fees of both the sdk txs and the evm txs of their grantees
+type FeegrantKeeper interface {
+	authante.FeegrantKeeper
+	FindGranter(ctx sdk.Context, grantee sdk.AccAddress, fee sdk.Coins, msgs []sdk.Msg) (sdk.AccAddress, bool)
+}
+
+// findGasFeeGranter returns the paymaster of the evm tx, which is the granter whose fee allowance
+// to the sender accepts the gas fee. A paymaster is only looked for if the sender can afford the
+// value of the tx but not its whole cost. The paymaster is resolved once per tx and carried on
+// the ctx, so that the later ante steps and the refund of the unused gas reuse it.
+func findGasFeeGranter(ctx *sdk.Context, fk FeegrantKeeper, acc exported.Account, msgEthTx *evmtypes.MsgEthereumTx, gasFee sdk.Coins) (sdk.AccAddress, bool) {
+	if granter := ctx.FeeGranter(); !granter.Empty() {
+		return granter, true
+	}
+
+	// the fee grant store is only committed since the Venus2 height
+	if fk == nil || !tmtypes.HigherThanVenus2(ctx.BlockHeight()) {
+		return nil, false
+	}
+
+	balance := acc.SpendableCoins(ctx.BlockTime()).AmountOf(sdk.DefaultBondDenom).BigInt()
+	if balance.Cmp(msgEthTx.Cost()) >= 0 || balance.Cmp(msgEthTx.Data.Amount) < 0 {
+		return nil, false
+	}
+
+	granter, found := fk.FindGranter(*ctx, acc.GetAddress(), gasFee, msgEthTx.GetMsgs())
+	if found {
+		ctx.SetFeeGranter(granter)
+	}
+	return granter, found
+}
+
+// deductGrantedGasFees spends the fee allowance granted to the sender of the evm tx and deducts
+// the gas fee from the granter
+func deductGrantedGasFees(ctx *sdk.Context, ak auth.AccountKeeper, sk types.SupplyKeeper, fk FeegrantKeeper,
+	granter sdk.AccAddress, msgEthTx *evmtypes.MsgEthereumTx, gasFee sdk.Coins) error {
+	err := fk.UseGrantedFees(*ctx, granter, msgEthTx.AccountAddress(), gasFee, msgEthTx.GetMsgs())
+	if err != nil {
+		return err
+	}
+
+	granterAcc := ak.GetAccount(*ctx, granter)
+	if granterAcc == nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnknownAddress, "fee granter address: %s does not exist", granter)
+	}
+	return auth.DeductFees(sk, *ctx, granterAcc, gasFee)
+}
@@ -27,11 +27,20 @@ import (
 	capabilitykeeper "github.com/okex/exchain/libs/cosmos-sdk/x/capability/keeper"
 	capabilitytypes "github.com/okex/exchain/libs/cosmos-sdk/x/capability/types"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/crisis"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/feegrant"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/mint"
 	govclient "github.com/okex/exchain/libs/cosmos-sdk/x/mint/client"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/supply"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/upgrade"
 	"github.com/okex/exchain/libs/iavl"
+	ica "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts"
+	icacontroller "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/controller"
+	icacontrollerkeeper "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/controller/keeper"
+	icacontrollertypes "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/controller/types"
+	icahost "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/host"
+	icahostkeeper "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/host/keeper"
+	icahosttypes "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/host/types"
+	icatypes "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/types"
 	ibctransfer "github.com/okex/exchain/libs/ibc-go/modules/apps/transfer"
 	ibctransferkeeper "github.com/okex/exchain/libs/ibc-go/modules/apps/transfer/keeper"
 	ibctransfertypes "github.com/okex/exchain/libs/ibc-go/modules/apps/transfer/types"
@@ -45,6 +54,7 @@ import (
 	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	dbm "github.com/okex/exchain/libs/tm-db"
 	"github.com/okex/exchain/x/ammswap"
+	"github.com/okex/exchain/x/authz"
 	"github.com/okex/exchain/x/common/analyzer"
 	commonversion "github.com/okex/exchain/x/common/version"
 	"github.com/okex/exchain/x/dex"
@@ -55,6 +65,7 @@ import (
 	"github.com/okex/exchain/x/evidence"
 	"github.com/okex/exchain/x/evm"
 	evmclient "github.com/okex/exchain/x/evm/client"
+	evmstream "github.com/okex/exchain/x/evm/stream"
 	evmtypes "github.com/okex/exchain/x/evm/types"
 	"github.com/okex/exchain/x/farm"
 	farmclient "github.com/okex/exchain/x/farm/client"
@@ -67,6 +78,7 @@ import (
 	"github.com/okex/exchain/x/slashing"
 	"github.com/okex/exchain/x/staking"
 	"github.com/okex/exchain/x/token"
+	"github.com/okex/exchain/x/vesting"
 
 	"github.com/spf13/viper"
 	"google.golang.org/grpc/encoding"
@@ -126,6 +138,10 @@ var (
 		ibc.AppModuleBasic{},
 		ibctransfer.AppModuleBasic{},
 		erc20.AppModuleBasic{},
+		ica.AppModuleBasic{},
+		feegrant.AppModuleBasic{},
+		authz.AppModuleBasic{},
+		vesting.AppModuleBasic{},
 	)
 
 	// module account permissions
@@ -188,6 +204,9 @@ type OKExChainApp struct {
 	OrderKeeper    order.Keeper
 	SwapKeeper     ammswap.Keeper
 	FarmKeeper     farm.Keeper
+	FeeGrantKeeper feegrant.Keeper
+	AuthzKeeper    authz.Keeper
+	VestingKeeper  vesting.Keeper
 
 	// the module manager
 	mm *module.Manager
@@ -202,12 +221,19 @@ type OKExChainApp struct {
 	ScopedIBCKeeper      capabilitykeeper.ScopedKeeper
 	ScopedTransferKeeper capabilitykeeper.ScopedKeeper
 	ScopedIBCMockKeeper  capabilitykeeper.ScopedKeeper
+	ScopedICAController  capabilitykeeper.ScopedKeeper
+	ScopedICAHost        capabilitykeeper.ScopedKeeper
 	TransferKeeper       ibctransferkeeper.Keeper
+	ICAControllerKeeper  icacontrollerkeeper.Keeper
+	ICAHostKeeper        icahostkeeper.Keeper
 	CapabilityKeeper     *capabilitykeeper.Keeper
 	IBCKeeper            *ibc.Keeper // IBC Keeper must be a pointer in the app, so we can SetRouter on it correctly
 	marshal              *codec.CodecProxy
 	heightTasks          map[int64]*upgradetypes.HeightTasks
 	Erc20Keeper          erc20.Keeper
+
+	// EvmStreamer streams the committed evm txs to the external indexers, it's nil if the evm stream is disabled
+	EvmStreamer *evmstream.Streamer
 }
 
 // NewOKExChainApp returns a reference to a new initialized OKExChain application.
@@ -249,7 +275,8 @@ func NewOKExChainApp(
 		evm.StoreKey, token.StoreKey, token.KeyLock, dex.StoreKey, dex.TokenPairStoreKey,
 		order.OrderStoreKey, ammswap.StoreKey, farm.StoreKey, ibctransfertypes.StoreKey, capabilitytypes.StoreKey,
 		ibchost.StoreKey,
-		erc20.StoreKey,
+		erc20.StoreKey, icacontrollertypes.StoreKey, icahosttypes.StoreKey,
+		feegrant.StoreKey, authz.StoreKey,
 	)
 
 	tkeys := sdk.NewTransientStoreKeys(params.TStoreKey)
@@ -285,6 +312,8 @@ func NewOKExChainApp(
 	app.subspaces[ibchost.ModuleName] = app.ParamsKeeper.Subspace(ibchost.ModuleName)
 	app.subspaces[ibctransfertypes.ModuleName] = app.ParamsKeeper.Subspace(ibctransfertypes.ModuleName)
 	app.subspaces[erc20.ModuleName] = app.ParamsKeeper.Subspace(erc20.DefaultParamspace)
+	app.subspaces[icacontrollertypes.SubModuleName] = app.ParamsKeeper.Subspace(icacontrollertypes.SubModuleName)
+	app.subspaces[icahosttypes.SubModuleName] = app.ParamsKeeper.Subspace(icahosttypes.SubModuleName)
 
 	//proxy := codec.NewMarshalProxy(cc, cdc)
 	app.marshal = codecProxy
@@ -328,6 +357,7 @@ func NewOKExChainApp(
 
 	app.TokenKeeper = token.NewKeeper(app.BankKeeper, app.subspaces[token.ModuleName], auth.FeeCollectorName, app.SupplyKeeper,
 		keys[token.StoreKey], keys[token.KeyLock], app.marshal.GetCdc(), false, &app.AccountKeeper)
+	(&bankKeeper).SetSendCoinsChecker(app.TokenKeeper.CheckTransferable)
 
 	app.DexKeeper = dex.NewKeeper(auth.FeeCollectorName, app.SupplyKeeper, app.subspaces[dex.ModuleName], app.TokenKeeper, &stakingKeeper,
 		app.BankKeeper, app.keys[dex.StoreKey], app.keys[dex.TokenPairStoreKey], app.marshal.GetCdc())
@@ -335,12 +365,18 @@ func NewOKExChainApp(
 	app.OrderKeeper = order.NewKeeper(
 		app.TokenKeeper, app.SupplyKeeper, app.DexKeeper, app.subspaces[order.ModuleName], auth.FeeCollectorName,
 		app.keys[order.OrderStoreKey], app.marshal.GetCdc(), false, orderMetrics)
+	// register the token hooks
+	app.TokenKeeper = *app.TokenKeeper.SetHooks(app.OrderKeeper.Hooks())
 
 	app.SwapKeeper = ammswap.NewKeeper(app.SupplyKeeper, app.TokenKeeper, app.marshal.GetCdc(), app.keys[ammswap.StoreKey], app.subspaces[ammswap.ModuleName])
 
 	app.FarmKeeper = farm.NewKeeper(auth.FeeCollectorName, app.SupplyKeeper, app.TokenKeeper, app.SwapKeeper, *app.EvmKeeper, app.subspaces[farm.StoreKey],
 		app.keys[farm.StoreKey], app.marshal.GetCdc())
 
+	app.FeeGrantKeeper = feegrant.NewKeeper(codecProxy.GetCdc(), keys[feegrant.StoreKey], &app.AccountKeeper)
+	app.AuthzKeeper = authz.NewKeeper(codecProxy.GetCdc(), keys[authz.StoreKey], app.Router())
+	app.VestingKeeper = vesting.NewKeeper(&app.AccountKeeper, app.BankKeeper)
+
 	// create evidence keeper with router
 	evidenceKeeper := evidence.NewKeeper(
 		codecProxy.GetCdc(), keys[evidence.StoreKey], app.subspaces[evidence.ModuleName], &app.StakingKeeper, app.SlashingKeeper,
@@ -353,6 +389,8 @@ func NewOKExChainApp(
 	app.CapabilityKeeper = capabilitykeeper.NewKeeper(codecProxy, keys[capabilitytypes.StoreKey], memKeys[capabilitytypes.MemStoreKey])
 	scopedIBCKeeper := app.CapabilityKeeper.ScopeToModule(ibchost.ModuleName)
 	scopedTransferKeeper := app.CapabilityKeeper.ScopeToModule(ibctransfertypes.ModuleName)
+	scopedICAControllerKeeper := app.CapabilityKeeper.ScopeToModule(icacontrollertypes.SubModuleName)
+	scopedICAHostKeeper := app.CapabilityKeeper.ScopeToModule(icahosttypes.SubModuleName)
 	// NOTE: the IBC mock keeper and application module is used only for testing core IBC. Do
 	// note replicate if you do not need to test core IBC or light clients.
 	scopedIBCMockKeeper := app.CapabilityKeeper.ScopeToModule("mock")
@@ -367,8 +405,21 @@ func NewOKExChainApp(
 		app.IBCKeeper.ChannelKeeper, &app.IBCKeeper.PortKeeper,
 		app.SupplyKeeper, app.SupplyKeeper, scopedTransferKeeper, interfaceReg,
 	)
+	// the paused tokens and the frozen accounts can't be transferred to other chains either
+	app.TransferKeeper.SetSendCoinsChecker(app.TokenKeeper.CheckTransferable)
 	ibctransfertypes.SetMarshal(codecProxy)
 
+	// Create interchain accounts keepers, the msgs of interchain accounts are routed by the app msg router
+	app.ICAControllerKeeper = icacontrollerkeeper.NewKeeper(
+		codecProxy, keys[icacontrollertypes.StoreKey], app.GetSubspace(icacontrollertypes.SubModuleName),
+		app.IBCKeeper.ChannelKeeper, &app.IBCKeeper.PortKeeper, scopedICAControllerKeeper, app.Router(),
+	)
+	app.ICAHostKeeper = icahostkeeper.NewKeeper(
+		codecProxy, keys[icahosttypes.StoreKey], app.GetSubspace(icahosttypes.SubModuleName),
+		app.IBCKeeper.ChannelKeeper, &app.IBCKeeper.PortKeeper, app.AccountKeeper, app.EvmKeeper,
+		scopedICAHostKeeper, app.Router(),
+	)
+
 	app.Erc20Keeper = erc20.NewKeeper(app.marshal.GetCdc(), app.keys[erc20.ModuleName], app.subspaces[erc20.ModuleName],
 		app.AccountKeeper, app.SupplyKeeper, app.BankKeeper, app.EvmKeeper, app.TransferKeeper)
 
@@ -404,14 +455,31 @@ func NewOKExChainApp(
 	app.Erc20Keeper.SetGovKeeper(app.GovKeeper)
 
 	// Set EVM hooks
-	app.EvmKeeper.SetHooks(evm.NewLogProcessEvmHook(erc20.NewSendToIbcEventHandler(app.Erc20Keeper)))
+	logProcessEvmHook := evm.NewLogProcessEvmHook(erc20.NewSendToIbcEventHandler(app.Erc20Keeper))
+	// the native precompile lets the contracts call the native modules as msg.sender
+	evm.RegisterStatefulPrecompile(evm.NativePrecompileAddress, evm.NewNativePrecompile(
+		append(ammswap.NewPrecompileMethods(app.SwapKeeper), order.NewPrecompileMethods(app.OrderKeeper)...)...))
+	if evmtypes.GetEnableEvmStream() {
+		streamer, err := evmstream.NewStreamer(logger)
+		if err != nil {
+			panic(err)
+		}
+		app.EvmStreamer = streamer
+		// the streamer goes last, so that the txs reverted by the other hooks are streamed as failed
+		app.EvmKeeper.SetHooks(evm.NewMultiEvmHooks(logProcessEvmHook, streamer))
+	} else {
+		app.EvmKeeper.SetHooks(logProcessEvmHook)
+	}
 	// Set IBC hooks
 	app.TransferKeeper = *app.TransferKeeper.SetHooks(erc20.NewIBCTransferHooks(app.Erc20Keeper))
 	transferModule := ibctransfer.NewAppModule(app.TransferKeeper, codecProxy)
 
 	// Create static IBC router, add transfer route, then set and seal it
 	ibcRouter := ibcporttypes.NewRouter()
-	ibcRouter.AddRoute(ibctransfertypes.ModuleName, transferModule)
+	// the transfer route is wrapped by the erc20 middleware to call evm contracts on packet memos
+	ibcRouter.AddRoute(ibctransfertypes.ModuleName, erc20.NewIBCMiddleware(transferModule, app.Erc20Keeper))
+	ibcRouter.AddRoute(icacontrollertypes.SubModuleName, icacontroller.NewIBCModule(app.ICAControllerKeeper))
+	ibcRouter.AddRoute(icahosttypes.SubModuleName, icahost.NewIBCModule(app.ICAHostKeeper))
 	//ibcRouter.AddRoute(ibcmock.ModuleName, mockModule)
 	app.IBCKeeper.SetRouter(ibcRouter)
 
@@ -447,6 +515,10 @@ func NewOKExChainApp(
 		capabilityModule.NewAppModule(codecProxy, *app.CapabilityKeeper),
 		transferModule,
 		erc20.NewAppModule(app.Erc20Keeper),
+		ica.NewAppModule(app.ICAControllerKeeper, app.ICAHostKeeper),
+		feegrant.NewAppModule(app.FeeGrantKeeper),
+		authz.NewAppModule(app.AuthzKeeper),
+		vesting.NewAppModule(app.VestingKeeper),
 	)
 
 	// During begin block slashing happens after distr.BeginBlocker so that
@@ -487,7 +559,7 @@ func NewOKExChainApp(
 		ibctransfertypes.ModuleName,
 		ibchost.ModuleName,
 		evm.ModuleName, crisis.ModuleName, genutil.ModuleName, params.ModuleName, evidence.ModuleName,
-		erc20.ModuleName,
+		erc20.ModuleName, icatypes.ModuleName, feegrant.ModuleName, authz.ModuleName, vesting.ModuleName,
 	)
 
 	app.mm.RegisterInvariants(&app.CrisisKeeper)
@@ -523,11 +595,12 @@ func NewOKExChainApp(
 	// initialize BaseApp
 	app.SetInitChainer(app.InitChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
-	app.SetAnteHandler(ante.NewAnteHandler(app.AccountKeeper, app.EvmKeeper, app.SupplyKeeper, validateMsgHook(app.OrderKeeper)))
+	app.SetAnteHandler(ante.NewAnteHandler(app.AccountKeeper, app.EvmKeeper, app.SupplyKeeper, app.FeeGrantKeeper, validateMsgHook(app.OrderKeeper)))
 	app.SetEndBlocker(app.EndBlocker)
 	app.SetGasRefundHandler(refund.NewGasRefundHandler(app.AccountKeeper, app.SupplyKeeper))
 	app.SetAccHandler(NewAccHandler(app.AccountKeeper))
 	app.SetParallelTxHandlers(updateFeeCollectorHandler(app.BankKeeper, app.SupplyKeeper), evmTxFeeHandler(), fixLogForParallelTxHandler(app.EvmKeeper))
+	app.SetParallelTxHintsHandler(evmTxHintsHandler())
 	app.SetEvmTxVerifySignHandler(evmTxVerifySigHandler())
 	if loadLatest {
 		err := app.LoadLatestVersion(app.keys[bam.MainStoreKey])
@@ -538,6 +611,8 @@ func NewOKExChainApp(
 
 	app.ScopedIBCKeeper = scopedIBCKeeper
 	app.ScopedTransferKeeper = scopedTransferKeeper
+	app.ScopedICAController = scopedICAControllerKeeper
+	app.ScopedICAHost = scopedICAHostKeeper
 
 	// NOTE: the IBC mock keeper and application module is used only for testing core IBC. Do
 	// note replicate if you do not need to test core IBC or light clients.
@@ -664,6 +739,8 @@ func validateMsgHook(orderKeeper order.Keeper) ante.ValidateMsgHandler {
 			"It is not allowed that a transaction with more than one message contains order or evm message")
 		var err error
 
+		// the msgs executed on behalf of the granters are validated as if they were sent by the granters
+		msgs = flattenExecMsgs(msgs)
 		for _, msg := range msgs {
 			switch assertedMsg := msg.(type) {
 			case order.MsgNewOrders:
@@ -690,6 +767,18 @@ func validateMsgHook(orderKeeper order.Keeper) ante.ValidateMsgHandler {
 	}
 }
 
+func flattenExecMsgs(msgs []sdk.Msg) []sdk.Msg {
+	var flattened []sdk.Msg
+	for _, msg := range msgs {
+		if execMsg, ok := msg.(authz.MsgExec); ok {
+			flattened = append(flattened, flattenExecMsgs(execMsg.Msgs)...)
+			continue
+		}
+		flattened = append(flattened, msg)
+	}
+	return flattened
+}
+
 func NewAccHandler(ak auth.AccountKeeper) sdk.AccHandler {
 	return func(
 		ctx sdk.Context, addr sdk.AccAddress,
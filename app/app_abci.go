@@ -7,6 +7,7 @@ import (
 	"github.com/okex/exchain/libs/tendermint/trace"
 	"github.com/okex/exchain/x/common/analyzer"
 	"github.com/okex/exchain/x/evm"
+	evmstream "github.com/okex/exchain/x/evm/stream"
 )
 
 // BeginBlock implements the Application interface
@@ -77,6 +78,17 @@ func (app *OKExChainApp) Commit(req abci.RequestCommit) abci.ResponseCommit {
 			}
 		}
 	}
+	var streamBlockCtx evmstream.BlockContext
+	if app.EvmStreamer != nil {
+		ctx := app.BaseApp.GetDeliverStateCtx()
+		header := ctx.BlockHeader()
+		streamBlockCtx = evmstream.BlockContext{
+			Height:   header.Height,
+			Hash:     app.EvmKeeper.Bhash,
+			Time:     header.Time.Unix(),
+			Proposer: header.ProposerAddress,
+		}
+	}
 	res := app.BaseApp.Commit(req)
 
 	// we call watch#Commit here ,because
@@ -84,6 +96,9 @@ func (app *OKExChainApp) Commit(req abci.RequestCommit) abci.ResponseCommit {
 	// 2. before commit the block,State#updateToState hasent not called yet,so the proposalBlockPart is not nil which means we wont
 	// 	  call the prerun during commit step(edge case)
 	app.EvmKeeper.Watcher.Commit()
+	if app.EvmStreamer != nil {
+		app.EvmStreamer.Commit(streamBlockCtx)
+	}
 
 	return res
 }
@@ -2,6 +2,7 @@ package app
 
 import (
 	"fmt"
+	"strings"
 
 	ethermint "github.com/okex/exchain/app/types"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
@@ -36,6 +37,24 @@ func evmTxFeeHandler() sdk.GetTxFeeHandler {
 	}
 }
 
+// evmTxHintsHandler returns the sender and the recipient of evm tx, the recipient is the contract called
+// by the tx, txs sharing one of them are likely to conflict
+func evmTxHintsHandler() sdk.GetTxHintsHandler {
+	return func(ctx sdk.Context, tx sdk.Tx) (hints []string) {
+		evmTx, ok := tx.(*evmtypes.MsgEthereumTx)
+		if !ok {
+			return
+		}
+		if evmTx.BaseTx.From != "" {
+			hints = append(hints, strings.ToLower(evmTx.BaseTx.From))
+		}
+		if to := evmTx.To(); to != nil {
+			hints = append(hints, strings.ToLower(to.Hex()))
+		}
+		return
+	}
+}
+
 // fixLogForParallelTxHandler fix log for parallel tx
 func fixLogForParallelTxHandler(ek *evm.Keeper) sdk.LogFix {
 	return func(execResults [][]string) (logs [][]byte) {
@@ -4,7 +4,13 @@ import (
 	"os"
 	"testing"
 
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/upgrade"
+	ibctransfertypes "github.com/okex/exchain/libs/ibc-go/modules/apps/transfer/types"
+	clienttypes "github.com/okex/exchain/libs/ibc-go/modules/core/02-client/types"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	tokentypes "github.com/okex/exchain/x/token/types"
 	"github.com/okex/exchain/x/dex"
 	distr "github.com/okex/exchain/x/distribution"
 	"github.com/okex/exchain/x/farm"
@@ -68,3 +74,24 @@ func TestProposalManager(t *testing.T) {
 	require.True(t, app.GovKeeper.ProposalHandleRouter().HasRoute(dex.RouterKey))
 	require.True(t, app.GovKeeper.ProposalHandleRouter().HasRoute(farm.RouterKey))
 }
+
+func TestTransferPausedToken(t *testing.T) {
+	app := Setup(false)
+	ctx := app.NewContext(false, abci.Header{Height: 2})
+	app.TransferKeeper.SetParams(ctx, ibctransfertypes.NewParams(true, true))
+
+	symbol := "xxb"
+	sender := sdk.AccAddress([]byte("ibc-transfer-sender1"))
+	app.TokenKeeper.SetTokenPaused(ctx, symbol, true)
+	send := func() error {
+		return app.TransferKeeper.SendTransfer(ctx, ibctransfertypes.PortID, "channel-0",
+			sdk.NewCoinAdapter(symbol, sdk.NewInt(1)), sender, "receiver", clienttypes.NewHeight(0, 100), 0)
+	}
+
+	// the tokens can't be paused before the Venus2 height
+	require.True(t, channeltypes.ErrChannelNotFound.Is(send()))
+
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(2)
+	defer tmtypes.UnittestOnlySetMilestoneVenus2Height(0)
+	require.Equal(t, tokentypes.ErrTokenPaused(symbol).Error(), send().Error())
+}
@@ -29,3 +29,44 @@ func CalBlockGasPriceIndex(blockGasPrice []*big.Int, weight int) GasPriceIndex {
 		RecommendGp: blockGasPrice[idx],
 	}
 }
+
+// TxGasAndReward is the gas used and the effective gas price of a tx, which is paid to the validators
+// entirely as there is no base fee
+type TxGasAndReward struct {
+	GasUsed uint64
+	Reward  *big.Int
+}
+
+// CalBlockRewardPercentiles returns the rewards of the txs in a block at the given percentiles,
+// which are weighted by the gas used of the txs as eth_feeHistory of go-ethereum does.
+// The percentiles must be sorted in ascending order
+func CalBlockRewardPercentiles(txs []TxGasAndReward, percentiles []float64) []*big.Int {
+	rewards := make([]*big.Int, len(percentiles))
+	if len(txs) == 0 {
+		for i := range rewards {
+			rewards[i] = new(big.Int)
+		}
+		return rewards
+	}
+
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].Reward.Cmp(txs[j].Reward) < 0
+	})
+
+	var blockGasUsed uint64
+	for _, tx := range txs {
+		blockGasUsed += tx.GasUsed
+	}
+
+	txIndex := 0
+	sumGasUsed := txs[0].GasUsed
+	for i, p := range percentiles {
+		thresholdGasUsed := uint64(float64(blockGasUsed) * p / 100)
+		for sumGasUsed < thresholdGasUsed && txIndex < len(txs)-1 {
+			txIndex++
+			sumGasUsed += txs[txIndex].GasUsed
+		}
+		rewards[i] = txs[txIndex].Reward
+	}
+	return rewards
+}
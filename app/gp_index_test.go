@@ -0,0 +1,37 @@
+package app
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalBlockGasPriceIndex(t *testing.T) {
+	require.Equal(t, GasPriceIndex{}, CalBlockGasPriceIndex(nil, 80))
+
+	gps := []*big.Int{big.NewInt(5), big.NewInt(1), big.NewInt(3), big.NewInt(2), big.NewInt(4)}
+	require.Equal(t, big.NewInt(4), CalBlockGasPriceIndex(gps, 80).RecommendGp)
+	require.Equal(t, big.NewInt(1), CalBlockGasPriceIndex(gps, 0).RecommendGp)
+}
+
+func TestCalBlockRewardPercentiles(t *testing.T) {
+	percentiles := []float64{0, 25, 50, 75, 100}
+
+	rewards := CalBlockRewardPercentiles(nil, percentiles)
+	require.Len(t, rewards, len(percentiles))
+	for _, reward := range rewards {
+		require.Equal(t, 0, reward.Sign())
+	}
+
+	txs := []TxGasAndReward{
+		{GasUsed: 21000, Reward: big.NewInt(30)},
+		{GasUsed: 63000, Reward: big.NewInt(10)},
+		{GasUsed: 21000, Reward: big.NewInt(20)},
+	}
+	// the rewards are weighted by the gas used: 10 takes 60% of the gas used, 20 and 30 take 20% each
+	require.Equal(t,
+		[]*big.Int{big.NewInt(10), big.NewInt(10), big.NewInt(10), big.NewInt(20), big.NewInt(30)},
+		CalBlockRewardPercentiles(txs, percentiles),
+	)
+}
@@ -53,6 +53,10 @@ func (handler Handler) GasRefund(ctx sdk.Context, tx sdk.Tx) (refundGasFee sdk.C
 	}
 
 	feePayer := feeTx.FeePayer(ctx)
+	if granter := ctx.FeeGranter(); !granter.Empty() {
+		// the gas fee was paid by the fee granter of fee payer
+		feePayer = granter
+	}
 
 	feePayerAcc, getAccountGasUsed := exported.GetAccountAndGas(&ctx, handler.ak, feePayer)
 	if feePayerAcc == nil {
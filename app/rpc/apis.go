@@ -24,6 +24,7 @@ import (
 	"github.com/okex/exchain/app/rpc/namespaces/eth/filters"
 	"github.com/okex/exchain/app/rpc/namespaces/net"
 	"github.com/okex/exchain/app/rpc/namespaces/personal"
+	"github.com/okex/exchain/app/rpc/namespaces/trace"
 	"github.com/okex/exchain/app/rpc/namespaces/web3"
 	rpctypes "github.com/okex/exchain/app/rpc/types"
 )
@@ -36,6 +37,7 @@ const (
 	NetNamespace      = "net"
 	TxpoolNamespace   = "txpool"
 	DebugNamespace    = "debug"
+	TraceNamespace    = "trace"
 
 	apiVersion = "1.0"
 )
@@ -105,11 +107,28 @@ func GetAPIs(clientCtx context.CLIContext, log log.Logger, keys ...ethsecp256k1.
 		apis = append(apis, rpc.API{
 			Namespace: DebugNamespace,
 			Version:   apiVersion,
-			Service:   debug.NewAPI(clientCtx, log, ethBackend),
+			Service:   debug.NewAPI(clientCtx, log, ethBackend, ethAPI),
 			Public:    true,
 		})
 	}
 
+	if viper.GetBool(FlagTraceAPI) {
+		apis = append(apis,
+			rpc.API{
+				Namespace: TraceNamespace,
+				Version:   apiVersion,
+				Service:   trace.NewAPI(clientCtx, log, ethBackend),
+				Public:    true,
+			},
+			rpc.API{
+				Namespace: EthNamespace,
+				Version:   apiVersion,
+				Service:   trace.NewStateDiffAPI(clientCtx),
+				Public:    true,
+			},
+		)
+	}
+
 	if viper.GetBool(FlagEnableMonitor) {
 		for _, api := range apis {
 			makeMonitorMetrics(api.Namespace, api.Service)
@@ -28,6 +28,7 @@ const (
 	flagWebsocket             = "wsport"
 	FlagPersonalAPI           = "personal-api"
 	FlagDebugAPI              = "debug-api"
+	FlagTraceAPI              = "trace-api"
 	FlagRateLimitAPI          = "rpc.rate-limit-api"
 	FlagRateLimitCount        = "rpc.rate-limit-count"
 	FlagRateLimitBurst        = "rpc.rate-limit-burst"
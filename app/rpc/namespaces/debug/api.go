@@ -10,23 +10,34 @@ import (
 	clientcontext "github.com/okex/exchain/libs/cosmos-sdk/client/context"
 
 	"github.com/okex/exchain/app/rpc/backend"
+	"github.com/okex/exchain/app/rpc/namespaces/eth"
+	rpctypes "github.com/okex/exchain/app/rpc/types"
 	"github.com/okex/exchain/libs/tendermint/libs/log"
 
 	evmtypes "github.com/okex/exchain/x/evm/types"
 )
 
+// txTraceResult is the trace result of a tx in the traced block
+type txTraceResult struct {
+	TxHash common.Hash `json:"txHash"`
+	Result interface{} `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
 // PublicTxPoolAPI offers and API for the transaction pool. It only operates on data that is non confidential.
 type PublicDebugAPI struct {
 	clientCtx clientcontext.CLIContext
 	logger    log.Logger
 	backend   backend.Backend
+	ethAPI    *eth.PublicEthereumAPI
 }
 
 // NewPublicTxPoolAPI creates a new tx pool service that gives information about the transaction pool.
-func NewAPI(clientCtx clientcontext.CLIContext, log log.Logger, backend backend.Backend) *PublicDebugAPI {
+func NewAPI(clientCtx clientcontext.CLIContext, log log.Logger, backend backend.Backend, ethAPI *eth.PublicEthereumAPI) *PublicDebugAPI {
 	api := &PublicDebugAPI{
 		clientCtx: clientCtx,
 		backend:   backend,
+		ethAPI:    ethAPI,
 		logger:    log.With("module", "json-rpc", "namespace", "debug"),
 	}
 	return api
@@ -72,3 +83,101 @@ func (api *PublicDebugAPI) TraceTransaction(txHash common.Hash, config evmtypes.
 
 	return decodedResult, nil
 }
+
+// TraceCall lets you trace a given eth_call. It collects the structured logs created during the execution
+// of EVM if the given transaction was added on top of the provided block and returns them as a JSON object.
+func (api *PublicDebugAPI) TraceCall(args rpctypes.CallArgs, blockNrOrHash rpctypes.BlockNumberOrHash, config evmtypes.TraceCallConfig) (interface{}, error) {
+	err := evmtypes.TestTracerConfig(&config.TraceConfig)
+	if err != nil {
+		return nil, fmt.Errorf("tracer err : %s", err.Error())
+	}
+	blockNr, err := api.backend.ConvertToBlockNumber(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	configBytes, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	simRes, err := eth.DoTraceCall(api.ethAPI, args, blockNr, configBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var decodedResult interface{}
+	if err := json.Unmarshal(simRes.Result.Data, &decodedResult); err != nil {
+		// the tracer failed and returned its error instead of the trace log
+		return nil, fmt.Errorf("tracer err : %s", string(simRes.Result.Data))
+	}
+	return decodedResult, nil
+}
+
+// TraceBlockByNumber returns the structured logs created during the execution of
+// EVM for all the transactions in the block of the given number.
+func (api *PublicDebugAPI) TraceBlockByNumber(blockNum rpctypes.BlockNumber, config evmtypes.TraceConfig) ([]*txTraceResult, error) {
+	height := blockNum.Int64()
+	if blockNum == rpctypes.LatestBlockNumber || blockNum == rpctypes.PendingBlockNumber {
+		latest, err := api.backend.LatestBlockNumber()
+		if err != nil {
+			return nil, err
+		}
+		height = latest
+	}
+	return api.traceBlock(height, config)
+}
+
+// TraceBlockByHash returns the structured logs created during the execution of
+// EVM for all the transactions in the block of the given hash.
+func (api *PublicDebugAPI) TraceBlockByHash(hash common.Hash, config evmtypes.TraceConfig) ([]*txTraceResult, error) {
+	blockNum, err := api.backend.ConvertToBlockNumber(rpctypes.BlockNumberOrHash{BlockHash: &hash})
+	if err != nil {
+		return nil, err
+	}
+	return api.traceBlock(blockNum.Int64(), config)
+}
+
+func (api *PublicDebugAPI) traceBlock(height int64, config evmtypes.TraceConfig) ([]*txTraceResult, error) {
+	err := evmtypes.TestTracerConfig(&config)
+	if err != nil {
+		return nil, fmt.Errorf("tracer err : %s", err.Error())
+	}
+	configBytes, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	queryParam := sdk.QueryTraceBlock{
+		Height:      height,
+		ConfigBytes: configBytes,
+	}
+	queryBytes, err := json.Marshal(&queryParam)
+	if err != nil {
+		return nil, err
+	}
+	resTrace, _, err := api.clientCtx.QueryWithData("app/traceBlock", queryBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []sdk.TraceTxResult
+	if err := api.clientCtx.Codec.UnmarshalBinaryBare(resTrace, &res); err != nil {
+		return nil, err
+	}
+	results := make([]*txTraceResult, len(res))
+	for i, txRes := range res {
+		result := &txTraceResult{
+			TxHash: txRes.TxHash,
+			Error:  txRes.Error,
+		}
+		if result.Error == "" {
+			var decodedResult interface{}
+			if err := json.Unmarshal(txRes.Data, &decodedResult); err != nil {
+				// the tracer failed and returned its error instead of the trace log
+				result.Error = string(txRes.Data)
+			} else {
+				result.Result = decodedResult
+			}
+		}
+		results[i] = result
+	}
+	return results, nil
+}
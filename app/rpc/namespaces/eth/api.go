@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -57,6 +58,9 @@ const (
 	CacheOfEthCallLru = 40960
 
 	FlagEnableMultiCall = "rpc.enable-multi-call"
+
+	// maxFeeHistory is the maximum number of blocks that can be queried by eth_feeHistory
+	maxFeeHistory = 1024
 )
 
 // PublicEthereumAPI is the eth_ prefixed set of APIs in the Web3 JSON-RPC spec.
@@ -233,6 +237,10 @@ func (api *PublicEthereumAPI) GasPrice() *hexutil.Big {
 	monitor := monitor.GetMonitor("eth_gasPrice", api.logger, api.Metrics).OnBegin()
 	defer monitor.OnEnd()
 
+	return api.suggestGasPrice()
+}
+
+func (api *PublicEthereumAPI) suggestGasPrice() *hexutil.Big {
 	if app.GlobalGpIndex.RecommendGp != nil {
 		return (*hexutil.Big)(app.GlobalGpIndex.RecommendGp)
 	}
@@ -279,11 +287,7 @@ func (api *PublicEthereumAPI) GetBalance(address common.Address, blockNrOrHash r
 	defer monitor.OnEnd("address", address, "block number", blockNrOrHash)
 	acc, err := api.wrappedBackend.MustGetAccount(address.Bytes())
 	if err == nil {
-		balance := acc.GetCoins().AmountOf(sdk.DefaultBondDenom).BigInt()
-		if balance == nil {
-			return (*hexutil.Big)(sdk.ZeroInt().BigInt()), nil
-		}
-		return (*hexutil.Big)(balance), nil
+		return (*hexutil.Big)(spendableBalance(acc, time.Now())), nil
 	}
 
 	blockNum, err := api.backend.ConvertToBlockNumber(blockNrOrHash)
@@ -311,7 +315,17 @@ func (api *PublicEthereumAPI) GetBalance(address common.Address, blockNrOrHash r
 		return nil, err
 	}
 
-	val := account.Balance(sdk.DefaultBondDenom).BigInt()
+	blockTime := time.Now()
+	if account.VestingSchedule != nil && blockNum != rpctypes.PendingBlockNumber && blockNum != rpctypes.LatestBlockNumber {
+		height := blockNum.Int64()
+		resBlock, err := api.clientCtx.Client.Block(&height)
+		if err != nil {
+			return nil, err
+		}
+		blockTime = resBlock.Block.Time
+	}
+
+	val := spendableBalance(&account, blockTime)
 	api.watcherBackend.CommitAccountToRpcDb(account)
 	if blockNum != rpctypes.PendingBlockNumber {
 		return (*hexutil.Big)(val), nil
@@ -339,6 +353,15 @@ func (api *PublicEthereumAPI) GetBalance(address common.Address, blockNrOrHash r
 	return (*hexutil.Big)(val), nil
 }
 
+// spendableBalance returns the balance of the account excluding the coins locked by its vesting schedule at the time
+func spendableBalance(acc *ethermint.EthAccount, blockTime time.Time) *big.Int {
+	balance := acc.SpendableCoins(blockTime).AmountOf(sdk.DefaultBondDenom).BigInt()
+	if balance == nil {
+		return sdk.ZeroInt().BigInt()
+	}
+	return balance
+}
+
 // GetBalanceBatch returns the provided account's balance up to the provided block number.
 func (api *PublicEthereumAPI) GetBalanceBatch(addresses []common.Address, blockNrOrHash rpctypes.BlockNumberOrHash) (interface{}, error) {
 	if !viper.GetBool(FlagEnableMultiCall) {
@@ -873,6 +896,22 @@ func (api *PublicEthereumAPI) MultiCall(args []rpctypes.CallArgs, blockNr rpctyp
 func (api *PublicEthereumAPI) doCall(
 	args rpctypes.CallArgs, blockNum rpctypes.BlockNumber, globalGasCap *big.Int, isEstimate bool,
 ) (*sdk.SimulationResponse, error) {
+	return api.simulateCall(args, blockNum, globalGasCap, isEstimate, nil)
+}
+
+// DoTraceCall simulates the call as eth_call does, with the evm tracer enabled by the trace config.
+// The result data of the simulation response is the trace log.
+func DoTraceCall(api *PublicEthereumAPI, args rpctypes.CallArgs, blockNum rpctypes.BlockNumber, traceConfig []byte) (*sdk.SimulationResponse, error) {
+	if traceConfig == nil {
+		traceConfig = []byte{}
+	}
+	return api.simulateCall(args, blockNum, big.NewInt(ethermint.DefaultRPCGasLimit), false, traceConfig)
+}
+
+// simulateCall simulates the call through the app, the call is traced if traceConfig isn't nil
+func (api *PublicEthereumAPI) simulateCall(
+	args rpctypes.CallArgs, blockNum rpctypes.BlockNumber, globalGasCap *big.Int, isEstimate bool, traceConfig []byte,
+) (*sdk.SimulationResponse, error) {
 
 	clientCtx := api.clientCtx
 	// pass the given block height to the context if the height is not pending or latest
@@ -924,10 +963,12 @@ func (api *PublicEthereumAPI) doCall(
 	// Create new call message
 	msg := evmtypes.NewMsgEthereumTx(nonce, args.To, value, gas, gasPrice, data)
 
-	sim := api.evmFactory.BuildSimulator(api)
-	//only worked when fast-query has been enabled
-	if sim != nil {
-		return sim.DoCall(msg, addr.String())
+	//only worked when fast-query has been enabled, which doesn't support tracing
+	if traceConfig == nil {
+		sim := api.evmFactory.BuildSimulator(api)
+		if sim != nil {
+			return sim.DoCall(msg, addr.String())
+		}
 	}
 
 	//Generate tx to be used to simulate (signature isn't needed)
@@ -947,12 +988,29 @@ func (api *PublicEthereumAPI) doCall(
 		return nil, err
 	}
 
-	// Transaction simulation through query. only pass from when eth_estimateGas.
-	// eth_call's from maybe nil
-	simulatePath := fmt.Sprintf("app/simulate/%s", addr.String())
-	res, _, err := clientCtx.QueryWithData(simulatePath, txBytes)
-	if err != nil {
-		return nil, err
+	var res []byte
+	if traceConfig != nil {
+		queryParam := sdk.QueryTraceCall{
+			TxBytes:     txBytes,
+			From:        addr.String(),
+			ConfigBytes: traceConfig,
+		}
+		queryBytes, err := json.Marshal(&queryParam)
+		if err != nil {
+			return nil, err
+		}
+		res, _, err = clientCtx.QueryWithData("app/traceCall", queryBytes)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Transaction simulation through query. only pass from when eth_estimateGas.
+		// eth_call's from maybe nil
+		simulatePath := fmt.Sprintf("app/simulate/%s", addr.String())
+		res, _, err = clientCtx.QueryWithData(simulatePath, txBytes)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	var simResponse sdk.SimulationResponse
@@ -1315,6 +1373,7 @@ func (api *PublicEthereumAPI) GetTransactionReceipt(hash common.Hash) (*watcher.
 		TransactionIndex:  hexutil.Uint64(tx.Index),
 		From:              ethTx.GetFrom(),
 		To:                ethTx.To(),
+		Type:              hexutil.Uint64(ethTx.Data.Type),
 	}
 
 	return receipt, nil
@@ -1409,6 +1468,7 @@ func (api *PublicEthereumAPI) GetTransactionReceiptsByBlock(blockNrOrHash rpctyp
 			TransactionIndex: hexutil.Uint64(tx.Index),
 			From:             ethTx.GetFrom(),
 			To:               ethTx.To(),
+			Type:             hexutil.Uint64(ethTx.Data.Type),
 		}
 		receipts = append(receipts, receipt)
 	}
@@ -1666,9 +1726,96 @@ func (api *PublicEthereumAPI) saveZeroAccount(address common.Address) {
 	api.watcherBackend.CommitAccountToRpcDb(zeroAccount)
 }
 
-func (e *PublicEthereumAPI) FeeHistory(blockCount rpc.DecimalOrHex, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*rpctypes.FeeHistoryResult, error) {
-	e.logger.Debug("eth_feeHistory")
-	return nil, fmt.Errorf("unsupported rpc function: eth_FeeHistory")
+// FeeHistory returns the fee history of the blocks ending with lastBlock, which is built from the blocks and
+// receipts stored by the watcher. The base fee is always zero, so the rewards are the effective gas prices.
+func (api *PublicEthereumAPI) FeeHistory(blockCount rpc.DecimalOrHex, lastBlock rpctypes.BlockNumber, rewardPercentiles []float64) (*rpctypes.FeeHistoryResult, error) {
+	monitor := monitor.GetMonitor("eth_feeHistory", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd("count", blockCount, "last", lastBlock, "percentiles", rewardPercentiles)
+
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 {
+			return nil, fmt.Errorf("invalid reward percentile: %f", p)
+		}
+		if i > 0 && p < rewardPercentiles[i-1] {
+			return nil, fmt.Errorf("invalid reward percentile: #%d:%f > #%d:%f", i-1, rewardPercentiles[i-1], i, p)
+		}
+	}
+
+	latest, err := api.backend.LatestBlockNumber()
+	if err != nil {
+		return nil, err
+	}
+	last := lastBlock.Int64()
+	if lastBlock == rpctypes.LatestBlockNumber || lastBlock == rpctypes.PendingBlockNumber || last > latest {
+		last = latest
+	}
+
+	count := int64(blockCount)
+	if count > maxFeeHistory {
+		count = maxFeeHistory
+	}
+	if count > last {
+		count = last
+	}
+	oldest := last - count + 1
+
+	result := &rpctypes.FeeHistoryResult{
+		OldestBlock:  (*hexutil.Big)(big.NewInt(oldest)),
+		GasUsedRatio: make([]float64, 0, count),
+	}
+	if count <= 0 {
+		return result, nil
+	}
+	if len(rewardPercentiles) != 0 {
+		result.Reward = make([][]*hexutil.Big, 0, count)
+	}
+	for height := oldest; height <= last; height++ {
+		block, err := api.wrappedBackend.GetBlockByNumber(uint64(height), true)
+		if err != nil {
+			return nil, err
+		}
+
+		var gasUsedRatio float64
+		if block.GasLimit > 0 && block.GasUsed != nil {
+			gasUsed, _ := new(big.Float).SetInt(block.GasUsed.ToInt()).Float64()
+			gasUsedRatio = gasUsed / float64(block.GasLimit)
+		}
+		result.GasUsedRatio = append(result.GasUsedRatio, gasUsedRatio)
+
+		if len(rewardPercentiles) == 0 {
+			continue
+		}
+		txs, _ := block.Transactions.([]*watcher.Transaction)
+		txRewards := make([]app.TxGasAndReward, 0, len(txs))
+		for _, tx := range txs {
+			receipt, err := api.wrappedBackend.GetTransactionReceipt(tx.Hash)
+			if err != nil {
+				return nil, err
+			}
+			txRewards = append(txRewards, app.TxGasAndReward{GasUsed: uint64(receipt.GasUsed), Reward: tx.GasPrice.ToInt()})
+		}
+		rewards := app.CalBlockRewardPercentiles(txRewards, rewardPercentiles)
+		blockRewards := make([]*hexutil.Big, len(rewards))
+		for i, reward := range rewards {
+			blockRewards[i] = (*hexutil.Big)(reward)
+		}
+		result.Reward = append(result.Reward, blockRewards)
+	}
+
+	// the base fee of the next block is included
+	result.BaseFee = make([]*hexutil.Big, count+1)
+	for i := range result.BaseFee {
+		result.BaseFee[i] = (*hexutil.Big)(new(big.Int))
+	}
+	return result, nil
+}
+
+// MaxPriorityFeePerGas returns the suggested priority fee, which is the same as the gas price as there is no base fee
+func (api *PublicEthereumAPI) MaxPriorityFeePerGas() *hexutil.Big {
+	monitor := monitor.GetMonitor("eth_maxPriorityFeePerGas", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd()
+
+	return api.suggestGasPrice()
 }
 
 // FillTransaction fills the defaults (nonce, gas, gasPrice or 1559 fields)
@@ -1720,3 +1867,39 @@ func (api *PublicEthereumAPI) FillTransaction(args rpctypes.SendTxArgs) (*rpctyp
 		Tx:  rpcTx,
 	}, nil
 }
+
+// GetInternalTransactions returns the internal transactions made by the evm tx identified by hash,
+// they are recorded by the node when it runs with the inner tx enabled.
+func (api *PublicEthereumAPI) GetInternalTransactions(hash common.Hash) ([]*evmtypes.InnerTx, error) {
+	monitor := monitor.GetMonitor("eth_getInternalTransactions", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd("hash", hash)
+
+	if !evmtypes.GetEnableInnerTx() {
+		return nil, evmtypes.ErrInnerTxDisabled
+	}
+	return evmtypes.GetInnerTxs(hash)
+}
+
+// GetBlockInternalTransactions returns the internal transactions made by every evm tx of the block
+// identified by number or hash.
+func (api *PublicEthereumAPI) GetBlockInternalTransactions(blockNrOrHash rpctypes.BlockNumberOrHash) ([]*evmtypes.TxInnerTxs, error) {
+	monitor := monitor.GetMonitor("eth_getBlockInternalTransactions", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd("block number", blockNrOrHash)
+
+	if !evmtypes.GetEnableInnerTx() {
+		return nil, evmtypes.ErrInnerTxDisabled
+	}
+
+	blockNum, err := api.backend.ConvertToBlockNumber(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	height := blockNum.Int64()
+	if blockNum == rpctypes.LatestBlockNumber || blockNum == rpctypes.PendingBlockNumber {
+		height, err = api.backend.LatestBlockNumber()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return evmtypes.GetBlockInnerTxs(height)
+}
@@ -0,0 +1,245 @@
+package trace
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/okex/exchain/app/rpc/backend"
+	rpctypes "github.com/okex/exchain/app/rpc/types"
+	clientcontext "github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+)
+
+const parityTracer = "parityTracer"
+
+// ReplayResult is the result of a replayed tx in the format of OpenEthereum's trace_replayBlockTransactions
+type ReplayResult struct {
+	*evmtypes.ParityTraceResult
+	TransactionHash common.Hash `json:"transactionHash"`
+}
+
+// LocalizedTrace is a flat call trace with the location of its tx, in the format of OpenEthereum's trace_block
+type LocalizedTrace struct {
+	*evmtypes.ParityTrace
+	BlockHash           common.Hash `json:"blockHash"`
+	BlockNumber         uint64      `json:"blockNumber"`
+	TransactionHash     common.Hash `json:"transactionHash"`
+	TransactionPosition uint64      `json:"transactionPosition"`
+}
+
+// PublicStateDiffAPI offers eth_getStateDiff, which replays the committed tx and reports the accounts changed by it.
+type PublicStateDiffAPI struct {
+	clientCtx clientcontext.CLIContext
+}
+
+// NewStateDiffAPI creates a new state diff API instance.
+func NewStateDiffAPI(clientCtx clientcontext.CLIContext) *PublicStateDiffAPI {
+	return &PublicStateDiffAPI{clientCtx: clientCtx}
+}
+
+// GetStateDiff returns the balances, nonces, code and storage slots changed by the tx of the given hash,
+// in the stateDiff format of OpenEthereum's trace_replayTransaction.
+func (api *PublicStateDiffAPI) GetStateDiff(txHash common.Hash) (evmtypes.ParityStateDiff, error) {
+	result, err := ReplayTransaction(api.clientCtx, txHash, []string{evmtypes.ParityTraceTypeStateDiff})
+	if err != nil {
+		return nil, err
+	}
+	return result.StateDiff, nil
+}
+
+// PublicTraceAPI offers the trace_ prefixed APIs of OpenEthereum, which replay the committed txs.
+type PublicTraceAPI struct {
+	clientCtx clientcontext.CLIContext
+	logger    log.Logger
+	backend   backend.Backend
+}
+
+// NewAPI creates a new trace API instance.
+func NewAPI(clientCtx clientcontext.CLIContext, log log.Logger, backend backend.Backend) *PublicTraceAPI {
+	return &PublicTraceAPI{
+		clientCtx: clientCtx,
+		backend:   backend,
+		logger:    log.With("module", "json-rpc", "namespace", "trace"),
+	}
+}
+
+// ReplayTransaction replays the tx of the given hash and returns the requested trace types of it,
+// "trace" for the flat call traces and "stateDiff" for the changed accounts.
+func (api *PublicTraceAPI) ReplayTransaction(txHash common.Hash, traceTypes []string) (*ReplayResult, error) {
+	result, err := ReplayTransaction(api.clientCtx, txHash, traceTypes)
+	if err != nil {
+		return nil, err
+	}
+	return &ReplayResult{ParityTraceResult: result, TransactionHash: txHash}, nil
+}
+
+// ReplayBlockTransactions replays all the evm txs in the block of the given number
+// and returns the requested trace types of them.
+func (api *PublicTraceAPI) ReplayBlockTransactions(blockNum rpctypes.BlockNumber, traceTypes []string) ([]*ReplayResult, error) {
+	height, err := api.blockHeight(blockNum)
+	if err != nil {
+		return nil, err
+	}
+	return api.replayBlock(height, traceTypes)
+}
+
+// Block returns the flat call traces of all the evm txs in the block of the given number.
+func (api *PublicTraceAPI) Block(blockNum rpctypes.BlockNumber) ([]*LocalizedTrace, error) {
+	height, err := api.blockHeight(blockNum)
+	if err != nil {
+		return nil, err
+	}
+	results, err := api.replayBlock(height, []string{evmtypes.ParityTraceTypeTrace})
+	if err != nil {
+		return nil, err
+	}
+
+	var traces []*LocalizedTrace
+	for _, result := range results {
+		tx, err := api.backend.GetTransactionByHash(result.TransactionHash)
+		if err != nil {
+			return nil, err
+		}
+		traces = append(traces, localizeTraces(tx.BlockHash, tx.BlockNumber, tx.TransactionIndex, result)...)
+	}
+	return traces, nil
+}
+
+// Transaction returns the flat call traces of the tx of the given hash.
+func (api *PublicTraceAPI) Transaction(txHash common.Hash) ([]*LocalizedTrace, error) {
+	tx, err := api.backend.GetTransactionByHash(txHash)
+	if err != nil {
+		return nil, err
+	}
+	result, err := api.ReplayTransaction(txHash, []string{evmtypes.ParityTraceTypeTrace})
+	if err != nil {
+		return nil, err
+	}
+	return localizeTraces(tx.BlockHash, tx.BlockNumber, tx.TransactionIndex, result), nil
+}
+
+func (api *PublicTraceAPI) blockHeight(blockNum rpctypes.BlockNumber) (int64, error) {
+	if blockNum == rpctypes.LatestBlockNumber || blockNum == rpctypes.PendingBlockNumber {
+		return api.backend.LatestBlockNumber()
+	}
+	return blockNum.Int64(), nil
+}
+
+func (api *PublicTraceAPI) replayBlock(height int64, traceTypes []string) ([]*ReplayResult, error) {
+	configBytes, err := parityTraceConfig(traceTypes)
+	if err != nil {
+		return nil, err
+	}
+	queryParam := sdk.QueryTraceBlock{
+		Height:      height,
+		ConfigBytes: configBytes,
+	}
+	queryBytes, err := json.Marshal(&queryParam)
+	if err != nil {
+		return nil, err
+	}
+	resTrace, _, err := api.clientCtx.QueryWithData("app/traceBlock", queryBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []sdk.TraceTxResult
+	if err := api.clientCtx.Codec.UnmarshalBinaryBare(resTrace, &res); err != nil {
+		return nil, err
+	}
+	results := make([]*ReplayResult, len(res))
+	for i, txRes := range res {
+		if txRes.Error != "" {
+			return nil, fmt.Errorf("failed to replay tx %s: %s", txRes.TxHash.Hex(), txRes.Error)
+		}
+		result, err := decodeParityResult(txRes.Data)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = &ReplayResult{ParityTraceResult: result, TransactionHash: txRes.TxHash}
+	}
+	return results, nil
+}
+
+// ReplayTransaction replays the committed tx of the given hash with the parity tracer
+func ReplayTransaction(clientCtx clientcontext.CLIContext, txHash common.Hash, traceTypes []string) (*evmtypes.ParityTraceResult, error) {
+	configBytes, err := parityTraceConfig(traceTypes)
+	if err != nil {
+		return nil, err
+	}
+	queryParam := sdk.QueryTraceTx{
+		TxHash:      txHash,
+		ConfigBytes: configBytes,
+	}
+	queryBytes, err := json.Marshal(&queryParam)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = clientCtx.Client.Tx(txHash.Bytes(), false); err != nil {
+		return nil, err
+	}
+	resTrace, _, err := clientCtx.QueryWithData("app/trace", queryBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var res sdk.Result
+	if err := clientCtx.Codec.UnmarshalBinaryBare(resTrace, &res); err != nil {
+		return nil, err
+	}
+	return decodeParityResult(res.Data)
+}
+
+func parityTraceConfig(traceTypes []string) ([]byte, error) {
+	if len(traceTypes) == 0 {
+		return nil, errors.New("at least one trace type is required")
+	}
+	tracerConfig, err := json.Marshal(map[string][]string{"traceTypes": traceTypes})
+	if err != nil {
+		return nil, err
+	}
+	config := evmtypes.TraceConfig{
+		Tracer:       parityTracer,
+		TracerConfig: tracerConfig,
+	}
+	if err := evmtypes.TestTracerConfig(&config); err != nil {
+		return nil, fmt.Errorf("tracer err : %s", err.Error())
+	}
+	return json.Marshal(config)
+}
+
+func decodeParityResult(data []byte) (*evmtypes.ParityTraceResult, error) {
+	var result evmtypes.ParityTraceResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		// the tracer failed and returned its error instead of the trace result
+		return nil, fmt.Errorf("tracer err : %s", string(data))
+	}
+	return &result, nil
+}
+
+func localizeTraces(blockHash *common.Hash, blockNumber *hexutil.Big, txIndex *hexutil.Uint64, result *ReplayResult) []*LocalizedTrace {
+	traces := make([]*LocalizedTrace, len(result.Trace))
+	for i, trace := range result.Trace {
+		localized := &LocalizedTrace{
+			ParityTrace:     trace,
+			TransactionHash: result.TransactionHash,
+		}
+		if blockHash != nil {
+			localized.BlockHash = *blockHash
+		}
+		if blockNumber != nil {
+			localized.BlockNumber = blockNumber.ToInt().Uint64()
+		}
+		if txIndex != nil {
+			localized.TransactionPosition = uint64(*txIndex)
+		}
+		traces[i] = localized
+	}
+	return traces
+}
@@ -55,6 +55,7 @@ func ToTransaction(tx *evmtypes.MsgEthereumTx, from *common.Address) *watcher.Tr
 		R:        (*hexutil.Big)(tx.Data.R),
 		S:        (*hexutil.Big)(tx.Data.S),
 	}
+	rpcTx.SetTypedTxData(&tx.Data)
 	return rpcTx
 }
 
@@ -34,7 +34,8 @@ func init() {
 // auth.BaseAccount type. It is compatible with the auth.AccountKeeper.
 type EthAccount struct {
 	*authtypes.BaseAccount `json:"base_account" yaml:"base_account"`
-	CodeHash               []byte `json:"code_hash" yaml:"code_hash"`
+	CodeHash               []byte           `json:"code_hash" yaml:"code_hash"`
+	VestingSchedule        *VestingSchedule `json:"vesting_schedule" yaml:"vesting_schedule"`
 }
 
 func (acc *EthAccount) UnmarshalFromAmino(cdc *amino.Codec, data []byte) error {
@@ -80,6 +81,13 @@ func (acc *EthAccount) UnmarshalFromAmino(cdc *amino.Codec, data []byte) error {
 		case 2:
 			acc.CodeHash = make([]byte, len(subData))
 			copy(acc.CodeHash, subData)
+		case 3:
+			vs := new(VestingSchedule)
+			err = cdc.UnmarshalBinaryBare(subData, vs)
+			if err != nil {
+				return err
+			}
+			acc.VestingSchedule = vs
 		default:
 			return fmt.Errorf("unexpect feild num %d", pos)
 		}
@@ -88,9 +96,15 @@ func (acc *EthAccount) UnmarshalFromAmino(cdc *amino.Codec, data []byte) error {
 }
 
 func (acc EthAccount) Copy() sdk.Account {
+	var vs *VestingSchedule
+	if acc.VestingSchedule != nil {
+		schedule := *acc.VestingSchedule
+		vs = &schedule
+	}
 	return &EthAccount{
 		authtypes.NewBaseAccount(acc.Address, acc.Coins, acc.PubKey, acc.AccountNumber, acc.Sequence),
 		acc.CodeHash,
+		vs,
 	}
 }
 
@@ -103,6 +117,10 @@ func (acc EthAccount) AminoSize(cdc *amino.Codec) int {
 	if len(acc.CodeHash) != 0 {
 		size += 1 + amino.ByteSliceSize(acc.CodeHash)
 	}
+	if acc.VestingSchedule != nil {
+		vsSize := len(cdc.MustMarshalBinaryBare(acc.VestingSchedule))
+		size += 1 + amino.UvarintSize(uint64(vsSize)) + vsSize
+	}
 	return size
 }
 
@@ -111,7 +129,7 @@ var ethAccountBufferPool = amino.NewBufferPool()
 func (acc EthAccount) MarshalToAmino(cdc *amino.Codec) ([]byte, error) {
 	var buf = ethAccountBufferPool.Get()
 	defer ethAccountBufferPool.Put(buf)
-	for pos := 1; pos < 3; pos++ {
+	for pos := 1; pos < 4; pos++ {
 		lBeforeKey := buf.Len()
 		var noWrite bool
 		posByte, err := amino.EncodeProtoPosAndTypeMustOneByte(pos, amino.Typ3_ByteLength)
@@ -155,6 +173,23 @@ func (acc EthAccount) MarshalToAmino(cdc *amino.Codec) ([]byte, error) {
 			if err != nil {
 				return nil, err
 			}
+		case 3:
+			if acc.VestingSchedule == nil {
+				noWrite = true
+				break
+			}
+			data, err := cdc.MarshalBinaryBare(acc.VestingSchedule)
+			if err != nil {
+				return nil, err
+			}
+			err = amino.EncodeUvarintToBuffer(buf, uint64(len(data)))
+			if err != nil {
+				return nil, err
+			}
+			_, err = buf.Write(data)
+			if err != nil {
+				return nil, err
+			}
 		default:
 			panic("unreachable")
 		}
@@ -216,6 +251,8 @@ type ethermintAccountPretty struct {
 	AccountNumber uint64         `json:"account_number" yaml:"account_number"`
 	Sequence      uint64         `json:"sequence" yaml:"sequence"`
 	CodeHash      string         `json:"code_hash" yaml:"code_hash"`
+
+	VestingSchedule *VestingSchedule `json:"vesting_schedule,omitempty" yaml:"vesting_schedule,omitempty"`
 }
 
 // MarshalYAML returns the YAML representation of an account.
@@ -227,6 +264,8 @@ func (acc EthAccount) MarshalYAML() (interface{}, error) {
 		AccountNumber: acc.AccountNumber,
 		Sequence:      acc.Sequence,
 		CodeHash:      ethcmn.Bytes2Hex(acc.CodeHash),
+
+		VestingSchedule: acc.VestingSchedule,
 	}
 
 	var err error
@@ -261,6 +300,8 @@ func (acc EthAccount) MarshalJSON() ([]byte, error) {
 		AccountNumber: acc.AccountNumber,
 		Sequence:      acc.Sequence,
 		CodeHash:      ethcmn.Bytes2Hex(acc.CodeHash),
+
+		VestingSchedule: acc.VestingSchedule,
 	}
 
 	var err error
@@ -324,6 +365,7 @@ func (acc *EthAccount) UnmarshalJSON(bz []byte) error {
 		Sequence:      alias.Sequence,
 	}
 	acc.CodeHash = ethcmn.Hex2Bytes(alias.CodeHash)
+	acc.VestingSchedule = alias.VestingSchedule
 
 	if alias.PubKey != "" {
 		acc.BaseAccount.PubKey, err = sdk.GetPubKeyFromBech32(sdk.Bech32PubKeyTypeAccPub, alias.PubKey)
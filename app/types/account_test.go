@@ -19,6 +19,7 @@ import (
 	"github.com/okex/exchain/app/crypto/ethsecp256k1"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
+	vestingtypes "github.com/okex/exchain/libs/cosmos-sdk/x/auth/vesting/types"
 
 	"github.com/okex/exchain/libs/cosmos-sdk/codec"
 	"github.com/okex/exchain/libs/tendermint/crypto/ed25519"
@@ -213,6 +214,7 @@ func TestEthAccountAmino(t *testing.T) {
 				1,
 			),
 			ethcrypto.Keccak256(nil),
+			nil,
 		},
 		{
 			auth.NewBaseAccount(
@@ -223,6 +225,7 @@ func TestEthAccountAmino(t *testing.T) {
 				0,
 			),
 			ethcrypto.Keccak256(nil),
+			nil,
 		},
 		{
 			auth.NewBaseAccount(
@@ -233,10 +236,29 @@ func TestEthAccountAmino(t *testing.T) {
 				0,
 			),
 			ethcrypto.Keccak256(nil),
+			nil,
 		},
 		{
 			BaseAccount: &auth.BaseAccount{},
 		},
+		{
+			auth.NewBaseAccount(
+				addr,
+				sdk.NewCoins(NewPhotonCoin(sdk.NewInt(10))),
+				pubKey,
+				1,
+				1,
+			),
+			ethcrypto.Keccak256(nil),
+			NewContinuousVestingSchedule(sdk.NewCoins(NewPhotonCoin(sdk.NewInt(10))), 100, 200),
+		},
+		{
+			BaseAccount: auth.NewBaseAccount(addr, nil, nil, 0, 0),
+			VestingSchedule: NewPeriodicVestingSchedule(100, vestingtypes.Periods{
+				{Length: 10, Amount: sdk.NewCoins(NewPhotonCoin(sdk.NewInt(1)))},
+				{Length: 20, Amount: sdk.NewCoins(NewPhotonCoin(sdk.NewInt(2)))},
+			}),
+		},
 	}
 
 	for _, testAccount := range accounts {
@@ -0,0 +1,217 @@
+package types
+
+import (
+	"errors"
+	"time"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	authtypes "github.com/okex/exchain/libs/cosmos-sdk/x/auth/types"
+	vestexported "github.com/okex/exchain/libs/cosmos-sdk/x/auth/vesting/exported"
+	vestingtypes "github.com/okex/exchain/libs/cosmos-sdk/x/auth/vesting/types"
+)
+
+var _ vestexported.VestingAccount = (*EthAccount)(nil)
+
+// VestingSchedule locks the original vesting coins of an EthAccount until they are vested. The coins are vested
+// by the periods from the start time if there are any periods, all at the end time if the schedule is delayed,
+// or else linearly from the start time to the end time.
+//
+// The locked coins can still be deposited to staking, which is tracked by the delegated free and vesting coins
+// the same way as the vesting accounts of the cosmos-sdk.
+type VestingSchedule struct {
+	OriginalVesting  sdk.Coins            `json:"original_vesting" yaml:"original_vesting"`
+	DelegatedFree    sdk.Coins            `json:"delegated_free" yaml:"delegated_free"`
+	DelegatedVesting sdk.Coins            `json:"delegated_vesting" yaml:"delegated_vesting"`
+	StartTime        int64                `json:"start_time" yaml:"start_time"`
+	EndTime          int64                `json:"end_time" yaml:"end_time"`
+	Delayed          bool                 `json:"delayed" yaml:"delayed"`
+	Periods          vestingtypes.Periods `json:"periods" yaml:"periods"`
+}
+
+// NewContinuousVestingSchedule creates a new VestingSchedule vesting linearly from the start time to the end time
+func NewContinuousVestingSchedule(originalVesting sdk.Coins, startTime, endTime int64) *VestingSchedule {
+	return &VestingSchedule{
+		OriginalVesting: originalVesting,
+		StartTime:       startTime,
+		EndTime:         endTime,
+	}
+}
+
+// NewDelayedVestingSchedule creates a new VestingSchedule vesting all the coins at the end time
+func NewDelayedVestingSchedule(originalVesting sdk.Coins, endTime int64) *VestingSchedule {
+	return &VestingSchedule{
+		OriginalVesting: originalVesting,
+		EndTime:         endTime,
+		Delayed:         true,
+	}
+}
+
+// NewPeriodicVestingSchedule creates a new VestingSchedule vesting the coins of each period at its end,
+// the original vesting coins are the sum of the coins of all the periods
+func NewPeriodicVestingSchedule(startTime int64, periods vestingtypes.Periods) *VestingSchedule {
+	endTime := startTime
+	var originalVesting sdk.Coins
+	for _, p := range periods {
+		endTime += p.Length
+		originalVesting = originalVesting.Add(p.Amount...)
+	}
+	return &VestingSchedule{
+		OriginalVesting: originalVesting,
+		StartTime:       startTime,
+		EndTime:         endTime,
+		Periods:         periods,
+	}
+}
+
+// Validate checks for errors on the vesting schedule
+func (vs VestingSchedule) Validate() error {
+	if vs.OriginalVesting.Empty() || !vs.OriginalVesting.IsValid() || !vs.OriginalVesting.IsAllPositive() {
+		return errors.New("original vesting coins must be valid and positive")
+	}
+	if vs.StartTime < 0 || vs.EndTime <= 0 {
+		return errors.New("vesting start and end time must be positive")
+	}
+	if vs.Delayed && len(vs.Periods) != 0 {
+		return errors.New("delayed vesting schedule cannot have periods")
+	}
+	if !vs.Delayed && vs.StartTime >= vs.EndTime {
+		return errors.New("vesting start time must be before the end time")
+	}
+
+	if len(vs.Periods) != 0 {
+		endTime := vs.StartTime
+		var total sdk.Coins
+		for _, p := range vs.Periods {
+			if p.Length <= 0 {
+				return errors.New("vesting period length must be positive")
+			}
+			if !p.Amount.IsValid() || !p.Amount.IsAllPositive() {
+				return errors.New("vesting period amount must be valid and positive")
+			}
+			endTime += p.Length
+			total = total.Add(p.Amount...)
+		}
+		if endTime != vs.EndTime {
+			return errors.New("vesting end time does not match the length of the periods")
+		}
+		if !total.IsEqual(vs.OriginalVesting) {
+			return errors.New("original vesting coins do not match the sum of the periods")
+		}
+	}
+	return nil
+}
+
+// vestingAccount returns the cosmos-sdk vesting account of the schedule over the base account,
+// whose vesting math is shared by the EthAccount
+func (vs VestingSchedule) vestingAccount(base *authtypes.BaseAccount) vestexported.VestingAccount {
+	bva := &vestingtypes.BaseVestingAccount{
+		BaseAccount:      base,
+		OriginalVesting:  vs.OriginalVesting,
+		DelegatedFree:    vs.DelegatedFree,
+		DelegatedVesting: vs.DelegatedVesting,
+		EndTime:          vs.EndTime,
+	}
+	switch {
+	case len(vs.Periods) != 0:
+		return vestingtypes.NewPeriodicVestingAccountRaw(bva, vs.StartTime, vs.Periods)
+	case vs.Delayed:
+		return vestingtypes.NewDelayedVestingAccountRaw(bva)
+	default:
+		return vestingtypes.NewContinuousVestingAccountRaw(bva, vs.StartTime)
+	}
+}
+
+// SpendableCoins returns the coins of the account which are not locked by its vesting schedule
+func (acc EthAccount) SpendableCoins(blockTime time.Time) sdk.Coins {
+	if acc.VestingSchedule == nil {
+		return acc.BaseAccount.SpendableCoins(blockTime)
+	}
+	return acc.VestingSchedule.vestingAccount(acc.BaseAccount).SpendableCoins(blockTime)
+}
+
+// LockedCoins returns the coins of the account which are locked by its vesting schedule
+func (acc EthAccount) LockedCoins(blockTime time.Time) sdk.Coins {
+	if acc.VestingSchedule == nil {
+		return sdk.NewCoins()
+	}
+	locked, _ := acc.GetCoins().SafeSub(acc.SpendableCoins(blockTime))
+	return locked
+}
+
+// TrackDelegation implements the VestingAccount interface, it tracks the delegated coins of the vesting schedule
+func (acc *EthAccount) TrackDelegation(blockTime time.Time, amount sdk.Coins) {
+	if acc.VestingSchedule == nil {
+		return
+	}
+	vacc := acc.VestingSchedule.vestingAccount(acc.BaseAccount)
+	vacc.TrackDelegation(blockTime, amount)
+	acc.VestingSchedule.DelegatedFree = vacc.GetDelegatedFree()
+	acc.VestingSchedule.DelegatedVesting = vacc.GetDelegatedVesting()
+}
+
+// TrackUndelegation implements the VestingAccount interface, it tracks the undelegated coins of the vesting schedule
+func (acc *EthAccount) TrackUndelegation(amount sdk.Coins) {
+	if acc.VestingSchedule == nil {
+		return
+	}
+	vacc := acc.VestingSchedule.vestingAccount(acc.BaseAccount)
+	vacc.TrackUndelegation(amount)
+	acc.VestingSchedule.DelegatedFree = vacc.GetDelegatedFree()
+	acc.VestingSchedule.DelegatedVesting = vacc.GetDelegatedVesting()
+}
+
+// GetVestedCoins returns the vested coins of the vesting schedule, nil if there is no schedule
+func (acc EthAccount) GetVestedCoins(blockTime time.Time) sdk.Coins {
+	if acc.VestingSchedule == nil {
+		return nil
+	}
+	return acc.VestingSchedule.vestingAccount(acc.BaseAccount).GetVestedCoins(blockTime)
+}
+
+// GetVestingCoins returns the coins still vesting by the vesting schedule, nil if there is no schedule
+func (acc EthAccount) GetVestingCoins(blockTime time.Time) sdk.Coins {
+	if acc.VestingSchedule == nil {
+		return nil
+	}
+	return acc.VestingSchedule.vestingAccount(acc.BaseAccount).GetVestingCoins(blockTime)
+}
+
+// GetStartTime returns the start time of the vesting schedule
+func (acc EthAccount) GetStartTime() int64 {
+	if acc.VestingSchedule == nil {
+		return 0
+	}
+	return acc.VestingSchedule.StartTime
+}
+
+// GetEndTime returns the end time of the vesting schedule
+func (acc EthAccount) GetEndTime() int64 {
+	if acc.VestingSchedule == nil {
+		return 0
+	}
+	return acc.VestingSchedule.EndTime
+}
+
+// GetOriginalVesting returns the original vesting coins of the vesting schedule
+func (acc EthAccount) GetOriginalVesting() sdk.Coins {
+	if acc.VestingSchedule == nil {
+		return nil
+	}
+	return acc.VestingSchedule.OriginalVesting
+}
+
+// GetDelegatedFree returns the delegated coins which are vested when delegated
+func (acc EthAccount) GetDelegatedFree() sdk.Coins {
+	if acc.VestingSchedule == nil {
+		return nil
+	}
+	return acc.VestingSchedule.DelegatedFree
+}
+
+// GetDelegatedVesting returns the delegated coins which are still vesting when delegated
+func (acc EthAccount) GetDelegatedVesting() sdk.Coins {
+	if acc.VestingSchedule == nil {
+		return nil
+	}
+	return acc.VestingSchedule.DelegatedVesting
+}
@@ -0,0 +1,84 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
+	vestingtypes "github.com/okex/exchain/libs/cosmos-sdk/x/auth/vesting/types"
+	"github.com/okex/exchain/libs/tendermint/crypto/secp256k1"
+)
+
+func newVestingEthAccount(coins sdk.Coins, schedule *VestingSchedule) *EthAccount {
+	addr := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	return &EthAccount{
+		BaseAccount:     auth.NewBaseAccount(addr, coins, nil, 0, 0),
+		VestingSchedule: schedule,
+	}
+}
+
+func TestVestingScheduleValidate(t *testing.T) {
+	coins := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100))
+	require.NoError(t, NewContinuousVestingSchedule(coins, 100, 200).Validate())
+	require.NoError(t, NewDelayedVestingSchedule(coins, 200).Validate())
+	require.NoError(t, NewPeriodicVestingSchedule(100, vestingtypes.Periods{{Length: 10, Amount: coins}}).Validate())
+
+	require.Error(t, NewContinuousVestingSchedule(coins, 200, 200).Validate())
+	require.Error(t, NewContinuousVestingSchedule(nil, 100, 200).Validate())
+	require.Error(t, NewDelayedVestingSchedule(coins, 0).Validate())
+	require.Error(t, NewPeriodicVestingSchedule(100, vestingtypes.Periods{{Length: 0, Amount: coins}}).Validate())
+
+	schedule := NewPeriodicVestingSchedule(100, vestingtypes.Periods{{Length: 10, Amount: coins}})
+	schedule.EndTime = 200
+	require.Error(t, schedule.Validate())
+}
+
+func TestEthAccountSpendableCoins(t *testing.T) {
+	now := time.Now()
+	coins := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100))
+	acc := newVestingEthAccount(coins, nil)
+	require.Equal(t, coins, acc.SpendableCoins(now))
+	require.True(t, acc.LockedCoins(now).IsZero())
+
+	// half of the coins are vested
+	schedule := NewContinuousVestingSchedule(coins, now.Add(-time.Hour).Unix(), now.Add(time.Hour).Unix())
+	acc = newVestingEthAccount(coins, schedule)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 50)), acc.SpendableCoins(now))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 50)), acc.LockedCoins(now))
+	require.Equal(t, coins, acc.SpendableCoins(now.Add(time.Hour)))
+
+	// the coins received after creation are not locked
+	require.NoError(t, acc.SetCoins(coins.Add(sdk.NewInt64Coin(sdk.DefaultBondDenom, 10))))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 60)), acc.SpendableCoins(now))
+
+	acc = newVestingEthAccount(coins, NewDelayedVestingSchedule(coins, now.Add(time.Hour).Unix()))
+	require.True(t, acc.SpendableCoins(now).IsZero())
+	require.Equal(t, coins, acc.SpendableCoins(now.Add(time.Hour)))
+}
+
+func TestEthAccountTrackDelegation(t *testing.T) {
+	now := time.Now()
+	coins := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100))
+	acc := newVestingEthAccount(coins, NewDelayedVestingSchedule(coins, now.Add(time.Hour).Unix()))
+
+	// the locked coins can be delegated
+	delegation := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 40))
+	acc.TrackDelegation(now, delegation)
+	require.NoError(t, acc.SetCoins(acc.GetCoins().Sub(delegation)))
+	require.Equal(t, delegation, acc.GetDelegatedVesting())
+	require.True(t, acc.GetDelegatedFree().IsZero())
+	require.True(t, acc.SpendableCoins(now).IsZero())
+
+	acc.TrackUndelegation(delegation)
+	require.NoError(t, acc.SetCoins(acc.GetCoins().Add(delegation...)))
+	require.True(t, acc.GetDelegatedVesting().IsZero())
+	require.True(t, acc.SpendableCoins(now).IsZero())
+
+	// no-op without a vesting schedule
+	acc = newVestingEthAccount(coins, nil)
+	acc.TrackDelegation(now, delegation)
+	require.Nil(t, acc.GetDelegatedVesting())
+}
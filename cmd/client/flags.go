@@ -14,6 +14,7 @@ import (
 	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	tmdb "github.com/okex/exchain/libs/tm-db"
 	"github.com/okex/exchain/x/common/analyzer"
+	evmstream "github.com/okex/exchain/x/evm/stream"
 	evmtypes "github.com/okex/exchain/x/evm/types"
 	"github.com/okex/exchain/x/evm/watcher"
 	"github.com/okex/exchain/x/token"
@@ -28,7 +29,15 @@ func RegisterAppFlag(cmd *cobra.Command) {
 	cmd.Flags().Bool(watcher.FlagCheckWd, false, "Enable check watchDB in log")
 	cmd.Flags().Bool(rpc.FlagPersonalAPI, true, "Enable the personal_ prefixed set of APIs in the Web3 JSON-RPC spec")
 	cmd.Flags().Bool(rpc.FlagDebugAPI, false, "Enable the debug_ prefixed set of APIs in the Web3 JSON-RPC spec")
+	cmd.Flags().Bool(rpc.FlagTraceAPI, false, "Enable the trace_ prefixed set of APIs of OpenEthereum and eth_getStateDiff, which replay the committed txs")
 	cmd.Flags().Bool(evmtypes.FlagEnableBloomFilter, false, "Enable bloom filter for event logs")
+	cmd.Flags().Bool(evmtypes.FlagEnableInnerTx, false, "Enable recording the internal transactions of evm txs and the eth_getInternalTransactions RPC APIs")
+	cmd.Flags().Bool(evmtypes.FlagEvmStream, false, "Enable streaming the committed evm txs with their receipts and state diffs to external indexers")
+	cmd.Flags().String(evmstream.FlagEvmStreamFile, "", "The file the evm stream is appended to")
+	cmd.Flags().String(evmstream.FlagEvmStreamUnix, "", "The unix socket path the evm stream is served on")
+	cmd.Flags().String(evmstream.FlagEvmStreamGrpc, "", "The address the evm stream grpc service is served on, such as \"127.0.0.1:9095\"")
+	cmd.Flags().String(evmstream.FlagEvmStreamFormat, evmstream.FormatJSON, "The format of the evm stream written to the file and the unix socket (json|protobuf)")
+	cmd.Flags().Int64(evmstream.FlagEvmStreamRetainBlocks, 100000, "The number of recent blocks kept for the evm stream replay, 0 to keep all")
 	cmd.Flags().Int64(filters.FlagGetLogsHeightSpan, 2000, "config the block height span for get logs")
 	// register application rpc to nacos
 	cmd.Flags().String(rpc.FlagRestApplicationName, "", "rest application name in  nacos")
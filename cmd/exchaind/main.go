@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"path/filepath"
 
 	"github.com/okex/exchain/app/logevents"
 
@@ -25,6 +26,7 @@ import (
 	clientkeys "github.com/okex/exchain/libs/cosmos-sdk/client/keys"
 	"github.com/okex/exchain/libs/cosmos-sdk/crypto/keys"
 	"github.com/okex/exchain/libs/cosmos-sdk/server"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/snapshots"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
 
@@ -114,7 +116,11 @@ func closeApp(iApp abci.Application) {
 	fmt.Println("Close App")
 	app := iApp.(*app.OKExChainApp)
 	app.StopStore()
+	if app.EvmStreamer != nil {
+		app.EvmStreamer.Stop()
+	}
 	evmtypes.CloseIndexer()
+	evmtypes.CloseInnerTxDB()
 	rpc.CloseEthBackend()
 }
 
@@ -124,6 +130,16 @@ func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application
 		panic(err)
 	}
 
+	snapshotDir := filepath.Join(viper.GetString(cli.HomeFlag), "data", "snapshots")
+	snapshotDB, err := sdk.NewLevelDB("metadata", snapshotDir)
+	if err != nil {
+		panic(err)
+	}
+	snapshotStore, err := snapshots.NewStore(snapshotDB, snapshotDir)
+	if err != nil {
+		panic(err)
+	}
+
 	return app.NewOKExChainApp(
 		logger,
 		db,
@@ -134,6 +150,9 @@ func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application
 		baseapp.SetPruning(pruningOpts),
 		baseapp.SetMinGasPrices(viper.GetString(server.FlagMinGasPrices)),
 		baseapp.SetHaltHeight(uint64(viper.GetInt(server.FlagHaltHeight))),
+		baseapp.SetSnapshotStore(snapshotStore),
+		baseapp.SetSnapshotInterval(viper.GetUint64(server.FlagStateSyncSnapshotInterval)),
+		baseapp.SetSnapshotKeepRecent(viper.GetUint32(server.FlagStateSyncSnapshotKeepRecent)),
 	)
 }
 
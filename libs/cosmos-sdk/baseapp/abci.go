@@ -267,6 +267,10 @@ func (app *BaseApp) Commit(req abci.RequestCommit) abci.ResponseCommit {
 		halt = true
 	}
 
+	if app.snapshotManager != nil && app.snapshotInterval > 0 && uint64(header.Height)%app.snapshotInterval == 0 {
+		go app.snapshot(header.Height)
+	}
+
 	if halt {
 		// Halt the binary and allow Tendermint to receive the ResponseCommit
 		// response with the commit ID hash. This will allow the node to successfully
@@ -410,6 +414,54 @@ func handleQueryApp(app *BaseApp, path []string, req abci.RequestQuery) abci.Res
 				Value:     codec.Cdc.MustMarshalBinaryBare(res),
 			}
 
+		case "traceCall":
+			var queryParam sdk.QueryTraceCall
+			err := json.Unmarshal(req.Data, &queryParam)
+			if err != nil {
+				return sdkerrors.QueryResult(sdkerrors.Wrap(err, "invalid trace call params"))
+			}
+			tx, err := app.txDecoder(queryParam.TxBytes)
+			if err != nil {
+				return sdkerrors.QueryResult(sdkerrors.Wrap(err, "failed to decode tx"))
+			}
+			// the same as simulate, only pass the valid from address
+			if addr, err := sdk.AccAddressFromBech32(queryParam.From); err != nil || sdk.VerifyAddressFormat(addr) != nil {
+				queryParam.From = ""
+			}
+			gInfo, res, err := app.TraceCall(queryParam, tx, req.Height)
+			if err != nil {
+				return sdkerrors.QueryResult(sdkerrors.Wrap(err, "failed to trace call"))
+			}
+			simRes := sdk.SimulationResponse{
+				GasInfo: gInfo,
+				Result:  res,
+			}
+			return abci.ResponseQuery{
+				Codespace: sdkerrors.RootCodespace,
+				Height:    req.Height,
+				Value:     codec.Cdc.MustMarshalBinaryBare(simRes),
+			}
+
+		case "traceBlock":
+			var queryParam sdk.QueryTraceBlock
+			err := json.Unmarshal(req.Data, &queryParam)
+			if err != nil {
+				return sdkerrors.QueryResult(sdkerrors.Wrap(err, "invalid trace block params"))
+			}
+			block, err := GetABCIBlock(queryParam.Height)
+			if err != nil {
+				return sdkerrors.QueryResult(sdkerrors.Wrap(err, "invalid trace block height"))
+			}
+			res, err := app.TraceBlock(queryParam, block.Block)
+			if err != nil {
+				return sdkerrors.QueryResult(sdkerrors.Wrap(err, "failed to trace block"))
+			}
+			return abci.ResponseQuery{
+				Codespace: sdkerrors.RootCodespace,
+				Height:    req.Height,
+				Value:     codec.Cdc.MustMarshalBinaryBare(res),
+			}
+
 		case "version":
 			return abci.ResponseQuery{
 				Codespace: sdkerrors.RootCodespace,
@@ -17,6 +17,8 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/okex/exchain/libs/cosmos-sdk/store"
 	"github.com/okex/exchain/libs/cosmos-sdk/store/rootmulti"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/snapshots"
+	snapshottypes "github.com/okex/exchain/libs/cosmos-sdk/store/snapshots/types"
 	storetypes "github.com/okex/exchain/libs/cosmos-sdk/store/types"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
@@ -144,6 +146,7 @@ type BaseApp struct { // nolint: maligned
 	fauxMerkleMode bool             // if true, IAVL MountStores uses MountStoresDB for simulation speed.
 
 	getTxFee                     sdk.GetTxFeeHandler
+	getTxHints                   sdk.GetTxHintsHandler
 	updateFeeCollectorAccHandler sdk.UpdateFeeCollectorAccHandler
 	logFix                       sdk.LogFix
 
@@ -177,6 +180,11 @@ type BaseApp struct { // nolint: maligned
 	// minimum block time (in Unix seconds) at which to halt the chain and gracefully shutdown
 	haltTime uint64
 
+	// manages snapshots, i.e. dumps of app state at certain intervals
+	snapshotManager    *snapshots.Manager
+	snapshotInterval   uint64 // block interval between state sync snapshots
+	snapshotKeepRecent uint32 // recent state sync snapshots to keep
+
 	// application's version string
 	appVersion string
 
@@ -250,8 +258,6 @@ func NewBaseApp(
 	}
 	app.cms.SetLogger(app.logger)
 
-	app.parallelTxManage.workgroup.Start()
-
 	return app
 }
 
@@ -466,6 +472,20 @@ func (app *BaseApp) initFromMainStore(baseKey *sdk.KVStoreKey) error {
 		app.setConsensusParams(consensusParams)
 	}
 
+	// the heights of the snapshots must not be pruned before they are exported in the background
+	if app.snapshotManager != nil && app.snapshotInterval > 0 {
+		rms, ok := app.cms.(*rootmulti.Store)
+		if !ok {
+			return errors.New("state sync snapshots require a rootmulti store")
+		}
+		pruningOpts := rms.GetPruning()
+		if pruningOpts.KeepEvery == 0 || app.snapshotInterval%pruningOpts.KeepEvery != 0 {
+			return fmt.Errorf(
+				"state sync snapshot interval %v must be a multiple of pruning keep every interval %v",
+				app.snapshotInterval, pruningOpts.KeepEvery)
+		}
+	}
+
 	// needed for the export command which inits from store but never calls initchain
 	app.setCheckState(abci.Header{})
 	app.Seal()
@@ -485,6 +505,26 @@ func (app *BaseApp) setHaltTime(haltTime uint64) {
 	app.haltTime = haltTime
 }
 
+func (app *BaseApp) setSnapshotStore(snapshotStore *snapshots.Store) {
+	if snapshotStore == nil {
+		app.snapshotManager = nil
+		return
+	}
+	snapshotter, ok := app.cms.(snapshottypes.Snapshotter)
+	if !ok {
+		panic(fmt.Sprintf("multistore %T does not support snapshots", app.cms))
+	}
+	app.snapshotManager = snapshots.NewManager(snapshotStore, snapshotter)
+}
+
+func (app *BaseApp) setSnapshotInterval(snapshotInterval uint64) {
+	app.snapshotInterval = snapshotInterval
+}
+
+func (app *BaseApp) setSnapshotKeepRecent(snapshotKeepRecent uint32) {
+	app.snapshotKeepRecent = snapshotKeepRecent
+}
+
 func (app *BaseApp) setInterBlockCache(cache sdk.MultiStorePersistentCache) {
 	app.interBlockCache = cache
 }
@@ -20,5 +20,9 @@ func (m *modeHandlerSimulate) handleStartHeight(info *runTxInfo, height int64) e
 		info.ctx = app.getContextForTx(m.mode, info.txBytes)
 	}
 
+	if err == nil && info.traceConfigBytes != nil {
+		info.ctx.SetIsTraceTxLog(true)
+		info.ctx.SetTraceTxLogConfig(info.traceConfigBytes)
+	}
 	return err
 }
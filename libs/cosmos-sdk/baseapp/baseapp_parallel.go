@@ -19,6 +19,7 @@ var (
 type extraDataForTx struct {
 	fee   sdk.Coins
 	isEvm bool
+	hints []string
 }
 
 // txByteWithIndex = txByte + index
@@ -48,11 +49,15 @@ func (app *BaseApp) getExtraDataByTxs(txs [][]byte) []*extraDataForTx {
 				res[index] = &extraDataForTx{}
 				return
 			}
-			coin, isEvm := app.getTxFee(app.getContextForTx(runTxModeDeliver, txBytes), tx)
+			ctx := app.getContextForTx(runTxModeDeliver, txBytes)
+			coin, isEvm := app.getTxFee(ctx, tx)
 			res[index] = &extraDataForTx{
 				fee:   coin,
 				isEvm: isEvm,
 			}
+			if app.getTxHints != nil {
+				res[index].hints = app.getTxHints(ctx, tx)
+			}
 		}()
 	}
 	wg.Wait()
@@ -108,7 +113,13 @@ func (app *BaseApp) ParallelTxs(txs [][]byte, onlyCalSender bool) []*abci.Respon
 	extraData := app.getExtraDataByTxs(txs)
 	app.parallelTxManage.isAsyncDeliverTx = true
 	evmIndex := uint32(0)
+	hints := make([][]string, len(txs))
+	serial := make([]bool, len(txs))
 	for k := range txs {
+		hints[k] = extraData[k].hints
+		// only evm txs are executed in parallel
+		serial[k] = !extraData[k].isEvm
+
 		t := &txStatus{
 			indexInBlock: uint32(k),
 		}
@@ -125,7 +136,7 @@ func (app *BaseApp) ParallelTxs(txs [][]byte, onlyCalSender bool) []*abci.Respon
 		app.parallelTxManage.indexMapBytes = append(app.parallelTxManage.indexMapBytes, vString)
 	}
 
-	return app.runTxs(txWithIndex)
+	return app.runTxs(txWithIndex, newTxScheduler(hints, serial))
 
 }
 
@@ -147,7 +158,7 @@ func (app *BaseApp) fixFeeCollector(txString string) {
 	cache.Write()
 }
 
-func (app *BaseApp) runTxs(txs [][]byte) []*abci.ResponseDeliverTx {
+func (app *BaseApp) runTxs(txs [][]byte, scheduler *txScheduler) []*abci.ResponseDeliverTx {
 	maxGas := app.getMaximumBlockGas()
 	currentGas := uint64(0)
 	overFlow := func(sumGas uint64, currGas int64, maxGas uint64) bool {
@@ -161,23 +172,30 @@ func (app *BaseApp) runTxs(txs [][]byte) []*abci.ResponseDeliverTx {
 	}
 
 	asCache := newAsyncCache()
-	signal := make(chan int, 1)
-	rerunIdx := 0
-	txIndex := 0
-	txReps := make([]*executeResult, len(txs))
 	deliverTxs := make([]*abci.ResponseDeliverTx, len(txs))
 
-	asyncCb := func(execRes *executeResult) {
-		txReps[execRes.GetCounter()] = execRes
-		for txReps[txIndex] != nil {
+	for !scheduler.done() {
+		app.executeRound(txs, scheduler.schedule(), scheduler)
+
+		// commit the results in order, until a tx is not executed yet or conflicts
+		for !scheduler.done() {
+			txIndex := scheduler.next
 			s := app.parallelTxManage.txStatus[app.parallelTxManage.indexMapBytes[txIndex]]
-			res := txReps[txIndex]
+			res := scheduler.results[txIndex]
 
-			if res.Conflict(asCache) || overFlow(currentGas, res.resp.GasUsed, maxGas) {
-				rerunIdx++
+			if res == nil && !scheduler.serial[txIndex] {
+				break
+			}
+			// a result without store is never committable, and a rescheduled tx conflicting again would
+			// conflict in every round, both are executed serially as a rerun tx
+			if scheduler.serial[txIndex] || res.ms == nil || overFlow(currentGas, res.resp.GasUsed, maxGas) ||
+				(scheduler.reRun[txIndex] && res.Conflict(asCache)) {
+				scheduler.reRun[txIndex] = true
 				s.reRun = true
 				res = app.deliverTxWithCache(txs[txIndex])
-
+			} else if res.Conflict(asCache) {
+				scheduler.reschedule(txIndex)
+				break
 			}
 			if s.anteErr != nil {
 				res.ms = nil
@@ -185,7 +203,7 @@ func (app *BaseApp) runTxs(txs [][]byte) []*abci.ResponseDeliverTx {
 
 			txRs := res.GetResponse()
 			deliverTxs[txIndex] = &txRs
-			res.Collect(asCache)
+			res.Collect(asCache, scheduler.round)
 			res.Commit()
 			app.fixFeeCollector(app.parallelTxManage.indexMapBytes[txIndex])
 			if !s.reRun {
@@ -193,24 +211,16 @@ func (app *BaseApp) runTxs(txs [][]byte) []*abci.ResponseDeliverTx {
 			}
 
 			currentGas += uint64(res.resp.GasUsed)
-			txIndex++
-			if txIndex == len(txs) {
-				ParaLog.Update(uint64(app.deliverState.ctx.BlockHeight()), len(txs), rerunIdx)
-				app.logger.Info("Paralleled-tx", "blockHeight", app.deliverState.ctx.BlockHeight(), "len(txs)", len(txs), "Parallel run", len(txs)-rerunIdx, "ReRun", rerunIdx)
-				signal <- 0
-				return
-			}
+			scheduler.next++
 		}
 	}
 
-	app.parallelTxManage.workgroup.cb = asyncCb
-	for _, tx := range txs {
-		go app.asyncDeliverTx(tx)
-	}
-
 	if len(txs) > 0 {
-		//waiting for call back
-		<-signal
+		reRunTxs := scheduler.reRunTxs()
+		ParaLog.Update(uint64(app.deliverState.ctx.BlockHeight()), len(txs), reRunTxs, scheduler.round)
+		app.logger.Info("Paralleled-tx", "blockHeight", app.deliverState.ctx.BlockHeight(), "len(txs)", len(txs),
+			"Parallel run", len(txs)-reRunTxs, "ReRun", reRunTxs, "Rounds", scheduler.round)
+
 		receiptsLogs := app.endParallelTxs()
 		for index, v := range receiptsLogs {
 			if len(v) != 0 { // only update evm tx result
@@ -275,6 +285,9 @@ type executeResult struct {
 	counter    uint32
 	err        error
 	evmCounter uint32
+
+	// round is the scheduling round the tx was executed in
+	round int
 }
 
 func (e executeResult) GetResponse() abci.ResponseDeliverTx {
@@ -284,12 +297,12 @@ func (e executeResult) GetResponse() abci.ResponseDeliverTx {
 func (e executeResult) Conflict(cache *asyncCache) bool {
 	rerun := false
 	if e.ms == nil {
-		return true //TODO fix later
+		return true
 	}
 
 	e.ms.IteratorCache(func(key, value []byte, isDirty bool) bool {
-		//the key we have read was wrote by pre txs
-		if cache.Has(key) && !whiteAccountList[hex.EncodeToString(key)] {
+		//the key we have read was wrote by pre txs after the execution
+		if cache.Has(key, e.round) && !whiteAccountList[hex.EncodeToString(key)] {
 			rerun = true
 			return false // break
 		}
@@ -304,14 +317,14 @@ var (
 	}
 )
 
-func (e executeResult) Collect(cache *asyncCache) {
+func (e executeResult) Collect(cache *asyncCache, round int) {
 	if e.ms == nil {
 		return
 	}
 	e.ms.IteratorCache(func(key, value []byte, isDirty bool) bool {
 		if isDirty {
 			//push every data we have written in current tx
-			cache.Push(key, value)
+			cache.Push(key, round)
 		}
 		return true
 	})
@@ -337,39 +350,9 @@ func newExecuteResult(r abci.ResponseDeliverTx, ms sdk.CacheMultiStore, counter
 	}
 }
 
-type asyncWorkGroup struct {
-	workCh chan *executeResult
-	cb     func(*executeResult)
-}
-
-func newAsyncWorkGroup() *asyncWorkGroup {
-	return &asyncWorkGroup{
-		workCh: make(chan *executeResult, 64),
-		cb:     nil,
-	}
-}
-
-func (a *asyncWorkGroup) Push(item *executeResult) {
-	a.workCh <- item
-}
-
-func (a *asyncWorkGroup) Start() {
-	go func() {
-		for {
-			select {
-			case exec := <-a.workCh:
-				if a.cb != nil {
-					a.cb(exec)
-				}
-			}
-		}
-	}()
-}
-
 type parallelTxManager struct {
 	mu               sync.RWMutex
 	isAsyncDeliverTx bool
-	workgroup        *asyncWorkGroup
 
 	fee       map[string]sdk.Coins
 	refundFee map[string]sdk.Coins
@@ -391,7 +374,6 @@ type txStatus struct {
 func newParallelTxManager() *parallelTxManager {
 	return &parallelTxManager{
 		isAsyncDeliverTx: false,
-		workgroup:        newAsyncWorkGroup(),
 		fee:              make(map[string]sdk.Coins),
 		refundFee:        make(map[string]sdk.Coins),
 
@@ -442,21 +424,23 @@ func (f *parallelTxManager) isReRun(tx string) bool {
 	return data.reRun
 }
 
+// asyncCache records the round in which each key was last written
 type asyncCache struct {
-	mem map[string][]byte
+	mem map[string]int
 }
 
 func newAsyncCache() *asyncCache {
-	return &asyncCache{mem: make(map[string][]byte)}
+	return &asyncCache{mem: make(map[string]int)}
 }
 
-func (a *asyncCache) Push(key, value []byte) {
-	a.mem[string(key)] = value
+func (a *asyncCache) Push(key []byte, round int) {
+	a.mem[string(key)] = round
 }
 
-func (a *asyncCache) Has(key []byte) bool {
-	_, ok := a.mem[string(key)]
-	return ok
+// Has returns true if the key was written in the round or later
+func (a *asyncCache) Has(key []byte, round int) bool {
+	r, ok := a.mem[string(key)]
+	return ok && r >= round
 }
 
 var (
@@ -471,6 +455,7 @@ type parallelBlockInfo struct {
 	height   uint64
 	txs      int
 	reRunTxs int
+	rounds   int
 }
 
 func (p parallelBlockInfo) better(n parallelBlockInfo) bool {
@@ -478,13 +463,22 @@ func (p parallelBlockInfo) better(n parallelBlockInfo) bool {
 }
 
 func (p parallelBlockInfo) string() string {
-	return fmt.Sprintf("Height:%d Txs %d ReRunTxs %d", p.height, p.txs, p.reRunTxs)
+	return fmt.Sprintf("Height:%d Txs %d ReRunTxs %d Rounds %d", p.height, p.txs, p.reRunTxs, p.rounds)
+}
+
+// parallelism is the average number of txs executed per round
+func (p parallelBlockInfo) parallelism() float64 {
+	if p.rounds == 0 {
+		return 0
+	}
+	return float64(p.txs) / float64(p.rounds)
 }
 
 type LogForParallel struct {
 	init         bool
 	sumTx        int
 	reRunTx      int
+	rounds       int
 	blockNumbers int
 
 	bestBlock     parallelBlockInfo
@@ -509,16 +503,17 @@ func NewLogForParallel() *LogForParallel {
 	}
 }
 
-func (l *LogForParallel) Update(height uint64, txs int, reRunCnt int) {
+func (l *LogForParallel) Update(height uint64, txs int, reRunCnt int, rounds int) {
 	l.sumTx += txs
 	l.reRunTx += reRunCnt
+	l.rounds += rounds
 	l.blockNumbers++
 
 	if txs < 20 {
 		return
 	}
 
-	info := parallelBlockInfo{height: height, txs: txs, reRunTxs: reRunCnt}
+	info := parallelBlockInfo{height: height, txs: txs, reRunTxs: reRunCnt, rounds: rounds}
 	if !l.init {
 		l.bestBlock = info
 		l.terribleBlock = info
@@ -538,7 +533,9 @@ func (l *LogForParallel) PrintLog() {
 	fmt.Println("BlockNumbers", l.blockNumbers)
 	fmt.Println("AllTxs", l.sumTx)
 	fmt.Println("ReRunTxs", l.reRunTx)
+	fmt.Println("Rounds", l.rounds)
 	fmt.Println("All Concurrency Rate", float64(l.reRunTx)/float64(l.sumTx))
-	fmt.Println("BestBlock", l.bestBlock.string(), "Concurrency Rate", 1-float64(l.bestBlock.reRunTxs)/float64(l.bestBlock.txs))
-	fmt.Println("TerribleBlock", l.terribleBlock.string(), "Concurrency Rate", 1-float64(l.terribleBlock.reRunTxs)/float64(l.terribleBlock.txs))
+	fmt.Println("All Parallelism", parallelBlockInfo{txs: l.sumTx, rounds: l.rounds}.parallelism())
+	fmt.Println("BestBlock", l.bestBlock.string(), "Concurrency Rate", 1-float64(l.bestBlock.reRunTxs)/float64(l.bestBlock.txs), "Parallelism", l.bestBlock.parallelism())
+	fmt.Println("TerribleBlock", l.terribleBlock.string(), "Concurrency Rate", 1-float64(l.terribleBlock.reRunTxs)/float64(l.terribleBlock.txs), "Parallelism", l.terribleBlock.parallelism())
 }
@@ -0,0 +1,141 @@
+package baseapp
+
+import (
+	"encoding/hex"
+	"runtime"
+	"sync"
+)
+
+// txScheduler splits the txs of a block into rounds of parallel execution.
+//
+// Every tx has a set of conflict keys: the hints known before its execution, plus the
+// keys it read or wrote once executed. A round executes the uncommitted txs whose keys
+// do not overlap the keys of any earlier uncommitted tx, so independent groups of txs
+// run concurrently while the txs depending on each other run in later rounds.
+//
+// Results are always committed in block order. A result is only committed if none of
+// the keys it touched has been written since the round it was executed in, otherwise
+// it is dropped and the tx is scheduled again, which keeps the block result identical
+// to a serial execution.
+type txScheduler struct {
+	keys    []map[string]struct{}
+	serial  []bool
+	results []*executeResult
+	reRun   []bool
+
+	// next is the index of the first uncommitted tx
+	next  int
+	round int
+}
+
+func newTxScheduler(hints [][]string, serial []bool) *txScheduler {
+	keys := make([]map[string]struct{}, len(hints))
+	for i, h := range hints {
+		keys[i] = make(map[string]struct{}, len(h))
+		for _, k := range h {
+			keys[i][k] = struct{}{}
+		}
+	}
+	return &txScheduler{
+		keys:    keys,
+		serial:  serial,
+		results: make([]*executeResult, len(hints)),
+		reRun:   make([]bool, len(hints)),
+	}
+}
+
+// schedule starts a new round and returns the txs to execute in it.
+// The walk stops at the first serial tx, its keys are unknown until it is executed.
+func (s *txScheduler) schedule() []int {
+	s.round++
+	var txs []int
+	pending := make(map[string]struct{})
+	for i := s.next; i < len(s.keys) && !s.serial[i]; i++ {
+		if s.results[i] == nil && !overlap(s.keys[i], pending) {
+			txs = append(txs, i)
+		}
+		for k := range s.keys[i] {
+			pending[k] = struct{}{}
+		}
+	}
+	return txs
+}
+
+// setResult keeps the result of the tx executed in the current round and records the keys it touched
+func (s *txScheduler) setResult(index int, res *executeResult) {
+	res.round = s.round
+	s.results[index] = res
+	if res.ms == nil {
+		return
+	}
+	res.ms.IteratorCache(func(key, value []byte, isDirty bool) bool {
+		if !whiteAccountList[hex.EncodeToString(key)] {
+			s.keys[index][string(key)] = struct{}{}
+		}
+		return true
+	})
+}
+
+// reschedule drops the conflicting result of the tx, it will be executed again in a later round
+func (s *txScheduler) reschedule(index int) {
+	s.results[index] = nil
+	s.reRun[index] = true
+}
+
+func (s *txScheduler) done() bool {
+	return s.next == len(s.keys)
+}
+
+// reRunTxs returns the number of txs whose first execution was not committed
+func (s *txScheduler) reRunTxs() int {
+	cnt := 0
+	for _, r := range s.reRun {
+		if r {
+			cnt++
+		}
+	}
+	return cnt
+}
+
+func overlap(keys, others map[string]struct{}) bool {
+	if len(others) < len(keys) {
+		keys, others = others, keys
+	}
+	for k := range keys {
+		if _, ok := others[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// executeRound executes the scheduled txs concurrently, the results are kept by the scheduler
+func (app *BaseApp) executeRound(txs [][]byte, indexes []int, s *txScheduler) {
+	maxNums := runtime.NumCPU()
+	if maxNums > len(indexes) {
+		maxNums = len(indexes)
+	}
+
+	results := make([]*executeResult, len(indexes))
+	jobs := make(chan int, len(indexes))
+	for k := range indexes {
+		jobs <- k
+	}
+	close(jobs)
+
+	var wg sync.WaitGroup
+	wg.Add(maxNums)
+	for w := 0; w < maxNums; w++ {
+		go func() {
+			defer wg.Done()
+			for k := range jobs {
+				results[k] = app.asyncDeliverTx(txs[indexes[k]])
+			}
+		}()
+	}
+	wg.Wait()
+
+	for k, index := range indexes {
+		s.setResult(index, results[k])
+	}
+}
@@ -0,0 +1,177 @@
+package baseapp
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+)
+
+func TestTxScheduler_Schedule(t *testing.T) {
+	// tx 1 conflicts with tx 0, tx 3 with tx 1, tx 2 is independent
+	hints := [][]string{{"a", "b"}, {"b", "c"}, {"d"}, {"c"}}
+	s := newTxScheduler(hints, make([]bool, len(hints)))
+
+	require.Equal(t, []int{0, 2}, s.schedule())
+	require.Equal(t, 1, s.round)
+	s.setResult(0, newExecuteResult(abci.ResponseDeliverTx{}, nil, 0, 0))
+	s.setResult(2, newExecuteResult(abci.ResponseDeliverTx{}, nil, 2, 0))
+	require.Equal(t, 1, s.results[0].round)
+
+	// the executed txs are not scheduled again, until committed or rescheduled
+	require.Equal(t, []int(nil), s.schedule())
+
+	// tx 0 committed, tx 1 is now the first uncommitted tx
+	s.next = 1
+	require.Equal(t, []int{1}, s.schedule())
+
+	// tx 2 conflicted, it is scheduled again
+	s.reschedule(2)
+	require.Equal(t, []int{1, 2}, s.schedule())
+	require.Equal(t, 1, s.reRunTxs())
+	require.False(t, s.done())
+	s.next = len(hints)
+	require.True(t, s.done())
+}
+
+func TestTxScheduler_ScheduleSerial(t *testing.T) {
+	hints := [][]string{{"a"}, {"b"}, {"c"}, {"d"}}
+	s := newTxScheduler(hints, []bool{false, false, true, false})
+
+	// the txs after a serial tx wait for it to be committed
+	require.Equal(t, []int{0, 1}, s.schedule())
+	s.next = 2
+	require.Equal(t, []int(nil), s.schedule())
+	s.next = 3
+	require.Equal(t, []int{3}, s.schedule())
+}
+
+func TestAsyncCache(t *testing.T) {
+	cache := newAsyncCache()
+	key := []byte("key")
+	require.False(t, cache.Has(key, 0))
+
+	cache.Push(key, 2)
+	// written after the results executed in round 1 and 2, visible to the later ones
+	require.True(t, cache.Has(key, 1))
+	require.True(t, cache.Has(key, 2))
+	require.False(t, cache.Has(key, 3))
+}
+
+func TestParallelTxsMatchSerial(t *testing.T) {
+	// the counter of a msg selects the key it increments, so the txs with the same counter conflict
+	txs := []*txTest{
+		newTxCounter(0, 0),
+		newTxCounter(1, 1),
+		newTxCounter(2, 0),
+		newTxCounter(3, 2, 0),
+		newTxCounter(4, 1),
+		newTxCounter(5, -1), // fails ValidateBasic
+		newTxCounter(6, 0),
+		newTxCounter(7, 1),
+		newTxCounter(8, 3),
+	}
+	txs[6].setFailOnHandler(true)
+	txs[7].setFailOnAnte(true)
+
+	cdc := codec.New()
+	registerTestCodec(cdc)
+	var block [][]byte
+	for _, tx := range txs {
+		txBytes, err := cdc.MarshalBinaryLengthPrefixed(tx)
+		require.NoError(t, err)
+		block = append(block, txBytes)
+	}
+
+	serialApp := setupParallelTestApp(t, false)
+	serialApp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	serialResps := make([]*abci.ResponseDeliverTx, len(block))
+	for i, txBytes := range block {
+		resp := serialApp.DeliverTx(abci.RequestDeliverTx{Tx: txBytes})
+		serialResps[i] = &resp
+	}
+	serialApp.EndBlock(abci.RequestEndBlock{})
+	serialHash := serialApp.Commit(abci.RequestCommit{}).Data
+
+	// tx 2 and tx 4 see the keys incremented by tx 0 and tx 1
+	require.Equal(t, counterEvent("counter", 2).ToABCIEvents(), serialResps[2].Events[1:])
+	require.Equal(t, counterEvent("counter", 2).ToABCIEvents(), serialResps[4].Events[1:])
+	require.Equal(t, sdkerrors.ErrInvalidSequence.ABCICode(), serialResps[5].Code)
+	require.Equal(t, sdkerrors.ErrInvalidRequest.ABCICode(), serialResps[6].Code)
+	require.Equal(t, sdkerrors.ErrUnauthorized.ABCICode(), serialResps[7].Code)
+
+	// the conflicts are either hinted before the execution or found by the reads of the txs
+	for _, withHints := range []bool{true, false} {
+		app := setupParallelTestApp(t, withHints)
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+		resps := app.ParallelTxs(block, false)
+		app.EndBlock(abci.RequestEndBlock{})
+		hash := app.Commit(abci.RequestCommit{}).Data
+
+		require.Equal(t, serialHash, hash, "hints: %v", withHints)
+		require.Equal(t, len(serialResps), len(resps))
+		for i := range resps {
+			require.Equal(t, serialResps[i].Code, resps[i].Code, "hints: %v, tx: %d", withHints, i)
+			require.Equal(t, serialResps[i].GasUsed, resps[i].GasUsed, "hints: %v, tx: %d", withHints, i)
+			require.Equal(t, serialResps[i].Data, resps[i].Data, "hints: %v, tx: %d", withHints, i)
+			require.Equal(t, serialResps[i].Log, resps[i].Log, "hints: %v, tx: %d", withHints, i)
+			require.Equal(t, serialResps[i].Events, resps[i].Events, "hints: %v, tx: %d", withHints, i)
+		}
+	}
+}
+
+// setupParallelTestApp sets up an app whose txs all run in parallel, the msgs increment the keys selected by
+// their counters and return the new values
+func setupParallelTestApp(t *testing.T, withHints bool) *BaseApp {
+	counterKey := func(counter int64) []byte {
+		return []byte(fmt.Sprintf("counter-%d", counter))
+	}
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (newCtx sdk.Context, err error) {
+			newCtx = ctx
+			newCtx.SetGasMeter(sdk.NewInfiniteGasMeter())
+			if tx.(*txTest).FailOnAnte {
+				return newCtx, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "ante handler failure")
+			}
+			return newCtx, nil
+		})
+	}
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+			m := msg.(*msgCounter)
+			if m.FailOnHandler {
+				return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "message handler failure")
+			}
+			store := ctx.KVStore(capKey1)
+			value := getIntFromStore(store, counterKey(m.Counter)) + 1
+			setIntOnStore(store, counterKey(m.Counter), value)
+			ctx.EventManager().EmitEvents(counterEvent("counter", value))
+			return &sdk.Result{Data: store.Get(counterKey(m.Counter)), Events: ctx.EventManager().Events()}, nil
+		})
+	}
+	parallelOpt := func(bapp *BaseApp) {
+		bapp.SetParallelTxHandlers(
+			func(ctx sdk.Context, balance sdk.Coins) error { return nil },
+			func(ctx sdk.Context, tx sdk.Tx) (sdk.Coins, bool) { return sdk.Coins{}, true },
+			func(txs [][]string) [][]byte { return make([][]byte, len(txs)) },
+		)
+		if withHints {
+			bapp.SetParallelTxHintsHandler(func(ctx sdk.Context, tx sdk.Tx) []string {
+				var hints []string
+				for _, msg := range tx.GetMsgs() {
+					hints = append(hints, string(counterKey(msg.(*msgCounter).Counter)))
+				}
+				return hints
+			})
+		}
+	}
+
+	app := setupBaseApp(t, anteOpt, routerOpt, parallelOpt)
+	app.InitChain(abci.RequestInitChain{})
+	return app
+}
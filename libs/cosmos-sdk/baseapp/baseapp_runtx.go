@@ -24,6 +24,9 @@ type runTxInfo struct {
 	result  *sdk.Result
 	txBytes []byte
 	tx      sdk.Tx
+
+	// traceConfigBytes is set to trace the simulated tx
+	traceConfigBytes []byte
 }
 
 func (app *BaseApp) runTx(mode runTxMode,
@@ -269,20 +272,16 @@ func (app *BaseApp) runTx_defer_recover(r interface{}, info *runTxInfo) error {
 	return err
 }
 
-func (app *BaseApp) asyncDeliverTx(txWithIndex []byte) {
+func (app *BaseApp) asyncDeliverTx(txWithIndex []byte) *executeResult {
 
 	txStatus := app.parallelTxManage.txStatus[string(txWithIndex)]
 	tx, err := app.txDecoder(getRealTxByte(txWithIndex))
 	if err != nil {
-		asyncExe := newExecuteResult(sdkerrors.ResponseDeliverTx(err, 0, 0, app.trace), nil, txStatus.indexInBlock, txStatus.evmIndex)
-		app.parallelTxManage.workgroup.Push(asyncExe)
-		return
+		return newExecuteResult(sdkerrors.ResponseDeliverTx(err, 0, 0, app.trace), nil, txStatus.indexInBlock, txStatus.evmIndex)
 	}
 
 	if !txStatus.isEvmTx {
-		asyncExe := newExecuteResult(abci.ResponseDeliverTx{}, nil, txStatus.indexInBlock, txStatus.evmIndex)
-		app.parallelTxManage.workgroup.Push(asyncExe)
-		return
+		return newExecuteResult(abci.ResponseDeliverTx{}, nil, txStatus.indexInBlock, txStatus.evmIndex)
 	}
 
 	var resp abci.ResponseDeliverTx
@@ -301,7 +300,7 @@ func (app *BaseApp) asyncDeliverTx(txWithIndex []byte) {
 
 	asyncExe := newExecuteResult(resp, info.msCacheAnte, txStatus.indexInBlock, txStatus.evmIndex)
 	asyncExe.err = e
-	app.parallelTxManage.workgroup.Push(asyncExe)
+	return asyncExe
 }
 
 func useCache(mode runTxMode) bool {
@@ -14,6 +14,7 @@ import (
 
 	"github.com/okex/exchain/libs/cosmos-sdk/codec"
 	"github.com/okex/exchain/libs/cosmos-sdk/store/rootmulti"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/snapshots"
 	store "github.com/okex/exchain/libs/cosmos-sdk/store/types"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
@@ -328,6 +329,37 @@ func TestLoadVersionInvalid(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestSnapshotIntervalPruning(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	snapshotStore, err := snapshots.NewStore(dbm.NewMemDB(), dir)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		pruning  store.PruningOptions
+		interval uint64
+		expErr   bool
+	}{
+		{store.PruneNothing, 7, false},
+		{store.NewPruningOptions(2, 3, 1, 10), 6, false},
+		{store.NewPruningOptions(2, 3, 1, 10), 0, false},
+		{store.NewPruningOptions(2, 3, 1, 10), 7, true},
+		{store.PruneEverything, 5, true},
+	}
+	for i, tc := range testCases {
+		app := newBaseApp(fmt.Sprintf("%s-%d", t.Name(), i), SetPruning(tc.pruning),
+			SetSnapshotStore(snapshotStore), SetSnapshotInterval(tc.interval))
+		app.MountStores(capKey1)
+		err := app.LoadLatestVersion(capKey1)
+		if tc.expErr {
+			require.Error(t, err, i)
+		} else {
+			require.NoError(t, err, i)
+		}
+	}
+}
+
 func TestLoadVersionPruning(t *testing.T) {
 	logger := log.NewNopLogger()
 	pruningOptions := store.PruningOptions{
@@ -1,6 +1,7 @@
 package baseapp
 
 import (
+	"github.com/ethereum/go-ethereum/common"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
@@ -58,6 +59,60 @@ func (app *BaseApp) TraceTx(queryTraceTx sdk.QueryTraceTx, targetTx sdk.Tx, txIn
 	}
 	return info.result, err
 }
+
+//TraceBlock returns the trace logs for all the evm txs in the block
+//The block is replayed only once, the evm txs are traced with the same config while the others are
+//run as the predesessors in TraceTx.
+func (app *BaseApp) TraceBlock(queryTraceBlock sdk.QueryTraceBlock, block *tmtypes.Block) ([]sdk.TraceTxResult, error) {
+	results := []sdk.TraceTxResult{}
+	if len(block.Txs) == 0 {
+		return results, nil
+	}
+
+	//begin trace block to init traceState and traceBlockCache
+	traceState, err := app.beginBlockForTracing(block.Txs[0], block)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "failed to beginblock for tracing")
+	}
+
+	traceState.ctx.SetTraceTxLogConfig(queryTraceBlock.ConfigBytes)
+	for _, txBytes := range block.Txs {
+		tx, err := app.txDecoder(txBytes, block.Height)
+		if err != nil {
+			return nil, sdkerrors.Wrap(err, "invalid tx in block")
+		}
+		isEvmTx := tx.GetType() == sdk.EvmTxType
+		traceState.ctx.SetIsTraceTxLog(isEvmTx)
+		info, err := app.tracetx(txBytes, tx, block.Height, traceState)
+		if !isEvmTx {
+			//ignore the err when run non-evm tx
+			continue
+		}
+
+		result := sdk.TraceTxResult{
+			TxHash: common.BytesToHash(tmtypes.Tx(txBytes).Hash(block.Height)),
+		}
+		if err != nil {
+			result.Error = err.Error()
+		} else if info != nil && info.result != nil {
+			result.Data = info.result.Data
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+//TraceCall simulates the tx at the given height as Simulate does, and returns the trace log of the tx
+//as the result data
+func (app *BaseApp) TraceCall(queryTraceCall sdk.QueryTraceCall, tx sdk.Tx, height int64) (sdk.GasInfo, *sdk.Result, error) {
+	info := &runTxInfo{traceConfigBytes: queryTraceCall.ConfigBytes}
+	if info.traceConfigBytes == nil {
+		info.traceConfigBytes = []byte{}
+	}
+	e := app.runtxWithInfo(info, runTxModeSimulate, queryTraceCall.TxBytes, tx, height, queryTraceCall.From)
+	return info.gInfo, info.result, e
+}
+
 func (app *BaseApp) tracetx(txBytes []byte, tx sdk.Tx, height int64, traceState *state) (info *runTxInfo, err error) {
 
 	mode := runTxModeTrace
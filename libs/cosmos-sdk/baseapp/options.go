@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/okex/exchain/libs/cosmos-sdk/store"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/snapshots"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	dbm "github.com/okex/exchain/libs/tm-db"
 )
@@ -43,6 +44,21 @@ func SetInterBlockCache(cache sdk.MultiStorePersistentCache) func(*BaseApp) {
 	return func(app *BaseApp) { app.setInterBlockCache(cache) }
 }
 
+// SetSnapshotStore sets the snapshot store used for state sync snapshots.
+func SetSnapshotStore(snapshotStore *snapshots.Store) func(*BaseApp) {
+	return func(app *BaseApp) { app.setSnapshotStore(snapshotStore) }
+}
+
+// SetSnapshotInterval sets the block interval between state sync snapshots.
+func SetSnapshotInterval(interval uint64) func(*BaseApp) {
+	return func(app *BaseApp) { app.setSnapshotInterval(interval) }
+}
+
+// SetSnapshotKeepRecent sets the number of recent state sync snapshots to keep.
+func SetSnapshotKeepRecent(keepRecent uint32) func(*BaseApp) {
+	return func(app *BaseApp) { app.setSnapshotKeepRecent(keepRecent) }
+}
+
 // SetTrace will turn on or off trace flag
 func SetTrace(trace bool) func(*BaseApp) {
 	return func(app *BaseApp) { app.setTrace(trace) }
@@ -172,6 +188,14 @@ func (app *BaseApp) SetParallelTxHandlers(feeCollectt sdk.UpdateFeeCollectorAccH
 	app.logFix = fixLog
 }
 
+// SetParallelTxHintsHandler sets the handler hinting which txs of a block conflict before their execution
+func (app *BaseApp) SetParallelTxHintsHandler(txHints sdk.GetTxHintsHandler) {
+	if app.sealed {
+		panic("SetParallelTxHintsHandler() on sealed BaseApp")
+	}
+	app.getTxHints = txHints
+}
+
 func (app *BaseApp) SetEvmTxVerifySignHandler(sigHandler sdk.TxVerifySigHandler) {
 	if app.sealed {
 		panic("SetEvmTxVerifySignHandler() on sealed BaseApp")
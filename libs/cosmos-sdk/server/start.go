@@ -54,6 +54,9 @@ const (
 	FlagEvmImportMode     = "evm-import-mode"
 	FlagGoroutineNum      = "goroutine-num"
 
+	FlagStateSyncSnapshotInterval   = "state-sync-snapshot-interval"
+	FlagStateSyncSnapshotKeepRecent = "state-sync-snapshot-keep-recent"
+
 	FlagPruningMaxWsNum = "pruning-max-worldstate-num"
 	FlagExportKeystore  = "export-keystore"
 	FlagLogServerUrl    = "log-server"
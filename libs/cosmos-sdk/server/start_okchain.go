@@ -169,6 +169,8 @@ func RegisterServerFlags(cmd *cobra.Command) *cobra.Command {
 	cmd.Flags().Uint64(FlagPruningKeepEvery, 0, "Offset heights to keep on disk after 'keep-every' (ignored if pruning is not 'custom')")
 	cmd.Flags().Uint64(FlagPruningInterval, 0, "Height interval at which pruned heights are removed from disk (ignored if pruning is not 'custom')")
 	cmd.Flags().Uint64(FlagPruningMaxWsNum, 0, "Max number of historic states to keep on disk (ignored if pruning is not 'custom')")
+	cmd.Flags().Uint64(FlagStateSyncSnapshotInterval, 0, "State sync snapshot interval in blocks (0 disables snapshots)")
+	cmd.Flags().Uint32(FlagStateSyncSnapshotKeepRecent, 2, "Number of recent state sync snapshots to keep (0 keeps all)")
 	cmd.Flags().String(FlagLocalRpcPort, "", "Local rpc port for mempool and block monitor on cosmos layer(ignored if mempool/block monitoring is not required)")
 	cmd.Flags().String(FlagPortMonitor, "", "Local target ports for connecting number monitoring(ignored if connecting number monitoring is not required)")
 	cmd.Flags().String(FlagEvmImportMode, "default", "Select import mode for evm state (default|files|db)")
@@ -178,6 +180,9 @@ func RegisterServerFlags(cmd *cobra.Command) *cobra.Command {
 	cmd.Flags().Bool(tmtypes.FlagDownloadDDS, false, "Download delta")
 	cmd.Flags().Bool(tmtypes.FlagUploadDDS, false, "Upload delta")
 	cmd.Flags().Bool(tmtypes.FlagAppendPid, false, "Append pid to the identity of delta producer")
+	cmd.Flags().String(tmtypes.FlagDeltaBroker, tmtypes.DeltaBrokerRedis, "delta broker. redis|fs|p2p")
+	cmd.Flags().String(tmtypes.FlagDeltaFsPath, "", "directory shared by the delta producer and consumers, used by the fs delta broker")
+	cmd.Flags().String(tmtypes.FlagDeltaP2PProducer, "", "hex encoded node pubkey of the delta producer, used by the p2p delta broker")
 	cmd.Flags().String(tmtypes.FlagRedisUrl, "localhost:6379", "redis url")
 	cmd.Flags().String(tmtypes.FlagRedisAuth, "", "redis auth")
 	cmd.Flags().Int(tmtypes.FlagRedisExpire, 300, "delta expiration time. unit is second")
@@ -127,6 +127,11 @@ func (rs *Store) SetPruning(pruningOpts types.PruningOptions) {
 	rs.pruningOpts = pruningOpts
 }
 
+// GetPruning returns the pruning strategy of the root store
+func (rs *Store) GetPruning() types.PruningOptions {
+	return rs.pruningOpts
+}
+
 // SetLazyLoading sets if the iavl store should be loaded lazily or not
 func (rs *Store) SetLazyLoading(lazyLoading bool) {
 	rs.lazyLoading = lazyLoading
@@ -0,0 +1,277 @@
+package rootmulti
+
+import (
+	"bufio"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/store/iavl"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/snapshots"
+	snapshottypes "github.com/okex/exchain/libs/cosmos-sdk/store/snapshots/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	iavltree "github.com/okex/exchain/libs/iavl"
+)
+
+const (
+	// snapshotChunkSize is the size of the snapshot chunks in bytes
+	snapshotChunkSize = uint64(10e6)
+	// snapshotBufferSize is the size of the buffer between the exporter and the chunk writer
+	snapshotBufferSize = int(snapshotChunkSize)
+)
+
+var _ snapshottypes.Snapshotter = (*Store)(nil)
+
+// Snapshot implements snapshottypes.Snapshotter. The stores committed at the given height are
+// exported in name order as a zlib compressed stream of SnapshotItems, split into chunks. The
+// output for a given format must be identical across nodes such that chunks from different
+// sources fit together, so the format must be bumped whenever the byte level output changes.
+func (rs *Store) Snapshot(height uint64, format uint32) (<-chan io.ReadCloser, error) {
+	if format != snapshottypes.CurrentFormat {
+		return nil, sdkerrors.Wrapf(snapshottypes.ErrUnknownFormat, "format %v", format)
+	}
+	if height == 0 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrLogic, "cannot snapshot height 0")
+	}
+	if height > uint64(rs.LastCommitID().Version) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrLogic, "cannot snapshot future height %v", height)
+	}
+
+	// Only the stores committed at the height take part in the app hash, e.g. the ibc stores
+	// before venus1 are mounted but filtered out on commit.
+	cInfo, err := getCommitInfo(rs.db, int64(height))
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(cInfo.StoreInfos))
+	for _, info := range cInfo.StoreInfos {
+		names = append(names, info.Name)
+	}
+	sort.Strings(names)
+
+	// Collect the IAVL stores to export, other committed stores (i.e. memory stores) hold no
+	// persisted state, so only their names are recorded.
+	iavlStores := make(map[string]*iavl.Store)
+	for _, name := range names {
+		key, ok := rs.keysByName[name]
+		if !ok {
+			return nil, fmt.Errorf("store %q is not mounted", name)
+		}
+		switch store := rs.GetCommitKVStore(key).(type) {
+		case *iavl.Store:
+			if !store.VersionExists(int64(height)) {
+				return nil, sdkerrors.Wrapf(sdkerrors.ErrLogic,
+					"version %v of store %q does not exist", height, name)
+			}
+			iavlStores[name] = store
+		default:
+			if store.GetStoreType() != types.StoreTypeMemory {
+				return nil, fmt.Errorf("don't know how to snapshot store %q of type %T", name, store)
+			}
+		}
+	}
+
+	ch := make(chan io.ReadCloser)
+	go func() {
+		chunkWriter := snapshots.NewChunkWriter(ch, snapshotChunkSize)
+		bufWriter := bufio.NewWriterSize(chunkWriter, snapshotBufferSize)
+		zWriter, err := zlib.NewWriterLevel(bufWriter, 7)
+		if err != nil {
+			chunkWriter.CloseWithError(sdkerrors.Wrap(err, "zlib failure"))
+			return
+		}
+
+		for _, name := range names {
+			err := snapshottypes.WriteItem(zWriter, snapshottypes.SnapshotItem{
+				Store: &snapshottypes.SnapshotStoreItem{Name: name},
+			})
+			if err != nil {
+				chunkWriter.CloseWithError(err)
+				return
+			}
+			store, ok := iavlStores[name]
+			if !ok {
+				continue
+			}
+			err = exportIAVLStore(zWriter, store, int64(height))
+			if err != nil {
+				chunkWriter.CloseWithError(sdkerrors.Wrapf(err, "failed to export store %q", name))
+				return
+			}
+		}
+
+		err = zWriter.Close()
+		if err != nil {
+			chunkWriter.CloseWithError(err)
+			return
+		}
+		err = bufWriter.Flush()
+		if err != nil {
+			chunkWriter.CloseWithError(err)
+			return
+		}
+		chunkWriter.Close()
+	}()
+
+	return ch, nil
+}
+
+// exportIAVLStore writes the nodes of an IAVL store version as SnapshotIAVLItems.
+func exportIAVLStore(w io.Writer, store *iavl.Store, version int64) error {
+	exporter, err := store.Export(version)
+	if err != nil {
+		return err
+	}
+	defer exporter.Close()
+
+	for {
+		node, err := exporter.Next()
+		if err == iavltree.ExportDone {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		err = snapshottypes.WriteItem(w, snapshottypes.SnapshotItem{
+			IAVL: &snapshottypes.SnapshotIAVLItem{
+				Key:     node.Key,
+				Value:   node.Value,
+				Height:  int32(node.Height),
+				Version: node.Version,
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Restore implements snapshottypes.Snapshotter. The stores must be empty, once all the chunks
+// are consumed the commit info of the restored stores is written and the latest version loaded.
+func (rs *Store) Restore(
+	height uint64, format uint32, chunks <-chan io.ReadCloser, ready chan<- struct{},
+) error {
+	if format != snapshottypes.CurrentFormat {
+		return sdkerrors.Wrapf(snapshottypes.ErrUnknownFormat, "format %v", format)
+	}
+	if height == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrLogic, "cannot restore snapshot at height 0")
+	}
+	if height > uint64(1<<63-1) {
+		return sdkerrors.Wrapf(snapshottypes.ErrInvalidMetadata,
+			"snapshot height %v cannot exceed %v", height, int64(1<<63-1))
+	}
+
+	// Signal readiness. Must be done before the readers below are set up, since the zlib
+	// reader reads from the stream on initialization, potentially causing deadlocks.
+	if ready != nil {
+		close(ready)
+	}
+
+	chunkReader := snapshots.NewChunkReader(chunks)
+	defer chunkReader.Close()
+	zReader, err := zlib.NewReader(chunkReader)
+	if err != nil {
+		return sdkerrors.Wrap(err, "zlib failure")
+	}
+	defer zReader.Close()
+	// amino reads the length prefix byte by byte, buffer the decompressed stream
+	reader := bufio.NewReader(zReader)
+
+	var importer *iavltree.Importer
+	var restored []string
+	for {
+		var item snapshottypes.SnapshotItem
+		err := snapshottypes.ReadItem(reader, &item)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return sdkerrors.Wrap(err, "invalid snapshot item")
+		}
+
+		switch {
+		case item.Store != nil:
+			if importer != nil {
+				err = importer.Commit()
+				if err != nil {
+					return sdkerrors.Wrap(err, "IAVL commit failed")
+				}
+				importer = nil
+			}
+			key, ok := rs.keysByName[item.Store.Name]
+			if !ok {
+				return sdkerrors.Wrapf(snapshottypes.ErrInvalidMetadata,
+					"snapshot contains unknown store %q", item.Store.Name)
+			}
+			restored = append(restored, item.Store.Name)
+			store, ok := rs.GetCommitKVStore(key).(*iavl.Store)
+			if !ok {
+				continue
+			}
+			importer, err = store.Import(int64(height))
+			if err != nil {
+				return sdkerrors.Wrap(err, "import failed")
+			}
+			defer importer.Close()
+
+		case item.IAVL != nil:
+			if importer == nil {
+				return sdkerrors.Wrap(sdkerrors.ErrLogic, "received IAVL node item before store item")
+			}
+			if item.IAVL.Height > 127 {
+				return sdkerrors.Wrapf(sdkerrors.ErrLogic, "node height %v cannot exceed 127", item.IAVL.Height)
+			}
+			node := &iavltree.ExportNode{
+				Key:     item.IAVL.Key,
+				Value:   item.IAVL.Value,
+				Height:  int8(item.IAVL.Height),
+				Version: item.IAVL.Version,
+			}
+			// Amino does not differentiate between []byte{} and nil, but fortunately IAVL does
+			// not allow nil keys nor nil values for leaf nodes, so we can always set them to empty.
+			if node.Key == nil {
+				node.Key = []byte{}
+			}
+			if node.Height == 0 && node.Value == nil {
+				node.Value = []byte{}
+			}
+			err := importer.Add(node)
+			if err != nil {
+				return sdkerrors.Wrap(err, "IAVL node import failed")
+			}
+
+		default:
+			return sdkerrors.Wrap(sdkerrors.ErrLogic, "unknown snapshot item")
+		}
+	}
+	if importer != nil {
+		err := importer.Commit()
+		if err != nil {
+			return sdkerrors.Wrap(err, "IAVL commit failed")
+		}
+	}
+
+	return rs.flushRestoredCommitInfo(int64(height), restored)
+}
+
+// flushRestoredCommitInfo writes the commit info of the restored stores at the given version
+// and loads it.
+func (rs *Store) flushRestoredCommitInfo(version int64, names []string) error {
+	cInfo := commitInfo{Version: version}
+	for _, name := range names {
+		store := rs.GetCommitKVStore(rs.keysByName[name])
+		cInfo.StoreInfos = append(cInfo.StoreInfos, storeInfo{
+			Name: name,
+			Core: storeCore{
+				CommitID: types.CommitID{
+					Version: version,
+					Hash:    store.LastCommitID().Hash,
+				},
+			},
+		})
+	}
+
+	flushMetadata(rs.db, version, cInfo, []int64{}, []int64{version})
+	return rs.LoadLatestVersion()
+}
@@ -0,0 +1,104 @@
+package rootmulti
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/store/snapshots"
+	snapshottypes "github.com/okex/exchain/libs/cosmos-sdk/store/snapshots/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/types"
+	dbm "github.com/okex/exchain/libs/tm-db"
+)
+
+func newMultiStoreWithData(t *testing.T, versions int, keys int) *Store {
+	ms := newMultiStoreWithMounts(dbm.NewMemDB(), types.PruneNothing)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	for v := 0; v < versions; v++ {
+		for _, name := range []string{"store1", "store2", "store3"} {
+			store := ms.getStoreByName(name).(types.KVStore)
+			for k := 0; k < keys; k++ {
+				store.Set([]byte(fmt.Sprintf("key%v", k)), []byte(fmt.Sprintf("%v-%v-%v", name, v, k)))
+			}
+		}
+		ms.CommitterCommitMap(nil)
+	}
+	return ms
+}
+
+func TestMultistoreSnapshot_Errors(t *testing.T) {
+	ms := newMultiStoreWithData(t, 2, 10)
+
+	_, err := ms.Snapshot(0, snapshottypes.CurrentFormat)
+	require.Error(t, err)
+
+	_, err = ms.Snapshot(3, snapshottypes.CurrentFormat)
+	require.Error(t, err)
+
+	_, err = ms.Snapshot(1, 9)
+	require.Error(t, err)
+	assert.True(t, snapshottypes.ErrUnknownFormat.Is(err))
+}
+
+func TestMultistoreSnapshotRestore(t *testing.T) {
+	source := newMultiStoreWithData(t, 3, 100)
+	target := newMultiStoreWithMounts(dbm.NewMemDB(), types.PruneNothing)
+	require.NoError(t, target.LoadLatestVersion())
+
+	version := uint64(source.LastCommitID().Version)
+	require.EqualValues(t, 3, version)
+
+	chunks, err := source.Snapshot(version, snapshottypes.CurrentFormat)
+	require.NoError(t, err)
+
+	// buffer the chunks, then feed them to the restore
+	var bodies [][]byte
+	for chunk := range chunks {
+		body, err := ioutil.ReadAll(chunk)
+		require.NoError(t, err)
+		bodies = append(bodies, body)
+	}
+	require.NotEmpty(t, bodies)
+
+	restoreChunks := make(chan io.ReadCloser, len(bodies))
+	for _, body := range bodies {
+		restoreChunks <- ioutil.NopCloser(bytes.NewReader(body))
+	}
+	close(restoreChunks)
+
+	err = target.Restore(version, snapshottypes.CurrentFormat, restoreChunks, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, source.LastCommitID(), target.LastCommitID())
+	for _, name := range []string{"store1", "store2", "store3"} {
+		sourceStore := source.getStoreByName(name).(types.KVStore)
+		targetStore := target.getStoreByName(name).(types.KVStore)
+		for k := 0; k < 100; k++ {
+			key := []byte(fmt.Sprintf("key%v", k))
+			assert.Equal(t, sourceStore.Get(key), targetStore.Get(key))
+		}
+	}
+
+	// the restored store can keep on committing
+	target.getStoreByName("store1").(types.KVStore).Set([]byte("new"), []byte("value"))
+	commitID, _ := target.CommitterCommitMap(nil)
+	assert.EqualValues(t, version+1, commitID.Version)
+}
+
+func TestMultistoreRestore_NonEmpty(t *testing.T) {
+	source := newMultiStoreWithData(t, 1, 10)
+	target := newMultiStoreWithData(t, 1, 10)
+
+	chunks, err := source.Snapshot(1, snapshottypes.CurrentFormat)
+	require.NoError(t, err)
+	defer snapshots.DrainChunks(chunks)
+
+	err = target.Restore(1, snapshottypes.CurrentFormat, chunks, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,22 @@
+package types
+
+import (
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+)
+
+// SnapshotCodespace is the codespace of the snapshot errors
+const SnapshotCodespace = "snapshot"
+
+var (
+	// ErrUnknownFormat is returned when an unknown format is used.
+	ErrUnknownFormat = sdkerrors.Register(SnapshotCodespace, 1, "unknown snapshot format")
+
+	// ErrChunkHashMismatch is returned when chunk hash verification failed.
+	ErrChunkHashMismatch = sdkerrors.Register(SnapshotCodespace, 2, "chunk hash verification failed")
+
+	// ErrInvalidMetadata is returned when the snapshot metadata is invalid.
+	ErrInvalidMetadata = sdkerrors.Register(SnapshotCodespace, 3, "invalid snapshot metadata")
+
+	// ErrInvalidSnapshotVersion is returned when the snapshot version is invalid
+	ErrInvalidSnapshotVersion = sdkerrors.Register(SnapshotCodespace, 4, "invalid snapshot version")
+)
@@ -0,0 +1,101 @@
+package types
+
+import (
+	"io"
+
+	amino "github.com/tendermint/go-amino"
+
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+)
+
+const (
+	// CurrentFormat is the currently used format for snapshots. Snapshots using the same format
+	// must be identical across all nodes for a given height, so this must be bumped when the binary
+	// snapshot output changes.
+	CurrentFormat uint32 = 1
+
+	// maxItemSize is the maximum size of a snapshot item, the largest IAVL node values are
+	// far below it
+	maxItemSize = 64e6
+)
+
+var cdc = amino.NewCodec()
+
+// Snapshot contains snapshot metadata.
+type Snapshot struct {
+	Height   uint64   `json:"height"`
+	Format   uint32   `json:"format"`
+	Chunks   uint32   `json:"chunks"`
+	Hash     []byte   `json:"hash"`
+	Metadata Metadata `json:"metadata"`
+}
+
+// Metadata contains SDK-specific snapshot metadata.
+type Metadata struct {
+	ChunkHashes [][]byte `json:"chunk_hashes"` // SHA-256 chunk hashes
+}
+
+// SnapshotItem is an item contained in a rootmulti.Store snapshot, exactly one of the fields is set.
+type SnapshotItem struct {
+	Store *SnapshotStoreItem `json:"store,omitempty"`
+	IAVL  *SnapshotIAVLItem  `json:"iavl,omitempty"`
+}
+
+// SnapshotStoreItem contains metadata about a snapshotted store.
+type SnapshotStoreItem struct {
+	Name string `json:"name"`
+}
+
+// SnapshotIAVLItem is an exported IAVL node.
+type SnapshotIAVLItem struct {
+	Key     []byte `json:"key"`
+	Value   []byte `json:"value"`
+	Version int64  `json:"version"`
+	Height  int32  `json:"height"`
+}
+
+// SnapshotFromABCI converts an ABCI snapshot to a snapshot. Mainly to decode the SDK metadata.
+func SnapshotFromABCI(in *abci.Snapshot) (Snapshot, error) {
+	snapshot := Snapshot{
+		Height: in.Height,
+		Format: in.Format,
+		Chunks: in.Chunks,
+		Hash:   in.Hash,
+	}
+	err := cdc.UnmarshalBinaryBare(in.Metadata, &snapshot.Metadata)
+	if err != nil {
+		return Snapshot{}, sdkerrors.Wrap(err, "failed to unmarshal snapshot metadata")
+	}
+	return snapshot, nil
+}
+
+// ToABCI converts a Snapshot to its ABCI representation. Mainly to encode the SDK metadata.
+func (s Snapshot) ToABCI() (abci.Snapshot, error) {
+	out := abci.Snapshot{
+		Height: s.Height,
+		Format: s.Format,
+		Chunks: s.Chunks,
+		Hash:   s.Hash,
+	}
+	var err error
+	out.Metadata, err = cdc.MarshalBinaryBare(s.Metadata)
+	if err != nil {
+		return abci.Snapshot{}, sdkerrors.Wrap(err, "failed to marshal snapshot metadata")
+	}
+	return out, nil
+}
+
+// WriteItem writes a length prefixed snapshot item to the snapshot stream
+func WriteItem(w io.Writer, item SnapshotItem) error {
+	_, err := cdc.MarshalBinaryLengthPrefixedWriter(w, item)
+	return err
+}
+
+// ReadItem reads a length prefixed snapshot item from the snapshot stream, io.EOF is returned
+// at the end of the stream
+func ReadItem(r io.Reader, item *SnapshotItem) error {
+	*item = SnapshotItem{}
+	_, err := cdc.UnmarshalBinaryLengthPrefixedReader(r, item, maxItemSize)
+	return err
+}
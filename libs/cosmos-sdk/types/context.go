@@ -45,6 +45,7 @@ type Context struct {
 	consParams         *abci.ConsensusParams
 	eventManager       *EventManager
 	accountNonce       uint64
+	feeGranter         AccAddress // feeGranter is set by the ante handler when the tx fee is paid by a fee allowance
 	cache              *Cache
 	trc                *trace.Tracer
 	accountCache       *AccountCache
@@ -87,6 +88,7 @@ func (c *Context) MinGasPrices() DecCoins      { return c.minGasPrice }
 func (c *Context) EventManager() *EventManager { return c.eventManager }
 func (c *Context) IsAsync() bool               { return c.isAsync }
 func (c *Context) AccountNonce() uint64        { return c.accountNonce }
+func (c *Context) FeeGranter() AccAddress      { return c.feeGranter }
 func (c *Context) AnteTracer() *trace.Tracer   { return c.trc }
 func (c *Context) Cache() *Cache               { return c.cache }
 
@@ -202,6 +204,11 @@ func (c *Context) SetAccountNonce(nonce uint64) *Context {
 	return c
 }
 
+func (c *Context) SetFeeGranter(granter AccAddress) *Context {
+	c.feeGranter = granter
+	return c
+}
+
 func (c *Context) SetCache(cache *Cache) *Context {
 	c.cache = cache
 	return c
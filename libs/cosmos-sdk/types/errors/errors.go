@@ -91,6 +91,8 @@ var (
 	
 	ErrKeyNotFound = Register(RootCodespace, 3000, "key not found")
 	ErrLogic = Register(RootCodespace, 35, "internal logic error")
+	// ErrConflict defines an error when an operation conflicts with one already in progress
+	ErrConflict = Register(RootCodespace, 36, "conflict")
 	// ErrInvalidHeight defines an error for an invalid height
 	ErrInvalidHeight = Register(RootCodespace, 26, "invalid height")
 	ErrPackAny = Register(RootCodespace, 33, "failed packing protobuf message to Any")
@@ -19,6 +19,10 @@ type LogFix func(isAnteFailed [][]string) (logs [][]byte)
 
 type GetTxFeeHandler func(ctx Context, tx Tx) (Coins, bool)
 
+// GetTxHintsHandler returns the keys (e.g. sender, recipient and contract addresses)
+// a tx is expected to touch, txs sharing a key are not executed in the same parallel round.
+type GetTxHintsHandler func(ctx Context, tx Tx) []string
+
 // AnteDecorator wraps the next AnteHandler to perform custom pre- and post-processing.
 type AnteDecorator interface {
 	AnteHandle(ctx Context, tx Tx, simulate bool, next AnteHandler) (newCtx Context, err error)
@@ -6,3 +6,21 @@ type QueryTraceTx struct {
 	TxHash      common.Hash `json:"tx"`
 	ConfigBytes []byte      `json:"config"`
 }
+
+type QueryTraceBlock struct {
+	Height      int64  `json:"height"`
+	ConfigBytes []byte `json:"config"`
+}
+
+type QueryTraceCall struct {
+	TxBytes     []byte `json:"tx"`
+	From        string `json:"from"`
+	ConfigBytes []byte `json:"config"`
+}
+
+// TraceTxResult is the trace log of a tx in the traced block, Error is set if the tx failed before being traced
+type TraceTxResult struct {
+	TxHash common.Hash `json:"tx"`
+	Data   []byte      `json:"data"`
+	Error  string      `json:"error"`
+}
@@ -7,12 +7,14 @@ import (
 	"github.com/okex/exchain/libs/cosmos-sdk/x/auth/exported"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/auth/keeper"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/auth/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 
 	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
 )
 
 var (
-	_ FeeTx = (*types.StdTx)(nil) // assert StdTx implements FeeTx
+	_ FeeTx        = (*types.StdTx)(nil) // assert StdTx implements FeeTx
+	_ FeeGranterTx = (*types.StdTx)(nil) // assert StdTx implements FeeGranterTx
 )
 
 // FeeTx defines the interface to be implemented by Tx to use the FeeDecorators
@@ -23,6 +25,16 @@ type FeeTx interface {
 	FeePayer(ctx sdk.Context) sdk.AccAddress
 }
 
+// FeeGranterTx defines the interface to be implemented by Tx whose fee can be paid by a fee granter
+type FeeGranterTx interface {
+	FeeGranter() sdk.AccAddress
+}
+
+// FeegrantKeeper defines the fee grant keeper used to spend the fee allowances granted to the fee payers
+type FeegrantKeeper interface {
+	UseGrantedFees(ctx sdk.Context, granter, grantee sdk.AccAddress, fee sdk.Coins, msgs []sdk.Msg) error
+}
+
 // MempoolFeeDecorator will check if the transaction's fee is at least as large
 // as the local validator's minimum gasFee (defined in validator config).
 // If fee is too low, decorator returns error and tx is rejected from mempool.
@@ -68,19 +80,24 @@ func (mfd MempoolFeeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate b
 	return next(ctx, tx, simulate)
 }
 
-// DeductFeeDecorator deducts fees from the first signer of the tx
-// If the first signer does not have the funds to pay for the fees, return with InsufficientFunds error
+// DeductFeeDecorator deducts fees from the first signer of the tx, or from the fee granter
+// of the tx through the fee allowance it granted to the first signer
+// If the fee payer does not have the funds to pay for the fees, return with InsufficientFunds error
 // Call next AnteHandler if fees successfully deducted
 // CONTRACT: Tx must implement FeeTx interface to use DeductFeeDecorator
 type DeductFeeDecorator struct {
-	ak           keeper.AccountKeeper
-	supplyKeeper types.SupplyKeeper
+	ak             keeper.AccountKeeper
+	supplyKeeper   types.SupplyKeeper
+	feegrantKeeper FeegrantKeeper
 }
 
-func NewDeductFeeDecorator(ak keeper.AccountKeeper, sk types.SupplyKeeper) DeductFeeDecorator {
+// NewDeductFeeDecorator creates a new DeductFeeDecorator. The fee granters are not
+// supported if the feegrant keeper is nil or before the Venus2 height.
+func NewDeductFeeDecorator(ak keeper.AccountKeeper, sk types.SupplyKeeper, fk FeegrantKeeper) DeductFeeDecorator {
 	return DeductFeeDecorator{
-		ak:           ak,
-		supplyKeeper: sk,
+		ak:             ak,
+		supplyKeeper:   sk,
+		feegrantKeeper: fk,
 	}
 }
 
@@ -95,6 +112,21 @@ func (dfd DeductFeeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bo
 	}
 
 	feePayer := feeTx.FeePayer(ctx)
+	if granterTx, ok := tx.(FeeGranterTx); ok && !granterTx.FeeGranter().Empty() {
+		granter := granterTx.FeeGranter()
+		// the fee grant store is only committed since the Venus2 height
+		if dfd.feegrantKeeper == nil || !tmtypes.HigherThanVenus2(ctx.BlockHeight()) {
+			return ctx, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "fee grants are not enabled")
+		}
+		if !granter.Equals(feePayer) {
+			err = dfd.feegrantKeeper.UseGrantedFees(ctx, granter, feePayer, feeTx.GetFee(), tx.GetMsgs())
+			if err != nil {
+				return ctx, sdkerrors.Wrapf(err, "%s not allowed to pay fees from %s", feePayer, granter)
+			}
+		}
+		feePayer = granter
+	}
+
 	feePayerAcc := dfd.ak.GetAccount(ctx, feePayer)
 
 	if feePayerAcc == nil {
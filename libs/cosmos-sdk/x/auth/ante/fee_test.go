@@ -4,9 +4,11 @@ import (
 	"testing"
 
 	"github.com/okex/exchain/libs/tendermint/crypto"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/stretchr/testify/require"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/auth/ante"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/auth/types"
 )
@@ -81,7 +83,7 @@ func TestDeductFees(t *testing.T) {
 	acc.SetCoins([]sdk.Coin{sdk.NewCoin("atom", sdk.NewInt(10))})
 	app.AccountKeeper.SetAccount(ctx, acc)
 
-	dfd := ante.NewDeductFeeDecorator(app.AccountKeeper, app.SupplyKeeper)
+	dfd := ante.NewDeductFeeDecorator(app.AccountKeeper, app.SupplyKeeper, nil)
 	antehandler := sdk.ChainAnteDecorators(dfd)
 
 	_, err := antehandler(ctx, tx, false)
@@ -96,3 +98,55 @@ func TestDeductFees(t *testing.T) {
 
 	require.Nil(t, err, "Tx errored after account has been set with sufficient funds")
 }
+
+type testFeegrantKeeper struct {
+	granter, grantee sdk.AccAddress
+}
+
+func (k testFeegrantKeeper) UseGrantedFees(_ sdk.Context, granter, grantee sdk.AccAddress, _ sdk.Coins, _ []sdk.Msg) error {
+	if !granter.Equals(k.granter) || !grantee.Equals(k.grantee) {
+		return sdkerrors.ErrUnauthorized
+	}
+	return nil
+}
+
+func TestDeductFeesWithGranter(t *testing.T) {
+	// setup
+	app, ctx := createTestApp(true)
+
+	// keys and addresses
+	priv1, _, addr1 := types.KeyTestPubAddr()
+	_, _, granter := types.KeyTestPubAddr()
+
+	msgs := []sdk.Msg{types.NewTestMsg(addr1)}
+	fee := types.NewTestStdFee()
+	fee.Granter = granter
+
+	privs, accNums, seqs := []crypto.PrivKey{priv1}, []uint64{0}, []uint64{0}
+	tx := types.NewTestTx(ctx, msgs, privs, accNums, seqs, fee)
+
+	// the fee payer can't pay for the fees by itself
+	acc := app.AccountKeeper.NewAccountWithAddress(ctx, addr1)
+	app.AccountKeeper.SetAccount(ctx, acc)
+	granterAcc := app.AccountKeeper.NewAccountWithAddress(ctx, granter)
+	granterAcc.SetCoins([]sdk.Coin{sdk.NewCoin("atom", sdk.NewInt(200))})
+	app.AccountKeeper.SetAccount(ctx, granterAcc)
+
+	_, err := sdk.ChainAnteDecorators(ante.NewDeductFeeDecorator(app.AccountKeeper, app.SupplyKeeper, nil))(ctx, tx, false)
+	require.NotNil(t, err, "Tx did not error when fee grants are not enabled")
+
+	fk := testFeegrantKeeper{granter: granter, grantee: addr1}
+	_, err = sdk.ChainAnteDecorators(ante.NewDeductFeeDecorator(app.AccountKeeper, app.SupplyKeeper, fk))(ctx, tx, false)
+	require.NotNil(t, err, "Tx did not error when the fee granter is set before the Venus2 height")
+
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(1)
+	defer tmtypes.UnittestOnlySetMilestoneVenus2Height(0)
+	ctx = ctx.WithBlockHeight(1)
+	_, err = sdk.ChainAnteDecorators(ante.NewDeductFeeDecorator(app.AccountKeeper, app.SupplyKeeper, fk))(ctx, tx, false)
+	require.Nil(t, err, "Tx errored when the fees are paid by the fee granter")
+	require.True(t, app.AccountKeeper.GetAccount(ctx, granter).GetCoins().AmountOf("atom").Equal(sdk.NewDec(50)))
+
+	fk = testFeegrantKeeper{granter: addr1, grantee: granter}
+	_, err = sdk.ChainAnteDecorators(ante.NewDeductFeeDecorator(app.AccountKeeper, app.SupplyKeeper, fk))(ctx, tx, false)
+	require.NotNil(t, err, "Tx did not error when the fee granter didn't grant the fee payer")
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -237,6 +238,10 @@ func (tx *StdTx) FeePayer(ctx sdk.Context) sdk.AccAddress {
 	return sdk.AccAddress{}
 }
 
+// FeeGranter returns the address whose fee allowance pays the fee of the tx,
+// or an empty address if the fee is paid by the fee payer itself
+func (tx *StdTx) FeeGranter() sdk.AccAddress { return tx.Fee.Granter }
+
 // GetGasPrice return gas price
 func (tx *StdTx) GetGasPrice() *big.Int {
 	gasPrices := tx.Fee.GasPrices()
@@ -267,9 +272,13 @@ func (tx *StdTx) GetNonce() uint64 {
 // StdFee includes the amount of coins paid in fees and the maximum
 // gas to be used by the transaction. The ratio yields an effective "gasprice",
 // which must be above some miminum to be accepted into the mempool.
+//
+// If Granter is set, the fee is paid by the granter through the fee allowance it
+// granted to the first signer, instead of by the first signer itself.
 type StdFee struct {
-	Amount sdk.Coins `json:"amount" yaml:"amount"`
-	Gas    uint64    `json:"gas" yaml:"gas"`
+	Amount  sdk.Coins      `json:"amount" yaml:"amount"`
+	Gas     uint64         `json:"gas" yaml:"gas"`
+	Granter sdk.AccAddress `json:"granter,omitempty" yaml:"granter,omitempty"`
 }
 
 // NewStdFee returns a new instance of StdFee
@@ -351,6 +360,9 @@ func (fee *StdFee) UnmarshalFromAmino(cdc *amino.Codec, data []byte) error {
 				return err
 			}
 			dataLen = uint64(n)
+		case 3:
+			fee.Granter = make([]byte, len(subData))
+			copy(fee.Granter, subData)
 		default:
 			return fmt.Errorf("unexpect feild num %d", pos)
 		}
@@ -437,11 +449,19 @@ func EthereumTxEncoder(_ *codec.Codec) sdk.TxEncoder {
 	}
 }
 
+// EthereumTxEncode encodes the tx by its canonical binary encoding if it is implemented (e.g. EIP-2718
+// typed transactions), otherwise by RLP
 func EthereumTxEncode(tx sdk.Tx) ([]byte, error) {
+	if m, ok := tx.(encoding.BinaryMarshaler); ok {
+		return m.MarshalBinary()
+	}
 	return rlp.EncodeToBytes(tx)
 }
 
 func EthereumTxDecode(b []byte, tx interface{}) error {
+	if u, ok := tx.(encoding.BinaryUnmarshaler); ok {
+		return u.UnmarshalBinary(b)
+	}
 	return rlp.DecodeBytes(b, tx)
 }
 
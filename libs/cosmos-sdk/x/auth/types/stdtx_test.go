@@ -290,6 +290,11 @@ func TestStdFeeAmino(t *testing.T) {
 			Amount: sdk.Coins{},
 			Gas:    math.MaxUint64,
 		},
+		{
+			Amount:  sdk.NewCoins(sdk.NewInt64Coin("dummy", 1)),
+			Gas:     uint64(5),
+			Granter: sdk.AccAddress("granter"),
+		},
 	}
 
 	for _, stdFee := range testCases {
@@ -307,3 +312,11 @@ func TestStdFeeAmino(t *testing.T) {
 		require.EqualValues(t, expectValue, actualValue)
 	}
 }
+
+func TestStdFeeBytesGranter(t *testing.T) {
+	fee := NewStdFee(50000, sdk.NewCoins(sdk.NewInt64Coin("atom", 150)))
+	require.NotContains(t, string(fee.Bytes()), "granter")
+
+	fee.Granter = sdk.AccAddress("granter")
+	require.Contains(t, string(fee.Bytes()), fmt.Sprintf(`"granter":"%s"`, fee.Granter))
+}
@@ -55,6 +55,7 @@ type (
 	BaseSendKeeper     = keeper.BaseSendKeeper
 	ViewKeeper         = keeper.ViewKeeper
 	BaseViewKeeper     = keeper.BaseViewKeeper
+	SendCoinsChecker   = keeper.SendCoinsChecker
 	GenesisState       = types.GenesisState
 	MsgSend            = types.MsgSend
 	MsgMultiSend       = types.MsgMultiSend
@@ -35,6 +35,10 @@ func handleMsgSend(ctx sdk.Context, k keeper.Keeper, msg types.MsgSend) (*sdk.Re
 		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not allowed to receive transactions", msg.ToAddress)
 	}
 
+	if err := k.CheckSendCoins(ctx, msg.Amount, msg.FromAddress, msg.ToAddress); err != nil {
+		return nil, err
+	}
+
 	err := k.SendCoins(ctx, msg.FromAddress, msg.ToAddress, msg.Amount)
 	if err != nil {
 		return nil, err
@@ -61,6 +65,15 @@ func handleMsgMultiSend(ctx sdk.Context, k keeper.Keeper, msg types.MsgMultiSend
 		if k.BlacklistedAddr(out.Address) {
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not allowed to receive transactions", out.Address)
 		}
+		if err := k.CheckSendCoins(ctx, out.Coins, out.Address); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, in := range msg.Inputs {
+		if err := k.CheckSendCoins(ctx, in.Coins, in.Address); err != nil {
+			return nil, err
+		}
 	}
 
 	err := k.InputOutputCoins(ctx, msg.Inputs, msg.Outputs)
@@ -172,8 +172,12 @@ type SendKeeper interface {
 	SetSendEnabled(ctx sdk.Context, enabled bool)
 
 	BlacklistedAddr(addr sdk.AccAddress) bool
+	CheckSendCoins(ctx sdk.Context, amt sdk.Coins, addrs ...sdk.AccAddress) error
 }
 
+// SendCoinsChecker checks whether the coins can be sent between the accounts by MsgSend and MsgMultiSend
+type SendCoinsChecker func(ctx sdk.Context, amt sdk.Coins, addrs ...sdk.AccAddress) error
+
 var _ SendKeeper = (*BaseSendKeeper)(nil)
 
 // BaseSendKeeper only allows transfers between accounts without the possibility of
@@ -189,6 +193,8 @@ type BaseSendKeeper struct {
 	blacklistedAddrs map[string]bool
 
 	ik innertx.InnerTxKeeper
+
+	sendCoinsChecker SendCoinsChecker
 }
 
 // NewBaseSendKeeper returns a new BaseSendKeeper.
@@ -465,6 +471,23 @@ func (k BaseSendKeeper) GetInnerTxKeeper() innertx.InnerTxKeeper {
 	return k.ik
 }
 
+// SetSendCoinsChecker set the checker of the coins sent by MsgSend and MsgMultiSend
+func (k *BaseKeeper) SetSendCoinsChecker(checker SendCoinsChecker) {
+	k.BaseSendKeeper.SetSendCoinsChecker(checker)
+}
+
+func (k *BaseSendKeeper) SetSendCoinsChecker(checker SendCoinsChecker) {
+	k.sendCoinsChecker = checker
+}
+
+// CheckSendCoins checks whether the coins can be sent between the accounts with the checker set, if any
+func (k BaseSendKeeper) CheckSendCoins(ctx sdk.Context, amt sdk.Coins, addrs ...sdk.AccAddress) error {
+	if k.sendCoinsChecker == nil {
+		return nil
+	}
+	return k.sendCoinsChecker(ctx, amt, addrs...)
+}
+
 var _ ViewKeeper = (*BaseViewKeeper)(nil)
 
 // ViewKeeper defines a module interface that facilitates read only access to
@@ -0,0 +1,65 @@
+package feegrant
+
+import (
+	"github.com/okex/exchain/libs/cosmos-sdk/x/feegrant/exported"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/feegrant/internal/keeper"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/feegrant/internal/types"
+)
+
+// nolint
+
+const (
+	ModuleName              = types.ModuleName
+	StoreKey                = types.StoreKey
+	RouterKey               = types.RouterKey
+	QuerierRoute            = types.QuerierRoute
+	QueryAllowance          = types.QueryAllowance
+	QueryAllowances         = types.QueryAllowances
+	TypeMsgGrantAllowance   = types.TypeMsgGrantAllowance
+	TypeMsgRevokeAllowance  = types.TypeMsgRevokeAllowance
+	EventTypeUseFeeGrant    = types.EventTypeUseFeeGrant
+	EventTypeRevokeFeeGrant = types.EventTypeRevokeFeeGrant
+	EventTypeSetFeeGrant    = types.EventTypeSetFeeGrant
+	AttributeValueCategory  = types.AttributeValueCategory
+	AttributeKeyGranter     = types.AttributeKeyGranter
+	AttributeKeyGrantee     = types.AttributeKeyGrantee
+)
+
+var (
+	NewKeeper  = keeper.NewKeeper
+	NewQuerier = keeper.NewQuerier
+
+	NewMsgGrantAllowance        = types.NewMsgGrantAllowance
+	NewMsgRevokeAllowance       = types.NewMsgRevokeAllowance
+	NewBasicAllowance           = types.NewBasicAllowance
+	NewPeriodicAllowance        = types.NewPeriodicAllowance
+	NewFeeAllowanceGrant        = types.NewFeeAllowanceGrant
+	NewQueryAllowanceParams     = types.NewQueryAllowanceParams
+	NewQueryAllowancesParams    = types.NewQueryAllowancesParams
+	RegisterCodec               = types.RegisterCodec
+	ModuleCdc                   = types.ModuleCdc
+	NewGenesisState             = types.NewGenesisState
+	DefaultGenesisState         = types.DefaultGenesisState
+	FeeAllowanceKey             = types.FeeAllowanceKey
+	FeeAllowancePrefixByGrantee = types.FeeAllowancePrefixByGrantee
+
+	ErrFeeLimitExceeded = types.ErrFeeLimitExceeded
+	ErrFeeLimitExpired  = types.ErrFeeLimitExpired
+	ErrInvalidDuration  = types.ErrInvalidDuration
+	ErrNoAllowance      = types.ErrNoAllowance
+	ErrAllowanceExists  = types.ErrAllowanceExists
+	ErrInvalidAllowance = types.ErrInvalidAllowance
+	ErrFeeGrantDisabled = types.ErrFeeGrantDisabled
+)
+
+type (
+	Keeper = keeper.Keeper
+
+	FeeAllowance       = exported.FeeAllowance
+	GenesisState       = types.GenesisState
+	FeeAllowanceGrant  = types.FeeAllowanceGrant
+	BasicAllowance     = types.BasicAllowance
+	PeriodicAllowance  = types.PeriodicAllowance
+	MsgGrantAllowance  = types.MsgGrantAllowance
+	MsgRevokeAllowance = types.MsgRevokeAllowance
+)
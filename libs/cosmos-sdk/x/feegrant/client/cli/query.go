@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client"
+	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	"github.com/okex/exchain/libs/cosmos-sdk/client/flags"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/version"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/feegrant/internal/types"
+)
+
+// GetQueryCmd returns the CLI command with all feegrant module query commands mounted.
+func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "Querying commands for the fee grant module",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	cmd.AddCommand(flags.GetCommands(
+		GetCmdQueryAllowance(queryRoute, cdc),
+		GetCmdQueryAllowances(queryRoute, cdc),
+	)...)
+	return cmd
+}
+
+// GetCmdQueryAllowance implements the command to query the fee allowance granted by the granter to the grantee
+func GetCmdQueryAllowance(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "grant [granter] [grantee]",
+		Short: "Query the fee allowance granted by the granter to the grantee",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the fee allowance granted by the granter to the grantee.
+
+Example:
+$ %s query %s grant ex1... ex1...
+`,
+				version.ClientName, types.ModuleName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			granter, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			grantee, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryAllowanceParams(granter, grantee))
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryAllowance)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var grant types.FeeAllowanceGrant
+			if err := cdc.UnmarshalJSON(res, &grant); err != nil {
+				return fmt.Errorf("failed to unmarshal fee allowance: %w", err)
+			}
+			return cliCtx.PrintOutput(grant)
+		},
+	}
+}
+
+// GetCmdQueryAllowances implements the command to query all the fee allowances granted to the grantee
+func GetCmdQueryAllowances(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "grants [grantee]",
+		Short: "Query all the fee allowances granted to the grantee",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query all the fee allowances granted to the grantee.
+
+Example:
+$ %s query %s grants ex1...
+`,
+				version.ClientName, types.ModuleName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryAllowancesParams(grantee))
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryAllowances)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var grants []types.FeeAllowanceGrant
+			if err := cdc.UnmarshalJSON(res, &grants); err != nil {
+				return fmt.Errorf("failed to unmarshal fee allowances: %w", err)
+			}
+			return cliCtx.PrintOutput(grants)
+		},
+	}
+}
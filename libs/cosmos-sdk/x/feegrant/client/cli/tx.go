@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client"
+	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	"github.com/okex/exchain/libs/cosmos-sdk/client/flags"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/version"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth/client/utils"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/feegrant/exported"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/feegrant/internal/types"
+)
+
+// flags for the fee allowances
+const (
+	FlagSpendLimit  = "spend-limit"
+	FlagExpiration  = "expiration"
+	FlagPeriod      = "period"
+	FlagPeriodLimit = "period-limit"
+)
+
+// GetTxCmd returns the transaction commands for the feegrant module
+func GetTxCmd(cdc *codec.Codec) *cobra.Command {
+	txCmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "Fee grant transactions subcommands",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	txCmd.AddCommand(flags.PostCommands(
+		GetCmdGrantAllowance(cdc),
+		GetCmdRevokeAllowance(cdc),
+	)...)
+	return txCmd
+}
+
+// GetCmdGrantAllowance implements the command to grant a fee allowance to the grantee
+func GetCmdGrantAllowance(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant [grantee]",
+		Short: "Grant the grantee an allowance to spend the fees of the granter",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Grant the grantee an allowance to spend the fees of the granter given by --from.
+The allowance is limited by an optional total spend limit and expiration time. A periodic
+allowance is granted if the period and the spend limit of each period are given.
+
+Example:
+$ %s tx %s grant ex1... --spend-limit=100okt --expiration=2023-01-01T00:00:00Z --from=mykey
+$ %s tx %s grant ex1... --spend-limit=100okt --period=24h --period-limit=10okt --from=mykey
+`,
+				version.ClientName, types.ModuleName, version.ClientName, types.ModuleName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			allowance, err := allowanceFromFlags()
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgGrantAllowance(cliCtx.GetFromAddress(), grantee, allowance)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagSpendLimit, "", "The total amount of fees the grantee can spend, no limit if empty")
+	cmd.Flags().String(FlagExpiration, "", "The RFC3339 time when the allowance expires, never expires if empty")
+	cmd.Flags().String(FlagPeriod, "", "The duration of each period of a periodic allowance, e.g. 24h")
+	cmd.Flags().String(FlagPeriodLimit, "", "The amount of fees the grantee can spend in each period of a periodic allowance")
+	return cmd
+}
+
+// GetCmdRevokeAllowance implements the command to revoke the fee allowance granted to the grantee
+func GetCmdRevokeAllowance(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke [grantee]",
+		Short: "Revoke the fee allowance granted to the grantee by the granter",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Revoke the fee allowance granted to the grantee by the granter given by --from.
+
+Example:
+$ %s tx %s revoke ex1... --from=mykey
+`,
+				version.ClientName, types.ModuleName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRevokeAllowance(cliCtx.GetFromAddress(), grantee)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func allowanceFromFlags() (exported.FeeAllowance, error) {
+	var basic types.BasicAllowance
+	if spendLimit := viper.GetString(FlagSpendLimit); spendLimit != "" {
+		coins, err := sdk.ParseDecCoins(spendLimit)
+		if err != nil {
+			return nil, err
+		}
+		basic.SpendLimit = coins
+	}
+	if expiration := viper.GetString(FlagExpiration); expiration != "" {
+		t, err := time.Parse(time.RFC3339, expiration)
+		if err != nil {
+			return nil, err
+		}
+		basic.Expiration = t
+	}
+
+	period, periodLimit := viper.GetString(FlagPeriod), viper.GetString(FlagPeriodLimit)
+	if period == "" && periodLimit == "" {
+		return &basic, nil
+	}
+	if period == "" || periodLimit == "" {
+		return nil, fmt.Errorf("both --%s and --%s are required for a periodic allowance", FlagPeriod, FlagPeriodLimit)
+	}
+
+	duration, err := time.ParseDuration(period)
+	if err != nil {
+		return nil, err
+	}
+	coins, err := sdk.ParseDecCoins(periodLimit)
+	if err != nil {
+		return nil, err
+	}
+	return types.NewPeriodicAllowance(basic, duration, coins), nil
+}
@@ -0,0 +1,16 @@
+package exported
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// FeeAllowance defines the permissions of a grantee to spend the fees of a granter.
+type FeeAllowance interface {
+	// Accept checks whether the fee can be spent by the grantee for the msgs,
+	// and updates the allowance by the spent fee. If remove is true, the allowance
+	// is used up or expired and shall be deleted.
+	Accept(ctx sdk.Context, fee sdk.Coins, msgs []sdk.Msg) (remove bool, err error)
+
+	// ValidateBasic performs a stateless validation of the allowance
+	ValidateBasic() error
+}
@@ -0,0 +1,26 @@
+package feegrant
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// InitGenesis initializes the feegrant module's state from a provided genesis
+// state.
+func InitGenesis(ctx sdk.Context, k Keeper, gs GenesisState) {
+	if err := gs.Validate(); err != nil {
+		panic(fmt.Sprintf("failed to validate %s genesis state: %s", ModuleName, err))
+	}
+
+	for _, grant := range gs.FeeAllowances {
+		if err := k.GrantAllowance(ctx, grant.Granter, grant.Grantee, grant.Allowance); err != nil {
+			panic(fmt.Sprintf("failed to init %s genesis state: %s", ModuleName, err))
+		}
+	}
+}
+
+// ExportGenesis returns the feegrant module's exported genesis.
+func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	return NewGenesisState(k.GetAllFeeAllowances(ctx))
+}
@@ -0,0 +1,60 @@
+package feegrant
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+)
+
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		ctx.SetEventManager(sdk.NewEventManager())
+
+		if !tmtypes.HigherThanVenus2(ctx.BlockHeight()) {
+			return nil, ErrFeeGrantDisabled
+		}
+
+		switch msg := msg.(type) {
+		case MsgGrantAllowance:
+			return handleMsgGrantAllowance(ctx, k, msg)
+
+		case MsgRevokeAllowance:
+			return handleMsgRevokeAllowance(ctx, k, msg)
+
+		default:
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized %s message type: %T", ModuleName, msg)
+		}
+	}
+}
+
+func handleMsgGrantAllowance(ctx sdk.Context, k Keeper, msg MsgGrantAllowance) (*sdk.Result, error) {
+	if err := k.GrantAllowance(ctx, msg.Granter, msg.Grantee, msg.Allowance); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Granter.String()),
+		),
+	)
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgRevokeAllowance(ctx sdk.Context, k Keeper, msg MsgRevokeAllowance) (*sdk.Result, error) {
+	if err := k.RevokeAllowance(ctx, msg.Granter, msg.Grantee); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Granter.String()),
+		),
+	)
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
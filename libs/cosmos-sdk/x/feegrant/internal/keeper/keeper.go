@@ -0,0 +1,186 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/feegrant/exported"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/feegrant/internal/types"
+)
+
+// Keeper manages the fee allowances granted between accounts, and spends them
+// when the fees of the grantees are paid by the granters.
+type Keeper struct {
+	cdc        *codec.Codec
+	storeKey   sdk.StoreKey
+	authKeeper types.AccountKeeper
+}
+
+func NewKeeper(cdc *codec.Codec, storeKey sdk.StoreKey, ak types.AccountKeeper) Keeper {
+	return Keeper{
+		cdc:        cdc,
+		storeKey:   storeKey,
+		authKeeper: ak,
+	}
+}
+
+// Logger returns a module-specific logger.
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
+}
+
+// GrantAllowance creates a new fee allowance granted by the granter to the grantee.
+// The grantee account is created if it doesn't exist, so that it can sign the txs
+// whose fees are paid by the granter.
+func (k Keeper) GrantAllowance(ctx sdk.Context, granter, grantee sdk.AccAddress, allowance exported.FeeAllowance) error {
+	if _, err := k.getGrant(ctx, granter, grantee); err == nil {
+		return sdkerrors.Wrapf(types.ErrAllowanceExists, "granter %s, grantee %s", granter, grantee)
+	}
+
+	if k.authKeeper.GetAccount(ctx, grantee) == nil {
+		k.authKeeper.SetAccount(ctx, k.authKeeper.NewAccountWithAddress(ctx, grantee))
+	}
+
+	k.setGrant(ctx, types.NewFeeAllowanceGrant(granter, grantee, allowance))
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeSetFeeGrant,
+			sdk.NewAttribute(types.AttributeKeyGranter, granter.String()),
+			sdk.NewAttribute(types.AttributeKeyGrantee, grantee.String()),
+		),
+	)
+	return nil
+}
+
+// RevokeAllowance removes the fee allowance granted by the granter to the grantee
+func (k Keeper) RevokeAllowance(ctx sdk.Context, granter, grantee sdk.AccAddress) error {
+	if _, err := k.getGrant(ctx, granter, grantee); err != nil {
+		return err
+	}
+
+	ctx.KVStore(k.storeKey).Delete(types.FeeAllowanceKey(granter, grantee))
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeRevokeFeeGrant,
+			sdk.NewAttribute(types.AttributeKeyGranter, granter.String()),
+			sdk.NewAttribute(types.AttributeKeyGrantee, grantee.String()),
+		),
+	)
+	return nil
+}
+
+// GetAllowance returns the fee allowance granted by the granter to the grantee
+func (k Keeper) GetAllowance(ctx sdk.Context, granter, grantee sdk.AccAddress) (exported.FeeAllowance, error) {
+	grant, err := k.getGrant(ctx, granter, grantee)
+	if err != nil {
+		return nil, err
+	}
+	return grant.Allowance, nil
+}
+
+// UseGrantedFees spends the fee from the allowance granted by the granter to the grantee.
+// The allowance is removed once it is used up or expired.
+func (k Keeper) UseGrantedFees(ctx sdk.Context, granter, grantee sdk.AccAddress, fee sdk.Coins, msgs []sdk.Msg) error {
+	grant, err := k.getGrant(ctx, granter, grantee)
+	if err != nil {
+		return err
+	}
+
+	remove, err := grant.Allowance.Accept(ctx, fee, msgs)
+	if remove {
+		ctx.KVStore(k.storeKey).Delete(types.FeeAllowanceKey(granter, grantee))
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeRevokeFeeGrant,
+				sdk.NewAttribute(types.AttributeKeyGranter, granter.String()),
+				sdk.NewAttribute(types.AttributeKeyGrantee, grantee.String()),
+			),
+		)
+	} else if err == nil {
+		k.setGrant(ctx, grant)
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeUseFeeGrant,
+			sdk.NewAttribute(types.AttributeKeyGranter, granter.String()),
+			sdk.NewAttribute(types.AttributeKeyGrantee, grantee.String()),
+		),
+	)
+	return nil
+}
+
+// FindGranter returns the first granter, in the order of the granter addresses, whose allowance
+// to the grantee accepts the fee. Only the first MaxGranteeAllowancesChecked allowances of the
+// grantee are checked. The allowances are not spent.
+func (k Keeper) FindGranter(ctx sdk.Context, grantee sdk.AccAddress, fee sdk.Coins, msgs []sdk.Msg) (granter sdk.AccAddress, found bool) {
+	checked := 0
+	k.IterateGranteeFeeAllowances(ctx, grantee, func(grant types.FeeAllowanceGrant) bool {
+		if _, err := grant.Allowance.Accept(ctx, fee, msgs); err == nil {
+			granter, found = grant.Granter, true
+			return true
+		}
+		checked++
+		return checked >= types.MaxGranteeAllowancesChecked
+	})
+	return
+}
+
+// IterateGranteeFeeAllowances iterates over all the fee allowances granted to the grantee.
+// If the cb returns true, the iterator will close and stop.
+func (k Keeper) IterateGranteeFeeAllowances(ctx sdk.Context, grantee sdk.AccAddress, cb func(types.FeeAllowanceGrant) bool) {
+	k.iterateFeeAllowances(ctx, types.FeeAllowancePrefixByGrantee(grantee), cb)
+}
+
+// IterateAllFeeAllowances iterates over all the fee allowances.
+// If the cb returns true, the iterator will close and stop.
+func (k Keeper) IterateAllFeeAllowances(ctx sdk.Context, cb func(types.FeeAllowanceGrant) bool) {
+	k.iterateFeeAllowances(ctx, types.FeeAllowanceKeyPrefix, cb)
+}
+
+// GetAllFeeAllowances returns all the fee allowances
+func (k Keeper) GetAllFeeAllowances(ctx sdk.Context) (grants []types.FeeAllowanceGrant) {
+	k.IterateAllFeeAllowances(ctx, func(grant types.FeeAllowanceGrant) bool {
+		grants = append(grants, grant)
+		return false
+	})
+	return grants
+}
+
+func (k Keeper) iterateFeeAllowances(ctx sdk.Context, prefix []byte, cb func(types.FeeAllowanceGrant) bool) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), prefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var grant types.FeeAllowanceGrant
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &grant)
+		if cb(grant) {
+			break
+		}
+	}
+}
+
+func (k Keeper) setGrant(ctx sdk.Context, grant types.FeeAllowanceGrant) {
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(grant)
+	ctx.KVStore(k.storeKey).Set(types.FeeAllowanceKey(grant.Granter, grant.Grantee), bz)
+}
+
+func (k Keeper) getGrant(ctx sdk.Context, granter, grantee sdk.AccAddress) (types.FeeAllowanceGrant, error) {
+	var grant types.FeeAllowanceGrant
+	bz := ctx.KVStore(k.storeKey).Get(types.FeeAllowanceKey(granter, grantee))
+	if len(bz) == 0 {
+		return grant, sdkerrors.Wrapf(types.ErrNoAllowance, "granter %s, grantee %s", granter, grantee)
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &grant)
+	return grant, nil
+}
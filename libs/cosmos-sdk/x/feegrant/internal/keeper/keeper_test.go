@@ -0,0 +1,185 @@
+package keeper_test
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	"github.com/okex/exchain/libs/cosmos-sdk/store"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/feegrant/internal/keeper"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/feegrant/internal/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/params"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/libs/tendermint/crypto/secp256k1"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	dbm "github.com/okex/exchain/libs/tm-db"
+)
+
+type KeeperTestSuite struct {
+	suite.Suite
+
+	ctx     sdk.Context
+	ak      auth.AccountKeeper
+	keeper  keeper.Keeper
+	querier sdk.Querier
+	cdc     *codec.Codec
+	addrs   []sdk.AccAddress
+}
+
+func (suite *KeeperTestSuite) SetupTest() {
+	keyAcc := sdk.NewKVStoreKey(auth.StoreKey)
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+	keyFeegrant := sdk.NewKVStoreKey(types.StoreKey)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	ms.MountStoreWithDB(keyFeegrant, sdk.StoreTypeIAVL, db)
+	suite.Require().NoError(ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	auth.RegisterCodec(cdc)
+	types.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	suite.ak = auth.NewAccountKeeper(cdc, keyAcc, pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
+	suite.keeper = keeper.NewKeeper(cdc, keyFeegrant, suite.ak)
+	suite.querier = keeper.NewQuerier(suite.keeper)
+	suite.cdc = cdc
+	suite.ctx = sdk.NewContext(ms, abci.Header{Time: time.Now().UTC()}, false, log.NewNopLogger())
+
+	suite.addrs = make([]sdk.AccAddress, 3)
+	for i := range suite.addrs {
+		suite.addrs[i] = sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	}
+}
+
+func TestKeeperTestSuite(t *testing.T) {
+	suite.Run(t, new(KeeperTestSuite))
+}
+
+func (suite *KeeperTestSuite) TestGrantAndRevokeAllowance() {
+	granter, grantee := suite.addrs[0], suite.addrs[1]
+	allowance := types.NewBasicAllowance(sdk.NewCoins(sdk.NewInt64Coin("okt", 10)), time.Time{})
+
+	suite.Require().NoError(suite.keeper.GrantAllowance(suite.ctx, granter, grantee, allowance))
+	suite.Require().Error(suite.keeper.GrantAllowance(suite.ctx, granter, grantee, allowance))
+	suite.Require().NotNil(suite.ak.GetAccount(suite.ctx, grantee))
+
+	got, err := suite.keeper.GetAllowance(suite.ctx, granter, grantee)
+	suite.Require().NoError(err)
+	suite.Require().Equal(allowance, got)
+
+	suite.Require().NoError(suite.keeper.RevokeAllowance(suite.ctx, granter, grantee))
+	suite.Require().Error(suite.keeper.RevokeAllowance(suite.ctx, granter, grantee))
+	_, err = suite.keeper.GetAllowance(suite.ctx, granter, grantee)
+	suite.Require().Error(err)
+}
+
+func (suite *KeeperTestSuite) TestUseGrantedFees() {
+	granter, grantee := suite.addrs[0], suite.addrs[1]
+	allowance := types.NewBasicAllowance(sdk.NewCoins(sdk.NewInt64Coin("okt", 10)), time.Time{})
+	suite.Require().NoError(suite.keeper.GrantAllowance(suite.ctx, granter, grantee, allowance))
+
+	fee := sdk.NewCoins(sdk.NewInt64Coin("okt", 6))
+	suite.Require().Error(suite.keeper.UseGrantedFees(suite.ctx, suite.addrs[2], grantee, fee, nil))
+	suite.Require().NoError(suite.keeper.UseGrantedFees(suite.ctx, granter, grantee, fee, nil))
+
+	got, err := suite.keeper.GetAllowance(suite.ctx, granter, grantee)
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewCoins(sdk.NewInt64Coin("okt", 4)), got.(*types.BasicAllowance).SpendLimit)
+
+	// the exceeded fee is rejected and the allowance is left unchanged
+	suite.Require().Error(suite.keeper.UseGrantedFees(suite.ctx, granter, grantee, fee, nil))
+	got, err = suite.keeper.GetAllowance(suite.ctx, granter, grantee)
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewCoins(sdk.NewInt64Coin("okt", 4)), got.(*types.BasicAllowance).SpendLimit)
+
+	// the used up allowance is removed
+	suite.Require().NoError(suite.keeper.UseGrantedFees(suite.ctx, granter, grantee, sdk.NewCoins(sdk.NewInt64Coin("okt", 4)), nil))
+	_, err = suite.keeper.GetAllowance(suite.ctx, granter, grantee)
+	suite.Require().Error(err)
+}
+
+func (suite *KeeperTestSuite) TestFindGranter() {
+	grantee := suite.addrs[2]
+	suite.Require().NoError(suite.keeper.GrantAllowance(suite.ctx, suite.addrs[0], grantee,
+		types.NewBasicAllowance(sdk.NewCoins(sdk.NewInt64Coin("okt", 1)), time.Time{})))
+	suite.Require().NoError(suite.keeper.GrantAllowance(suite.ctx, suite.addrs[1], grantee,
+		types.NewBasicAllowance(sdk.NewCoins(sdk.NewInt64Coin("okt", 10)), time.Time{})))
+
+	granter, found := suite.keeper.FindGranter(suite.ctx, grantee, sdk.NewCoins(sdk.NewInt64Coin("okt", 5)), nil)
+	suite.Require().True(found)
+	suite.Require().Equal(suite.addrs[1], granter)
+
+	// the allowance is not spent
+	got, err := suite.keeper.GetAllowance(suite.ctx, suite.addrs[1], grantee)
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewCoins(sdk.NewInt64Coin("okt", 10)), got.(*types.BasicAllowance).SpendLimit)
+
+	_, found = suite.keeper.FindGranter(suite.ctx, grantee, sdk.NewCoins(sdk.NewInt64Coin("okt", 11)), nil)
+	suite.Require().False(found)
+}
+
+func (suite *KeeperTestSuite) TestFindGranterBounded() {
+	grantee := suite.addrs[2]
+	granters := make([]sdk.AccAddress, types.MaxGranteeAllowancesChecked+1)
+	for i := range granters {
+		granters[i] = sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	}
+	sort.Slice(granters, func(i, j int) bool { return bytes.Compare(granters[i], granters[j]) < 0 })
+
+	// only the allowance of the last granter accepts the fee
+	for _, granter := range granters[:types.MaxGranteeAllowancesChecked] {
+		suite.Require().NoError(suite.keeper.GrantAllowance(suite.ctx, granter, grantee,
+			types.NewBasicAllowance(sdk.NewCoins(sdk.NewInt64Coin("okt", 1)), time.Time{})))
+	}
+	last := granters[types.MaxGranteeAllowancesChecked]
+	suite.Require().NoError(suite.keeper.GrantAllowance(suite.ctx, last, grantee,
+		types.NewBasicAllowance(sdk.NewCoins(sdk.NewInt64Coin("okt", 10)), time.Time{})))
+
+	// the allowances beyond the bound are not checked
+	_, found := suite.keeper.FindGranter(suite.ctx, grantee, sdk.NewCoins(sdk.NewInt64Coin("okt", 5)), nil)
+	suite.Require().False(found)
+
+	// the allowance is found once it is within the bound
+	suite.Require().NoError(suite.keeper.RevokeAllowance(suite.ctx, granters[0], grantee))
+	granter, found := suite.keeper.FindGranter(suite.ctx, grantee, sdk.NewCoins(sdk.NewInt64Coin("okt", 5)), nil)
+	suite.Require().True(found)
+	suite.Require().Equal(last, granter)
+}
+
+func (suite *KeeperTestSuite) TestQueryAllowances() {
+	grantee := suite.addrs[2]
+	for _, granter := range suite.addrs[:2] {
+		suite.Require().NoError(suite.keeper.GrantAllowance(suite.ctx, granter, grantee, types.NewBasicAllowance(nil, time.Time{})))
+	}
+
+	bz, err := suite.cdc.MarshalJSON(types.NewQueryAllowancesParams(grantee))
+	suite.Require().NoError(err)
+	res, err := suite.querier(suite.ctx, []string{types.QueryAllowances}, abci.RequestQuery{Data: bz})
+	suite.Require().NoError(err)
+
+	var grants []types.FeeAllowanceGrant
+	suite.Require().NoError(suite.cdc.UnmarshalJSON(res, &grants))
+	suite.Require().Len(grants, 2)
+
+	bz, err = suite.cdc.MarshalJSON(types.NewQueryAllowanceParams(suite.addrs[0], grantee))
+	suite.Require().NoError(err)
+	res, err = suite.querier(suite.ctx, []string{types.QueryAllowance}, abci.RequestQuery{Data: bz})
+	suite.Require().NoError(err)
+
+	var grant types.FeeAllowanceGrant
+	suite.Require().NoError(suite.cdc.UnmarshalJSON(res, &grant))
+	suite.Require().Equal(suite.addrs[0], grant.Granter)
+}
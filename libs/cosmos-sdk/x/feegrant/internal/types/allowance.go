@@ -0,0 +1,171 @@
+package types
+
+import (
+	"time"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/feegrant/exported"
+)
+
+var (
+	_ exported.FeeAllowance = (*BasicAllowance)(nil)
+	_ exported.FeeAllowance = (*PeriodicAllowance)(nil)
+)
+
+// BasicAllowance allows the grantee to spend the fees up to a total spend limit before an optional expiration.
+// An empty SpendLimit means no limit, and a zero Expiration means the allowance never expires.
+type BasicAllowance struct {
+	SpendLimit sdk.Coins `json:"spend_limit" yaml:"spend_limit"`
+	Expiration time.Time `json:"expiration" yaml:"expiration"`
+}
+
+// NewBasicAllowance creates a new BasicAllowance instance
+func NewBasicAllowance(spendLimit sdk.Coins, expiration time.Time) *BasicAllowance {
+	return &BasicAllowance{
+		SpendLimit: spendLimit,
+		Expiration: expiration,
+	}
+}
+
+// Accept implements exported.FeeAllowance
+func (a *BasicAllowance) Accept(ctx sdk.Context, fee sdk.Coins, _ []sdk.Msg) (bool, error) {
+	if a.isExpired(ctx.BlockTime()) {
+		return true, sdkerrors.Wrap(ErrFeeLimitExpired, "basic allowance")
+	}
+
+	if len(a.SpendLimit) != 0 {
+		left, hasNeg := a.SpendLimit.SafeSub(fee)
+		if hasNeg {
+			return false, sdkerrors.Wrapf(ErrFeeLimitExceeded, "basic allowance: %s < %s", a.SpendLimit, fee)
+		}
+		a.SpendLimit = left
+		return left.IsZero(), nil
+	}
+
+	return false, nil
+}
+
+func (a *BasicAllowance) isExpired(blockTime time.Time) bool {
+	return !a.Expiration.IsZero() && !blockTime.Before(a.Expiration)
+}
+
+// ValidateBasic implements exported.FeeAllowance
+func (a *BasicAllowance) ValidateBasic() error {
+	if len(a.SpendLimit) != 0 {
+		if !a.SpendLimit.IsValid() {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "spend limit: %s", a.SpendLimit)
+		}
+		if !a.SpendLimit.IsAllPositive() {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "spend limit must be positive")
+		}
+	}
+
+	if !a.Expiration.IsZero() && a.Expiration.Unix() < 0 {
+		return sdkerrors.Wrap(ErrInvalidDuration, "expiration time cannot be negative")
+	}
+
+	return nil
+}
+
+// PeriodicAllowance extends the BasicAllowance with a spend limit that is reset every period.
+// The first period starts at the first use of the allowance if PeriodReset is not set.
+type PeriodicAllowance struct {
+	Basic            BasicAllowance `json:"basic" yaml:"basic"`
+	Period           time.Duration  `json:"period" yaml:"period"`
+	PeriodSpendLimit sdk.Coins      `json:"period_spend_limit" yaml:"period_spend_limit"`
+	PeriodCanSpend   sdk.Coins      `json:"period_can_spend" yaml:"period_can_spend"`
+	PeriodReset      time.Time      `json:"period_reset" yaml:"period_reset"`
+}
+
+// NewPeriodicAllowance creates a new PeriodicAllowance instance
+func NewPeriodicAllowance(basic BasicAllowance, period time.Duration, periodSpendLimit sdk.Coins) *PeriodicAllowance {
+	return &PeriodicAllowance{
+		Basic:            basic,
+		Period:           period,
+		PeriodSpendLimit: periodSpendLimit,
+	}
+}
+
+// Accept implements exported.FeeAllowance
+func (a *PeriodicAllowance) Accept(ctx sdk.Context, fee sdk.Coins, _ []sdk.Msg) (bool, error) {
+	blockTime := ctx.BlockTime()
+	if a.Basic.isExpired(blockTime) {
+		return true, sdkerrors.Wrap(ErrFeeLimitExpired, "periodic allowance")
+	}
+
+	a.tryResetPeriod(blockTime)
+
+	canSpend, hasNeg := a.PeriodCanSpend.SafeSub(fee)
+	if hasNeg {
+		return false, sdkerrors.Wrapf(ErrFeeLimitExceeded, "periodic allowance: %s < %s", a.PeriodCanSpend, fee)
+	}
+	a.PeriodCanSpend = canSpend
+
+	if len(a.Basic.SpendLimit) != 0 {
+		left, hasNeg := a.Basic.SpendLimit.SafeSub(fee)
+		if hasNeg {
+			return false, sdkerrors.Wrapf(ErrFeeLimitExceeded, "basic allowance: %s < %s", a.Basic.SpendLimit, fee)
+		}
+		a.Basic.SpendLimit = left
+		return left.IsZero(), nil
+	}
+
+	return false, nil
+}
+
+// tryResetPeriod refills the PeriodCanSpend up to the PeriodSpendLimit if the period is over.
+// The next period starts from the end of the last period, or from the block time if a whole
+// period has been skipped.
+func (a *PeriodicAllowance) tryResetPeriod(blockTime time.Time) {
+	if blockTime.Before(a.PeriodReset) {
+		return
+	}
+
+	a.PeriodCanSpend = a.PeriodSpendLimit
+	if len(a.Basic.SpendLimit) != 0 {
+		a.PeriodCanSpend = minCoins(a.PeriodSpendLimit, a.Basic.SpendLimit)
+	}
+
+	a.PeriodReset = a.PeriodReset.Add(a.Period)
+	if blockTime.After(a.PeriodReset) {
+		a.PeriodReset = blockTime.Add(a.Period)
+	}
+}
+
+// ValidateBasic implements exported.FeeAllowance
+func (a *PeriodicAllowance) ValidateBasic() error {
+	if err := a.Basic.ValidateBasic(); err != nil {
+		return err
+	}
+
+	if !a.PeriodSpendLimit.IsValid() || a.PeriodSpendLimit.Empty() {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "period spend limit: %s", a.PeriodSpendLimit)
+	}
+	if !a.PeriodSpendLimit.IsAllPositive() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "period spend limit must be positive")
+	}
+	if a.PeriodCanSpend.IsAnyNegative() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "period can spend cannot be negative")
+	}
+	if len(a.Basic.SpendLimit) != 0 && !a.Basic.SpendLimit.IsAllGTE(a.PeriodSpendLimit) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "period spend limit exceeds the spend limit")
+	}
+	if a.Period <= 0 {
+		return sdkerrors.Wrap(ErrInvalidDuration, "period must be positive")
+	}
+
+	return nil
+}
+
+// minCoins returns the minimum amounts of the denoms in both a and b
+func minCoins(a, b sdk.Coins) sdk.Coins {
+	var min sdk.Coins
+	for _, coin := range a {
+		amount := sdk.MinDec(coin.Amount, b.AmountOf(coin.Denom))
+		if amount.IsPositive() {
+			min = append(min, sdk.NewDecCoinFromDec(coin.Denom, amount))
+		}
+	}
+	return min
+}
@@ -0,0 +1,69 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+)
+
+func newTestContext(blockTime time.Time) sdk.Context {
+	return sdk.NewContext(nil, abci.Header{Time: blockTime}, false, nil)
+}
+
+func TestBasicAllowanceAccept(t *testing.T) {
+	now := time.Now().UTC()
+	expiration := now.Add(time.Hour)
+	ctx := newTestContext(now)
+
+	allowance := NewBasicAllowance(sdk.NewCoins(sdk.NewInt64Coin("okt", 10)), expiration)
+	require.NoError(t, allowance.ValidateBasic())
+
+	remove, err := allowance.Accept(ctx, sdk.NewCoins(sdk.NewInt64Coin("okt", 4)), nil)
+	require.NoError(t, err)
+	require.False(t, remove)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("okt", 6)), allowance.SpendLimit)
+
+	_, err = allowance.Accept(ctx, sdk.NewCoins(sdk.NewInt64Coin("okt", 7)), nil)
+	require.Error(t, err)
+
+	remove, err = allowance.Accept(ctx, sdk.NewCoins(sdk.NewInt64Coin("okt", 6)), nil)
+	require.NoError(t, err)
+	require.True(t, remove)
+
+	allowance = NewBasicAllowance(nil, expiration)
+	remove, err = allowance.Accept(newTestContext(expiration), sdk.NewCoins(sdk.NewInt64Coin("okt", 1)), nil)
+	require.Error(t, err)
+	require.True(t, remove)
+}
+
+func TestPeriodicAllowanceAccept(t *testing.T) {
+	now := time.Now().UTC()
+	basic := BasicAllowance{SpendLimit: sdk.NewCoins(sdk.NewInt64Coin("okt", 15))}
+	allowance := NewPeriodicAllowance(basic, time.Hour, sdk.NewCoins(sdk.NewInt64Coin("okt", 10)))
+	require.NoError(t, allowance.ValidateBasic())
+
+	// the first period starts at the first use
+	remove, err := allowance.Accept(newTestContext(now), sdk.NewCoins(sdk.NewInt64Coin("okt", 8)), nil)
+	require.NoError(t, err)
+	require.False(t, remove)
+	require.Equal(t, now.Add(time.Hour), allowance.PeriodReset)
+
+	_, err = allowance.Accept(newTestContext(now.Add(time.Minute)), sdk.NewCoins(sdk.NewInt64Coin("okt", 3)), nil)
+	require.Error(t, err)
+
+	// the period can spend is limited by the spend limit left
+	remove, err = allowance.Accept(newTestContext(now.Add(time.Hour)), sdk.NewCoins(sdk.NewInt64Coin("okt", 7)), nil)
+	require.NoError(t, err)
+	require.True(t, remove)
+}
+
+func TestPeriodicAllowanceValidateBasic(t *testing.T) {
+	basic := BasicAllowance{SpendLimit: sdk.NewCoins(sdk.NewInt64Coin("okt", 5))}
+	require.Error(t, NewPeriodicAllowance(basic, time.Hour, sdk.NewCoins(sdk.NewInt64Coin("okt", 10))).ValidateBasic())
+	require.Error(t, NewPeriodicAllowance(basic, 0, sdk.NewCoins(sdk.NewInt64Coin("okt", 1))).ValidateBasic())
+	require.Error(t, NewPeriodicAllowance(basic, time.Hour, nil).ValidateBasic())
+}
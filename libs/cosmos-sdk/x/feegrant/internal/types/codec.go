@@ -0,0 +1,25 @@
+package types
+
+import (
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/feegrant/exported"
+)
+
+// ModuleCdc defines the feegrant module's codec
+var ModuleCdc = codec.New()
+
+// RegisterCodec registers all the necessary types and interfaces for the
+// feegrant module.
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterInterface((*exported.FeeAllowance)(nil), nil)
+	cdc.RegisterConcrete(&BasicAllowance{}, "cosmos-sdk/BasicAllowance", nil)
+	cdc.RegisterConcrete(&PeriodicAllowance{}, "cosmos-sdk/PeriodicAllowance", nil)
+	cdc.RegisterConcrete(MsgGrantAllowance{}, "cosmos-sdk/MsgGrantAllowance", nil)
+	cdc.RegisterConcrete(MsgRevokeAllowance{}, "cosmos-sdk/MsgRevokeAllowance", nil)
+}
+
+func init() {
+	RegisterCodec(ModuleCdc)
+	codec.RegisterCrypto(ModuleCdc)
+	ModuleCdc.Seal()
+}
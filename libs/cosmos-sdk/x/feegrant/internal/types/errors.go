@@ -0,0 +1,17 @@
+// DONTCOVER
+package types
+
+import (
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+)
+
+// x/feegrant module sentinel errors
+var (
+	ErrFeeLimitExceeded = sdkerrors.Register(ModuleName, 1, "fee limit exceeded")
+	ErrFeeLimitExpired  = sdkerrors.Register(ModuleName, 2, "fee allowance expired")
+	ErrInvalidDuration  = sdkerrors.Register(ModuleName, 3, "invalid duration")
+	ErrNoAllowance      = sdkerrors.Register(ModuleName, 4, "no fee allowance")
+	ErrAllowanceExists  = sdkerrors.Register(ModuleName, 5, "fee allowance already exists")
+	ErrInvalidAllowance = sdkerrors.Register(ModuleName, 6, "invalid fee allowance")
+	ErrFeeGrantDisabled = sdkerrors.Register(ModuleName, 7, "fee grant is not enabled before the Venus2 height")
+)
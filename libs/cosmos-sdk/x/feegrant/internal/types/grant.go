@@ -0,0 +1,41 @@
+package types
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/feegrant/exported"
+)
+
+// FeeAllowanceGrant is the fee allowance granted by the granter to the grantee
+type FeeAllowanceGrant struct {
+	Granter   sdk.AccAddress        `json:"granter" yaml:"granter"`
+	Grantee   sdk.AccAddress        `json:"grantee" yaml:"grantee"`
+	Allowance exported.FeeAllowance `json:"allowance" yaml:"allowance"`
+}
+
+// NewFeeAllowanceGrant creates a new FeeAllowanceGrant instance
+func NewFeeAllowanceGrant(granter, grantee sdk.AccAddress, allowance exported.FeeAllowance) FeeAllowanceGrant {
+	return FeeAllowanceGrant{
+		Granter:   granter,
+		Grantee:   grantee,
+		Allowance: allowance,
+	}
+}
+
+// ValidateBasic performs a stateless validation of the grant
+func (g FeeAllowanceGrant) ValidateBasic() error {
+	if g.Granter.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing granter address")
+	}
+	if g.Grantee.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing grantee address")
+	}
+	if g.Granter.Equals(g.Grantee) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "cannot self-grant fee allowance")
+	}
+	if g.Allowance == nil {
+		return sdkerrors.Wrap(ErrInvalidAllowance, "missing allowance")
+	}
+
+	return g.Allowance.ValidateBasic()
+}
@@ -0,0 +1,46 @@
+package types
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+const (
+	// ModuleName defines the module name
+	ModuleName = "feegrant"
+
+	// StoreKey defines the primary module store key
+	StoreKey = ModuleName
+
+	// RouterKey defines the module's message routing key
+	RouterKey = ModuleName
+
+	// QuerierRoute defines the module's query routing key
+	QuerierRoute = ModuleName
+
+	// MaxGranteeAllowancesChecked bounds the fee allowances of a grantee that are checked
+	// when the granter paying the gas fee of an evm tx is looked for
+	MaxGranteeAllowancesChecked = 8
+)
+
+// KVStore key prefixes
+var (
+	// FeeAllowanceKeyPrefix is the prefix of the fee allowances, which are keyed by the grantee first,
+	// so that the allowances of a grantee can be iterated in the ante handler
+	FeeAllowanceKeyPrefix = []byte{0x00}
+)
+
+// FeeAllowanceKey returns the store key of the fee allowance granted by the granter to the grantee:
+// 0x00 | len(grantee) | grantee | len(granter) | granter
+func FeeAllowanceKey(granter, grantee sdk.AccAddress) []byte {
+	key := FeeAllowancePrefixByGrantee(grantee)
+	key = append(key, byte(len(granter)))
+	return append(key, granter...)
+}
+
+// FeeAllowancePrefixByGrantee returns the store key prefix of all the fee allowances granted to the grantee
+func FeeAllowancePrefixByGrantee(grantee sdk.AccAddress) []byte {
+	key := make([]byte, 0, len(FeeAllowanceKeyPrefix)+1+len(grantee))
+	key = append(key, FeeAllowanceKeyPrefix...)
+	key = append(key, byte(len(grantee)))
+	return append(key, grantee...)
+}
@@ -0,0 +1,102 @@
+package types
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/feegrant/exported"
+)
+
+// Message types for the feegrant module
+const (
+	TypeMsgGrantAllowance  = "grant_fee_allowance"
+	TypeMsgRevokeAllowance = "revoke_fee_allowance"
+)
+
+var (
+	_ sdk.Msg = MsgGrantAllowance{}
+	_ sdk.Msg = MsgRevokeAllowance{}
+)
+
+// MsgGrantAllowance grants the grantee an allowance to spend the fees of the granter
+type MsgGrantAllowance struct {
+	Granter   sdk.AccAddress        `json:"granter" yaml:"granter"`
+	Grantee   sdk.AccAddress        `json:"grantee" yaml:"grantee"`
+	Allowance exported.FeeAllowance `json:"allowance" yaml:"allowance"`
+}
+
+// NewMsgGrantAllowance creates a new MsgGrantAllowance instance
+func NewMsgGrantAllowance(granter, grantee sdk.AccAddress, allowance exported.FeeAllowance) MsgGrantAllowance {
+	return MsgGrantAllowance{
+		Granter:   granter,
+		Grantee:   grantee,
+		Allowance: allowance,
+	}
+}
+
+// Route returns the MsgGrantAllowance's route.
+func (m MsgGrantAllowance) Route() string { return RouterKey }
+
+// Type returns the MsgGrantAllowance's type.
+func (m MsgGrantAllowance) Type() string { return TypeMsgGrantAllowance }
+
+// ValidateBasic performs basic (non-state-dependant) validation on a MsgGrantAllowance.
+func (m MsgGrantAllowance) ValidateBasic() error {
+	return NewFeeAllowanceGrant(m.Granter, m.Grantee, m.Allowance).ValidateBasic()
+}
+
+// GetSignBytes returns the raw bytes a signer is expected to sign when submitting
+// a MsgGrantAllowance message.
+func (m MsgGrantAllowance) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(m))
+}
+
+// GetSigners returns the granter as the single expected signer.
+func (m MsgGrantAllowance) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{m.Granter}
+}
+
+// MsgRevokeAllowance removes the fee allowance granted by the granter to the grantee
+type MsgRevokeAllowance struct {
+	Granter sdk.AccAddress `json:"granter" yaml:"granter"`
+	Grantee sdk.AccAddress `json:"grantee" yaml:"grantee"`
+}
+
+// NewMsgRevokeAllowance creates a new MsgRevokeAllowance instance
+func NewMsgRevokeAllowance(granter, grantee sdk.AccAddress) MsgRevokeAllowance {
+	return MsgRevokeAllowance{
+		Granter: granter,
+		Grantee: grantee,
+	}
+}
+
+// Route returns the MsgRevokeAllowance's route.
+func (m MsgRevokeAllowance) Route() string { return RouterKey }
+
+// Type returns the MsgRevokeAllowance's type.
+func (m MsgRevokeAllowance) Type() string { return TypeMsgRevokeAllowance }
+
+// ValidateBasic performs basic (non-state-dependant) validation on a MsgRevokeAllowance.
+func (m MsgRevokeAllowance) ValidateBasic() error {
+	if m.Granter.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing granter address")
+	}
+	if m.Grantee.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing grantee address")
+	}
+	if m.Granter.Equals(m.Grantee) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "granter and grantee cannot be the same")
+	}
+
+	return nil
+}
+
+// GetSignBytes returns the raw bytes a signer is expected to sign when submitting
+// a MsgRevokeAllowance message.
+func (m MsgRevokeAllowance) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(m))
+}
+
+// GetSigners returns the granter as the single expected signer.
+func (m MsgRevokeAllowance) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{m.Granter}
+}
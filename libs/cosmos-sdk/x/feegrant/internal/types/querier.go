@@ -0,0 +1,32 @@
+package types
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// Querier routes for the feegrant module
+const (
+	QueryAllowance  = "allowance"
+	QueryAllowances = "allowances"
+)
+
+// QueryAllowanceParams defines the parameters necessary for querying the fee allowance
+// granted by the granter to the grantee.
+type QueryAllowanceParams struct {
+	Granter sdk.AccAddress `json:"granter" yaml:"granter"`
+	Grantee sdk.AccAddress `json:"grantee" yaml:"grantee"`
+}
+
+func NewQueryAllowanceParams(granter, grantee sdk.AccAddress) QueryAllowanceParams {
+	return QueryAllowanceParams{Granter: granter, Grantee: grantee}
+}
+
+// QueryAllowancesParams defines the parameters necessary for querying all the fee allowances
+// granted to the grantee.
+type QueryAllowancesParams struct {
+	Grantee sdk.AccAddress `json:"grantee" yaml:"grantee"`
+}
+
+func NewQueryAllowancesParams(grantee sdk.AccAddress) QueryAllowancesParams {
+	return QueryAllowancesParams{Grantee: grantee}
+}
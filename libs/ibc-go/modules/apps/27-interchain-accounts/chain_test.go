@@ -0,0 +1,423 @@
+package ica_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/okex/exchain/libs/cosmos-sdk/baseapp"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	codectypes "github.com/okex/exchain/libs/cosmos-sdk/codec/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/store"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/bank"
+	capabilitykeeper "github.com/okex/exchain/libs/cosmos-sdk/x/capability/keeper"
+	capabilitytypes "github.com/okex/exchain/libs/cosmos-sdk/x/capability/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/params"
+	ica "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/controller"
+	controllerkeeper "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/controller/keeper"
+	controllertypes "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/controller/types"
+	icahost "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/host"
+	hostkeeper "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/host/keeper"
+	hosttypes "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/host/types"
+	icatypes "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/types"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+	portkeeper "github.com/okex/exchain/libs/ibc-go/modules/core/05-port/keeper"
+	porttypes "github.com/okex/exchain/libs/ibc-go/modules/core/05-port/types"
+	host "github.com/okex/exchain/libs/ibc-go/modules/core/24-host"
+	"github.com/okex/exchain/libs/ibc-go/modules/core/exported"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	dbm "github.com/okex/exchain/libs/tm-db"
+	"github.com/stretchr/testify/require"
+)
+
+const testDenom = "okt"
+
+// testChain is an in-process chain with the interchain accounts controller and host wired to the real
+// capability keeper, port keeper and 05-port router. The channel keeper is replaced by testChannelKeeper
+// which stores the channel ends and sent packets without verifying the proofs of the counterparty.
+type testChain struct {
+	t            *testing.T
+	ctx          sdk.Context
+	connectionID string
+
+	cdc           *codec.CodecProxy
+	accountKeeper auth.AccountKeeper
+	bankKeeper    bank.Keeper
+	scopedIBC     capabilitykeeper.ScopedKeeper
+	portKeeper    portkeeper.Keeper
+	channels      *testChannelKeeper
+	evm           *testEvmKeeper
+
+	controllerKeeper controllerkeeper.Keeper
+	hostKeeper       hostkeeper.Keeper
+	handler          sdk.Handler
+}
+
+func makeTestCodec() *codec.Codec {
+	cdc := codec.New()
+	codec.RegisterCrypto(cdc)
+	sdk.RegisterCodec(cdc)
+	auth.RegisterCodec(cdc)
+	bank.RegisterCodec(cdc)
+	ica.AppModuleBasic{}.RegisterCodec(cdc)
+	return cdc
+}
+
+func newTestChain(t *testing.T, connectionID string) *testChain {
+	keyAcc := sdk.NewKVStoreKey(auth.StoreKey)
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+	keyCapability := sdk.NewKVStoreKey(capabilitytypes.StoreKey)
+	memKeyCapability := sdk.NewMemoryStoreKeys(capabilitytypes.MemStoreKey)[capabilitytypes.MemStoreKey]
+	keyController := sdk.NewKVStoreKey(controllertypes.StoreKey)
+	keyHost := sdk.NewKVStoreKey(hosttypes.StoreKey)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	ms.MountStoreWithDB(keyCapability, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(memKeyCapability, sdk.StoreTypeMemory, nil)
+	ms.MountStoreWithDB(keyController, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyHost, sdk.StoreTypeIAVL, db)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: connectionID, Height: 1, Time: time.Now().UTC()}, false, log.NewNopLogger())
+
+	cdc := makeTestCodec()
+	proxy := codec.NewCodecProxy(codec.NewProtoCodec(codectypes.NewInterfaceRegistry()), cdc)
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+
+	chain := &testChain{t: t, ctx: ctx, connectionID: connectionID, cdc: proxy}
+	chain.accountKeeper = auth.NewAccountKeeper(cdc, keyAcc, pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
+	bankKeeper := bank.NewBaseKeeper(chain.accountKeeper, pk.Subspace(bank.DefaultParamspace), map[string]bool{})
+	bankKeeper.SetSendEnabled(ctx, true)
+	chain.bankKeeper = bankKeeper
+
+	capabilityKeeper := capabilitykeeper.NewKeeper(proxy, keyCapability, memKeyCapability)
+	chain.scopedIBC = capabilityKeeper.ScopeToModule(host.ModuleName)
+	scopedController := capabilityKeeper.ScopeToModule(controllertypes.SubModuleName)
+	scopedHost := capabilityKeeper.ScopeToModule(hosttypes.SubModuleName)
+	capabilityKeeper.InitializeIndex(ctx, 1)
+	chain.portKeeper = portkeeper.NewKeeper(&chain.scopedIBC)
+	chain.channels = newTestChannelKeeper(chain)
+	chain.evm = &testEvmKeeper{}
+
+	msgRouter := baseapp.NewRouter()
+	chain.controllerKeeper = controllerkeeper.NewKeeper(proxy, keyController, pk.Subspace(controllertypes.SubModuleName),
+		chain.channels, &chain.portKeeper, scopedController, msgRouter)
+	chain.hostKeeper = hostkeeper.NewKeeper(proxy, keyHost, pk.Subspace(hosttypes.SubModuleName),
+		chain.channels, &chain.portKeeper, chain.accountKeeper, chain.evm, scopedHost, msgRouter)
+
+	chain.handler = ica.NewHandler(chain.controllerKeeper)
+	msgRouter.AddRoute(host.RouterKey, chain.channels.handleMsg).
+		AddRoute(bank.RouterKey, bank.NewHandler(bankKeeper)).
+		AddRoute(icatypes.RouterKey, chain.handler)
+
+	ibcRouter := porttypes.NewRouter()
+	ibcRouter.AddRoute(controllertypes.SubModuleName, controller.NewIBCModule(chain.controllerKeeper))
+	ibcRouter.AddRoute(hosttypes.SubModuleName, icahost.NewIBCModule(chain.hostKeeper))
+	ibcRouter.Seal()
+	chain.portKeeper.Router = ibcRouter
+
+	chain.controllerKeeper.InitGenesis(ctx, controllertypes.NewGenesisState(nil, nil, nil, controllertypes.NewParams(true)))
+	hostGenesis := hosttypes.DefaultGenesisState()
+	hostGenesis.Params = hosttypes.NewParams(true, hosttypes.DefaultAllowMessages)
+	chain.hostKeeper.InitGenesis(ctx, hostGenesis)
+
+	return chain
+}
+
+// cbs returns the IBC module callbacks bound to the port through the port keeper and the router
+func (chain *testChain) cbs(ctx sdk.Context, portID string) porttypes.IBCModule {
+	module, _, err := chain.portKeeper.LookupModuleByPort(ctx, portID)
+	require.NoError(chain.t, err)
+	cbs, ok := chain.portKeeper.Router.GetRoute(module)
+	require.True(chain.t, ok)
+	return cbs
+}
+
+// deliver executes the msg with the interchain accounts handler, all the state changes are reverted on error
+func (chain *testChain) deliver(msg sdk.Msg) (*sdk.Result, error) {
+	cacheCtx, write := chain.ctx.CacheContext()
+	res, err := chain.handler(cacheCtx, msg)
+	if err == nil {
+		write()
+	}
+	return res, err
+}
+
+func (chain *testChain) fund(addr sdk.AccAddress, amount int64) {
+	_, err := chain.bankKeeper.AddCoins(chain.ctx, addr, sdk.NewCoins(sdk.NewInt64Coin(testDenom, amount)))
+	require.NoError(chain.t, err)
+}
+
+func (chain *testChain) balance(addr sdk.AccAddress) sdk.Int {
+	return chain.bankKeeper.GetCoins(chain.ctx, addr).AmountOf(testDenom).TruncateInt()
+}
+
+// testChannelKeeper stores the channel ends of a testChain and the packets sent through them. The handshake
+// and packet messages are executed against the callbacks of the ports like the core IBC handler does.
+type testChannelKeeper struct {
+	chain    *testChain
+	channels map[string]channeltypes.Channel
+	nextSend map[string]uint64
+	sent     []channeltypes.Packet
+	count    uint64
+}
+
+func newTestChannelKeeper(chain *testChain) *testChannelKeeper {
+	return &testChannelKeeper{
+		chain:    chain,
+		channels: make(map[string]channeltypes.Channel),
+		nextSend: make(map[string]uint64),
+	}
+}
+
+func (k *testChannelKeeper) GetChannel(_ sdk.Context, portID, channelID string) (channeltypes.Channel, bool) {
+	ch, found := k.channels[host.ChannelPath(portID, channelID)]
+	return ch, found
+}
+
+func (k *testChannelKeeper) GetNextSequenceSend(_ sdk.Context, portID, channelID string) (uint64, bool) {
+	seq, found := k.nextSend[host.ChannelPath(portID, channelID)]
+	return seq, found
+}
+
+func (k *testChannelKeeper) SendPacket(ctx sdk.Context, chanCap *capabilitytypes.Capability, packet exported.PacketI) error {
+	capName := host.ChannelCapabilityPath(packet.GetSourcePort(), packet.GetSourceChannel())
+	if !k.chain.scopedIBC.AuthenticateCapability(ctx, chanCap, capName) {
+		return sdkerrors.Wrap(channeltypes.ErrChannelCapabilityNotFound, capName)
+	}
+
+	ch, found := k.GetChannel(ctx, packet.GetSourcePort(), packet.GetSourceChannel())
+	if !found || ch.State != channeltypes.OPEN {
+		return channeltypes.ErrInvalidChannelState
+	}
+
+	path := host.ChannelPath(packet.GetSourcePort(), packet.GetSourceChannel())
+	if packet.GetSequence() != k.nextSend[path] {
+		return channeltypes.ErrPacketSequenceOutOfOrder
+	}
+	k.nextSend[path]++
+	k.sent = append(k.sent, packet.(channeltypes.Packet))
+	return nil
+}
+
+// handleMsg handles the MsgChannelOpenInit routed by the controller keeper
+func (k *testChannelKeeper) handleMsg(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+	openInit, ok := msg.(*channeltypes.MsgChannelOpenInit)
+	if !ok {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unexpected msg %T", msg)
+	}
+
+	channelID, chanCap, err := k.newChannelEnd(ctx, openInit.PortId, openInit.Channel)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := openInit.Channel
+	if err := k.chain.cbs(ctx, openInit.PortId).OnChanOpenInit(ctx, ch.Ordering, ch.ConnectionHops, openInit.PortId,
+		channelID, chanCap, ch.Counterparty, ch.Version); err != nil {
+		delete(k.channels, host.ChannelPath(openInit.PortId, channelID))
+		return nil, err
+	}
+
+	return &sdk.Result{Data: []byte(channelID), Events: ctx.EventManager().Events()}, nil
+}
+
+func (k *testChannelKeeper) newChannelEnd(ctx sdk.Context, portID string, ch channeltypes.Channel) (string, *capabilitytypes.Capability, error) {
+	if _, _, err := k.chain.portKeeper.LookupModuleByPort(ctx, portID); err != nil {
+		return "", nil, err
+	}
+
+	channelID := channeltypes.FormatChannelIdentifier(k.count)
+	chanCap, err := k.chain.scopedIBC.NewCapability(ctx, host.ChannelCapabilityPath(portID, channelID))
+	if err != nil {
+		return "", nil, err
+	}
+	k.count++
+	k.channels[host.ChannelPath(portID, channelID)] = ch
+	k.nextSend[host.ChannelPath(portID, channelID)] = 1
+	return channelID, chanCap, nil
+}
+
+func (k *testChannelKeeper) setState(portID, channelID string, state channeltypes.State) {
+	path := host.ChannelPath(portID, channelID)
+	ch := k.channels[path]
+	ch.State = state
+	k.channels[path] = ch
+}
+
+// testEvmKeeper records the evm calls executed by the host
+type testEvmKeeper struct {
+	calls []testEvmCall
+	err   error
+}
+
+type testEvmCall struct {
+	from common.Address
+	to   *common.Address
+	data []byte
+}
+
+func (k *testEvmKeeper) CallEvm(_ sdk.Context, from common.Address, to *common.Address, _ *big.Int, data []byte) ([]byte, error) {
+	if k.err != nil {
+		return nil, k.err
+	}
+	k.calls = append(k.calls, testEvmCall{from: from, to: to, data: data})
+	return []byte("evm-ret"), nil
+}
+
+// coordinator relays the handshake and packets between a controller and a host chain
+type coordinator struct {
+	t          *testing.T
+	controller *testChain
+	host       *testChain
+}
+
+func newCoordinator(t *testing.T) *coordinator {
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(1)
+	t.Cleanup(func() { tmtypes.UnittestOnlySetMilestoneVenus2Height(0) })
+	return &coordinator{
+		t:          t,
+		controller: newTestChain(t, "connection-0"),
+		host:       newTestChain(t, "connection-1"),
+	}
+}
+
+// registerInterchainAccount executes MsgRegisterInterchainAccount on the controller and relays the
+// channel handshake to the host, returning the controller port and the ids of both channel ends
+func (c *coordinator) registerInterchainAccount(owner sdk.AccAddress) (portID, controllerChannelID, hostChannelID string) {
+	_, err := c.controller.deliver(controllertypes.NewMsgRegisterInterchainAccount(owner, c.controller.connectionID))
+	require.NoError(c.t, err)
+
+	portID, err = icatypes.NewControllerPortID(owner.String())
+	require.NoError(c.t, err)
+	controllerChannelID = channeltypes.FormatChannelIdentifier(c.controller.channels.count - 1)
+
+	hostChannelID, err = c.chanOpenTry(portID, controllerChannelID)
+	require.NoError(c.t, err)
+	require.NoError(c.t, c.chanOpenAck(portID, controllerChannelID, hostChannelID))
+	require.NoError(c.t, c.chanOpenConfirm(hostChannelID))
+	return
+}
+
+func (c *coordinator) chanOpenTry(controllerPortID, controllerChannelID string) (string, error) {
+	ctx, write := c.host.ctx.CacheContext()
+	counterpartyCh, _ := c.controller.channels.GetChannel(c.controller.ctx, controllerPortID, controllerChannelID)
+	counterparty := channeltypes.NewCounterparty(controllerPortID, controllerChannelID)
+	cbs := c.host.cbs(ctx, icatypes.PortID)
+
+	version, err := cbs.NegotiateAppVersion(ctx, counterpartyCh.Ordering, c.host.connectionID, icatypes.PortID, counterparty, counterpartyCh.Version)
+	if err != nil {
+		return "", err
+	}
+
+	ch := channeltypes.NewChannel(channeltypes.TRYOPEN, counterpartyCh.Ordering, counterparty, []string{c.host.connectionID}, version)
+	channelID, chanCap, err := c.host.channels.newChannelEnd(ctx, icatypes.PortID, ch)
+	if err != nil {
+		return "", err
+	}
+
+	if err := cbs.OnChanOpenTry(ctx, ch.Ordering, ch.ConnectionHops, icatypes.PortID, channelID, chanCap,
+		counterparty, version, counterpartyCh.Version); err != nil {
+		delete(c.host.channels.channels, host.ChannelPath(icatypes.PortID, channelID))
+		return "", err
+	}
+
+	write()
+	return channelID, nil
+}
+
+func (c *coordinator) chanOpenAck(controllerPortID, controllerChannelID, hostChannelID string) error {
+	hostCh, _ := c.host.channels.GetChannel(c.host.ctx, icatypes.PortID, hostChannelID)
+	ctx, write := c.controller.ctx.CacheContext()
+	if err := c.controller.cbs(ctx, controllerPortID).OnChanOpenAck(ctx, controllerPortID, controllerChannelID, hostCh.Version); err != nil {
+		return err
+	}
+
+	path := host.ChannelPath(controllerPortID, controllerChannelID)
+	ch := c.controller.channels.channels[path]
+	ch.State = channeltypes.OPEN
+	ch.Version = hostCh.Version
+	ch.Counterparty.ChannelId = hostChannelID
+	c.controller.channels.channels[path] = ch
+	write()
+	return nil
+}
+
+func (c *coordinator) chanOpenConfirm(hostChannelID string) error {
+	ctx, write := c.host.ctx.CacheContext()
+	c.host.channels.setState(icatypes.PortID, hostChannelID, channeltypes.OPEN)
+	if err := c.host.cbs(ctx, icatypes.PortID).OnChanOpenConfirm(ctx, icatypes.PortID, hostChannelID); err != nil {
+		c.host.channels.setState(icatypes.PortID, hostChannelID, channeltypes.TRYOPEN)
+		return err
+	}
+	write()
+	return nil
+}
+
+// relayPacket delivers the last packet sent by the controller to the host and the acknowledgement back to
+// the controller. Like the core IBC handler, the host state changes are discarded on an error acknowledgement.
+func (c *coordinator) relayPacket() channeltypes.Acknowledgement {
+	sent := c.controller.channels.sent
+	require.NotEmpty(c.t, sent)
+	packet := sent[len(sent)-1]
+
+	hostCtx, write := c.host.ctx.CacheContext()
+	ack := c.host.cbs(hostCtx, packet.DestinationPort).OnRecvPacket(hostCtx, packet, nil)
+	if ack.Success() {
+		write()
+	}
+	c.host.ctx.EventManager().EmitEvents(hostCtx.EventManager().Events())
+
+	require.NoError(c.t, c.controller.cbs(c.controller.ctx, packet.SourcePort).OnAcknowledgementPacket(c.controller.ctx, packet, ack.Acknowledgement(), nil))
+
+	var res channeltypes.Acknowledgement
+	require.NoError(c.t, channeltypes.SubModuleCdc.UnmarshalJSON(ack.Acknowledgement(), &res))
+	return res
+}
+
+// timeoutPacket times out the last packet sent by the controller, which closes the ORDERED channel on
+// both chains
+func (c *coordinator) timeoutPacket() {
+	sent := c.controller.channels.sent
+	require.NotEmpty(c.t, sent)
+	packet := sent[len(sent)-1]
+
+	c.controller.channels.setState(packet.SourcePort, packet.SourceChannel, channeltypes.CLOSED)
+	require.NoError(c.t, c.controller.cbs(c.controller.ctx, packet.SourcePort).OnTimeoutPacket(c.controller.ctx, packet, nil))
+
+	c.host.channels.setState(packet.DestinationPort, packet.DestinationChannel, channeltypes.CLOSED)
+	require.NoError(c.t, c.host.cbs(c.host.ctx, packet.DestinationPort).OnChanCloseConfirm(c.host.ctx, packet.DestinationPort, packet.DestinationChannel))
+}
+
+func (c *coordinator) sendTx(owner sdk.AccAddress, msgs ...sdk.Msg) error {
+	data, err := icatypes.SerializeCosmosTx(c.controller.cdc.GetCdc(), msgs)
+	require.NoError(c.t, err)
+
+	packetData := icatypes.NewInterchainAccountPacketData(icatypes.TypeExecuteTx, data, "")
+	_, err = c.controller.deliver(controllertypes.NewMsgSendTx(owner, c.controller.connectionID, packetData, 0))
+	return err
+}
+
+func (c *coordinator) interchainAccount(owner sdk.AccAddress) sdk.AccAddress {
+	portID, err := icatypes.NewControllerPortID(owner.String())
+	require.NoError(c.t, err)
+
+	addr, found := c.controller.controllerKeeper.GetInterchainAccountAddress(c.controller.ctx, c.controller.connectionID, portID)
+	require.True(c.t, found, fmt.Sprintf("interchain account of %s not found", owner))
+	accAddr, err := sdk.AccAddressFromBech32(addr)
+	require.NoError(c.t, err)
+	return accAddr
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client"
+	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	"github.com/okex/exchain/libs/cosmos-sdk/client/flags"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/controller/types"
+	icatypes "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/types"
+	"github.com/spf13/cobra"
+)
+
+// GetQueryCmd returns the query commands for the interchain accounts controller
+func GetQueryCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        icatypes.ModuleName,
+		Short:                      "Querying commands for the interchain accounts module",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+	cmd.AddCommand(flags.GetCommands(
+		GetCmdQueryInterchainAccount(cdc),
+		GetCmdQueryParams(cdc),
+	)...)
+	return cmd
+}
+
+// GetCmdQueryInterchainAccount implements the command to query the interchain account address of an owner
+func GetCmdQueryInterchainAccount(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "interchain-account [owner] [connection-id]",
+		Short: "Query the interchain account address of an owner on the host chain of the connection",
+		Long: strings.TrimSpace(`Query the interchain account address of an owner on the host chain of the connection:
+
+$ exchaincli query interchainaccounts interchain-account ex1... connection-0
+`),
+		Args: cobra.ExactArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			owner, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryInterchainAccountParams(owner, args[1]))
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", icatypes.QuerierRoute, types.QueryInterchainAccount)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var resp types.QueryInterchainAccountResponse
+			cdc.MustUnmarshalJSON(res, &resp)
+			return cliCtx.PrintOutput(resp)
+		},
+	}
+}
+
+// GetCmdQueryParams implements the command to query the parameters of the interchain accounts controller
+func GetCmdQueryParams(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "params",
+		Short: "Query the parameters of the interchain accounts controller",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			route := fmt.Sprintf("custom/%s/%s", icatypes.QuerierRoute, types.QueryParams)
+			bz, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var params types.Params
+			cdc.MustUnmarshalJSON(bz, &params)
+			return cliCtx.PrintOutput(params)
+		},
+	}
+}
@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client"
+	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	"github.com/okex/exchain/libs/cosmos-sdk/client/flags"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/version"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth/client/utils"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/controller/types"
+	icatypes "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/types"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagMemo            = "packet-memo"
+	flagRelativeTimeout = "relative-packet-timeout"
+)
+
+// GetTxCmd returns the transaction commands for the interchain accounts controller
+func GetTxCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        icatypes.ModuleName,
+		Short:                      "Interchain accounts transaction subcommands",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+	cmd.AddCommand(flags.PostCommands(
+		GetCmdRegisterInterchainAccount(cdc),
+		GetCmdSendTx(cdc),
+	)...)
+	return cmd
+}
+
+// GetCmdRegisterInterchainAccount implements the command to register an interchain account of the sender
+func GetCmdRegisterInterchainAccount(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "register [connection-id]",
+		Short: "Register an interchain account on the host chain of the connection",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Register an interchain account owned by the sender on the host chain of the connection.
+
+Example:
+$ %s tx %s register connection-0 --from=<key_or_address>
+`, version.ClientName, icatypes.ModuleName,
+			)),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgRegisterInterchainAccount(cliCtx.GetFromAddress(), args[0])
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdSendTx implements the command to execute msgs with the interchain account of the sender
+func GetCmdSendTx(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "send-tx [connection-id] [path/to/msgs.json]",
+		Short: "Execute msgs with the interchain account of the sender on the host chain",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Execute the JSON encoded msgs of the file with the interchain account of the sender
+on the host chain of the connection. The file must contain a JSON array of amino encoded msgs whose
+signer is the interchain account:
+
+[
+  {
+    "type": "okexchain/interchainaccounts/MsgEvmCall",
+    "value": {
+      "from": "ex1...",
+      "to": "0x...",
+      "value": "0",
+      "data": "..."
+    }
+  }
+]
+
+Example:
+$ %s tx %s send-tx connection-0 msgs.json --from=<key_or_address>
+`, version.ClientName, icatypes.ModuleName,
+			)),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			contents, err := ioutil.ReadFile(args[1])
+			if err != nil {
+				return err
+			}
+
+			var msgs []sdk.Msg
+			if err := cdc.UnmarshalJSON(contents, &msgs); err != nil {
+				return err
+			}
+
+			data, err := icatypes.SerializeCosmosTx(cdc, msgs)
+			if err != nil {
+				return err
+			}
+
+			memo, err := cmd.Flags().GetString(flagMemo)
+			if err != nil {
+				return err
+			}
+
+			relativeTimeout, err := cmd.Flags().GetUint64(flagRelativeTimeout)
+			if err != nil {
+				return err
+			}
+
+			packetData := icatypes.NewInterchainAccountPacketData(icatypes.TypeExecuteTx, data, memo)
+			msg := types.NewMsgSendTx(cliCtx.GetFromAddress(), args[0], packetData, relativeTimeout)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().String(flagMemo, "", "memo of the interchain account packet")
+	cmd.Flags().Uint64(flagRelativeTimeout, types.DefaultRelativePacketTimeoutTimestamp,
+		"packet timeout timestamp in nanoseconds relative to the current block timestamp")
+
+	return cmd
+}
@@ -0,0 +1,57 @@
+package keeper
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	icatypes "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/types"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+)
+
+// RegisterInterchainAccount is the entry point to registering an interchain account. It binds the controller port
+// of the owner and routes a new MsgChannelOpenInit to the host port through the msg router, which executes
+// the OnChanOpenInit callback of the controller.
+// An error is returned if the controller port already has an open channel over the connection, while an account
+// whose channel is closed by a packet timeout regains access to its interchain account with a new channel.
+func (k Keeper) RegisterInterchainAccount(ctx sdk.Context, connectionID string, owner sdk.AccAddress) error {
+	portID, err := icatypes.NewControllerPortID(owner.String())
+	if err != nil {
+		return err
+	}
+
+	if !k.IsBound(ctx, portID) {
+		if err := k.BindPort(ctx, portID); err != nil {
+			return sdkerrors.Wrap(err, "unable to bind to newly generated portID")
+		}
+	}
+
+	if k.hasOpenActiveChannel(ctx, connectionID, portID) {
+		return sdkerrors.Wrapf(icatypes.ErrActiveChannelAlreadySet, "existing active channel for portID %s", portID)
+	}
+
+	msg := channeltypes.NewMsgChannelOpenInit(portID, icatypes.Version, channeltypes.ORDERED, []string{connectionID}, icatypes.PortID, owner)
+	handler := k.msgRouter.Route(ctx, msg.Route())
+	if handler == nil {
+		return icatypes.ErrInvalidRoute
+	}
+
+	res, err := handler(ctx, msg)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: The sdk msg handler creates a new EventManager, so events must be correctly propagated back to the current context
+	ctx.EventManager().EmitEvents(res.Events)
+
+	return nil
+}
+
+// hasOpenActiveChannel returns true if the active channel of the connectionID and portID is still open
+func (k Keeper) hasOpenActiveChannel(ctx sdk.Context, connectionID, portID string) bool {
+	channelID, found := k.GetActiveChannelID(ctx, connectionID, portID)
+	if !found {
+		return false
+	}
+
+	channel, found := k.channelKeeper.GetChannel(ctx, portID, channelID)
+	return found && channel.State == channeltypes.OPEN
+}
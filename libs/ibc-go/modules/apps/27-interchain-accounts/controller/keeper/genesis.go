@@ -0,0 +1,39 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/controller/types"
+)
+
+// InitGenesis initializes the interchain accounts controller submodule state and binds to the controller ports.
+func (k Keeper) InitGenesis(ctx sdk.Context, state types.GenesisState) {
+	for _, portID := range state.Ports {
+		if !k.IsBound(ctx, portID) {
+			if err := k.BindPort(ctx, portID); err != nil {
+				panic(fmt.Sprintf("could not claim port capability: %v", err))
+			}
+		}
+	}
+
+	for _, ch := range state.ActiveChannels {
+		k.SetActiveChannelID(ctx, ch.ConnectionID, ch.PortID, ch.ChannelID)
+	}
+
+	for _, acc := range state.InterchainAccounts {
+		k.SetInterchainAccountAddress(ctx, acc.ConnectionID, acc.PortID, acc.AccountAddress)
+	}
+
+	k.SetParams(ctx, state.Params)
+}
+
+// ExportGenesis exports the interchain accounts controller submodule state.
+func (k Keeper) ExportGenesis(ctx sdk.Context) types.GenesisState {
+	return types.NewGenesisState(
+		k.GetAllActiveChannels(ctx),
+		k.GetAllInterchainAccounts(ctx),
+		k.GetAllPorts(ctx),
+		k.GetParams(ctx),
+	)
+}
@@ -0,0 +1,43 @@
+package keeper
+
+import (
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/controller/types"
+	icatypes "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/types"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+)
+
+// NewQuerier creates a querier for the interchain accounts controller submodule
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+		switch path[0] {
+		case types.QueryInterchainAccount:
+			return queryInterchainAccount(ctx, req, k)
+		case types.QueryParams:
+			return codec.MarshalJSONIndent(types.ModuleCdc, k.GetParams(ctx))
+		default:
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unknown %s query endpoint: %s", icatypes.ModuleName, path[0])
+		}
+	}
+}
+
+func queryInterchainAccount(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryInterchainAccountParams
+	if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	portID, err := icatypes.NewControllerPortID(params.Owner.String())
+	if err != nil {
+		return nil, err
+	}
+
+	addr, found := k.GetInterchainAccountAddress(ctx, params.ConnectionID, portID)
+	if !found {
+		return nil, sdkerrors.Wrapf(icatypes.ErrInterchainAccountNotFound, "failed to retrieve account address for %s on connection %s", portID, params.ConnectionID)
+	}
+
+	return codec.MarshalJSONIndent(types.ModuleCdc, types.QueryInterchainAccountResponse{Address: addr})
+}
@@ -0,0 +1,74 @@
+package keeper
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	icatypes "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/types"
+	clienttypes "github.com/okex/exchain/libs/ibc-go/modules/core/02-client/types"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+	host "github.com/okex/exchain/libs/ibc-go/modules/core/24-host"
+)
+
+// SendTx takes pre-built packet data containing the msgs to be executed on the host chain from the authentication
+// module and attempts to send the packet over the active channel of the controller port.
+// The packet sequence for the outgoing packet is returned as a result.
+func (k Keeper) SendTx(ctx sdk.Context, connectionID, portID string, packetData icatypes.InterchainAccountPacketData,
+	timeoutTimestamp uint64) (uint64, error) {
+	activeChannelID, found := k.GetActiveChannelID(ctx, connectionID, portID)
+	if !found {
+		return 0, sdkerrors.Wrapf(icatypes.ErrActiveChannelNotFound, "failed to retrieve active channel on connection %s for port %s", connectionID, portID)
+	}
+
+	chanCap, found := k.scopedKeeper.GetCapability(ctx, host.ChannelCapabilityPath(portID, activeChannelID))
+	if !found {
+		return 0, sdkerrors.Wrap(channeltypes.ErrChannelCapabilityNotFound, "module does not own channel capability")
+	}
+
+	channel, found := k.channelKeeper.GetChannel(ctx, portID, activeChannelID)
+	if !found {
+		return 0, sdkerrors.Wrapf(channeltypes.ErrChannelNotFound, "port ID (%s) channel ID (%s)", portID, activeChannelID)
+	}
+
+	if uint64(ctx.BlockTime().UnixNano()) >= timeoutTimestamp {
+		return 0, icatypes.ErrInvalidTimeoutTimestamp
+	}
+
+	if err := packetData.ValidateBasic(); err != nil {
+		return 0, sdkerrors.Wrap(err, "invalid interchain account packet data")
+	}
+
+	// get the next sequence
+	sequence, found := k.channelKeeper.GetNextSequenceSend(ctx, portID, activeChannelID)
+	if !found {
+		return 0, sdkerrors.Wrapf(
+			channeltypes.ErrSequenceSendNotFound,
+			"source port: %s, source channel: %s", portID, activeChannelID,
+		)
+	}
+
+	packet := channeltypes.NewPacket(
+		packetData.GetBytes(),
+		sequence,
+		portID,
+		activeChannelID,
+		channel.Counterparty.PortId,
+		channel.Counterparty.ChannelId,
+		clienttypes.ZeroHeight(),
+		timeoutTimestamp,
+	)
+
+	if err := k.channelKeeper.SendPacket(ctx, chanCap, packet); err != nil {
+		return 0, err
+	}
+
+	return sequence, nil
+}
+
+// OnTimeoutPacket is called on the controller chain when a packet sent by SendTx times out. Since the
+// channel is ORDERED, it has been closed by the timeout and the owner needs to register again to regain access
+// to the interchain account with a new channel.
+func (k Keeper) OnTimeoutPacket(ctx sdk.Context, packet channeltypes.Packet) error {
+	k.Logger(ctx).Info("interchain accounts packet timed out, the channel is closed",
+		"port-id", packet.SourcePort, "channel-id", packet.SourceChannel, "sequence", packet.Sequence)
+	return nil
+}
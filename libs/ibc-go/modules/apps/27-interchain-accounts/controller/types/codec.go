@@ -0,0 +1,19 @@
+package types
+
+import (
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+)
+
+// RegisterCodec registers the interchain accounts controller msgs on the provided amino codec
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgRegisterInterchainAccount{}, "okexchain/icacontroller/MsgRegisterInterchainAccount", nil)
+	cdc.RegisterConcrete(MsgSendTx{}, "okexchain/icacontroller/MsgSendTx", nil)
+}
+
+// ModuleCdc references the global interchain accounts controller codec
+var ModuleCdc = codec.New()
+
+func init() {
+	RegisterCodec(ModuleCdc)
+	ModuleCdc.Seal()
+}
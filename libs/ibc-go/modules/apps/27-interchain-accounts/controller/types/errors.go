@@ -0,0 +1,8 @@
+package types
+
+import sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+
+// interchain accounts controller sentinel errors
+var (
+	ErrControllerSubModuleDisabled = sdkerrors.Register(SubModuleName, 2, "controller submodule is disabled")
+)
@@ -0,0 +1,53 @@
+package types
+
+import (
+	icatypes "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/types"
+	host "github.com/okex/exchain/libs/ibc-go/modules/core/24-host"
+)
+
+// GenesisState defines the interchain accounts controller genesis state
+type GenesisState struct {
+	ActiveChannels     []icatypes.ActiveChannel               `json:"active_channels" yaml:"active_channels"`
+	InterchainAccounts []icatypes.RegisteredInterchainAccount `json:"interchain_accounts" yaml:"interchain_accounts"`
+	Ports              []string                               `json:"ports" yaml:"ports"`
+	Params             Params                                 `json:"params" yaml:"params"`
+}
+
+// NewGenesisState creates a new interchain accounts controller GenesisState instance
+func NewGenesisState(channels []icatypes.ActiveChannel, accounts []icatypes.RegisteredInterchainAccount,
+	ports []string, params Params) GenesisState {
+	return GenesisState{
+		ActiveChannels:     channels,
+		InterchainAccounts: accounts,
+		Ports:              ports,
+		Params:             params,
+	}
+}
+
+// DefaultGenesisState returns a GenesisState without any controller port
+func DefaultGenesisState() GenesisState {
+	return NewGenesisState(nil, nil, nil, DefaultParams())
+}
+
+// Validate performs basic genesis state validation returning an error upon any failure
+func (gs GenesisState) Validate() error {
+	for _, ch := range gs.ActiveChannels {
+		if err := ch.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, acc := range gs.InterchainAccounts {
+		if err := acc.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, port := range gs.Ports {
+		if err := host.PortIdentifierValidator(port); err != nil {
+			return err
+		}
+	}
+
+	return gs.Params.Validate()
+}
@@ -0,0 +1,15 @@
+package types
+
+import "time"
+
+const (
+	// SubModuleName defines the interchain accounts controller submodule name
+	SubModuleName = "icacontroller"
+
+	// StoreKey is the store key string for the interchain accounts controller submodule
+	StoreKey = SubModuleName
+)
+
+// DefaultRelativePacketTimeoutTimestamp is the default packet timeout timestamp (in nanoseconds)
+// relative to the current block timestamp of the controller chain
+var DefaultRelativePacketTimeoutTimestamp = uint64((time.Duration(10) * time.Minute).Nanoseconds())
@@ -0,0 +1,113 @@
+package types
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	icatypes "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/types"
+	host "github.com/okex/exchain/libs/ibc-go/modules/core/24-host"
+)
+
+const (
+	// TypeMsgRegisterInterchainAccount defines the type string of MsgRegisterInterchainAccount
+	TypeMsgRegisterInterchainAccount = "register_interchain_account"
+	// TypeMsgSendTx defines the type string of MsgSendTx
+	TypeMsgSendTx = "send_tx"
+)
+
+var (
+	_ sdk.Msg = MsgRegisterInterchainAccount{}
+	_ sdk.Msg = MsgSendTx{}
+)
+
+// MsgRegisterInterchainAccount registers an interchain account of the owner on the host chain of the connection
+type MsgRegisterInterchainAccount struct {
+	Owner        sdk.AccAddress `json:"owner" yaml:"owner"`
+	ConnectionID string         `json:"connection_id" yaml:"connection_id"`
+}
+
+// NewMsgRegisterInterchainAccount creates a new MsgRegisterInterchainAccount instance
+func NewMsgRegisterInterchainAccount(owner sdk.AccAddress, connectionID string) MsgRegisterInterchainAccount {
+	return MsgRegisterInterchainAccount{
+		Owner:        owner,
+		ConnectionID: connectionID,
+	}
+}
+
+// Route implements sdk.Msg
+func (msg MsgRegisterInterchainAccount) Route() string { return icatypes.RouterKey }
+
+// Type implements sdk.Msg
+func (msg MsgRegisterInterchainAccount) Type() string { return TypeMsgRegisterInterchainAccount }
+
+// GetSigners implements sdk.Msg
+func (msg MsgRegisterInterchainAccount) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgRegisterInterchainAccount) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgRegisterInterchainAccount) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing owner address")
+	}
+
+	if err := host.ConnectionIdentifierValidator(msg.ConnectionID); err != nil {
+		return sdkerrors.Wrap(err, "invalid connection ID")
+	}
+
+	_, err := icatypes.NewControllerPortID(msg.Owner.String())
+	return err
+}
+
+// MsgSendTx sends the packet data to be executed by the interchain account of the owner on the host chain.
+// The packet times out after RelativeTimeout nanoseconds since the block time, or the default timeout if it's zero.
+type MsgSendTx struct {
+	Owner           sdk.AccAddress                       `json:"owner" yaml:"owner"`
+	ConnectionID    string                               `json:"connection_id" yaml:"connection_id"`
+	PacketData      icatypes.InterchainAccountPacketData `json:"packet_data" yaml:"packet_data"`
+	RelativeTimeout uint64                               `json:"relative_timeout" yaml:"relative_timeout"`
+}
+
+// NewMsgSendTx creates a new MsgSendTx instance
+func NewMsgSendTx(owner sdk.AccAddress, connectionID string, packetData icatypes.InterchainAccountPacketData,
+	relativeTimeout uint64) MsgSendTx {
+	return MsgSendTx{
+		Owner:           owner,
+		ConnectionID:    connectionID,
+		PacketData:      packetData,
+		RelativeTimeout: relativeTimeout,
+	}
+}
+
+// Route implements sdk.Msg
+func (msg MsgSendTx) Route() string { return icatypes.RouterKey }
+
+// Type implements sdk.Msg
+func (msg MsgSendTx) Type() string { return TypeMsgSendTx }
+
+// GetSigners implements sdk.Msg
+func (msg MsgSendTx) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgSendTx) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgSendTx) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing owner address")
+	}
+
+	if err := host.ConnectionIdentifierValidator(msg.ConnectionID); err != nil {
+		return sdkerrors.Wrap(err, "invalid connection ID")
+	}
+
+	return msg.PacketData.ValidateBasic()
+}
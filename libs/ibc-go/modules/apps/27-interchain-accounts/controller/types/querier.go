@@ -0,0 +1,28 @@
+package types
+
+import sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+
+// querier keys
+const (
+	QueryInterchainAccount = "interchain_account"
+	QueryParams            = "params"
+)
+
+// QueryInterchainAccountParams defines the params for querying the interchain account address of an owner
+type QueryInterchainAccountParams struct {
+	Owner        sdk.AccAddress `json:"owner" yaml:"owner"`
+	ConnectionID string         `json:"connection_id" yaml:"connection_id"`
+}
+
+// NewQueryInterchainAccountParams creates a new QueryInterchainAccountParams instance
+func NewQueryInterchainAccountParams(owner sdk.AccAddress, connectionID string) QueryInterchainAccountParams {
+	return QueryInterchainAccountParams{
+		Owner:        owner,
+		ConnectionID: connectionID,
+	}
+}
+
+// QueryInterchainAccountResponse is the response type of the interchain account query
+type QueryInterchainAccountResponse struct {
+	Address string `json:"address" yaml:"address"`
+}
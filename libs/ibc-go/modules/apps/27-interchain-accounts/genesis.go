@@ -0,0 +1,34 @@
+package ica
+
+import (
+	controllertypes "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/controller/types"
+	hosttypes "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/host/types"
+)
+
+// GenesisState defines the interchain accounts genesis state of both submodules
+type GenesisState struct {
+	ControllerGenesisState controllertypes.GenesisState `json:"controller_genesis_state" yaml:"controller_genesis_state"`
+	HostGenesisState       hosttypes.GenesisState       `json:"host_genesis_state" yaml:"host_genesis_state"`
+}
+
+// NewGenesisState creates a new interchain accounts GenesisState instance
+func NewGenesisState(controllerGenesisState controllertypes.GenesisState, hostGenesisState hosttypes.GenesisState) GenesisState {
+	return GenesisState{
+		ControllerGenesisState: controllerGenesisState,
+		HostGenesisState:       hostGenesisState,
+	}
+}
+
+// DefaultGenesisState returns the default genesis state of both submodules
+func DefaultGenesisState() GenesisState {
+	return NewGenesisState(controllertypes.DefaultGenesisState(), hosttypes.DefaultGenesisState())
+}
+
+// Validate performs basic genesis state validation returning an error upon any failure
+func (gs GenesisState) Validate() error {
+	if err := gs.ControllerGenesisState.Validate(); err != nil {
+		return err
+	}
+
+	return gs.HostGenesisState.Validate()
+}
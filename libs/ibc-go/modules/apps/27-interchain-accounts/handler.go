@@ -0,0 +1,88 @@
+package ica
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/controller/keeper"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/controller/types"
+	icatypes "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+)
+
+// NewHandler returns sdk.Handler for the interchain accounts controller messages
+func NewHandler(k keeper.Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		ctx.SetEventManager(sdk.NewEventManager())
+
+		if !tmtypes.HigherThanVenus2(ctx.BlockHeight()) {
+			return nil, icatypes.ErrDisabled
+		}
+
+		if !k.GetControllerEnabled(ctx) {
+			return nil, types.ErrControllerSubModuleDisabled
+		}
+
+		switch msg := msg.(type) {
+		case types.MsgRegisterInterchainAccount:
+			return handleMsgRegisterInterchainAccount(ctx, k, msg)
+
+		case types.MsgSendTx:
+			return handleMsgSendTx(ctx, k, msg)
+
+		case icatypes.MsgEvmCall:
+			return nil, sdkerrors.Wrap(icatypes.ErrUnsupported, "evm call can only be executed by an interchain account through MsgSendTx")
+
+		default:
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized ICS-27 interchain accounts message type: %T", msg)
+		}
+	}
+}
+
+func handleMsgRegisterInterchainAccount(ctx sdk.Context, k keeper.Keeper, msg types.MsgRegisterInterchainAccount) (*sdk.Result, error) {
+	if err := k.RegisterInterchainAccount(ctx, msg.ConnectionID, msg.Owner); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, icatypes.ModuleName),
+			sdk.NewAttribute(icatypes.AttributeKeyOwner, msg.Owner.String()),
+			sdk.NewAttribute(icatypes.AttributeKeyConnectionID, msg.ConnectionID),
+		),
+	)
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgSendTx(ctx sdk.Context, k keeper.Keeper, msg types.MsgSendTx) (*sdk.Result, error) {
+	portID, err := icatypes.NewControllerPortID(msg.Owner.String())
+	if err != nil {
+		return nil, err
+	}
+
+	relativeTimeout := msg.RelativeTimeout
+	if relativeTimeout == 0 {
+		relativeTimeout = types.DefaultRelativePacketTimeoutTimestamp
+	}
+	timeoutTimestamp := uint64(ctx.BlockTime().UnixNano()) + relativeTimeout
+
+	seq, err := k.SendTx(ctx, msg.ConnectionID, portID, msg.PacketData, timeoutTimestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			icatypes.EventTypeSubmitTx,
+			sdk.NewAttribute(sdk.AttributeKeyModule, icatypes.ModuleName),
+			sdk.NewAttribute(icatypes.AttributeKeyOwner, msg.Owner.String()),
+			sdk.NewAttribute(icatypes.AttributeKeyConnectionID, msg.ConnectionID),
+			sdk.NewAttribute(icatypes.AttributeKeySequence, fmt.Sprintf("%d", seq)),
+		),
+	)
+
+	return &sdk.Result{Data: sdk.Uint64ToBigEndian(seq), Events: ctx.EventManager().Events()}, nil
+}
@@ -0,0 +1,17 @@
+package keeper
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// RegisterInterchainAccount attempts to create a new account using the provided address and
+// stores it keyed by the connection and the controller port.
+// An existing account of the address is reused because nobody owns the private key of the generated address,
+// it only happens when the address is funded before the channel handshake or a closed channel is reopened.
+func (k Keeper) RegisterInterchainAccount(ctx sdk.Context, connectionID, controllerPortID string, accAddress sdk.AccAddress) {
+	if acc := k.accountKeeper.GetAccount(ctx, accAddress); acc == nil {
+		k.accountKeeper.SetAccount(ctx, k.accountKeeper.NewAccountWithAddress(ctx, accAddress))
+	}
+
+	k.SetInterchainAccountAddress(ctx, connectionID, controllerPortID, accAddress.String())
+}
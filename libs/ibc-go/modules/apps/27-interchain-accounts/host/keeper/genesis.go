@@ -0,0 +1,39 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/host/types"
+)
+
+// InitGenesis initializes the interchain accounts host submodule state and binds to the host port.
+func (k Keeper) InitGenesis(ctx sdk.Context, state types.GenesisState) {
+	// Only try to bind to port if it is not already bound, since we may already own
+	// port capability from capability InitGenesis
+	if !k.IsBound(ctx, state.Port) {
+		if err := k.BindPort(ctx, state.Port); err != nil {
+			panic(fmt.Sprintf("could not claim port capability: %v", err))
+		}
+	}
+
+	for _, ch := range state.ActiveChannels {
+		k.SetActiveChannelID(ctx, ch.ConnectionID, ch.PortID, ch.ChannelID)
+	}
+
+	for _, acc := range state.InterchainAccounts {
+		k.SetInterchainAccountAddress(ctx, acc.ConnectionID, acc.PortID, acc.AccountAddress)
+	}
+
+	k.SetParams(ctx, state.Params)
+}
+
+// ExportGenesis exports the interchain accounts host submodule state.
+func (k Keeper) ExportGenesis(ctx sdk.Context) types.GenesisState {
+	return types.NewGenesisState(
+		k.GetAllActiveChannels(ctx),
+		k.GetAllInterchainAccounts(ctx),
+		k.GetPort(ctx),
+		k.GetParams(ctx),
+	)
+}
@@ -0,0 +1,102 @@
+package keeper
+
+import (
+	"strings"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	capabilitytypes "github.com/okex/exchain/libs/cosmos-sdk/x/capability/types"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/host/types"
+	icatypes "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/types"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+	host "github.com/okex/exchain/libs/ibc-go/modules/core/24-host"
+)
+
+// OnChanOpenTry performs basic validation of the ORDERED channel to the host port, registers the interchain account
+// negotiated in the channel version and claims the channel capability.
+// The version must be the one returned by NegotiateAppVersion.
+func (k Keeper) OnChanOpenTry(
+	ctx sdk.Context,
+	order channeltypes.Order,
+	connectionHops []string,
+	portID,
+	channelID string,
+	chanCap *capabilitytypes.Capability,
+	counterparty channeltypes.Counterparty,
+	version,
+	counterpartyVersion string,
+) error {
+	if !k.GetHostEnabled(ctx) {
+		return types.ErrHostSubModuleDisabled
+	}
+
+	if order != channeltypes.ORDERED {
+		return sdkerrors.Wrapf(channeltypes.ErrInvalidChannelOrdering, "expected %s channel, got %s", channeltypes.ORDERED, order)
+	}
+
+	if portID != icatypes.PortID {
+		return sdkerrors.Wrapf(icatypes.ErrInvalidHostPort, "expected %s, got %s", icatypes.PortID, portID)
+	}
+
+	if !strings.HasPrefix(counterparty.PortId, icatypes.PortPrefix) {
+		return sdkerrors.Wrapf(icatypes.ErrInvalidControllerPort, "expected %s{owner-account-address}, got %s", icatypes.PortPrefix, counterparty.PortId)
+	}
+
+	if counterpartyVersion != icatypes.Version {
+		return sdkerrors.Wrapf(icatypes.ErrInvalidVersion, "expected counterparty version %s, got %s", icatypes.Version, counterpartyVersion)
+	}
+
+	connectionID := connectionHops[0]
+	accAddress := icatypes.GenerateAddress(connectionID, counterparty.PortId)
+	if expected := icatypes.NewAppVersion(icatypes.Version, accAddress.String()); version != expected {
+		return sdkerrors.Wrapf(icatypes.ErrInvalidVersion, "expected %s, got %s", expected, version)
+	}
+
+	if activeChannelID, found := k.GetActiveChannelID(ctx, connectionID, counterparty.PortId); found {
+		// the previous channel must be closed, e.g. by a packet timeout, before a new one is opened
+		if channel, found := k.channelKeeper.GetChannel(ctx, portID, activeChannelID); found && channel.State != channeltypes.CLOSED {
+			return sdkerrors.Wrapf(icatypes.ErrActiveChannelAlreadySet, "existing active channel %s for portID %s", activeChannelID, counterparty.PortId)
+		}
+	}
+
+	// On the host chain the capability may only be claimed during the OnChanOpenTry
+	// The capability being claimed in OpenInit is for a controller chain (the port is different)
+	if err := k.ClaimCapability(ctx, chanCap, host.ChannelCapabilityPath(portID, channelID)); err != nil {
+		return err
+	}
+
+	k.RegisterInterchainAccount(ctx, connectionID, counterparty.PortId, accAddress)
+	return nil
+}
+
+// OnChanOpenConfirm completes the handshake process by setting the active channel in state on the host chain
+func (k Keeper) OnChanOpenConfirm(
+	ctx sdk.Context,
+	portID,
+	channelID string,
+) error {
+	channel, found := k.channelKeeper.GetChannel(ctx, portID, channelID)
+	if !found {
+		return sdkerrors.Wrapf(channeltypes.ErrChannelNotFound, "failed to retrieve channel %s on port %s", channelID, portID)
+	}
+
+	// the closed channel of the controller port is replaced once a new one is opened
+	k.SetActiveChannelID(ctx, channel.ConnectionHops[0], channel.Counterparty.PortId, channelID)
+
+	return nil
+}
+
+// OnChanCloseConfirm removes the active channel stored in state
+func (k Keeper) OnChanCloseConfirm(
+	ctx sdk.Context,
+	portID,
+	channelID string,
+) error {
+	channel, found := k.channelKeeper.GetChannel(ctx, portID, channelID)
+	if !found {
+		return sdkerrors.Wrapf(channeltypes.ErrChannelNotFound, "failed to retrieve channel %s on port %s", channelID, portID)
+	}
+
+	k.DeleteActiveChannelID(ctx, channel.ConnectionHops[0], channel.Counterparty.PortId)
+	return nil
+}
@@ -0,0 +1,193 @@
+package keeper
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	capabilitykeeper "github.com/okex/exchain/libs/cosmos-sdk/x/capability/keeper"
+	capabilitytypes "github.com/okex/exchain/libs/cosmos-sdk/x/capability/types"
+	paramtypes "github.com/okex/exchain/libs/cosmos-sdk/x/params"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/host/types"
+	icatypes "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/types"
+	host "github.com/okex/exchain/libs/ibc-go/modules/core/24-host"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+)
+
+// Keeper defines the IBC interchain accounts host keeper
+type Keeper struct {
+	storeKey   sdk.StoreKey
+	cdc        *codec.CodecProxy
+	paramSpace paramtypes.Subspace
+
+	channelKeeper icatypes.ChannelKeeper
+	portKeeper    icatypes.PortKeeper
+	accountKeeper icatypes.AccountKeeper
+	evmKeeper     icatypes.EvmKeeper
+	scopedKeeper  capabilitykeeper.ScopedKeeper
+
+	msgRouter sdk.Router
+}
+
+// NewKeeper creates a new interchain accounts host Keeper instance
+func NewKeeper(
+	proxy *codec.CodecProxy, key sdk.StoreKey, paramSpace paramtypes.Subspace,
+	channelKeeper icatypes.ChannelKeeper, portKeeper icatypes.PortKeeper,
+	accountKeeper icatypes.AccountKeeper, evmKeeper icatypes.EvmKeeper,
+	scopedKeeper capabilitykeeper.ScopedKeeper, msgRouter sdk.Router,
+) Keeper {
+	// set KeyTable if it has not already been set
+	if !paramSpace.HasKeyTable() {
+		paramSpace = paramSpace.WithKeyTable(types.ParamKeyTable())
+	}
+
+	return Keeper{
+		storeKey:      key,
+		cdc:           proxy,
+		paramSpace:    paramSpace,
+		channelKeeper: channelKeeper,
+		portKeeper:    portKeeper,
+		accountKeeper: accountKeeper,
+		evmKeeper:     evmKeeper,
+		scopedKeeper:  scopedKeeper,
+		msgRouter:     msgRouter,
+	}
+}
+
+// Logger returns the application logger, scoped to the associated module
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", fmt.Sprintf("x/%s-%s", host.ModuleName, icatypes.ModuleName))
+}
+
+// BindPort stores the provided portID, binds to it and claims the returned capability
+func (k Keeper) BindPort(ctx sdk.Context, portID string) error {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(icatypes.KeyPort(portID), []byte{0x01})
+
+	cap := k.portKeeper.BindPort(ctx, portID)
+	return k.ClaimCapability(ctx, cap, host.PortPath(portID))
+}
+
+// IsBound checks if the interchain account host module is already bound to the desired port
+func (k Keeper) IsBound(ctx sdk.Context, portID string) bool {
+	_, ok := k.scopedKeeper.GetCapability(ctx, host.PortPath(portID))
+	return ok
+}
+
+// GetPort returns the portID for the interchain accounts host submodule
+func (k Keeper) GetPort(ctx sdk.Context) string {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, []byte(icatypes.PortKeyPrefix))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		return strings.TrimPrefix(string(iterator.Key()), icatypes.PortKeyPrefix+"/")
+	}
+
+	return ""
+}
+
+// AuthenticateCapability wraps the scopedKeeper's AuthenticateCapability function
+func (k Keeper) AuthenticateCapability(ctx sdk.Context, cap *capabilitytypes.Capability, name string) bool {
+	return k.scopedKeeper.AuthenticateCapability(ctx, cap, name)
+}
+
+// ClaimCapability wraps the scopedKeeper's ClaimCapability function
+func (k Keeper) ClaimCapability(ctx sdk.Context, cap *capabilitytypes.Capability, name string) error {
+	return k.scopedKeeper.ClaimCapability(ctx, cap, name)
+}
+
+// GetActiveChannelID retrieves the active channelID from the store keyed by the provided connectionID and portID
+func (k Keeper) GetActiveChannelID(ctx sdk.Context, connectionID, portID string) (string, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(icatypes.KeyActiveChannel(connectionID, portID))
+	if bz == nil {
+		return "", false
+	}
+
+	return string(bz), true
+}
+
+// SetActiveChannelID stores the active channelID, keyed by the provided connectionID and portID
+func (k Keeper) SetActiveChannelID(ctx sdk.Context, connectionID, portID, channelID string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(icatypes.KeyActiveChannel(connectionID, portID), []byte(channelID))
+}
+
+// DeleteActiveChannelID removes the active channel keyed by the provided connectionID and portID from the store
+func (k Keeper) DeleteActiveChannelID(ctx sdk.Context, connectionID, portID string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(icatypes.KeyActiveChannel(connectionID, portID))
+}
+
+// IsActiveChannel returns true if there exists an active channel for the provided connectionID and portID
+func (k Keeper) IsActiveChannel(ctx sdk.Context, connectionID, portID string) bool {
+	_, ok := k.GetActiveChannelID(ctx, connectionID, portID)
+	return ok
+}
+
+// GetInterchainAccountAddress retrieves the interchain account address from the store keyed by the provided
+// connectionID and controller portID
+func (k Keeper) GetInterchainAccountAddress(ctx sdk.Context, connectionID, portID string) (string, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(icatypes.KeyOwnerAccount(connectionID, portID))
+	if bz == nil {
+		return "", false
+	}
+
+	return string(bz), true
+}
+
+// SetInterchainAccountAddress stores the interchain account address, keyed by the associated connectionID
+// and controller portID
+func (k Keeper) SetInterchainAccountAddress(ctx sdk.Context, connectionID, portID, address string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(icatypes.KeyOwnerAccount(connectionID, portID), []byte(address))
+}
+
+// GetAllActiveChannels returns a list of all active interchain accounts host channels and their associated
+// connection and port identifiers
+func (k Keeper) GetAllActiveChannels(ctx sdk.Context) []icatypes.ActiveChannel {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, []byte(icatypes.ActiveChannelKeyPrefix))
+	defer iterator.Close()
+
+	var activeChannels []icatypes.ActiveChannel
+	for ; iterator.Valid(); iterator.Next() {
+		keySplit := strings.Split(string(iterator.Key()), "/")
+
+		ch := icatypes.ActiveChannel{
+			ConnectionID: keySplit[1],
+			PortID:       keySplit[2],
+			ChannelID:    string(iterator.Value()),
+		}
+
+		activeChannels = append(activeChannels, ch)
+	}
+
+	return activeChannels
+}
+
+// GetAllInterchainAccounts returns a list of all registered interchain account addresses and their associated
+// connection and controller port identifiers
+func (k Keeper) GetAllInterchainAccounts(ctx sdk.Context) []icatypes.RegisteredInterchainAccount {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, []byte(icatypes.OwnerKeyPrefix))
+	defer iterator.Close()
+
+	var interchainAccounts []icatypes.RegisteredInterchainAccount
+	for ; iterator.Valid(); iterator.Next() {
+		keySplit := strings.Split(string(iterator.Key()), "/")
+
+		acc := icatypes.RegisteredInterchainAccount{
+			ConnectionID:   keySplit[1],
+			PortID:         keySplit[2],
+			AccountAddress: string(iterator.Value()),
+		}
+
+		interchainAccounts = append(interchainAccounts, acc)
+	}
+
+	return interchainAccounts
+}
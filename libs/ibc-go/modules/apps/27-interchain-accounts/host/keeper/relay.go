@@ -0,0 +1,134 @@
+package keeper
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/host/types"
+	icatypes "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/types"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+)
+
+// OnRecvPacket handles a given interchain accounts packet on a destination host chain.
+// It returns the data of the executed msgs to be acknowledged, and an error if any of the msgs fails
+// so that all the state changes of the packet are reverted.
+func (k Keeper) OnRecvPacket(ctx sdk.Context, packet channeltypes.Packet) ([]byte, error) {
+	if !k.GetHostEnabled(ctx) {
+		return nil, types.ErrHostSubModuleDisabled
+	}
+
+	var data icatypes.InterchainAccountPacketData
+	if err := icatypes.ModuleCdc.UnmarshalJSON(packet.GetData(), &data); err != nil {
+		// UnmarshalJSON errors are indeterminate and therefore are not wrapped and included in failed acks
+		return nil, sdkerrors.Wrap(icatypes.ErrUnknownDataType, "cannot unmarshal ICS-27 interchain account packet data")
+	}
+
+	switch data.Type {
+	case icatypes.TypeExecuteTx:
+		msgs, err := icatypes.DeserializeCosmosTx(k.cdc.GetCdc(), data.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		return k.executeTx(ctx, packet.SourcePort, packet.DestinationPort, packet.DestinationChannel, msgs)
+	default:
+		return nil, icatypes.ErrUnknownDataType
+	}
+}
+
+// executeTx attempts to execute the provided msgs with the interchain account of the controller port
+func (k Keeper) executeTx(ctx sdk.Context, sourcePort, destPort, destChannel string, msgs []sdk.Msg) ([]byte, error) {
+	channel, found := k.channelKeeper.GetChannel(ctx, destPort, destChannel)
+	if !found {
+		return nil, channeltypes.ErrChannelNotFound
+	}
+
+	if err := k.authenticateTx(ctx, msgs, channel.ConnectionHops[0], sourcePort); err != nil {
+		return nil, err
+	}
+
+	results := make([][]byte, len(msgs))
+	for i, msg := range msgs {
+		if err := msg.ValidateBasic(); err != nil {
+			return nil, err
+		}
+
+		data, err := k.executeMsg(ctx, msg)
+		if err != nil {
+			return nil, sdkerrors.Wrapf(err, "failed to execute msg %d of type %s", i, icatypes.MsgTypeKey(msg))
+		}
+		results[i] = data
+	}
+
+	return icatypes.ModuleCdc.MarshalJSON(results)
+}
+
+// authenticateTx ensures the provided msgs are allowed by the host and contain only the interchain account
+// of the controller port as signer
+func (k Keeper) authenticateTx(ctx sdk.Context, msgs []sdk.Msg, connectionID, portID string) error {
+	interchainAccountAddr, found := k.GetInterchainAccountAddress(ctx, connectionID, portID)
+	if !found {
+		return sdkerrors.Wrapf(icatypes.ErrInterchainAccountNotFound, "failed to retrieve interchain account on port %s", portID)
+	}
+
+	allowMsgs := k.GetAllowMessages(ctx)
+	for _, msg := range msgs {
+		if !containsMsgType(allowMsgs, msg) {
+			return sdkerrors.Wrapf(icatypes.ErrUnauthorizedMsg, "msg type %s", icatypes.MsgTypeKey(msg))
+		}
+
+		for _, signer := range msg.GetSigners() {
+			if interchainAccountAddr != signer.String() {
+				return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "unexpected signer address: expected %s, got %s", interchainAccountAddr, signer)
+			}
+		}
+	}
+
+	return nil
+}
+
+// executeMsg routes the msg to its handler, or the evm keeper for a MsgEvmCall
+func (k Keeper) executeMsg(ctx sdk.Context, msg sdk.Msg) ([]byte, error) {
+	if evmCall, ok := msg.(icatypes.MsgEvmCall); ok {
+		return k.executeEvmCall(ctx, evmCall)
+	}
+
+	handler := k.msgRouter.Route(ctx, msg.Route())
+	if handler == nil {
+		return nil, icatypes.ErrInvalidRoute
+	}
+
+	res, err := handler(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: The sdk msg handler creates a new EventManager, so events must be correctly propagated back to the current context
+	ctx.EventManager().EmitEvents(res.Events)
+
+	return res.Data, nil
+}
+
+func (k Keeper) executeEvmCall(ctx sdk.Context, msg icatypes.MsgEvmCall) ([]byte, error) {
+	if k.evmKeeper == nil {
+		return nil, sdkerrors.Wrap(icatypes.ErrUnsupported, "evm calls are not supported by the host")
+	}
+
+	ret, err := k.evmKeeper.CallEvm(ctx, common.BytesToAddress(msg.From), msg.GetRecipient(), msg.Value.BigInt(), msg.Data)
+	if err != nil {
+		return nil, sdkerrors.Wrap(icatypes.ErrEvmCallFailed, err.Error())
+	}
+
+	return ret, nil
+}
+
+func containsMsgType(allowMsgs []string, msg sdk.Msg) bool {
+	typeKey := icatypes.MsgTypeKey(msg)
+	for _, allowMsg := range allowMsgs {
+		if allowMsg == typeKey {
+			return true
+		}
+	}
+
+	return false
+}
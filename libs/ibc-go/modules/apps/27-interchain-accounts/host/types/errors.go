@@ -0,0 +1,8 @@
+package types
+
+import sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+
+// interchain accounts host sentinel errors
+var (
+	ErrHostSubModuleDisabled = sdkerrors.Register(SubModuleName, 2, "host submodule is disabled")
+)
@@ -0,0 +1,51 @@
+package types
+
+import (
+	icatypes "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/types"
+	host "github.com/okex/exchain/libs/ibc-go/modules/core/24-host"
+)
+
+// GenesisState defines the interchain accounts host genesis state
+type GenesisState struct {
+	ActiveChannels     []icatypes.ActiveChannel               `json:"active_channels" yaml:"active_channels"`
+	InterchainAccounts []icatypes.RegisteredInterchainAccount `json:"interchain_accounts" yaml:"interchain_accounts"`
+	Port               string                                 `json:"port" yaml:"port"`
+	Params             Params                                 `json:"params" yaml:"params"`
+}
+
+// NewGenesisState creates a new interchain accounts host GenesisState instance
+func NewGenesisState(channels []icatypes.ActiveChannel, accounts []icatypes.RegisteredInterchainAccount,
+	port string, params Params) GenesisState {
+	return GenesisState{
+		ActiveChannels:     channels,
+		InterchainAccounts: accounts,
+		Port:               port,
+		Params:             params,
+	}
+}
+
+// DefaultGenesisState returns a GenesisState with "icahost" as the default port
+func DefaultGenesisState() GenesisState {
+	return NewGenesisState(nil, nil, icatypes.PortID, DefaultParams())
+}
+
+// Validate performs basic genesis state validation returning an error upon any failure
+func (gs GenesisState) Validate() error {
+	for _, ch := range gs.ActiveChannels {
+		if err := ch.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, acc := range gs.InterchainAccounts {
+		if err := acc.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := host.PortIdentifierValidator(gs.Port); err != nil {
+		return err
+	}
+
+	return gs.Params.Validate()
+}
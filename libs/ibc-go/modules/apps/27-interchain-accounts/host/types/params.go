@@ -0,0 +1,103 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	paramtypes "github.com/okex/exchain/libs/cosmos-sdk/x/params"
+)
+
+const (
+	// DefaultHostEnabled is the default value for the host param (set to false)
+	DefaultHostEnabled = false
+)
+
+var (
+	// KeyHostEnabled is the store key for HostEnabled Params
+	KeyHostEnabled = []byte("HostEnabled")
+	// KeyAllowMessages is the store key for the AllowMessages Params
+	KeyAllowMessages = []byte("AllowMessages")
+
+	// DefaultAllowMessages is the default list of msgs allowed to be executed by interchain accounts,
+	// which are the bank, staking and evm msgs in the form of route/type
+	DefaultAllowMessages = []string{
+		"bank/send",
+		"staking/deposit",
+		"staking/withdraw",
+		"staking/add_shares_to_validators",
+		"interchainaccounts/evm_call",
+	}
+)
+
+// Params defines the parameters of the interchain accounts host submodule
+type Params struct {
+	HostEnabled   bool     `json:"host_enabled" yaml:"host_enabled"`
+	AllowMessages []string `json:"allow_messages" yaml:"allow_messages"`
+}
+
+// ParamKeyTable type declaration for parameters
+func ParamKeyTable() paramtypes.KeyTable {
+	return paramtypes.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// NewParams creates a new parameter configuration for the host submodule
+func NewParams(enableHost bool, allowMsgs []string) Params {
+	return Params{
+		HostEnabled:   enableHost,
+		AllowMessages: allowMsgs,
+	}
+}
+
+// DefaultParams is the default parameter configuration for the host submodule
+func DefaultParams() Params {
+	return NewParams(DefaultHostEnabled, DefaultAllowMessages)
+}
+
+// Validate validates all host submodule parameters
+func (p Params) Validate() error {
+	if err := validateEnabled(p.HostEnabled); err != nil {
+		return err
+	}
+
+	return validateAllowlist(p.AllowMessages)
+}
+
+// ParamSetPairs implements params.ParamSet
+func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
+	return paramtypes.ParamSetPairs{
+		paramtypes.NewParamSetPair(KeyHostEnabled, &p.HostEnabled, validateEnabled),
+		paramtypes.NewParamSetPair(KeyAllowMessages, &p.AllowMessages, validateAllowlist),
+	}
+}
+
+// String implements the Stringer interface
+func (p Params) String() string {
+	return fmt.Sprintf(`Params:
+  Host Enabled:   %t
+  Allow Messages: %s`,
+		p.HostEnabled, strings.Join(p.AllowMessages, ", "))
+}
+
+func validateEnabled(i interface{}) error {
+	_, ok := i.(bool)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+func validateAllowlist(i interface{}) error {
+	allowMsgs, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	for _, typeKey := range allowMsgs {
+		if strings.Count(typeKey, "/") != 1 || strings.HasPrefix(typeKey, "/") || strings.HasSuffix(typeKey, "/") {
+			return fmt.Errorf("invalid msg type key %s, expected route/type", typeKey)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package ica_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/bank"
+	ica "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts"
+	controllertypes "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/controller/types"
+	hosttypes "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/host/types"
+	icatypes "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/types"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+	"github.com/okex/exchain/libs/ibc-go/modules/core/base"
+	"github.com/okex/exchain/libs/tendermint/crypto/ed25519"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	owner     = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	recipient = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+)
+
+func TestRegisterInterchainAccount(t *testing.T) {
+	c := newCoordinator(t)
+	portID, controllerChannelID, hostChannelID := c.registerInterchainAccount(owner)
+
+	activeChannelID, found := c.controller.controllerKeeper.GetActiveChannelID(c.controller.ctx, c.controller.connectionID, portID)
+	require.True(t, found)
+	require.Equal(t, controllerChannelID, activeChannelID)
+
+	activeChannelID, found = c.host.hostKeeper.GetActiveChannelID(c.host.ctx, c.host.connectionID, portID)
+	require.True(t, found)
+	require.Equal(t, hostChannelID, activeChannelID)
+
+	// the address negotiated in the channel version is the account created on the host
+	accAddr := c.interchainAccount(owner)
+	require.Equal(t, icatypes.GenerateAddress(c.host.connectionID, portID), accAddr)
+	require.NotNil(t, c.host.accountKeeper.GetAccount(c.host.ctx, accAddr))
+
+	hostAddr, found := c.host.hostKeeper.GetInterchainAccountAddress(c.host.ctx, c.host.connectionID, portID)
+	require.True(t, found)
+	require.Equal(t, accAddr.String(), hostAddr)
+
+	// an open channel can't be registered twice
+	_, err := c.controller.deliver(controllertypes.NewMsgRegisterInterchainAccount(owner, c.controller.connectionID))
+	require.True(t, errors.Is(err, icatypes.ErrActiveChannelAlreadySet))
+}
+
+func TestRegisterInterchainAccountDisabled(t *testing.T) {
+	c := newCoordinator(t)
+	c.controller.controllerKeeper.SetParams(c.controller.ctx, controllertypes.NewParams(false))
+	_, err := c.controller.deliver(controllertypes.NewMsgRegisterInterchainAccount(owner, c.controller.connectionID))
+	require.True(t, errors.Is(err, controllertypes.ErrControllerSubModuleDisabled))
+
+	c.controller.controllerKeeper.SetParams(c.controller.ctx, controllertypes.NewParams(true))
+	c.host.hostKeeper.SetParams(c.host.ctx, hosttypes.NewParams(false, hosttypes.DefaultAllowMessages))
+	_, err = c.controller.deliver(controllertypes.NewMsgRegisterInterchainAccount(owner, c.controller.connectionID))
+	require.NoError(t, err)
+
+	portID, err := icatypes.NewControllerPortID(owner.String())
+	require.NoError(t, err)
+	_, err = c.chanOpenTry(portID, channeltypes.FormatChannelIdentifier(0))
+	require.True(t, errors.Is(err, hosttypes.ErrHostSubModuleDisabled))
+	require.Empty(t, c.host.channels.channels)
+}
+
+func TestInterchainAccountsBeforeVenus2(t *testing.T) {
+	c := newCoordinator(t)
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(2)
+	_, err := c.controller.deliver(controllertypes.NewMsgRegisterInterchainAccount(owner, c.controller.connectionID))
+	require.True(t, errors.Is(err, icatypes.ErrDisabled))
+
+	// the stores of both submodules are committed and initialized only since the Venus2 height
+	module := ica.NewAppModule(c.controller.controllerKeeper, c.host.hostKeeper)
+	require.Equal(t, int64(2), module.UpgradeHeight())
+	require.Equal(t, []string{controllertypes.StoreKey, hosttypes.StoreKey}, module.BlockStoreModules())
+	require.NotContains(t, base.NewBaseIBCUpgradeModule(ica.AppModuleBasic{}).BlockStoreModules(), controllertypes.StoreKey)
+	require.NotContains(t, base.NewBaseIBCUpgradeModule(ica.AppModuleBasic{}).BlockStoreModules(), hosttypes.StoreKey)
+}
+
+func TestSendTxBankSend(t *testing.T) {
+	c := newCoordinator(t)
+	c.registerInterchainAccount(owner)
+	accAddr := c.interchainAccount(owner)
+	c.host.fund(accAddr, 100)
+
+	msg := bank.NewMsgSend(accAddr, recipient, sdk.NewCoins(sdk.NewInt64Coin(testDenom, 40)))
+	require.NoError(t, c.sendTx(owner, msg))
+
+	ack := c.relayPacket()
+	require.True(t, ack.Success(), ack.String())
+	require.Equal(t, sdk.NewInt(60), c.host.balance(accAddr))
+	require.Equal(t, sdk.NewInt(40), c.host.balance(recipient))
+}
+
+func TestSendTxErrorAcknowledgement(t *testing.T) {
+	c := newCoordinator(t)
+	c.registerInterchainAccount(owner)
+	accAddr := c.interchainAccount(owner)
+	c.host.fund(accAddr, 100)
+
+	testCases := []struct {
+		name string
+		msgs []sdk.Msg
+	}{
+		{
+			"insufficient funds reverts the previous msgs",
+			[]sdk.Msg{
+				bank.NewMsgSend(accAddr, recipient, sdk.NewCoins(sdk.NewInt64Coin(testDenom, 40))),
+				bank.NewMsgSend(accAddr, recipient, sdk.NewCoins(sdk.NewInt64Coin(testDenom, 80))),
+			},
+		},
+		{
+			"signer is not the interchain account",
+			[]sdk.Msg{bank.NewMsgSend(owner, recipient, sdk.NewCoins(sdk.NewInt64Coin(testDenom, 1)))},
+		},
+		{
+			"msg is not allowed by the host",
+			[]sdk.Msg{bank.NewMsgMultiSend(
+				[]bank.Input{bank.NewInput(accAddr, sdk.NewCoins(sdk.NewInt64Coin(testDenom, 1)))},
+				[]bank.Output{bank.NewOutput(recipient, sdk.NewCoins(sdk.NewInt64Coin(testDenom, 1)))},
+			)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, c.sendTx(owner, tc.msgs...))
+			ack := c.relayPacket()
+			require.False(t, ack.Success())
+			require.Equal(t, sdk.NewInt(100), c.host.balance(accAddr))
+			require.True(t, c.host.balance(recipient).IsZero())
+		})
+	}
+}
+
+func TestSendTxEvmCall(t *testing.T) {
+	c := newCoordinator(t)
+	c.registerInterchainAccount(owner)
+	accAddr := c.interchainAccount(owner)
+
+	contract := common.HexToAddress("0x1033796B018B2bf0Fc9CB88c0793b2F275eDB624")
+	msg := icatypes.NewMsgEvmCall(accAddr, contract.Hex(), sdk.ZeroInt(), []byte{0xa9, 0x05, 0x9c, 0xbb})
+	require.NoError(t, c.sendTx(owner, msg))
+
+	ack := c.relayPacket()
+	require.True(t, ack.Success(), ack.String())
+	require.Len(t, c.host.evm.calls, 1)
+	require.Equal(t, common.BytesToAddress(accAddr), c.host.evm.calls[0].from)
+	require.Equal(t, contract, *c.host.evm.calls[0].to)
+	require.Equal(t, msg.Data, c.host.evm.calls[0].data)
+
+	// a reverted evm call results in an error acknowledgement
+	c.host.evm.err = errors.New("execution reverted")
+	require.NoError(t, c.sendTx(owner, msg))
+	ack = c.relayPacket()
+	require.False(t, ack.Success())
+	require.Len(t, c.host.evm.calls, 1)
+
+	// evm calls can't be sent directly to the controller
+	_, err := c.controller.deliver(msg)
+	require.True(t, errors.Is(err, icatypes.ErrUnsupported))
+}
+
+func TestTimeoutAndReregister(t *testing.T) {
+	c := newCoordinator(t)
+	portID, controllerChannelID, _ := c.registerInterchainAccount(owner)
+	accAddr := c.interchainAccount(owner)
+
+	msg := bank.NewMsgSend(accAddr, recipient, sdk.NewCoins(sdk.NewInt64Coin(testDenom, 1)))
+	require.NoError(t, c.sendTx(owner, msg))
+	c.timeoutPacket()
+
+	// the channel is closed by the timeout and no more packets can be sent over it
+	require.Error(t, c.sendTx(owner, msg))
+	require.False(t, c.host.hostKeeper.IsActiveChannel(c.host.ctx, c.host.connectionID, portID))
+
+	// registering again opens a new channel to the same interchain account
+	_, newControllerChannelID, newHostChannelID := c.registerInterchainAccount(owner)
+	require.NotEqual(t, controllerChannelID, newControllerChannelID)
+	require.Equal(t, accAddr, c.interchainAccount(owner))
+
+	activeChannelID, found := c.host.hostKeeper.GetActiveChannelID(c.host.ctx, c.host.connectionID, portID)
+	require.True(t, found)
+	require.Equal(t, newHostChannelID, activeChannelID)
+
+	c.host.fund(accAddr, 1)
+	require.NoError(t, c.sendTx(owner, msg))
+	ack := c.relayPacket()
+	require.True(t, ack.Success(), ack.String())
+	require.Equal(t, sdk.NewInt(1), c.host.balance(recipient))
+}
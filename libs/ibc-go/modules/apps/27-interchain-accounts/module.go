@@ -0,0 +1,166 @@
+package ica
+
+import (
+	"encoding/json"
+
+	"github.com/gorilla/mux"
+	"github.com/spf13/cobra"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/types/module"
+	"github.com/okex/exchain/libs/cosmos-sdk/types/upgrade"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/params"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/client/cli"
+	controllerkeeper "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/controller/keeper"
+	controllertypes "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/controller/types"
+	hostkeeper "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/host/keeper"
+	hosttypes "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/host/types"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/types"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+)
+
+var (
+	_ module.AppModuleBasic = AppModuleBasic{}
+	_ module.AppModule      = AppModule{}
+	_ upgrade.UpgradeModule = AppModule{}
+)
+
+// AppModuleBasic is the interchain accounts AppModuleBasic
+type AppModuleBasic struct{}
+
+// Name implements AppModuleBasic interface
+func (AppModuleBasic) Name() string {
+	return types.ModuleName
+}
+
+// RegisterCodec registers the interchain accounts msgs of both submodules
+func (AppModuleBasic) RegisterCodec(cdc *codec.Codec) {
+	types.RegisterCodec(cdc)
+	controllertypes.RegisterCodec(cdc)
+}
+
+// DefaultGenesis returns nil, the genesis state is initialized by the upgrade task
+func (AppModuleBasic) DefaultGenesis() json.RawMessage {
+	return nil
+}
+
+// ValidateGenesis performs genesis state validation for the interchain accounts module
+func (AppModuleBasic) ValidateGenesis(bz json.RawMessage) error {
+	return nil
+}
+
+// RegisterRESTRoutes implements AppModuleBasic interface
+func (AppModuleBasic) RegisterRESTRoutes(ctx context.CLIContext, rtr *mux.Router) {}
+
+// GetTxCmd returns the root tx command of the interchain accounts controller
+func (AppModuleBasic) GetTxCmd(cdc *codec.Codec) *cobra.Command {
+	return cli.GetTxCmd(cdc)
+}
+
+// GetQueryCmd returns the root query command of the interchain accounts controller
+func (AppModuleBasic) GetQueryCmd(cdc *codec.Codec) *cobra.Command {
+	return cli.GetQueryCmd(cdc)
+}
+
+//____________________________________________________________________________
+
+// AppModule is the application module for interchain accounts, the IBC callbacks
+// of each submodule are provided by the controller and host IBCModule
+type AppModule struct {
+	AppModuleBasic
+	controllerKeeper controllerkeeper.Keeper
+	hostKeeper       hostkeeper.Keeper
+}
+
+// NewAppModule creates a new interchain accounts module
+func NewAppModule(controllerKeeper controllerkeeper.Keeper, hostKeeper hostkeeper.Keeper) AppModule {
+	return AppModule{
+		controllerKeeper: controllerKeeper,
+		hostKeeper:       hostKeeper,
+	}
+}
+
+// RegisterInvariants implements the AppModule interface
+func (AppModule) RegisterInvariants(ir sdk.InvariantRegistry) {}
+
+// Route implements the AppModule interface
+func (AppModule) Route() string {
+	return types.RouterKey
+}
+
+// NewHandler returns the handler of the interchain accounts controller msgs
+func (am AppModule) NewHandler() sdk.Handler {
+	return NewHandler(am.controllerKeeper)
+}
+
+// QuerierRoute implements the AppModule interface
+func (AppModule) QuerierRoute() string {
+	return types.QuerierRoute
+}
+
+// NewQuerierHandler returns the querier of the interchain accounts controller
+func (am AppModule) NewQuerierHandler() sdk.Querier {
+	return controllerkeeper.NewQuerier(am.controllerKeeper)
+}
+
+// BeginBlock implements the AppModule interface
+func (AppModule) BeginBlock(_ sdk.Context, _ abci.RequestBeginBlock) {}
+
+// EndBlock implements the AppModule interface
+func (AppModule) EndBlock(_ sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+	return []abci.ValidatorUpdate{}
+}
+
+// InitGenesis implements the AppModule interface
+func (AppModule) InitGenesis(ctx sdk.Context, data json.RawMessage) []abci.ValidatorUpdate {
+	return nil
+}
+
+func (am AppModule) initGenesis(ctx sdk.Context, data json.RawMessage) []abci.ValidatorUpdate {
+	var genesisState GenesisState
+	types.ModuleCdc.MustUnmarshalJSON(data, &genesisState)
+	am.controllerKeeper.InitGenesis(ctx, genesisState.ControllerGenesisState)
+	am.hostKeeper.InitGenesis(ctx, genesisState.HostGenesisState)
+	return []abci.ValidatorUpdate{}
+}
+
+// ExportGenesis implements the AppModule interface
+func (AppModule) ExportGenesis(ctx sdk.Context) json.RawMessage {
+	return nil
+}
+
+func (am AppModule) exportGenesis(ctx sdk.Context) json.RawMessage {
+	gs := NewGenesisState(am.controllerKeeper.ExportGenesis(ctx), am.hostKeeper.ExportGenesis(ctx))
+	return types.ModuleCdc.MustMarshalJSON(gs)
+}
+
+// ModuleName returns the interchain accounts module's name.
+func (AppModule) ModuleName() string {
+	return types.ModuleName
+}
+
+// RegisterTask initializes the genesis state and the params of both submodules at the upgrade height.
+func (am AppModule) RegisterTask() upgrade.HeightTask {
+	return upgrade.NewHeightTask(5, func(ctx sdk.Context) error {
+		am.initGenesis(ctx, types.ModuleCdc.MustMarshalJSON(DefaultGenesisState()))
+		return nil
+	})
+}
+
+// UpgradeHeight returns the Venus2 height since which the interchain accounts stores are committed.
+func (AppModule) UpgradeHeight() int64 {
+	return tmtypes.GetVenus2Height()
+}
+
+// BlockStoreModules returns the interchain accounts stores, which aren't committed before the upgrade height.
+func (AppModule) BlockStoreModules() []string {
+	return []string{controllertypes.StoreKey, hosttypes.StoreKey}
+}
+
+// RegisterParam returns nil, the params of both submodules are set by the upgrade task.
+func (AppModule) RegisterParam() params.ParamSet {
+	return nil
+}
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	host "github.com/okex/exchain/libs/ibc-go/modules/core/24-host"
+)
+
+// GenerateAddress returns the address of the interchain account controlled by the controller port on
+// the host chain over the connection.
+// The address follows the same ADR 028 construction as the escrow address of ics20.
+func GenerateAddress(connectionID, portID string) sdk.AccAddress {
+	// a slash is used to create domain separation between connection and port identifiers to
+	// prevent address collisions between interchain accounts created by different controllers
+	contents := fmt.Sprintf("%s/%s", connectionID, portID)
+
+	preImage := []byte(ModuleName)
+	preImage = append(preImage, 0)
+	preImage = append(preImage, contents...)
+	hash := sha256.Sum256(preImage)
+	return hash[:20]
+}
+
+// NewControllerPortID returns the controller port identifier of the owner
+func NewControllerPortID(owner string) (string, error) {
+	if strings.TrimSpace(owner) == "" {
+		return "", sdkerrors.Wrap(ErrInvalidAccountAddress, "owner address cannot be empty")
+	}
+
+	portID := PortPrefix + owner
+	if err := host.PortIdentifierValidator(portID); err != nil {
+		return "", sdkerrors.Wrap(ErrInvalidControllerPort, err.Error())
+	}
+	return portID, nil
+}
+
+// NewAppVersion returns the channel version negotiated by the host with the interchain account address
+func NewAppVersion(version, accAddr string) string {
+	return fmt.Sprint(version, Delimiter, accAddr)
+}
+
+// ParseAddressFromVersion parses the interchain account address from the negotiated channel version
+func ParseAddressFromVersion(version string) (string, error) {
+	parts := strings.Split(version, Delimiter)
+	if len(parts) != 2 || parts[0] != Version {
+		return "", sdkerrors.Wrapf(ErrInvalidVersion, "expected %s, got %s", NewAppVersion(Version, "{address}"), version)
+	}
+
+	if strings.TrimSpace(parts[1]) == "" {
+		return "", sdkerrors.Wrap(ErrInvalidAccountAddress, "interchain account address cannot be empty")
+	}
+	return parts[1], nil
+}
@@ -0,0 +1,19 @@
+package types
+
+import (
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+)
+
+// RegisterCodec registers the msgs executed by the interchain accounts host on the provided amino codec
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgEvmCall{}, "okexchain/interchainaccounts/MsgEvmCall", nil)
+}
+
+// ModuleCdc references the global interchain accounts module codec, it's only used for
+// the JSON encoding of the packet data and the sign bytes of msgs
+var ModuleCdc = codec.New()
+
+func init() {
+	RegisterCodec(ModuleCdc)
+	ModuleCdc.Seal()
+}
@@ -0,0 +1,23 @@
+package types
+
+import sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+
+// interchain accounts sentinel errors
+var (
+	ErrUnknownDataType           = sdkerrors.Register(ModuleName, 2, "unknown data type")
+	ErrInvalidChannelFlow        = sdkerrors.Register(ModuleName, 3, "invalid message sent to channel end")
+	ErrInvalidOutgoingData       = sdkerrors.Register(ModuleName, 4, "invalid outgoing data")
+	ErrInvalidRoute              = sdkerrors.Register(ModuleName, 5, "invalid route")
+	ErrInterchainAccountNotFound = sdkerrors.Register(ModuleName, 6, "interchain account not found")
+	ErrActiveChannelAlreadySet   = sdkerrors.Register(ModuleName, 7, "active channel already set for this owner")
+	ErrActiveChannelNotFound     = sdkerrors.Register(ModuleName, 8, "no active channel for this owner")
+	ErrInvalidVersion            = sdkerrors.Register(ModuleName, 9, "invalid interchain accounts version")
+	ErrInvalidAccountAddress     = sdkerrors.Register(ModuleName, 10, "invalid account address")
+	ErrUnsupported               = sdkerrors.Register(ModuleName, 11, "interchain account does not support this action")
+	ErrInvalidControllerPort     = sdkerrors.Register(ModuleName, 12, "invalid controller port")
+	ErrInvalidHostPort           = sdkerrors.Register(ModuleName, 13, "invalid host port")
+	ErrInvalidTimeoutTimestamp   = sdkerrors.Register(ModuleName, 14, "timeout timestamp must be in the future")
+	ErrUnauthorizedMsg           = sdkerrors.Register(ModuleName, 15, "message is not allowed to be executed by interchain account")
+	ErrEvmCallFailed             = sdkerrors.Register(ModuleName, 16, "evm call of interchain account failed")
+	ErrDisabled                  = sdkerrors.Register(ModuleName, 17, "interchain accounts are not enabled before the Venus2 height")
+)
@@ -0,0 +1,18 @@
+package types
+
+// interchain accounts events
+const (
+	EventTypePacket   = "ics27_packet"
+	EventTypeRegister = "register_interchain_account"
+	EventTypeSubmitTx = "submit_interchain_account_tx"
+	EventTypeTimeout  = "timeout"
+
+	AttributeKeyAccountAddress = "account_address"
+	AttributeKeyOwner          = "owner"
+	AttributeKeyConnectionID   = "connection_id"
+	AttributeKeyPortID         = "port_id"
+	AttributeKeyChannelID      = "channel_id"
+	AttributeKeySequence       = "sequence"
+	AttributeKeyAckSuccess     = "success"
+	AttributeKeyAckError       = "error"
+)
@@ -0,0 +1,44 @@
+package types
+
+import (
+	host "github.com/okex/exchain/libs/ibc-go/modules/core/24-host"
+)
+
+// ActiveChannel contains the connection and port identifiers with the associated active channel identifier
+type ActiveChannel struct {
+	ConnectionID string `json:"connection_id" yaml:"connection_id"`
+	PortID       string `json:"port_id" yaml:"port_id"`
+	ChannelID    string `json:"channel_id" yaml:"channel_id"`
+}
+
+// Validate performs basic validation of the active channel
+func (ac ActiveChannel) Validate() error {
+	if err := host.ConnectionIdentifierValidator(ac.ConnectionID); err != nil {
+		return err
+	}
+	if err := host.PortIdentifierValidator(ac.PortID); err != nil {
+		return err
+	}
+	return host.ChannelIdentifierValidator(ac.ChannelID)
+}
+
+// RegisteredInterchainAccount contains the connection and port identifiers with the associated interchain account address
+type RegisteredInterchainAccount struct {
+	ConnectionID   string `json:"connection_id" yaml:"connection_id"`
+	PortID         string `json:"port_id" yaml:"port_id"`
+	AccountAddress string `json:"account_address" yaml:"account_address"`
+}
+
+// Validate performs basic validation of the registered interchain account
+func (ria RegisteredInterchainAccount) Validate() error {
+	if err := host.ConnectionIdentifierValidator(ria.ConnectionID); err != nil {
+		return err
+	}
+	if err := host.PortIdentifierValidator(ria.PortID); err != nil {
+		return err
+	}
+	if ria.AccountAddress == "" {
+		return ErrInvalidAccountAddress
+	}
+	return nil
+}
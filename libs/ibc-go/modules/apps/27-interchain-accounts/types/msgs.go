@@ -0,0 +1,85 @@
+package types
+
+import (
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+)
+
+// TypeMsgEvmCall defines the type string of MsgEvmCall
+const TypeMsgEvmCall = "evm_call"
+
+var _ sdk.Msg = MsgEvmCall{}
+
+// MsgEvmCall calls an evm contract, or creates one when To is empty, with the interchain account.
+// It can only be executed by the interchain accounts host because an interchain account has no private key
+// to sign an ethereum tx.
+type MsgEvmCall struct {
+	From  sdk.AccAddress `json:"from"`
+	To    string         `json:"to"`
+	Value sdk.Int        `json:"value"`
+	Data  []byte         `json:"data"`
+}
+
+// NewMsgEvmCall creates a new MsgEvmCall instance
+func NewMsgEvmCall(from sdk.AccAddress, to string, value sdk.Int, data []byte) MsgEvmCall {
+	return MsgEvmCall{
+		From:  from,
+		To:    to,
+		Value: value,
+		Data:  data,
+	}
+}
+
+// Route implements sdk.Msg
+func (msg MsgEvmCall) Route() string { return RouterKey }
+
+// Type implements sdk.Msg
+func (msg MsgEvmCall) Type() string { return TypeMsgEvmCall }
+
+// GetSigners implements sdk.Msg
+func (msg MsgEvmCall) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgEvmCall) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgEvmCall) ValidateBasic() error {
+	if msg.From.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing sender address")
+	}
+
+	if strings.TrimSpace(msg.To) == "" {
+		if len(msg.Data) == 0 {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "contract creation requires the bytecode")
+		}
+	} else if !common.IsHexAddress(msg.To) {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid contract address %s", msg.To)
+	}
+
+	if msg.Value.IsNil() || msg.Value.IsNegative() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "value cannot be negative")
+	}
+
+	return nil
+}
+
+// GetRecipient returns the contract address of the call, or nil for a contract creation
+func (msg MsgEvmCall) GetRecipient() *common.Address {
+	if strings.TrimSpace(msg.To) == "" {
+		return nil
+	}
+	to := common.HexToAddress(msg.To)
+	return &to
+}
+
+// MsgTypeKey returns the key of the msg used by the allow list of the host, in the form of route/type
+func MsgTypeKey(msg sdk.Msg) string {
+	return msg.Route() + "/" + msg.Type()
+}
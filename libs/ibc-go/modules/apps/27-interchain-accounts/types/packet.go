@@ -0,0 +1,90 @@
+package types
+
+import (
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+)
+
+// MaxMemoCharLength defines the maximum length for the InterchainAccountPacketData memo field
+const MaxMemoCharLength = 256
+
+// Type defines the type of the interchain account packet data
+type Type int32
+
+const (
+	// TypeUnspecified defines a default zero value for the type
+	TypeUnspecified Type = 0
+	// TypeExecuteTx defines a type that executes a batch of msgs with the interchain account
+	TypeExecuteTx Type = 1
+)
+
+// InterchainAccountPacketData is the packet data of interchain accounts
+type InterchainAccountPacketData struct {
+	Type Type   `json:"type"`
+	Data []byte `json:"data"`
+	Memo string `json:"memo"`
+}
+
+// NewInterchainAccountPacketData creates a new InterchainAccountPacketData instance
+func NewInterchainAccountPacketData(typ Type, data []byte, memo string) InterchainAccountPacketData {
+	return InterchainAccountPacketData{
+		Type: typ,
+		Data: data,
+		Memo: memo,
+	}
+}
+
+// ValidateBasic performs basic validation of the interchain account packet data.
+// The memo may be empty.
+func (iapd InterchainAccountPacketData) ValidateBasic() error {
+	if iapd.Type == TypeUnspecified {
+		return sdkerrors.Wrap(ErrInvalidOutgoingData, "packet data type cannot be unspecified")
+	}
+
+	if len(iapd.Data) == 0 {
+		return sdkerrors.Wrap(ErrInvalidOutgoingData, "packet data cannot be empty")
+	}
+
+	if len(iapd.Memo) > MaxMemoCharLength {
+		return sdkerrors.Wrapf(ErrInvalidOutgoingData, "packet data memo cannot be greater than %d characters", MaxMemoCharLength)
+	}
+
+	return nil
+}
+
+// GetBytes returns the sorted JSON encoding of the packet data for relaying
+func (iapd InterchainAccountPacketData) GetBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(iapd))
+}
+
+// CosmosTx contains the msgs to be executed by the interchain account on the host chain
+type CosmosTx struct {
+	Messages []sdk.Msg `json:"messages"`
+}
+
+// SerializeCosmosTx serializes the msgs with the codec which all the msgs are registered in
+func SerializeCosmosTx(cdc *codec.Codec, msgs []sdk.Msg) ([]byte, error) {
+	if len(msgs) == 0 {
+		return nil, sdkerrors.Wrap(ErrInvalidOutgoingData, "msgs cannot be empty")
+	}
+
+	bz, err := cdc.MarshalJSON(CosmosTx{Messages: msgs})
+	if err != nil {
+		return nil, sdkerrors.Wrap(ErrInvalidOutgoingData, err.Error())
+	}
+	return bz, nil
+}
+
+// DeserializeCosmosTx unmarshals the msgs serialized by SerializeCosmosTx
+func DeserializeCosmosTx(cdc *codec.Codec, data []byte) ([]sdk.Msg, error) {
+	var tx CosmosTx
+	if err := cdc.UnmarshalJSON(data, &tx); err != nil {
+		return nil, sdkerrors.Wrapf(ErrInvalidOutgoingData, "cannot unmarshal cosmos tx: %s", err.Error())
+	}
+
+	if len(tx.Messages) == 0 {
+		return nil, sdkerrors.Wrap(ErrInvalidOutgoingData, "msgs cannot be empty")
+	}
+	return tx.Messages, nil
+}
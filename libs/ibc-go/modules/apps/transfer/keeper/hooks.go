@@ -17,6 +17,13 @@ func (k *Keeper) SetHooks(hooks types.TransferHooks) *Keeper {
 	return k
 }
 
+// SetSendCoinsChecker sets the checker of the coins sent by a transfer, which is called before
+// the coins are escrowed or burned
+func (k *Keeper) SetSendCoinsChecker(checker types.SendCoinsChecker) *Keeper {
+	k.sendCoinsChecker = checker
+	return k
+}
+
 func (k Keeper) CallAfterSendTransferHooks(
 	ctx sdk.Context,
 	sourcePort, sourceChannel string,
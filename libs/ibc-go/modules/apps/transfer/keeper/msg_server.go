@@ -19,9 +19,9 @@ func (k Keeper) Transfer(goCtx context.Context, msg *types.MsgTransfer) (*types.
 	if err != nil {
 		return nil, err
 	}
-	if err := k.SendTransfer(
+	if err := k.SendTransferWithMemo(
 		ctx, msg.SourcePort, msg.SourceChannel, msg.Token,
-		sender, msg.Receiver, msg.TimeoutHeight, msg.TimeoutTimestamp,
+		sender, msg.Receiver, msg.TimeoutHeight, msg.TimeoutTimestamp, msg.Memo,
 	); err != nil {
 		return nil, err
 	}
@@ -53,6 +53,42 @@ func (k Keeper) SendTransfer(
 	receiver string,
 	timeoutHeight clienttypes.Height,
 	timeoutTimestamp uint64,
+) error {
+	return k.sendTransfer(
+		ctx, sourcePort, sourceChannel, adapterToken, sender, receiver,
+		timeoutHeight, timeoutTimestamp, "",
+	)
+}
+
+// SendTransferWithMemo behaves like SendTransfer but attaches the given memo to
+// the outgoing packet data so that middleware on the destination chain can act on it.
+func (k Keeper) SendTransferWithMemo(
+	ctx sdk.Context,
+	sourcePort,
+	sourceChannel string,
+	adapterToken sdk.CoinAdapter,
+	sender sdk.AccAddress,
+	receiver string,
+	timeoutHeight clienttypes.Height,
+	timeoutTimestamp uint64,
+	memo string,
+) error {
+	return k.sendTransfer(
+		ctx, sourcePort, sourceChannel, adapterToken, sender, receiver,
+		timeoutHeight, timeoutTimestamp, memo,
+	)
+}
+
+func (k Keeper) sendTransfer(
+	ctx sdk.Context,
+	sourcePort,
+	sourceChannel string,
+	adapterToken sdk.CoinAdapter,
+	sender sdk.AccAddress,
+	receiver string,
+	timeoutHeight clienttypes.Height,
+	timeoutTimestamp uint64,
+	memo string,
 ) error {
 	if !k.GetSendEnabled(ctx) {
 		return types.ErrSendDisabled
@@ -64,6 +100,11 @@ func (k Keeper) SendTransfer(
 		"token", token.String(), "tokenAmountBigInt", token.Amount.Int.String(),
 		"adapterToken", adapterToken.String(), "adapterTokenBigInt", adapterToken.Amount.String(),
 	)
+	if k.sendCoinsChecker != nil {
+		if err := k.sendCoinsChecker(ctx, sdk.NewCoins(token), sender); err != nil {
+			return err
+		}
+	}
 	sourceChannelEnd, found := k.channelKeeper.GetChannel(ctx, sourcePort, sourceChannel)
 	if !found {
 		return sdkerrors.Wrapf(channeltypes.ErrChannelNotFound, "port ID (%s) channel ID (%s)", sourcePort, sourceChannel)
@@ -136,6 +177,7 @@ func (k Keeper) SendTransfer(
 	packetData := types.NewFungibleTokenPacketData(
 		fullDenomPath, adapterToken.Amount.String(), sender.String(), receiver,
 	)
+	packetData.Memo = memo
 
 	packet := channeltypes.NewPacket(
 		packetData.GetBytes(),
@@ -24,6 +24,9 @@ type BankKeeper interface {
 	SendCoinsFromAccountToModule(ctx sdk.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins) error
 }
 
+// SendCoinsChecker checks whether the coins can be sent from the accounts, e.g. whether the tokens are paused
+type SendCoinsChecker func(ctx sdk.Context, amt sdk.Coins, addrs ...sdk.AccAddress) error
+
 // ChannelKeeper defines the expected IBC channel keeper
 type ChannelKeeper interface {
 	GetChannel(ctx sdk.Context, srcPort, srcChan string) (channel channeltypes.Channel, found bool)
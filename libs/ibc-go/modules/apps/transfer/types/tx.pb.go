@@ -52,6 +52,8 @@ type MsgTransfer struct {
 	// Timeout timestamp (in nanoseconds) relative to the current block timestamp.
 	// The timeout is disabled when set to 0.
 	TimeoutTimestamp uint64 `protobuf:"varint,7,opt,name=timeout_timestamp,json=timeoutTimestamp,proto3" json:"timeout_timestamp,omitempty" yaml:"timeout_timestamp"`
+	// optional memo forwarded in the packet data
+	Memo string `protobuf:"bytes,8,opt,name=memo,proto3" json:"memo,omitempty"`
 }
 
 func (m *MsgTransfer) Reset()         { *m = MsgTransfer{} }
@@ -136,38 +138,38 @@ func init() {
 }
 
 var fileDescriptor_7401ed9bed2f8e09 = []byte{
-	// 488 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x93, 0x41, 0x6f, 0xd3, 0x30,
+	// 495 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x93, 0xcf, 0x6f, 0xd3, 0x30,
 	0x14, 0xc7, 0x13, 0xd6, 0x95, 0xe2, 0x6a, 0x13, 0x18, 0x36, 0x65, 0xd5, 0x48, 0xaa, 0x48, 0x48,
 	0xe5, 0x80, 0xad, 0x0c, 0x21, 0xa4, 0x1d, 0x10, 0xca, 0x2e, 0x70, 0x98, 0x84, 0xa2, 0x1d, 0x10,
-	0x97, 0x91, 0x78, 0x26, 0xb1, 0xd6, 0xd8, 0x91, 0xed, 0x46, 0xdb, 0x37, 0xe0, 0xc8, 0x47, 0xd8,
-	0x99, 0x4f, 0xb2, 0xe3, 0x8e, 0x9c, 0x2a, 0xd4, 0x5e, 0x38, 0xf7, 0x13, 0xa0, 0xc4, 0x6e, 0x69,
-	0x0f, 0x20, 0x4e, 0xf1, 0x7b, 0xff, 0xdf, 0xf3, 0x5f, 0xcf, 0xef, 0x05, 0x3c, 0x63, 0x19, 0xc1,
-	0x69, 0x55, 0x8d, 0x19, 0x49, 0x35, 0x13, 0x5c, 0x61, 0x2d, 0x53, 0xae, 0xbe, 0x50, 0x89, 0xeb,
-	0x08, 0xeb, 0x2b, 0x54, 0x49, 0xa1, 0x05, 0x3c, 0x64, 0x19, 0x41, 0xeb, 0x18, 0x5a, 0x62, 0xa8,
-	0x8e, 0x06, 0x4f, 0x72, 0x91, 0x8b, 0x16, 0xc4, 0xcd, 0xc9, 0xd4, 0x0c, 0x7c, 0x22, 0x54, 0x29,
-	0x14, 0xce, 0x52, 0x45, 0x71, 0x1d, 0x65, 0x54, 0xa7, 0x11, 0x26, 0x82, 0x71, 0xab, 0x07, 0x8d,
-	0x35, 0x11, 0x92, 0x62, 0x32, 0x66, 0x94, 0xeb, 0xc6, 0xd0, 0x9c, 0x0c, 0x10, 0x7e, 0xdf, 0x02,
-	0xfd, 0x53, 0x95, 0x9f, 0x59, 0x27, 0xf8, 0x1a, 0xf4, 0x95, 0x98, 0x48, 0x42, 0xcf, 0x2b, 0x21,
-	0xb5, 0xe7, 0x0e, 0xdd, 0xd1, 0x83, 0x78, 0x7f, 0x31, 0x0d, 0xe0, 0x75, 0x5a, 0x8e, 0x8f, 0xc3,
-	0x35, 0x31, 0x4c, 0x80, 0x89, 0x3e, 0x08, 0xa9, 0xe1, 0x5b, 0xb0, 0x6b, 0x35, 0x52, 0xa4, 0x9c,
-	0xd3, 0xb1, 0x77, 0xaf, 0xad, 0x3d, 0x58, 0x4c, 0x83, 0xbd, 0x8d, 0x5a, 0xab, 0x87, 0xc9, 0x8e,
-	0x49, 0x9c, 0x98, 0x18, 0xbe, 0x02, 0xdb, 0x5a, 0x5c, 0x52, 0xee, 0x6d, 0x0d, 0xdd, 0x51, 0xff,
+	0x97, 0x91, 0x78, 0x26, 0xb1, 0xd6, 0xd8, 0x91, 0xed, 0x46, 0xdb, 0x7f, 0xc0, 0x91, 0x3f, 0x61,
+	0x7f, 0x09, 0xe7, 0x1d, 0x77, 0xe4, 0x54, 0xa1, 0xf6, 0xc2, 0xb9, 0x7f, 0x01, 0x4a, 0xec, 0x96,
+	0xf6, 0x00, 0xe2, 0xe4, 0xf7, 0xe3, 0xf3, 0xfc, 0xd5, 0xf3, 0x7b, 0x06, 0xcf, 0x58, 0x46, 0x70,
+	0x5a, 0x55, 0x63, 0x46, 0x52, 0xcd, 0x04, 0x57, 0x58, 0xcb, 0x94, 0xab, 0x2f, 0x54, 0xe2, 0x3a,
+	0xc2, 0xfa, 0x0a, 0x55, 0x52, 0x68, 0x01, 0x0f, 0x59, 0x46, 0xd0, 0x3a, 0x86, 0x96, 0x18, 0xaa,
+	0xa3, 0xc1, 0x93, 0x5c, 0xe4, 0xa2, 0x05, 0x71, 0x63, 0x99, 0x9a, 0x81, 0x4f, 0x84, 0x2a, 0x85,
+	0xc2, 0x59, 0xaa, 0x28, 0xae, 0xa3, 0x8c, 0xea, 0x34, 0xc2, 0x44, 0x30, 0x6e, 0xf3, 0x41, 0x23,
+	0x4d, 0x84, 0xa4, 0x98, 0x8c, 0x19, 0xe5, 0xba, 0x11, 0x34, 0x96, 0x01, 0xc2, 0xef, 0x5b, 0xa0,
+	0x7f, 0xaa, 0xf2, 0x33, 0xab, 0x04, 0x5f, 0x83, 0xbe, 0x12, 0x13, 0x49, 0xe8, 0x79, 0x25, 0xa4,
+	0xf6, 0xdc, 0xa1, 0x3b, 0x7a, 0x10, 0xef, 0x2f, 0xa6, 0x01, 0xbc, 0x4e, 0xcb, 0xf1, 0x71, 0xb8,
+	0x96, 0x0c, 0x13, 0x60, 0xbc, 0x0f, 0x42, 0x6a, 0xf8, 0x16, 0xec, 0xda, 0x1c, 0x29, 0x52, 0xce,
+	0xe9, 0xd8, 0xbb, 0xd7, 0xd6, 0x1e, 0x2c, 0xa6, 0xc1, 0xde, 0x46, 0xad, 0xcd, 0x87, 0xc9, 0x8e,
+	0x09, 0x9c, 0x18, 0x1f, 0xbe, 0x02, 0xdb, 0x5a, 0x5c, 0x52, 0xee, 0x6d, 0x0d, 0xdd, 0x51, 0xff,
 	0xe8, 0x00, 0x99, 0xde, 0x50, 0xd3, 0x1b, 0xb2, 0xbd, 0xa1, 0x13, 0xc1, 0x78, 0xdc, 0xb9, 0x9d,
-	0x06, 0x4e, 0x62, 0x68, 0xb8, 0x0f, 0xba, 0x8a, 0xf2, 0x0b, 0x2a, 0xbd, 0x4e, 0x63, 0x98, 0xd8,
-	0x08, 0x0e, 0x40, 0x4f, 0x52, 0x42, 0x59, 0x4d, 0xa5, 0xb7, 0xdd, 0x2a, 0xab, 0x18, 0x7e, 0x06,
-	0xbb, 0x9a, 0x95, 0x54, 0x4c, 0xf4, 0x79, 0x41, 0x59, 0x5e, 0x68, 0xaf, 0xdb, 0x7a, 0x0e, 0x50,
-	0x33, 0x83, 0xe6, 0xbd, 0x90, 0x7d, 0xa5, 0x3a, 0x42, 0xef, 0x5a, 0x22, 0x7e, 0xda, 0x98, 0xfe,
-	0x69, 0x66, 0xb3, 0x3e, 0x4c, 0x76, 0x6c, 0xc2, 0xd0, 0xf0, 0x3d, 0x78, 0xb4, 0x24, 0x9a, 0xaf,
+	0x06, 0x4e, 0x62, 0x68, 0xb8, 0x0f, 0xba, 0x8a, 0xf2, 0x0b, 0x2a, 0xbd, 0x4e, 0x23, 0x98, 0x58,
+	0x0f, 0x0e, 0x40, 0x4f, 0x52, 0x42, 0x59, 0x4d, 0xa5, 0xb7, 0xdd, 0x66, 0x56, 0x3e, 0xfc, 0x0c,
+	0x76, 0x35, 0x2b, 0xa9, 0x98, 0xe8, 0xf3, 0x82, 0xb2, 0xbc, 0xd0, 0x5e, 0xb7, 0xd5, 0x1c, 0xa0,
+	0x66, 0x06, 0xcd, 0x7b, 0x21, 0xfb, 0x4a, 0x75, 0x84, 0xde, 0xb5, 0x44, 0xfc, 0xb4, 0x11, 0xfd,
+	0xd3, 0xcc, 0x66, 0x7d, 0x98, 0xec, 0xd8, 0x80, 0xa1, 0xe1, 0x7b, 0xf0, 0x68, 0x49, 0x34, 0xa7,
 	0xd2, 0x69, 0x59, 0x79, 0xf7, 0x87, 0xee, 0xa8, 0x13, 0x1f, 0x2e, 0xa6, 0x81, 0xb7, 0x79, 0xc9,
-	0x0a, 0x09, 0x93, 0x87, 0x36, 0x77, 0xb6, 0x4c, 0x1d, 0xf7, 0xbe, 0xde, 0x04, 0xce, 0xaf, 0x9b,
-	0xc0, 0x09, 0xf7, 0xc0, 0xe3, 0xb5, 0x59, 0x25, 0x54, 0x55, 0x82, 0x2b, 0x7a, 0x24, 0xc0, 0xd6,
-	0xa9, 0xca, 0x61, 0x01, 0x7a, 0xab, 0x31, 0x3e, 0x47, 0xff, 0x5a, 0x26, 0xb4, 0x76, 0xcb, 0x20,
-	0xfa, 0x6f, 0x74, 0x69, 0x18, 0x7f, 0xbc, 0x9d, 0xf9, 0xee, 0xdd, 0xcc, 0x77, 0x7f, 0xce, 0x7c,
-	0xf7, 0xdb, 0xdc, 0x77, 0xee, 0xe6, 0xbe, 0xf3, 0x63, 0xee, 0x3b, 0x9f, 0xde, 0xe4, 0x4c, 0x17,
-	0x93, 0x0c, 0x11, 0x51, 0x62, 0xbb, 0x9a, 0xe6, 0xf3, 0x42, 0x5d, 0x5c, 0xe2, 0x2b, 0xfc, 0xf7,
-	0x3f, 0x41, 0x5f, 0x57, 0x54, 0x65, 0xdd, 0x76, 0x2b, 0x5f, 0xfe, 0x0e, 0x00, 0x00, 0xff, 0xff,
-	0x26, 0x76, 0x5b, 0xfa, 0x33, 0x03, 0x00, 0x00,
+	0x0a, 0x09, 0x93, 0x87, 0x36, 0x76, 0xb6, 0x0c, 0x41, 0x08, 0x3a, 0x25, 0x2d, 0x85, 0xd7, 0x6b,
+	0x9b, 0x68, 0xed, 0xe3, 0xde, 0xd7, 0x9b, 0xc0, 0xf9, 0x75, 0x13, 0x38, 0xe1, 0x1e, 0x78, 0xbc,
+	0x36, 0xbf, 0x84, 0xaa, 0x4a, 0x70, 0x45, 0x8f, 0x04, 0xd8, 0x3a, 0x55, 0x39, 0x2c, 0x40, 0x6f,
+	0x35, 0xda, 0xe7, 0xe8, 0x5f, 0x0b, 0x86, 0xd6, 0x6e, 0x19, 0x44, 0xff, 0x8d, 0x2e, 0x05, 0xe3,
+	0x8f, 0xb7, 0x33, 0xdf, 0xbd, 0x9b, 0xf9, 0xee, 0xcf, 0x99, 0xef, 0x7e, 0x9b, 0xfb, 0xce, 0xdd,
+	0xdc, 0x77, 0x7e, 0xcc, 0x7d, 0xe7, 0xd3, 0x9b, 0x9c, 0xe9, 0x62, 0x92, 0x21, 0x22, 0x4a, 0x6c,
+	0xd7, 0xd5, 0x1c, 0x2f, 0xd4, 0xc5, 0x25, 0xbe, 0xc2, 0x7f, 0xff, 0x1d, 0xfa, 0xba, 0xa2, 0x2a,
+	0xeb, 0xb6, 0x9b, 0xfa, 0xf2, 0xf7, 0x00, 0xe5, 0x77, 0x44, 0x95, 0x47, 0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -272,6 +274,13 @@ func (m *MsgTransfer) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Memo) > 0 {
+		i -= len(m.Memo)
+		copy(dAtA[i:], m.Memo)
+		i = encodeVarintTx(dAtA, i, uint64(len(m.Memo)))
+		i--
+		dAtA[i] = 0x42
+	}
 	if m.TimeoutTimestamp != 0 {
 		i = encodeVarintTx(dAtA, i, uint64(m.TimeoutTimestamp))
 		i--
@@ -391,6 +400,10 @@ func (m *MsgTransfer) Size() (n int) {
 	if m.TimeoutTimestamp != 0 {
 		n += 1 + sovTx(uint64(m.TimeoutTimestamp))
 	}
+	l = len(m.Memo)
+	if l > 0 {
+		n += 1 + l + sovTx(uint64(l))
+	}
 	return n
 }
 
@@ -651,6 +664,38 @@ func (m *MsgTransfer) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Memo", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Memo = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTx(dAtA[iNdEx:])
@@ -0,0 +1,103 @@
+package types
+
+// Snapshot is a snapshot of the application state taken at a height, it is split into chunks which
+// are transferred between the nodes separately. The format and the metadata are opaque to tendermint.
+type Snapshot struct {
+	Height   uint64 `json:"height"`
+	Format   uint32 `json:"format"`
+	Chunks   uint32 `json:"chunks"`
+	Hash     []byte `json:"hash"`
+	Metadata []byte `json:"metadata"`
+}
+
+type RequestListSnapshots struct {
+}
+
+type ResponseListSnapshots struct {
+	Snapshots []*Snapshot `json:"snapshots"`
+}
+
+// RequestOfferSnapshot offers a snapshot discovered from the peers to the application,
+// AppHash is the light client verified app hash of the snapshot height
+type RequestOfferSnapshot struct {
+	Snapshot *Snapshot `json:"snapshot"`
+	AppHash  []byte    `json:"app_hash"`
+}
+
+type ResponseOfferSnapshot_Result int32
+
+const (
+	ResponseOfferSnapshot_UNKNOWN       ResponseOfferSnapshot_Result = 0
+	ResponseOfferSnapshot_ACCEPT        ResponseOfferSnapshot_Result = 1
+	ResponseOfferSnapshot_ABORT         ResponseOfferSnapshot_Result = 2
+	ResponseOfferSnapshot_REJECT        ResponseOfferSnapshot_Result = 3
+	ResponseOfferSnapshot_REJECT_FORMAT ResponseOfferSnapshot_Result = 4
+	ResponseOfferSnapshot_REJECT_SENDER ResponseOfferSnapshot_Result = 5
+)
+
+var responseOfferSnapshotResultNames = map[ResponseOfferSnapshot_Result]string{
+	ResponseOfferSnapshot_UNKNOWN:       "UNKNOWN",
+	ResponseOfferSnapshot_ACCEPT:        "ACCEPT",
+	ResponseOfferSnapshot_ABORT:         "ABORT",
+	ResponseOfferSnapshot_REJECT:        "REJECT",
+	ResponseOfferSnapshot_REJECT_FORMAT: "REJECT_FORMAT",
+	ResponseOfferSnapshot_REJECT_SENDER: "REJECT_SENDER",
+}
+
+func (r ResponseOfferSnapshot_Result) String() string {
+	return responseOfferSnapshotResultNames[r]
+}
+
+type ResponseOfferSnapshot struct {
+	Result ResponseOfferSnapshot_Result `json:"result"`
+}
+
+type RequestLoadSnapshotChunk struct {
+	Height uint64 `json:"height"`
+	Format uint32 `json:"format"`
+	Chunk  uint32 `json:"chunk"`
+}
+
+type ResponseLoadSnapshotChunk struct {
+	Chunk []byte `json:"chunk"`
+}
+
+// RequestApplySnapshotChunk applies a chunk of the accepted snapshot, Sender is the id of the peer
+// which sent the chunk
+type RequestApplySnapshotChunk struct {
+	Index  uint32 `json:"index"`
+	Chunk  []byte `json:"chunk"`
+	Sender string `json:"sender"`
+}
+
+type ResponseApplySnapshotChunk_Result int32
+
+const (
+	ResponseApplySnapshotChunk_UNKNOWN         ResponseApplySnapshotChunk_Result = 0
+	ResponseApplySnapshotChunk_ACCEPT          ResponseApplySnapshotChunk_Result = 1
+	ResponseApplySnapshotChunk_ABORT           ResponseApplySnapshotChunk_Result = 2
+	ResponseApplySnapshotChunk_RETRY           ResponseApplySnapshotChunk_Result = 3
+	ResponseApplySnapshotChunk_RETRY_SNAPSHOT  ResponseApplySnapshotChunk_Result = 4
+	ResponseApplySnapshotChunk_REJECT_SNAPSHOT ResponseApplySnapshotChunk_Result = 5
+)
+
+var responseApplySnapshotChunkResultNames = map[ResponseApplySnapshotChunk_Result]string{
+	ResponseApplySnapshotChunk_UNKNOWN:         "UNKNOWN",
+	ResponseApplySnapshotChunk_ACCEPT:          "ACCEPT",
+	ResponseApplySnapshotChunk_ABORT:           "ABORT",
+	ResponseApplySnapshotChunk_RETRY:           "RETRY",
+	ResponseApplySnapshotChunk_RETRY_SNAPSHOT:  "RETRY_SNAPSHOT",
+	ResponseApplySnapshotChunk_REJECT_SNAPSHOT: "REJECT_SNAPSHOT",
+}
+
+func (r ResponseApplySnapshotChunk_Result) String() string {
+	return responseApplySnapshotChunkResultNames[r]
+}
+
+// ResponseApplySnapshotChunk tells tendermint to refetch the chunks of RefetchChunks,
+// and to ban the peers of RejectSenders
+type ResponseApplySnapshotChunk struct {
+	Result        ResponseApplySnapshotChunk_Result `json:"result"`
+	RefetchChunks []uint32                          `json:"refetch_chunks"`
+	RejectSenders []string                          `json:"reject_senders"`
+}
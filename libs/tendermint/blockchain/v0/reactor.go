@@ -74,6 +74,7 @@ type BlockchainReactor struct {
 	fastSync     bool
 	autoFastSync bool
 	isSyncing    bool
+	stateSyncing bool
 	mtx          sync.RWMutex
 
 	requestsCh <-chan BlockRequest
@@ -218,7 +219,7 @@ func (bcR *BlockchainReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte)
 		// Got a peer status. Unverified. TODO: should verify before SetPeerRange
 		shouldSync := bcR.pool.SetPeerRange(src.ID(), msg.Base, msg.Height, bcR.store.Height())
 		// should switch to fast-sync when more than XX peers' height is greater than store.Height
-		if shouldSync {
+		if shouldSync && !bcR.getStateSyncing() {
 			bcR.Logger.Info("ShouldSync.", "Status peer", msg.Height, "now", bcR.store.Height())
 			go bcR.poolRoutine()
 		}
@@ -229,6 +230,32 @@ func (bcR *BlockchainReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte)
 	}
 }
 
+// SetStateSyncing keeps the reactor from switching to fast sync while the state sync is in progress
+func (bcR *BlockchainReactor) SetStateSyncing(value bool) {
+	bcR.mtx.Lock()
+	bcR.stateSyncing = value
+	bcR.mtx.Unlock()
+}
+
+func (bcR *BlockchainReactor) getStateSyncing() bool {
+	bcR.mtx.Lock()
+	defer bcR.mtx.Unlock()
+	return bcR.stateSyncing
+}
+
+// SwitchToFastSync is called by the state sync reactor when it has restored the state,
+// the reactor fast syncs the blocks after the snapshot height from the peers.
+func (bcR *BlockchainReactor) SwitchToFastSync(state sm.State) error {
+	bcR.mtx.Lock()
+	bcR.fastSync = true
+	bcR.stateSyncing = false
+	bcR.curState = state
+	bcR.mtx.Unlock()
+
+	go bcR.poolRoutine()
+	return nil
+}
+
 // Handle messages from the poolReactor telling the reactor what to do.
 // NOTE: Don't sleep in the FOR_LOOP or otherwise slow it down!
 func (bcR *BlockchainReactor) poolRoutine() {
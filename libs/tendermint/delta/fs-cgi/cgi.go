@@ -0,0 +1,219 @@
+package fs_cgi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/okex/exchain/libs/tendermint/delta"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/okex/exchain/libs/tendermint/types"
+)
+
+const (
+	lockerExpire = 4 * time.Second
+
+	// pruneInterval is the height interval at which expired deltas are removed
+	pruneInterval = 100
+)
+
+var (
+	mostRecentHeightFile string
+	deltaLockerFile      string
+	deltaFilePrefix      string
+)
+
+var once sync.Once
+
+var _ delta.DeltaBroker = (*FsClient)(nil)
+
+// init initialize the mostRecentHeightFile, deltaLockerFile and deltaFilePrefix
+// the names are based types.DeltaVersion, which can specified by user.
+func (f *FsClient) init() {
+	const (
+		mostRecentHeight = "MostRecentHeight"
+		deltaLocker      = "DeltaLocker"
+	)
+	once.Do(func() {
+		mostRecentHeightFile = fmt.Sprintf("dds-%d-%s", types.DeltaVersion, mostRecentHeight)
+		deltaLockerFile = fmt.Sprintf("dds-%d-%s.lock", types.DeltaVersion, deltaLocker)
+		deltaFilePrefix = fmt.Sprintf("DH-%d-", types.DeltaVersion)
+	})
+}
+
+// FsClient is a DeltaBroker keeping the deltas as files in a directory shared by
+// the producer and the consumers, a lock file takes the place of the redis locker.
+type FsClient struct {
+	dir    string
+	ttl    time.Duration
+	logger log.Logger
+}
+
+func NewFsClient(dir string, ttl time.Duration, l log.Logger) *FsClient {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		panic(fmt.Sprintf("failed to create delta directory %s: %s", dir, err))
+	}
+	fsClient := FsClient{dir, ttl, l}
+	fsClient.init()
+
+	return &fsClient
+}
+
+func (f *FsClient) GetLocker() bool {
+	path := filepath.Join(f.dir, deltaLockerFile)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if os.IsExist(err) {
+		// the locker expires like the redis one, in case its holder died without releasing it
+		info, statErr := os.Stat(path)
+		if statErr != nil || time.Since(info.ModTime()) < lockerExpire {
+			return false
+		}
+		if err = os.Remove(path); err != nil && !os.IsNotExist(err) {
+			f.logger.Error("Failed to remove expired locker", "err", err)
+			return false
+		}
+		file, err = os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	}
+	if err != nil {
+		if !os.IsExist(err) {
+			f.logger.Error("GetLocker err", "err", err)
+		}
+		return false
+	}
+	file.Close()
+	return true
+}
+
+func (f *FsClient) ReleaseLocker() {
+	err := os.Remove(filepath.Join(f.dir, deltaLockerFile))
+	if err != nil && !os.IsNotExist(err) {
+		f.logger.Error("Failed to Release Locker", "err", err)
+	}
+}
+
+// return bool: if change the value of latest_height, need to upload
+func (f *FsClient) ResetMostRecentHeightAfterUpload(targetHeight int64, upload func(int64) bool) (bool, int64, error) {
+	var res bool
+	mrh, err := f.readMostRecentHeight()
+	if err != nil {
+		return res, mrh, err
+	}
+
+	if mrh < targetHeight && upload(mrh) {
+		err = writeFileAtomic(filepath.Join(f.dir, mostRecentHeightFile), []byte(strconv.FormatInt(targetHeight, 10)))
+		if err == nil {
+			res = true
+			f.logger.Info("Reset most recent height", "new-mrh", targetHeight, "old-mrh", mrh)
+		} else {
+			f.logger.Error("Failed to reset most recent height",
+				"target-mrh", targetHeight,
+				"existing-mrh", mrh, "err", err)
+		}
+	}
+	return res, mrh, err
+}
+
+func (f *FsClient) SetDeltas(height int64, bytes []byte) error {
+	if len(bytes) == 0 {
+		return fmt.Errorf("delta is empty")
+	}
+	path := f.deltaPath(height)
+	// keep the first delta of a height, as SetNX does
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if err := writeFileAtomic(path, bytes); err != nil {
+		return err
+	}
+	if height%pruneInterval == 0 {
+		go f.pruneExpired()
+	}
+	return nil
+}
+
+func (f *FsClient) GetDeltas(height int64) ([]byte, error, int64) {
+	mrh := f.getMostRecentHeight()
+	path := f.deltaPath(height)
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) || (err == nil && f.expired(info)) {
+		return nil, fmt.Errorf("get empty delta"), mrh
+	}
+	if err != nil {
+		return nil, err, mrh
+	}
+	bytes, err := ioutil.ReadFile(path)
+	return bytes, err, mrh
+}
+
+func (f *FsClient) getMostRecentHeight() (mrh int64) {
+	mrh = -1
+	h, err := f.readMostRecentHeight()
+	if err == nil {
+		mrh = h
+	}
+	return
+}
+
+// readMostRecentHeight returns 0 if the most recent height has never been set
+func (f *FsClient) readMostRecentHeight() (int64, error) {
+	bz, err := ioutil.ReadFile(filepath.Join(f.dir, mostRecentHeightFile))
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(bz)), 10, 64)
+}
+
+func (f *FsClient) expired(info os.FileInfo) bool {
+	return f.ttl > 0 && time.Since(info.ModTime()) > f.ttl
+}
+
+// pruneExpired removes the delta files older than the ttl
+func (f *FsClient) pruneExpired() {
+	if f.ttl <= 0 {
+		return
+	}
+	infos, err := ioutil.ReadDir(f.dir)
+	if err != nil {
+		f.logger.Error("Failed to list deltas", "err", err)
+		return
+	}
+	for _, info := range infos {
+		if !strings.HasPrefix(info.Name(), deltaFilePrefix) || !f.expired(info) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(f.dir, info.Name())); err != nil && !os.IsNotExist(err) {
+			f.logger.Error("Failed to remove expired delta", "file", info.Name(), "err", err)
+		}
+	}
+}
+
+func (f *FsClient) deltaPath(height int64) string {
+	return filepath.Join(f.dir, deltaFilePrefix+strconv.FormatInt(height, 10))
+}
+
+// writeFileAtomic writes into a temporary file renamed afterwards, so that readers
+// never see a partially written file
+func writeFileAtomic(path string, bytes []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(bytes); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
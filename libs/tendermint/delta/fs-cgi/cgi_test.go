@@ -0,0 +1,115 @@
+package fs_cgi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ConstDeltaBytes = "delta-bytes"
+	ConstTestHeight = 1
+)
+
+func getFsClient(t *testing.T) *FsClient {
+	return NewFsClient(t.TempDir(), time.Minute, log.TestingLogger())
+}
+
+func TestFsClient_SetGetDeltas(t *testing.T) {
+	f := getFsClient(t)
+	require.True(t, f != nil, f)
+
+	height := int64(ConstTestHeight)
+	// delta is empty
+	re, err, _ := f.GetDeltas(height)
+	require.True(t, re == nil, re)
+	require.True(t, err != nil, err)
+
+	// set delta
+	bytes := []byte(ConstDeltaBytes)
+	err = f.SetDeltas(height, bytes)
+	require.Nil(t, err)
+
+	// get delta
+	re, err, _ = f.GetDeltas(height)
+	require.Equal(t, bytes, re)
+	require.True(t, err == nil, err)
+
+	// the first delta of a height is kept
+	err = f.SetDeltas(height, []byte("other"))
+	require.Nil(t, err)
+	re, _, _ = f.GetDeltas(height)
+	require.Equal(t, bytes, re)
+
+	// get wrong key
+	fakeKey := height + 1
+	noResult, err, _ := f.GetDeltas(fakeKey)
+	require.True(t, noResult == nil, noResult)
+	require.True(t, err != nil, err)
+}
+
+func TestFsClient_ExpiredDeltas(t *testing.T) {
+	f := NewFsClient(t.TempDir(), time.Millisecond, log.TestingLogger())
+
+	require.Nil(t, f.SetDeltas(ConstTestHeight, []byte(ConstDeltaBytes)))
+	time.Sleep(10 * time.Millisecond)
+
+	re, err, _ := f.GetDeltas(ConstTestHeight)
+	require.Nil(t, re)
+	require.NotNil(t, err)
+
+	f.pruneExpired()
+	_, err = os.Stat(f.deltaPath(ConstTestHeight))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestFsClient_Locker(t *testing.T) {
+	f := getFsClient(t)
+
+	require.True(t, f.GetLocker())
+	require.False(t, f.GetLocker())
+	f.ReleaseLocker()
+	require.True(t, f.GetLocker())
+
+	// an expired locker can be taken over
+	past := time.Now().Add(-2 * lockerExpire)
+	require.Nil(t, os.Chtimes(filepath.Join(f.dir, deltaLockerFile), past, past))
+	require.True(t, f.GetLocker())
+	f.ReleaseLocker()
+}
+
+func TestFsClient_ResetLatestHeightAfterUpload(t *testing.T) {
+	f := getFsClient(t)
+	require.True(t, f != nil, f)
+	uploadSuccess := func(int64) bool { return true }
+	uploadFailed := func(int64) bool { return false }
+	h := int64(ConstTestHeight)
+	type args struct {
+		height int64
+		upload func(int64) bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{"upload failed", args{h, uploadFailed}, false},
+		{"first time set", args{h, uploadSuccess}, true},
+		{"height<latestHeight", args{h - 1, uploadSuccess}, false},
+		{"height==latestHeight", args{h, uploadSuccess}, false},
+		{"height>latestHeight", args{h + 1, uploadSuccess}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, _ := f.ResetMostRecentHeightAfterUpload(tt.args.height, tt.args.upload)
+			if got != tt.want {
+				t.Errorf("ResetLatestHeightAfterUpload() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	require.Equal(t, h+1, f.getMostRecentHeight())
+}
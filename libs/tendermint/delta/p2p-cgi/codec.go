@@ -0,0 +1,11 @@
+package p2p_cgi
+
+import (
+	amino "github.com/tendermint/go-amino"
+)
+
+var cdc = amino.NewCodec()
+
+func init() {
+	RegisterMessages(cdc)
+}
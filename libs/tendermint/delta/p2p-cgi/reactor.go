@@ -0,0 +1,425 @@
+package p2p_cgi
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	amino "github.com/tendermint/go-amino"
+
+	"github.com/okex/exchain/libs/tendermint/crypto"
+	"github.com/okex/exchain/libs/tendermint/crypto/tmhash"
+	"github.com/okex/exchain/libs/tendermint/delta"
+	"github.com/okex/exchain/libs/tendermint/p2p"
+)
+
+const (
+	DeltaChannel = byte(0x70)
+
+	maxMsgSize = 64 * 1024 * 1024 // 64MB, deltas of large blocks are big
+
+	// requestInterval is the minimum interval between two requests for the same height
+	requestInterval = time.Second
+
+	// defaultHeightWindow is the number of heights below the highest one whose deltas are kept
+	defaultHeightWindow = 64
+	// defaultMaxTotalSize bounds the memory taken by the deltas kept
+	defaultMaxTotalSize = 512 * 1024 * 1024
+
+	maxSignatureSize = 64
+)
+
+var _ delta.DeltaBroker = (*Reactor)(nil)
+
+// Reactor is a DeltaBroker gossiping the deltas between peers over DeltaChannel,
+// it lets delta producers and consumers share deltas without a redis server.
+// Only the deltas signed by the producer are accepted, they are kept in memory for ttl,
+// within a window of heights and a total size, and forwarded to the other peers.
+type Reactor struct {
+	p2p.BaseReactor
+
+	ttl time.Duration
+
+	// producer is the key the deltas must be signed by
+	producer crypto.PubKey
+	// privKey signs the deltas set by this node, nil if it doesn't produce deltas
+	privKey crypto.PrivKey
+
+	heightWindow int64
+	maxTotalSize int
+
+	mtx       sync.Mutex
+	deltas    map[int64]*deltaEntry
+	totalSize int
+	maxHeight int64
+	mrh       int64
+	requested map[int64]time.Time
+
+	locked int32
+}
+
+type deltaEntry struct {
+	msg      *DeltaMessage
+	received time.Time
+}
+
+// NewReactor returns a new Reactor keeping the deltas signed by the producer for ttl.
+// privKey must be the one of the producer if the node produces deltas, nil otherwise.
+func NewReactor(ttl time.Duration, producer crypto.PubKey, privKey crypto.PrivKey) *Reactor {
+	dR := &Reactor{
+		ttl:          ttl,
+		producer:     producer,
+		privKey:      privKey,
+		heightWindow: defaultHeightWindow,
+		maxTotalSize: defaultMaxTotalSize,
+		deltas:       make(map[int64]*deltaEntry),
+		requested:    make(map[int64]time.Time),
+	}
+	dR.BaseReactor = *p2p.NewBaseReactor("Delta", dR)
+	return dR
+}
+
+// GetChannels implements Reactor.
+func (dR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
+	return []*p2p.ChannelDescriptor{
+		{
+			ID:                  DeltaChannel,
+			Priority:            3,
+			SendQueueCapacity:   10,
+			RecvMessageCapacity: maxMsgSize,
+		},
+	}
+}
+
+// AddPeer implements Reactor.
+func (dR *Reactor) AddPeer(peer p2p.Peer) {}
+
+// RemovePeer implements Reactor.
+func (dR *Reactor) RemovePeer(peer p2p.Peer, reason interface{}) {}
+
+// Receive implements Reactor.
+// It stores the received deltas signed by the producer, forwarding the new ones, and serves the delta requests.
+func (dR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
+	msg, err := decodeMsg(msgBytes)
+	if err != nil {
+		dR.Logger.Error("Error decoding message", "src", src, "chId", chID, "err", err)
+		dR.Switch.StopPeerForError(src, err)
+		return
+	}
+
+	if err = msg.ValidateBasic(); err != nil {
+		dR.Logger.Error("Peer sent us invalid msg", "peer", src, "msg", msg, "err", err)
+		dR.Switch.StopPeerForError(src, err)
+		return
+	}
+
+	dR.Logger.Debug("Receive", "src", src, "chId", chID, "msg", msg)
+
+	switch msg := msg.(type) {
+	case *DeltaMessage:
+		if err = msg.Verify(dR.producer); err != nil {
+			dR.Logger.Error("Peer sent us delta not signed by the producer", "peer", src, "msg", msg, "err", err)
+			dR.Switch.StopPeerForError(src, err)
+			return
+		}
+		if dR.store(msg) {
+			dR.broadcast(msg, src)
+		}
+	case *DeltaRequestMessage:
+		if entry := dR.getEntry(msg.Height); entry != nil {
+			src.TrySend(DeltaChannel, cdc.MustMarshalBinaryBare(entry.msg))
+		}
+	default:
+		dR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
+	}
+}
+
+// GetLocker implements DeltaBroker, the deltas are only uploaded by one routine of the node at a time.
+func (dR *Reactor) GetLocker() bool {
+	return atomic.CompareAndSwapInt32(&dR.locked, 0, 1)
+}
+
+// ReleaseLocker implements DeltaBroker.
+func (dR *Reactor) ReleaseLocker() {
+	atomic.StoreInt32(&dR.locked, 0)
+}
+
+// ResetMostRecentHeightAfterUpload implements DeltaBroker.
+// return bool: if change the value of latest_height, need to upload
+func (dR *Reactor) ResetMostRecentHeightAfterUpload(targetHeight int64, upload func(int64) bool) (bool, int64, error) {
+	mrh := dR.getMostRecentHeight()
+	if mrh < targetHeight && upload(mrh) {
+		dR.mtx.Lock()
+		if dR.mrh < targetHeight {
+			dR.mrh = targetHeight
+		}
+		dR.mtx.Unlock()
+		dR.Logger.Info("Reset most recent height", "new-mrh", targetHeight, "old-mrh", mrh)
+		return true, mrh, nil
+	}
+	return false, mrh, nil
+}
+
+// SetDeltas implements DeltaBroker, the deltas are signed, stored and broadcast to all the peers.
+func (dR *Reactor) SetDeltas(height int64, bytes []byte) error {
+	if dR.privKey == nil {
+		return fmt.Errorf("node is not the delta producer")
+	}
+	if len(bytes) == 0 {
+		return fmt.Errorf("delta is empty")
+	}
+	if len(bytes) > maxMsgSize {
+		return fmt.Errorf("delta exceeds max size (%d > %d)", len(bytes), maxMsgSize)
+	}
+	msg := &DeltaMessage{Height: height, Deltas: bytes, Timestamp: time.Now().UnixNano()}
+	if err := msg.Sign(dR.privKey); err != nil {
+		return err
+	}
+	if dR.store(msg) {
+		dR.broadcast(msg, nil)
+	}
+	return nil
+}
+
+// GetDeltas implements DeltaBroker. The peers are asked for the missing deltas.
+func (dR *Reactor) GetDeltas(height int64) ([]byte, error, int64) {
+	mrh := dR.getMostRecentHeight()
+	if bytes := dR.get(height); bytes != nil {
+		return bytes, nil, mrh
+	}
+	dR.request(height)
+	return nil, fmt.Errorf("get empty delta"), mrh
+}
+
+func (dR *Reactor) getMostRecentHeight() int64 {
+	dR.mtx.Lock()
+	defer dR.mtx.Unlock()
+	return dR.mrh
+}
+
+// store keeps the verified deltas of the height, replacing the ones signed earlier by the producer.
+// It returns false if they are already known, outdated or out of the height window.
+func (dR *Reactor) store(msg *DeltaMessage) bool {
+	dR.mtx.Lock()
+	defer dR.mtx.Unlock()
+
+	dR.pruneExpired()
+	if msg.Height <= dR.maxHeight-dR.heightWindow {
+		return false
+	}
+	if entry, ok := dR.deltas[msg.Height]; ok {
+		if entry.msg.Timestamp >= msg.Timestamp {
+			return false
+		}
+		dR.remove(msg.Height)
+	}
+	dR.deltas[msg.Height] = &deltaEntry{msg: msg, received: time.Now()}
+	dR.totalSize += len(msg.Deltas)
+	delete(dR.requested, msg.Height)
+	if dR.maxHeight < msg.Height {
+		dR.maxHeight = msg.Height
+	}
+	if dR.mrh < msg.Height {
+		dR.mrh = msg.Height
+	}
+	dR.pruneOutOfBounds()
+	return true
+}
+
+func (dR *Reactor) get(height int64) []byte {
+	if entry := dR.getEntry(height); entry != nil {
+		return entry.msg.Deltas
+	}
+	return nil
+}
+
+func (dR *Reactor) getEntry(height int64) *deltaEntry {
+	dR.mtx.Lock()
+	defer dR.mtx.Unlock()
+
+	entry, ok := dR.deltas[height]
+	if !ok || dR.expired(entry) {
+		return nil
+	}
+	return entry
+}
+
+// request asks the peers for the deltas of the height, at most once per requestInterval
+func (dR *Reactor) request(height int64) {
+	dR.mtx.Lock()
+	last, ok := dR.requested[height]
+	if ok && time.Since(last) < requestInterval {
+		dR.mtx.Unlock()
+		return
+	}
+	dR.requested[height] = time.Now()
+	dR.mtx.Unlock()
+
+	dR.broadcast(&DeltaRequestMessage{Height: height}, nil)
+}
+
+// broadcast sends the message to all the peers except the source
+func (dR *Reactor) broadcast(msg Message, src p2p.Peer) {
+	if dR.Switch == nil {
+		return
+	}
+	bz := cdc.MustMarshalBinaryBare(msg)
+	for _, peer := range dR.Switch.Peers().List() {
+		if src != nil && peer.ID() == src.ID() {
+			continue
+		}
+		peer.TrySend(DeltaChannel, bz)
+	}
+}
+
+func (dR *Reactor) expired(entry *deltaEntry) bool {
+	return dR.ttl > 0 && time.Since(entry.received) > dR.ttl
+}
+
+// remove deletes the deltas of the height, the caller must hold the mutex
+func (dR *Reactor) remove(height int64) {
+	if entry, ok := dR.deltas[height]; ok {
+		dR.totalSize -= len(entry.msg.Deltas)
+		delete(dR.deltas, height)
+	}
+}
+
+// pruneOutOfBounds removes the deltas below the height window, then the lowest ones
+// until the total size fits, the caller must hold the mutex
+func (dR *Reactor) pruneOutOfBounds() {
+	lowest := dR.maxHeight
+	for height := range dR.deltas {
+		if height <= dR.maxHeight-dR.heightWindow {
+			dR.remove(height)
+		} else if height < lowest {
+			lowest = height
+		}
+	}
+	for ; dR.totalSize > dR.maxTotalSize && lowest < dR.maxHeight; lowest++ {
+		dR.remove(lowest)
+	}
+}
+
+// pruneExpired removes the deltas older than the ttl, the caller must hold the mutex
+func (dR *Reactor) pruneExpired() {
+	for height, entry := range dR.deltas {
+		if dR.expired(entry) {
+			dR.remove(height)
+		}
+	}
+	for height, last := range dR.requested {
+		if time.Since(last) > requestInterval {
+			delete(dR.requested, height)
+		}
+	}
+}
+
+//-----------------------------------------------------------------------------
+// Messages
+
+// Message is a message sent or received by the Reactor.
+type Message interface {
+	ValidateBasic() error
+}
+
+func RegisterMessages(cdc *amino.Codec) {
+	cdc.RegisterInterface((*Message)(nil), nil)
+	cdc.RegisterConcrete(&DeltaMessage{},
+		"tendermint/delta/DeltaMessage", nil)
+	cdc.RegisterConcrete(&DeltaRequestMessage{},
+		"tendermint/delta/DeltaRequestMessage", nil)
+}
+
+func decodeMsg(bz []byte) (msg Message, err error) {
+	if len(bz) > maxMsgSize {
+		return msg, fmt.Errorf("msg exceeds max size (%d > %d)", len(bz), maxMsgSize)
+	}
+	err = cdc.UnmarshalBinaryBare(bz, &msg)
+	return
+}
+
+//-------------------------------------
+
+// DeltaMessage contains the marshaled deltas of a height signed by the delta producer.
+// Timestamp orders the deltas signed for the same height, the latest one is kept.
+type DeltaMessage struct {
+	Height    int64
+	Deltas    []byte
+	Timestamp int64
+	Signature []byte
+}
+
+// deltaSignBytes is signed by the producer instead of the whole deltas
+type deltaSignBytes struct {
+	Height     int64
+	DeltasHash []byte
+	Timestamp  int64
+}
+
+// SignBytes returns the bytes signed by the producer.
+func (m *DeltaMessage) SignBytes() []byte {
+	return cdc.MustMarshalBinaryBare(deltaSignBytes{
+		Height:     m.Height,
+		DeltasHash: tmhash.Sum(m.Deltas),
+		Timestamp:  m.Timestamp,
+	})
+}
+
+// Sign signs the message with the producer key.
+func (m *DeltaMessage) Sign(privKey crypto.PrivKey) (err error) {
+	m.Signature, err = privKey.Sign(m.SignBytes())
+	return err
+}
+
+// Verify checks the message is signed by the producer.
+func (m *DeltaMessage) Verify(producer crypto.PubKey) error {
+	if producer == nil {
+		return fmt.Errorf("delta producer is unknown")
+	}
+	if !producer.VerifyBytes(m.SignBytes(), m.Signature) {
+		return fmt.Errorf("invalid delta signature")
+	}
+	return nil
+}
+
+// ValidateBasic performs basic validation.
+func (m *DeltaMessage) ValidateBasic() error {
+	if m.Height <= 0 {
+		return fmt.Errorf("invalid height %d", m.Height)
+	}
+	if len(m.Deltas) == 0 {
+		return fmt.Errorf("delta is empty")
+	}
+	if len(m.Signature) == 0 {
+		return fmt.Errorf("delta is not signed")
+	}
+	if len(m.Signature) > maxSignatureSize {
+		return fmt.Errorf("signature exceeds max size (%d > %d)", len(m.Signature), maxSignatureSize)
+	}
+	return nil
+}
+
+// String returns a string representation of the DeltaMessage.
+func (m *DeltaMessage) String() string {
+	return fmt.Sprintf("[DeltaMessage %d %dB %d]", m.Height, len(m.Deltas), m.Timestamp)
+}
+
+// DeltaRequestMessage asks the peers for the deltas of a height.
+type DeltaRequestMessage struct {
+	Height int64
+}
+
+// ValidateBasic performs basic validation.
+func (m *DeltaRequestMessage) ValidateBasic() error {
+	if m.Height <= 0 {
+		return fmt.Errorf("invalid height %d", m.Height)
+	}
+	return nil
+}
+
+// String returns a string representation of the DeltaRequestMessage.
+func (m *DeltaRequestMessage) String() string {
+	return fmt.Sprintf("[DeltaRequestMessage %d]", m.Height)
+}
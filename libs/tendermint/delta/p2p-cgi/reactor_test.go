@@ -0,0 +1,212 @@
+package p2p_cgi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/okex/exchain/libs/tendermint/config"
+	"github.com/okex/exchain/libs/tendermint/crypto/ed25519"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/okex/exchain/libs/tendermint/p2p"
+)
+
+const (
+	ConstDeltaBytes = "delta-bytes"
+	ConstTestHeight = 1
+)
+
+// connect N delta reactors in a line, so that deltas have to be forwarded,
+// the first one is the delta producer
+func makeAndConnectReactors(n int) ([]*Reactor, []*p2p.Switch) {
+	producerKey := ed25519.GenPrivKey()
+	reactors := make([]*Reactor, n)
+	for i := 0; i < n; i++ {
+		if i == 0 {
+			reactors[i] = NewReactor(time.Minute, producerKey.PubKey(), producerKey)
+		} else {
+			reactors[i] = NewReactor(time.Minute, producerKey.PubKey(), nil)
+		}
+		reactors[i].SetLogger(log.TestingLogger().With("node", i))
+	}
+
+	switches := p2p.MakeConnectedSwitches(cfg.TestConfig().P2P, n, func(i int, s *p2p.Switch) *p2p.Switch {
+		s.AddReactor("DELTA", reactors[i])
+		return s
+	}, func(switches []*p2p.Switch, i, j int) {
+		if j == i+1 {
+			p2p.Connect2Switches(switches, i, j)
+		}
+	})
+	return reactors, switches
+}
+
+func TestReactor_GossipDeltas(t *testing.T) {
+	reactors, switches := makeAndConnectReactors(3)
+	defer func() {
+		for _, s := range switches {
+			s.Stop()
+		}
+	}()
+
+	bytes := []byte(ConstDeltaBytes)
+	require.Nil(t, reactors[0].SetDeltas(ConstTestHeight, bytes))
+
+	for _, r := range reactors[1:] {
+		r := r
+		require.Eventually(t, func() bool {
+			got, err, _ := r.GetDeltas(ConstTestHeight)
+			return err == nil && string(got) == ConstDeltaBytes
+		}, 5*time.Second, 10*time.Millisecond)
+		require.EqualValues(t, ConstTestHeight, r.getMostRecentHeight())
+	}
+}
+
+func TestReactor_RequestDeltas(t *testing.T) {
+	reactors, switches := makeAndConnectReactors(2)
+	defer func() {
+		for _, s := range switches {
+			s.Stop()
+		}
+	}()
+
+	// the delta is known by the first node only
+	msg := &DeltaMessage{Height: ConstTestHeight, Deltas: []byte(ConstDeltaBytes), Timestamp: 1}
+	require.Nil(t, msg.Sign(reactors[0].privKey))
+	require.True(t, reactors[0].store(msg))
+	_, err, _ := reactors[1].GetDeltas(ConstTestHeight)
+	require.NotNil(t, err)
+
+	// the miss above requested the delta from the peers
+	require.Eventually(t, func() bool {
+		got, err, _ := reactors[1].GetDeltas(ConstTestHeight)
+		return err == nil && string(got) == ConstDeltaBytes
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestReactor_ForgedDeltas(t *testing.T) {
+	reactors, switches := makeAndConnectReactors(2)
+	defer func() {
+		for _, s := range switches {
+			s.Stop()
+		}
+	}()
+
+	// the consumer can't produce deltas
+	require.NotNil(t, reactors[1].SetDeltas(ConstTestHeight, []byte(ConstDeltaBytes)))
+
+	// the peer sending deltas signed by another key is disconnected
+	forged := &DeltaMessage{Height: ConstTestHeight, Deltas: []byte(ConstDeltaBytes), Timestamp: 1}
+	require.Nil(t, forged.Sign(ed25519.GenPrivKey()))
+	require.NotNil(t, forged.Verify(reactors[0].producer))
+	peer := switches[1].Peers().List()[0]
+	reactors[1].Receive(DeltaChannel, peer, cdc.MustMarshalBinaryBare(forged))
+	require.Nil(t, reactors[1].get(ConstTestHeight))
+	require.Eventually(t, func() bool {
+		return switches[1].Peers().Size() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// unsigned deltas are invalid
+	unsigned := &DeltaMessage{Height: ConstTestHeight, Deltas: []byte(ConstDeltaBytes)}
+	require.NotNil(t, unsigned.ValidateBasic())
+}
+
+func TestReactor_ReplaceDeltas(t *testing.T) {
+	producerKey := ed25519.GenPrivKey()
+	r := NewReactor(time.Minute, producerKey.PubKey(), producerKey)
+	newMsg := func(deltas string, timestamp int64) *DeltaMessage {
+		msg := &DeltaMessage{Height: ConstTestHeight, Deltas: []byte(deltas), Timestamp: timestamp}
+		require.Nil(t, msg.Sign(producerKey))
+		return msg
+	}
+
+	require.True(t, r.store(newMsg("first", 2)))
+	// the deltas signed earlier don't take the place of the later ones
+	require.False(t, r.store(newMsg("earlier", 1)))
+	require.False(t, r.store(newMsg("first", 2)))
+	require.Equal(t, "first", string(r.get(ConstTestHeight)))
+	require.True(t, r.store(newMsg("later", 3)))
+	require.Equal(t, "later", string(r.get(ConstTestHeight)))
+	require.Equal(t, len("later"), r.totalSize)
+}
+
+func TestReactor_BoundedDeltas(t *testing.T) {
+	producerKey := ed25519.GenPrivKey()
+	r := NewReactor(time.Minute, producerKey.PubKey(), producerKey)
+	r.heightWindow = 3
+	r.maxTotalSize = 4 * len(ConstDeltaBytes)
+
+	for h := int64(1); h <= 5; h++ {
+		require.Nil(t, r.SetDeltas(h, []byte(ConstDeltaBytes)))
+	}
+	// the heights below the window are pruned and not stored anymore
+	require.Len(t, r.deltas, 3)
+	require.Nil(t, r.get(2))
+	require.NotNil(t, r.get(3))
+	require.Nil(t, r.SetDeltas(1, []byte(ConstDeltaBytes)))
+	require.Nil(t, r.get(1))
+
+	// the lowest heights are pruned when the total size is exceeded
+	require.Nil(t, r.SetDeltas(6, make([]byte, 3*len(ConstDeltaBytes)+1)))
+	require.Nil(t, r.get(4))
+	require.Nil(t, r.get(5))
+	require.NotNil(t, r.get(6))
+	require.LessOrEqual(t, r.totalSize, r.maxTotalSize)
+}
+
+func TestReactor_Locker(t *testing.T) {
+	r := NewReactor(time.Minute, nil, nil)
+	require.True(t, r.GetLocker())
+	require.False(t, r.GetLocker())
+	r.ReleaseLocker()
+	require.True(t, r.GetLocker())
+}
+
+func TestReactor_ResetLatestHeightAfterUpload(t *testing.T) {
+	r := NewReactor(time.Minute, nil, nil)
+	r.SetLogger(log.TestingLogger())
+	uploadSuccess := func(int64) bool { return true }
+	uploadFailed := func(int64) bool { return false }
+	h := int64(ConstTestHeight)
+	tests := []struct {
+		name   string
+		height int64
+		upload func(int64) bool
+		want   bool
+	}{
+		{"upload failed", h, uploadFailed, false},
+		{"first time set", h, uploadSuccess, true},
+		{"height<latestHeight", h - 1, uploadSuccess, false},
+		{"height==latestHeight", h, uploadSuccess, false},
+		{"height>latestHeight", h + 1, uploadSuccess, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, _ := r.ResetMostRecentHeightAfterUpload(tt.height, tt.upload)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReactor_ExpiredDeltas(t *testing.T) {
+	producerKey := ed25519.GenPrivKey()
+	r := NewReactor(time.Millisecond, producerKey.PubKey(), producerKey)
+	require.Nil(t, r.SetDeltas(ConstTestHeight, []byte(ConstDeltaBytes)))
+	time.Sleep(10 * time.Millisecond)
+
+	got, err, _ := r.GetDeltas(ConstTestHeight)
+	require.Nil(t, got)
+	require.NotNil(t, err)
+}
+
+func TestMessages_ValidateBasic(t *testing.T) {
+	sig := []byte{1}
+	require.Nil(t, (&DeltaMessage{Height: 1, Deltas: []byte{1}, Signature: sig}).ValidateBasic())
+	require.NotNil(t, (&DeltaMessage{Height: 0, Deltas: []byte{1}, Signature: sig}).ValidateBasic())
+	require.NotNil(t, (&DeltaMessage{Height: 1, Signature: sig}).ValidateBasic())
+	require.NotNil(t, (&DeltaMessage{Height: 1, Deltas: []byte{1}}).ValidateBasic())
+	require.NotNil(t, (&DeltaMessage{Height: 1, Deltas: []byte{1}, Signature: make([]byte, maxSignatureSize+1)}).ValidateBasic())
+	require.Nil(t, (&DeltaRequestMessage{Height: 1}).ValidateBasic())
+	require.NotNil(t, (&DeltaRequestMessage{Height: 0}).ValidateBasic())
+}
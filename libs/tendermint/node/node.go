@@ -28,10 +28,12 @@ import (
 	"github.com/okex/exchain/libs/tendermint/consensus"
 	cs "github.com/okex/exchain/libs/tendermint/consensus"
 	"github.com/okex/exchain/libs/tendermint/crypto"
+	p2p_cgi "github.com/okex/exchain/libs/tendermint/delta/p2p-cgi"
 	"github.com/okex/exchain/libs/tendermint/evidence"
 	"github.com/okex/exchain/libs/tendermint/libs/log"
 	tmpubsub "github.com/okex/exchain/libs/tendermint/libs/pubsub"
 	"github.com/okex/exchain/libs/tendermint/libs/service"
+	lite "github.com/okex/exchain/libs/tendermint/lite2"
 	mempl "github.com/okex/exchain/libs/tendermint/mempool"
 	"github.com/okex/exchain/libs/tendermint/p2p"
 	"github.com/okex/exchain/libs/tendermint/p2p/pex"
@@ -45,6 +47,7 @@ import (
 	"github.com/okex/exchain/libs/tendermint/state/txindex"
 	"github.com/okex/exchain/libs/tendermint/state/txindex/kv"
 	"github.com/okex/exchain/libs/tendermint/state/txindex/null"
+	"github.com/okex/exchain/libs/tendermint/statesync"
 	"github.com/okex/exchain/libs/tendermint/store"
 	"github.com/okex/exchain/libs/tendermint/types"
 	tmtime "github.com/okex/exchain/libs/tendermint/types/time"
@@ -172,9 +175,11 @@ type Node struct {
 	// services
 	eventBus         *types.EventBus // pub/sub for services
 	stateDB          dbm.DB
-	blockStore       *store.BlockStore // store the blockchain to disk
-	bcReactor        p2p.Reactor       // for fast-syncing
-	mempoolReactor   *mempl.Reactor    // for gossipping transactions
+	blockStore       *store.BlockStore  // store the blockchain to disk
+	bcReactor        p2p.Reactor        // for fast-syncing
+	stateSyncReactor *statesync.Reactor // for hosting and restoring state sync snapshots
+	stateSync        bool               // whether the node should state sync on startup
+	mempoolReactor   *mempl.Reactor     // for gossipping transactions
 	mempool          mempl.Mempool
 	consensusState   *cs.State      // latest consensus state
 	consensusReactor *cs.Reactor    // for participating in the consensus
@@ -481,6 +486,7 @@ func createSwitch(config *cfg.Config,
 	peerFilters []p2p.PeerFilterFunc,
 	mempoolReactor *mempl.Reactor,
 	bcReactor p2p.Reactor,
+	stateSyncReactor *statesync.Reactor,
 	consensusReactor *consensus.Reactor,
 	evidenceReactor *evidence.Reactor,
 	nodeInfo p2p.NodeInfo,
@@ -498,6 +504,7 @@ func createSwitch(config *cfg.Config,
 	sw.AddReactor("BLOCKCHAIN", bcReactor)
 	sw.AddReactor("CONSENSUS", consensusReactor)
 	sw.AddReactor("EVIDENCE", evidenceReactor)
+	sw.AddReactor("STATESYNC", stateSyncReactor)
 
 	sw.SetNodeInfo(nodeInfo)
 	sw.SetNodeKey(nodeKey)
@@ -554,6 +561,44 @@ func createPEXReactorAndAddToSwitch(addrBook pex.AddrBook, config *cfg.Config,
 	return pexReactor
 }
 
+// startStateSync starts an asynchronous state sync process, then switches to fast sync mode.
+func startStateSync(ssR *statesync.Reactor, bcR *bcv0.BlockchainReactor,
+	config *cfg.StateSyncConfig, stateDB dbm.DB, blockStore *store.BlockStore, chainID string) error {
+	ssR.Logger.Info("Starting state sync")
+
+	stateProvider, err := statesync.NewLightClientStateProvider(
+		chainID, config.RPCServers, lite.TrustOptions{
+			Period: config.TrustPeriod,
+			Height: config.TrustHeight,
+			Hash:   config.TrustHashBytes(),
+		}, ssR.Logger.With("module", "lite"))
+	if err != nil {
+		return fmt.Errorf("failed to set up light client state provider: %w", err)
+	}
+
+	go func() {
+		state, commit, err := ssR.Sync(stateProvider, config.DiscoveryTime)
+		if err != nil {
+			ssR.Logger.Error("State sync failed", "err", err)
+			return
+		}
+		sm.BootstrapState(stateDB, state)
+		err = blockStore.SaveSeenCommit(state.LastBlockHeight, commit)
+		if err != nil {
+			ssR.Logger.Error("Failed to store last seen commit", "err", err)
+			return
+		}
+		global.SetGlobalHeight(state.LastBlockHeight)
+
+		err = bcR.SwitchToFastSync(state)
+		if err != nil {
+			ssR.Logger.Error("Failed to switch to fast sync", "err", err)
+			return
+		}
+	}()
+	return nil
+}
+
 // NewNode returns a new, ready to go, Tendermint Node.
 func NewNode(config *cfg.Config,
 	privValidator types.PrivValidator,
@@ -632,6 +677,18 @@ func NewNode(config *cfg.Config,
 	fastSync := config.FastSyncMode && !onlyValidatorIsUs(state, pubKey)
 	autoFastSync := config.AutoFastSync
 
+	// Determine whether we should do state sync. This must happen after the handshake, since the
+	// app may modify the validator set, specifying ourself as the only validator.
+	stateSync := config.StateSync.Enable && !onlyValidatorIsUs(state, pubKey)
+	if stateSync && state.LastBlockHeight > 0 {
+		logger.Info("Found local state with non-zero height, skipping state sync")
+		stateSync = false
+	}
+	if stateSync && config.FastSync.Version != "v0" {
+		return nil, fmt.Errorf("state sync is only supported with fastsync version v0, got %v",
+			config.FastSync.Version)
+	}
+
 	csMetrics, p2pMetrics, memplMetrics, smMetrics := metricsProvider(genDoc.ChainID)
 
 	// Make MempoolReactor
@@ -643,6 +700,16 @@ func NewNode(config *cfg.Config,
 		return nil, err
 	}
 
+	// Make the delta reactor if deltas are gossiped between peers
+	blockExecOptions := []sm.BlockExecutorOption{sm.BlockExecutorWithMetrics(smMetrics)}
+	deltaReactor, err := sm.NewP2PDeltaBroker(logger.With("module", "delta"), nodeKey.PrivKey)
+	if err != nil {
+		return nil, err
+	}
+	if deltaReactor != nil {
+		blockExecOptions = append(blockExecOptions, sm.BlockExecutorWithDeltaBroker(deltaReactor))
+	}
+
 	// make block executor for consensus and blockchain reactors to execute blocks
 	blockExec := sm.NewBlockExecutor(
 		stateDB,
@@ -650,22 +717,32 @@ func NewNode(config *cfg.Config,
 		proxyApp.Consensus(),
 		mempool,
 		evidencePool,
-		sm.BlockExecutorWithMetrics(smMetrics),
+		blockExecOptions...,
 	)
 
 	// Make BlockchainReactor
-	bcReactor, err := createBlockchainReactor(config, state, blockExec, blockStore, deltasStore, fastSync, logger)
+	// The blockchain reactor waits for the state sync to switch it to fast sync.
+	bcReactor, err := createBlockchainReactor(config, state, blockExec, blockStore, deltasStore,
+		fastSync && !stateSync, logger)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not create blockchain reactor")
 	}
+	if stateSync {
+		bcReactor.(*bcv0.BlockchainReactor).SetStateSyncing(true)
+	}
 
-	// Make ConsensusReactor
+	// Make ConsensusReactor. Don't enable fully if doing a state sync and/or fast sync first.
 	consensusReactor, consensusState := createConsensusReactor(
 		config, state, blockExec, blockStore, deltasStore, mempool, evidencePool,
-		privValidator, csMetrics, fastSync, autoFastSync, eventBus, consensusLogger,
+		privValidator, csMetrics, fastSync || stateSync, autoFastSync, eventBus, consensusLogger,
 	)
 
-	nodeInfo, err := makeNodeInfo(config, nodeKey, txIndexer, genDoc, state)
+	// Set up state sync reactor, the sync itself is scheduled in OnStart if requested.
+	stateSyncReactor := statesync.NewReactor(proxyApp.Snapshot(), proxyApp.Query(),
+		config.StateSync.TempDir)
+	stateSyncReactor.SetLogger(logger.With("module", "statesync"))
+
+	nodeInfo, err := makeNodeInfo(config, nodeKey, txIndexer, genDoc, state, deltaReactor != nil)
 	if err != nil {
 		return nil, err
 	}
@@ -677,8 +754,11 @@ func NewNode(config *cfg.Config,
 	p2pLogger := logger.With("module", "p2p")
 	sw := createSwitch(
 		config, transport, p2pMetrics, peerFilters, mempoolReactor, bcReactor,
-		consensusReactor, evidenceReactor, nodeInfo, nodeKey, p2pLogger,
+		stateSyncReactor, consensusReactor, evidenceReactor, nodeInfo, nodeKey, p2pLogger,
 	)
+	if deltaReactor != nil {
+		sw.AddReactor("DELTA", deltaReactor)
+	}
 
 	err = sw.AddPersistentPeers(splitAndTrimEmpty(config.P2P.PersistentPeers, ",", " "))
 	if err != nil {
@@ -732,6 +812,8 @@ func NewNode(config *cfg.Config,
 		stateDB:          stateDB,
 		blockStore:       blockStore,
 		bcReactor:        bcReactor,
+		stateSyncReactor: stateSyncReactor,
+		stateSync:        stateSync,
 		mempoolReactor:   mempoolReactor,
 		mempool:          mempool,
 		consensusState:   consensusState,
@@ -809,6 +891,19 @@ func (n *Node) OnStart() error {
 		return errors.Wrap(err, "could not dial peers from persistent_peers field")
 	}
 
+	// Run state sync
+	if n.stateSync {
+		bcR, ok := n.bcReactor.(*bcv0.BlockchainReactor)
+		if !ok {
+			return fmt.Errorf("this blockchain reactor does not support switching from state sync")
+		}
+		err := startStateSync(n.stateSyncReactor, bcR, n.config.StateSync,
+			n.stateDB, n.blockStore, n.genesisDoc.ChainID)
+		if err != nil {
+			return fmt.Errorf("failed to start state sync: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -1102,6 +1197,7 @@ func makeNodeInfo(
 	txIndexer txindex.TxIndexer,
 	genDoc *types.GenesisDoc,
 	state sm.State,
+	p2pDelta bool,
 ) (p2p.NodeInfo, error) {
 	txIndexerStatus := "on"
 	if _, ok := txIndexer.(*null.TxIndex); ok {
@@ -1134,6 +1230,7 @@ func makeNodeInfo(
 			cs.StateChannel, cs.DataChannel, cs.VoteChannel, cs.VoteSetBitsChannel,
 			mempl.MempoolChannel,
 			evidence.EvidenceChannel,
+			statesync.SnapshotChannel, statesync.ChunkChannel,
 		},
 		Moniker: config.Moniker,
 		Other: p2p.DefaultNodeInfoOther{
@@ -1146,6 +1243,10 @@ func makeNodeInfo(
 		nodeInfo.Channels = append(nodeInfo.Channels, pex.PexChannel)
 	}
 
+	if p2pDelta {
+		nodeInfo.Channels = append(nodeInfo.Channels, p2p_cgi.DeltaChannel)
+	}
+
 	lAddr := config.P2P.ExternalAddress
 
 	if lAddr == "" {
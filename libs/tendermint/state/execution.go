@@ -7,6 +7,7 @@ import (
 
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
 	cfg "github.com/okex/exchain/libs/tendermint/config"
+	"github.com/okex/exchain/libs/tendermint/delta"
 	"github.com/okex/exchain/libs/tendermint/global"
 	"github.com/okex/exchain/libs/tendermint/libs/automation"
 	"github.com/okex/exchain/libs/tendermint/libs/fail"
@@ -61,6 +62,14 @@ func BlockExecutorWithMetrics(metrics *Metrics) BlockExecutorOption {
 	}
 }
 
+// BlockExecutorWithDeltaBroker sets the broker the deltas are uploaded to and downloaded from,
+// it takes the place of the one selected by the delta-broker flag.
+func BlockExecutorWithDeltaBroker(broker delta.DeltaBroker) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.deltaContext.deltaBroker = broker
+	}
+}
+
 // NewBlockExecutor returns a new BlockExecutor with a NopEventBus.
 // Call SetEventBus to provide one.
 func NewBlockExecutor(
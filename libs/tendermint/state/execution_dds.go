@@ -1,13 +1,18 @@
 package state
 
 import (
+	"encoding/hex"
 	"fmt"
 	"sync/atomic"
 	"time"
 
 	"github.com/okex/exchain/libs/iavl"
 	"github.com/okex/exchain/libs/system"
+	"github.com/okex/exchain/libs/tendermint/crypto"
+	"github.com/okex/exchain/libs/tendermint/crypto/ed25519"
 	"github.com/okex/exchain/libs/tendermint/delta"
+	fs_cgi "github.com/okex/exchain/libs/tendermint/delta/fs-cgi"
+	p2p_cgi "github.com/okex/exchain/libs/tendermint/delta/p2p-cgi"
 	redis_cgi "github.com/okex/exchain/libs/tendermint/delta/redis-cgi"
 	"github.com/okex/exchain/libs/tendermint/libs/log"
 	"github.com/okex/exchain/libs/tendermint/trace"
@@ -93,15 +98,10 @@ func (dc *DeltaContext) init() {
 		if dc.bufferSize < 5 {
 			dc.bufferSize = 5
 		}
-		url := viper.GetString(types.FlagRedisUrl)
-		auth := viper.GetString(types.FlagRedisAuth)
-		expire := time.Duration(viper.GetInt(types.FlagRedisExpire)) * time.Second
-		dbNum := viper.GetInt(types.FlagRedisDB)
-		if dbNum < 0 || dbNum > 15 {
-			panic("delta-redis-db only support 0~15")
+		// the p2p broker is a reactor, injected by the node
+		if dc.deltaBroker == nil {
+			dc.deltaBroker = dc.newDeltaBroker()
 		}
-		dc.deltaBroker = redis_cgi.NewRedisClient(url, auth, expire, dbNum, dc.logger)
-		dc.logger.Info("Init delta broker", "url", url)
 	}
 
 	// control if iavl produce delta or not
@@ -119,6 +119,71 @@ func (dc *DeltaContext) init() {
 
 }
 
+func (dc *DeltaContext) newDeltaBroker() delta.DeltaBroker {
+	expire := time.Duration(viper.GetInt(types.FlagRedisExpire)) * time.Second
+	switch broker := viper.GetString(types.FlagDeltaBroker); broker {
+	case types.DeltaBrokerRedis, "":
+		url := viper.GetString(types.FlagRedisUrl)
+		auth := viper.GetString(types.FlagRedisAuth)
+		dbNum := viper.GetInt(types.FlagRedisDB)
+		if dbNum < 0 || dbNum > 15 {
+			panic("delta-redis-db only support 0~15")
+		}
+		dc.logger.Info("Init delta broker", "url", url)
+		return redis_cgi.NewRedisClient(url, auth, expire, dbNum, dc.logger)
+	case types.DeltaBrokerFs:
+		path := viper.GetString(types.FlagDeltaFsPath)
+		if path == "" {
+			panic("delta-fs-path is required by the fs delta broker")
+		}
+		dc.logger.Info("Init delta broker", "path", path)
+		return fs_cgi.NewFsClient(path, expire, dc.logger)
+	case types.DeltaBrokerP2P:
+		panic("p2p delta broker must be set by BlockExecutorWithDeltaBroker")
+	default:
+		panic(fmt.Sprintf("unknown delta broker %s", broker))
+	}
+}
+
+// NewP2PDeltaBroker returns the reactor gossiping deltas between peers if the p2p
+// delta broker is selected and deltas are enabled, nil otherwise.
+// The delta producer signs the deltas with its node key, the consumers verify them
+// against the producer pubkey set by the delta-p2p-producer flag.
+func NewP2PDeltaBroker(l log.Logger, nodeKey crypto.PrivKey) (*p2p_cgi.Reactor, error) {
+	if !(types.UploadDelta || types.DownloadDelta) || viper.GetString(types.FlagDeltaBroker) != types.DeltaBrokerP2P {
+		return nil, nil
+	}
+
+	var producer crypto.PubKey
+	if str := viper.GetString(types.FlagDeltaP2PProducer); str != "" {
+		bz, err := hex.DecodeString(str)
+		if err != nil || len(bz) != ed25519.PubKeyEd25519Size {
+			return nil, fmt.Errorf("invalid %s: %s", types.FlagDeltaP2PProducer, str)
+		}
+		var pubKey ed25519.PubKeyEd25519
+		copy(pubKey[:], bz)
+		producer = pubKey
+	}
+
+	var privKey crypto.PrivKey
+	if types.UploadDelta {
+		privKey = nodeKey
+		if producer == nil {
+			producer = nodeKey.PubKey()
+		} else if !producer.Equals(nodeKey.PubKey()) {
+			return nil, fmt.Errorf("%s doesn't match the node key of the delta producer", types.FlagDeltaP2PProducer)
+		}
+	}
+	if producer == nil {
+		return nil, fmt.Errorf("%s is required to download deltas from the p2p delta broker", types.FlagDeltaP2PProducer)
+	}
+
+	expire := time.Duration(viper.GetInt(types.FlagRedisExpire)) * time.Second
+	reactor := p2p_cgi.NewReactor(expire, producer, privKey)
+	reactor.SetLogger(l)
+	return reactor, nil
+}
+
 func (dc *DeltaContext) setIdentity() {
 
 	var err error
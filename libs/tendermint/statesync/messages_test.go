@@ -0,0 +1,65 @@
+package statesync
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ssproto "github.com/okex/exchain/libs/tendermint/proto/statesync"
+)
+
+func TestValidateMsg(t *testing.T) {
+	testcases := map[string]struct {
+		msg   proto.Message
+		valid bool
+	}{
+		"nil":       {nil, false},
+		"unrelated": {&ssproto.Message{}, false},
+
+		"ChunkRequest valid":    {&ssproto.ChunkRequest{Height: 1, Format: 1, Index: 1}, true},
+		"ChunkRequest 0 height": {&ssproto.ChunkRequest{Height: 0, Format: 1, Index: 1}, false},
+
+		"ChunkResponse valid":            {&ssproto.ChunkResponse{Height: 1, Format: 1, Index: 1, Chunk: []byte{1}}, true},
+		"ChunkResponse 0 height":         {&ssproto.ChunkResponse{Height: 0, Format: 1, Index: 1, Chunk: []byte{1}}, false},
+		"ChunkResponse empty body":       {&ssproto.ChunkResponse{Height: 1, Format: 1, Index: 1, Chunk: []byte{}}, true},
+		"ChunkResponse nil body":         {&ssproto.ChunkResponse{Height: 1, Format: 1, Index: 1, Chunk: nil}, false},
+		"ChunkResponse missing":          {&ssproto.ChunkResponse{Height: 1, Format: 1, Index: 1, Missing: true}, true},
+		"ChunkResponse missing with msg": {&ssproto.ChunkResponse{Height: 1, Format: 1, Index: 1, Chunk: []byte{1}, Missing: true}, false},
+
+		"SnapshotsRequest valid": {&ssproto.SnapshotsRequest{}, true},
+
+		"SnapshotsResponse valid":    {&ssproto.SnapshotsResponse{Height: 1, Format: 1, Chunks: 2, Hash: []byte{1}}, true},
+		"SnapshotsResponse 0 height": {&ssproto.SnapshotsResponse{Height: 0, Format: 1, Chunks: 2, Hash: []byte{1}}, false},
+		"SnapshotsResponse 0 chunks": {&ssproto.SnapshotsResponse{Height: 1, Format: 1, Hash: []byte{1}}, false},
+		"SnapshotsResponse no hash":  {&ssproto.SnapshotsResponse{Height: 1, Format: 1, Chunks: 2, Hash: []byte{}}, false},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			err := validateMsg(tc.msg)
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestEncodeDecodeMsg(t *testing.T) {
+	msgs := []proto.Message{
+		&ssproto.SnapshotsRequest{},
+		&ssproto.SnapshotsResponse{Height: 1, Format: 2, Chunks: 3, Hash: []byte{4}, Metadata: []byte{5}},
+		&ssproto.ChunkRequest{Height: 1, Format: 2, Index: 3},
+		&ssproto.ChunkResponse{Height: 1, Format: 2, Index: 3, Chunk: []byte{4}},
+	}
+	for _, msg := range msgs {
+		decoded, err := decodeMsg(mustEncodeMsg(msg))
+		require.NoError(t, err)
+		assert.Equal(t, msg.String(), decoded.String())
+	}
+
+	assert.Panics(t, func() { mustEncodeMsg(&ssproto.Message{}) })
+}
@@ -0,0 +1,170 @@
+package statesync
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/libs/tendermint/p2p"
+	"github.com/okex/exchain/libs/tendermint/p2p/mock"
+	sm "github.com/okex/exchain/libs/tendermint/state"
+	"github.com/okex/exchain/libs/tendermint/types"
+)
+
+// fakeStateProvider returns the app hash of a height as the height itself
+type fakeStateProvider struct{}
+
+func (fakeStateProvider) AppHash(height uint64) ([]byte, error) {
+	return []byte{byte(height)}, nil
+}
+
+func (fakeStateProvider) Commit(height uint64) (*types.Commit, error) {
+	return &types.Commit{Height: int64(height)}, nil
+}
+
+func (fakeStateProvider) State(height uint64) (sm.State, error) {
+	return sm.State{LastBlockHeight: int64(height)}, nil
+}
+
+func newTestPeer(ip byte) p2p.Peer {
+	return mock.NewPeer(net.IP{10, 0, 0, ip})
+}
+
+func TestSnapshot_Key(t *testing.T) {
+	testcases := map[string]struct {
+		modify func(*snapshot)
+	}{
+		"new height":      {func(s *snapshot) { s.Height = 9 }},
+		"new format":      {func(s *snapshot) { s.Format = 9 }},
+		"new chunk count": {func(s *snapshot) { s.Chunks = 9 }},
+		"new hash":        {func(s *snapshot) { s.Hash = []byte{9} }},
+		"no metadata":     {func(s *snapshot) { s.Metadata = nil }},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			s := snapshot{
+				Height:   3,
+				Format:   1,
+				Chunks:   7,
+				Hash:     []byte{1, 2, 3},
+				Metadata: []byte{255},
+			}
+			before := s.Key()
+			tc.modify(&s)
+			after := s.Key()
+			assert.NotEqual(t, before, after)
+		})
+	}
+}
+
+func TestSnapshotPool_Add(t *testing.T) {
+	peer := newTestPeer(1)
+	pool := newSnapshotPool(fakeStateProvider{})
+
+	added, err := pool.Add(peer, &snapshot{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1}})
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	// Adding to the pool should have added the peer and the trusted app hash
+	assert.Equal(t, []p2p.Peer{peer}, pool.GetPeers(pool.Best()))
+	assert.Equal(t, []byte{1}, pool.Best().trustedAppHash)
+
+	// Adding again from a different peer should return false, but add the peer
+	otherPeer := newTestPeer(2)
+	added, err = pool.Add(otherPeer, &snapshot{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1}})
+	require.NoError(t, err)
+	assert.False(t, added)
+	assert.Len(t, pool.GetPeers(pool.Best()), 2)
+}
+
+func TestSnapshotPool_Ranked_Best(t *testing.T) {
+	pool := newSnapshotPool(fakeStateProvider{})
+
+	// snapshots in expected order (best to worst), the first has the most peers
+	expectSnapshots := []struct {
+		snapshot *snapshot
+		peers    []byte
+	}{
+		{&snapshot{Height: 2, Format: 2, Chunks: 4, Hash: []byte{1, 3}}, []byte{1, 2, 3}},
+		{&snapshot{Height: 2, Format: 2, Chunks: 5, Hash: []byte{1, 2}}, []byte{3}},
+		{&snapshot{Height: 2, Format: 1, Chunks: 3, Hash: []byte{1, 2}}, []byte{1, 3}},
+		{&snapshot{Height: 1, Format: 2, Chunks: 5, Hash: []byte{1, 2}}, []byte{2, 3}},
+		{&snapshot{Height: 1, Format: 1, Chunks: 4, Hash: []byte{1, 2}}, []byte{1, 2, 3}},
+	}
+
+	// Add snapshots in reverse order, to make sure the pool enforces some order.
+	for i := len(expectSnapshots) - 1; i >= 0; i-- {
+		for _, ip := range expectSnapshots[i].peers {
+			_, err := pool.Add(newTestPeer(ip), expectSnapshots[i].snapshot)
+			require.NoError(t, err)
+		}
+	}
+
+	ranked := pool.Ranked()
+	require.Len(t, ranked, len(expectSnapshots))
+	for i := range ranked {
+		assert.Equal(t, expectSnapshots[i].snapshot, ranked[i])
+	}
+	assert.Equal(t, expectSnapshots[0].snapshot, pool.Best())
+}
+
+func TestSnapshotPool_Reject(t *testing.T) {
+	pool := newSnapshotPool(fakeStateProvider{})
+	peer := newTestPeer(1)
+
+	snapshots := []*snapshot{
+		{Height: 2, Format: 2, Chunks: 1, Hash: []byte{1, 2}},
+		{Height: 2, Format: 1, Chunks: 1, Hash: []byte{1, 2}},
+		{Height: 1, Format: 2, Chunks: 1, Hash: []byte{1, 2}},
+		{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1, 2}},
+	}
+	for _, s := range snapshots {
+		_, err := pool.Add(peer, s)
+		require.NoError(t, err)
+	}
+
+	pool.Reject(snapshots[0])
+	assert.Equal(t, snapshots[1:], pool.Ranked())
+
+	// a rejected snapshot can't be added again
+	added, err := pool.Add(peer, snapshots[0])
+	require.NoError(t, err)
+	assert.False(t, added)
+
+	pool.RejectFormat(1)
+	assert.Equal(t, []*snapshot{snapshots[2]}, pool.Ranked())
+}
+
+func TestSnapshotPool_RejectPeer(t *testing.T) {
+	pool := newSnapshotPool(fakeStateProvider{})
+	peerA := newTestPeer(1)
+	peerB := newTestPeer(2)
+
+	s1 := &snapshot{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1}}
+	s2 := &snapshot{Height: 2, Format: 1, Chunks: 1, Hash: []byte{2}}
+	s3 := &snapshot{Height: 3, Format: 1, Chunks: 1, Hash: []byte{2}}
+
+	_, err := pool.Add(peerA, s1)
+	require.NoError(t, err)
+	_, err = pool.Add(peerA, s2)
+	require.NoError(t, err)
+	_, err = pool.Add(peerB, s2)
+	require.NoError(t, err)
+	_, err = pool.Add(peerB, s3)
+	require.NoError(t, err)
+
+	// the snapshots only peer A has are removed along with the peer
+	pool.RejectPeer(peerA.ID())
+	assert.Empty(t, pool.GetPeers(s1))
+	assert.Equal(t, []p2p.Peer{peerB}, pool.GetPeers(s2))
+	assert.Equal(t, []*snapshot{s3, s2}, pool.Ranked())
+
+	// a rejected peer can't add snapshots again
+	added, err := pool.Add(peerA, s1)
+	require.NoError(t, err)
+	assert.False(t, added)
+	assert.Equal(t, []*snapshot{s3, s2}, pool.Ranked())
+}
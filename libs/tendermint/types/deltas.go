@@ -17,6 +17,14 @@ const (
 	FlagDDSCompressType = "compress-type"
 	FlagDDSCompressFlag = "compress-flag"
 
+	// FlagDeltaBroker selects where the deltas are exchanged: redis, fs or p2p
+	FlagDeltaBroker = "delta-broker"
+	// FlagDeltaFsPath is the directory shared by the fs broker
+	FlagDeltaFsPath = "delta-fs-path"
+	// FlagDeltaP2PProducer is the hex encoded ed25519 node pubkey of the delta producer,
+	// the p2p broker only accepts the deltas signed by it
+	FlagDeltaP2PProducer = "delta-p2p-producer"
+
 	// redis
 	// url fmt (ip:port)
 	FlagRedisUrl  = "delta-redis-url"
@@ -37,6 +45,12 @@ var (
 	DeltaVersion = 9
 )
 
+const (
+	DeltaBrokerRedis = "redis"
+	DeltaBrokerFs    = "fs"
+	DeltaBrokerP2P   = "p2p"
+)
+
 var (
 	FastQuery     = false
 	DownloadDelta = false
@@ -24,6 +24,9 @@ var (
 	MILESTONE_VENUS1_HEIGHT string
 	milestoneVenus1Height   int64
 
+	MILESTONE_VENUS2_HEIGHT string
+	milestoneVenus2Height   int64
+
 	once sync.Once
 )
 
@@ -33,6 +36,7 @@ func init() {
 		milestoneMercuryHeight = string2number(MILESTONE_MERCURY_HEIGHT)
 		milestoneVenusHeight = string2number(MILESTONE_VENUS_HEIGHT)
 		milestoneVenus1Height = string2number(MILESTONE_VENUS1_HEIGHT)
+		milestoneVenus2Height = string2number(MILESTONE_VENUS2_HEIGHT)
 	})
 }
 
@@ -114,3 +118,23 @@ func GetVenus1Height() int64 {
 }
 // =========== Venus1 ===============
 // ==================================
+
+// ==================================
+// =========== Venus2 ===============
+func HigherThanVenus2(h int64) bool {
+	if milestoneVenus2Height == 0 {
+		return false
+	}
+	return h >= milestoneVenus2Height
+}
+
+// can be used in unit test only
+func UnittestOnlySetMilestoneVenus2Height(h int64) {
+	milestoneVenus2Height = h
+}
+
+func GetVenus2Height() int64 {
+	return milestoneVenus2Height
+}
+// =========== Venus2 ===============
+// ==================================
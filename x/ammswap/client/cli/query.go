@@ -12,6 +12,7 @@ import (
 	"github.com/okex/exchain/x/ammswap/types"
 	"github.com/spf13/cobra"
 	"strings"
+	"time"
 )
 
 // GetQueryCmd returns the cli query commands for this module
@@ -32,6 +33,8 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			GetCmdAllSwapTokenPairs(queryRoute, cdc),
 			GetCmdRedeemableAssets(queryRoute, cdc),
 			GetCmdQueryBuyAmount(queryRoute, cdc),
+			GetCmdQueryBestSwapPath(queryRoute, cdc),
+			GetCmdQueryTWAP(queryRoute, cdc),
 		)...,
 	)
 
@@ -109,6 +112,83 @@ $ %s query swap amount 100eth-245 xxb`, version.ClientName,
 	}
 }
 
+// GetCmdQueryBestSwapPath queries the swap path which buys the most token by the given amount of token to sell
+func GetCmdQueryBestSwapPath(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "best-path [token-to-sell] [token-name-to-buy]",
+		Short: "Query the swap path which returns the most token by the given amount of token to sell",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(
+				`Query the swap path which returns the most token by the given amount of token to sell.
+
+Example:
+$ %s query swap best-path 100eth-245 xxb`, version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			sellToken, err := sdk.ParseDecCoin(args[0])
+			if err != nil {
+				return err
+			}
+			params := types.NewQueryBestSwapPathParams(sellToken, args[1])
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryBestSwapPath), bz)
+			if err != nil {
+				return err
+			}
+
+			var pathInfo types.SwapPathInfo
+			cdc.MustUnmarshalJSON(res, &pathInfo)
+
+			return cliCtx.PrintOutput(pathInfo)
+		},
+	}
+}
+
+// GetCmdQueryTWAP queries the time-weighted average prices of a pool over a window before the latest block
+func GetCmdQueryTWAP(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "twap [base-token] [quote-token] [window]",
+		Short: "Query the time-weighted average prices of a pool",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(
+				`Query the time-weighted average prices of a pool over a window before the latest block.
+
+Example:
+$ %s query swap twap eth-355 okt 1h`, version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			window, err := time.ParseDuration(args[2])
+			if err != nil {
+				return err
+			}
+			swapTokenPairName := types.GetSwapTokenPairName(args[0], args[1])
+			params := types.NewQueryTWAPParams(swapTokenPairName, int64(window.Seconds()))
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryTWAP), bz)
+			if err != nil {
+				return err
+			}
+
+			var twap types.TWAP
+			cdc.MustUnmarshalJSON(res, &twap)
+
+			return cliCtx.PrintOutput(twap)
+		},
+	}
+}
+
 // GetCmdQueryParams queries the parameters of the AMM swap system
 func GetCmdQueryParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
@@ -30,6 +30,9 @@ const (
 	flagRecipient        = "recipient"
 	flagToken0           = "token0"
 	flagToken1           = "token1"
+	flagPath             = "path"
+	flagBuyAmount        = "buy-amount"
+	flagMaxSellAmount    = "max-sell-amount"
 )
 
 // GetTxCmd returns the transaction commands for this module
@@ -44,6 +47,8 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 		getCmdRemoveLiquidity(cdc),
 		getCmdCreateExchange(cdc),
 		getCmdTokenSwap(cdc),
+		getCmdSwapExactIn(cdc),
+		getCmdSwapExactOut(cdc),
 	)...)
 
 	return txCmd
@@ -257,3 +262,138 @@ $ exchaincli tx swap token --sell-amount 1eth-355 --min-buy-amount 60btc-366
 
 	return cmd
 }
+
+func getCmdSwapExactIn(cdc *codec.Codec) *cobra.Command {
+	// flags
+	var path string
+	var soldTokenAmount string
+	var minBoughtTokenAmount string
+	var deadline string
+	var recipient string
+	cmd := &cobra.Command{
+		Use:   "swap-exact-in",
+		Short: "swap an exact amount of token through a path of swap token pairs",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`swap an exact amount of token through a path of swap token pairs.
+
+Example:
+$ exchaincli tx swap swap-exact-in --path eth-355,okt,btc-366 --sell-amount 1eth-355 --min-buy-amount 60btc-366
+
+`),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			soldTokenAmount, err := sdk.ParseDecCoin(soldTokenAmount)
+			if err != nil {
+				return err
+			}
+			minBoughtTokenAmount, err := sdk.ParseDecCoin(minBoughtTokenAmount)
+			if err != nil {
+				return err
+			}
+			deadline, recip, err := parseDeadlineAndRecipient(cliCtx, deadline, recipient)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSwapExactIn(strings.Split(path, ","), soldTokenAmount, minBoughtTokenAmount,
+				deadline, recip, cliCtx.FromAddress)
+
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().StringVarP(&path, flagPath, "", "",
+		"Comma separated tokens to swap through, from the token to sell to the token to buy")
+	cmd.Flags().StringVarP(&soldTokenAmount, flagSellAmount, "", "",
+		"Amount expected to sell")
+	cmd.Flags().StringVarP(&minBoughtTokenAmount, flagMinBuyAmount, "", "",
+		"Minimum amount expected to buy")
+	cmd.Flags().StringVarP(&recipient, flagRecipient, "", "",
+		"The address to receive the amount bought")
+	cmd.Flags().StringVarP(&deadline, flagDeadlineDuration, "", "100s",
+		"Duration after which this transaction can no longer be executed. such as \"300ms\", \"1.5h\" or \"2h45m\". Valid time units are \"ns\", \"us\" (or \"µs\"), \"ms\", \"s\", \"m\", \"h\".")
+	cmd.MarkFlagRequired(flagPath)
+	cmd.MarkFlagRequired(flagSellAmount)
+	cmd.MarkFlagRequired(flagMinBuyAmount)
+
+	return cmd
+}
+
+func getCmdSwapExactOut(cdc *codec.Codec) *cobra.Command {
+	// flags
+	var path string
+	var boughtTokenAmount string
+	var maxSoldTokenAmount string
+	var deadline string
+	var recipient string
+	cmd := &cobra.Command{
+		Use:   "swap-exact-out",
+		Short: "swap token for an exact amount of token through a path of swap token pairs",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`swap token for an exact amount of token through a path of swap token pairs.
+
+Example:
+$ exchaincli tx swap swap-exact-out --path eth-355,okt,btc-366 --buy-amount 60btc-366 --max-sell-amount 1eth-355
+
+`),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			boughtTokenAmount, err := sdk.ParseDecCoin(boughtTokenAmount)
+			if err != nil {
+				return err
+			}
+			maxSoldTokenAmount, err := sdk.ParseDecCoin(maxSoldTokenAmount)
+			if err != nil {
+				return err
+			}
+			deadline, recip, err := parseDeadlineAndRecipient(cliCtx, deadline, recipient)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSwapExactOut(strings.Split(path, ","), boughtTokenAmount, maxSoldTokenAmount,
+				deadline, recip, cliCtx.FromAddress)
+
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().StringVarP(&path, flagPath, "", "",
+		"Comma separated tokens to swap through, from the token to sell to the token to buy")
+	cmd.Flags().StringVarP(&boughtTokenAmount, flagBuyAmount, "", "",
+		"Amount expected to buy")
+	cmd.Flags().StringVarP(&maxSoldTokenAmount, flagMaxSellAmount, "", "",
+		"Maximum amount expected to sell")
+	cmd.Flags().StringVarP(&recipient, flagRecipient, "", "",
+		"The address to receive the amount bought")
+	cmd.Flags().StringVarP(&deadline, flagDeadlineDuration, "", "100s",
+		"Duration after which this transaction can no longer be executed. such as \"300ms\", \"1.5h\" or \"2h45m\". Valid time units are \"ns\", \"us\" (or \"µs\"), \"ms\", \"s\", \"m\", \"h\".")
+	cmd.MarkFlagRequired(flagPath)
+	cmd.MarkFlagRequired(flagBuyAmount)
+	cmd.MarkFlagRequired(flagMaxSellAmount)
+
+	return cmd
+}
+
+func parseDeadlineAndRecipient(cliCtx context.CLIContext, deadline, recipient string) (int64, sdk.AccAddress, error) {
+	dur, err := time.ParseDuration(deadline)
+	if err != nil {
+		return 0, nil, err
+	}
+	if recipient == "" {
+		return time.Now().Add(dur).Unix(), cliCtx.FromAddress, nil
+	}
+	recip, err := sdk.AccAddressFromBech32(recipient)
+	if err != nil {
+		return 0, nil, err
+	}
+	return time.Now().Add(dur).Unix(), recip, nil
+}
@@ -4,10 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/libs/cosmos-sdk/types/rest"
 	"github.com/okex/exchain/x/ammswap/types"
 	"github.com/okex/exchain/x/common"
@@ -20,6 +22,8 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/liquidity/add_quote/{token}", swapAddQuoteHandler(cliCtx)).Methods("GET")
 	r.HandleFunc("/liquidity/remove_quote/{token_pair}", queryRedeemableAssetsHandler(cliCtx)).Methods("GET")
 	r.HandleFunc("/quote/{token}", swapQuoteHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/best_path/{token}", bestSwapPathHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/twap/{token_pair}", queryTWAPHandler(cliCtx)).Methods("GET")
 }
 
 func querySwapTokenPairHandler(cliContext context.CLIContext) func(http.ResponseWriter, *http.Request) {
@@ -128,3 +132,59 @@ func swapAddQuoteHandler(cliCtx context.CLIContext) http.HandlerFunc {
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
 }
+
+func bestSwapPathHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		buyToken := vars["token"]
+		sellTokenAmount := r.URL.Query().Get("sell_token_amount")
+		sellToken, err := sdk.ParseDecCoin(sellTokenAmount)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, types.CodeConvertSellTokenAmount, err.Error())
+			return
+		}
+
+		params := types.NewQueryBestSwapPathParams(sellToken, buyToken)
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, common.CodeMarshalJSONFailed, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryBestSwapPath), bz)
+		if err != nil {
+			sdkErr := common.ParseSDKError(err.Error())
+			common.HandleErrorMsg(w, cliCtx, sdkErr.Code, sdkErr.Message)
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryTWAPHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tokenPair := mux.Vars(r)["token_pair"]
+		window, err := strconv.ParseInt(r.URL.Query().Get("window"), 10, 64)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, types.CodeInvalidTWAPWindow, err.Error())
+			return
+		}
+
+		params := types.NewQueryTWAPParams(tokenPair, window)
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, common.CodeMarshalJSONFailed, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryTWAP), bz)
+		if err != nil {
+			sdkErr := common.ParseSDKError(err.Error())
+			common.HandleErrorMsg(w, cliCtx, sdkErr.Code, sdkErr.Message)
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
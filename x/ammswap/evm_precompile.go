@@ -0,0 +1,267 @@
+package ammswap
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/ammswap/keeper"
+	"github.com/okex/exchain/x/ammswap/types"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+)
+
+// gas charged for the precompile methods, it is also the gas limit of the native execution
+const (
+	QuoteMethodGas     uint64 = 30000
+	SwapMethodGas      uint64 = 200000
+	LiquidityMethodGas uint64 = 200000
+)
+
+var (
+	// SwapExactInMethod represent the signature of
+	// `function swapExactIn(string[] path, uint256 soldAmount, uint256 minBoughtAmount, address recipient, uint256 deadline) returns (uint256 boughtAmount)`
+	SwapExactInMethod abi.Method
+	// SwapExactOutMethod represent the signature of
+	// `function swapExactOut(string[] path, uint256 boughtAmount, uint256 maxSoldAmount, address recipient, uint256 deadline) returns (uint256 soldAmount)`
+	SwapExactOutMethod abi.Method
+	// AddLiquidityMethod represent the signature of
+	// `function addLiquidity(string baseToken, string quoteToken, uint256 minLiquidity, uint256 maxBaseAmount, uint256 quoteAmount, uint256 deadline) returns (uint256 liquidity)`
+	AddLiquidityMethod abi.Method
+	// RemoveLiquidityMethod represent the signature of
+	// `function removeLiquidity(string baseToken, string quoteToken, uint256 liquidity, uint256 minBaseAmount, uint256 minQuoteAmount, uint256 deadline) returns (uint256 baseAmount, uint256 quoteAmount)`
+	RemoveLiquidityMethod abi.Method
+	// QuoteSwapExactInMethod represent the signature of
+	// `function quoteSwapExactIn(string[] path, uint256 soldAmount) view returns (uint256 boughtAmount)`
+	QuoteSwapExactInMethod abi.Method
+	// QuoteSwapExactOutMethod represent the signature of
+	// `function quoteSwapExactOut(string[] path, uint256 boughtAmount) view returns (uint256 soldAmount)`
+	QuoteSwapExactOutMethod abi.Method
+)
+
+func init() {
+	addressType, _ := abi.NewType("address", "", nil)
+	uint256Type, _ := abi.NewType("uint256", "", nil)
+	stringType, _ := abi.NewType("string", "", nil)
+	stringArrayType, _ := abi.NewType("string[]", "", nil)
+	amount := func(name string) abi.Argument { return abi.Argument{Name: name, Type: uint256Type} }
+	token := func(name string) abi.Argument { return abi.Argument{Name: name, Type: stringType} }
+	path := abi.Argument{Name: "path", Type: stringArrayType}
+	recipient := abi.Argument{Name: "recipient", Type: addressType}
+	newMethod := func(name string, view bool, inputs abi.Arguments, outputs ...abi.Argument) abi.Method {
+		mutability := "nonpayable"
+		if view {
+			mutability = "view"
+		}
+		return abi.NewMethod(name, name, abi.Function, mutability, view, false, inputs, outputs)
+	}
+
+	SwapExactInMethod = newMethod("swapExactIn", false,
+		abi.Arguments{path, amount("soldAmount"), amount("minBoughtAmount"), recipient, amount("deadline")},
+		amount("boughtAmount"))
+	SwapExactOutMethod = newMethod("swapExactOut", false,
+		abi.Arguments{path, amount("boughtAmount"), amount("maxSoldAmount"), recipient, amount("deadline")},
+		amount("soldAmount"))
+	AddLiquidityMethod = newMethod("addLiquidity", false,
+		abi.Arguments{token("baseToken"), token("quoteToken"), amount("minLiquidity"), amount("maxBaseAmount"),
+			amount("quoteAmount"), amount("deadline")},
+		amount("liquidity"))
+	RemoveLiquidityMethod = newMethod("removeLiquidity", false,
+		abi.Arguments{token("baseToken"), token("quoteToken"), amount("liquidity"), amount("minBaseAmount"),
+			amount("minQuoteAmount"), amount("deadline")},
+		amount("baseAmount"), amount("quoteAmount"))
+	QuoteSwapExactInMethod = newMethod("quoteSwapExactIn", true, abi.Arguments{path, amount("soldAmount")},
+		amount("boughtAmount"))
+	QuoteSwapExactOutMethod = newMethod("quoteSwapExactOut", true, abi.Arguments{path, amount("boughtAmount")},
+		amount("soldAmount"))
+}
+
+// NewPrecompileMethods returns the methods of the native precompile which let a contract quote and swap against the
+// swap token pairs, and add or remove liquidity as msg.sender. Amounts are uint256 with 18 decimals.
+func NewPrecompileMethods(k Keeper) []evmtypes.PrecompileMethod {
+	handler := NewHandler(k)
+	return []evmtypes.PrecompileMethod{
+		evmtypes.NewPrecompileMethod(SwapExactInMethod, SwapMethodGas,
+			func(ctx sdk.Context, caller sdk.AccAddress, args []interface{}) ([]interface{}, error) {
+				msg, err := swapExactInMsg(caller, args)
+				if err != nil {
+					return nil, err
+				}
+				swap := msg.(types.MsgSwapExactIn)
+				bought, err := handleForBalanceChanges(ctx, k, handler, msg, swap.Recipient, swap.MinBoughtTokenAmount.Denom)
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{bought[0]}, nil
+			}),
+		evmtypes.NewPrecompileMethod(SwapExactOutMethod, SwapMethodGas,
+			func(ctx sdk.Context, caller sdk.AccAddress, args []interface{}) ([]interface{}, error) {
+				msg, err := swapExactOutMsg(caller, args)
+				if err != nil {
+					return nil, err
+				}
+				swap := msg.(types.MsgSwapExactOut)
+				changes, err := handleForBalanceChanges(ctx, k, handler, msg, swap.Sender, swap.MaxSoldTokenAmount.Denom)
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{new(big.Int).Neg(changes[0])}, nil
+			}),
+		evmtypes.NewPrecompileMethod(AddLiquidityMethod, LiquidityMethodGas,
+			func(ctx sdk.Context, caller sdk.AccAddress, args []interface{}) ([]interface{}, error) {
+				msg, err := addLiquidityMsg(caller, args)
+				if err != nil {
+					return nil, err
+				}
+				add := msg.(types.MsgAddLiquidity)
+				poolTokenName := types.GetPoolTokenName(add.MaxBaseAmount.Denom, add.QuoteAmount.Denom)
+				liquidity, err := handleForBalanceChanges(ctx, k, handler, msg, caller, poolTokenName)
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{liquidity[0]}, nil
+			}),
+		evmtypes.NewPrecompileMethod(RemoveLiquidityMethod, LiquidityMethodGas,
+			func(ctx sdk.Context, caller sdk.AccAddress, args []interface{}) ([]interface{}, error) {
+				msg, err := removeLiquidityMsg(caller, args)
+				if err != nil {
+					return nil, err
+				}
+				remove := msg.(types.MsgRemoveLiquidity)
+				amounts, err := handleForBalanceChanges(ctx, k, handler, msg, caller,
+					remove.MinBaseAmount.Denom, remove.MinQuoteAmount.Denom)
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{amounts[0], amounts[1]}, nil
+			}),
+		evmtypes.NewPrecompileMethod(QuoteSwapExactInMethod, QuoteMethodGas,
+			func(ctx sdk.Context, _ sdk.AccAddress, args []interface{}) ([]interface{}, error) {
+				path := args[0].([]string)
+				if err := types.ValidateSwapPath(path); err != nil {
+					return nil, err
+				}
+				swapTokenPairs, err := k.GetSwapPathPairs(ctx, path)
+				if err != nil {
+					return nil, err
+				}
+				amounts := keeper.CalculateSwapExactIn(swapTokenPairs, path, evmAmountToCoin(path[0], args[1]), k.GetParams(ctx))
+				return []interface{}{amounts[len(amounts)-1].Amount.BigInt()}, nil
+			}),
+		evmtypes.NewPrecompileMethod(QuoteSwapExactOutMethod, QuoteMethodGas,
+			func(ctx sdk.Context, _ sdk.AccAddress, args []interface{}) ([]interface{}, error) {
+				path := args[0].([]string)
+				if err := types.ValidateSwapPath(path); err != nil {
+					return nil, err
+				}
+				swapTokenPairs, err := k.GetSwapPathPairs(ctx, path)
+				if err != nil {
+					return nil, err
+				}
+				amounts, err := keeper.CalculateSwapExactOut(swapTokenPairs, path,
+					evmAmountToCoin(path[len(path)-1], args[1]), k.GetParams(ctx))
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{amounts[0].Amount.BigInt()}, nil
+			}),
+	}
+}
+
+// handleForBalanceChanges handles msg and returns how the balances of addr in denoms change, with 18 decimals
+func handleForBalanceChanges(ctx sdk.Context, k Keeper, handler sdk.Handler, msg sdk.Msg, addr sdk.AccAddress,
+	denoms ...string) ([]*big.Int, error) {
+	before := k.GetTokenKeeper().GetCoins(ctx, addr)
+	if _, err := evmtypes.HandlePrecompileMsg(ctx, handler, msg); err != nil {
+		return nil, err
+	}
+	after := k.GetTokenKeeper().GetCoins(ctx, addr)
+	changes := make([]*big.Int, len(denoms))
+	for i, denom := range denoms {
+		changes[i] = after.AmountOf(denom).Sub(before.AmountOf(denom)).BigInt()
+	}
+	return changes, nil
+}
+
+// swapExactInMsg builds MsgSwapExactIn from the arguments of SwapExactInMethod
+func swapExactInMsg(sender sdk.AccAddress, args []interface{}) (sdk.Msg, error) {
+	path := args[0].([]string)
+	if err := types.ValidateSwapPath(path); err != nil {
+		return nil, err
+	}
+	deadline, err := evmDeadline(args[4])
+	if err != nil {
+		return nil, err
+	}
+	return types.NewMsgSwapExactIn(path,
+		evmAmountToCoin(path[0], args[1]), evmAmountToCoin(path[len(path)-1], args[2]),
+		deadline, evmAddressToAcc(args[3]), sender), nil
+}
+
+// swapExactOutMsg builds MsgSwapExactOut from the arguments of SwapExactOutMethod
+func swapExactOutMsg(sender sdk.AccAddress, args []interface{}) (sdk.Msg, error) {
+	path := args[0].([]string)
+	if err := types.ValidateSwapPath(path); err != nil {
+		return nil, err
+	}
+	deadline, err := evmDeadline(args[4])
+	if err != nil {
+		return nil, err
+	}
+	return types.NewMsgSwapExactOut(path,
+		evmAmountToCoin(path[len(path)-1], args[1]), evmAmountToCoin(path[0], args[2]),
+		deadline, evmAddressToAcc(args[3]), sender), nil
+}
+
+// addLiquidityMsg builds MsgAddLiquidity from the arguments of AddLiquidityMethod
+func addLiquidityMsg(sender sdk.AccAddress, args []interface{}) (sdk.Msg, error) {
+	baseToken, quoteToken := args[0].(string), args[1].(string)
+	if err := types.ValidateBaseAndQuoteAmount(baseToken, quoteToken); err != nil {
+		return nil, err
+	}
+	deadline, err := evmDeadline(args[5])
+	if err != nil {
+		return nil, err
+	}
+	return types.NewMsgAddLiquidity(evmAmountToDec(args[2]),
+		evmAmountToCoin(baseToken, args[3]), evmAmountToCoin(quoteToken, args[4]),
+		deadline, sender), nil
+}
+
+// removeLiquidityMsg builds MsgRemoveLiquidity from the arguments of RemoveLiquidityMethod
+func removeLiquidityMsg(sender sdk.AccAddress, args []interface{}) (sdk.Msg, error) {
+	baseToken, quoteToken := args[0].(string), args[1].(string)
+	if err := types.ValidateBaseAndQuoteAmount(baseToken, quoteToken); err != nil {
+		return nil, err
+	}
+	deadline, err := evmDeadline(args[5])
+	if err != nil {
+		return nil, err
+	}
+	return types.NewMsgRemoveLiquidity(evmAmountToDec(args[2]),
+		evmAmountToCoin(baseToken, args[3]), evmAmountToCoin(quoteToken, args[4]),
+		deadline, sender), nil
+}
+
+// evmAmountToDec converts an uint256 with 18 decimals into sdk.Dec
+func evmAmountToDec(amount interface{}) sdk.Dec {
+	return sdk.NewDecFromBigIntWithPrec(amount.(*big.Int), sdk.Precision)
+}
+
+func evmAmountToCoin(denom string, amount interface{}) sdk.SysCoin {
+	return sdk.NewDecCoinFromDec(denom, evmAmountToDec(amount))
+}
+
+func evmAddressToAcc(addr interface{}) sdk.AccAddress {
+	return sdk.AccAddress(addr.(common.Address).Bytes())
+}
+
+func evmDeadline(deadline interface{}) (int64, error) {
+	d := deadline.(*big.Int)
+	if !d.IsInt64() {
+		return 0, fmt.Errorf("deadline overflows int64: %s", d)
+	}
+	return d.Int64(), nil
+}
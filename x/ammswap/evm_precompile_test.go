@@ -0,0 +1,208 @@
+package ammswap_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/okex/exchain/app"
+	"github.com/okex/exchain/app/crypto/ethsecp256k1"
+	ethermint "github.com/okex/exchain/app/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/mint"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/ammswap"
+	"github.com/okex/exchain/x/ammswap/keeper"
+	"github.com/okex/exchain/x/ammswap/types"
+	"github.com/okex/exchain/x/evm"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+	"github.com/stretchr/testify/require"
+)
+
+// the proxies forward calldata[32:] to the address in calldata[:32]. proxyCode returns or reverts with the result of
+// the call, staticProxyCode does the same with a static call, revertingProxyCode always reverts and tryProxyCode
+// always succeeds whatever the call results in.
+const (
+	proxyCode = "0x6029600c60003960296000f3" +
+		"3660006000376000600060203603602060006000515af13d600060003e6024573d6000fd5b3d6000f3"
+	staticProxyCode = "0x6027600c60003960276000f3" +
+		"366000600037600060006020360360206000515afa3d600060003e6022573d6000fd5b3d6000f3"
+	revertingProxyCode = "0x601d600c600039601d6000f3" +
+		"3660006000376000600060203603602060006000515af15060006000fd"
+	tryProxyCode = "0x6019600c60003960196000f3" +
+		"3660006000376000600060203603602060006000515af15000"
+)
+
+// evmTxSender sends the evm txs signed by one account, the state of a failed tx is dropped as the deliver tx does
+type evmTxSender func(to *common.Address, payload []byte) (*sdk.Result, error)
+
+// initEvmTest enables the evm on top of initSwapPathTest and returns the sender of the evm txs
+func initEvmTest(t *testing.T, blockTime time.Time) (*app.OKExChainApp, sdk.Context, sdk.AccAddress, common.Address, evmTxSender) {
+	mapp, ctx, sender := initSwapPathTest(t, blockTime)
+	ctx.SetBlockHeight(2)
+	ctx.SetChainID("ethermint-3")
+	ctx.SetDeliver()
+	require.Nil(t, ethermint.SetChainId("ethermint-3"))
+	params := evmtypes.DefaultParams()
+	params.EnableCreate = true
+	params.EnableCall = true
+	mapp.EvmKeeper.SetParams(ctx, params)
+	handler := evm.NewHandler(mapp.EvmKeeper)
+
+	priv, err := ethsecp256k1.GenerateKey()
+	require.Nil(t, err)
+	nonce := uint64(0)
+	sendTx := func(to *common.Address, payload []byte) (*sdk.Result, error) {
+		tx := evmtypes.NewMsgEthereumTx(nonce, to, big.NewInt(0), 3000000, big.NewInt(1), payload)
+		require.Nil(t, tx.Sign(big.NewInt(3), priv.ToECDSA()))
+		nonce++
+		cacheCtx, write := ctx.CacheContext()
+		res, err := handler(cacheCtx, tx)
+		if err == nil {
+			write()
+		}
+		return res, err
+	}
+	return mapp, ctx, sender, common.BytesToAddress(priv.PubKey().Address()), sendTx
+}
+
+// deployContract deploys code and returns the address of the contract
+func deployContract(t *testing.T, sendTx evmTxSender, code string) common.Address {
+	res, err := sendTx(nil, common.FromHex(code))
+	require.Nil(t, err)
+	resultData, err := evmtypes.DecodeResultData(res.Data)
+	require.Nil(t, err)
+	return resultData.ContractAddress
+}
+
+func TestNativePrecompile(t *testing.T) {
+	blockTime := time.Unix(1600000000, 0)
+	mapp, ctx, signerAcc, signer, sendTx := initEvmTest(t, blockTime)
+	k := mapp.SwapKeeper
+
+	proxy := deployContract(t, sendTx, proxyCode)
+	staticProxy := deployContract(t, sendTx, staticProxyCode)
+	revertingProxy := deployContract(t, sendTx, revertingProxyCode)
+	tryProxy := deployContract(t, sendTx, tryProxyCode)
+
+	// the proxies hold 100 a, the signer holds the native token to not be an empty account
+	coins := sdk.SysCoins{sdk.NewDecCoinFromDec(tokenA, sdk.NewDec(100))}
+	signerCoins := sdk.SysCoins{sdk.NewDecCoinFromDec(quote, sdk.NewDec(1))}
+	require.Nil(t, mapp.SupplyKeeper.MintCoins(ctx, mint.ModuleName, coins.Add(coins...).Add(signerCoins...)))
+	require.Nil(t, mapp.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, mint.ModuleName, proxy.Bytes(), coins))
+	require.Nil(t, mapp.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, mint.ModuleName, revertingProxy.Bytes(), coins))
+	require.Nil(t, mapp.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, mint.ModuleName, signer.Bytes(), signerCoins))
+	balanceOf := func(addr common.Address, denom string) sdk.Dec {
+		return mapp.AccountKeeper.GetAccount(ctx, addr.Bytes()).GetCoins().AmountOf(denom)
+	}
+	pooledOf := func(base string) sdk.Dec {
+		swapTokenPair, err := k.GetSwapTokenPair(ctx, types.GetSwapTokenPairName(base, quote))
+		require.Nil(t, err)
+		return swapTokenPair.BasePooledCoin.Amount
+	}
+
+	// call calls the method of the precompile through the contracts in via, the first of them is called by the signer.
+	// A call returning nothing has no outputs.
+	call := func(via []common.Address, method abi.Method, args ...interface{}) ([]interface{}, error) {
+		data, err := method.Inputs.Pack(args...)
+		require.Nil(t, err)
+		payload := append(method.ID, data...)
+		hops := append(via, evmtypes.NativePrecompileAddress)
+		for i := len(hops) - 1; i > 0; i-- {
+			payload = append(common.LeftPadBytes(hops[i].Bytes(), 32), payload...)
+		}
+		res, err := sendTx(&hops[0], payload)
+		if err != nil {
+			return nil, err
+		}
+		resultData, err := evmtypes.DecodeResultData(res.Data)
+		require.Nil(t, err)
+		if len(resultData.Ret) == 0 {
+			return nil, nil
+		}
+		return method.Outputs.Unpack(resultData.Ret)
+	}
+
+	oneToken := new(big.Int).Exp(big.NewInt(10), big.NewInt(sdk.Precision), nil)
+	tokens := func(n int64) *big.Int { return new(big.Int).Mul(big.NewInt(n), oneToken) }
+	recipient := common.BytesToAddress(signerAcc)
+	deadline := big.NewInt(blockTime.Unix() + 100)
+	path := []string{tokenA, quote, tokenC}
+	pairs, err := k.GetSwapPathPairs(ctx, path)
+	require.Nil(t, err)
+	amounts := keeper.CalculateSwapExactIn(pairs, path, sdk.NewDecCoinFromDec(tokenA, sdk.NewDec(10)), k.GetParams(ctx))
+	tokenBuy := amounts[len(amounts)-1]
+
+	// the precompile is an empty account before the Venus2 height, which runs on the istanbul rules
+	_, ok := vm.PrecompiledContractsIstanbul[evmtypes.NativePrecompileAddress]
+	require.False(t, ok)
+	outputs, err := call([]common.Address{proxy}, ammswap.SwapExactInMethod, path, tokens(10), big.NewInt(0),
+		recipient, deadline)
+	require.Nil(t, err)
+	require.Nil(t, outputs)
+	require.Equal(t, sdk.NewDec(100), balanceOf(proxy, tokenA))
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(1)
+	defer tmtypes.UnittestOnlySetMilestoneVenus2Height(0)
+
+	// the quotes can be made by a static call
+	outputs, err = call(nil, ammswap.QuoteSwapExactInMethod, path, tokens(10))
+	require.Nil(t, err)
+	require.Equal(t, tokenBuy.Amount.BigInt(), outputs[0])
+	outputs, err = call([]common.Address{staticProxy}, ammswap.QuoteSwapExactInMethod, path, tokens(10))
+	require.Nil(t, err)
+	require.Equal(t, tokenBuy.Amount.BigInt(), outputs[0])
+
+	// a swap can't be made by a static call
+	_, err = call([]common.Address{staticProxy}, ammswap.SwapExactInMethod, path, tokens(10), big.NewInt(0),
+		recipient, deadline)
+	require.NotNil(t, err)
+
+	// the slippage protection reverts the call
+	_, err = call([]common.Address{proxy}, ammswap.SwapExactInMethod, path, tokens(10), tokens(10), recipient, deadline)
+	require.NotNil(t, err)
+	require.Equal(t, sdk.NewDec(100), balanceOf(proxy, tokenA))
+	require.Equal(t, sdk.NewDec(1000), pooledOf(tokenA))
+
+	// the swap is made as the calling contract and returns the bought amount synchronously
+	outputs, err = call([]common.Address{proxy}, ammswap.SwapExactInMethod, path, tokens(10), big.NewInt(0),
+		recipient, deadline)
+	require.Nil(t, err)
+	require.Equal(t, tokenBuy.Amount.BigInt(), outputs[0])
+	require.Equal(t, sdk.NewDec(90), balanceOf(proxy, tokenA))
+	require.Equal(t, tokenBuy.Amount, balanceOf(recipient, tokenC))
+
+	// the amounts are converted from 18 decimals, the sold amount is returned
+	boughtBefore := balanceOf(recipient, tokenC)
+	outputs, err = call([]common.Address{proxy}, ammswap.SwapExactOutMethod, path, tokens(1), tokens(10), recipient,
+		deadline)
+	require.Nil(t, err)
+	sold := sdk.NewDecFromBigIntWithPrec(outputs[0].(*big.Int), sdk.Precision)
+	require.True(t, sold.IsPositive())
+	require.Equal(t, sdk.NewDec(90).Sub(sold), balanceOf(proxy, tokenA))
+	require.True(t, balanceOf(recipient, tokenC).GTE(boughtBefore.Add(sdk.NewDec(1))))
+
+	// an invalid path fails before the msg is handled
+	_, err = call([]common.Address{proxy}, ammswap.SwapExactInMethod, []string{tokenA, quote, tokenA}, tokens(10),
+		big.NewInt(0), recipient, deadline)
+	require.NotNil(t, err)
+
+	// the native changes are reverted along with the evm call reverting them, even if the tx succeeds
+	pooled, bought := pooledOf(tokenA), balanceOf(recipient, tokenC)
+	_, err = call([]common.Address{tryProxy, revertingProxy}, ammswap.SwapExactInMethod, path, tokens(10),
+		big.NewInt(0), recipient, deadline)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(100), balanceOf(revertingProxy, tokenA))
+	require.Equal(t, pooled, pooledOf(tokenA))
+	require.Equal(t, bought, balanceOf(recipient, tokenC))
+
+	// the native token bought by a contract is kept when the evm state of the contract is committed
+	soldBefore := balanceOf(proxy, tokenA)
+	outputs, err = call([]common.Address{proxy}, ammswap.SwapExactInMethod, []string{tokenA, quote}, tokens(10),
+		big.NewInt(0), proxy, deadline)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDecFromBigIntWithPrec(outputs[0].(*big.Int), sdk.Precision), balanceOf(proxy, quote))
+	require.Equal(t, soldBefore.Sub(sdk.NewDec(10)), balanceOf(proxy, tokenA))
+}
@@ -1,12 +1,15 @@
 package ammswap
 
 import (
+	"fmt"
+
 	"github.com/okex/exchain/x/ammswap/keeper"
 	"github.com/okex/exchain/x/ammswap/types"
 	"github.com/okex/exchain/x/common"
 	"github.com/okex/exchain/x/common/perf"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 )
 
 // NewHandler creates an sdk.Handler for all the ammswap type messages
@@ -36,6 +39,16 @@ func NewHandler(k Keeper) sdk.Handler {
 			handlerFun = func() (*sdk.Result, error) {
 				return handleMsgTokenToToken(ctx, k, msg)
 			}
+		case types.MsgSwapExactIn:
+			name = "handleMsgSwapExactIn"
+			handlerFun = func() (*sdk.Result, error) {
+				return handleMsgSwapExactIn(ctx, k, msg)
+			}
+		case types.MsgSwapExactOut:
+			name = "handleMsgSwapExactOut"
+			handlerFun = func() (*sdk.Result, error) {
+				return handleMsgSwapExactOut(ctx, k, msg)
+			}
 		default:
 			return nil, types.ErrSwapUnknownMsgType()
 		}
@@ -344,16 +357,136 @@ func swapTokenNativeToken(
 	}
 
 	// update swapTokenPair
-	if msg.MinBoughtTokenAmount.Denom < msg.SoldTokenAmount.Denom {
-		swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Add(msg.SoldTokenAmount)
+	swapTokenPair = updateSwapTokenPair(swapTokenPair, msg.SoldTokenAmount, tokenBuy)
+	k.SetSwapTokenPair(ctx, msg.GetSwapTokenPairName(), swapTokenPair)
+	k.OnSwapToken(ctx, msg.Recipient, swapTokenPair, msg.SoldTokenAmount, tokenBuy)
+	return &sdk.Result{}, nil
+}
+
+func handleMsgSwapExactIn(ctx sdk.Context, k Keeper, msg types.MsgSwapExactIn) (*sdk.Result, error) {
+	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
+
+	if !tmtypes.HigherThanVenus2(ctx.BlockHeight()) {
+		return types.ErrSwapPathNotEnabled().Result()
+	}
+	if msg.Deadline < ctx.BlockTime().Unix() {
+		return types.ErrBlockTimeBigThanDeadline().Result()
+	}
+	if err := common.HasSufficientCoins(msg.Sender, k.GetTokenKeeper().GetCoins(ctx, msg.Sender),
+		sdk.SysCoins{msg.SoldTokenAmount}); err != nil {
+		return common.ErrInsufficientCoins(DefaultParamspace, err.Error()).Result()
+	}
+	swapTokenPairs, err := k.GetSwapPathPairs(ctx, msg.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	params := k.GetParams(ctx)
+	amounts := keeper.CalculateSwapExactIn(swapTokenPairs, msg.Path, msg.SoldTokenAmount, params)
+	tokenBuy := amounts[len(amounts)-1]
+	if tokenBuy.Amount.LT(msg.MinBoughtTokenAmount.Amount) {
+		return types.ErrLessThan("token buy amount", "min bought token amount").Result()
+	}
+
+	res, err := swapTokenByPath(ctx, k, swapTokenPairs, amounts, msg.Sender, msg.Recipient)
+	if err != nil {
+		return res, err
+	}
+	event.AppendAttributes(sdk.NewAttribute("bought_token_amount", tokenBuy.String()))
+	event.AppendAttributes(sdk.NewAttribute("recipient", msg.Recipient.String()))
+	ctx.EventManager().EmitEvent(event)
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgSwapExactOut(ctx sdk.Context, k Keeper, msg types.MsgSwapExactOut) (*sdk.Result, error) {
+	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
+
+	if !tmtypes.HigherThanVenus2(ctx.BlockHeight()) {
+		return types.ErrSwapPathNotEnabled().Result()
+	}
+	if msg.Deadline < ctx.BlockTime().Unix() {
+		return types.ErrBlockTimeBigThanDeadline().Result()
+	}
+	swapTokenPairs, err := k.GetSwapPathPairs(ctx, msg.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	params := k.GetParams(ctx)
+	requiredAmounts, err := keeper.CalculateSwapExactOut(swapTokenPairs, msg.Path, msg.BoughtTokenAmount, params)
+	if err != nil {
+		return nil, err
+	}
+	tokenSell := requiredAmounts[0]
+	if msg.MaxSoldTokenAmount.Amount.LT(tokenSell.Amount) {
+		return types.ErrLessThan("max sold token amount", "token sell amount").Result()
+	}
+	if err := common.HasSufficientCoins(msg.Sender, k.GetTokenKeeper().GetCoins(ctx, msg.Sender),
+		sdk.SysCoins{tokenSell}); err != nil {
+		return common.ErrInsufficientCoins(DefaultParamspace, err.Error()).Result()
+	}
+
+	// the pools are always updated by the exact-in formula, the sell amount is rounded up so
+	// the recipient gets at least the bought token amount
+	amounts := keeper.CalculateSwapExactIn(swapTokenPairs, msg.Path, tokenSell, params)
+	tokenBuy := amounts[len(amounts)-1]
+	if tokenBuy.Amount.LT(msg.BoughtTokenAmount.Amount) {
+		return types.ErrLessThan("token buy amount", "bought token amount").Result()
+	}
+
+	res, err := swapTokenByPath(ctx, k, swapTokenPairs, amounts, msg.Sender, msg.Recipient)
+	if err != nil {
+		return res, err
+	}
+	event.AppendAttributes(sdk.NewAttribute("sold_token_amount", tokenSell.String()))
+	event.AppendAttributes(sdk.NewAttribute("bought_token_amount", tokenBuy.String()))
+	event.AppendAttributes(sdk.NewAttribute("recipient", msg.Recipient.String()))
+	ctx.EventManager().EmitEvent(event)
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// swapTokenByPath settles a swap through swapTokenPairs where amounts[i] is sold to swapTokenPairs[i] for amounts[i+1].
+// All pools share the module account, so only the first and the last tokens need to be transferred.
+func swapTokenByPath(
+	ctx sdk.Context, k Keeper, swapTokenPairs []SwapTokenPair, amounts sdk.SysCoins,
+	sender, recipient sdk.AccAddress,
+) (*sdk.Result, error) {
+	for _, amount := range amounts {
+		if amount.IsZero() {
+			return types.ErrIsZeroValue(fmt.Sprintf("%s amount along swap path", amount.Denom)).Result()
+		}
+	}
+
+	// transfer coins
+	err := k.SendCoinsToPool(ctx, sdk.SysCoins{amounts[0]}, sender)
+	if err != nil {
+		return types.ErrSendCoinsToPoolFailed(err.Error()).Result()
+	}
+
+	err = k.SendCoinsFromPoolToAccount(ctx, sdk.SysCoins{amounts[len(amounts)-1]}, recipient)
+	if err != nil {
+		return types.ErrSendCoinsFromPoolToAccountFailed(err.Error()).Result()
+	}
+
+	// update swapTokenPairs
+	for i, swapTokenPair := range swapTokenPairs {
+		swapTokenPair = updateSwapTokenPair(swapTokenPair, amounts[i], amounts[i+1])
+		k.SetSwapTokenPair(ctx, swapTokenPair.TokenPairName(), swapTokenPair)
+		k.OnSwapToken(ctx, recipient, swapTokenPair, amounts[i], amounts[i+1])
+	}
+	return &sdk.Result{}, nil
+}
+
+// updateSwapTokenPair moves tokenSell into and tokenBuy out of the pool
+func updateSwapTokenPair(swapTokenPair SwapTokenPair, tokenSell, tokenBuy sdk.SysCoin) SwapTokenPair {
+	if tokenBuy.Denom < tokenSell.Denom {
+		swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Add(tokenSell)
 		swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Sub(tokenBuy)
 	} else {
 		swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Sub(tokenBuy)
-		swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Add(msg.SoldTokenAmount)
+		swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Add(tokenSell)
 	}
-	k.SetSwapTokenPair(ctx, msg.GetSwapTokenPairName(), swapTokenPair)
-	k.OnSwapToken(ctx, msg.Recipient, swapTokenPair, msg.SoldTokenAmount, tokenBuy)
-	return &sdk.Result{}, nil
+	return swapTokenPair
 }
 
 func coinSort(coins sdk.SysCoins) sdk.SysCoins {
@@ -456,6 +456,89 @@ func TestHandleMsgTokenToTokenDirectly(t *testing.T) {
 	}
 }
 
+func TestHandleMsgSwapExactInAndOut(t *testing.T) {
+	mapp, addrKeysSlice := getMockAppWithBalance(t, 1, 100000)
+	k := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10).WithBlockTime(time.Now())
+	mapp.swapKeeper.SetParams(ctx, types.DefaultParams())
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	handler := NewHandler(k)
+	addr := addrKeysSlice[0].Address
+
+	for _, symbol := range []string{types.TestBasePooledToken, types.TestBasePooledToken2, types.TestBasePooledToken3, types.TestQuotePooledToken} {
+		mapp.tokenKeeper.NewToken(ctx, token.InitTestToken(symbol))
+	}
+	deadLine := time.Now().Unix()
+	for _, pair := range [][2]string{
+		{types.TestBasePooledToken, types.TestQuotePooledToken},
+		{types.TestBasePooledToken2, types.TestQuotePooledToken},
+		{types.TestBasePooledToken2, types.TestBasePooledToken3},
+	} {
+		_, err := handler(ctx, types.NewMsgCreateExchange(pair[0], pair[1], addr))
+		require.Nil(t, err)
+		_, err = handler(ctx, types.NewMsgAddLiquidity(sdk.NewDec(1), sdk.NewDecCoinFromDec(pair[0], sdk.NewDec(10000)),
+			sdk.NewDecCoinFromDec(pair[1], sdk.NewDec(10000)), deadLine, addr))
+		require.Nil(t, err)
+	}
+
+	path := []string{types.TestBasePooledToken, types.TestQuotePooledToken, types.TestBasePooledToken2, types.TestBasePooledToken3}
+	params := k.GetParams(ctx)
+	getBalance := func(denom string) sdk.Dec {
+		return mapp.AccountKeeper.GetAccount(ctx, addr).GetCoins().AmountOf(denom)
+	}
+
+	// exact in
+	soldTokenAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(100))
+	pairs, err := k.GetSwapPathPairs(ctx, path)
+	require.Nil(t, err)
+	amounts := keeper.CalculateSwapExactIn(pairs, path, soldTokenAmount, params)
+	expectedBuy := amounts[len(amounts)-1]
+
+	tooMuchMinBought := sdk.NewDecCoinFromDec(types.TestBasePooledToken3, expectedBuy.Amount.Add(sdk.NewDecWithPrec(1, sdk.Precision)))
+	_, err = handler(ctx, types.NewMsgSwapExactIn(path, soldTokenAmount, tooMuchMinBought, deadLine, addr, addr))
+	require.NotNil(t, err)
+	_, err = handler(ctx, types.NewMsgSwapExactIn(path, soldTokenAmount, expectedBuy, 0, addr, addr))
+	require.NotNil(t, err)
+	unknownPath := []string{types.TestBasePooledToken, types.TestBasePooledToken2, types.TestBasePooledToken3}
+	_, err = handler(ctx, types.NewMsgSwapExactIn(unknownPath, soldTokenAmount, expectedBuy, deadLine, addr, addr))
+	require.NotNil(t, err)
+
+	balanceIn, balanceOut := getBalance(types.TestBasePooledToken), getBalance(types.TestBasePooledToken3)
+	_, err = handler(ctx, types.NewMsgSwapExactIn(path, soldTokenAmount, expectedBuy, deadLine, addr, addr))
+	require.Nil(t, err)
+	require.Equal(t, balanceIn.Sub(soldTokenAmount.Amount), getBalance(types.TestBasePooledToken))
+	require.Equal(t, balanceOut.Add(expectedBuy.Amount), getBalance(types.TestBasePooledToken3))
+	// intermediate tokens stay in the pools
+	for i, pair := range pairs {
+		updated, err := k.GetSwapTokenPair(ctx, pair.TokenPairName())
+		require.Nil(t, err)
+		require.True(t, updated.BasePooledCoin.Amount.Add(updated.QuotePooledCoin.Amount).Equal(
+			pair.BasePooledCoin.Amount.Add(pair.QuotePooledCoin.Amount).Add(amounts[i].Amount).Sub(amounts[i+1].Amount)))
+	}
+
+	// exact out
+	boughtTokenAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken3, sdk.NewDec(50))
+	pairs, err = k.GetSwapPathPairs(ctx, path)
+	require.Nil(t, err)
+	requiredAmounts, err := keeper.CalculateSwapExactOut(pairs, path, boughtTokenAmount, params)
+	require.Nil(t, err)
+	expectedSell := requiredAmounts[0]
+
+	tooLittleMaxSold := sdk.NewDecCoinFromDec(types.TestBasePooledToken, expectedSell.Amount.Sub(sdk.NewDecWithPrec(1, sdk.Precision)))
+	_, err = handler(ctx, types.NewMsgSwapExactOut(path, boughtTokenAmount, tooLittleMaxSold, deadLine, addr, addr))
+	require.NotNil(t, err)
+	tooMuchBought := sdk.NewDecCoinFromDec(types.TestBasePooledToken3, sdk.NewDec(100000))
+	_, err = handler(ctx, types.NewMsgSwapExactOut(path, tooMuchBought, sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(100000)), deadLine, addr, addr))
+	require.NotNil(t, err)
+
+	balanceIn, balanceOut = getBalance(types.TestBasePooledToken), getBalance(types.TestBasePooledToken3)
+	_, err = handler(ctx, types.NewMsgSwapExactOut(path, boughtTokenAmount, expectedSell, deadLine, addr, addr))
+	require.Nil(t, err)
+	require.Equal(t, balanceIn.Sub(expectedSell.Amount), getBalance(types.TestBasePooledToken))
+	require.True(t, getBalance(types.TestBasePooledToken3).Sub(balanceOut).GTE(boughtTokenAmount.Amount))
+}
+
 func TestGetInputPrice(t *testing.T) {
 	tests := []struct {
 		testCase           string
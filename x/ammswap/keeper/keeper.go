@@ -6,6 +6,7 @@ import (
 	"github.com/okex/exchain/x/common"
 
 	"github.com/okex/exchain/libs/tendermint/libs/log"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 
 	"github.com/okex/exchain/libs/cosmos-sdk/codec"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
@@ -58,8 +59,12 @@ func (k Keeper) GetSwapTokenPair(ctx sdk.Context, tokenPairName string) (types.S
 	return item, nil
 }
 
-// SetSwapTokenPair sets the entire SwapTokenPair data struct for a quote token name
+// SetSwapTokenPair sets the entire SwapTokenPair data struct for a quote token name.
+// Since the Venus2 height the price accumulator of the token pair is updated before the pooled coins change.
 func (k Keeper) SetSwapTokenPair(ctx sdk.Context, tokenPairName string, swapTokenPair types.SwapTokenPair) {
+	if tmtypes.HigherThanVenus2(ctx.BlockHeight()) {
+		k.updateCumulativePrice(ctx, tokenPairName)
+	}
 	store := ctx.KVStore(k.storeKey)
 	bz := k.cdc.MustMarshalBinaryLengthPrefixed(swapTokenPair)
 	store.Set(types.GetTokenPairKey(tokenPairName), bz)
@@ -126,13 +131,21 @@ func (k Keeper) BurnPoolCoinsFromUser(ctx sdk.Context, coins sdk.SysCoins, addr
 	return k.supplyKeeper.BurnCoins(ctx, types.ModuleName, coins)
 }
 
-// SendCoinsToPool sends coins from user account to module account
+// SendCoinsToPool sends coins from user account to module account,
+// it fails if any of the tokens is paused or the account is frozen for it
 func (k Keeper) SendCoinsToPool(ctx sdk.Context, coins sdk.SysCoins, addr sdk.AccAddress) error {
+	if err := k.tokenKeeper.CheckTransferable(ctx, coins, addr); err != nil {
+		return err
+	}
 	return k.supplyKeeper.SendCoinsFromAccountToModule(ctx, addr, types.ModuleName, coins)
 }
 
-// SendCoinsFromPoolToAccount sends coins from module account to user account
+// SendCoinsFromPoolToAccount sends coins from module account to user account,
+// it fails if any of the tokens is paused or the account is frozen for it
 func (k Keeper) SendCoinsFromPoolToAccount(ctx sdk.Context, coins sdk.SysCoins, addr sdk.AccAddress) error {
+	if err := k.tokenKeeper.CheckTransferable(ctx, coins, addr); err != nil {
+		return err
+	}
 	return k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, addr, coins)
 }
 
@@ -196,6 +209,30 @@ func GetInputPrice(inputAmount, inputReserve, outputReserve, feeRate sdk.Dec) sd
 	return common.MulAndQuo(inputAmountWithFee, outputReserve, denominator)
 }
 
+// CalculateTokenToSell returns the amount of sellTokenDenom needed to buy buyToken from the swap token pair
+func CalculateTokenToSell(swapTokenPair types.SwapTokenPair, buyToken sdk.SysCoin, sellTokenDenom string, params types.Params) (sdk.SysCoin, error) {
+	var inputReserve, outputReserve sdk.Dec
+	if buyToken.Denom < sellTokenDenom {
+		inputReserve = swapTokenPair.QuotePooledCoin.Amount
+		outputReserve = swapTokenPair.BasePooledCoin.Amount
+	} else {
+		inputReserve = swapTokenPair.BasePooledCoin.Amount
+		outputReserve = swapTokenPair.QuotePooledCoin.Amount
+	}
+	if buyToken.Amount.GTE(outputReserve) {
+		return sdk.SysCoin{}, types.ErrInsufficientLiquidity(swapTokenPair.TokenPairName())
+	}
+	tokenSellAmt := GetOutputPrice(buyToken.Amount, inputReserve, outputReserve, params.FeeRate)
+	return sdk.NewDecCoinFromDec(sellTokenDenom, tokenSellAmt), nil
+}
+
+// GetOutputPrice is the inverse of GetInputPrice, it rounds up so that selling the result buys at least outputAmount
+func GetOutputPrice(outputAmount, inputReserve, outputReserve, feeRate sdk.Dec) sdk.Dec {
+	inputAmountWithFee := common.MulAndQuo(outputAmount, inputReserve.MulTruncate(sdk.NewDec(1000)), outputReserve.Sub(outputAmount))
+	inputAmount := inputAmountWithFee.Quo(sdk.OneDec().Sub(feeRate).MulTruncate(sdk.NewDec(1000)))
+	return inputAmount.Add(sdk.NewDecWithPrec(1, sdk.Precision))
+}
+
 func (k *Keeper) SetObserverKeeper(bk types.BackendKeeper) {
 	k.ObserverKeeper = append(k.ObserverKeeper, bk)
 }
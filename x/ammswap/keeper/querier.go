@@ -28,6 +28,10 @@ func NewQuerier(k Keeper) sdk.Querier {
 			res, err = querySwapQuoteInfo(ctx, req, k)
 		case types.QuerySwapAddLiquidityQuote:
 			res, err = querySwapAddLiquidityQuote(ctx, req, k)
+		case types.QueryBestSwapPath:
+			res, err = queryBestSwapPath(ctx, req, k)
+		case types.QueryTWAP:
+			res, err = queryTWAP(ctx, req, k)
 
 		default:
 			return nil, types.ErrSwapUnknownQueryType()
@@ -328,3 +332,45 @@ func querySwapAddLiquidityQuote(ctx sdk.Context, req abci.RequestQuery, keeper K
 	return bz, nil
 
 }
+
+// queryBestSwapPath returns the path which buys the most token and the amounts along it
+func queryBestSwapPath(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var queryParams types.QueryBestSwapPathParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &queryParams)
+	if err != nil {
+		return nil, common.ErrUnMarshalJSONFailed(err.Error())
+	}
+	if err := types.ValidateSwapAmountName(queryParams.TokenToBuy); err != nil {
+		return nil, err
+	}
+	if err := types.ValidateSwapAmountName(queryParams.SoldToken.Denom); err != nil {
+		return nil, err
+	}
+	if !queryParams.SoldToken.IsPositive() {
+		return nil, types.ErrSoldTokenAmountIsNegative()
+	}
+
+	pathInfo, err := keeper.FindBestSwapPath(ctx, queryParams.SoldToken, queryParams.TokenToBuy)
+	if err != nil {
+		return nil, err
+	}
+	return keeper.cdc.MustMarshalJSON(pathInfo), nil
+}
+
+// queryTWAP returns the time-weighted average prices of a token pair
+func queryTWAP(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var queryParams types.QueryTWAPParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &queryParams)
+	if err != nil {
+		return nil, common.ErrUnMarshalJSONFailed(err.Error())
+	}
+	if _, err := keeper.GetSwapTokenPair(ctx, queryParams.TokenPairName); err != nil {
+		return nil, err
+	}
+
+	twap, err := keeper.GetTWAP(ctx, queryParams.TokenPairName, queryParams.Window)
+	if err != nil {
+		return nil, err
+	}
+	return keeper.cdc.MustMarshalJSON(twap), nil
+}
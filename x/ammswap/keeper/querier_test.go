@@ -153,3 +153,25 @@ func TestQueryBuyAmount(t *testing.T) {
 	expectedToken = "33.233233333634235135"
 	require.Equal(t, expectedToken, result)
 }
+
+func TestQueryBestSwapPath(t *testing.T) {
+	mapp, addrList, ctx, keeper, querier := initQurierTest(t)
+
+	initTestPool(t, addrList, mapp, ctx, keeper, sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(100)),
+		sdk.NewDecCoinFromDec(types.TestQuotePooledToken, sdk.NewDec(100)), sdk.NewDec(1))
+	initTestPool(t, addrList, mapp, ctx, keeper, sdk.NewDecCoinFromDec(types.TestBasePooledToken2, sdk.NewDec(100)),
+		sdk.NewDecCoinFromDec(types.TestQuotePooledToken, sdk.NewDec(100)), sdk.NewDec(1))
+
+	path := []string{types.QueryBestSwapPath}
+	queryParams := types.NewQueryBestSwapPathParams(sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(1)), types.TestBasePooledToken2)
+	resultBytes, err := querier(ctx, path, abci.RequestQuery{Data: keeper.cdc.MustMarshalJSON(queryParams)})
+	require.Nil(t, err)
+	var result types.SwapPathInfo
+	keeper.cdc.MustUnmarshalJSON(resultBytes, &result)
+	require.Equal(t, []string{types.TestBasePooledToken, types.TestQuotePooledToken, types.TestBasePooledToken2}, result.Path)
+	require.True(t, result.BuyAmount.IsPositive())
+
+	queryParams = types.NewQueryBestSwapPathParams(sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(1)), types.TestBasePooledToken3)
+	_, err = querier(ctx, path, abci.RequestQuery{Data: keeper.cdc.MustMarshalJSON(queryParams)})
+	require.NotNil(t, err)
+}
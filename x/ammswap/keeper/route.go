@@ -0,0 +1,109 @@
+package keeper
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/ammswap/types"
+)
+
+// GetSwapPathPairs returns the swap token pairs the path goes through, each of which must have liquidity
+func (k Keeper) GetSwapPathPairs(ctx sdk.Context, path []string) ([]types.SwapTokenPair, error) {
+	var pairs []types.SwapTokenPair
+	for _, tokenPairName := range types.GetSwapPathPairNames(path) {
+		swapTokenPair, err := k.GetSwapTokenPair(ctx, tokenPairName)
+		if err != nil {
+			return nil, err
+		}
+		if swapTokenPair.BasePooledCoin.IsZero() || swapTokenPair.QuotePooledCoin.IsZero() {
+			return nil, types.ErrIsZeroValue("base pooled coin or quote pooled coin")
+		}
+		pairs = append(pairs, swapTokenPair)
+	}
+	return pairs, nil
+}
+
+// CalculateSwapExactIn returns the amounts of every token along the path when selling soldToken
+func CalculateSwapExactIn(pairs []types.SwapTokenPair, path []string, soldToken sdk.SysCoin, params types.Params) []sdk.SysCoin {
+	amounts := []sdk.SysCoin{soldToken}
+	for i, pair := range pairs {
+		amounts = append(amounts, CalculateTokenToBuy(pair, amounts[i], path[i+1], params))
+	}
+	return amounts
+}
+
+// CalculateSwapExactOut returns the amounts of every token along the path needed to buy boughtToken
+func CalculateSwapExactOut(pairs []types.SwapTokenPair, path []string, boughtToken sdk.SysCoin, params types.Params) ([]sdk.SysCoin, error) {
+	amounts := make([]sdk.SysCoin, len(path))
+	amounts[len(path)-1] = boughtToken
+	for i := len(pairs) - 1; i >= 0; i-- {
+		tokenSell, err := CalculateTokenToSell(pairs[i], amounts[i+1], path[i], params)
+		if err != nil {
+			return nil, err
+		}
+		amounts[i] = tokenSell
+	}
+	return amounts, nil
+}
+
+// FindBestSwapPath searches the swap token pairs with liquidity for the path which buys the most tokenToBuy with
+// soldToken. Paths go through at most MaxSwapPathHops pairs, the shorter path wins when two paths buy the same amount.
+func (k Keeper) FindBestSwapPath(ctx sdk.Context, soldToken sdk.SysCoin, tokenToBuy string) (types.SwapPathInfo, error) {
+	if soldToken.Denom == tokenToBuy {
+		return types.SwapPathInfo{}, types.ErrSellAmountEqualBuyToken()
+	}
+
+	// pairs are iterated in the order of their names, which keeps the search deterministic
+	graph := make(map[string][]types.SwapTokenPair)
+	for _, pair := range k.GetSwapTokenPairs(ctx) {
+		if pair.BasePooledCoin.IsZero() || pair.QuotePooledCoin.IsZero() {
+			continue
+		}
+		graph[pair.BasePooledCoin.Denom] = append(graph[pair.BasePooledCoin.Denom], pair)
+		graph[pair.QuotePooledCoin.Denom] = append(graph[pair.QuotePooledCoin.Denom], pair)
+	}
+
+	params := k.GetParams(ctx)
+	var best types.SwapPathInfo
+	found := false
+	visited := map[string]bool{soldToken.Denom: true}
+	var search func(path []string, amounts []sdk.SysCoin)
+	search = func(path []string, amounts []sdk.SysCoin) {
+		current := amounts[len(amounts)-1]
+		if current.Denom == tokenToBuy {
+			if !found || current.Amount.GT(best.BuyAmount.Amount) ||
+				(current.Amount.Equal(best.BuyAmount.Amount) && len(path) < len(best.Path)) {
+				best = types.SwapPathInfo{
+					Path:      append([]string{}, path...),
+					Amounts:   append([]sdk.SysCoin{}, amounts...),
+					BuyAmount: current,
+				}
+				found = true
+			}
+			return
+		}
+		if len(path)-1 >= types.MaxSwapPathHops {
+			return
+		}
+		for _, pair := range graph[current.Denom] {
+			next := pair.BasePooledCoin.Denom
+			if next == current.Denom {
+				next = pair.QuotePooledCoin.Denom
+			}
+			if visited[next] {
+				continue
+			}
+			tokenBuy := CalculateTokenToBuy(pair, current, next, params)
+			if !tokenBuy.IsPositive() {
+				continue
+			}
+			visited[next] = true
+			search(append(path, next), append(amounts, tokenBuy))
+			visited[next] = false
+		}
+	}
+	search([]string{soldToken.Denom}, []sdk.SysCoin{soldToken})
+
+	if !found {
+		return types.SwapPathInfo{}, types.ErrSwapPathNotFound(soldToken.Denom, tokenToBuy)
+	}
+	return best, nil
+}
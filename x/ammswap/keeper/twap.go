@@ -0,0 +1,167 @@
+package keeper
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/ammswap/types"
+)
+
+// SetPriceSnapshot stores the price snapshot of a token pair
+func (k Keeper) SetPriceSnapshot(ctx sdk.Context, tokenPairName string, snapshot types.PriceSnapshot) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(snapshot)
+	store.Set(types.GetPriceSnapshotKey(tokenPairName, snapshot.Timestamp), bz)
+}
+
+// GetLatestPriceSnapshot returns the latest price snapshot of a token pair
+func (k Keeper) GetLatestPriceSnapshot(ctx sdk.Context, tokenPairName string) (types.PriceSnapshot, bool) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStoreReversePrefixIterator(store, types.GetPriceSnapshotPrefix(tokenPairName))
+	defer iterator.Close()
+	if !iterator.Valid() {
+		return types.PriceSnapshot{}, false
+	}
+	var snapshot types.PriceSnapshot
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &snapshot)
+	return snapshot, true
+}
+
+// GetPriceSnapshotAtOrBefore returns the latest price snapshot of a token pair taken no later than timestamp
+func (k Keeper) GetPriceSnapshotAtOrBefore(ctx sdk.Context, tokenPairName string, timestamp int64) (types.PriceSnapshot, bool) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.ReverseIterator(types.GetPriceSnapshotPrefix(tokenPairName), types.GetPriceSnapshotKey(tokenPairName, timestamp+1))
+	defer iterator.Close()
+	if !iterator.Valid() {
+		return types.PriceSnapshot{}, false
+	}
+	var snapshot types.PriceSnapshot
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &snapshot)
+	return snapshot, true
+}
+
+// GetPriceSnapshots returns all the price snapshots of a token pair in time order
+func (k Keeper) GetPriceSnapshots(ctx sdk.Context, tokenPairName string) []types.PriceSnapshot {
+	var snapshots []types.PriceSnapshot
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetPriceSnapshotPrefix(tokenPairName))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var snapshot types.PriceSnapshot
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &snapshot)
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots
+}
+
+// GetPriceSnapshotAfter returns the earliest price snapshot of a token pair taken later than timestamp
+func (k Keeper) GetPriceSnapshotAfter(ctx sdk.Context, tokenPairName string, timestamp int64) (types.PriceSnapshot, bool) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(types.GetPriceSnapshotKey(tokenPairName, timestamp+1),
+		sdk.PrefixEndBytes(types.GetPriceSnapshotPrefix(tokenPairName)))
+	defer iterator.Close()
+	if !iterator.Valid() {
+		return types.PriceSnapshot{}, false
+	}
+	var snapshot types.PriceSnapshot
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &snapshot)
+	return snapshot, true
+}
+
+// updateCumulativePrice accumulates the spot price of a token pair over the seconds since its latest snapshot,
+// which is the accumulator of the token pair, and stores a new snapshot at the block time. It must run before
+// the pooled coins change. Only the first change of a block accumulates, so the accumulated price is always
+// the one the previous block left.
+func (k Keeper) updateCumulativePrice(ctx sdk.Context, tokenPairName string) {
+	now := ctx.BlockTime().Unix()
+	snapshot, found := k.GetLatestPriceSnapshot(ctx, tokenPairName)
+	if !found {
+		k.SetPriceSnapshot(ctx, tokenPairName, types.NewPriceSnapshot(sdk.ZeroDec(), sdk.ZeroDec(), now))
+		return
+	}
+	if now <= snapshot.Timestamp {
+		return
+	}
+	if swapTokenPair, err := k.GetSwapTokenPair(ctx, tokenPairName); err == nil {
+		snapshot = accumulatePrice(snapshot, swapTokenPair, now)
+	}
+	snapshot.Timestamp = now
+	k.SetPriceSnapshot(ctx, tokenPairName, snapshot)
+	k.prunePriceSnapshots(ctx, tokenPairName, now-types.TWAPSnapshotRetention)
+}
+
+// accumulatePrice returns the snapshot advanced to timestamp with the spot price of the pooled coins
+func accumulatePrice(snapshot types.PriceSnapshot, swapTokenPair types.SwapTokenPair, timestamp int64) types.PriceSnapshot {
+	elapsed := timestamp - snapshot.Timestamp
+	if elapsed > 0 && swapTokenPair.BasePooledCoin.IsPositive() && swapTokenPair.QuotePooledCoin.IsPositive() {
+		basePrice := swapTokenPair.QuotePooledCoin.Amount.Quo(swapTokenPair.BasePooledCoin.Amount)
+		quotePrice := swapTokenPair.BasePooledCoin.Amount.Quo(swapTokenPair.QuotePooledCoin.Amount)
+		snapshot.BaseCumulativePrice = snapshot.BaseCumulativePrice.Add(basePrice.MulInt64(elapsed))
+		snapshot.QuoteCumulativePrice = snapshot.QuoteCumulativePrice.Add(quotePrice.MulInt64(elapsed))
+	}
+	snapshot.Timestamp = timestamp
+	return snapshot
+}
+
+// prunePriceSnapshots deletes the snapshots taken before cutoff except the latest one of them,
+// which is still needed by a TWAP window starting at cutoff
+func (k Keeper) prunePriceSnapshots(ctx sdk.Context, tokenPairName string, cutoff int64) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(types.GetPriceSnapshotPrefix(tokenPairName), types.GetPriceSnapshotKey(tokenPairName, cutoff+1))
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, append([]byte{}, iterator.Key()...))
+	}
+	iterator.Close()
+	for i := 0; i+1 < len(keys); i++ {
+		store.Delete(keys[i])
+	}
+}
+
+// GetTWAP returns the time-weighted average prices of a token pair over the window seconds before the block time.
+// The cumulative prices between two snapshots are interpolated, since the spot price only changes at a snapshot,
+// and the cumulative prices after the latest snapshot are accumulated with the current spot price.
+func (k Keeper) GetTWAP(ctx sdk.Context, tokenPairName string, window int64) (types.TWAP, error) {
+	if window <= 0 || window > types.TWAPSnapshotRetention {
+		return types.TWAP{}, types.ErrInvalidTWAPWindow(window)
+	}
+	now := ctx.BlockTime().Unix()
+	startTime := now - window
+	latest, found := k.GetLatestPriceSnapshot(ctx, tokenPairName)
+	if !found {
+		return types.TWAP{}, types.ErrInsufficientPriceHistory(tokenPairName, startTime)
+	}
+	swapTokenPair, err := k.GetSwapTokenPair(ctx, tokenPairName)
+	if err != nil {
+		return types.TWAP{}, err
+	}
+	end := accumulatePrice(latest, swapTokenPair, now)
+
+	before, found := k.GetPriceSnapshotAtOrBefore(ctx, tokenPairName, startTime)
+	if !found {
+		return types.TWAP{}, types.ErrInsufficientPriceHistory(tokenPairName, startTime)
+	}
+	after, found := k.GetPriceSnapshotAfter(ctx, tokenPairName, startTime)
+	if !found {
+		after = end
+	}
+	start := types.NewPriceSnapshot(
+		interpolate(before.BaseCumulativePrice, after.BaseCumulativePrice, before.Timestamp, after.Timestamp, startTime),
+		interpolate(before.QuoteCumulativePrice, after.QuoteCumulativePrice, before.Timestamp, after.Timestamp, startTime),
+		startTime,
+	)
+
+	return types.TWAP{
+		TokenPairName: tokenPairName,
+		BasePrice:     end.BaseCumulativePrice.Sub(start.BaseCumulativePrice).QuoInt64(window),
+		QuotePrice:    end.QuoteCumulativePrice.Sub(start.QuoteCumulativePrice).QuoInt64(window),
+		StartTime:     startTime,
+		EndTime:       now,
+	}, nil
+}
+
+// interpolate returns the cumulative price at timestamp between the cumulative prices at t0 and t1
+func interpolate(price0, price1 sdk.Dec, t0, t1, timestamp int64) sdk.Dec {
+	if t1 <= t0 {
+		return price0
+	}
+	return price0.Add(price1.Sub(price0).MulInt64(timestamp - t0).QuoInt64(t1 - t0))
+}
@@ -0,0 +1,111 @@
+package keeper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/okex/exchain/app"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/ammswap/keeper"
+	"github.com/okex/exchain/x/ammswap/types"
+	"github.com/stretchr/testify/require"
+)
+
+func initTWAPTest(t *testing.T, startTime time.Time) (sdk.Context, keeper.Keeper, types.SwapTokenPair) {
+	mapp := app.Setup(false)
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Height: 1, Time: startTime})
+	swapTokenPair := *types.NewSwapTokenPair(
+		sdk.NewDecCoinFromDec(types.TestQuotePooledToken, sdk.NewDec(200)),
+		sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(100)),
+		types.GetPoolTokenName(types.TestBasePooledToken, types.TestQuotePooledToken),
+	)
+	return ctx, mapp.SwapKeeper, swapTokenPair
+}
+
+func TestGetTWAP(t *testing.T) {
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(1)
+	defer tmtypes.UnittestOnlySetMilestoneVenus2Height(0)
+	startTime := time.Unix(1600000000, 0)
+	ctx, k, swapTokenPair := initTWAPTest(t, startTime)
+	tokenPairName := swapTokenPair.TokenPairName()
+
+	// creating the pool starts the accumulator
+	k.SetSwapTokenPair(ctx, tokenPairName, swapTokenPair)
+	require.Equal(t, 1, len(k.GetPriceSnapshots(ctx, tokenPairName)))
+	_, err := k.GetTWAP(ctx, tokenPairName, 10)
+	require.NotNil(t, err)
+
+	// base price is 2 for 10s, then 4 for 30s. Only the first change of a block accumulates the price
+	ctx.SetBlockHeight(2)
+	ctx.SetBlockTime(startTime.Add(10 * time.Second))
+	swapTokenPair.QuotePooledCoin.Amount = sdk.NewDec(800)
+	k.SetSwapTokenPair(ctx, tokenPairName, swapTokenPair)
+	swapTokenPair.QuotePooledCoin.Amount = sdk.NewDec(400)
+	k.SetSwapTokenPair(ctx, tokenPairName, swapTokenPair)
+	require.Equal(t, 2, len(k.GetPriceSnapshots(ctx, tokenPairName)))
+
+	// no snapshot is stored while the pool doesn't change
+	ctx.SetBlockHeight(3)
+	ctx.SetBlockTime(startTime.Add(40 * time.Second))
+	require.Equal(t, 2, len(k.GetPriceSnapshots(ctx, tokenPairName)))
+
+	twap, err := k.GetTWAP(ctx, tokenPairName, 40)
+	require.Nil(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("3.5"), twap.BasePrice)
+	require.Equal(t, sdk.MustNewDecFromStr("0.3125"), twap.QuotePrice)
+	require.Equal(t, startTime.Unix(), twap.StartTime)
+	require.Equal(t, startTime.Unix()+40, twap.EndTime)
+
+	twap, err = k.GetTWAP(ctx, tokenPairName, 30)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(4), twap.BasePrice)
+	require.Equal(t, startTime.Unix()+10, twap.StartTime)
+
+	// the cumulative price at the start of the window is interpolated between the snapshots around it
+	twap, err = k.GetTWAP(ctx, tokenPairName, 35)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(2*5+4*30).QuoInt64(35), twap.BasePrice)
+	require.Equal(t, startTime.Unix()+5, twap.StartTime)
+
+	_, err = k.GetTWAP(ctx, tokenPairName, 41)
+	require.NotNil(t, err)
+	_, err = k.GetTWAP(ctx, tokenPairName, 0)
+	require.NotNil(t, err)
+	_, err = k.GetTWAP(ctx, tokenPairName, types.TWAPSnapshotRetention+1)
+	require.NotNil(t, err)
+
+	querier := keeper.NewQuerier(k)
+	queryParams := types.NewQueryTWAPParams(tokenPairName, 40)
+	resultBytes, err := querier(ctx, []string{types.QueryTWAP}, abci.RequestQuery{Data: types.ModuleCdc.MustMarshalJSON(queryParams)})
+	require.Nil(t, err)
+	var result types.TWAP
+	types.ModuleCdc.MustUnmarshalJSON(resultBytes, &result)
+	require.Equal(t, sdk.MustNewDecFromStr("3.5"), result.BasePrice)
+
+	// snapshots out of retention are pruned except the one a full window needs
+	ctx.SetBlockHeight(4)
+	ctx.SetBlockTime(startTime.Add(time.Duration(types.TWAPSnapshotRetention+20) * time.Second))
+	k.SetSwapTokenPair(ctx, tokenPairName, swapTokenPair)
+	snapshots := k.GetPriceSnapshots(ctx, tokenPairName)
+	require.Equal(t, 2, len(snapshots))
+	require.Equal(t, startTime.Unix()+10, snapshots[0].Timestamp)
+	twap, err = k.GetTWAP(ctx, tokenPairName, types.TWAPSnapshotRetention)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(4), twap.BasePrice)
+}
+
+func TestGetTWAPBeforeVenus2(t *testing.T) {
+	startTime := time.Unix(1600000000, 0)
+	ctx, k, swapTokenPair := initTWAPTest(t, startTime)
+	tokenPairName := swapTokenPair.TokenPairName()
+
+	k.SetSwapTokenPair(ctx, tokenPairName, swapTokenPair)
+	ctx.SetBlockHeight(2)
+	ctx.SetBlockTime(startTime.Add(10 * time.Second))
+	k.SetSwapTokenPair(ctx, tokenPairName, swapTokenPair)
+	require.Equal(t, 0, len(k.GetPriceSnapshots(ctx, tokenPairName)))
+	_, err := k.GetTWAP(ctx, tokenPairName, 10)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,203 @@
+package ammswap_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/okex/exchain/app"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/mint"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/ammswap"
+	"github.com/okex/exchain/x/ammswap/keeper"
+	"github.com/okex/exchain/x/ammswap/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	tokenA = types.TestBasePooledToken
+	tokenC = types.TestBasePooledToken2
+	tokenD = types.TestBasePooledToken3
+	quote  = types.TestQuotePooledToken
+)
+
+// initSwapPathTest creates the pools a/quote and c/quote with deep liquidity, a shallow direct pool a/c,
+// and funds the sender with 100 a
+func initSwapPathTest(t *testing.T, blockTime time.Time) (*app.OKExChainApp, sdk.Context, sdk.AccAddress) {
+	mapp := app.Setup(false)
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Height: 1, Time: blockTime})
+	mapp.SwapKeeper.SetParams(ctx, types.DefaultParams())
+
+	pools := [][2]sdk.SysCoin{
+		{sdk.NewDecCoinFromDec(tokenA, sdk.NewDec(1000)), sdk.NewDecCoinFromDec(quote, sdk.NewDec(1000))},
+		{sdk.NewDecCoinFromDec(tokenC, sdk.NewDec(1000)), sdk.NewDecCoinFromDec(quote, sdk.NewDec(1000))},
+		{sdk.NewDecCoinFromDec(tokenA, sdk.NewDec(10)), sdk.NewDecCoinFromDec(tokenC, sdk.NewDec(10))},
+	}
+	for _, pool := range pools {
+		base, quote := pool[0], pool[1]
+		swapTokenPair := *types.NewSwapTokenPair(quote, base, types.GetPoolTokenName(base.Denom, quote.Denom))
+		mapp.SwapKeeper.SetSwapTokenPair(ctx, swapTokenPair.TokenPairName(), swapTokenPair)
+		require.Nil(t, mapp.SupplyKeeper.MintCoins(ctx, types.ModuleName, sdk.SysCoins{base, quote}))
+	}
+
+	sender := sdk.AccAddress([]byte("swap-path-sender-001"))
+	coins := sdk.SysCoins{sdk.NewDecCoinFromDec(tokenA, sdk.NewDec(100))}
+	require.Nil(t, mapp.SupplyKeeper.MintCoins(ctx, mint.ModuleName, coins))
+	require.Nil(t, mapp.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, mint.ModuleName, sender, coins))
+	return mapp, ctx, sender
+}
+
+func TestFindBestSwapPath(t *testing.T) {
+	mapp, ctx, _ := initSwapPathTest(t, time.Unix(1600000000, 0))
+	k := mapp.SwapKeeper
+	soldToken := sdk.NewDecCoinFromDec(tokenA, sdk.NewDec(10))
+
+	// the deep pools through the quote token buy more than the shallow direct pool
+	pathInfo, err := k.FindBestSwapPath(ctx, soldToken, tokenC)
+	require.Nil(t, err)
+	require.Equal(t, []string{tokenA, quote, tokenC}, pathInfo.Path)
+	pairs, err := k.GetSwapPathPairs(ctx, pathInfo.Path)
+	require.Nil(t, err)
+	amounts := keeper.CalculateSwapExactIn(pairs, pathInfo.Path, soldToken, k.GetParams(ctx))
+	require.Equal(t, amounts, pathInfo.Amounts)
+	require.Equal(t, amounts[2], pathInfo.BuyAmount)
+
+	direct, err := k.GetSwapPathPairs(ctx, []string{tokenA, tokenC})
+	require.Nil(t, err)
+	directAmounts := keeper.CalculateSwapExactIn(direct, []string{tokenA, tokenC}, soldToken, k.GetParams(ctx))
+	require.True(t, pathInfo.BuyAmount.Amount.GT(directAmounts[1].Amount))
+
+	// the direct pool wins once it is the deepest one
+	swapTokenPair, err := k.GetSwapTokenPair(ctx, types.GetSwapTokenPairName(tokenA, tokenC))
+	require.Nil(t, err)
+	swapTokenPair.BasePooledCoin.Amount = sdk.NewDec(100000)
+	swapTokenPair.QuotePooledCoin.Amount = sdk.NewDec(100000)
+	k.SetSwapTokenPair(ctx, swapTokenPair.TokenPairName(), swapTokenPair)
+	pathInfo, err = k.FindBestSwapPath(ctx, soldToken, tokenC)
+	require.Nil(t, err)
+	require.Equal(t, []string{tokenA, tokenC}, pathInfo.Path)
+
+	// no pool has liquidity for the token
+	_, err = k.FindBestSwapPath(ctx, soldToken, tokenD)
+	require.NotNil(t, err)
+	_, err = k.FindBestSwapPath(ctx, soldToken, tokenA)
+	require.NotNil(t, err)
+}
+
+func TestValidateSwapPath(t *testing.T) {
+	sender := sdk.AccAddress([]byte("swap-path-sender-001"))
+	sold := sdk.NewDecCoinFromDec(tokenA, sdk.NewDec(10))
+	bought := sdk.NewDecCoinFromDec(tokenD, sdk.NewDec(1))
+
+	require.Nil(t, types.ValidateSwapPath([]string{tokenA, quote, tokenC, tokenD}))
+	require.NotNil(t, types.ValidateSwapPath([]string{tokenA}))
+
+	// a path can't go through a token twice
+	path := []string{tokenA, quote, tokenC, quote, tokenD}
+	require.NotNil(t, types.ValidateSwapPath(path))
+	require.NotNil(t, types.NewMsgSwapExactIn(path, sold, bought, 0, sender, sender).ValidateBasic())
+	require.NotNil(t, types.NewMsgSwapExactOut(path, bought, sold, 0, sender, sender).ValidateBasic())
+	path = []string{tokenA, quote, tokenA}
+	require.NotNil(t, types.ValidateSwapPath(path))
+
+	// the path must start with the sold token and end with the bought token
+	path = []string{tokenA, quote, tokenD}
+	require.Nil(t, types.NewMsgSwapExactIn(path, sold, bought, 0, sender, sender).ValidateBasic())
+	require.NotNil(t, types.NewMsgSwapExactIn([]string{quote, tokenD}, sold, bought, 0, sender, sender).ValidateBasic())
+	require.NotNil(t, types.NewMsgSwapExactOut([]string{tokenA, quote}, bought, sold, 0, sender, sender).ValidateBasic())
+}
+
+func TestHandleMsgSwapExactIn(t *testing.T) {
+	blockTime := time.Unix(1600000000, 0)
+	mapp, ctx, sender := initSwapPathTest(t, blockTime)
+	k := mapp.SwapKeeper
+	handler := ammswap.NewHandler(k)
+	recipient := sdk.AccAddress([]byte("swap-path-recipient1"))
+	deadline := blockTime.Unix() + 100
+
+	path := []string{tokenA, quote, tokenC}
+	soldToken := sdk.NewDecCoinFromDec(tokenA, sdk.NewDec(10))
+	pairs, err := k.GetSwapPathPairs(ctx, path)
+	require.Nil(t, err)
+	amounts := keeper.CalculateSwapExactIn(pairs, path, soldToken, k.GetParams(ctx))
+	tokenBuy := amounts[len(amounts)-1]
+
+	// the swaps along a path are rejected before the Venus2 height
+	_, err = handler(ctx, types.NewMsgSwapExactIn(path, soldToken, tokenBuy, deadline, recipient, sender))
+	require.NotNil(t, err)
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(1)
+	defer tmtypes.UnittestOnlySetMilestoneVenus2Height(0)
+
+	// the bought amount is less than the min bought amount
+	minBought := sdk.NewDecCoinFromDec(tokenC, tokenBuy.Amount.Add(sdk.NewDecWithPrec(1, sdk.Precision)))
+	_, err = handler(ctx, types.NewMsgSwapExactIn(path, soldToken, minBought, deadline, recipient, sender))
+	require.NotNil(t, err)
+	require.Equal(t, sdk.NewDec(100), mapp.AccountKeeper.GetAccount(ctx, sender).GetCoins().AmountOf(tokenA))
+
+	_, err = handler(ctx, types.NewMsgSwapExactIn(path, soldToken, tokenBuy, deadline-200, recipient, sender))
+	require.NotNil(t, err)
+
+	_, err = handler(ctx, types.NewMsgSwapExactIn(path, soldToken, tokenBuy, deadline, recipient, sender))
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(90), mapp.AccountKeeper.GetAccount(ctx, sender).GetCoins().AmountOf(tokenA))
+	require.Equal(t, tokenBuy.Amount, mapp.AccountKeeper.GetAccount(ctx, recipient).GetCoins().AmountOf(tokenC))
+
+	// every pool along the path is updated, the direct pool is untouched
+	swapTokenPair, err := k.GetSwapTokenPair(ctx, types.GetSwapTokenPairName(tokenA, quote))
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(1010), swapTokenPair.BasePooledCoin.Amount)
+	require.Equal(t, sdk.NewDec(1000).Sub(amounts[1].Amount), swapTokenPair.QuotePooledCoin.Amount)
+	swapTokenPair, err = k.GetSwapTokenPair(ctx, types.GetSwapTokenPairName(tokenC, quote))
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(1000).Sub(tokenBuy.Amount), swapTokenPair.BasePooledCoin.Amount)
+	require.Equal(t, sdk.NewDec(1000).Add(amounts[1].Amount), swapTokenPair.QuotePooledCoin.Amount)
+	swapTokenPair, err = k.GetSwapTokenPair(ctx, types.GetSwapTokenPairName(tokenA, tokenC))
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(10), swapTokenPair.BasePooledCoin.Amount)
+
+	// a pool along the path doesn't exist
+	_, err = handler(ctx, types.NewMsgSwapExactIn([]string{tokenA, quote, tokenD}, soldToken,
+		sdk.NewDecCoinFromDec(tokenD, sdk.ZeroDec()), deadline, recipient, sender))
+	require.NotNil(t, err)
+}
+
+func TestHandleMsgSwapExactOut(t *testing.T) {
+	blockTime := time.Unix(1600000000, 0)
+	mapp, ctx, sender := initSwapPathTest(t, blockTime)
+	k := mapp.SwapKeeper
+	handler := ammswap.NewHandler(k)
+	recipient := sdk.AccAddress([]byte("swap-path-recipient1"))
+	deadline := blockTime.Unix() + 100
+
+	path := []string{tokenA, quote, tokenC}
+	boughtToken := sdk.NewDecCoinFromDec(tokenC, sdk.NewDec(5))
+	pairs, err := k.GetSwapPathPairs(ctx, path)
+	require.Nil(t, err)
+	requiredAmounts, err := keeper.CalculateSwapExactOut(pairs, path, boughtToken, k.GetParams(ctx))
+	require.Nil(t, err)
+	tokenSell := requiredAmounts[0]
+
+	// the swaps along a path are rejected before the Venus2 height
+	_, err = handler(ctx, types.NewMsgSwapExactOut(path, boughtToken, tokenSell, deadline, recipient, sender))
+	require.NotNil(t, err)
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(1)
+	defer tmtypes.UnittestOnlySetMilestoneVenus2Height(0)
+
+	// the sold amount is more than the max sold amount
+	maxSold := sdk.NewDecCoinFromDec(tokenA, tokenSell.Amount.Sub(sdk.NewDecWithPrec(1, sdk.Precision)))
+	_, err = handler(ctx, types.NewMsgSwapExactOut(path, boughtToken, maxSold, deadline, recipient, sender))
+	require.NotNil(t, err)
+	require.Equal(t, sdk.NewDec(100), mapp.AccountKeeper.GetAccount(ctx, sender).GetCoins().AmountOf(tokenA))
+
+	// the sender can't afford the sold amount
+	_, err = handler(ctx, types.NewMsgSwapExactOut(path, sdk.NewDecCoinFromDec(tokenC, sdk.NewDec(500)),
+		sdk.NewDecCoinFromDec(tokenA, sdk.NewDec(100000)), deadline, recipient, sender))
+	require.NotNil(t, err)
+
+	_, err = handler(ctx, types.NewMsgSwapExactOut(path, boughtToken, tokenSell, deadline, recipient, sender))
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(100).Sub(tokenSell.Amount),
+		mapp.AccountKeeper.GetAccount(ctx, sender).GetCoins().AmountOf(tokenA))
+	require.True(t, mapp.AccountKeeper.GetAccount(ctx, recipient).GetCoins().AmountOf(tokenC).GTE(boughtToken.Amount))
+}
@@ -10,6 +10,8 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgRemoveLiquidity{}, "okexchain/ammswap/MsgRemoveLiquidity", nil)
 	cdc.RegisterConcrete(MsgCreateExchange{}, "okexchain/ammswap/MsgCreateExchange", nil)
 	cdc.RegisterConcrete(MsgTokenToToken{}, "okexchain/ammswap/MsgSwapToken", nil)
+	cdc.RegisterConcrete(MsgSwapExactIn{}, "okexchain/ammswap/MsgSwapExactIn", nil)
+	cdc.RegisterConcrete(MsgSwapExactOut{}, "okexchain/ammswap/MsgSwapExactOut", nil)
 }
 
 // ModuleCdc defines the module codec
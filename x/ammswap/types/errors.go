@@ -56,6 +56,12 @@ const (
 	CodeIsSwapTokenPairExist                    uint32 = 65043
 	CodeIsPoolTokenPairExist                    uint32 = 65044
 	CodeInternalError                           uint32 = 65045
+	CodeInvalidSwapPath                         uint32 = 65046
+	CodeInsufficientLiquidity                   uint32 = 65047
+	CodeSwapPathNotFound                        uint32 = 65048
+	CodeInvalidTWAPWindow                       uint32 = 65049
+	CodeInsufficientPriceHistory                uint32 = 65050
+	CodeSwapPathNotEnabled                      uint32 = 65051
 )
 
 func ErrNonExistSwapTokenPair(tokenPairName string) sdk.EnvelopedErr {
@@ -239,3 +245,27 @@ func ErrSwapTokenPairExist() sdk.EnvelopedErr {
 func ErrPoolTokenPairExist() sdk.EnvelopedErr {
 	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeIsPoolTokenPairExist, "the pool token pair already exists")}
 }
+
+func ErrInvalidSwapPath(msg string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeInvalidSwapPath, fmt.Sprintf("invalid swap path: %s", msg))}
+}
+
+func ErrInsufficientLiquidity(tokenPairName string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeInsufficientLiquidity, fmt.Sprintf("insufficient liquidity in swap token pair: %s", tokenPairName))}
+}
+
+func ErrSwapPathNotFound(soldToken, tokenToBuy string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeSwapPathNotFound, fmt.Sprintf("no swap path found from %s to %s", soldToken, tokenToBuy))}
+}
+
+func ErrInvalidTWAPWindow(window int64) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeInvalidTWAPWindow, fmt.Sprintf("twap window should be positive and no more than %d seconds: %d", TWAPSnapshotRetention, window))}
+}
+
+func ErrInsufficientPriceHistory(tokenPairName string, startTime int64) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeInsufficientPriceHistory, fmt.Sprintf("no price snapshot of %s at or before %d", tokenPairName, startTime))}
+}
+
+func ErrSwapPathNotEnabled() sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeSwapPathNotEnabled, "the swaps along a path are not enabled before the Venus2 height")}
+}
@@ -39,6 +39,7 @@ type TokenKeeper interface {
 	GetCoins(ctx sdk.Context, addr sdk.AccAddress) sdk.SysCoins
 	TokenExist(ctx sdk.Context, symbol string) bool
 	GetTokensInfo(ctx sdk.Context) (tokens []token.Token)
+	CheckTransferable(ctx sdk.Context, coins sdk.SysCoins, addrs ...sdk.AccAddress) error
 }
 
 type BackendKeeper interface {
@@ -1,5 +1,9 @@
 package types
 
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
 const (
 	// ModuleName is the name of the module
 	ModuleName = "ammswap"
@@ -21,14 +25,29 @@ const (
 	QueryBuyAmount             = "buy"
 	QuerySwapQuoteInfo         = "swapQuoteInfo"
 	QuerySwapAddLiquidityQuote = "swapAddLiquidityQuote"
+	QueryBestSwapPath          = "bestSwapPath"
+	QueryTWAP                  = "twap"
 )
 
 var (
 	// TokenPairPrefixKey to be used for KVStore
 	TokenPairPrefixKey = []byte{0x01}
+	// PriceSnapshotPrefixKey to be used for KVStore
+	PriceSnapshotPrefixKey = []byte{0x02}
 )
 
 // nolint
 func GetTokenPairKey(key string) []byte {
 	return append(TokenPairPrefixKey, []byte(key)...)
 }
+
+// GetPriceSnapshotPrefix returns the prefix of all price snapshots of a token pair.
+// The name is length prefixed so that a pair name never prefixes another one.
+func GetPriceSnapshotPrefix(tokenPairName string) []byte {
+	return append(append(PriceSnapshotPrefixKey, byte(len(tokenPairName))), []byte(tokenPairName)...)
+}
+
+// GetPriceSnapshotKey returns the key of the price snapshot of a token pair at timestamp
+func GetPriceSnapshotKey(tokenPairName string, timestamp int64) []byte {
+	return append(GetPriceSnapshotPrefix(tokenPairName), sdk.Uint64ToBigEndian(uint64(timestamp))...)
+}
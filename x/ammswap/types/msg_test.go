@@ -263,3 +263,86 @@ func TestMsgTokenToTokenInvalid(t *testing.T) {
 		testCode(t, err, testCase.exceptResultCode)
 	}
 }
+
+func TestMsgSwapExactIn(t *testing.T) {
+	addr, err := hex.DecodeString(addrStr)
+	require.Nil(t, err)
+	path := []string{TestBasePooledToken, TestQuotePooledToken, TestBasePooledToken2}
+	soldTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.NewDec(2))
+	minBoughtTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken2, sdk.NewDec(1))
+	deadLine := time.Now().Unix()
+	msg := NewMsgSwapExactIn(path, soldTokenAmount, minBoughtTokenAmount, deadLine, addr, addr)
+
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, RouterKey, msg.Route())
+	require.Equal(t, TypeMsgSwapExactIn, msg.Type())
+
+	bytesMsg := msg.GetSignBytes()
+	resMsg := &MsgSwapExactIn{}
+	err = json.Unmarshal(bytesMsg, resMsg)
+	require.Nil(t, err)
+	require.EqualValues(t, addr, msg.GetSigners()[0])
+
+	tests := []struct {
+		testCase         string
+		path             []string
+		addr             sdk.AccAddress
+		exceptResultCode uint32
+	}{
+		{"success(direct)", []string{TestBasePooledToken, TestBasePooledToken2}, addr, sdk.CodeOK},
+		{"success(max hops)", []string{TestBasePooledToken, "a1", "a2", TestQuotePooledToken, TestBasePooledToken2}, addr, sdk.CodeOK},
+		{"empty sender", path, nil, sdk.CodeInvalidAddress},
+		{"too short path", []string{TestBasePooledToken}, addr, CodeInvalidSwapPath},
+		{"too long path", []string{TestBasePooledToken, "a1", "a2", "a3", TestQuotePooledToken, TestBasePooledToken2}, addr, CodeInvalidSwapPath},
+		{"repeated token", []string{TestBasePooledToken, TestQuotePooledToken, TestBasePooledToken, TestBasePooledToken2}, addr, CodeInvalidSwapPath},
+		{"invalid token", []string{TestBasePooledToken, "1ab", TestBasePooledToken2}, addr, CodeValidateDenom},
+		{"path does not start with sold token", []string{TestQuotePooledToken, TestBasePooledToken2}, addr, CodeInvalidSwapPath},
+		{"path does not end with bought token", []string{TestBasePooledToken, TestQuotePooledToken}, addr, CodeInvalidSwapPath},
+	}
+	for _, testCase := range tests {
+		msg := NewMsgSwapExactIn(testCase.path, soldTokenAmount, minBoughtTokenAmount, deadLine, addr, testCase.addr)
+		err := msg.ValidateBasic()
+		testCode(t, err, testCase.exceptResultCode)
+	}
+}
+
+func TestMsgSwapExactOut(t *testing.T) {
+	addr, err := hex.DecodeString(addrStr)
+	require.Nil(t, err)
+	path := []string{TestBasePooledToken, TestQuotePooledToken, TestBasePooledToken2}
+	boughtTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken2, sdk.NewDec(1))
+	maxSoldTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.NewDec(2))
+	deadLine := time.Now().Unix()
+	msg := NewMsgSwapExactOut(path, boughtTokenAmount, maxSoldTokenAmount, deadLine, addr, addr)
+
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, RouterKey, msg.Route())
+	require.Equal(t, TypeMsgSwapExactOut, msg.Type())
+
+	bytesMsg := msg.GetSignBytes()
+	resMsg := &MsgSwapExactOut{}
+	err = json.Unmarshal(bytesMsg, resMsg)
+	require.Nil(t, err)
+	require.EqualValues(t, addr, msg.GetSigners()[0])
+
+	zeroBoughtTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken2, sdk.ZeroDec())
+	tests := []struct {
+		testCase           string
+		path               []string
+		boughtTokenAmount  sdk.SysCoin
+		maxSoldTokenAmount sdk.SysCoin
+		recipient          sdk.AccAddress
+		exceptResultCode   uint32
+	}{
+		{"success", path, boughtTokenAmount, maxSoldTokenAmount, addr, sdk.CodeOK},
+		{"empty recipient", path, boughtTokenAmount, maxSoldTokenAmount, nil, sdk.CodeInvalidAddress},
+		{"zero bought token amount", path, zeroBoughtTokenAmount, maxSoldTokenAmount, addr, CodeIsZeroValue},
+		{"path does not match tokens", path, maxSoldTokenAmount, boughtTokenAmount, addr, CodeInvalidSwapPath},
+		{"repeated token", []string{TestBasePooledToken, TestBasePooledToken2, TestBasePooledToken2}, boughtTokenAmount, maxSoldTokenAmount, addr, CodeInvalidSwapPath},
+	}
+	for _, testCase := range tests {
+		msg := NewMsgSwapExactOut(testCase.path, testCase.boughtTokenAmount, testCase.maxSoldTokenAmount, deadLine, testCase.recipient, addr)
+		err := msg.ValidateBasic()
+		testCode(t, err, testCase.exceptResultCode)
+	}
+}
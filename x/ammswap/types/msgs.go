@@ -8,6 +8,8 @@ import (
 const (
 	TypeMsgAddLiquidity = "add_liquidity"
 	TypeMsgTokenSwap    = "token_swap"
+	TypeMsgSwapExactIn  = "swap_exact_in"
+	TypeMsgSwapExactOut = "swap_exact_out"
 )
 
 // MsgAddLiquidity Deposit quote_amount and base_amount at current ratio to mint pool tokens.
@@ -265,3 +267,146 @@ func (msg MsgTokenToToken) GetSigners() []sdk.AccAddress {
 func (msg MsgTokenToToken) GetSwapTokenPairName() string {
 	return GetSwapTokenPairName(msg.MinBoughtTokenAmount.Denom, msg.SoldTokenAmount.Denom)
 }
+
+// MsgSwapExactIn define the message for selling an exact amount of token through a path of swap token pairs
+type MsgSwapExactIn struct {
+	Path                 []string       `json:"path"`                    // Tokens to swap through, from the sold token to the bought token.
+	SoldTokenAmount      sdk.SysCoin    `json:"sold_token_amount"`       // Amount of Tokens sold.
+	MinBoughtTokenAmount sdk.SysCoin    `json:"min_bought_token_amount"` // Minimum token purchased.
+	Deadline             int64          `json:"deadline"`                // Time after which this transaction can no longer be executed.
+	Recipient            sdk.AccAddress `json:"recipient"`               // Recipient address,transfer Tokens to recipient.default recipient is sender.
+	Sender               sdk.AccAddress `json:"sender"`                  // Sender
+}
+
+// NewMsgSwapExactIn is a constructor function for MsgSwapExactIn
+func NewMsgSwapExactIn(
+	path []string, soldTokenAmount, minBoughtTokenAmount sdk.SysCoin, deadline int64, recipient, sender sdk.AccAddress,
+) MsgSwapExactIn {
+	return MsgSwapExactIn{
+		Path:                 path,
+		SoldTokenAmount:      soldTokenAmount,
+		MinBoughtTokenAmount: minBoughtTokenAmount,
+		Deadline:             deadline,
+		Recipient:            recipient,
+		Sender:               sender,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgSwapExactIn) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgSwapExactIn) Type() string { return TypeMsgSwapExactIn }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgSwapExactIn) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return ErrAddressIsRequire("sender")
+	}
+
+	if msg.Recipient.Empty() {
+		return ErrAddressIsRequire("recipient")
+	}
+
+	if !(msg.SoldTokenAmount.IsPositive()) {
+		return ErrSoldTokenAmountIsNegative()
+	}
+	if !msg.SoldTokenAmount.IsValid() {
+		return ErrSoldTokenAmount()
+	}
+
+	if !msg.MinBoughtTokenAmount.IsValid() {
+		return ErrMinBoughtTokenAmount()
+	}
+
+	if err := ValidateSwapPath(msg.Path); err != nil {
+		return err
+	}
+	if msg.Path[0] != msg.SoldTokenAmount.Denom || msg.Path[len(msg.Path)-1] != msg.MinBoughtTokenAmount.Denom {
+		return ErrInvalidSwapPath("path must start with the sold token and end with the bought token")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgSwapExactIn) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgSwapExactIn) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// MsgSwapExactOut define the message for buying an exact amount of token through a path of swap token pairs
+type MsgSwapExactOut struct {
+	Path               []string       `json:"path"`                  // Tokens to swap through, from the sold token to the bought token.
+	BoughtTokenAmount  sdk.SysCoin    `json:"bought_token_amount"`   // Amount of Tokens bought.
+	MaxSoldTokenAmount sdk.SysCoin    `json:"max_sold_token_amount"` // Maximum token sold.
+	Deadline           int64          `json:"deadline"`              // Time after which this transaction can no longer be executed.
+	Recipient          sdk.AccAddress `json:"recipient"`             // Recipient address,transfer Tokens to recipient.default recipient is sender.
+	Sender             sdk.AccAddress `json:"sender"`                // Sender
+}
+
+// NewMsgSwapExactOut is a constructor function for MsgSwapExactOut
+func NewMsgSwapExactOut(
+	path []string, boughtTokenAmount, maxSoldTokenAmount sdk.SysCoin, deadline int64, recipient, sender sdk.AccAddress,
+) MsgSwapExactOut {
+	return MsgSwapExactOut{
+		Path:               path,
+		BoughtTokenAmount:  boughtTokenAmount,
+		MaxSoldTokenAmount: maxSoldTokenAmount,
+		Deadline:           deadline,
+		Recipient:          recipient,
+		Sender:             sender,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgSwapExactOut) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgSwapExactOut) Type() string { return TypeMsgSwapExactOut }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgSwapExactOut) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return ErrAddressIsRequire("sender")
+	}
+
+	if msg.Recipient.Empty() {
+		return ErrAddressIsRequire("recipient")
+	}
+
+	if !msg.BoughtTokenAmount.IsPositive() {
+		return ErrIsZeroValue("bought token amount")
+	}
+	if !msg.BoughtTokenAmount.IsValid() {
+		return ErrMinBoughtTokenAmount()
+	}
+
+	if !(msg.MaxSoldTokenAmount.IsPositive()) {
+		return ErrSoldTokenAmountIsNegative()
+	}
+	if !msg.MaxSoldTokenAmount.IsValid() {
+		return ErrSoldTokenAmount()
+	}
+
+	if err := ValidateSwapPath(msg.Path); err != nil {
+		return err
+	}
+	if msg.Path[0] != msg.MaxSoldTokenAmount.Denom || msg.Path[len(msg.Path)-1] != msg.BoughtTokenAmount.Denom {
+		return ErrInvalidSwapPath("path must start with the sold token and end with the bought token")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgSwapExactOut) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgSwapExactOut) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
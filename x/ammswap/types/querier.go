@@ -50,3 +50,38 @@ type QueryBuyAmountParams struct {
 	SoldToken  sdk.SysCoin
 	TokenToBuy string
 }
+
+// QueryBestSwapPathParams defines the params for querying the best swap path
+type QueryBestSwapPathParams struct {
+	SoldToken  sdk.SysCoin `json:"sold_token"`
+	TokenToBuy string      `json:"token_to_buy"`
+}
+
+// NewQueryBestSwapPathParams creates a new instance of QueryBestSwapPathParams
+func NewQueryBestSwapPathParams(soldToken sdk.SysCoin, tokenToBuy string) QueryBestSwapPathParams {
+	return QueryBestSwapPathParams{
+		SoldToken:  soldToken,
+		TokenToBuy: tokenToBuy,
+	}
+}
+
+// SwapPathInfo defines the quote of swapping through a path
+type SwapPathInfo struct {
+	Path      []string      `json:"path"`
+	Amounts   []sdk.SysCoin `json:"amounts"`
+	BuyAmount sdk.SysCoin   `json:"buy_amount"`
+}
+
+// QueryTWAPParams defines the params for querying the time-weighted average price of a token pair
+type QueryTWAPParams struct {
+	TokenPairName string `json:"token_pair_name"`
+	Window        int64  `json:"window"` // Seconds before the latest block time
+}
+
+// NewQueryTWAPParams creates a new instance of QueryTWAPParams
+func NewQueryTWAPParams(tokenPairName string, window int64) QueryTWAPParams {
+	return QueryTWAPParams{
+		TokenPairName: tokenPairName,
+		Window:        window,
+	}
+}
@@ -12,6 +12,9 @@ import (
 // PoolTokenPrefix defines pool token prefix name
 const PoolTokenPrefix = "ammswap_"
 
+// MaxSwapPathHops defines the max number of swap token pairs a swap path can go through
+const MaxSwapPathHops = 4
+
 // SwapTokenPair defines token pair exchange
 type SwapTokenPair struct {
 	QuotePooledCoin sdk.SysCoin `json:"quote_pooled_coin"` // The volume of quote token in the token pair exchange pool
@@ -118,3 +121,34 @@ func SplitPoolToken(symbol string) (token0, token1 string) {
 	token1 = splits[1]
 	return
 }
+
+// ValidateSwapPath checks that the path is a list of distinct tokens which goes through at least one
+// and at most MaxSwapPathHops swap token pairs
+func ValidateSwapPath(path []string) error {
+	if len(path) < 2 {
+		return ErrInvalidSwapPath("path must contain at least 2 tokens")
+	}
+	if len(path)-1 > MaxSwapPathHops {
+		return ErrInvalidSwapPath(fmt.Sprintf("path goes through more than %d swap token pairs", MaxSwapPathHops))
+	}
+	visited := make(map[string]bool, len(path))
+	for _, denom := range path {
+		if err := ValidateSwapAmountName(denom); err != nil {
+			return err
+		}
+		if visited[denom] {
+			return ErrInvalidSwapPath(fmt.Sprintf("token %s appears more than once", denom))
+		}
+		visited[denom] = true
+	}
+	return nil
+}
+
+// GetSwapPathPairNames returns the names of the swap token pairs the path goes through
+func GetSwapPathPairNames(path []string) []string {
+	var names []string
+	for i := 0; i+1 < len(path); i++ {
+		names = append(names, GetSwapTokenPairName(path[i], path[i+1]))
+	}
+	return names
+}
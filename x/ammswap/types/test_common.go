@@ -29,7 +29,7 @@ func GetTestSwapTokenPair() SwapTokenPair {
 func SetTestTokens(ctx sdk.Context, tokenKeeper token.Keeper, supplyKeeper supply.Keeper, addr sdk.AccAddress, coins sdk.DecCoins) error {
 	for _, coin := range coins {
 		name := coin.Denom
-		tokenKeeper.NewToken(ctx, tokentypes.Token{"", name, name, name, coin.Amount, 1, addr, true})
+		tokenKeeper.NewToken(ctx, tokentypes.Token{"", name, name, name, coin.Amount, 1, addr, true, false, false})
 	}
 	err := supplyKeeper.MintCoins(ctx, tokentypes.ModuleName, coins)
 	if err != nil {
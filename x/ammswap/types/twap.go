@@ -0,0 +1,53 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// TWAPSnapshotRetention defines how many seconds price snapshots are kept, which bounds the TWAP window
+const TWAPSnapshotRetention int64 = 7 * 24 * 60 * 60
+
+// PriceSnapshot records the cumulative prices of a swap token pair at a block time.
+// The cumulative price is the sum of the spot price weighted by the seconds it lasted.
+type PriceSnapshot struct {
+	BaseCumulativePrice  sdk.Dec `json:"base_cumulative_price"`  // Cumulative price of base token in quote token
+	QuoteCumulativePrice sdk.Dec `json:"quote_cumulative_price"` // Cumulative price of quote token in base token
+	Timestamp            int64   `json:"timestamp"`              // Block time in seconds
+}
+
+// NewPriceSnapshot is a constructor function for PriceSnapshot
+func NewPriceSnapshot(baseCumulativePrice, quoteCumulativePrice sdk.Dec, timestamp int64) PriceSnapshot {
+	return PriceSnapshot{
+		BaseCumulativePrice:  baseCumulativePrice,
+		QuoteCumulativePrice: quoteCumulativePrice,
+		Timestamp:            timestamp,
+	}
+}
+
+// String implement fmt.Stringer
+func (s PriceSnapshot) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`BaseCumulativePrice: %s
+QuoteCumulativePrice: %s
+Timestamp: %d`, s.BaseCumulativePrice, s.QuoteCumulativePrice, s.Timestamp))
+}
+
+// TWAP defines the time-weighted average prices of a swap token pair between StartTime and EndTime
+type TWAP struct {
+	TokenPairName string  `json:"token_pair_name"`
+	BasePrice     sdk.Dec `json:"base_price"`  // Average price of base token in quote token
+	QuotePrice    sdk.Dec `json:"quote_price"` // Average price of quote token in base token
+	StartTime     int64   `json:"start_time"`
+	EndTime       int64   `json:"end_time"`
+}
+
+// String implement fmt.Stringer
+func (t TWAP) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`TokenPairName: %s
+BasePrice: %s
+QuotePrice: %s
+StartTime: %d
+EndTime: %d`, t.TokenPairName, t.BasePrice, t.QuotePrice, t.StartTime, t.EndTime))
+}
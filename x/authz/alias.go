@@ -0,0 +1,49 @@
+package authz
+
+import (
+	"github.com/okex/exchain/x/authz/keeper"
+	"github.com/okex/exchain/x/authz/types"
+)
+
+// nolint
+const (
+	ModuleName   = types.ModuleName
+	StoreKey     = types.StoreKey
+	RouterKey    = types.RouterKey
+	QuerierRoute = types.QuerierRoute
+)
+
+// nolint
+var (
+	NewKeeper               = keeper.NewKeeper
+	NewQuerier              = keeper.NewQuerier
+	NewGrant                = types.NewGrant
+	NewGenericAuthorization = types.NewGenericAuthorization
+	NewSendAuthorization    = types.NewSendAuthorization
+	NewStakingAuthorization = types.NewStakingAuthorization
+	NewMsgGrant             = types.NewMsgGrant
+	NewMsgRevoke            = types.NewMsgRevoke
+	NewMsgExec              = types.NewMsgExec
+	MsgTypeKey              = types.MsgTypeKey
+	RegisterCodec           = types.RegisterCodec
+	DefaultGenesisState     = types.DefaultGenesisState
+	ModuleCdc               = types.ModuleCdc
+	ErrNoAuthorization      = types.ErrNoAuthorization
+	ErrAuthorizationExpired = types.ErrAuthorizationExpired
+	ErrUnauthorized         = types.ErrUnauthorized
+	ErrAuthzDisabled        = types.ErrAuthzDisabled
+)
+
+// nolint
+type (
+	Keeper               = keeper.Keeper
+	Authorization        = types.Authorization
+	GenericAuthorization = types.GenericAuthorization
+	SendAuthorization    = types.SendAuthorization
+	StakingAuthorization = types.StakingAuthorization
+	Grant                = types.Grant
+	GenesisState         = types.GenesisState
+	MsgGrant             = types.MsgGrant
+	MsgRevoke            = types.MsgRevoke
+	MsgExec              = types.MsgExec
+)
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client"
+	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	"github.com/okex/exchain/libs/cosmos-sdk/client/flags"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/version"
+	"github.com/okex/exchain/x/authz/types"
+)
+
+// GetQueryCmd returns the CLI command with all authz module query commands mounted.
+func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "Querying commands for the authz module",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	cmd.AddCommand(flags.GetCommands(
+		GetCmdQueryGrants(queryRoute, cdc),
+		GetCmdQueryGranterGrants(queryRoute, cdc),
+	)...)
+	return cmd
+}
+
+// GetCmdQueryGrants implements the command to query the grants from the granter to the grantee
+func GetCmdQueryGrants(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "grants [granter] [grantee] [msg-type]",
+		Short: "Query the grants from the granter to the grantee, optionally of the msg type",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the grants from the granter to the grantee, optionally of the msg type.
+
+Example:
+$ %s query %s grants ex1... ex1...
+$ %s query %s grants ex1... ex1... token/send
+`,
+				version.ClientName, types.ModuleName, version.ClientName, types.ModuleName,
+			),
+		),
+		Args: cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			granter, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			grantee, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+			var msgType string
+			if len(args) == 3 {
+				msgType = args[2]
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryGrantsParams(granter, grantee, msgType))
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryGrants)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var grants []types.Grant
+			if err := cdc.UnmarshalJSON(res, &grants); err != nil {
+				return fmt.Errorf("failed to unmarshal grants: %w", err)
+			}
+			return cliCtx.PrintOutput(grants)
+		},
+	}
+}
+
+// GetCmdQueryGranterGrants implements the command to query all the grants from the granter
+func GetCmdQueryGranterGrants(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "granter-grants [granter]",
+		Short: "Query all the grants from the granter",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query all the grants from the granter.
+
+Example:
+$ %s query %s granter-grants ex1...
+`,
+				version.ClientName, types.ModuleName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			granter, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryGranterGrantsParams(granter))
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryGranterGrants)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var grants []types.Grant
+			if err := cdc.UnmarshalJSON(res, &grants); err != nil {
+				return fmt.Errorf("failed to unmarshal grants: %w", err)
+			}
+			return cliCtx.PrintOutput(grants)
+		},
+	}
+}
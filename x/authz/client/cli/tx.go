@@ -0,0 +1,208 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client"
+	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	"github.com/okex/exchain/libs/cosmos-sdk/client/flags"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/version"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth/client/utils"
+	"github.com/okex/exchain/x/authz/types"
+)
+
+// flags for the authorizations
+const (
+	FlagMsgType           = "msg-type"
+	FlagSpendLimit        = "spend-limit"
+	FlagAllowedValidators = "allowed-validators"
+	FlagExpiration        = "expiration"
+)
+
+// authorization kinds of the grant command
+const (
+	authorizationGeneric = "generic"
+	authorizationSend    = "send"
+	authorizationStaking = "staking"
+)
+
+// GetTxCmd returns the transaction commands for the authz module
+func GetTxCmd(cdc *codec.Codec) *cobra.Command {
+	txCmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "Authorization transactions subcommands",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	txCmd.AddCommand(flags.PostCommands(
+		GetCmdGrant(cdc),
+		GetCmdRevoke(cdc),
+		GetCmdExec(cdc),
+	)...)
+	return txCmd
+}
+
+// GetCmdGrant implements the command to grant an authorization to the grantee
+func GetCmdGrant(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant [grantee] [generic|send|staking]",
+		Short: "Grant the grantee an authorization to execute msgs on behalf of the granter",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Grant the grantee an authorization to execute msgs on behalf of the granter given by --from.
+A generic authorization allows any msg of the type given by --%s, the other kinds are limited to
+the coins or validators given by the flags. The authorization never expires if --%s is empty.
+
+Example:
+$ %s tx %s grant ex1... generic --msg-type=farm/claim --from=mykey
+$ %s tx %s grant ex1... send --spend-limit=100okt --expiration=2023-01-01T00:00:00Z --from=mykey
+$ %s tx %s grant ex1... staking --allowed-validators=exvaloper1...,exvaloper1... --from=mykey
+`,
+				FlagMsgType, FlagExpiration,
+				version.ClientName, types.ModuleName, version.ClientName, types.ModuleName,
+				version.ClientName, types.ModuleName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			authorization, err := authorizationFromFlags(args[1])
+			if err != nil {
+				return err
+			}
+
+			var expiration time.Time
+			if exp := viper.GetString(FlagExpiration); exp != "" {
+				if expiration, err = time.Parse(time.RFC3339, exp); err != nil {
+					return err
+				}
+			}
+
+			msg := types.NewMsgGrant(cliCtx.GetFromAddress(), grantee, authorization, expiration)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagMsgType, "", "The route/type of the msgs allowed by a generic authorization, e.g. farm/claim")
+	cmd.Flags().String(FlagSpendLimit, "", "The total amount of coins the grantee can send by a send authorization")
+	cmd.Flags().StringSlice(FlagAllowedValidators, nil, "The validators the grantee can add shares to by a staking authorization")
+	cmd.Flags().String(FlagExpiration, "", "The RFC3339 time when the authorization expires, never expires if empty")
+	return cmd
+}
+
+// GetCmdRevoke implements the command to revoke the authorization granted to the grantee
+func GetCmdRevoke(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke [grantee] [msg-type]",
+		Short: "Revoke the authorization of the msg type granted to the grantee by the granter",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Revoke the authorization of the msg type granted to the grantee by the granter given by --from.
+
+Example:
+$ %s tx %s revoke ex1... token/send --from=mykey
+`,
+				version.ClientName, types.ModuleName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRevoke(cliCtx.GetFromAddress(), grantee, args[1])
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdExec implements the command to execute the msgs of a tx file on behalf of their signers
+func GetCmdExec(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "exec [tx-json-file]",
+		Short: "Execute the msgs of the tx file on behalf of the granters",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Execute the msgs of the tx file generated by --generate-only on behalf of their signers,
+who have granted the authorizations of the msgs to the grantee given by --from.
+
+Example:
+$ %s tx token send ex1granter... ex1... 10okt --from=ex1granter... --generate-only > tx.json
+$ %s tx %s exec tx.json --from=mykey
+`,
+				version.ClientName, version.ClientName, types.ModuleName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			stdTx, err := utils.ReadStdTxFromFile(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgExec(cliCtx.GetFromAddress(), stdTx.GetMsgs())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func authorizationFromFlags(kind string) (types.Authorization, error) {
+	switch kind {
+	case authorizationGeneric:
+		return types.NewGenericAuthorization(viper.GetString(FlagMsgType)), nil
+	case authorizationSend:
+		spendLimit, err := sdk.ParseDecCoins(viper.GetString(FlagSpendLimit))
+		if err != nil {
+			return nil, err
+		}
+		return types.NewSendAuthorization(spendLimit), nil
+	case authorizationStaking:
+		var validators []sdk.ValAddress
+		for _, v := range viper.GetStringSlice(FlagAllowedValidators) {
+			valAddr, err := sdk.ValAddressFromBech32(v)
+			if err != nil {
+				return nil, err
+			}
+			validators = append(validators, valAddr)
+		}
+		return types.NewStakingAuthorization(validators), nil
+	default:
+		return nil, fmt.Errorf("unknown authorization %q, expected %s, %s or %s",
+			kind, authorizationGeneric, authorizationSend, authorizationStaking)
+	}
+}
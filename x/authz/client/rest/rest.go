@@ -0,0 +1,19 @@
+package rest
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
+)
+
+// REST query parameter values
+const (
+	RestGranter = "granter"
+	RestGrantee = "grantee"
+	RestMsgType = "msg_type"
+)
+
+// RegisterRoutes registers the authz module's REST service handlers
+func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router) {
+	registerQueryRoutes(cliCtx, r)
+}
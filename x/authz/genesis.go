@@ -0,0 +1,33 @@
+package authz
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/authz/keeper"
+	"github.com/okex/exchain/x/authz/types"
+)
+
+// InitGenesis initializes the authz module's state from the genesis state, the expired grants are skipped
+func InitGenesis(ctx sdk.Context, k keeper.Keeper, gs types.GenesisState) {
+	if err := gs.Validate(); err != nil {
+		panic(err)
+	}
+
+	for _, grant := range gs.Grants {
+		if grant.IsExpired(ctx.BlockTime()) {
+			continue
+		}
+		if err := k.SaveGrant(ctx, grant.Granter, grant.Grantee, grant.Authorization, grant.Expiration); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// ExportGenesis returns the authz module's exported genesis state
+func ExportGenesis(ctx sdk.Context, k keeper.Keeper) types.GenesisState {
+	grants := []types.Grant{}
+	k.IterateGrants(ctx, func(grant types.Grant) bool {
+		grants = append(grants, grant)
+		return false
+	})
+	return types.NewGenesisState(grants)
+}
@@ -0,0 +1,80 @@
+package authz
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/authz/keeper"
+	"github.com/okex/exchain/x/authz/types"
+)
+
+// NewHandler returns a handler for authz type messages.
+func NewHandler(k keeper.Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		ctx.SetEventManager(sdk.NewEventManager())
+
+		if !tmtypes.HigherThanVenus2(ctx.BlockHeight()) {
+			return nil, types.ErrAuthzDisabled
+		}
+
+		switch msg := msg.(type) {
+		case types.MsgGrant:
+			return handleMsgGrant(ctx, k, msg)
+		case types.MsgRevoke:
+			return handleMsgRevoke(ctx, k, msg)
+		case types.MsgExec:
+			return handleMsgExec(ctx, k, msg)
+		default:
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized %s message type: %T", types.ModuleName, msg)
+		}
+	}
+}
+
+func handleMsgGrant(ctx sdk.Context, k keeper.Keeper, msg types.MsgGrant) (*sdk.Result, error) {
+	if err := k.SaveGrant(ctx, msg.Granter, msg.Grantee, msg.Authorization, msg.Expiration); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Granter.String()),
+		),
+	)
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgRevoke(ctx sdk.Context, k keeper.Keeper, msg types.MsgRevoke) (*sdk.Result, error) {
+	if err := k.DeleteGrant(ctx, msg.Granter, msg.Grantee, msg.MsgType); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Granter.String()),
+		),
+	)
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgExec(ctx sdk.Context, k keeper.Keeper, msg types.MsgExec) (*sdk.Result, error) {
+	results, err := k.DispatchActions(ctx, msg.Grantee, msg.Msgs)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Grantee.String()),
+		),
+	)
+	return &sdk.Result{
+		Data:   types.ModuleCdc.MustMarshalBinaryLengthPrefixed(results),
+		Events: ctx.EventManager().Events(),
+	}, nil
+}
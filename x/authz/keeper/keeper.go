@@ -0,0 +1,196 @@
+package keeper
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/okex/exchain/x/authz/types"
+)
+
+// Keeper manages the authorizations granted between accounts, and executes the msgs
+// of the granters on behalf of them.
+type Keeper struct {
+	cdc      *codec.Codec
+	storeKey sdk.StoreKey
+	router   sdk.Router
+}
+
+// NewKeeper creates a new authz keeper, the msgs are executed through the handlers of the router
+func NewKeeper(cdc *codec.Codec, storeKey sdk.StoreKey, router sdk.Router) Keeper {
+	return Keeper{
+		cdc:      cdc,
+		storeKey: storeKey,
+		router:   router,
+	}
+}
+
+// Logger returns a module-specific logger.
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
+}
+
+// SaveGrant grants the authorization to the grantee, which replaces the previous one of the same msg type
+func (k Keeper) SaveGrant(ctx sdk.Context, granter, grantee sdk.AccAddress, authorization types.Authorization, expiration time.Time) error {
+	grant := types.NewGrant(granter, grantee, authorization, expiration)
+	if grant.IsExpired(ctx.BlockTime()) {
+		return sdkerrors.Wrapf(types.ErrInvalidExpiration, "%s <= %s", expiration, ctx.BlockTime())
+	}
+
+	k.setGrant(ctx, grant)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeGrant,
+			sdk.NewAttribute(types.AttributeKeyGranter, granter.String()),
+			sdk.NewAttribute(types.AttributeKeyGrantee, grantee.String()),
+			sdk.NewAttribute(types.AttributeKeyMsgType, authorization.MsgType()),
+		),
+	)
+	return nil
+}
+
+// DeleteGrant revokes the authorization granted to the grantee to execute the msgs of the type
+func (k Keeper) DeleteGrant(ctx sdk.Context, granter, grantee sdk.AccAddress, msgType string) error {
+	if _, found := k.GetGrant(ctx, granter, grantee, msgType); !found {
+		return sdkerrors.Wrapf(types.ErrNoAuthorization, "granter %s, grantee %s, msg type %s", granter, grantee, msgType)
+	}
+
+	k.deleteGrant(ctx, granter, grantee, msgType)
+	return nil
+}
+
+// GetGrant returns the grant of the authorization to execute the msgs of the type
+func (k Keeper) GetGrant(ctx sdk.Context, granter, grantee sdk.AccAddress, msgType string) (grant types.Grant, found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GrantKey(granter, grantee, msgType))
+	if len(bz) == 0 {
+		return grant, false
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &grant)
+	return grant, true
+}
+
+// DispatchActions executes the msgs on behalf of their signers, after spending the authorizations
+// granted by the signers to the grantee. The msgs signed by the grantee itself are executed directly.
+func (k Keeper) DispatchActions(ctx sdk.Context, grantee sdk.AccAddress, msgs []sdk.Msg) ([][]byte, error) {
+	results := make([][]byte, len(msgs))
+	for i, msg := range msgs {
+		signers := msg.GetSigners()
+		if len(signers) != 1 {
+			return nil, sdkerrors.Wrapf(types.ErrInvalidMsg, "msg %s must have exactly one signer", types.MsgTypeKey(msg))
+		}
+
+		granter := signers[0]
+		if !granter.Equals(grantee) {
+			if err := k.useGrant(ctx, granter, grantee, msg); err != nil {
+				return nil, err
+			}
+		}
+
+		handler := k.router.Route(ctx, msg.Route())
+		if handler == nil {
+			return nil, sdkerrors.Wrapf(types.ErrNoHandler, "msg %s", types.MsgTypeKey(msg))
+		}
+
+		res, err := handler(ctx, msg)
+		if err != nil {
+			return nil, sdkerrors.Wrapf(err, "failed to execute msg %s", types.MsgTypeKey(msg))
+		}
+
+		// NOTE: The sdk msg handler creates a new EventManager, so events must be correctly propagated back to the current context
+		ctx.EventManager().EmitEvents(res.Events)
+		results[i] = res.Data
+	}
+	return results, nil
+}
+
+func (k Keeper) useGrant(ctx sdk.Context, granter, grantee sdk.AccAddress, msg sdk.Msg) error {
+	msgType := types.MsgTypeKey(msg)
+	grant, found := k.GetGrant(ctx, granter, grantee, msgType)
+	if !found {
+		return sdkerrors.Wrapf(types.ErrNoAuthorization, "granter %s, grantee %s, msg type %s", granter, grantee, msgType)
+	}
+	if grant.IsExpired(ctx.BlockTime()) {
+		k.deleteGrant(ctx, granter, grantee, msgType)
+		return sdkerrors.Wrapf(types.ErrAuthorizationExpired, "granter %s, grantee %s, msg type %s", granter, grantee, msgType)
+	}
+
+	remove, err := grant.Authorization.Accept(ctx, msg)
+	if err != nil {
+		return err
+	}
+	if remove {
+		k.deleteGrant(ctx, granter, grantee, msgType)
+	} else {
+		k.setGrant(ctx, grant)
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeExec,
+			sdk.NewAttribute(types.AttributeKeyGranter, granter.String()),
+			sdk.NewAttribute(types.AttributeKeyGrantee, grantee.String()),
+			sdk.NewAttribute(types.AttributeKeyMsgType, msgType),
+		),
+	)
+	return nil
+}
+
+// GetGrants returns all the grants from the granter to the grantee
+func (k Keeper) GetGrants(ctx sdk.Context, granter, grantee sdk.AccAddress) (grants []types.Grant) {
+	k.iterateGrants(ctx, types.GrantPrefix(granter, grantee), func(grant types.Grant) bool {
+		grants = append(grants, grant)
+		return false
+	})
+	return grants
+}
+
+// GetGranterGrants returns all the grants from the granter
+func (k Keeper) GetGranterGrants(ctx sdk.Context, granter sdk.AccAddress) (grants []types.Grant) {
+	k.iterateGrants(ctx, types.GranterGrantPrefix(granter), func(grant types.Grant) bool {
+		grants = append(grants, grant)
+		return false
+	})
+	return grants
+}
+
+// IterateGrants iterates over all the grants.
+// If the cb returns true, the iterator will close and stop.
+func (k Keeper) IterateGrants(ctx sdk.Context, cb func(grant types.Grant) bool) {
+	k.iterateGrants(ctx, types.GrantKeyPrefix, cb)
+}
+
+func (k Keeper) iterateGrants(ctx sdk.Context, prefix []byte, cb func(grant types.Grant) bool) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), prefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var grant types.Grant
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &grant)
+		if cb(grant) {
+			break
+		}
+	}
+}
+
+func (k Keeper) setGrant(ctx sdk.Context, grant types.Grant) {
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(grant)
+	ctx.KVStore(k.storeKey).Set(types.GrantKey(grant.Granter, grant.Grantee, grant.Authorization.MsgType()), bz)
+}
+
+func (k Keeper) deleteGrant(ctx sdk.Context, granter, grantee sdk.AccAddress, msgType string) {
+	ctx.KVStore(k.storeKey).Delete(types.GrantKey(granter, grantee, msgType))
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeRevoke,
+			sdk.NewAttribute(types.AttributeKeyGranter, granter.String()),
+			sdk.NewAttribute(types.AttributeKeyGrantee, grantee.String()),
+			sdk.NewAttribute(types.AttributeKeyMsgType, msgType),
+		),
+	)
+}
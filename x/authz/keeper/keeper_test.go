@@ -0,0 +1,161 @@
+package keeper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/baseapp"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	"github.com/okex/exchain/libs/cosmos-sdk/store"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/libs/tendermint/crypto/secp256k1"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	dbm "github.com/okex/exchain/libs/tm-db"
+	"github.com/okex/exchain/x/authz/keeper"
+	"github.com/okex/exchain/x/authz/types"
+	tokentypes "github.com/okex/exchain/x/token/types"
+)
+
+type KeeperTestSuite struct {
+	suite.Suite
+
+	ctx     sdk.Context
+	keeper  keeper.Keeper
+	querier sdk.Querier
+	cdc     *codec.Codec
+	addrs   []sdk.AccAddress
+	handled []sdk.Msg
+}
+
+func (suite *KeeperTestSuite) SetupTest() {
+	keyAuthz := sdk.NewKVStoreKey(types.StoreKey)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAuthz, sdk.StoreTypeIAVL, db)
+	suite.Require().NoError(ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	types.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+
+	suite.handled = nil
+	router := baseapp.NewRouter()
+	router.AddRoute(tokentypes.RouterKey, func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		suite.handled = append(suite.handled, msg)
+		return &sdk.Result{Data: []byte(msg.Type())}, nil
+	})
+
+	suite.keeper = keeper.NewKeeper(cdc, keyAuthz, router)
+	suite.querier = keeper.NewQuerier(suite.keeper)
+	suite.cdc = cdc
+	suite.ctx = sdk.NewContext(ms, abci.Header{Time: time.Now().UTC()}, false, log.NewNopLogger())
+
+	suite.addrs = make([]sdk.AccAddress, 3)
+	for i := range suite.addrs {
+		suite.addrs[i] = sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	}
+}
+
+func TestKeeperTestSuite(t *testing.T) {
+	suite.Run(t, new(KeeperTestSuite))
+}
+
+func (suite *KeeperTestSuite) TestSaveAndDeleteGrant() {
+	granter, grantee := suite.addrs[0], suite.addrs[1]
+	auth := types.NewGenericAuthorization("token/send")
+
+	expired := suite.ctx.BlockTime().Add(-time.Hour)
+	suite.Require().Error(suite.keeper.SaveGrant(suite.ctx, granter, grantee, auth, expired))
+
+	suite.Require().NoError(suite.keeper.SaveGrant(suite.ctx, granter, grantee, auth, time.Time{}))
+	grant, found := suite.keeper.GetGrant(suite.ctx, granter, grantee, "token/send")
+	suite.Require().True(found)
+	suite.Require().Equal(auth, grant.Authorization)
+	suite.Require().True(grant.Expiration.IsZero())
+	suite.Require().Len(suite.keeper.GetGrants(suite.ctx, granter, grantee), 1)
+	suite.Require().Len(suite.keeper.GetGranterGrants(suite.ctx, granter), 1)
+
+	suite.Require().NoError(suite.keeper.DeleteGrant(suite.ctx, granter, grantee, "token/send"))
+	suite.Require().Error(suite.keeper.DeleteGrant(suite.ctx, granter, grantee, "token/send"))
+	_, found = suite.keeper.GetGrant(suite.ctx, granter, grantee, "token/send")
+	suite.Require().False(found)
+}
+
+func (suite *KeeperTestSuite) TestDispatchActions() {
+	granter, grantee, to := suite.addrs[0], suite.addrs[1], suite.addrs[2]
+	send := func(amount int64) sdk.Msg {
+		return tokentypes.NewMsgTokenSend(granter, to, sdk.NewCoins(sdk.NewInt64Coin("okt", amount)))
+	}
+
+	// no authorization
+	_, err := suite.keeper.DispatchActions(suite.ctx, grantee, []sdk.Msg{send(1)})
+	suite.Require().Error(err)
+	suite.Require().Empty(suite.handled)
+
+	// the msgs of the grantee itself need no authorization
+	own := tokentypes.NewMsgTokenSend(grantee, to, sdk.NewCoins(sdk.NewInt64Coin("okt", 1)))
+	results, err := suite.keeper.DispatchActions(suite.ctx, grantee, []sdk.Msg{own})
+	suite.Require().NoError(err)
+	suite.Require().Equal([][]byte{[]byte("send")}, results)
+
+	auth := types.NewSendAuthorization(sdk.NewCoins(sdk.NewInt64Coin("okt", 10)))
+	suite.Require().NoError(suite.keeper.SaveGrant(suite.ctx, granter, grantee, auth, time.Time{}))
+
+	_, err = suite.keeper.DispatchActions(suite.ctx, grantee, []sdk.Msg{send(4)})
+	suite.Require().NoError(err)
+	grant, found := suite.keeper.GetGrant(suite.ctx, granter, grantee, "token/send")
+	suite.Require().True(found)
+	suite.Require().Equal(sdk.NewCoins(sdk.NewInt64Coin("okt", 6)), grant.Authorization.(*types.SendAuthorization).SpendLimit)
+
+	// exceeds the spend limit
+	_, err = suite.keeper.DispatchActions(suite.ctx, grantee, []sdk.Msg{send(7)})
+	suite.Require().Error(err)
+
+	// the grant is removed once the spend limit is used up
+	_, err = suite.keeper.DispatchActions(suite.ctx, grantee, []sdk.Msg{send(6)})
+	suite.Require().NoError(err)
+	_, found = suite.keeper.GetGrant(suite.ctx, granter, grantee, "token/send")
+	suite.Require().False(found)
+	suite.Require().Len(suite.handled, 3)
+}
+
+func (suite *KeeperTestSuite) TestDispatchActionsExpired() {
+	granter, grantee := suite.addrs[0], suite.addrs[1]
+	auth := types.NewGenericAuthorization("token/send")
+	expiration := suite.ctx.BlockTime().Add(time.Hour)
+	suite.Require().NoError(suite.keeper.SaveGrant(suite.ctx, granter, grantee, auth, expiration))
+
+	msg := tokentypes.NewMsgTokenSend(granter, suite.addrs[2], sdk.NewCoins(sdk.NewInt64Coin("okt", 1)))
+	_, err := suite.keeper.DispatchActions(suite.ctx, grantee, []sdk.Msg{msg})
+	suite.Require().NoError(err)
+
+	ctx := suite.ctx.WithBlockTime(expiration)
+	_, err = suite.keeper.DispatchActions(ctx, grantee, []sdk.Msg{msg})
+	suite.Require().True(types.ErrAuthorizationExpired.Is(err))
+	_, found := suite.keeper.GetGrant(ctx, granter, grantee, "token/send")
+	suite.Require().False(found)
+}
+
+func (suite *KeeperTestSuite) TestQuerier() {
+	granter, grantee := suite.addrs[0], suite.addrs[1]
+	suite.Require().NoError(suite.keeper.SaveGrant(suite.ctx, granter, grantee, types.NewGenericAuthorization("token/send"), time.Time{}))
+	suite.Require().NoError(suite.keeper.SaveGrant(suite.ctx, granter, grantee, types.NewGenericAuthorization("farm/claim"), time.Time{}))
+
+	req := abci.RequestQuery{Data: suite.cdc.MustMarshalJSON(types.NewQueryGrantsParams(granter, grantee, "farm/claim"))}
+	bz, err := suite.querier(suite.ctx, []string{types.QueryGrants}, req)
+	suite.Require().NoError(err)
+	var grants []types.Grant
+	suite.Require().NoError(suite.cdc.UnmarshalJSON(bz, &grants))
+	suite.Require().Len(grants, 1)
+	suite.Require().Equal("farm/claim", grants[0].Authorization.MsgType())
+
+	req = abci.RequestQuery{Data: suite.cdc.MustMarshalJSON(types.NewQueryGranterGrantsParams(granter))}
+	bz, err = suite.querier(suite.ctx, []string{types.QueryGranterGrants}, req)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.cdc.UnmarshalJSON(bz, &grants))
+	suite.Require().Len(grants, 2)
+}
@@ -0,0 +1,63 @@
+package keeper
+
+import (
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/x/authz/types"
+)
+
+// NewQuerier creates a querier for the authz module
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+		switch path[0] {
+		case types.QueryGrants:
+			return queryGrants(ctx, req, k)
+		case types.QueryGranterGrants:
+			return queryGranterGrants(ctx, req, k)
+		default:
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unknown %s query endpoint: %s", types.ModuleName, path[0])
+		}
+	}
+}
+
+func queryGrants(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryGrantsParams
+	if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	grants := []types.Grant{}
+	if params.MsgType != "" {
+		grant, found := k.GetGrant(ctx, params.Granter, params.Grantee, params.MsgType)
+		if !found {
+			return nil, sdkerrors.Wrapf(types.ErrNoAuthorization, "granter %s, grantee %s, msg type %s",
+				params.Granter, params.Grantee, params.MsgType)
+		}
+		grants = append(grants, grant)
+	} else {
+		grants = append(grants, k.GetGrants(ctx, params.Granter, params.Grantee)...)
+	}
+
+	return marshalGrants(k.cdc, grants)
+}
+
+func queryGranterGrants(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryGranterGrantsParams
+	if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	grants := []types.Grant{}
+	grants = append(grants, k.GetGranterGrants(ctx, params.Granter)...)
+	return marshalGrants(k.cdc, grants)
+}
+
+func marshalGrants(cdc *codec.Codec, grants []types.Grant) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(cdc, grants)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
@@ -0,0 +1,25 @@
+package types
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// Authorization represents the permission granted to the grantee to execute the msgs
+// of a type on behalf of the granter.
+type Authorization interface {
+	// MsgType returns the type key of the msgs authorized, see MsgTypeKey
+	MsgType() string
+
+	// Accept checks whether the msg can be executed on behalf of the granter, and updates
+	// the authorization to what's left of it after the execution. The authorization is
+	// removed if remove is true.
+	Accept(ctx sdk.Context, msg sdk.Msg) (remove bool, err error)
+
+	// ValidateBasic does a simple validation check that doesn't require access to any other information.
+	ValidateBasic() error
+}
+
+// MsgTypeKey returns the type key of the msg, which is the route and the type of the msg joined with a slash
+func MsgTypeKey(msg sdk.Msg) string {
+	return msg.Route() + "/" + msg.Type()
+}
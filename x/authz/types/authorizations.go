@@ -0,0 +1,131 @@
+package types
+
+import (
+	"strings"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	stakingtypes "github.com/okex/exchain/x/staking/types"
+	tokentypes "github.com/okex/exchain/x/token/types"
+)
+
+var (
+	_ Authorization = (*GenericAuthorization)(nil)
+	_ Authorization = (*SendAuthorization)(nil)
+	_ Authorization = (*StakingAuthorization)(nil)
+)
+
+// GenericAuthorization allows the grantee to execute any msg of the type on behalf of the granter
+type GenericAuthorization struct {
+	Msg string `json:"msg" yaml:"msg"`
+}
+
+// NewGenericAuthorization creates a new GenericAuthorization instance
+func NewGenericAuthorization(msgType string) *GenericAuthorization {
+	return &GenericAuthorization{Msg: msgType}
+}
+
+// MsgType implements Authorization
+func (a *GenericAuthorization) MsgType() string { return a.Msg }
+
+// Accept implements Authorization
+func (a *GenericAuthorization) Accept(_ sdk.Context, _ sdk.Msg) (bool, error) {
+	return false, nil
+}
+
+// ValidateBasic implements Authorization
+func (a *GenericAuthorization) ValidateBasic() error {
+	if !strings.Contains(a.Msg, "/") {
+		return sdkerrors.Wrapf(ErrInvalidAuthorization, "invalid msg type %q, expected route/type", a.Msg)
+	}
+	return nil
+}
+
+// SendAuthorization allows the grantee to send the coins of the granter up to a total spend limit
+type SendAuthorization struct {
+	SpendLimit sdk.SysCoins `json:"spend_limit" yaml:"spend_limit"`
+}
+
+// NewSendAuthorization creates a new SendAuthorization instance
+func NewSendAuthorization(spendLimit sdk.SysCoins) *SendAuthorization {
+	return &SendAuthorization{SpendLimit: spendLimit}
+}
+
+// MsgType implements Authorization
+func (a *SendAuthorization) MsgType() string { return MsgTypeKey(tokentypes.MsgSend{}) }
+
+// Accept implements Authorization
+func (a *SendAuthorization) Accept(_ sdk.Context, msg sdk.Msg) (bool, error) {
+	send, ok := msg.(tokentypes.MsgSend)
+	if !ok {
+		return false, sdkerrors.Wrapf(sdkerrors.ErrInvalidType, "expected %T, got %T", tokentypes.MsgSend{}, msg)
+	}
+
+	left, hasNeg := a.SpendLimit.SafeSub(send.Amount)
+	if hasNeg {
+		return false, sdkerrors.Wrapf(ErrUnauthorized, "spend limit exceeded: %s < %s", a.SpendLimit, send.Amount)
+	}
+	a.SpendLimit = left
+	return left.IsZero(), nil
+}
+
+// ValidateBasic implements Authorization
+func (a *SendAuthorization) ValidateBasic() error {
+	if a.SpendLimit.Empty() || !a.SpendLimit.IsValid() {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "spend limit: %s", a.SpendLimit)
+	}
+	if !a.SpendLimit.IsAllPositive() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "spend limit must be positive")
+	}
+	return nil
+}
+
+// StakingAuthorization allows the grantee to add the shares of the granter to the allowed validators
+type StakingAuthorization struct {
+	AllowedValidators []sdk.ValAddress `json:"allowed_validators" yaml:"allowed_validators"`
+}
+
+// NewStakingAuthorization creates a new StakingAuthorization instance
+func NewStakingAuthorization(allowedValidators []sdk.ValAddress) *StakingAuthorization {
+	return &StakingAuthorization{AllowedValidators: allowedValidators}
+}
+
+// MsgType implements Authorization
+func (a *StakingAuthorization) MsgType() string { return MsgTypeKey(stakingtypes.MsgAddShares{}) }
+
+// Accept implements Authorization
+func (a *StakingAuthorization) Accept(_ sdk.Context, msg sdk.Msg) (bool, error) {
+	addShares, ok := msg.(stakingtypes.MsgAddShares)
+	if !ok {
+		return false, sdkerrors.Wrapf(sdkerrors.ErrInvalidType, "expected %T, got %T", stakingtypes.MsgAddShares{}, msg)
+	}
+
+	for _, valAddr := range addShares.ValAddrs {
+		if !containsValAddress(a.AllowedValidators, valAddr) {
+			return false, sdkerrors.Wrapf(ErrUnauthorized, "validator %s is not allowed", valAddr)
+		}
+	}
+	return false, nil
+}
+
+// ValidateBasic implements Authorization
+func (a *StakingAuthorization) ValidateBasic() error {
+	if len(a.AllowedValidators) == 0 {
+		return sdkerrors.Wrap(ErrInvalidAuthorization, "allowed validators cannot be empty")
+	}
+	for _, valAddr := range a.AllowedValidators {
+		if valAddr.Empty() {
+			return sdkerrors.Wrap(ErrInvalidAuthorization, "allowed validator cannot be empty")
+		}
+	}
+	return nil
+}
+
+func containsValAddress(list []sdk.ValAddress, addr sdk.ValAddress) bool {
+	for _, item := range list {
+		if item.Equals(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/tendermint/crypto/secp256k1"
+	stakingtypes "github.com/okex/exchain/x/staking/types"
+	tokentypes "github.com/okex/exchain/x/token/types"
+)
+
+func TestSendAuthorization(t *testing.T) {
+	from := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	to := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	auth := NewSendAuthorization(sdk.NewCoins(sdk.NewInt64Coin("okt", 10)))
+	require.NoError(t, auth.ValidateBasic())
+	require.Equal(t, "token/send", auth.MsgType())
+
+	remove, err := auth.Accept(sdk.Context{}, tokentypes.NewMsgTokenSend(from, to, sdk.NewCoins(sdk.NewInt64Coin("okt", 4))))
+	require.NoError(t, err)
+	require.False(t, remove)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("okt", 6)), auth.SpendLimit)
+
+	_, err = auth.Accept(sdk.Context{}, tokentypes.NewMsgTokenSend(from, to, sdk.NewCoins(sdk.NewInt64Coin("okt", 7))))
+	require.Error(t, err)
+
+	remove, err = auth.Accept(sdk.Context{}, tokentypes.NewMsgTokenSend(from, to, sdk.NewCoins(sdk.NewInt64Coin("okt", 6))))
+	require.NoError(t, err)
+	require.True(t, remove)
+
+	require.Error(t, NewSendAuthorization(nil).ValidateBasic())
+}
+
+func TestStakingAuthorization(t *testing.T) {
+	allowed := sdk.ValAddress(secp256k1.GenPrivKey().PubKey().Address())
+	other := sdk.ValAddress(secp256k1.GenPrivKey().PubKey().Address())
+	auth := NewStakingAuthorization([]sdk.ValAddress{allowed})
+	require.NoError(t, auth.ValidateBasic())
+
+	_, err := auth.Accept(sdk.Context{}, stakingtypes.MsgAddShares{ValAddrs: []sdk.ValAddress{allowed}})
+	require.NoError(t, err)
+	_, err = auth.Accept(sdk.Context{}, stakingtypes.MsgAddShares{ValAddrs: []sdk.ValAddress{allowed, other}})
+	require.Error(t, err)
+
+	require.Error(t, NewStakingAuthorization(nil).ValidateBasic())
+}
+
+func TestGenericAuthorization(t *testing.T) {
+	require.NoError(t, NewGenericAuthorization("farm/claim").ValidateBasic())
+	require.Error(t, NewGenericAuthorization("claim").ValidateBasic())
+}
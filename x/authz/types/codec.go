@@ -0,0 +1,28 @@
+package types
+
+import (
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// ModuleCdc defines the authz module's codec
+var ModuleCdc = codec.New()
+
+// RegisterCodec registers all the necessary types and interfaces for the authz module
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterInterface((*Authorization)(nil), nil)
+	cdc.RegisterConcrete(&GenericAuthorization{}, "okexchain/authz/GenericAuthorization", nil)
+	cdc.RegisterConcrete(&SendAuthorization{}, "okexchain/authz/SendAuthorization", nil)
+	cdc.RegisterConcrete(&StakingAuthorization{}, "okexchain/authz/StakingAuthorization", nil)
+
+	cdc.RegisterConcrete(MsgGrant{}, "okexchain/authz/MsgGrant", nil)
+	cdc.RegisterConcrete(MsgRevoke{}, "okexchain/authz/MsgRevoke", nil)
+	cdc.RegisterConcrete(MsgExec{}, "okexchain/authz/MsgExec", nil)
+}
+
+func init() {
+	ModuleCdc.RegisterInterface((*sdk.Msg)(nil), nil)
+	RegisterCodec(ModuleCdc)
+	codec.RegisterCrypto(ModuleCdc)
+	ModuleCdc.Seal()
+}
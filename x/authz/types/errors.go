@@ -0,0 +1,25 @@
+package types
+
+import (
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+)
+
+// x/authz module sentinel errors
+var (
+	// ErrNoAuthorization is returned if the grant doesn't exist
+	ErrNoAuthorization = sdkerrors.Register(ModuleName, 1, "authorization not found")
+	// ErrAuthorizationExpired is returned if the grant has expired
+	ErrAuthorizationExpired = sdkerrors.Register(ModuleName, 2, "authorization expired")
+	// ErrInvalidExpiration is returned if the expiration of the grant is not in the future
+	ErrInvalidExpiration = sdkerrors.Register(ModuleName, 3, "expiration time of authorization should be in the future")
+	// ErrInvalidAuthorization is returned if the authorization is malformed
+	ErrInvalidAuthorization = sdkerrors.Register(ModuleName, 4, "invalid authorization")
+	// ErrUnauthorized is returned if the msg is not allowed by the authorization
+	ErrUnauthorized = sdkerrors.Register(ModuleName, 5, "msg not allowed by authorization")
+	// ErrInvalidMsg is returned if the msg can't be executed on behalf of another account
+	ErrInvalidMsg = sdkerrors.Register(ModuleName, 6, "invalid msg to execute")
+	// ErrNoHandler is returned if there is no handler routing the msg
+	ErrNoHandler = sdkerrors.Register(ModuleName, 7, "no handler for the msg")
+	// ErrAuthzDisabled is returned if the msg is handled before the Venus2 height
+	ErrAuthzDisabled = sdkerrors.Register(ModuleName, 8, "authz is not enabled before the Venus2 height")
+)
@@ -0,0 +1,14 @@
+package types
+
+// authz module event types
+const (
+	EventTypeGrant  = "grant"
+	EventTypeRevoke = "revoke"
+	EventTypeExec   = "exec_authorization"
+
+	AttributeKeyGranter = "granter"
+	AttributeKeyGrantee = "grantee"
+	AttributeKeyMsgType = "msg_type"
+
+	AttributeValueCategory = ModuleName
+)
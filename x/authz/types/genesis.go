@@ -0,0 +1,26 @@
+package types
+
+// GenesisState defines the authz module's genesis state
+type GenesisState struct {
+	Grants []Grant `json:"grants" yaml:"grants"`
+}
+
+// NewGenesisState creates a new GenesisState instance
+func NewGenesisState(grants []Grant) GenesisState {
+	return GenesisState{Grants: grants}
+}
+
+// DefaultGenesisState returns the default genesis state of the authz module
+func DefaultGenesisState() GenesisState {
+	return NewGenesisState([]Grant{})
+}
+
+// Validate performs a basic validation of the genesis state
+func (gs GenesisState) Validate() error {
+	for _, grant := range gs.Grants {
+		if err := grant.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
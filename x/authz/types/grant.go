@@ -0,0 +1,49 @@
+package types
+
+import (
+	"time"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+)
+
+// Grant is the authorization granted by the granter to the grantee until an optional expiration.
+// A zero Expiration means the grant never expires.
+type Grant struct {
+	Granter       sdk.AccAddress `json:"granter" yaml:"granter"`
+	Grantee       sdk.AccAddress `json:"grantee" yaml:"grantee"`
+	Authorization Authorization  `json:"authorization" yaml:"authorization"`
+	Expiration    time.Time      `json:"expiration" yaml:"expiration"`
+}
+
+// NewGrant creates a new Grant instance
+func NewGrant(granter, grantee sdk.AccAddress, authorization Authorization, expiration time.Time) Grant {
+	return Grant{
+		Granter:       granter,
+		Grantee:       grantee,
+		Authorization: authorization,
+		Expiration:    expiration,
+	}
+}
+
+// IsExpired returns true if the grant has expired at the block time
+func (g Grant) IsExpired(blockTime time.Time) bool {
+	return !g.Expiration.IsZero() && !blockTime.Before(g.Expiration)
+}
+
+// ValidateBasic performs a basic validation of the grant
+func (g Grant) ValidateBasic() error {
+	if g.Granter.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing granter address")
+	}
+	if g.Grantee.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing grantee address")
+	}
+	if g.Granter.Equals(g.Grantee) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "granter and grantee cannot be same")
+	}
+	if g.Authorization == nil {
+		return sdkerrors.Wrap(ErrInvalidAuthorization, "missing authorization")
+	}
+	return g.Authorization.ValidateBasic()
+}
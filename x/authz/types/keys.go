@@ -0,0 +1,41 @@
+package types
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+const (
+	// ModuleName is the name of the authz module
+	ModuleName = "authz"
+	// StoreKey is the string store representation
+	StoreKey = ModuleName
+	// RouterKey is the msg router key for the authz module
+	RouterKey = ModuleName
+	// QuerierRoute is the querier route for the authz module
+	QuerierRoute = ModuleName
+)
+
+// GrantKeyPrefix is the prefix of the keys of the grants in the store
+var GrantKeyPrefix = []byte{0x01}
+
+// GrantKey returns the key of the grant that authorizes the grantee to execute the msgs of the type
+// on behalf of the granter: 0x01 | len(granter) | granter | len(grantee) | grantee | msgType
+func GrantKey(granter, grantee sdk.AccAddress, msgType string) []byte {
+	return append(GrantPrefix(granter, grantee), msgType...)
+}
+
+// GrantPrefix returns the prefix of the keys of all the grants from the granter to the grantee
+func GrantPrefix(granter, grantee sdk.AccAddress) []byte {
+	return append(GranterGrantPrefix(granter), lengthPrefixed(grantee)...)
+}
+
+// GranterGrantPrefix returns the prefix of the keys of all the grants from the granter
+func GranterGrantPrefix(granter sdk.AccAddress) []byte {
+	key := make([]byte, 0, len(GrantKeyPrefix)+1+len(granter))
+	key = append(key, GrantKeyPrefix...)
+	return append(key, lengthPrefixed(granter)...)
+}
+
+func lengthPrefixed(addr sdk.AccAddress) []byte {
+	return append([]byte{byte(len(addr))}, addr...)
+}
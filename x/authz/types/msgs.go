@@ -0,0 +1,177 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+)
+
+// Message types for the authz module
+const (
+	TypeMsgGrant  = "grant"
+	TypeMsgRevoke = "revoke"
+	TypeMsgExec   = "exec"
+)
+
+var (
+	_ sdk.Msg = MsgGrant{}
+	_ sdk.Msg = MsgRevoke{}
+	_ sdk.Msg = MsgExec{}
+)
+
+// MsgGrant grants the grantee the authorization to execute the msgs on behalf of the granter
+type MsgGrant struct {
+	Granter       sdk.AccAddress `json:"granter" yaml:"granter"`
+	Grantee       sdk.AccAddress `json:"grantee" yaml:"grantee"`
+	Authorization Authorization  `json:"authorization" yaml:"authorization"`
+	Expiration    time.Time      `json:"expiration" yaml:"expiration"`
+}
+
+// NewMsgGrant creates a new MsgGrant instance
+func NewMsgGrant(granter, grantee sdk.AccAddress, authorization Authorization, expiration time.Time) MsgGrant {
+	return MsgGrant{
+		Granter:       granter,
+		Grantee:       grantee,
+		Authorization: authorization,
+		Expiration:    expiration,
+	}
+}
+
+// Route implements sdk.Msg
+func (msg MsgGrant) Route() string { return RouterKey }
+
+// Type implements sdk.Msg
+func (msg MsgGrant) Type() string { return TypeMsgGrant }
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgGrant) ValidateBasic() error {
+	return NewGrant(msg.Granter, msg.Grantee, msg.Authorization, msg.Expiration).ValidateBasic()
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgGrant) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners implements sdk.Msg
+func (msg MsgGrant) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
+
+// MsgRevoke revokes the authorization granted to the grantee to execute the msgs of the type
+type MsgRevoke struct {
+	Granter sdk.AccAddress `json:"granter" yaml:"granter"`
+	Grantee sdk.AccAddress `json:"grantee" yaml:"grantee"`
+	MsgType string         `json:"msg_type" yaml:"msg_type"`
+}
+
+// NewMsgRevoke creates a new MsgRevoke instance
+func NewMsgRevoke(granter, grantee sdk.AccAddress, msgType string) MsgRevoke {
+	return MsgRevoke{
+		Granter: granter,
+		Grantee: grantee,
+		MsgType: msgType,
+	}
+}
+
+// Route implements sdk.Msg
+func (msg MsgRevoke) Route() string { return RouterKey }
+
+// Type implements sdk.Msg
+func (msg MsgRevoke) Type() string { return TypeMsgRevoke }
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgRevoke) ValidateBasic() error {
+	if msg.Granter.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing granter address")
+	}
+	if msg.Grantee.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing grantee address")
+	}
+	if msg.Granter.Equals(msg.Grantee) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "granter and grantee cannot be same")
+	}
+	if len(msg.MsgType) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "missing msg type")
+	}
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgRevoke) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners implements sdk.Msg
+func (msg MsgRevoke) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
+
+// MsgExec executes the msgs on behalf of their signers, who have granted the grantee the authorizations
+type MsgExec struct {
+	Grantee sdk.AccAddress `json:"grantee" yaml:"grantee"`
+	Msgs    []sdk.Msg      `json:"msgs" yaml:"msgs"`
+}
+
+// NewMsgExec creates a new MsgExec instance
+func NewMsgExec(grantee sdk.AccAddress, msgs []sdk.Msg) MsgExec {
+	return MsgExec{
+		Grantee: grantee,
+		Msgs:    msgs,
+	}
+}
+
+// Route implements sdk.Msg
+func (msg MsgExec) Route() string { return RouterKey }
+
+// Type implements sdk.Msg
+func (msg MsgExec) Type() string { return TypeMsgExec }
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgExec) ValidateBasic() error {
+	if msg.Grantee.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing grantee address")
+	}
+	if len(msg.Msgs) == 0 {
+		return sdkerrors.Wrap(ErrInvalidMsg, "msgs cannot be empty")
+	}
+
+	for _, m := range msg.Msgs {
+		if _, ok := m.(*evmtypes.MsgEthereumTx); ok {
+			return sdkerrors.Wrap(ErrInvalidMsg, "evm txs cannot be executed on behalf of another account")
+		}
+		if len(m.GetSigners()) != 1 {
+			return sdkerrors.Wrapf(ErrInvalidMsg, "msg %s must have exactly one signer", MsgTypeKey(m))
+		}
+		if err := m.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg. The msgs to execute are signed with their own sign bytes,
+// so that the codec of the authz module doesn't need to know all the msgs.
+func (msg MsgExec) GetSignBytes() []byte {
+	msgs := make([]json.RawMessage, len(msg.Msgs))
+	for i, m := range msg.Msgs {
+		msgs[i] = m.GetSignBytes()
+	}
+
+	bz, err := json.Marshal(struct {
+		Grantee sdk.AccAddress    `json:"grantee"`
+		Msgs    []json.RawMessage `json:"msgs"`
+	}{msg.Grantee, msgs})
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners implements sdk.Msg
+func (msg MsgExec) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Grantee}
+}
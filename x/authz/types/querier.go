@@ -0,0 +1,34 @@
+package types
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// Querier routes for the authz module
+const (
+	QueryGrants        = "grants"
+	QueryGranterGrants = "granter_grants"
+)
+
+// QueryGrantsParams defines the parameters necessary for querying the grants from the granter to the grantee.
+// All the grants are returned if MsgType is empty.
+type QueryGrantsParams struct {
+	Granter sdk.AccAddress `json:"granter" yaml:"granter"`
+	Grantee sdk.AccAddress `json:"grantee" yaml:"grantee"`
+	MsgType string         `json:"msg_type" yaml:"msg_type"`
+}
+
+// NewQueryGrantsParams creates a new QueryGrantsParams instance
+func NewQueryGrantsParams(granter, grantee sdk.AccAddress, msgType string) QueryGrantsParams {
+	return QueryGrantsParams{Granter: granter, Grantee: grantee, MsgType: msgType}
+}
+
+// QueryGranterGrantsParams defines the parameters necessary for querying all the grants from the granter
+type QueryGranterGrantsParams struct {
+	Granter sdk.AccAddress `json:"granter" yaml:"granter"`
+}
+
+// NewQueryGranterGrantsParams creates a new QueryGranterGrantsParams instance
+func NewQueryGranterGrantsParams(granter sdk.AccAddress) QueryGranterGrantsParams {
+	return QueryGranterGrantsParams{Granter: granter}
+}
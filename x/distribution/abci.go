@@ -17,6 +17,12 @@ func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k keeper.Keeper)
 		previousTotalPower += voteInfo.Validator.Power
 	}
 
+	// the delegations made before are initialized to accrue rewards once the delegator rewards are enabled
+	if tmtypes.HigherThanVenus2(ctx.BlockHeight()) && k.GetDelegatorRewardsEnabled(ctx) &&
+		!k.HasDelegatorRewardsInitialized(ctx) {
+		k.InitializeDelegatorRewards(ctx)
+	}
+
 	// TODO this is Tendermint-dependent
 	// ref https://github.com/cosmos/cosmos-sdk/issues/3095
 	if ctx.BlockHeight() > tmtypes.GetStartBlockHeight()+1 {
@@ -13,50 +13,66 @@ import (
 )
 
 const (
-	ModuleName               = types.ModuleName
-	StoreKey                 = types.StoreKey
-	RouterKey                = types.RouterKey
-	QuerierRoute             = types.QuerierRoute
-	QueryParams              = types.QueryParams
-	QueryValidatorCommission = types.QueryValidatorCommission
-	QueryWithdrawAddr        = types.QueryWithdrawAddr
-	ParamWithdrawAddrEnabled = types.ParamWithdrawAddrEnabled
-	DefaultParamspace        = types.DefaultParamspace
+	ModuleName                       = types.ModuleName
+	StoreKey                         = types.StoreKey
+	RouterKey                        = types.RouterKey
+	QuerierRoute                     = types.QuerierRoute
+	QueryParams                      = types.QueryParams
+	QueryValidatorCommission         = types.QueryValidatorCommission
+	QueryWithdrawAddr                = types.QueryWithdrawAddr
+	ParamWithdrawAddrEnabled         = types.ParamWithdrawAddrEnabled
+	QueryDelegationRewards           = types.QueryDelegationRewards
+	QueryDelegatorTotalRewards       = types.QueryDelegatorTotalRewards
+	QueryValidatorOutstandingRewards = types.QueryValidatorOutstandingRewards
+	ParamDelegatorRewardsEnabled     = types.ParamDelegatorRewardsEnabled
+	DefaultParamspace                = types.DefaultParamspace
 )
 
 var (
 	// functions aliases
-	RegisterInvariants                       = keeper.RegisterInvariants
-	NewKeeper                                = keeper.NewKeeper
-	GetDelegatorWithdrawInfoAddress          = types.GetDelegatorWithdrawInfoAddress
-	GetValidatorAccumulatedCommissionAddress = types.GetValidatorAccumulatedCommissionAddress
-	GetDelegatorWithdrawAddrKey              = types.GetDelegatorWithdrawAddrKey
-	GetValidatorAccumulatedCommissionKey     = types.GetValidatorAccumulatedCommissionKey
-	NewQuerier                               = keeper.NewQuerier
-	RegisterCodec                            = types.RegisterCodec
-	ErrNilDelegatorAddr                      = types.ErrNilDelegatorAddr
-	ErrNoValidatorCommission                 = types.ErrNoValidatorCommission
-	ErrSetWithdrawAddrDisabled               = types.ErrSetWithdrawAddrDisabled
-	InitialFeePool                           = types.InitialFeePool
-	NewGenesisState                          = types.NewGenesisState
-	DefaultGenesisState                      = types.DefaultGenesisState
-	ValidateGenesis                          = types.ValidateGenesis
-	NewMsgSetWithdrawAddress                 = types.NewMsgSetWithdrawAddress
-	NewMsgWithdrawValidatorCommission        = types.NewMsgWithdrawValidatorCommission
-	NewQueryValidatorCommissionParams        = types.NewQueryValidatorCommissionParams
-	NewQueryDelegatorWithdrawAddrParams      = types.NewQueryDelegatorWithdrawAddrParams
-	InitialValidatorAccumulatedCommission    = types.InitialValidatorAccumulatedCommission
+	RegisterInvariants                        = keeper.RegisterInvariants
+	NewKeeper                                 = keeper.NewKeeper
+	GetDelegatorWithdrawInfoAddress           = types.GetDelegatorWithdrawInfoAddress
+	GetValidatorAccumulatedCommissionAddress  = types.GetValidatorAccumulatedCommissionAddress
+	GetDelegatorWithdrawAddrKey               = types.GetDelegatorWithdrawAddrKey
+	GetValidatorAccumulatedCommissionKey      = types.GetValidatorAccumulatedCommissionKey
+	NewQuerier                                = keeper.NewQuerier
+	RegisterCodec                             = types.RegisterCodec
+	ErrNilDelegatorAddr                       = types.ErrNilDelegatorAddr
+	ErrNoValidatorCommission                  = types.ErrNoValidatorCommission
+	ErrSetWithdrawAddrDisabled                = types.ErrSetWithdrawAddrDisabled
+	InitialFeePool                            = types.InitialFeePool
+	NewGenesisState                           = types.NewGenesisState
+	DefaultGenesisState                       = types.DefaultGenesisState
+	ValidateGenesis                           = types.ValidateGenesis
+	NewMsgSetWithdrawAddress                  = types.NewMsgSetWithdrawAddress
+	NewMsgWithdrawValidatorCommission         = types.NewMsgWithdrawValidatorCommission
+	NewQueryValidatorCommissionParams         = types.NewQueryValidatorCommissionParams
+	NewQueryDelegatorWithdrawAddrParams       = types.NewQueryDelegatorWithdrawAddrParams
+	InitialValidatorAccumulatedCommission     = types.InitialValidatorAccumulatedCommission
+	NewMsgWithdrawDelegatorReward             = types.NewMsgWithdrawDelegatorReward
+	NewMsgWithdrawDelegatorAllRewards         = types.NewMsgWithdrawDelegatorAllRewards
+	NewQueryDelegationRewardsParams           = types.NewQueryDelegationRewardsParams
+	NewQueryDelegatorParams                   = types.NewQueryDelegatorParams
+	NewQueryValidatorOutstandingRewardsParams = types.NewQueryValidatorOutstandingRewardsParams
+	ErrDelegatorRewardsDisabled               = types.ErrDelegatorRewardsDisabled
 
 	// variable aliases
 	FeePoolKey                           = types.FeePoolKey
 	ProposerKey                          = types.ProposerKey
 	DelegatorWithdrawAddrPrefix          = types.DelegatorWithdrawAddrPrefix
 	ValidatorAccumulatedCommissionPrefix = types.ValidatorAccumulatedCommissionPrefix
+	ValidatorOutstandingRewardsPrefix    = types.ValidatorOutstandingRewardsPrefix
+	DelegatorStartingInfoPrefix          = types.DelegatorStartingInfoPrefix
+	ValidatorHistoricalRewardsPrefix     = types.ValidatorHistoricalRewardsPrefix
+	ValidatorCurrentRewardsPrefix        = types.ValidatorCurrentRewardsPrefix
 	ModuleCdc                            = types.ModuleCdc
 	EventTypeSetWithdrawAddress          = types.EventTypeSetWithdrawAddress
 	EventTypeCommission                  = types.EventTypeCommission
 	EventTypeWithdrawCommission          = types.EventTypeWithdrawCommission
 	EventTypeProposerReward              = types.EventTypeProposerReward
+	EventTypeRewards                     = types.EventTypeRewards
+	EventTypeWithdrawRewards             = types.EventTypeWithdrawRewards
 	AttributeKeyWithdrawAddress          = types.AttributeKeyWithdrawAddress
 	AttributeKeyValidator                = types.AttributeKeyValidator
 	AttributeValueCategory               = types.AttributeValueCategory
@@ -74,4 +90,10 @@ type (
 	QueryValidatorCommissionParams       = types.QueryValidatorCommissionParams
 	QueryDelegatorWithdrawAddrParams     = types.QueryDelegatorWithdrawAddrParams
 	ValidatorAccumulatedCommission       = types.ValidatorAccumulatedCommission
+	MsgWithdrawDelegatorReward           = types.MsgWithdrawDelegatorReward
+	MsgWithdrawDelegatorAllRewards       = types.MsgWithdrawDelegatorAllRewards
+	DelegatorStartingInfo                = types.DelegatorStartingInfo
+	ValidatorHistoricalRewards           = types.ValidatorHistoricalRewards
+	ValidatorCurrentRewards              = types.ValidatorCurrentRewards
+	ValidatorOutstandingRewards          = types.ValidatorOutstandingRewards
 )
@@ -32,6 +32,8 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		GetCmdQueryParams(queryRoute, cdc),
 		GetCmdQueryValidatorCommission(queryRoute, cdc),
 		GetCmdQueryCommunityPool(queryRoute, cdc),
+		GetCmdQueryDelegatorRewards(queryRoute, cdc),
+		GetCmdQueryValidatorOutstandingRewards(queryRoute, cdc),
 	)...)
 
 	return distQueryCmd
@@ -120,3 +122,97 @@ $ %s query distr community-pool
 		},
 	}
 }
+
+// GetCmdQueryDelegatorRewards implements the query delegator rewards command.
+func GetCmdQueryDelegatorRewards(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rewards [delegator-addr] [<validator-addr>]",
+		Args:  cobra.RangeArgs(1, 2),
+		Short: "Query all distribution delegator rewards or rewards from a particular validator",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query all rewards earned by a delegator, optionally restrict to rewards from a single validator.
+
+Example:
+$ %s query distr rewards ex1cftp8q8g4aa65nw9s5trwexe77d9t6cr8ndu02
+$ %s query distr rewards ex1cftp8q8g4aa65nw9s5trwexe77d9t6cr8ndu02 exvaloper1alq9na49n9yycysh889rl90g9nhe58lcqkfpfg
+`,
+				version.ClientName, version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			delegatorAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			// query for rewards from a particular validator
+			if len(args) == 2 {
+				validatorAddr, err := sdk.ValAddressFromBech32(args[1])
+				if err != nil {
+					return err
+				}
+
+				res, _, err := common.QueryDelegationRewards(cliCtx, queryRoute, delegatorAddr, validatorAddr)
+				if err != nil {
+					return err
+				}
+
+				var result sdk.SysCoins
+				if err := cdc.UnmarshalJSON(res, &result); err != nil {
+					return fmt.Errorf("failed to unmarshal response: %w", err)
+				}
+				return cliCtx.PrintOutput(result)
+			}
+
+			res, _, err := common.QueryDelegatorTotalRewards(cliCtx, queryRoute, delegatorAddr)
+			if err != nil {
+				return err
+			}
+
+			var result types.QueryDelegatorTotalRewardsResponse
+			if err := cdc.UnmarshalJSON(res, &result); err != nil {
+				return fmt.Errorf("failed to unmarshal response: %w", err)
+			}
+			return cliCtx.PrintOutput(result)
+		},
+	}
+}
+
+// GetCmdQueryValidatorOutstandingRewards implements the query validator outstanding rewards command.
+func GetCmdQueryValidatorOutstandingRewards(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "outstanding-rewards [validator]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Query distribution outstanding (un-withdrawn) rewards of a validator's delegators",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query distribution outstanding (un-withdrawn) rewards of a validator's delegators.
+
+Example:
+$ %s query distr outstanding-rewards exvaloper1alq9na49n9yycysh889rl90g9nhe58lcqkfpfg
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			validatorAddr, err := sdk.ValAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			res, _, err := common.QueryValidatorOutstandingRewards(cliCtx, queryRoute, validatorAddr)
+			if err != nil {
+				return err
+			}
+
+			var outstandingRewards types.ValidatorOutstandingRewards
+			if err := cdc.UnmarshalJSON(res, &outstandingRewards); err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(outstandingRewards)
+		},
+	}
+}
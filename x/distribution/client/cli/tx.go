@@ -34,6 +34,8 @@ func GetTxCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 	distTxCmd.AddCommand(flags.PostCommands(
 		GetCmdWithdrawRewards(cdc),
 		GetCmdSetWithdrawAddr(cdc),
+		GetCmdWithdrawDelegatorRewards(cdc),
+		GetCmdWithdrawAllRewards(cdc),
 	)...)
 
 	return distTxCmd
@@ -104,6 +106,61 @@ $ %s tx distr withdraw-rewards exvaloper1alq9na49n9yycysh889rl90g9nhe58lcqkfpfg
 	return cmd
 }
 
+// GetCmdWithdrawDelegatorRewards command to withdraw the rewards of delegator from a validator
+func GetCmdWithdrawDelegatorRewards(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "withdraw-delegator-rewards [validator-addr]",
+		Short: "withdraw the rewards of delegator from a validator it added shares to",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`
+Example:
+$ %s tx distr withdraw-delegator-rewards exvaloper1alq9na49n9yycysh889rl90g9nhe58lcqkfpfg --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			valAddr, err := sdk.ValAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgWithdrawDelegatorReward(cliCtx.GetFromAddress(), valAddr)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdWithdrawAllRewards command to withdraw the rewards of delegator from all the validators
+func GetCmdWithdrawAllRewards(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "withdraw-all-rewards",
+		Short: "withdraw the rewards of delegator from all the validators it added shares to",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`
+Example:
+$ %s tx distr withdraw-all-rewards --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgWithdrawDelegatorAllRewards(cliCtx.GetFromAddress())
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
 // GetCmdSubmitProposal implements the command to submit a community-pool-spend proposal
 func GetCmdSubmitProposal(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
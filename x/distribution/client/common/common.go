@@ -50,3 +50,30 @@ func WithdrawValidatorRewardsAndCommission(validatorAddr sdk.ValAddress) ([]sdk.
 
 	return []sdk.Msg{commissionMsg}, nil
 }
+
+// QueryDelegationRewards returns the rewards of a delegator on a validator
+func QueryDelegationRewards(cliCtx context.CLIContext, queryRoute string, delegatorAddr sdk.AccAddress,
+	validatorAddr sdk.ValAddress) ([]byte, int64, error) {
+	return cliCtx.QueryWithData(
+		fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryDelegationRewards),
+		cliCtx.Codec.MustMarshalJSON(types.NewQueryDelegationRewardsParams(delegatorAddr, validatorAddr)),
+	)
+}
+
+// QueryDelegatorTotalRewards returns the rewards of a delegator on all the validators it added shares to
+func QueryDelegatorTotalRewards(cliCtx context.CLIContext, queryRoute string, delegatorAddr sdk.AccAddress) (
+	[]byte, int64, error) {
+	return cliCtx.QueryWithData(
+		fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryDelegatorTotalRewards),
+		cliCtx.Codec.MustMarshalJSON(types.NewQueryDelegatorParams(delegatorAddr)),
+	)
+}
+
+// QueryValidatorOutstandingRewards returns the outstanding rewards of a validator's delegators
+func QueryValidatorOutstandingRewards(cliCtx context.CLIContext, queryRoute string, validatorAddr sdk.ValAddress) (
+	[]byte, int64, error) {
+	return cliCtx.QueryWithData(
+		fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryValidatorOutstandingRewards),
+		cliCtx.Codec.MustMarshalJSON(types.NewQueryValidatorOutstandingRewardsParams(validatorAddr)),
+	)
+}
@@ -26,6 +26,21 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, supplyKeeper types.SupplyKeeper
 	}
 	moduleHoldings = moduleHoldings.Add(data.FeePool.CommunityPool...)
 
+	keeper.SetDelegatorRewardsEnabled(ctx, data.DelegatorRewardsEnabled)
+	for _, rew := range data.OutstandingRewards {
+		keeper.SetValidatorOutstandingRewards(ctx, rew.ValidatorAddress, rew.OutstandingRewards)
+		moduleHoldings = moduleHoldings.Add(rew.OutstandingRewards...)
+	}
+	for _, his := range data.ValidatorHistoricalRewards {
+		keeper.SetValidatorHistoricalRewards(ctx, his.ValidatorAddress, his.Period, his.Rewards)
+	}
+	for _, cur := range data.ValidatorCurrentRewards {
+		keeper.SetValidatorCurrentRewards(ctx, cur.ValidatorAddress, cur.Rewards)
+	}
+	for _, del := range data.DelegatorStartingInfos {
+		keeper.SetDelegatorStartingInfo(ctx, del.ValidatorAddress, del.DelegatorAddress, del.StartingInfo)
+	}
+
 	// check if the module account exists
 	moduleAcc := keeper.GetDistributionAccount(ctx)
 	if moduleAcc == nil {
@@ -64,5 +79,49 @@ func ExportGenesis(ctx sdk.Context, keeper Keeper) types.GenesisState {
 		},
 	)
 
-	return types.NewGenesisState(params, feePool, dwi, pp, acc)
+	outstanding := make([]types.ValidatorOutstandingRewardsRecord, 0)
+	keeper.IterateValidatorOutstandingRewards(ctx,
+		func(addr sdk.ValAddress, rewards types.ValidatorOutstandingRewards) (stop bool) {
+			outstanding = append(outstanding, types.ValidatorOutstandingRewardsRecord{
+				ValidatorAddress:   addr,
+				OutstandingRewards: rewards,
+			})
+			return false
+		},
+	)
+	his := make([]types.ValidatorHistoricalRewardsRecord, 0)
+	keeper.IterateValidatorHistoricalRewards(ctx,
+		func(val sdk.ValAddress, period uint64, rewards types.ValidatorHistoricalRewards) (stop bool) {
+			his = append(his, types.ValidatorHistoricalRewardsRecord{
+				ValidatorAddress: val,
+				Period:           period,
+				Rewards:          rewards,
+			})
+			return false
+		},
+	)
+	cur := make([]types.ValidatorCurrentRewardsRecord, 0)
+	keeper.IterateValidatorCurrentRewards(ctx,
+		func(val sdk.ValAddress, rewards types.ValidatorCurrentRewards) (stop bool) {
+			cur = append(cur, types.ValidatorCurrentRewardsRecord{
+				ValidatorAddress: val,
+				Rewards:          rewards,
+			})
+			return false
+		},
+	)
+	dels := make([]types.DelegatorStartingInfoRecord, 0)
+	keeper.IterateDelegatorStartingInfos(ctx,
+		func(val sdk.ValAddress, del sdk.AccAddress, info types.DelegatorStartingInfo) (stop bool) {
+			dels = append(dels, types.DelegatorStartingInfoRecord{
+				ValidatorAddress: val,
+				DelegatorAddress: del,
+				StartingInfo:     info,
+			})
+			return false
+		},
+	)
+
+	return types.NewGenesisState(params, feePool, dwi, pp, acc, keeper.GetDelegatorRewardsEnabled(ctx),
+		outstanding, his, cur, dels)
 }
@@ -20,6 +20,12 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 		case types.MsgWithdrawValidatorCommission:
 			return handleMsgWithdrawValidatorCommission(ctx, msg, k)
 
+		case types.MsgWithdrawDelegatorReward:
+			return handleMsgWithdrawDelegatorReward(ctx, msg, k)
+
+		case types.MsgWithdrawDelegatorAllRewards:
+			return handleMsgWithdrawDelegatorAllRewards(ctx, msg, k)
+
 		default:
 			return nil, types.ErrUnknownDistributionMsgType()
 		}
@@ -60,6 +66,46 @@ func handleMsgWithdrawValidatorCommission(ctx sdk.Context, msg types.MsgWithdraw
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
 
+func handleMsgWithdrawDelegatorReward(ctx sdk.Context, msg types.MsgWithdrawDelegatorReward, k keeper.Keeper) (*sdk.Result, error) {
+	if !k.IsDelegatorRewardsEnabled(ctx) {
+		return nil, types.ErrDelegatorRewardsDisabled()
+	}
+
+	_, err := k.WithdrawDelegationRewards(ctx, msg.DelegatorAddress, msg.ValidatorAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.DelegatorAddress.String()),
+		),
+	)
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgWithdrawDelegatorAllRewards(ctx sdk.Context, msg types.MsgWithdrawDelegatorAllRewards, k keeper.Keeper) (*sdk.Result, error) {
+	if !k.IsDelegatorRewardsEnabled(ctx) {
+		return nil, types.ErrDelegatorRewardsDisabled()
+	}
+
+	_, err := k.WithdrawDelegationAllRewards(ctx, msg.DelegatorAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.DelegatorAddress.String()),
+		),
+	)
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
 func NewCommunityPoolSpendProposalHandler(k Keeper) govtypes.Handler {
 	return func(ctx sdk.Context, content *govtypes.Proposal) error {
 		switch c := content.Content.(type) {
@@ -143,15 +143,46 @@ func (k Keeper) allocateByShares(ctx sdk.Context, rewards sdk.SysCoins) sdk.SysC
 
 // AllocateTokensToValidator allocate tokens to a particular validator, splitting according to commissions
 func (k Keeper) AllocateTokensToValidator(ctx sdk.Context, val exported.ValidatorI, tokens sdk.SysCoins) {
-	// split tokens between validator and delegators according to commissions
-	// commissions is always 1.0, so tokens.MulDec(val.GetCommission()) = tokens
-	// only update current commissions
+	// commissions is always 1.0 unless the delegator rewards are enabled, then all the tokens go to commission
+	commissionTokens := tokens
+	if k.IsDelegatorRewardsEnabled(ctx) {
+		// split tokens between validator and delegators according to commissions
+		commissionTokens = tokens.MulDecTruncate(val.GetCommission())
+		k.allocateTokensToDelegators(ctx, val, tokens.Sub(commissionTokens))
+	}
+
+	// update current commissions
 	commission := k.GetValidatorAccumulatedCommission(ctx, val.GetOperator())
-	commission = commission.Add(tokens...)
+	commission = commission.Add(commissionTokens...)
 	k.SetValidatorAccumulatedCommission(ctx, val.GetOperator(), commission)
 	ctx.EventManager().EmitEvent(
 		sdk.NewEvent(
 			types.EventTypeCommission,
+			sdk.NewAttribute(sdk.AttributeKeyAmount, commissionTokens.String()),
+			sdk.NewAttribute(types.AttributeKeyValidator, val.GetOperator().String()),
+		),
+	)
+}
+
+// allocateTokensToDelegators adds the rewards of validator's delegators to the current period
+func (k Keeper) allocateTokensToDelegators(ctx sdk.Context, val exported.ValidatorI, tokens sdk.SysCoins) {
+	if tokens.IsZero() {
+		return
+	}
+	k.ensureValidatorRewards(ctx, val.GetOperator())
+
+	// update current rewards
+	currentRewards := k.GetValidatorCurrentRewards(ctx, val.GetOperator())
+	currentRewards.Rewards = currentRewards.Rewards.Add(tokens...)
+	k.SetValidatorCurrentRewards(ctx, val.GetOperator(), currentRewards)
+
+	// update outstanding rewards
+	outstanding := k.GetValidatorOutstandingRewards(ctx, val.GetOperator())
+	outstanding = outstanding.Add(tokens...)
+	k.SetValidatorOutstandingRewards(ctx, val.GetOperator(), outstanding)
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeRewards,
 			sdk.NewAttribute(sdk.AttributeKeyAmount, tokens.String()),
 			sdk.NewAttribute(types.AttributeKeyValidator, val.GetOperator().String()),
 		),
@@ -0,0 +1,217 @@
+package keeper
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+
+	"github.com/okex/exchain/x/distribution/types"
+	"github.com/okex/exchain/x/staking/exported"
+)
+
+// initialize starting info for a new delegation
+func (k Keeper) initializeDelegation(ctx sdk.Context, val sdk.ValAddress, del sdk.AccAddress, shares sdk.Dec) {
+	// period has already been incremented - we want to store the period ended by this delegation action
+	previousPeriod := k.GetValidatorCurrentRewards(ctx, val).Period - 1
+
+	// increment reference count for the period we're going to track
+	k.incrementReferenceCount(ctx, val, previousPeriod)
+
+	k.SetDelegatorStartingInfo(ctx, val, del, types.NewDelegatorStartingInfo(previousPeriod, shares, uint64(ctx.BlockHeight())))
+}
+
+// calculate the rewards accrued by a delegation between two periods
+func (k Keeper) calculateDelegationRewardsBetween(ctx sdk.Context, val exported.ValidatorI,
+	startingPeriod, endingPeriod uint64, stake sdk.Dec) (rewards sdk.SysCoins) {
+	// sanity check
+	if startingPeriod > endingPeriod {
+		panic("startingPeriod cannot be greater than endingPeriod")
+	}
+
+	// sanity check
+	if stake.IsNegative() {
+		panic("stake should not be negative")
+	}
+
+	// return staking * (ending - starting)
+	starting := k.GetValidatorHistoricalRewards(ctx, val.GetOperator(), startingPeriod)
+	ending := k.GetValidatorHistoricalRewards(ctx, val.GetOperator(), endingPeriod)
+	difference := ending.CumulativeRewardRatio.Sub(starting.CumulativeRewardRatio)
+	if difference.IsAnyNegative() {
+		panic("negative rewards should not be possible")
+	}
+	// note: necessary to truncate so we don't allow withdrawing more rewards than owed
+	return difference.MulDecTruncate(stake)
+}
+
+// calculate the total rewards accrued by a delegation up to the ending period
+func (k Keeper) calculateDelegationRewards(ctx sdk.Context, val exported.ValidatorI, delAddr sdk.AccAddress,
+	endingPeriod uint64) (rewards sdk.SysCoins) {
+	startingInfo, _ := k.GetDelegatorStartingInfo(ctx, val.GetOperator(), delAddr)
+
+	// the shares of delegator can only be modified with the starting info updated,
+	// the lesser one is taken in case of any inconsistency
+	stake := startingInfo.Stake
+	if shares, found := k.stakingKeeper.GetShares(ctx, delAddr, val.GetOperator()); !found {
+		stake = sdk.ZeroDec()
+	} else if shares.LT(stake) {
+		stake = shares
+	}
+
+	return k.calculateDelegationRewardsBetween(ctx, val, startingInfo.PreviousPeriod, endingPeriod, stake)
+}
+
+// withdrawDelegationRewards withdraws the rewards of a delegation, the starting info is deleted
+func (k Keeper) withdrawDelegationRewards(ctx sdk.Context, val exported.ValidatorI, delAddr sdk.AccAddress) (
+	sdk.Coins, error) {
+	// check existence of delegator starting info
+	startingInfo, found := k.GetDelegatorStartingInfo(ctx, val.GetOperator(), delAddr)
+	if !found {
+		return nil, types.ErrEmptyDelegationDistInfo()
+	}
+
+	// end current period and calculate rewards
+	endingPeriod := k.incrementValidatorPeriod(ctx, val)
+	rewardsRaw := k.calculateDelegationRewards(ctx, val, delAddr, endingPeriod)
+	outstanding := k.GetValidatorOutstandingRewards(ctx, val.GetOperator())
+
+	// defensive edge case may happen on the very final digits
+	// of the decCoins due to operation order of the distribution mechanism.
+	rewards := rewardsRaw.Intersect(outstanding)
+	if !rewards.IsEqual(rewardsRaw) {
+		k.Logger(ctx).Info("rounding error withdrawing rewards from validator",
+			"delegator", delAddr.String(), "validator", val.GetOperator().String(),
+			"got", rewards.String(), "expected", rewardsRaw.String())
+	}
+
+	// truncate coins, return remainder to community pool
+	coins, remainder := rewards.TruncateDecimal()
+
+	// add coins to user account
+	if !coins.IsZero() {
+		withdrawAddr := k.GetDelegatorWithdrawAddr(ctx, delAddr)
+		err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, withdrawAddr, coins)
+		if err != nil {
+			return nil, types.ErrSendCoinsFromModuleToAccountFailed()
+		}
+	}
+
+	// update the outstanding rewards and the community pool only if the transaction was successful
+	k.SetValidatorOutstandingRewards(ctx, val.GetOperator(), outstanding.Sub(rewards))
+	feePool := k.GetFeePool(ctx)
+	feePool.CommunityPool = feePool.CommunityPool.Add(remainder...)
+	k.SetFeePool(ctx, feePool)
+
+	// decrement reference count of starting period
+	k.decrementReferenceCount(ctx, val.GetOperator(), startingInfo.PreviousPeriod)
+
+	// remove delegator starting info
+	k.deleteDelegatorStartingInfo(ctx, val.GetOperator(), delAddr)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeWithdrawRewards,
+			sdk.NewAttribute(sdk.AttributeKeyAmount, coins.String()),
+			sdk.NewAttribute(types.AttributeKeyValidator, val.GetOperator().String()),
+		),
+	)
+
+	return coins, nil
+}
+
+// WithdrawDelegationRewards withdraws the rewards of a delegator on a validator it added shares to
+func (k Keeper) WithdrawDelegationRewards(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) (
+	sdk.Coins, error) {
+	val := k.stakingKeeper.Validator(ctx, valAddr)
+	if val == nil {
+		return nil, types.ErrEmptyValidatorDistInfo()
+	}
+
+	shares, found := k.stakingKeeper.GetShares(ctx, delAddr, valAddr)
+	if !found {
+		return nil, types.ErrNoSharesAddedToValidator(delAddr.String(), valAddr.String())
+	}
+
+	// the delegation made before the delegator rewards were introduced starts to accrue rewards from now on
+	if !k.HasDelegatorStartingInfo(ctx, valAddr, delAddr) {
+		k.incrementValidatorPeriod(ctx, val)
+		k.initializeDelegation(ctx, valAddr, delAddr, shares)
+		return sdk.Coins{}, nil
+	}
+
+	rewards, err := k.withdrawDelegationRewards(ctx, val, delAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	// reinitialize the delegation
+	k.initializeDelegation(ctx, valAddr, delAddr, shares)
+	return rewards, nil
+}
+
+// WithdrawDelegationAllRewards withdraws the rewards of a delegator on all the validators it added shares to
+func (k Keeper) WithdrawDelegationAllRewards(ctx sdk.Context, delAddr sdk.AccAddress) (sdk.Coins, error) {
+	del := k.stakingKeeper.Delegator(ctx, delAddr)
+	if del == nil || len(del.GetShareAddedValidatorAddresses()) == 0 {
+		return nil, types.ErrNoDelegatorShares(delAddr.String())
+	}
+
+	var total sdk.Coins
+	for _, valAddr := range del.GetShareAddedValidatorAddresses() {
+		// the validator may have been removed
+		if k.stakingKeeper.Validator(ctx, valAddr) == nil {
+			continue
+		}
+		rewards, err := k.WithdrawDelegationRewards(ctx, delAddr, valAddr)
+		if err != nil {
+			return nil, err
+		}
+		total = total.Add(rewards...)
+	}
+	return total, nil
+}
+
+// CalculateDelegationRewards returns the rewards a delegator would get if it withdrew from a validator now
+func (k Keeper) CalculateDelegationRewards(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) (
+	sdk.SysCoins, error) {
+	// cache-wrap context as to not persist state changes during querying
+	ctx, _ = ctx.CacheContext()
+
+	val := k.stakingKeeper.Validator(ctx, valAddr)
+	if val == nil {
+		return nil, types.ErrEmptyValidatorDistInfo()
+	}
+
+	_, found := k.stakingKeeper.GetShares(ctx, delAddr, valAddr)
+	if !found {
+		return nil, types.ErrNoSharesAddedToValidator(delAddr.String(), valAddr.String())
+	}
+
+	if !k.HasDelegatorStartingInfo(ctx, valAddr, delAddr) {
+		return nil, types.ErrEmptyDelegationDistInfo()
+	}
+
+	endingPeriod := k.incrementValidatorPeriod(ctx, val)
+	return k.calculateDelegationRewards(ctx, val, delAddr, endingPeriod), nil
+}
+
+// InitializeDelegatorRewards initializes the rewards records of the validators and the starting infos of the
+// delegations made before the delegator rewards were enabled, so that the rewards allocated to them are withdrawable.
+// The ones already initialized are skipped.
+func (k Keeper) InitializeDelegatorRewards(ctx sdk.Context) {
+	k.stakingKeeper.IterateValidators(ctx, func(_ int64, val exported.ValidatorI) (stop bool) {
+		k.ensureValidatorRewards(ctx, val.GetOperator())
+		return false
+	})
+
+	k.stakingKeeper.IterateShares(ctx, func(_ int64, delAddr sdk.AccAddress, valAddr sdk.ValAddress,
+		shares sdk.Dec) (stop bool) {
+		val := k.stakingKeeper.Validator(ctx, valAddr)
+		if val == nil || k.HasDelegatorStartingInfo(ctx, valAddr, delAddr) {
+			return false
+		}
+		k.incrementValidatorPeriod(ctx, val)
+		k.initializeDelegation(ctx, valAddr, delAddr, shares)
+		return false
+	})
+
+	k.setDelegatorRewardsInitialized(ctx)
+}
@@ -0,0 +1,218 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+
+	"github.com/okex/exchain/x/distribution/types"
+	"github.com/okex/exchain/x/staking"
+)
+
+// setTestDelegation deposits tokens of delegator and adds shares to validators with the staking handler
+func setTestDelegation(t *testing.T, ctx sdk.Context, sk staking.Keeper, delAddr sdk.AccAddress,
+	amount sdk.SysCoin, valAddrs []sdk.ValAddress) {
+	h := staking.NewHandler(sk)
+	_, err := h(ctx, staking.NewMsgDeposit(delAddr, amount))
+	require.Nil(t, err)
+	_, err = h(ctx, staking.NewMsgAddShares(delAddr, valAddrs))
+	require.Nil(t, err)
+}
+
+// enableDelegatorRewards enables the delegator rewards since the Venus2 height, initializing the existing delegations
+func enableDelegatorRewards(ctx sdk.Context, k Keeper) sdk.Context {
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(1)
+	ctx = ctx.WithBlockHeight(1)
+	k.SetDelegatorRewardsEnabled(ctx, true)
+	k.InitializeDelegatorRewards(ctx)
+	return ctx
+}
+
+// setTestCommissionRate sets the commission rate charged by validator on the rewards of its delegators
+func setTestCommissionRate(t *testing.T, ctx sdk.Context, sk staking.Keeper, valAddr sdk.ValAddress, rate sdk.Dec) {
+	val, found := sk.GetValidator(ctx, valAddr)
+	require.True(t, found)
+	val.Commission.Rate = rate
+	sk.SetValidator(ctx, val)
+}
+
+// allocateTestTokens allocates tokens to validator with the coins added to the distribution module account
+func allocateTestTokens(t *testing.T, ctx sdk.Context, ak auth.AccountKeeper, k Keeper,
+	valAddr sdk.ValAddress, tokens sdk.SysCoins) {
+	acc := ak.GetAccount(ctx, k.supplyKeeper.GetModuleAddress(types.ModuleName))
+	require.NoError(t, acc.SetCoins(acc.GetCoins().Add(tokens...)))
+	ak.SetAccount(ctx, acc)
+	k.AllocateTokensToValidator(ctx, k.stakingKeeper.Validator(ctx, valAddr), tokens)
+}
+
+func TestAllocateTokensToValidatorWithDelegatorRewards(t *testing.T) {
+	ctx, _, k, sk, _ := CreateTestInputDefault(t, false, 1000)
+	ctx = enableDelegatorRewards(ctx.WithBlockTime(time.Now()), k)
+
+	// the commission rate is 1.0 by default, the rewards all go to commission
+	tokens := NewTestSysCoins(100, 0)
+	k.AllocateTokensToValidator(ctx, sk.Validator(ctx, valOpAddr1), tokens)
+	require.Equal(t, tokens, k.GetValidatorAccumulatedCommission(ctx, valOpAddr1))
+	require.True(t, k.GetValidatorOutstandingRewards(ctx, valOpAddr1).IsZero())
+
+	// half of the rewards go to delegators
+	setTestCommissionRate(t, ctx, sk, valOpAddr1, sdk.NewDecWithPrec(5, 1))
+	k.AllocateTokensToValidator(ctx, sk.Validator(ctx, valOpAddr1), tokens)
+	require.Equal(t, NewTestSysCoins(150, 0), k.GetValidatorAccumulatedCommission(ctx, valOpAddr1))
+	require.Equal(t, NewTestSysCoins(50, 0), k.GetValidatorOutstandingRewards(ctx, valOpAddr1))
+	require.Equal(t, NewTestSysCoins(50, 0), k.GetValidatorCurrentRewards(ctx, valOpAddr1).Rewards)
+
+	// the rewards all go to commission once it's disabled
+	k.SetDelegatorRewardsEnabled(ctx, false)
+	k.AllocateTokensToValidator(ctx, sk.Validator(ctx, valOpAddr1), tokens)
+	require.Equal(t, NewTestSysCoins(250, 0), k.GetValidatorAccumulatedCommission(ctx, valOpAddr1))
+	require.Equal(t, NewTestSysCoins(50, 0), k.GetValidatorOutstandingRewards(ctx, valOpAddr1))
+}
+
+func TestWithdrawDelegationRewards(t *testing.T) {
+	ctx, ak, k, sk, _ := CreateTestInputDefault(t, false, 1000)
+	ctx = enableDelegatorRewards(ctx.WithBlockTime(time.Now()), k)
+	setTestCommissionRate(t, ctx, sk, valOpAddr1, sdk.ZeroDec())
+
+	// no shares added
+	_, err := k.WithdrawDelegationRewards(ctx, delAddr1, valOpAddr1)
+	require.NotNil(t, err)
+
+	setTestDelegation(t, ctx, sk, delAddr1, NewTestSysCoin(100, 0), []sdk.ValAddress{valOpAddr1})
+	setTestDelegation(t, ctx, sk, delAddr2, NewTestSysCoin(100, 0), []sdk.ValAddress{valOpAddr1})
+	startingInfo, found := k.GetDelegatorStartingInfo(ctx, valOpAddr1, delAddr1)
+	require.True(t, found)
+	shares, _ := sk.GetShares(ctx, delAddr1, valOpAddr1)
+	require.Equal(t, shares, startingInfo.Stake)
+
+	// the rewards are split by shares, the share of msd isn't owned by any delegator
+	tokens := NewTestSysCoins(1000, 0)
+	allocateTestTokens(t, ctx, ak, k, valOpAddr1, tokens)
+	val := sk.Validator(ctx, valOpAddr1)
+	expected := tokens.QuoDecTruncate(val.GetDelegatorShares()).MulDecTruncate(shares)
+	rewards, err := k.CalculateDelegationRewards(ctx, delAddr1, valOpAddr1)
+	require.Nil(t, err)
+	require.Equal(t, expected, rewards)
+
+	// withdraw to the withdraw address
+	balance := ak.GetAccount(ctx, delAddr1).GetCoins()
+	coins, err := k.WithdrawDelegationRewards(ctx, delAddr1, valOpAddr1)
+	require.Nil(t, err)
+	truncated, _ := expected.TruncateDecimal()
+	require.Equal(t, truncated, coins)
+	require.Equal(t, balance.Add(coins...), ak.GetAccount(ctx, delAddr1).GetCoins())
+	require.Equal(t, tokens.Sub(expected), k.GetValidatorOutstandingRewards(ctx, valOpAddr1))
+
+	// nothing left to withdraw
+	rewards, err = k.CalculateDelegationRewards(ctx, delAddr1, valOpAddr1)
+	require.Nil(t, err)
+	require.True(t, rewards.IsZero())
+
+	// the rewards are withdrawn automatically once the shares are modified
+	balance = ak.GetAccount(ctx, delAddr2).GetCoins()
+	setTestDelegation(t, ctx, sk, delAddr2, NewTestSysCoin(1, 0), []sdk.ValAddress{valOpAddr1, valOpAddr2})
+	require.Equal(t, balance.Add(coins...).Sub(NewTestSysCoins(1, 0)), ak.GetAccount(ctx, delAddr2).GetCoins())
+	require.True(t, k.HasDelegatorStartingInfo(ctx, valOpAddr2, delAddr2))
+
+	_, broken := ModuleAccountInvariant(k)(ctx)
+	require.False(t, broken)
+}
+
+func TestWithdrawDelegationAllRewards(t *testing.T) {
+	ctx, ak, k, sk, _ := CreateTestInputDefault(t, false, 1000)
+	ctx = enableDelegatorRewards(ctx.WithBlockTime(time.Now()), k)
+	for _, valAddr := range []sdk.ValAddress{valOpAddr1, valOpAddr2} {
+		setTestCommissionRate(t, ctx, sk, valAddr, sdk.ZeroDec())
+	}
+
+	_, err := k.WithdrawDelegationAllRewards(ctx, delAddr1)
+	require.NotNil(t, err)
+
+	setTestDelegation(t, ctx, sk, delAddr1, NewTestSysCoin(100, 0), []sdk.ValAddress{valOpAddr1, valOpAddr2})
+	allocateTestTokens(t, ctx, ak, k, valOpAddr1, NewTestSysCoins(1000, 0))
+	allocateTestTokens(t, ctx, ak, k, valOpAddr2, NewTestSysCoins(1000, 0))
+
+	expected := sdk.SysCoins{}
+	for _, valAddr := range []sdk.ValAddress{valOpAddr1, valOpAddr2} {
+		rewards, err := k.CalculateDelegationRewards(ctx, delAddr1, valAddr)
+		require.Nil(t, err)
+		truncated, _ := rewards.TruncateDecimal()
+		expected = expected.Add(truncated...)
+	}
+	require.False(t, expected.IsZero())
+
+	coins, err := k.WithdrawDelegationAllRewards(ctx, delAddr1)
+	require.Nil(t, err)
+	require.Equal(t, expected, coins)
+
+	_, broken := ModuleAccountInvariant(k)(ctx)
+	require.False(t, broken)
+}
+
+func TestAfterValidatorRemovedWithOutstandingRewards(t *testing.T) {
+	ctx, ak, k, sk, _ := CreateTestInputDefault(t, false, 1000)
+	ctx = enableDelegatorRewards(ctx.WithBlockTime(time.Now()), k)
+	setTestCommissionRate(t, ctx, sk, valOpAddr1, sdk.ZeroDec())
+	setTestDelegation(t, ctx, sk, delAddr1, NewTestSysCoin(100, 0), []sdk.ValAddress{valOpAddr1})
+	allocateTestTokens(t, ctx, ak, k, valOpAddr1, NewTestSysCoins(1000, 0))
+
+	// the outstanding rewards go to the community pool
+	k.Hooks().AfterValidatorRemoved(ctx, nil, valOpAddr1)
+	require.Equal(t, NewTestSysCoins(1000, 0), k.GetFeePoolCommunityCoins(ctx))
+	require.False(t, k.HasValidatorCurrentRewards(ctx, valOpAddr1))
+	require.False(t, k.HasDelegatorStartingInfo(ctx, valOpAddr1, delAddr1))
+
+	_, broken := ModuleAccountInvariant(k)(ctx)
+	require.False(t, broken)
+}
+
+func TestInitializeDelegatorRewards(t *testing.T) {
+	ctx, ak, k, sk, _ := CreateTestInputDefault(t, false, 1000)
+	ctx = ctx.WithBlockTime(time.Now())
+	setTestCommissionRate(t, ctx, sk, valOpAddr1, sdk.ZeroDec())
+
+	// the delegations don't accrue rewards before the delegator rewards get active
+	setTestDelegation(t, ctx, sk, delAddr1, NewTestSysCoin(100, 0), []sdk.ValAddress{valOpAddr1})
+	require.False(t, k.IsDelegatorRewardsActive(ctx))
+	require.False(t, k.HasDelegatorStartingInfo(ctx, valOpAddr1, delAddr1))
+	require.False(t, k.HasValidatorCurrentRewards(ctx, valOpAddr1))
+
+	// enabling the rewards before the Venus2 height takes no effect
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(2)
+	ctx = ctx.WithBlockHeight(1)
+	k.SetDelegatorRewardsEnabled(ctx, true)
+	setTestDelegation(t, ctx, sk, delAddr2, NewTestSysCoin(100, 0), []sdk.ValAddress{valOpAddr1})
+	require.False(t, k.IsDelegatorRewardsEnabled(ctx))
+	require.False(t, k.HasDelegatorStartingInfo(ctx, valOpAddr1, delAddr2))
+
+	// the existing delegations are initialized since the Venus2 height, only once
+	ctx = ctx.WithBlockHeight(2)
+	k.InitializeDelegatorRewards(ctx)
+	require.True(t, k.IsDelegatorRewardsEnabled(ctx))
+	startingInfo, found := k.GetDelegatorStartingInfo(ctx, valOpAddr1, delAddr1)
+	require.True(t, found)
+	k.InitializeDelegatorRewards(ctx)
+	startingInfo2, _ := k.GetDelegatorStartingInfo(ctx, valOpAddr1, delAddr1)
+	require.Equal(t, startingInfo, startingInfo2)
+
+	// the rewards allocated to the delegations made before are withdrawable
+	allocateTestTokens(t, ctx, ak, k, valOpAddr1, NewTestSysCoins(1000, 0))
+	for _, delAddr := range []sdk.AccAddress{delAddr1, delAddr2} {
+		coins, err := k.WithdrawDelegationRewards(ctx, delAddr, valOpAddr1)
+		require.Nil(t, err)
+		require.False(t, coins.IsZero())
+	}
+
+	// the delegations keep accruing rewards once they're disabled
+	k.SetDelegatorRewardsEnabled(ctx, false)
+	require.True(t, k.IsDelegatorRewardsActive(ctx))
+	require.False(t, k.IsDelegatorRewardsEnabled(ctx))
+
+	_, broken := ModuleAccountInvariant(k)(ctx)
+	require.False(t, broken)
+}
@@ -2,6 +2,7 @@ package keeper
 
 import (
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 
 	"github.com/okex/exchain/x/distribution/types"
 	stakingtypes "github.com/okex/exchain/x/staking/types"
@@ -21,6 +22,9 @@ func (k Keeper) Hooks() Hooks { return Hooks{k} }
 func (h Hooks) AfterValidatorCreated(ctx sdk.Context, valAddr sdk.ValAddress) {
 	val := h.k.stakingKeeper.Validator(ctx, valAddr)
 	h.k.initializeValidator(ctx, val)
+	if h.k.IsDelegatorRewardsActive(ctx) {
+		h.k.initializeValidatorRewards(ctx, valAddr)
+	}
 }
 
 // AfterValidatorRemoved cleans up for after validator is removed
@@ -49,6 +53,25 @@ func (h Hooks) AfterValidatorRemoved(ctx sdk.Context, _ sdk.ConsAddress, valAddr
 
 	// remove commission record
 	h.k.deleteValidatorAccumulatedCommission(ctx, valAddr)
+
+	// there were no delegator rewards records before Venus2
+	if !tmtypes.HigherThanVenus2(ctx.BlockHeight()) {
+		return
+	}
+
+	// the rewards of delegators that haven't been withdrawn go to the community pool
+	outstanding := h.k.GetValidatorOutstandingRewards(ctx, valAddr)
+	if !outstanding.IsZero() {
+		feePool := h.k.GetFeePool(ctx)
+		feePool.CommunityPool = feePool.CommunityPool.Add(outstanding...)
+		h.k.SetFeePool(ctx, feePool)
+	}
+
+	// remove delegator rewards records
+	h.k.deleteValidatorOutstandingRewards(ctx, valAddr)
+	h.k.deleteValidatorHistoricalRewards(ctx, valAddr)
+	h.k.deleteValidatorCurrentRewards(ctx, valAddr)
+	h.k.deleteDelegatorStartingInfos(ctx, valAddr)
 }
 
 // AfterValidatorDestroyed nothing to do
@@ -56,7 +79,57 @@ func (h Hooks) AfterValidatorDestroyed(ctx sdk.Context, consAddr sdk.ConsAddress
 
 }
 
+// BeforeValidatorModified ends the current period of validator before its shares are modified
+func (h Hooks) BeforeValidatorModified(ctx sdk.Context, valAddr sdk.ValAddress) {
+	if !h.k.IsDelegatorRewardsActive(ctx) {
+		return
+	}
+	if val := h.k.stakingKeeper.Validator(ctx, valAddr); val != nil {
+		h.k.incrementValidatorPeriod(ctx, val)
+	}
+}
+
+// BeforeDelegationCreated ends the current period of validators before the delegator adds shares to them
+func (h Hooks) BeforeDelegationCreated(ctx sdk.Context, _ sdk.AccAddress, valAddrs []sdk.ValAddress) {
+	if !h.k.IsDelegatorRewardsActive(ctx) {
+		return
+	}
+	for _, valAddr := range valAddrs {
+		val := h.k.stakingKeeper.Validator(ctx, valAddr)
+		h.k.incrementValidatorPeriod(ctx, val)
+	}
+}
+
+// BeforeDelegationSharesModified withdraws the rewards of delegator before its shares on validators are modified
+func (h Hooks) BeforeDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddrs []sdk.ValAddress) {
+	if !h.k.IsDelegatorRewardsActive(ctx) {
+		return
+	}
+	for _, valAddr := range valAddrs {
+		val := h.k.stakingKeeper.Validator(ctx, valAddr)
+		if !h.k.HasDelegatorStartingInfo(ctx, valAddr, delAddr) {
+			// the delegation made while the delegator rewards were inactive has nothing to withdraw
+			h.k.incrementValidatorPeriod(ctx, val)
+			continue
+		}
+		if _, err := h.k.withdrawDelegationRewards(ctx, val, delAddr); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// AfterDelegationModified creates new starting info of delegator on validators after its shares are modified
+func (h Hooks) AfterDelegationModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddrs []sdk.ValAddress) {
+	if !h.k.IsDelegatorRewardsActive(ctx) {
+		return
+	}
+	for _, valAddr := range valAddrs {
+		if shares, found := h.k.stakingKeeper.GetShares(ctx, delAddr, valAddr); found {
+			h.k.initializeDelegation(ctx, valAddr, delAddr, shares)
+		}
+	}
+}
+
 // nolint - unused hooks
-func (h Hooks) BeforeValidatorModified(_ sdk.Context, _ sdk.ValAddress)                         {}
 func (h Hooks) AfterValidatorBonded(_ sdk.Context, _ sdk.ConsAddress, _ sdk.ValAddress)         {}
 func (h Hooks) AfterValidatorBeginUnbonding(_ sdk.Context, _ sdk.ConsAddress, _ sdk.ValAddress) {}
@@ -2,6 +2,7 @@ package keeper
 
 import (
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 
 	"github.com/okex/exchain/x/distribution/types"
 )
@@ -42,3 +43,27 @@ func (k Keeper) GetWithdrawAddrEnabled(ctx sdk.Context) (enabled bool) {
 func (k Keeper) SetWithdrawAddrEnabled(ctx sdk.Context, enabled bool) {
 	k.paramSpace.Set(ctx, types.ParamStoreKeyWithdrawAddrEnabled, &enabled)
 }
+
+// GetDelegatorRewardsEnabled returns whether the rewards are distributed to delegators, it's disabled if it's not set
+func (k Keeper) GetDelegatorRewardsEnabled(ctx sdk.Context) (enabled bool) {
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyDelegatorRewardsEnabled, &enabled)
+	return enabled
+}
+
+// SetDelegatorRewardsEnabled sets the value of delegator rewards enabled
+// nolint: errcheck
+func (k Keeper) SetDelegatorRewardsEnabled(ctx sdk.Context, enabled bool) {
+	k.paramSpace.Set(ctx, types.ParamStoreKeyDelegatorRewardsEnabled, &enabled)
+}
+
+// IsDelegatorRewardsActive returns whether the delegations accrue rewards. It's active since the Venus2 height once
+// the delegator rewards were enabled and the existing delegations initialized, then it's kept active even if they're
+// disabled later, so that the rewards accrued before are still withdrawable.
+func (k Keeper) IsDelegatorRewardsActive(ctx sdk.Context) bool {
+	return tmtypes.HigherThanVenus2(ctx.BlockHeight()) && k.HasDelegatorRewardsInitialized(ctx)
+}
+
+// IsDelegatorRewardsEnabled returns whether the rewards are distributed to delegators and withdrawable now
+func (k Keeper) IsDelegatorRewardsEnabled(ctx sdk.Context) bool {
+	return k.IsDelegatorRewardsActive(ctx) && k.GetDelegatorRewardsEnabled(ctx)
+}
@@ -25,6 +25,15 @@ func NewQuerier(k Keeper) sdk.Querier {
 		case types.QueryCommunityPool:
 			return queryCommunityPool(ctx, path[1:], req, k)
 
+		case types.QueryDelegationRewards:
+			return queryDelegationRewards(ctx, path[1:], req, k)
+
+		case types.QueryDelegatorTotalRewards:
+			return queryDelegatorTotalRewards(ctx, path[1:], req, k)
+
+		case types.QueryValidatorOutstandingRewards:
+			return queryValidatorOutstandingRewards(ctx, path[1:], req, k)
+
 		default:
 			return nil, types.ErrUnknownDistributionQueryType()
 		}
@@ -45,6 +54,12 @@ func queryParams(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper
 			return nil, comm.ErrMarshalJSONFailed(err.Error())
 		}
 		return bz, nil
+	case types.ParamDelegatorRewardsEnabled:
+		bz, err := codec.MarshalJSONIndent(k.cdc, k.GetDelegatorRewardsEnabled(ctx))
+		if err != nil {
+			return nil, comm.ErrMarshalJSONFailed(err.Error())
+		}
+		return bz, nil
 
 	default:
 		return nil, types.ErrUnknownDistributionParamType()
@@ -103,3 +118,81 @@ func queryCommunityPool(ctx sdk.Context, _ []string, req abci.RequestQuery, k Ke
 
 	return bz, nil
 }
+
+func queryDelegationRewards(ctx sdk.Context, _ []string, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryDelegationRewardsParams
+	err := k.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, comm.ErrUnMarshalJSONFailed(err.Error())
+	}
+
+	rewards, err := k.CalculateDelegationRewards(ctx, params.DelegatorAddress, params.ValidatorAddress)
+	if err != nil {
+		return nil, err
+	}
+	if rewards == nil {
+		rewards = sdk.SysCoins{}
+	}
+
+	bz, err := codec.MarshalJSONIndent(k.cdc, rewards)
+	if err != nil {
+		return nil, comm.ErrMarshalJSONFailed(err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryDelegatorTotalRewards(ctx sdk.Context, _ []string, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryDelegatorParams
+	err := k.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, comm.ErrUnMarshalJSONFailed(err.Error())
+	}
+
+	del := k.stakingKeeper.Delegator(ctx, params.DelegatorAddress)
+	if del == nil {
+		return nil, types.ErrNoDelegatorShares(params.DelegatorAddress.String())
+	}
+
+	total := sdk.SysCoins{}
+	delRewards := make([]types.DelegationDelegatorReward, 0)
+	for _, valAddr := range del.GetShareAddedValidatorAddresses() {
+		// the validators removed and the delegations without distribution info have no rewards
+		if !k.HasDelegatorStartingInfo(ctx, valAddr, params.DelegatorAddress) {
+			continue
+		}
+		rewards, err := k.CalculateDelegationRewards(ctx, params.DelegatorAddress, valAddr)
+		if err != nil {
+			return nil, err
+		}
+		delRewards = append(delRewards, types.NewDelegationDelegatorReward(valAddr, rewards))
+		total = total.Add(rewards...)
+	}
+
+	bz, err := codec.MarshalJSONIndent(k.cdc, types.NewQueryDelegatorTotalRewardsResponse(delRewards, total))
+	if err != nil {
+		return nil, comm.ErrMarshalJSONFailed(err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryValidatorOutstandingRewards(ctx sdk.Context, _ []string, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryValidatorOutstandingRewardsParams
+	err := k.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, comm.ErrUnMarshalJSONFailed(err.Error())
+	}
+
+	rewards := k.GetValidatorOutstandingRewards(ctx, params.ValidatorAddress)
+	if rewards == nil {
+		rewards = types.ValidatorOutstandingRewards{}
+	}
+
+	bz, err := codec.MarshalJSONIndent(k.cdc, rewards)
+	if err != nil {
+		return nil, comm.ErrMarshalJSONFailed(err.Error())
+	}
+
+	return bz, nil
+}
@@ -0,0 +1,69 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// DelegatorStartingInfo is the starting info of a delegator's reward period on a validator. It tracks the previous
+// validator period, the delegator's shares on the validator and the height of the starting period
+type DelegatorStartingInfo struct {
+	PreviousPeriod uint64  `json:"previous_period" yaml:"previous_period"`
+	Stake          sdk.Dec `json:"stake" yaml:"stake"`
+	Height         uint64  `json:"creation_height" yaml:"creation_height"`
+}
+
+// NewDelegatorStartingInfo creates a new DelegatorStartingInfo
+func NewDelegatorStartingInfo(previousPeriod uint64, stake sdk.Dec, height uint64) DelegatorStartingInfo {
+	return DelegatorStartingInfo{
+		PreviousPeriod: previousPeriod,
+		Stake:          stake,
+		Height:         height,
+	}
+}
+
+// String returns a human readable string representation of DelegatorStartingInfo
+func (dsi DelegatorStartingInfo) String() string {
+	return fmt.Sprintf(`Delegator Starting Info:
+  Previous Period:  %d
+  Stake:            %s
+  Height:           %d`,
+		dsi.PreviousPeriod, dsi.Stake, dsi.Height)
+}
+
+// DelegationDelegatorReward is the rewards of a delegator on a validator
+type DelegationDelegatorReward struct {
+	ValidatorAddress sdk.ValAddress `json:"validator_address" yaml:"validator_address"`
+	Reward           sdk.SysCoins   `json:"reward" yaml:"reward"`
+}
+
+// NewDelegationDelegatorReward creates a new DelegationDelegatorReward
+func NewDelegationDelegatorReward(valAddr sdk.ValAddress, reward sdk.SysCoins) DelegationDelegatorReward {
+	return DelegationDelegatorReward{ValidatorAddress: valAddr, Reward: reward}
+}
+
+// QueryDelegatorTotalRewardsResponse is the response of query 'custom/distr/delegator_total_rewards'
+type QueryDelegatorTotalRewardsResponse struct {
+	Rewards []DelegationDelegatorReward `json:"rewards" yaml:"rewards"`
+	Total   sdk.SysCoins                `json:"total" yaml:"total"`
+}
+
+// NewQueryDelegatorTotalRewardsResponse creates a new QueryDelegatorTotalRewardsResponse
+func NewQueryDelegatorTotalRewardsResponse(rewards []DelegationDelegatorReward,
+	total sdk.SysCoins) QueryDelegatorTotalRewardsResponse {
+	return QueryDelegatorTotalRewardsResponse{Rewards: rewards, Total: total}
+}
+
+// String returns a human readable string representation of QueryDelegatorTotalRewardsResponse
+func (res QueryDelegatorTotalRewardsResponse) String() string {
+	out := "Delegator Total Rewards:\n"
+	out += "  Rewards:"
+	for _, reward := range res.Rewards {
+		out += fmt.Sprintf(`
+    ValidatorAddress: %s
+    Reward:           %s`, reward.ValidatorAddress, reward.Reward)
+	}
+	out += fmt.Sprintf("\n  Total: %s\n", res.Total)
+	return out
+}
@@ -2,6 +2,8 @@
 package types
 
 import (
+	"fmt"
+
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
 )
@@ -28,6 +30,11 @@ const (
 	CodeBadDistribution                             uint32 = 67816
 	CodeInvalidProposalAmount                       uint32 = 67817
 	CodeEmptyProposalRecipient                      uint32 = 67818
+	CodeDelegatorRewardsDisabled                    uint32 = 67819
+	CodeEmptyValidatorDistInfo                      uint32 = 67820
+	CodeEmptyDelegationDistInfo                     uint32 = 67821
+	CodeNoSharesAddedToValidator                    uint32 = 67822
+	CodeNoDelegatorShares                           uint32 = 67823
 )
 
 func ErrNilDelegatorAddr() sdk.Error {
@@ -89,3 +96,25 @@ func ErrInvalidProposalAmount() sdk.Error {
 func ErrEmptyProposalRecipient() sdk.Error {
 	return sdkerrors.New(DefaultCodespace, CodeEmptyProposalRecipient, "invalid community pool spend proposal recipient")
 }
+
+func ErrDelegatorRewardsDisabled() sdk.Error {
+	return sdkerrors.New(DefaultCodespace, CodeDelegatorRewardsDisabled, "delegator rewards distribution disabled")
+}
+
+func ErrEmptyValidatorDistInfo() sdk.Error {
+	return sdkerrors.New(DefaultCodespace, CodeEmptyValidatorDistInfo, "no validator distribution info")
+}
+
+func ErrEmptyDelegationDistInfo() sdk.Error {
+	return sdkerrors.New(DefaultCodespace, CodeEmptyDelegationDistInfo, "no delegation distribution info")
+}
+
+func ErrNoSharesAddedToValidator(delAddr string, valAddr string) sdk.Error {
+	return sdkerrors.New(DefaultCodespace, CodeNoSharesAddedToValidator,
+		fmt.Sprintf("delegator %s hasn't added shares to validator %s", delAddr, valAddr))
+}
+
+func ErrNoDelegatorShares(delAddr string) sdk.Error {
+	return sdkerrors.New(DefaultCodespace, CodeNoDelegatorShares,
+		fmt.Sprintf("delegator %s hasn't added shares to any validator", delAddr))
+}
@@ -6,6 +6,8 @@ const (
 	EventTypeCommission         = "commission"
 	EventTypeWithdrawCommission = "withdraw_commission"
 	EventTypeProposerReward     = "proposer_reward"
+	EventTypeRewards            = "rewards"
+	EventTypeWithdrawRewards    = "withdraw_rewards"
 
 	AttributeKeyWithdrawAddress = "withdraw_address"
 	AttributeKeyValidator       = "validator"
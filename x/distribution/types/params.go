@@ -26,7 +26,7 @@ type Params struct {
 
 // ParamKeyTable returns the parameter key table.
 func ParamKeyTable() params.KeyTable {
-	return params.NewKeyTable().RegisterParamSet(&Params{})
+	return params.NewKeyTable().RegisterParamSet(&Params{}).RegisterParamSet(&DelegatorRewardsParamsSet{})
 }
 
 // DefaultParams returns default distribution parameters
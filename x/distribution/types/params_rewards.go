@@ -0,0 +1,37 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/okex/exchain/x/params"
+)
+
+// ParamStoreKeyDelegatorRewardsEnabled is the parameter key of the delegator rewards switch. It isn't a member of
+// Params, so that the chains without it in the param store still work with the rewards disabled.
+var ParamStoreKeyDelegatorRewardsEnabled = []byte("delegatorrewardsenabled")
+
+var (
+	_ params.ParamSet = &DelegatorRewardsParamsSet{}
+)
+
+// DelegatorRewardsParamsSet is the param set which toggles the distribution of rewards to delegators
+type DelegatorRewardsParamsSet struct {
+	DelegatorRewardsEnabled bool `json:"delegator_rewards_enabled" yaml:"delegator_rewards_enabled"`
+}
+
+// ParamSetPairs implements the ParamSet interface
+func (p *DelegatorRewardsParamsSet) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		params.NewParamSetPair(ParamStoreKeyDelegatorRewardsEnabled, &p.DelegatorRewardsEnabled,
+			validateDelegatorRewardsEnabled),
+	}
+}
+
+func validateDelegatorRewardsEnabled(i interface{}) error {
+	_, ok := i.(bool)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
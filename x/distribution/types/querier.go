@@ -4,13 +4,17 @@ import sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 
 // querier keys
 const (
-	QueryParams              = "params"
-	QueryValidatorCommission = "validator_commission"
-	QueryWithdrawAddr        = "withdraw_addr"
-	QueryCommunityPool       = "community_pool"
+	QueryParams                      = "params"
+	QueryValidatorCommission         = "validator_commission"
+	QueryWithdrawAddr                = "withdraw_addr"
+	QueryCommunityPool               = "community_pool"
+	QueryDelegationRewards           = "delegation_rewards"
+	QueryDelegatorTotalRewards       = "delegator_total_rewards"
+	QueryValidatorOutstandingRewards = "validator_outstanding_rewards"
 
-	ParamCommunityTax        = "community_tax"
-	ParamWithdrawAddrEnabled = "withdraw_addr_enabled"
+	ParamCommunityTax            = "community_tax"
+	ParamWithdrawAddrEnabled     = "withdraw_addr_enabled"
+	ParamDelegatorRewardsEnabled = "delegator_rewards_enabled"
 )
 
 // QueryValidatorCommissionParams is the struct of params for query 'custom/distr/validator_commission'
@@ -34,3 +38,38 @@ type QueryDelegatorWithdrawAddrParams struct {
 func NewQueryDelegatorWithdrawAddrParams(delegatorAddr sdk.AccAddress) QueryDelegatorWithdrawAddrParams {
 	return QueryDelegatorWithdrawAddrParams{DelegatorAddress: delegatorAddr}
 }
+
+// QueryDelegationRewardsParams is the struct of params for query 'custom/distr/delegation_rewards'
+type QueryDelegationRewardsParams struct {
+	DelegatorAddress sdk.AccAddress `json:"delegator_address" yaml:"delegator_address"`
+	ValidatorAddress sdk.ValAddress `json:"validator_address" yaml:"validator_address"`
+}
+
+// NewQueryDelegationRewardsParams creates a new instance of QueryDelegationRewardsParams
+func NewQueryDelegationRewardsParams(delegatorAddr sdk.AccAddress,
+	validatorAddr sdk.ValAddress) QueryDelegationRewardsParams {
+	return QueryDelegationRewardsParams{
+		DelegatorAddress: delegatorAddr,
+		ValidatorAddress: validatorAddr,
+	}
+}
+
+// QueryDelegatorParams is the struct of params for query 'custom/distr/delegator_total_rewards'
+type QueryDelegatorParams struct {
+	DelegatorAddress sdk.AccAddress `json:"delegator_address" yaml:"delegator_address"`
+}
+
+// NewQueryDelegatorParams creates a new instance of QueryDelegatorParams
+func NewQueryDelegatorParams(delegatorAddr sdk.AccAddress) QueryDelegatorParams {
+	return QueryDelegatorParams{DelegatorAddress: delegatorAddr}
+}
+
+// QueryValidatorOutstandingRewardsParams is the struct of params for query 'custom/distr/validator_outstanding_rewards'
+type QueryValidatorOutstandingRewardsParams struct {
+	ValidatorAddress sdk.ValAddress `json:"validator_address" yaml:"validator_address"`
+}
+
+// NewQueryValidatorOutstandingRewardsParams creates a new instance of QueryValidatorOutstandingRewardsParams
+func NewQueryValidatorOutstandingRewardsParams(validatorAddr sdk.ValAddress) QueryValidatorOutstandingRewardsParams {
+	return QueryValidatorOutstandingRewardsParams{ValidatorAddress: validatorAddr}
+}
@@ -12,3 +12,38 @@ type ValidatorAccumulatedCommission = sdk.SysCoins
 func InitialValidatorAccumulatedCommission() ValidatorAccumulatedCommission {
 	return ValidatorAccumulatedCommission{}
 }
+
+// ValidatorHistoricalRewards is the cumulative reward ratio of a validator's delegators at a period.
+// The reference count indicates the number of objects which might need to reference this historical entry
+// at any point: one per delegator starting info which refers to the period, plus one for the next period
+type ValidatorHistoricalRewards struct {
+	CumulativeRewardRatio sdk.SysCoins `json:"cumulative_reward_ratio" yaml:"cumulative_reward_ratio"`
+	ReferenceCount        uint16       `json:"reference_count" yaml:"reference_count"`
+}
+
+// NewValidatorHistoricalRewards creates a new ValidatorHistoricalRewards
+func NewValidatorHistoricalRewards(cumulativeRewardRatio sdk.SysCoins, referenceCount uint16) ValidatorHistoricalRewards {
+	return ValidatorHistoricalRewards{
+		CumulativeRewardRatio: cumulativeRewardRatio,
+		ReferenceCount:        referenceCount,
+	}
+}
+
+// ValidatorCurrentRewards is the rewards of a validator's delegators accumulated in the current period,
+// they're folded into the cumulative reward ratio when the period is incremented
+type ValidatorCurrentRewards struct {
+	Rewards sdk.SysCoins `json:"rewards" yaml:"rewards"`
+	Period  uint64       `json:"period" yaml:"period"`
+}
+
+// NewValidatorCurrentRewards creates a new ValidatorCurrentRewards
+func NewValidatorCurrentRewards(rewards sdk.SysCoins, period uint64) ValidatorCurrentRewards {
+	return ValidatorCurrentRewards{
+		Rewards: rewards,
+		Period:  period,
+	}
+}
+
+// ValidatorOutstandingRewards is the rewards of a validator's delegators that haven't been withdrawn yet,
+// the commission isn't included
+type ValidatorOutstandingRewards = sdk.SysCoins
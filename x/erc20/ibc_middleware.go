@@ -0,0 +1,223 @@
+package erc20
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	capabilitytypes "github.com/okex/exchain/libs/cosmos-sdk/x/capability/types"
+	ibctransferType "github.com/okex/exchain/libs/ibc-go/modules/apps/transfer/types"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+	porttypes "github.com/okex/exchain/libs/ibc-go/modules/core/05-port/types"
+	ibcexported "github.com/okex/exchain/libs/ibc-go/modules/core/exported"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/erc20/types"
+)
+
+var _ porttypes.IBCModule = IBCMiddleware{}
+
+// IBCMiddleware wraps the ics20 transfer module and interprets the json memo of the packets:
+// an `evm` memo calls a contract with the received funds and an `evm_callback` memo notifies
+// a contract about the acknowledgement or timeout of a sent packet.
+type IBCMiddleware struct {
+	app    porttypes.IBCModule
+	keeper Keeper
+}
+
+// NewIBCMiddleware creates a new IBCMiddleware given the transfer module and the erc20 keeper
+func NewIBCMiddleware(app porttypes.IBCModule, k Keeper) IBCMiddleware {
+	return IBCMiddleware{
+		app:    app,
+		keeper: k,
+	}
+}
+
+// OnChanOpenInit implements the IBCModule interface
+func (im IBCMiddleware) OnChanOpenInit(
+	ctx sdk.Context,
+	order channeltypes.Order,
+	connectionHops []string,
+	portID string,
+	channelID string,
+	chanCap *capabilitytypes.Capability,
+	counterparty channeltypes.Counterparty,
+	version string,
+) error {
+	return im.app.OnChanOpenInit(ctx, order, connectionHops, portID, channelID, chanCap, counterparty, version)
+}
+
+// OnChanOpenTry implements the IBCModule interface
+func (im IBCMiddleware) OnChanOpenTry(
+	ctx sdk.Context,
+	order channeltypes.Order,
+	connectionHops []string,
+	portID,
+	channelID string,
+	chanCap *capabilitytypes.Capability,
+	counterparty channeltypes.Counterparty,
+	version,
+	counterpartyVersion string,
+) error {
+	return im.app.OnChanOpenTry(ctx, order, connectionHops, portID, channelID, chanCap, counterparty, version, counterpartyVersion)
+}
+
+// OnChanOpenAck implements the IBCModule interface
+func (im IBCMiddleware) OnChanOpenAck(
+	ctx sdk.Context,
+	portID,
+	channelID string,
+	counterpartyVersion string,
+) error {
+	return im.app.OnChanOpenAck(ctx, portID, channelID, counterpartyVersion)
+}
+
+// OnChanOpenConfirm implements the IBCModule interface
+func (im IBCMiddleware) OnChanOpenConfirm(
+	ctx sdk.Context,
+	portID,
+	channelID string,
+) error {
+	return im.app.OnChanOpenConfirm(ctx, portID, channelID)
+}
+
+// OnChanCloseInit implements the IBCModule interface
+func (im IBCMiddleware) OnChanCloseInit(
+	ctx sdk.Context,
+	portID,
+	channelID string,
+) error {
+	return im.app.OnChanCloseInit(ctx, portID, channelID)
+}
+
+// OnChanCloseConfirm implements the IBCModule interface
+func (im IBCMiddleware) OnChanCloseConfirm(
+	ctx sdk.Context,
+	portID,
+	channelID string,
+) error {
+	return im.app.OnChanCloseConfirm(ctx, portID, channelID)
+}
+
+// OnRecvPacket implements the IBCModule interface. If the memo of the packet requests an evm call,
+// the funds are received by an address derived from the channel and the original sender, which then
+// calls the contract. An error acknowledgement is returned if the call fails, so that the receipt
+// of the funds is reverted and they are refunded on the sender chain. The memos are only interpreted
+// since the Venus2 height.
+func (im IBCMiddleware) OnRecvPacket(
+	ctx sdk.Context,
+	packet channeltypes.Packet,
+	relayer sdk.AccAddress,
+) ibcexported.Acknowledgement {
+	if !tmtypes.HigherThanVenus2(ctx.BlockHeight()) {
+		return im.app.OnRecvPacket(ctx, packet, relayer)
+	}
+
+	var data ibctransferType.FungibleTokenPacketData
+	if err := ibctransferType.ModuleCdc.UnmarshalJSON(packet.GetData(), &data); err != nil {
+		return im.app.OnRecvPacket(ctx, packet, relayer)
+	}
+
+	memo, err := types.ParseIbcHooksMemo(data.Memo)
+	if err != nil {
+		return channeltypes.NewErrorAcknowledgement(err.Error())
+	}
+	if memo == nil || memo.Evm == nil {
+		return im.app.OnRecvPacket(ctx, packet, relayer)
+	}
+
+	// the receiver must be the contract so that the sender chain sees where the funds go
+	receiver, err := sdk.AccAddressFromBech32(data.Receiver)
+	if err != nil || !receiver.Equals(sdk.AccAddress(common.HexToAddress(memo.Evm.Contract).Bytes())) {
+		return channeltypes.NewErrorAcknowledgement(
+			fmt.Sprintf("%s: receiver %s must be the contract %s", types.ErrInvalidIbcHooksMemo, data.Receiver, memo.Evm.Contract))
+	}
+
+	sender := types.DeriveIbcHooksSender(packet.GetDestChannel(), data.Sender)
+	data.Receiver = sender.String()
+	packet.Data = data.GetBytes()
+
+	ack := im.app.OnRecvPacket(ctx, packet, relayer)
+	if !ack.Success() {
+		return ack
+	}
+
+	amount, ok := sdk.NewIntFromString(data.Amount)
+	if !ok {
+		return channeltypes.NewErrorAcknowledgement(fmt.Sprintf("invalid amount %s", data.Amount))
+	}
+	coin := sdk.NewCoin(types.ReceivedDenom(packet, data), sdk.NewDecFromIntWithPrec(amount, sdk.Precision))
+	if err := im.keeper.OnRecvEvmCall(ctx, sender, coin, *memo.Evm); err != nil {
+		return channeltypes.NewErrorAcknowledgement(err.Error())
+	}
+	return ack
+}
+
+// OnAcknowledgementPacket implements the IBCModule interface
+func (im IBCMiddleware) OnAcknowledgementPacket(
+	ctx sdk.Context,
+	packet channeltypes.Packet,
+	acknowledgement []byte,
+	relayer sdk.AccAddress,
+) error {
+	if err := im.app.OnAcknowledgementPacket(ctx, packet, acknowledgement, relayer); err != nil {
+		return err
+	}
+
+	callback := im.parseCallback(ctx, packet)
+	if callback == "" {
+		return nil
+	}
+	var ack channeltypes.Acknowledgement
+	success := channeltypes.SubModuleCdc.UnmarshalJSON(acknowledgement, &ack) == nil && ack.Success()
+	im.keeper.OnAcknowledgementEvmCallback(ctx, callback, packet.GetSourceChannel(), packet.GetSequence(), success)
+	return nil
+}
+
+// OnTimeoutPacket implements the IBCModule interface
+func (im IBCMiddleware) OnTimeoutPacket(
+	ctx sdk.Context,
+	packet channeltypes.Packet,
+	relayer sdk.AccAddress,
+) error {
+	if err := im.app.OnTimeoutPacket(ctx, packet, relayer); err != nil {
+		return err
+	}
+
+	if callback := im.parseCallback(ctx, packet); callback != "" {
+		im.keeper.OnTimeoutEvmCallback(ctx, callback, packet.GetSourceChannel(), packet.GetSequence())
+	}
+	return nil
+}
+
+// NegotiateAppVersion implements the IBCModule interface
+func (im IBCMiddleware) NegotiateAppVersion(
+	ctx sdk.Context,
+	order channeltypes.Order,
+	connectionID string,
+	portID string,
+	counterparty channeltypes.Counterparty,
+	proposedVersion string,
+) (string, error) {
+	return im.app.NegotiateAppVersion(ctx, order, connectionID, portID, counterparty, proposedVersion)
+}
+
+// parseCallback returns the callback contract of a sent packet, or an empty string if there is none.
+// There is no callback before the Venus2 height.
+func (im IBCMiddleware) parseCallback(ctx sdk.Context, packet channeltypes.Packet) string {
+	if !tmtypes.HigherThanVenus2(ctx.BlockHeight()) {
+		return ""
+	}
+	var data ibctransferType.FungibleTokenPacketData
+	if err := ibctransferType.ModuleCdc.UnmarshalJSON(packet.GetData(), &data); err != nil {
+		return ""
+	}
+	memo, err := types.ParseIbcHooksMemo(data.Memo)
+	if err != nil {
+		im.keeper.Logger(ctx).Error("invalid ibc hooks memo of sent packet", "memo", data.Memo, "error", err)
+		return ""
+	}
+	if memo == nil {
+		return ""
+	}
+	return memo.EvmCallback
+}
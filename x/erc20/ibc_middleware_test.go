@@ -0,0 +1,281 @@
+package erc20_test
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	ibctransferType "github.com/okex/exchain/libs/ibc-go/modules/apps/transfer/types"
+	clienttypes "github.com/okex/exchain/libs/ibc-go/modules/core/02-client/types"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+	porttypes "github.com/okex/exchain/libs/ibc-go/modules/core/05-port/types"
+	ibcexported "github.com/okex/exchain/libs/ibc-go/modules/core/exported"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/erc20"
+	"github.com/okex/exchain/x/erc20/types"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+)
+
+const (
+	// counterCode increments the storage slot 0 on every call
+	counterCode = "60016000540160005500"
+	// revertCode reverts every call
+	revertCode = "60006000fd"
+	// loopCode loops until it runs out of gas
+	loopCode = "5b600056"
+)
+
+// mockTransferApp stands for the ics20 transfer module wrapped by the middleware
+type mockTransferApp struct {
+	porttypes.IBCModule
+	err error
+}
+
+func (m mockTransferApp) OnRecvPacket(sdk.Context, channeltypes.Packet, sdk.AccAddress) ibcexported.Acknowledgement {
+	if m.err != nil {
+		return channeltypes.NewErrorAcknowledgement(m.err.Error())
+	}
+	return channeltypes.NewResultAcknowledgement([]byte{byte(1)})
+}
+
+func (m mockTransferApp) OnAcknowledgementPacket(sdk.Context, channeltypes.Packet, []byte, sdk.AccAddress) error {
+	return m.err
+}
+
+func (m mockTransferApp) OnTimeoutPacket(sdk.Context, channeltypes.Packet, sdk.AccAddress) error {
+	return m.err
+}
+
+func (suite *Erc20TestSuite) deployRuntimeCode(runtimeCode string) common.Address {
+	evmParams := evmtypes.DefaultParams()
+	evmParams.EnableCreate = true
+	evmParams.EnableCall = true
+	suite.app.EvmKeeper.SetParams(suite.ctx, evmParams)
+
+	deployer := common.BigToAddress(big.NewInt(0xdeadbeef))
+	acc := suite.app.AccountKeeper.GetAccount(suite.ctx, deployer.Bytes())
+	if acc == nil {
+		acc = suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, deployer.Bytes())
+		suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+	}
+	nonce := acc.GetSequence()
+
+	runtime := common.Hex2Bytes(runtimeCode)
+	initCode := common.Hex2Bytes(fmt.Sprintf("60%02x600c60003960%02x6000f3", len(runtime), len(runtime)))
+	_, err := suite.app.EvmKeeper.CallEvm(suite.ctx, deployer, nil, big.NewInt(0), append(initCode, runtime...))
+	suite.Require().NoError(err)
+	return crypto.CreateAddress(deployer, nonce)
+}
+
+func (suite *Erc20TestSuite) counter(contract common.Address) int64 {
+	return suite.app.EvmKeeper.GetState(suite.ctx, contract, common.Hash{}).Big().Int64()
+}
+
+func newHooksPacket(data ibctransferType.FungibleTokenPacketData) channeltypes.Packet {
+	return channeltypes.NewPacket(data.GetBytes(), 1, "transfer", "channel-1", "transfer", "channel-0",
+		clienttypes.NewHeight(0, 100), 0)
+}
+
+func (suite *Erc20TestSuite) TestIBCMiddlewareOnRecvPacket() {
+	testCases := []struct {
+		msg        string
+		code       string
+		memo       func(contract common.Address) string
+		receiver   func(contract common.Address) string
+		appErr     error
+		expCounter int64
+		expPass    bool
+	}{
+		{
+			"call the contract",
+			counterCode,
+			func(contract common.Address) string {
+				return fmt.Sprintf(`{"evm":{"contract":"%s","data":"0x"}}`, contract.String())
+			},
+			func(contract common.Address) string { return sdk.AccAddress(contract.Bytes()).String() },
+			nil, 1, true,
+		},
+		{
+			"reverted call returns an error ack",
+			revertCode,
+			func(contract common.Address) string {
+				return fmt.Sprintf(`{"evm":{"contract":"%s","data":"0x"}}`, contract.String())
+			},
+			func(contract common.Address) string { return sdk.AccAddress(contract.Bytes()).String() },
+			nil, 0, false,
+		},
+		{
+			"receiver is not the contract",
+			counterCode,
+			func(contract common.Address) string {
+				return fmt.Sprintf(`{"evm":{"contract":"%s","data":"0x"}}`, contract.String())
+			},
+			func(common.Address) string {
+				return sdk.AccAddress(common.BigToAddress(big.NewInt(1)).Bytes()).String()
+			},
+			nil, 0, false,
+		},
+		{
+			"failed transfer is not followed by the call",
+			counterCode,
+			func(contract common.Address) string {
+				return fmt.Sprintf(`{"evm":{"contract":"%s","data":"0x"}}`, contract.String())
+			},
+			func(contract common.Address) string { return sdk.AccAddress(contract.Bytes()).String() },
+			errors.New("transfer failed"), 0, false,
+		},
+		{
+			"memo not addressed to the middleware",
+			counterCode,
+			func(common.Address) string { return "hello" },
+			func(contract common.Address) string { return sdk.AccAddress(contract.Bytes()).String() },
+			nil, 0, true,
+		},
+	}
+
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(1)
+	defer tmtypes.UnittestOnlySetMilestoneVenus2Height(0)
+
+	for _, tc := range testCases {
+		suite.Run(tc.msg, func() {
+			suite.SetupTest()
+			contract := suite.deployRuntimeCode(tc.code)
+			middleware := erc20.NewIBCMiddleware(mockTransferApp{err: tc.appErr}, suite.app.Erc20Keeper)
+
+			data := ibctransferType.NewFungibleTokenPacketData(
+				"transfer/channel-1/"+sdk.DefaultBondDenom, "1000000000000000000", "cosmos1sender", tc.receiver(contract))
+			data.Memo = tc.memo(contract)
+			// the mock transfer module doesn't credit the funds, so they are minted to the sender beforehand
+			sender := types.DeriveIbcHooksSender("channel-0", data.Sender)
+			coins := sdk.NewCoins(sdk.NewDecCoinFromDec(sdk.DefaultBondDenom, sdk.NewDec(1)))
+			suite.Require().NoError(suite.app.SupplyKeeper.MintCoins(suite.ctx, types.ModuleName, coins))
+			suite.Require().NoError(suite.app.SupplyKeeper.SendCoinsFromModuleToAccount(suite.ctx, types.ModuleName, sender, coins))
+
+			ack := middleware.OnRecvPacket(suite.ctx, newHooksPacket(data), sdk.AccAddress{})
+			suite.Require().Equal(tc.expPass, ack.Success())
+			suite.Require().Equal(tc.expCounter, suite.counter(contract))
+		})
+	}
+}
+
+func (suite *Erc20TestSuite) TestIBCMiddlewareCallbacks() {
+	ackBytes := channeltypes.SubModuleCdc.MustMarshalJSON(&channeltypes.Acknowledgement{
+		Response: &channeltypes.Acknowledgement_Result{Result: []byte{byte(1)}},
+	})
+
+	testCases := []struct {
+		msg        string
+		code       string
+		timeout    bool
+		appErr     error
+		expCounter int64
+		expErr     bool
+	}{
+		{"ack callback", counterCode, false, nil, 1, false},
+		{"timeout callback", counterCode, true, nil, 1, false},
+		{"reverted ack callback doesn't fail the ack", revertCode, false, nil, 0, false},
+		{"reverted timeout callback doesn't fail the refund", revertCode, true, nil, 0, false},
+		{"ack callback out of gas doesn't fail the ack", loopCode, false, nil, 0, false},
+		{"timeout callback out of gas doesn't fail the refund", loopCode, true, nil, 0, false},
+		{"failed ack of the transfer module", counterCode, false, errors.New("ack failed"), 0, true},
+		{"failed timeout of the transfer module", counterCode, true, errors.New("timeout failed"), 0, true},
+	}
+
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(1)
+	defer tmtypes.UnittestOnlySetMilestoneVenus2Height(0)
+
+	for _, tc := range testCases {
+		suite.Run(tc.msg, func() {
+			suite.SetupTest()
+			contract := suite.deployRuntimeCode(tc.code)
+			middleware := erc20.NewIBCMiddleware(mockTransferApp{err: tc.appErr}, suite.app.Erc20Keeper)
+
+			data := ibctransferType.NewFungibleTokenPacketData(sdk.DefaultBondDenom, "1", "cosmos1sender", "cosmos1receiver")
+			data.Memo = fmt.Sprintf(`{"evm_callback":"%s"}`, contract.String())
+			packet := newHooksPacket(data)
+
+			var err error
+			suite.Require().NotPanics(func() {
+				if tc.timeout {
+					err = middleware.OnTimeoutPacket(suite.ctx, packet, sdk.AccAddress{})
+				} else {
+					err = middleware.OnAcknowledgementPacket(suite.ctx, packet, ackBytes, sdk.AccAddress{})
+				}
+			})
+			if tc.expErr {
+				suite.Require().Error(err)
+			} else {
+				suite.Require().NoError(err)
+			}
+			suite.Require().Equal(tc.expCounter, suite.counter(contract))
+		})
+	}
+}
+
+func (suite *Erc20TestSuite) TestIBCMiddlewareBeforeVenus2() {
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(2)
+	defer tmtypes.UnittestOnlySetMilestoneVenus2Height(0)
+	contract := suite.deployRuntimeCode(counterCode)
+	middleware := erc20.NewIBCMiddleware(mockTransferApp{}, suite.app.Erc20Keeper)
+
+	// the receiver isn't checked against the contract and the contract isn't called
+	data := ibctransferType.NewFungibleTokenPacketData(sdk.DefaultBondDenom, "1", "cosmos1sender", "cosmos1receiver")
+	data.Memo = fmt.Sprintf(`{"evm":{"contract":"%s","data":"0x"}}`, contract.String())
+	ack := middleware.OnRecvPacket(suite.ctx, newHooksPacket(data), sdk.AccAddress{})
+	suite.Require().True(ack.Success())
+	suite.Require().Equal(int64(0), suite.counter(contract))
+
+	data.Memo = fmt.Sprintf(`{"evm_callback":"%s"}`, contract.String())
+	suite.Require().NoError(middleware.OnTimeoutPacket(suite.ctx, newHooksPacket(data), sdk.AccAddress{}))
+	suite.Require().Equal(int64(0), suite.counter(contract))
+}
+
+func (suite *Erc20TestSuite) TestIBCMiddlewareRevertsVoucher() {
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(1)
+	defer tmtypes.UnittestOnlySetMilestoneVenus2Height(0)
+
+	testCases := []struct {
+		msg     string
+		code    string
+		expPass bool
+	}{
+		{"the voucher is converted and approved to the contract", counterCode, true},
+		{"the voucher is dropped along with the reverted call", revertCode, false},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.msg, func() {
+			suite.SetupTest()
+			params := types.DefaultParams()
+			params.EnableAutoDeployment = true
+			suite.app.Erc20Keeper.SetParams(suite.ctx, params)
+			suite.app.TransferKeeper.SetParams(suite.ctx, ibctransferType.NewParams(true, true))
+			contract := suite.deployRuntimeCode(tc.code)
+			transferModule, found := suite.app.IBCKeeper.Router.GetRoute(ibctransferType.ModuleName)
+			suite.Require().True(found)
+
+			data := ibctransferType.NewFungibleTokenPacketData(
+				"uatom", "1000000000000000000", "cosmos1sender", sdk.AccAddress(contract.Bytes()).String())
+			data.Memo = fmt.Sprintf(`{"evm":{"contract":"%s","data":"0x"}}`, contract.String())
+			packet := newHooksPacket(data)
+			voucher := types.ReceivedDenom(packet, data)
+
+			// the receipt of the packet is only written with a successful ack, as the ibc handler does
+			cacheCtx, write := suite.ctx.CacheContext()
+			ack := transferModule.OnRecvPacket(cacheCtx, packet, sdk.AccAddress{})
+			if ack.Success() {
+				write()
+			}
+
+			suite.Require().Equal(tc.expPass, ack.Success())
+			_, found = suite.app.Erc20Keeper.GetContractByDenom(suite.ctx, voucher)
+			suite.Require().Equal(tc.expPass, found)
+			supply := suite.app.SupplyKeeper.GetSupply(suite.ctx).GetTotal().AmountOf(voucher)
+			suite.Require().Equal(tc.expPass, supply.IsPositive())
+			suite.Require().Equal(map[bool]int64{true: 1}[tc.expPass], suite.counter(contract))
+		})
+	}
+}
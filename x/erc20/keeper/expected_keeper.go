@@ -1,6 +1,9 @@
 package keeper
 
 import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	authexported "github.com/okex/exchain/libs/cosmos-sdk/x/auth/exported"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/params"
@@ -19,6 +22,8 @@ type GovKeeper interface {
 // AccountKeeper defines the expected account keeper interface
 type AccountKeeper interface {
 	GetAccount(ctx sdk.Context, addr sdk.AccAddress) authexported.Account
+	NewAccountWithAddress(ctx sdk.Context, addr sdk.AccAddress) authexported.Account
+	SetAccount(ctx sdk.Context, acc authexported.Account)
 }
 
 type SupplyKeeper interface {
@@ -41,6 +46,9 @@ type BankKeeper interface {
 type EvmKeeper interface {
 	GetChainConfig(ctx sdk.Context) (evmtypes.ChainConfig, bool)
 	GenerateCSDBParams() evmtypes.CommitStateDBParams
+	CallEvm(ctx sdk.Context, from common.Address, to *common.Address, value *big.Int, data []byte) ([]byte, error)
+	CallEvmWithGasLimit(ctx sdk.Context, from common.Address, to *common.Address, value *big.Int, data []byte,
+		gasLimit uint64) ([]byte, error)
 }
 
 type TransferKeeper interface {
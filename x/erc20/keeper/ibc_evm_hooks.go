@@ -0,0 +1,146 @@
+package keeper
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	"github.com/okex/exchain/x/erc20/types"
+)
+
+// OnRecvEvmCall calls the contract of the memo with the funds received by the ibc hooks sender.
+// The native token is attached as the value of the call, while the evm tokens converted from
+// vouchers are approved to the contract beforehand. The calls are bounded by the gas left to the
+// packet, which is paid by the relayer. The whole packet receipt is reverted by the caller if an
+// error is returned.
+func (k Keeper) OnRecvEvmCall(ctx sdk.Context, sender sdk.AccAddress, coin sdk.SysCoin, call types.EvmCallMemo) error {
+	k.Logger(ctx).Info("call evm contract by ibc hooks",
+		"sender", sender.String(), "contract", call.Contract, "coin", coin.String())
+
+	// the gas limit of a call includes the gas consumed by ctx, so the limit of its gas meter
+	// leaves the calls the gas left to the packet
+	gasLimit := ctx.GasMeter().Limit()
+	if gasLimit == 0 {
+		gasLimit = types.IbcHooksCallbackGasLimit
+	}
+
+	k.ensureAccount(ctx, sender)
+	from := common.BytesToAddress(sender.Bytes())
+	contract := common.HexToAddress(call.Contract)
+	amount := coin.Amount.BigInt()
+
+	value := big.NewInt(0)
+	if coin.Denom == sdk.DefaultBondDenom {
+		value = amount
+	} else {
+		token, found := k.GetContractByDenom(ctx, coin.Denom)
+		if !found {
+			return sdkerrors.Wrapf(types.ErrIbcHooksCallFailed, "no contract found for the denom %s", coin.Denom)
+		}
+		input, err := types.ModuleERC20Contract.ABI.Pack("approve", contract, amount)
+		if err != nil {
+			return err
+		}
+		if _, err := k.evmKeeper.CallEvmWithGasLimit(ctx, from, &token, big.NewInt(0), input, gasLimit); err != nil {
+			return sdkerrors.Wrapf(types.ErrIbcHooksCallFailed, "approve %s of %s: %s", coin.Denom, token.String(), err)
+		}
+	}
+
+	if _, err := k.evmKeeper.CallEvmWithGasLimit(ctx, from, &contract, value, call.Data, gasLimit); err != nil {
+		return sdkerrors.Wrapf(types.ErrIbcHooksCallFailed, "call %s: %s", contract.String(), err)
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeIbcEvmCall,
+			sdk.NewAttribute(types.AttributeKeyContract, contract.String()),
+			sdk.NewAttribute(types.AttributeKeySender, sender.String()),
+			sdk.NewAttribute(types.AttributeKeyDenom, coin.Denom),
+			sdk.NewAttribute(types.AttributeKeyAmount, coin.Amount.String()),
+		),
+	)
+	return nil
+}
+
+// OnAcknowledgementEvmCallback notifies the callback contract of the memo about the
+// acknowledgement of a sent packet. Failures of the callback are logged and never
+// affect the acknowledgement.
+func (k Keeper) OnAcknowledgementEvmCallback(ctx sdk.Context, callback string, channel string, sequence uint64, success bool) {
+	k.evmCallback(ctx, callback, channel, sequence, types.IbcHooksAckMethod, channel, sequence, success)
+}
+
+// OnTimeoutEvmCallback notifies the callback contract of the memo about the timeout of a sent packet.
+// Failures of the callback are logged and never affect the refund.
+func (k Keeper) OnTimeoutEvmCallback(ctx sdk.Context, callback string, channel string, sequence uint64) {
+	k.evmCallback(ctx, callback, channel, sequence, types.IbcHooksTimeoutMethod, channel, sequence)
+}
+
+// evmCallback calls the callback contract in a cache context with its own gas meter bounded by
+// IbcHooksCallbackGasLimit and the gas left in ctx. The gas used by the callback is charged to ctx
+// afterwards, so that neither an error, an out of gas nor a panic of the callback can fail the caller.
+func (k Keeper) evmCallback(ctx sdk.Context, callback, channel string, sequence uint64, method string, args ...interface{}) {
+	gasLimit := types.IbcHooksCallbackGasLimit
+	if limit := ctx.GasMeter().Limit(); limit > 0 {
+		if left := limit - ctx.GasMeter().GasConsumedToLimit(); left < gasLimit {
+			gasLimit = left
+		}
+	}
+	callbackGasMeter := sdk.NewGasMeter(gasLimit)
+
+	cacheCtx, commit := ctx.CacheContext()
+	cacheCtx.SetGasMeter(callbackGasMeter)
+	err := k.callEvmCallback(cacheCtx, common.HexToAddress(callback), gasLimit, method, args...)
+	ctx.GasMeter().ConsumeGas(callbackGasMeter.GasConsumedToLimit(), "ibc evm callback")
+	if err != nil {
+		k.Logger(ctx).Error(
+			fmt.Sprintf("Failed to call %s of callback contract %s for packet %s/%d. Receive error %s",
+				method, callback, channel, sequence, err))
+	} else {
+		commit()
+		ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	}
+
+	event := sdk.NewEvent(
+		types.EventTypeIbcEvmCallback,
+		sdk.NewAttribute(types.AttributeKeyContract, callback),
+		sdk.NewAttribute(types.AttributeKeyMethod, method),
+		sdk.NewAttribute(types.AttributeKeyChannel, channel),
+		sdk.NewAttribute(types.AttributeKeySequence, fmt.Sprintf("%d", sequence)),
+		sdk.NewAttribute(types.AttributeKeySuccess, fmt.Sprintf("%t", err == nil)),
+	)
+	if err != nil {
+		event = event.AppendAttributes(sdk.NewAttribute(types.AttributeKeyError, err.Error()))
+	}
+	ctx.EventManager().EmitEvent(event)
+}
+
+func (k Keeper) callEvmCallback(ctx sdk.Context, contract common.Address, gasLimit uint64, method string,
+	args ...interface{}) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch rType := r.(type) {
+			case sdk.ErrorOutOfGas:
+				err = sdkerrors.Wrapf(sdkerrors.ErrOutOfGas, "out of gas in location: %v", rType.Descriptor)
+			default:
+				err = fmt.Errorf("callback panic: %v", r)
+			}
+		}
+	}()
+
+	input, err := types.IbcHooksCallbackABI.Pack(method, args...)
+	if err != nil {
+		return err
+	}
+	k.ensureAccount(ctx, types.IbcHooksBechAddr)
+	_, err = k.evmKeeper.CallEvmWithGasLimit(ctx, types.IbcHooksETHAddr, &contract, big.NewInt(0), input, gasLimit)
+	return err
+}
+
+// ensureAccount creates the account if it doesn't exist, so that it can send evm transactions
+func (k Keeper) ensureAccount(ctx sdk.Context, addr sdk.AccAddress) {
+	if k.accountKeeper.GetAccount(ctx, addr) == nil {
+		k.accountKeeper.SetAccount(ctx, k.accountKeeper.NewAccountWithAddress(ctx, addr))
+	}
+}
@@ -0,0 +1,185 @@
+package keeper_test
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/erc20/types"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+)
+
+const (
+	// counterCode increments the storage slot 0 on every call
+	counterCode = "60016000540160005500"
+	// revertCode reverts every call
+	revertCode = "60006000fd"
+	// loopCode loops until it runs out of gas
+	loopCode = "5b600056"
+)
+
+// deployRuntimeCode deploys a contract with the given runtime code and returns its address
+func (suite *KeeperTestSuite) deployRuntimeCode(runtimeCode string) common.Address {
+	evmParams := evmtypes.DefaultParams()
+	evmParams.EnableCreate = true
+	evmParams.EnableCall = true
+	suite.app.EvmKeeper.SetParams(suite.ctx, evmParams)
+
+	deployer := common.BigToAddress(big.NewInt(0xdeadbeef))
+	acc := suite.app.AccountKeeper.GetAccount(suite.ctx, deployer.Bytes())
+	if acc == nil {
+		acc = suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, deployer.Bytes())
+		suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+	}
+	nonce := acc.GetSequence()
+
+	runtime := common.Hex2Bytes(runtimeCode)
+	// copy the runtime code following the 12 bytes of init code into memory and return it
+	initCode := common.Hex2Bytes(fmt.Sprintf("60%02x600c60003960%02x6000f3", len(runtime), len(runtime)))
+	_, err := suite.app.EvmKeeper.CallEvm(suite.ctx, deployer, nil, big.NewInt(0), append(initCode, runtime...))
+	suite.Require().NoError(err)
+
+	contract := crypto.CreateAddress(deployer, nonce)
+	suite.Require().Equal(runtime, suite.app.EvmKeeper.GetCode(suite.ctx, contract))
+	return contract
+}
+
+func (suite *KeeperTestSuite) callbackEvent() sdk.Event {
+	var found *sdk.Event
+	for _, event := range suite.ctx.EventManager().Events() {
+		if event.Type == types.EventTypeIbcEvmCallback {
+			e := event
+			found = &e
+		}
+	}
+	suite.Require().NotNil(found, "no ibc evm callback event")
+	return *found
+}
+
+func eventAttribute(event sdk.Event, key string) string {
+	for _, attr := range event.Attributes {
+		if string(attr.Key) == key {
+			return string(attr.Value)
+		}
+	}
+	return ""
+}
+
+func (suite *KeeperTestSuite) TestOnRecvEvmCall() {
+	sender := types.DeriveIbcHooksSender("channel-0", "cosmos1sender")
+	okt := sdk.NewDecCoinFromDec(sdk.DefaultBondDenom, sdk.NewDec(1))
+
+	testCases := []struct {
+		msg        string
+		code       string
+		coin       sdk.SysCoin
+		expCounter int64
+		expPass    bool
+	}{
+		{"call the contract with the received native token", counterCode, okt, 1, true},
+		{"reverted call", revertCode, okt, 0, false},
+		{"no erc20 contract for the received voucher", counterCode,
+			sdk.NewDecCoinFromDec("ibc/ddcd907790b8aa2bf9b2b3b614718fa66bfc7540e832ce3e3696ea717dceff49", sdk.NewDec(1)), 0, false},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.msg, func() {
+			suite.SetupTest()
+			contract := suite.deployRuntimeCode(tc.code)
+			suite.Require().NoError(suite.MintCoins(sender, sdk.NewCoins(tc.coin)))
+
+			cacheCtx, _ := suite.ctx.CacheContext()
+			err := suite.app.Erc20Keeper.OnRecvEvmCall(cacheCtx, sender, tc.coin, types.EvmCallMemo{Contract: contract.String()})
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Equal(common.BigToHash(big.NewInt(tc.expCounter)),
+					suite.app.EvmKeeper.GetState(cacheCtx, contract, common.Hash{}))
+				balance := suite.app.AccountKeeper.GetAccount(cacheCtx, contract.Bytes()).GetCoins()
+				suite.Require().Equal(okt.Amount, balance.AmountOf(sdk.DefaultBondDenom))
+			} else {
+				suite.Require().Error(err)
+				suite.Require().True(types.ErrIbcHooksCallFailed.Is(err))
+			}
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestEvmCallback() {
+	testCases := []struct {
+		msg        string
+		code       string
+		gasLimit   uint64
+		timeout    bool
+		expCounter int64
+		expPass    bool
+	}{
+		{"ack callback", counterCode, 0, false, 1, true},
+		{"timeout callback", counterCode, 0, true, 1, true},
+		{"reverted ack callback", revertCode, 0, false, 0, false},
+		{"reverted timeout callback", revertCode, 0, true, 0, false},
+		{"callback out of gas is bounded", loopCode, 0, false, 0, false},
+		{"not enough gas left for the callback", counterCode, 10000, false, 0, false},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.msg, func() {
+			suite.SetupTest()
+			contract := suite.deployRuntimeCode(tc.code)
+
+			gasMeter := sdk.NewInfiniteGasMeter()
+			if tc.gasLimit > 0 {
+				gasMeter = sdk.NewGasMeter(tc.gasLimit)
+			}
+			suite.ctx.SetGasMeter(gasMeter)
+			suite.ctx.SetEventManager(sdk.NewEventManager())
+
+			suite.Require().NotPanics(func() {
+				if tc.timeout {
+					suite.app.Erc20Keeper.OnTimeoutEvmCallback(suite.ctx, contract.String(), "channel-0", 1)
+				} else {
+					suite.app.Erc20Keeper.OnAcknowledgementEvmCallback(suite.ctx, contract.String(), "channel-0", 1, true)
+				}
+			})
+
+			gasConsumed := gasMeter.GasConsumed()
+			suite.Require().LessOrEqual(gasConsumed, types.IbcHooksCallbackGasLimit)
+			if tc.gasLimit > 0 {
+				suite.Require().LessOrEqual(gasConsumed, tc.gasLimit)
+			}
+
+			suite.ctx.SetGasMeter(sdk.NewInfiniteGasMeter())
+			suite.Require().Equal(common.BigToHash(big.NewInt(tc.expCounter)),
+				suite.app.EvmKeeper.GetState(suite.ctx, contract, common.Hash{}))
+
+			event := suite.callbackEvent()
+			suite.Require().Equal(fmt.Sprintf("%t", tc.expPass), eventAttribute(event, types.AttributeKeySuccess))
+			if tc.expPass {
+				suite.Require().Empty(eventAttribute(event, types.AttributeKeyError))
+			} else {
+				suite.Require().NotEmpty(eventAttribute(event, types.AttributeKeyError))
+			}
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestOnRecvEvmCallGasLimit() {
+	sender := types.DeriveIbcHooksSender("channel-0", "cosmos1sender")
+	okt := sdk.NewDecCoinFromDec(sdk.DefaultBondDenom, sdk.NewDec(1))
+	contract := suite.deployRuntimeCode(loopCode)
+	suite.Require().NoError(suite.MintCoins(sender, sdk.NewCoins(okt)))
+
+	// the call only uses the gas left to the packet
+	gasLimit := uint64(200000)
+	gasMeter := sdk.NewGasMeter(gasLimit)
+	gasMeter.ConsumeGas(50000, "packet")
+	cacheCtx, _ := suite.ctx.CacheContext()
+	cacheCtx.SetGasMeter(gasMeter)
+	var err error
+	suite.Require().NotPanics(func() {
+		err = suite.app.Erc20Keeper.OnRecvEvmCall(cacheCtx, sender, okt, types.EvmCallMemo{Contract: contract.String()})
+	})
+	suite.Require().Error(err)
+	suite.Require().Equal(gasLimit, gasMeter.GasConsumed())
+}
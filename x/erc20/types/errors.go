@@ -21,6 +21,10 @@ var (
 	// ErrEmptyAddressList returns an error if the address list is empty
 	ErrEmptyAddressList = sdkerrors.Register(ModuleName, 4, "Empty account address list")
 	ErrIbcDenomInvalid  = sdkerrors.Register(ModuleName, 5, "ibc denom is invalid")
+	// ErrInvalidIbcHooksMemo returns an error if the memo of an ics20 packet can't be interpreted by the ibc hooks
+	ErrInvalidIbcHooksMemo = sdkerrors.Register(ModuleName, 6, "invalid ibc hooks memo")
+	// ErrIbcHooksCallFailed returns an error if the contract call triggered by an ics20 packet failed
+	ErrIbcHooksCallFailed = sdkerrors.Register(ModuleName, 7, "ibc hooks contract call failed")
 )
 
 func ErrRegisteredContract(contract string) sdk.EnvelopedErr {
@@ -0,0 +1,154 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	authtypes "github.com/okex/exchain/libs/cosmos-sdk/x/auth/types"
+	ibctransferType "github.com/okex/exchain/libs/ibc-go/modules/apps/transfer/types"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+)
+
+const (
+	IbcHooksModuleName = "ibc-evm-hooks"
+
+	IbcHooksMemoKeyEvm         = "evm"
+	IbcHooksMemoKeyEvmCallback = "evm_callback"
+
+	IbcHooksAckMethod     = "ibcAck"
+	IbcHooksTimeoutMethod = "ibcTimeout"
+
+	EventTypeIbcEvmCall     = "ibc_evm_call"
+	EventTypeIbcEvmCallback = "ibc_evm_callback"
+
+	AttributeKeyContract = "contract"
+	AttributeKeySender   = "sender"
+	AttributeKeyDenom    = "denom"
+	AttributeKeyAmount   = "amount"
+	AttributeKeyMethod   = "method"
+	AttributeKeyChannel  = "channel"
+	AttributeKeySequence = "sequence"
+	AttributeKeySuccess  = "success"
+	AttributeKeyError    = "error"
+)
+
+// IbcHooksCallbackGasLimit is the gas limit of an ack or timeout callback, and of the calls on receiving
+// a packet by a context without gas limit. The callback is executed by the relayer of the packet, so it
+// must not be able to consume an arbitrary amount of gas.
+const IbcHooksCallbackGasLimit uint64 = 1000000
+
+var (
+	// IbcHooksETHAddr is the caller of the ack and timeout callbacks, contracts
+	// may check msg.sender against it to authenticate the callbacks.
+	IbcHooksETHAddr  common.Address
+	IbcHooksBechAddr sdk.AccAddress
+
+	// IbcHooksCallbackABI contains the methods a callback contract has to implement:
+	// `function ibcAck(string channel, uint64 sequence, bool success)`
+	// `function ibcTimeout(string channel, uint64 sequence)`
+	IbcHooksCallbackABI abi.ABI
+)
+
+func init() {
+	IbcHooksBechAddr = authtypes.NewModuleAddress(IbcHooksModuleName)
+	IbcHooksETHAddr = common.BytesToAddress(IbcHooksBechAddr.Bytes())
+
+	stringType, _ := abi.NewType("string", "", nil)
+	uint64Type, _ := abi.NewType("uint64", "", nil)
+	boolType, _ := abi.NewType("bool", "", nil)
+
+	IbcHooksCallbackABI = abi.ABI{Methods: map[string]abi.Method{
+		IbcHooksAckMethod: abi.NewMethod(
+			IbcHooksAckMethod, IbcHooksAckMethod, abi.Function, "nonpayable", false, false,
+			abi.Arguments{
+				{Name: "channel", Type: stringType},
+				{Name: "sequence", Type: uint64Type},
+				{Name: "success", Type: boolType},
+			},
+			nil,
+		),
+		IbcHooksTimeoutMethod: abi.NewMethod(
+			IbcHooksTimeoutMethod, IbcHooksTimeoutMethod, abi.Function, "nonpayable", false, false,
+			abi.Arguments{
+				{Name: "channel", Type: stringType},
+				{Name: "sequence", Type: uint64Type},
+			},
+			nil,
+		),
+	}}
+}
+
+// IbcHooksMemo is the json memo of an ics20 packet interpreted by the ibc hooks middleware, e.g.
+// `{"evm":{"contract":"0x...","data":"0x..."}}` calls the contract with the received funds and
+// `{"evm_callback":"0x..."}` notifies the contract about the ack or timeout of a sent packet.
+type IbcHooksMemo struct {
+	Evm         *EvmCallMemo `json:"evm,omitempty"`
+	EvmCallback string       `json:"evm_callback,omitempty"`
+}
+
+// EvmCallMemo defines the contract call executed on receiving an ics20 packet
+type EvmCallMemo struct {
+	Contract string        `json:"contract"`
+	Data     hexutil.Bytes `json:"data"`
+}
+
+// ParseIbcHooksMemo parses the memo of an ics20 packet. A nil memo is returned without error
+// if the memo is not a json object or not addressed to the ibc hooks middleware.
+func ParseIbcHooksMemo(memo string) (*IbcHooksMemo, error) {
+	if len(strings.TrimSpace(memo)) == 0 {
+		return nil, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(memo), &fields); err != nil {
+		return nil, nil
+	}
+	_, hasEvm := fields[IbcHooksMemoKeyEvm]
+	_, hasCallback := fields[IbcHooksMemoKeyEvmCallback]
+	if !hasEvm && !hasCallback {
+		return nil, nil
+	}
+
+	var hooksMemo IbcHooksMemo
+	if err := json.Unmarshal([]byte(memo), &hooksMemo); err != nil {
+		return nil, sdkerrors.Wrap(ErrInvalidIbcHooksMemo, err.Error())
+	}
+	if hasEvm {
+		if hooksMemo.Evm == nil || !common.IsHexAddress(hooksMemo.Evm.Contract) {
+			return nil, sdkerrors.Wrapf(ErrInvalidIbcHooksMemo, "invalid contract in %s memo", IbcHooksMemoKeyEvm)
+		}
+	}
+	if hasCallback && !common.IsHexAddress(hooksMemo.EvmCallback) {
+		return nil, sdkerrors.Wrapf(ErrInvalidIbcHooksMemo, "invalid contract in %s memo", IbcHooksMemoKeyEvmCallback)
+	}
+	return &hooksMemo, nil
+}
+
+// DeriveIbcHooksSender returns the address that receives the funds of an ics20 packet and calls
+// the contract on behalf of the original sender, so that it can't be spoofed by local accounts.
+func DeriveIbcHooksSender(channel, originalSender string) sdk.AccAddress {
+	return authtypes.NewModuleAddress(fmt.Sprintf("%s/%s/%s", IbcHooksModuleName, channel, originalSender))
+}
+
+// ReceivedDenom returns the denom of the coins credited on this chain when receiving the packet
+func ReceivedDenom(packet channeltypes.Packet, data ibctransferType.FungibleTokenPacketData) string {
+	if ibctransferType.ReceiverChainIsSource(packet.GetSourcePort(), packet.GetSourceChannel(), data.Denom) {
+		voucherPrefix := ibctransferType.GetDenomPrefix(packet.GetSourcePort(), packet.GetSourceChannel())
+		unprefixedDenom := data.Denom[len(voucherPrefix):]
+
+		denomTrace := ibctransferType.ParseDenomTrace(unprefixedDenom)
+		if denomTrace.Path != "" {
+			return denomTrace.IBCDenom()
+		}
+		return unprefixedDenom
+	}
+
+	sourcePrefix := ibctransferType.GetDenomPrefix(packet.GetDestPort(), packet.GetDestChannel())
+	return ibctransferType.ParseDenomTrace(sourcePrefix + data.Denom).IBCDenom()
+}
@@ -0,0 +1,52 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseIbcHooksMemo(t *testing.T) {
+	contract := "0x2ca6c5B4E2BB8a8dC05A3B8C0b9A5f7d7C7B8fA1"
+	tests := []struct {
+		name     string
+		memo     string
+		isNil    bool
+		success  bool
+		callback string
+	}{
+		{"empty memo", "", true, true, ""},
+		{"plain text memo", "hello", true, true, ""},
+		{"json memo of other middleware", `{"wasm":{"contract":"abc"}}`, true, true, ""},
+		{"evm call", `{"evm":{"contract":"` + contract + `","data":"0x01"}}`, false, true, ""},
+		{"evm callback", `{"evm_callback":"` + contract + `"}`, false, true, contract},
+		{"invalid evm contract", `{"evm":{"contract":"okexchain1abc","data":"0x01"}}`, false, false, ""},
+		{"missing evm contract", `{"evm":null}`, false, false, ""},
+		{"invalid evm data", `{"evm":{"contract":"` + contract + `","data":"01"}}`, false, false, ""},
+		{"invalid evm callback", `{"evm_callback":"0x01"}`, false, false, ""},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			memo, err := ParseIbcHooksMemo(tt.memo)
+			if !tt.success {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.isNil {
+				require.Nil(t, memo)
+				return
+			}
+			require.NotNil(t, memo)
+			require.Equal(t, tt.callback, memo.EvmCallback)
+		})
+	}
+}
+
+func Test_DeriveIbcHooksSender(t *testing.T) {
+	sender := "cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du"
+	require.Equal(t, DeriveIbcHooksSender("channel-0", sender), DeriveIbcHooksSender("channel-0", sender))
+	require.NotEqual(t, DeriveIbcHooksSender("channel-0", sender), DeriveIbcHooksSender("channel-1", sender))
+	require.NotEqual(t, DeriveIbcHooksSender("channel-0", sender), IbcHooksBechAddr)
+}
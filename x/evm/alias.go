@@ -17,10 +17,14 @@ const (
 
 // nolint
 var (
-	NewKeeper              = keeper.NewKeeper
-	TxDecoder              = types.TxDecoder
-	NewSimulateKeeper      = keeper.NewSimulateKeeper
-	NewLogProcessEvmHook   = keeper.NewLogProcessEvmHook
+	NewKeeper                  = keeper.NewKeeper
+	TxDecoder                  = types.TxDecoder
+	NewSimulateKeeper          = keeper.NewSimulateKeeper
+	NewLogProcessEvmHook       = keeper.NewLogProcessEvmHook
+	NewMultiEvmHooks           = keeper.NewMultiEvmHooks
+	NewNativePrecompile        = types.NewNativePrecompile
+	RegisterStatefulPrecompile = types.RegisterStatefulPrecompile
+	NativePrecompileAddress    = types.NativePrecompileAddress
 )
 
 //nolint
@@ -105,7 +105,7 @@ func (k Keeper) EndBlock(ctx sdk.Context, req abci.RequestEndBlock) []abci.Valid
 		k.Watcher.SaveBlock(bloom)
 	}
 
-	k.UpdateInnerBlockData()
+	k.UpdateInnerBlockData(ctx)
 
 	return []abci.ValidatorUpdate{}
 }
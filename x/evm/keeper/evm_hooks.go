@@ -9,8 +9,9 @@ import (
 )
 
 var (
-	_ types.EvmHooks = MultiEvmHooks{}
-	_ types.EvmHooks = LogProcessEvmHook{}
+	_ types.EvmHooks   = MultiEvmHooks{}
+	_ types.EvmTxHooks = MultiEvmHooks{}
+	_ types.EvmHooks   = LogProcessEvmHook{}
 )
 
 // MultiEvmHooks combine multiple evm hooks, all hook functions are run in array sequence
@@ -31,6 +32,23 @@ func (mh MultiEvmHooks) PostTxProcessing(ctx sdk.Context, from common.Address, t
 	return nil
 }
 
+// PostTxProcessingWithContext delegate the call to underlying hooks, the hooks without the tx context
+// are only called for the successful txs
+func (mh MultiEvmHooks) PostTxProcessingWithContext(ctx sdk.Context, txCtx *types.EvmTxContext, receipt *ethtypes.Receipt) error {
+	for i := range mh {
+		var err error
+		if txHooks, ok := mh[i].(types.EvmTxHooks); ok {
+			err = txHooks.PostTxProcessingWithContext(ctx, txCtx, receipt)
+		} else if receipt.Status == ethtypes.ReceiptStatusSuccessful {
+			err = mh[i].PostTxProcessing(ctx, txCtx.From, txCtx.To, receipt)
+		}
+		if err != nil {
+			return sdkerror.Wrapf(err, "EVM hook %T failed", mh[i])
+		}
+	}
+	return nil
+}
+
 // LogProcessEvmHook is an evm hook that convert specific contract logs into native module calls
 type LogProcessEvmHook struct {
 	handlers map[common.Hash]types.EvmLogHandler
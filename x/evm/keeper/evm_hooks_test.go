@@ -78,3 +78,49 @@ func (suite *KeeperTestSuite) TestEvmHooks() {
 		tc.expFunc(hook, result)
 	}
 }
+
+// TxRecordHook records the receipts of all the txs
+type TxRecordHook struct {
+	Receipts []*ethtypes.Receipt
+}
+
+func (th *TxRecordHook) PostTxProcessing(ctx sdk.Context, from common.Address, to *common.Address, receipt *ethtypes.Receipt) error {
+	return errors.New("the hook with the tx context is expected")
+}
+
+func (th *TxRecordHook) PostTxProcessingWithContext(ctx sdk.Context, txCtx *types.EvmTxContext, receipt *ethtypes.Receipt) error {
+	th.Receipts = append(th.Receipts, receipt)
+	return nil
+}
+
+func (suite *KeeperTestSuite) TestEvmTxHooks() {
+	suite.SetupTest()
+	logHook := &LogRecordHook{}
+	txHook := &TxRecordHook{}
+	suite.app.EvmKeeper.ResetHooks()
+	suite.app.EvmKeeper.SetHooks(keeper.NewMultiEvmHooks(logHook, txHook))
+
+	txCtx := &types.EvmTxContext{From: suite.address}
+	failed := &ethtypes.Receipt{
+		Status: ethtypes.ReceiptStatusFailed,
+		Logs:   []*ethtypes.Log{{Address: suite.address}},
+	}
+	suite.Require().NoError(suite.app.EvmKeeper.CallEvmTxHooks(suite.ctx, txCtx, failed))
+	// the failed txs are only passed to the hooks with the tx context
+	suite.Require().Equal(0, len(logHook.Logs))
+	suite.Require().Equal(1, len(txHook.Receipts))
+
+	successful := &ethtypes.Receipt{
+		Status: ethtypes.ReceiptStatusSuccessful,
+		Logs:   []*ethtypes.Log{{Address: suite.address}},
+	}
+	suite.Require().NoError(suite.app.EvmKeeper.CallEvmTxHooks(suite.ctx, txCtx, successful))
+	suite.Require().Equal(1, len(logHook.Logs))
+	suite.Require().Equal(2, len(txHook.Receipts))
+
+	// the hooks without the tx context are called for the successful txs only
+	suite.app.EvmKeeper.ResetHooks()
+	suite.app.EvmKeeper.SetHooks(FailureHook{})
+	suite.Require().NoError(suite.app.EvmKeeper.CallEvmTxHooks(suite.ctx, txCtx, failed))
+	suite.Require().Error(suite.app.EvmKeeper.CallEvmTxHooks(suite.ctx, txCtx, successful))
+}
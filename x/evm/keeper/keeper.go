@@ -50,7 +50,7 @@ type Keeper struct {
 	LogsManages *LogsManager
 
 	// add inner block data
-	innerBlockData BlockInnerData
+	innerBlockData *BlockInnerData
 
 	// cache chain config
 	cci *chainConfigInfo
@@ -349,3 +349,18 @@ func (k *Keeper) CallEvmHooks(ctx sdk.Context, from common.Address, to *common.A
 	}
 	return k.hooks.PostTxProcessing(ctx, from, to, receipt)
 }
+
+// CallEvmTxHooks delegate the call with the tx context to the hooks, the hooks without the tx context are only called
+// for the successful txs. If no hook has been registered, this function returns with a `nil` error
+func (k *Keeper) CallEvmTxHooks(ctx sdk.Context, txCtx *types.EvmTxContext, receipt *ethtypes.Receipt) error {
+	if k.hooks == nil {
+		return nil
+	}
+	if txHooks, ok := k.hooks.(types.EvmTxHooks); ok {
+		return txHooks.PostTxProcessingWithContext(ctx, txCtx, receipt)
+	}
+	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+		return nil
+	}
+	return k.hooks.PostTxProcessing(ctx, txCtx.From, txCtx.To, receipt)
+}
@@ -0,0 +1,68 @@
+package keeper
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethermint "github.com/okex/exchain/app/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	"github.com/okex/exchain/x/evm/types"
+)
+
+// CallEvm executes an evm call, or a contract creation when to is nil, on behalf of the sender from a native module.
+// The nonce of sender is increased after the execution just like the ante handler does for an ethereum tx.
+func (k *Keeper) CallEvm(ctx sdk.Context, from common.Address, to *common.Address, value *big.Int, data []byte) ([]byte, error) {
+	return k.CallEvmWithGasLimit(ctx, from, to, value, data, types.DefaultMaxGasLimitPerTx)
+}
+
+// CallEvmWithGasLimit is the same as CallEvm, but the execution is bounded by gasLimit, including
+// the gas already consumed by the gas meter of ctx.
+func (k *Keeper) CallEvmWithGasLimit(ctx sdk.Context, from common.Address, to *common.Address, value *big.Int, data []byte,
+	gasLimit uint64) ([]byte, error) {
+	config, found := k.GetChainConfig(ctx)
+	if !found {
+		return nil, types.ErrChainConfigNotFound
+	}
+
+	chainIDEpoch, err := ethermint.ParseChainID(ctx.ChainID())
+	if err != nil {
+		return nil, err
+	}
+
+	acc := k.accountKeeper.GetAccount(ctx, from.Bytes())
+	if acc == nil {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownAddress, "account %s does not exist", from.String())
+	}
+
+	st := types.StateTransition{
+		AccountNonce: acc.GetSequence(),
+		Price:        big.NewInt(0),
+		GasLimit:     gasLimit,
+		Recipient:    to,
+		Amount:       value,
+		Payload:      data,
+		Csdb:         types.CreateEmptyCommitStateDB(k.GenerateCSDBParams(), ctx),
+		ChainID:      chainIDEpoch,
+		TxHash:       &common.Hash{},
+		Sender:       from,
+		Simulate:     ctx.IsCheckTx(),
+	}
+
+	_, resultData, err, _, _ := st.TransitionDb(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	// the account might be updated by the state transition, or deleted if it was empty
+	acc = k.accountKeeper.GetAccount(ctx, from.Bytes())
+	if acc == nil {
+		acc = k.accountKeeper.NewAccountWithAddress(ctx, from.Bytes())
+	}
+	if err := acc.SetSequence(acc.GetSequence() + 1); err != nil {
+		return nil, err
+	}
+	k.accountKeeper.SetAccount(ctx, acc)
+
+	return resultData.Ret, nil
+}
@@ -1,22 +1,78 @@
 package keeper
 
+import (
+	"sync"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/evm/types"
+)
+
 func initInnerDB() error {
-	return nil
+	if !types.GetEnableInnerTx() {
+		return nil
+	}
+	return types.InitInnerTxDB()
 }
 
-type BlockInnerData = interface{}
+// BlockInnerData caches the inner txs of the evm txs delivered in the current block,
+// they are flushed to the inner tx db at the end of the block
+type BlockInnerData struct {
+	mtx      sync.Mutex
+	txHashes []ethcmn.Hash
+	innerTxs map[ethcmn.Hash][]*types.InnerTx
+}
 
-func defaultBlockInnerData() BlockInnerData {
-	return nil
+func defaultBlockInnerData() *BlockInnerData {
+	return &BlockInnerData{
+		innerTxs: make(map[ethcmn.Hash][]*types.InnerTx),
+	}
 }
 
 // InitInnerBlock init inner block data
-func (k *Keeper) InitInnerBlock(...interface{}) {}
+func (k *Keeper) InitInnerBlock(...interface{}) {
+	if !types.GetEnableInnerTx() {
+		return
+	}
+	k.innerBlockData = defaultBlockInnerData()
+}
+
+// UpdateInnerBlockData writes the inner txs of the block to the inner tx db
+func (k *Keeper) UpdateInnerBlockData(ctx sdk.Context) {
+	if !types.GetEnableInnerTx() {
+		return
+	}
 
-func (k *Keeper) UpdateInnerBlockData(...interface{}) {}
+	data := k.innerBlockData
+	data.mtx.Lock()
+	txs := make([]*types.TxInnerTxs, len(data.txHashes))
+	for i, txHash := range data.txHashes {
+		txs[i] = &types.TxInnerTxs{TxHash: txHash, InnerTxs: data.innerTxs[txHash]}
+	}
+	data.mtx.Unlock()
+
+	if err := types.WriteBlockInnerTxs(ctx.BlockHeight(), txs); err != nil {
+		k.Logger(ctx).Error("failed to write inner txs", "height", ctx.BlockHeight(), "error", err)
+	}
+	k.innerBlockData = defaultBlockInnerData()
+}
 
 // AddInnerTx add inner tx
-func (k *Keeper) AddInnerTx(...interface{}) {}
+func (k *Keeper) AddInnerTx(txHash ethcmn.Hash, innerTxs interface{}) {
+	txs, ok := innerTxs.([]*types.InnerTx)
+	if !ok || len(txs) == 0 {
+		return
+	}
+
+	data := k.innerBlockData
+	data.mtx.Lock()
+	defer data.mtx.Unlock()
+	// a tx re-executed by the parallel execution replaces the inner txs of its former execution
+	if _, found := data.innerTxs[txHash]; !found {
+		data.txHashes = append(data.txHashes, txHash)
+	}
+	data.innerTxs[txHash] = txs
+}
 
 // AddContract add erc20 contract
 func (k *Keeper) AddContract(...interface{}) {}
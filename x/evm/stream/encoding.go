@@ -0,0 +1,163 @@
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/okex/exchain/x/evm/types"
+)
+
+const (
+	// FormatJSON encodes a block as a line of json
+	FormatJSON = "json"
+	// FormatProtobuf encodes a block as a varint length prefixed protobuf message, see stream.proto
+	FormatProtobuf = "protobuf"
+)
+
+// EncodeBlock encodes a block in the given format for the file and unix socket sinks
+func EncodeBlock(format string, block *Block) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		bz, err := json.Marshal(block)
+		if err != nil {
+			return nil, err
+		}
+		return append(bz, '\n'), nil
+	case FormatProtobuf:
+		bz := MarshalBlockProto(block)
+		return append(protowire.AppendVarint(nil, uint64(len(bz))), bz...), nil
+	default:
+		return nil, fmt.Errorf("unsupported evm stream format %s", format)
+	}
+}
+
+// MarshalBlockProto encodes a block as the Block message of stream.proto
+func MarshalBlockProto(block *Block) []byte {
+	var bz []byte
+	bz = appendUint64(bz, 1, uint64(block.Height))
+	bz = appendBytes(bz, 2, block.Hash.Bytes())
+	bz = appendUint64(bz, 3, uint64(block.Time))
+	bz = appendBytes(bz, 4, block.Proposer)
+	for _, tx := range block.Txs {
+		bz = appendMessage(bz, 5, marshalTxProto(tx))
+	}
+	return bz
+}
+
+func marshalTxProto(tx *Tx) []byte {
+	var bz []byte
+	bz = appendBytes(bz, 1, tx.Hash.Bytes())
+	bz = appendUint64(bz, 2, uint64(tx.Index))
+	bz = appendBytes(bz, 3, tx.From.Bytes())
+	if tx.To != nil {
+		bz = appendBytes(bz, 4, tx.To.Bytes())
+	}
+	bz = appendUint64(bz, 5, uint64(tx.Nonce))
+	bz = appendBytes(bz, 6, bigBytes(tx.Value.ToInt()))
+	bz = appendBytes(bz, 7, bigBytes(tx.GasPrice.ToInt()))
+	bz = appendUint64(bz, 8, uint64(tx.GasLimit))
+	bz = appendBytes(bz, 9, tx.Input)
+	bz = appendUint64(bz, 10, uint64(tx.Status))
+	bz = appendUint64(bz, 11, uint64(tx.GasUsed))
+	if tx.ContractAddress != nil {
+		bz = appendBytes(bz, 12, tx.ContractAddress.Bytes())
+	}
+	for _, log := range tx.Logs {
+		bz = appendMessage(bz, 13, marshalLogProto(log))
+	}
+	for _, accDiff := range tx.StateDiff {
+		bz = appendMessage(bz, 14, marshalAccountDiffProto(accDiff))
+	}
+	return bz
+}
+
+func marshalLogProto(log *Log) []byte {
+	var bz []byte
+	bz = appendBytes(bz, 1, log.Address.Bytes())
+	for _, topic := range log.Topics {
+		bz = protowire.AppendTag(bz, 2, protowire.BytesType)
+		bz = protowire.AppendBytes(bz, topic.Bytes())
+	}
+	bz = appendBytes(bz, 3, log.Data)
+	bz = appendUint64(bz, 4, uint64(log.Index))
+	return bz
+}
+
+func marshalAccountDiffProto(accDiff *types.AccountDiff) []byte {
+	var bz []byte
+	bz = appendBytes(bz, 1, accDiff.Address.Bytes())
+	bz = appendBytes(bz, 2, bigBytes(accDiff.BalanceBefore.ToInt()))
+	bz = appendBytes(bz, 3, bigBytes(accDiff.BalanceAfter.ToInt()))
+	bz = appendUint64(bz, 4, uint64(accDiff.NonceBefore))
+	bz = appendUint64(bz, 5, uint64(accDiff.NonceAfter))
+	bz = appendBytes(bz, 6, accDiff.Code)
+	if accDiff.Deleted {
+		bz = appendUint64(bz, 7, 1)
+	}
+	for _, storage := range accDiff.Storage {
+		var storageBz []byte
+		storageBz = appendBytes(storageBz, 1, storage.Key.Bytes())
+		storageBz = appendBytes(storageBz, 2, storage.Before.Bytes())
+		storageBz = appendBytes(storageBz, 3, storage.After.Bytes())
+		bz = appendMessage(bz, 8, storageBz)
+	}
+	return bz
+}
+
+// unmarshalSubscribeRequest decodes the SubscribeRequest message of stream.proto
+func unmarshalSubscribeRequest(bz []byte) (fromHeight int64, err error) {
+	for len(bz) > 0 {
+		num, typ, n := protowire.ConsumeTag(bz)
+		if n < 0 {
+			return 0, protowire.ParseError(n)
+		}
+		bz = bz[n:]
+		if num == 1 && typ == protowire.VarintType {
+			v, m := protowire.ConsumeVarint(bz)
+			if m < 0 {
+				return 0, protowire.ParseError(m)
+			}
+			fromHeight = int64(v)
+			bz = bz[m:]
+			continue
+		}
+		m := protowire.ConsumeFieldValue(num, typ, bz)
+		if m < 0 {
+			return 0, protowire.ParseError(m)
+		}
+		bz = bz[m:]
+	}
+	return fromHeight, nil
+}
+
+// the default values are omitted as proto3 does
+func appendUint64(bz []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return bz
+	}
+	bz = protowire.AppendTag(bz, num, protowire.VarintType)
+	return protowire.AppendVarint(bz, v)
+}
+
+func appendBytes(bz []byte, num protowire.Number, v []byte) []byte {
+	if len(v) == 0 {
+		return bz
+	}
+	bz = protowire.AppendTag(bz, num, protowire.BytesType)
+	return protowire.AppendBytes(bz, v)
+}
+
+func appendMessage(bz []byte, num protowire.Number, msg []byte) []byte {
+	bz = protowire.AppendTag(bz, num, protowire.BytesType)
+	return protowire.AppendBytes(bz, msg)
+}
+
+func bigBytes(v *big.Int) []byte {
+	if v == nil {
+		return nil
+	}
+	return v.Bytes()
+}
@@ -0,0 +1,184 @@
+package stream
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	grpcServiceName = "exchain.evm.stream.v1.EvmStream"
+)
+
+type server interface {
+	stop()
+}
+
+// unixServer streams the blocks to the clients of a unix socket. A client writes the height to replay the blocks from
+// in a line at first, the blocks are written in the format of the streamer then
+type unixServer struct {
+	streamer *Streamer
+	listener net.Listener
+}
+
+func newUnixServer(streamer *Streamer, path string) (*unixServer, error) {
+	// remove the socket left by the last run
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	srv := &unixServer{streamer: streamer, listener: listener}
+	go srv.serve()
+	return srv, nil
+}
+
+func (srv *unixServer) serve() {
+	for {
+		conn, err := srv.listener.Accept()
+		if err != nil {
+			select {
+			case <-srv.streamer.quit:
+			default:
+				srv.streamer.logger.Error("evm stream unix socket stopped", "error", err)
+			}
+			return
+		}
+		go srv.handle(conn)
+	}
+}
+
+func (srv *unixServer) handle(conn net.Conn) {
+	defer conn.Close()
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return
+	}
+	var fromHeight int64
+	if line = strings.TrimSpace(line); line != "" {
+		if fromHeight, err = strconv.ParseInt(line, 10, 64); err != nil {
+			fmt.Fprintf(conn, "invalid height %s\n", line)
+			return
+		}
+	}
+	sub, err := srv.streamer.Subscribe(fromHeight)
+	if err != nil {
+		fmt.Fprintln(conn, err.Error())
+		return
+	}
+	defer sub.Unsubscribe()
+
+	for block := range sub.Blocks() {
+		bz, err := EncodeBlock(srv.streamer.format, block)
+		if err != nil {
+			srv.streamer.logger.Error("failed to encode the evm stream block", "height", block.Height, "error", err)
+			return
+		}
+		if _, err = conn.Write(bz); err != nil {
+			return
+		}
+	}
+}
+
+func (srv *unixServer) stop() {
+	srv.listener.Close()
+}
+
+// grpcServer streams the blocks by the EvmStream service of stream.proto
+type grpcServer struct {
+	server *grpc.Server
+}
+
+// rawCodec passes the messages encoded by the streamer through
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	bz, ok := v.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected message type %T", v)
+	}
+	return bz, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	bz, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", v)
+	}
+	*bz = append((*bz)[:0], data...)
+	return nil
+}
+
+func (rawCodec) Name() string {
+	return "proto"
+}
+
+var grpcServiceDesc = grpc.ServiceDesc{
+	ServiceName: grpcServiceName,
+	HandlerType: (*interface{})(nil),
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       grpcSubscribeHandler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "x/evm/stream/stream.proto",
+}
+
+func grpcSubscribeHandler(srv interface{}, stream grpc.ServerStream) error {
+	var req []byte
+	if err := stream.RecvMsg(&req); err != nil {
+		return err
+	}
+	fromHeight, err := unmarshalSubscribeRequest(req)
+	if err != nil {
+		return err
+	}
+	sub, err := srv.(*Streamer).Subscribe(fromHeight)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case block, ok := <-sub.Blocks():
+			if !ok {
+				return nil
+			}
+			if err = stream.SendMsg(MarshalBlockProto(block)); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+func newGrpcServer(streamer *Streamer, addr string) (*grpcServer, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	srv := &grpcServer{server: grpc.NewServer(grpc.ForceServerCodec(rawCodec{}))}
+	srv.server.RegisterService(&grpcServiceDesc, streamer)
+	go func() {
+		if err := srv.server.Serve(listener); err != nil {
+			streamer.logger.Error("evm stream grpc server stopped", "error", err)
+		}
+	}()
+	return srv, nil
+}
+
+func (srv *grpcServer) stop() {
+	srv.server.Stop()
+}
@@ -0,0 +1,90 @@
+package stream
+
+import (
+	"encoding/binary"
+	"encoding/json"
+
+	dbm "github.com/okex/exchain/libs/tm-db"
+)
+
+var (
+	blockKeyPrefix = []byte{0x01}
+)
+
+// store keeps the streamed blocks by height for the replay of the subscriptions
+type store struct {
+	db dbm.DB
+}
+
+func blockKey(height int64) []byte {
+	key := make([]byte, len(blockKeyPrefix)+8)
+	copy(key, blockKeyPrefix)
+	binary.BigEndian.PutUint64(key[len(blockKeyPrefix):], uint64(height))
+	return key
+}
+
+func heightFromKey(key []byte) int64 {
+	return int64(binary.BigEndian.Uint64(key[len(blockKeyPrefix):]))
+}
+
+// heightRange returns the earliest and the latest heights in the store, they are 0 if the store is empty
+func (s store) heightRange() (earliest, latest int64, err error) {
+	it, err := s.db.Iterator(blockKey(0), blockKey(-1))
+	if err != nil {
+		return 0, 0, err
+	}
+	if it.Valid() {
+		earliest = heightFromKey(it.Key())
+	}
+	it.Close()
+
+	rit, err := s.db.ReverseIterator(blockKey(0), blockKey(-1))
+	if err != nil {
+		return 0, 0, err
+	}
+	if rit.Valid() {
+		latest = heightFromKey(rit.Key())
+	}
+	rit.Close()
+	return earliest, latest, nil
+}
+
+func (s store) getBlock(height int64) (*Block, error) {
+	bz, err := s.db.Get(blockKey(height))
+	if err != nil || bz == nil {
+		return nil, err
+	}
+	block := &Block{}
+	if err = json.Unmarshal(bz, block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+func (s store) setBlock(height int64, block *Block) error {
+	bz, err := json.Marshal(block)
+	if err != nil {
+		return err
+	}
+	return s.db.SetSync(blockKey(height), bz)
+}
+
+// prune deletes the blocks lower than the given height
+func (s store) prune(height int64) error {
+	it, err := s.db.Iterator(blockKey(0), blockKey(height))
+	if err != nil {
+		return err
+	}
+	var keys [][]byte
+	for ; it.Valid(); it.Next() {
+		keys = append(keys, it.Key())
+	}
+	it.Close()
+
+	for _, key := range keys {
+		if err = s.db.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,309 @@
+package stream
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/okex/exchain/x/evm/types"
+	"github.com/spf13/viper"
+)
+
+const (
+	FlagEvmStreamFile         = "evm-stream-file"
+	FlagEvmStreamUnix         = "evm-stream-unix"
+	FlagEvmStreamGrpc         = "evm-stream-grpc"
+	FlagEvmStreamFormat       = "evm-stream-format"
+	FlagEvmStreamRetainBlocks = "evm-stream-retain-blocks"
+
+	streamDir = "evmstream"
+
+	subscriptionBufferSize = 64
+)
+
+var _ types.EvmTxHooks = &Streamer{}
+
+// Streamer is an evm hook which streams the committed evm txs with their receipts, state diffs and block contexts
+// to the file, unix socket and grpc sinks in commit order. The streamed blocks are kept in a local db, so that the
+// subscriptions can replay them from a height on reconnect
+type Streamer struct {
+	logger       log.Logger
+	store        store
+	format       string
+	retainBlocks int64
+	file         *os.File
+	servers      []server
+
+	// the txs delivered in the current block by hash, the txs re-executed by the parallel execution replace the
+	// previous results
+	pendingMtx sync.Mutex
+	pending    map[common.Hash]*Tx
+
+	headMtx  sync.RWMutex
+	earliest int64
+	latest   int64
+	notify   chan struct{}
+
+	quit     chan struct{}
+	stopOnce sync.Once
+}
+
+// Subscription receives the streamed blocks from a height
+type Subscription struct {
+	blocks chan *Block
+	quit   chan struct{}
+	once   sync.Once
+}
+
+// Blocks returns the channel of the blocks, which is closed if the subscription or the streamer is stopped
+func (sub *Subscription) Blocks() <-chan *Block {
+	return sub.blocks
+}
+
+// Unsubscribe stops the subscription
+func (sub *Subscription) Unsubscribe() {
+	sub.once.Do(func() {
+		close(sub.quit)
+	})
+}
+
+// NewStreamer opens the local db of the streamed blocks under the data dir of the node and starts the sinks
+// configured by the flags
+func NewStreamer(logger log.Logger) (*Streamer, error) {
+	dataDir := filepath.Join(viper.GetString("home"), "data")
+	db, err := sdk.NewLevelDB(streamDir, dataDir)
+	if err != nil {
+		return nil, err
+	}
+	s := &Streamer{
+		logger:       logger.With("module", "evm-stream"),
+		store:        store{db: db},
+		format:       viper.GetString(FlagEvmStreamFormat),
+		retainBlocks: viper.GetInt64(FlagEvmStreamRetainBlocks),
+		pending:      make(map[common.Hash]*Tx),
+		notify:       make(chan struct{}),
+		quit:         make(chan struct{}),
+	}
+	if s.format == "" {
+		s.format = FormatJSON
+	}
+	if s.format != FormatJSON && s.format != FormatProtobuf {
+		db.Close()
+		return nil, fmt.Errorf("unsupported evm stream format %s", s.format)
+	}
+	if s.earliest, s.latest, err = s.store.heightRange(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	if err = s.startSinks(); err != nil {
+		s.Stop()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Streamer) startSinks() error {
+	if path := viper.GetString(FlagEvmStreamFile); path != "" {
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		s.file = file
+	}
+	if path := viper.GetString(FlagEvmStreamUnix); path != "" {
+		srv, err := newUnixServer(s, path)
+		if err != nil {
+			return err
+		}
+		s.servers = append(s.servers, srv)
+	}
+	if addr := viper.GetString(FlagEvmStreamGrpc); addr != "" {
+		srv, err := newGrpcServer(s, addr)
+		if err != nil {
+			return err
+		}
+		s.servers = append(s.servers, srv)
+	}
+	return nil
+}
+
+// Stop stops the sinks and the subscriptions, and closes the local db
+func (s *Streamer) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.quit)
+		for _, srv := range s.servers {
+			srv.stop()
+		}
+		if s.file != nil {
+			s.file.Close()
+		}
+		s.store.db.Close()
+	})
+}
+
+// PostTxProcessing does nothing, the txs are received by PostTxProcessingWithContext
+func (s *Streamer) PostTxProcessing(sdk.Context, common.Address, *common.Address, *ethtypes.Receipt) error {
+	return nil
+}
+
+// PostTxProcessingWithContext records the delivered tx, it never returns an error to not revert the tx
+func (s *Streamer) PostTxProcessingWithContext(ctx sdk.Context, txCtx *types.EvmTxContext, receipt *ethtypes.Receipt) error {
+	if ctx.IsCheckTx() || ctx.IsTraceTx() || ctx.IsTraceTxLog() {
+		return nil
+	}
+	tx := newTx(txCtx, receipt)
+
+	s.pendingMtx.Lock()
+	s.pending[tx.Hash] = tx
+	s.pendingMtx.Unlock()
+	return nil
+}
+
+// Commit streams the txs delivered in the committed block, it must be called in the order of the blocks committed
+func (s *Streamer) Commit(blockCtx BlockContext) {
+	s.pendingMtx.Lock()
+	pending := s.pending
+	s.pending = make(map[common.Hash]*Tx)
+	s.pendingMtx.Unlock()
+
+	// the blocks replayed by the node on start may have been streamed
+	if blockCtx.Height <= s.latestHeight() {
+		return
+	}
+
+	block := &Block{
+		Height:   hexutil.Uint64(blockCtx.Height),
+		Hash:     blockCtx.Hash,
+		Time:     hexutil.Uint64(blockCtx.Time),
+		Proposer: blockCtx.Proposer,
+		Txs:      make([]*Tx, 0, len(pending)),
+	}
+	for _, tx := range pending {
+		block.Txs = append(block.Txs, tx)
+	}
+	sort.SliceStable(block.Txs, func(i, j int) bool {
+		return block.Txs[i].receiptIndex < block.Txs[j].receiptIndex
+	})
+	for i, tx := range block.Txs {
+		tx.Index = hexutil.Uint64(i)
+	}
+
+	if err := s.store.setBlock(blockCtx.Height, block); err != nil {
+		s.logger.Error("failed to store the evm stream block", "height", blockCtx.Height, "error", err)
+		return
+	}
+	earliest := s.earliestHeight()
+	if earliest == 0 {
+		earliest = blockCtx.Height
+	}
+	if s.retainBlocks > 0 && blockCtx.Height-s.retainBlocks >= earliest {
+		earliest = blockCtx.Height - s.retainBlocks + 1
+		if err := s.store.prune(earliest); err != nil {
+			s.logger.Error("failed to prune the evm stream blocks", "height", earliest, "error", err)
+		}
+	}
+
+	if s.file != nil {
+		if bz, err := EncodeBlock(s.format, block); err != nil {
+			s.logger.Error("failed to encode the evm stream block", "height", blockCtx.Height, "error", err)
+		} else if _, err = s.file.Write(bz); err != nil {
+			s.logger.Error("failed to write the evm stream block", "height", blockCtx.Height, "error", err)
+		}
+	}
+
+	s.headMtx.Lock()
+	s.earliest = earliest
+	s.latest = blockCtx.Height
+	close(s.notify)
+	s.notify = make(chan struct{})
+	s.headMtx.Unlock()
+}
+
+func (s *Streamer) earliestHeight() int64 {
+	s.headMtx.RLock()
+	defer s.headMtx.RUnlock()
+	return s.earliest
+}
+
+func (s *Streamer) latestHeight() int64 {
+	s.headMtx.RLock()
+	defer s.headMtx.RUnlock()
+	return s.latest
+}
+
+func (s *Streamer) head() (latest int64, notify <-chan struct{}) {
+	s.headMtx.RLock()
+	defer s.headMtx.RUnlock()
+	return s.latest, s.notify
+}
+
+// Subscribe subscribes the blocks from the given height, the blocks stored are replayed at first.
+// Only the new blocks are streamed if the height is 0
+func (s *Streamer) Subscribe(fromHeight int64) (*Subscription, error) {
+	s.headMtx.RLock()
+	earliest, latest := s.earliest, s.latest
+	s.headMtx.RUnlock()
+
+	if fromHeight < 0 {
+		return nil, fmt.Errorf("invalid height %d", fromHeight)
+	}
+	if fromHeight == 0 {
+		fromHeight = latest + 1
+	}
+	if fromHeight <= latest && fromHeight < earliest {
+		return nil, fmt.Errorf("height %d is pruned, the earliest height is %d", fromHeight, earliest)
+	}
+
+	sub := &Subscription{
+		blocks: make(chan *Block, subscriptionBufferSize),
+		quit:   make(chan struct{}),
+	}
+	go s.serve(sub, fromHeight)
+	return sub, nil
+}
+
+// serve reads the blocks of the subscription from the store, so that a slow subscription never blocks the commit
+func (s *Streamer) serve(sub *Subscription, next int64) {
+	defer close(sub.blocks)
+	for {
+		latest, notify := s.head()
+		for ; next <= latest; next++ {
+			block, err := s.store.getBlock(next)
+			if err != nil {
+				s.logger.Error("failed to read the evm stream block", "height", next, "error", err)
+				return
+			}
+			if block == nil {
+				if next < s.earliestHeight() {
+					s.logger.Error("the evm stream block is pruned before being sent to the subscription", "height", next)
+					return
+				}
+				// the node didn't stream the height
+				continue
+			}
+			select {
+			case sub.blocks <- block:
+			case <-sub.quit:
+				return
+			case <-s.quit:
+				return
+			}
+		}
+
+		select {
+		case <-notify:
+		case <-sub.quit:
+			return
+		case <-s.quit:
+			return
+		}
+	}
+}
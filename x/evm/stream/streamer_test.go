@@ -0,0 +1,185 @@
+package stream
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/okex/exchain/x/evm/types"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func newTestStreamer(t *testing.T, home string, retainBlocks int64) *Streamer {
+	viper.Set("home", home)
+	viper.Set(FlagEvmStreamRetainBlocks, retainBlocks)
+	s, err := NewStreamer(log.NewNopLogger())
+	require.NoError(t, err)
+	return s
+}
+
+func deliverTx(t *testing.T, s *Streamer, hash common.Hash, index uint, status uint64) {
+	to := common.HexToAddress("0x02")
+	txCtx := &types.EvmTxContext{
+		From:     common.HexToAddress("0x01"),
+		To:       &to,
+		Value:    big.NewInt(1),
+		GasPrice: big.NewInt(1),
+		GasLimit: 21000,
+		StateDiff: types.StateDiff{
+			{
+				Address:       to,
+				BalanceBefore: (*hexutil.Big)(big.NewInt(0)),
+				BalanceAfter:  (*hexutil.Big)(big.NewInt(1)),
+			},
+		},
+	}
+	receipt := &ethtypes.Receipt{
+		Status:           status,
+		TxHash:           hash,
+		GasUsed:          21000,
+		TransactionIndex: index,
+	}
+	require.NoError(t, s.PostTxProcessingWithContext(sdk.Context{}, txCtx, receipt))
+}
+
+func receiveBlock(t *testing.T, sub *Subscription) *Block {
+	select {
+	case block := <-sub.Blocks():
+		require.NotNil(t, block)
+		return block
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout to receive the block")
+	}
+	return nil
+}
+
+func TestStreamerCommitAndReplay(t *testing.T) {
+	home, err := ioutil.TempDir("", "evmstream")
+	require.NoError(t, err)
+	defer os.RemoveAll(home)
+
+	s := newTestStreamer(t, home, 0)
+	hash1, hash2 := common.HexToHash("0x01"), common.HexToHash("0x02")
+	deliverTx(t, s, hash2, 2, ethtypes.ReceiptStatusSuccessful)
+	deliverTx(t, s, hash1, 1, ethtypes.ReceiptStatusSuccessful)
+	// the re-executed tx replaces the previous result
+	deliverTx(t, s, hash1, 1, ethtypes.ReceiptStatusFailed)
+	s.Commit(BlockContext{Height: 1})
+	s.Commit(BlockContext{Height: 2})
+	// the committed heights are ignored
+	deliverTx(t, s, hash1, 1, ethtypes.ReceiptStatusSuccessful)
+	s.Commit(BlockContext{Height: 2})
+
+	sub, err := s.Subscribe(1)
+	require.NoError(t, err)
+	block := receiveBlock(t, sub)
+	require.Equal(t, hexutil.Uint64(1), block.Height)
+	require.Equal(t, 2, len(block.Txs))
+	require.Equal(t, hash1, block.Txs[0].Hash)
+	require.Equal(t, hexutil.Uint64(0), block.Txs[0].Index)
+	require.Equal(t, hexutil.Uint64(ethtypes.ReceiptStatusFailed), block.Txs[0].Status)
+	require.Equal(t, hash2, block.Txs[1].Hash)
+	require.Equal(t, hexutil.Uint64(1), block.Txs[1].Index)
+	require.Equal(t, 1, len(block.Txs[1].StateDiff))
+	block = receiveBlock(t, sub)
+	require.Equal(t, hexutil.Uint64(2), block.Height)
+	require.Equal(t, 0, len(block.Txs))
+
+	// the new blocks are pushed after the replay
+	deliverTx(t, s, hash1, 1, ethtypes.ReceiptStatusSuccessful)
+	s.Commit(BlockContext{Height: 3})
+	block = receiveBlock(t, sub)
+	require.Equal(t, hexutil.Uint64(3), block.Height)
+	require.Equal(t, 1, len(block.Txs))
+	sub.Unsubscribe()
+	s.Stop()
+
+	// the streamed blocks are kept after restart
+	s = newTestStreamer(t, home, 2)
+	defer s.Stop()
+	require.Equal(t, int64(3), s.latestHeight())
+	s.Commit(BlockContext{Height: 4})
+	_, err = s.Subscribe(2)
+	require.Error(t, err)
+
+	sub, err = s.Subscribe(3)
+	require.NoError(t, err)
+	require.Equal(t, hexutil.Uint64(3), receiveBlock(t, sub).Height)
+	require.Equal(t, hexutil.Uint64(4), receiveBlock(t, sub).Height)
+	sub.Unsubscribe()
+}
+
+func TestEncodeBlock(t *testing.T) {
+	to := common.HexToAddress("0x02")
+	block := &Block{
+		Height: 10,
+		Hash:   common.HexToHash("0x0a"),
+		Time:   1000,
+		Txs: []*Tx{
+			{
+				Hash:     common.HexToHash("0x01"),
+				From:     common.HexToAddress("0x01"),
+				To:       &to,
+				Value:    (*hexutil.Big)(big.NewInt(100)),
+				GasPrice: (*hexutil.Big)(big.NewInt(1)),
+				Status:   1,
+				Logs:     []*Log{{Address: to, Topics: []common.Hash{common.HexToHash("0x03")}}},
+				StateDiff: types.StateDiff{
+					{
+						Address:       to,
+						BalanceBefore: (*hexutil.Big)(big.NewInt(0)),
+						BalanceAfter:  (*hexutil.Big)(big.NewInt(100)),
+						Storage:       []types.StorageDiff{{Key: common.HexToHash("0x01"), After: common.HexToHash("0x02")}},
+					},
+				},
+			},
+		},
+	}
+
+	bz, err := EncodeBlock(FormatJSON, block)
+	require.NoError(t, err)
+	require.Equal(t, byte('\n'), bz[len(bz)-1])
+	decoded := &Block{}
+	require.NoError(t, json.Unmarshal(bz, decoded))
+	require.Equal(t, block.Height, decoded.Height)
+	require.Equal(t, block.Txs[0].StateDiff[0].Storage, decoded.Txs[0].StateDiff[0].Storage)
+
+	bz, err = EncodeBlock(FormatProtobuf, block)
+	require.NoError(t, err)
+	size, n := protowire.ConsumeVarint(bz)
+	require.True(t, n > 0)
+	require.Equal(t, len(bz)-n, int(size))
+	num, typ, n := protowire.ConsumeTag(bz[n:])
+	require.True(t, n > 0)
+	require.Equal(t, protowire.Number(1), num)
+	require.Equal(t, protowire.VarintType, typ)
+
+	_, err = EncodeBlock("xml", block)
+	require.Error(t, err)
+}
+
+func TestUnmarshalSubscribeRequest(t *testing.T) {
+	fromHeight, err := unmarshalSubscribeRequest(nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), fromHeight)
+
+	bz := protowire.AppendTag(nil, 2, protowire.BytesType)
+	bz = protowire.AppendBytes(bz, []byte("unknown"))
+	bz = appendUint64(bz, 1, 100)
+	fromHeight, err = unmarshalSubscribeRequest(bz)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), fromHeight)
+
+	_, err = unmarshalSubscribeRequest([]byte{0x08})
+	require.Error(t, err)
+}
@@ -0,0 +1,86 @@
+package stream
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/okex/exchain/x/evm/types"
+)
+
+// BlockContext is the context of a committed block
+type BlockContext struct {
+	Height   int64
+	Hash     common.Hash
+	Time     int64
+	Proposer []byte
+}
+
+// Block is a committed block with the evm txs delivered in it, the blocks without evm txs are streamed as well
+type Block struct {
+	Height   hexutil.Uint64 `json:"number"`
+	Hash     common.Hash    `json:"hash"`
+	Time     hexutil.Uint64 `json:"timestamp"`
+	Proposer hexutil.Bytes  `json:"proposer"`
+	Txs      []*Tx          `json:"transactions"`
+}
+
+// Tx is a committed evm tx with its receipt and state diff. The state diff is the changes made by the evm,
+// the fee and the nonce of the sender charged before the execution are not included
+type Tx struct {
+	Hash            common.Hash     `json:"hash"`
+	Index           hexutil.Uint64  `json:"transactionIndex"`
+	From            common.Address  `json:"from"`
+	To              *common.Address `json:"to"`
+	Nonce           hexutil.Uint64  `json:"nonce"`
+	Value           *hexutil.Big    `json:"value"`
+	GasPrice        *hexutil.Big    `json:"gasPrice"`
+	GasLimit        hexutil.Uint64  `json:"gas"`
+	Input           hexutil.Bytes   `json:"input"`
+	Status          hexutil.Uint64  `json:"status"`
+	GasUsed         hexutil.Uint64  `json:"gasUsed"`
+	ContractAddress *common.Address `json:"contractAddress"`
+	Logs            []*Log          `json:"logs"`
+	StateDiff       types.StateDiff `json:"stateDiff"`
+
+	// receiptIndex is the index given by the evm keeper, which is used to sort the txs of the block
+	receiptIndex uint
+}
+
+// Log is an event emitted by an evm tx
+type Log struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+	Index   hexutil.Uint   `json:"logIndex"`
+}
+
+func newTx(txCtx *types.EvmTxContext, receipt *ethtypes.Receipt) *Tx {
+	tx := &Tx{
+		Hash:         receipt.TxHash,
+		From:         txCtx.From,
+		To:           txCtx.To,
+		Nonce:        hexutil.Uint64(txCtx.Nonce),
+		Value:        (*hexutil.Big)(txCtx.Value),
+		GasPrice:     (*hexutil.Big)(txCtx.GasPrice),
+		GasLimit:     hexutil.Uint64(txCtx.GasLimit),
+		Input:        txCtx.Input,
+		Status:       hexutil.Uint64(receipt.Status),
+		GasUsed:      hexutil.Uint64(receipt.GasUsed),
+		Logs:         make([]*Log, 0, len(receipt.Logs)),
+		StateDiff:    txCtx.StateDiff,
+		receiptIndex: receipt.TransactionIndex,
+	}
+	if txCtx.To == nil && receipt.Status == ethtypes.ReceiptStatusSuccessful {
+		contractAddress := receipt.ContractAddress
+		tx.ContractAddress = &contractAddress
+	}
+	for _, log := range receipt.Logs {
+		tx.Logs = append(tx.Logs, &Log{
+			Address: log.Address,
+			Topics:  log.Topics,
+			Data:    log.Data,
+			Index:   hexutil.Uint(log.Index),
+		})
+	}
+	return tx
+}
@@ -63,31 +63,55 @@ func (tx *Tx) Transition(config types.ChainConfig) (result Result, err error) {
 			Err:        err,
 		})
 	}
-	if err != nil {
-		return
-	}
 
-	// call evm hooks
+	// call evm hooks, the failed txs are only passed to the hooks with the tx context
 	if tmtypes.HigherThanVenus1(tx.Ctx.BlockHeight()) && !tx.Ctx.IsCheckTx() {
-		receipt := &ethtypes.Receipt{
-			Status:           ethtypes.ReceiptStatusSuccessful,
-			Bloom:            result.ResultData.Bloom,
-			Logs:             result.ResultData.Logs,
-			TxHash:           result.ResultData.TxHash,
-			ContractAddress:  result.ResultData.ContractAddress,
-			GasUsed:          result.ExecResult.GasInfo.GasConsumed,
-			BlockNumber:      big.NewInt(tx.Ctx.BlockHeight()),
-			TransactionIndex: uint(tx.Keeper.TxCount),
-		}
-		err = tx.Keeper.CallEvmHooks(tx.Ctx, tx.StateTransition.Sender, tx.StateTransition.Recipient, receipt)
-		if err != nil {
-			tx.Keeper.Logger(tx.Ctx).Error("tx call evm hooks failed", "error", err)
+		if hookErr := tx.callEvmHooks(&result, err); hookErr != nil {
+			tx.Keeper.Logger(tx.Ctx).Error("tx call evm hooks failed", "error", hookErr)
+			if err == nil {
+				err = hookErr
+				// the tx is reverted by the hooks, pass it to the hooks with the tx context as a failed tx again
+				tx.callEvmHooks(&result, err)
+			}
 		}
 	}
 
 	return
 }
 
+func (tx *Tx) callEvmHooks(result *Result, txErr error) error {
+	st := &tx.StateTransition
+	receipt := &ethtypes.Receipt{
+		Status:           ethtypes.ReceiptStatusFailed,
+		GasUsed:          tx.Ctx.GasMeter().GasConsumed(),
+		BlockNumber:      big.NewInt(tx.Ctx.BlockHeight()),
+		TransactionIndex: uint(tx.Keeper.TxCount),
+	}
+	if st.TxHash != nil {
+		receipt.TxHash = *st.TxHash
+	}
+	txCtx := &types.EvmTxContext{
+		From:     st.Sender,
+		To:       st.Recipient,
+		Nonce:    st.AccountNonce,
+		Value:    st.Amount,
+		GasPrice: st.Price,
+		GasLimit: st.GasLimit,
+		Input:    st.Payload,
+	}
+	if txErr == nil {
+		receipt.Status = ethtypes.ReceiptStatusSuccessful
+		receipt.Bloom = result.ResultData.Bloom
+		receipt.Logs = result.ResultData.Logs
+		receipt.TxHash = result.ResultData.TxHash
+		receipt.ContractAddress = result.ResultData.ContractAddress
+		receipt.GasUsed = result.ExecResult.GasInfo.GasConsumed
+		txCtx.StateDiff = result.ExecResult.StateDiff
+	}
+
+	return tx.Keeper.CallEvmTxHooks(tx.Ctx, txCtx, receipt)
+}
+
 // DecorateResult TraceTxLog situation Decorate the result
 // it was replaced to trace logs when trace tx even if err != nil
 func (tx *Tx) DecorateResult(inResult *Result, inErr error) (result *sdk.Result, err error) {
@@ -153,6 +177,9 @@ func (tx *Tx) RefundFeesWatcher(account authexported.Account, coins sdk.Coins, p
 // RestoreWatcherTransactionReceipt check Tx do not need restore
 func (tx *Tx) RestoreWatcherTransactionReceipt(msg *types.MsgEthereumTx) {}
 
+// SaveInnerTx check Tx do not need
+func (tx *Tx) SaveInnerTx(result *Result) {}
+
 // Commit check Tx do not need
 func (tx *Tx) Commit(msg *types.MsgEthereumTx, result *Result) {}
 
@@ -34,6 +34,7 @@ func msg2st(ctx *sdk.Context, k *Keeper, msg *types.MsgEthereumTx) (st types.Sta
 		Recipient:    msg.Data.Recipient,
 		Amount:       msg.Data.Amount,
 		Payload:      msg.Data.Payload,
+		AccessList:   msg.Data.Accesses,
 		Csdb:         types.CreateEmptyCommitStateDB(k.GenerateCSDBParams(), *ctx),
 		ChainID:      chainIDEpoch,
 		TxHash:       &ethHash,
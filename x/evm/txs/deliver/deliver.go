@@ -76,10 +76,13 @@ func (tx *Tx) RestoreWatcherTransactionReceipt(msg *types.MsgEthereumTx) {
 		&types.ResultData{}, tx.Ctx.GasMeter().GasConsumed())
 }
 
-func (tx *Tx) Commit(msg *types.MsgEthereumTx, result *base.Result) {
+func (tx *Tx) SaveInnerTx(result *base.Result) {
 	if result.InnerTxs != nil {
-		tx.Keeper.AddInnerTx(tx.StateTransition.TxHash.Hex(), result.InnerTxs)
+		tx.Keeper.AddInnerTx(*tx.StateTransition.TxHash, result.InnerTxs)
 	}
+}
+
+func (tx *Tx) Commit(msg *types.MsgEthereumTx, result *base.Result) {
 	if result.Erc20Contracts != nil {
 		tx.Keeper.AddContract(result.Erc20Contracts)
 	}
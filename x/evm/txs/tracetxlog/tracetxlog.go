@@ -1,10 +1,13 @@
 package tracetxlog
 
 import (
+	"encoding/json"
 	"fmt"
+
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/x/evm/txs/base"
 	"github.com/okex/exchain/x/evm/txs/check"
+	"github.com/okex/exchain/x/evm/types"
 )
 
 // tx trace tx log depends on check tx
@@ -18,6 +21,23 @@ func NewTx(config base.Config) *tx {
 	}
 }
 
+// Prepare convert msg to state transition, and apply the state overrides of the trace config if any
+func (t *tx) Prepare(msg *types.MsgEthereumTx) (err error) {
+	if err = t.Tx.Prepare(msg); err != nil {
+		return
+	}
+
+	configBytes := t.Ctx.TraceTxLogConfig()
+	if configBytes == nil {
+		return
+	}
+	config := &types.TraceCallConfig{}
+	if err = json.Unmarshal(configBytes, config); err != nil {
+		return
+	}
+	return config.StateOverrides.Apply(t.StateTransition.Csdb)
+}
+
 // DecorateResult trace log tx need modify the result to log, and swallow error
 func (t *tx) DecorateResult(inResult *base.Result, inErr error) (result *sdk.Result, err error) {
 	if inResult == nil || inResult.ExecResult == nil || inResult.ExecResult.Result == nil {
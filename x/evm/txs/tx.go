@@ -39,7 +39,10 @@ type Tx interface {
 	// RestoreWatcherTransactionReceipt restore watcher TransactionReceipt
 	RestoreWatcherTransactionReceipt(msg *types.MsgEthereumTx)
 
-	// Commit save the inner tx and contracts
+	// SaveInnerTx save the inner txs of the evm tx, the failed txs are saved as well
+	SaveInnerTx(result *base.Result)
+
+	// Commit save the contracts
 	Commit(msg *types.MsgEthereumTx, result *base.Result)
 
 	// EmitEvent emit event
@@ -91,8 +94,9 @@ func TransitionEvmTx(tx Tx, msg *types.MsgEthereumTx) (result *sdk.Result, err e
 	// execute evm tx
 	var baseResult base.Result
 	baseResult, err = tx.Transition(config)
+	tx.SaveInnerTx(&baseResult)
 	if err == nil {
-		// Commit save the inner tx and contracts
+		// Commit save the contracts
 		tx.Commit(msg, &baseResult)
 		tx.EmitEvent(msg, &baseResult)
 	} else {
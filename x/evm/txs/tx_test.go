@@ -80,6 +80,7 @@ func (e EmptyTx) DecorateResult(inResult *base.Result, inErr error) (result *sdk
 }
 
 func (e EmptyTx) RestoreWatcherTransactionReceipt(msg *types.MsgEthereumTx) {}
+func (e EmptyTx) SaveInnerTx(result *base.Result)                           {}
 func (e EmptyTx) Commit(msg *types.MsgEthereumTx, result *base.Result)      {}
 func (e EmptyTx) EmitEvent(msg *types.MsgEthereumTx, result *base.Result)   {}
 func (e EmptyTx) FinalizeWatcher(account authexported.Account, err error)   {}
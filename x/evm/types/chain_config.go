@@ -11,6 +11,7 @@ import (
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params"
@@ -48,7 +49,8 @@ type ChainConfig struct {
 }
 
 // EthereumConfig returns an Ethereum ChainConfig for EVM state transitions.
-// All the negative or nil values are converted to nil
+// All the negative or nil values are converted to nil. Berlin is enabled since the Venus2 height, along with the
+// access list txs.
 func (cc ChainConfig) EthereumConfig(chainID *big.Int) *params.ChainConfig {
 	return &params.ChainConfig{
 		ChainID:             chainID,
@@ -64,9 +66,18 @@ func (cc ChainConfig) EthereumConfig(chainID *big.Int) *params.ChainConfig {
 		PetersburgBlock:     getBlockValue(cc.PetersburgBlock),
 		IstanbulBlock:       getBlockValue(cc.IstanbulBlock),
 		MuirGlacierBlock:    getBlockValue(cc.MuirGlacierBlock),
+		BerlinBlock:         venus2Block(),
 	}
 }
 
+// venus2Block returns the Venus2 height, or nil if the milestone is not set
+func venus2Block() *big.Int {
+	if tmtypes.GetVenus2Height() == 0 {
+		return nil
+	}
+	return big.NewInt(tmtypes.GetVenus2Height())
+}
+
 // IsIstanbul returns whether the Istanbul version is enabled.
 func (cc ChainConfig) IsIstanbul() bool {
 	return getBlockValue(cc.IstanbulBlock) != nil
@@ -2,6 +2,7 @@ package types
 
 import (
 	"math"
+	"math/big"
 	"testing"
 
 	"github.com/tendermint/go-amino"
@@ -9,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -342,3 +344,14 @@ func TestChainConfigAmino(t *testing.T) {
 		require.EqualValues(t, expectValue, actualValue)
 	}
 }
+
+func TestChainConfigBerlinSinceVenus2(t *testing.T) {
+	config := DefaultChainConfig().EthereumConfig(big.NewInt(65))
+	require.False(t, config.IsBerlin(big.NewInt(100)))
+
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(10)
+	defer tmtypes.UnittestOnlySetMilestoneVenus2Height(0)
+	config = DefaultChainConfig().EthereumConfig(big.NewInt(65))
+	require.False(t, config.IsBerlin(big.NewInt(9)))
+	require.True(t, config.IsBerlin(big.NewInt(10)))
+}
@@ -29,6 +29,16 @@ func (cv ContractVerifier) Verify(stateDB vm.StateDB, op vm.OpCode, from, to com
 	if !ok {
 		panic(ErrContractBlockedVerify{"unknown stateDB expected CommitStateDB"})
 	}
+	//hand the context of the call over to the stateful precompile it calls
+	if err := verifyPrecompileCall(csdb, op, from, to, input, value); err != nil {
+		return err
+	}
+	//the balance a contract selfdestructs with must not include the coins locked by its vesting schedule
+	if op == vm.SELFDESTRUCT {
+		if err := csdb.verifyVestingLock(from, value); err != nil {
+			return err
+		}
+	}
 	//check whether contract has been blocked
 	if !cv.params.EnableContractBlockedList {
 		return nil
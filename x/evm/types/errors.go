@@ -3,6 +3,7 @@ package types
 import (
 	"errors"
 	"fmt"
+	"math/big"
 
 	ethcmn "github.com/ethereum/go-ethereum/common"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
@@ -62,6 +63,8 @@ var (
 	ErrorHexData = "HexData"
 
 	ErrorContractMethodBlockedIsNotExist = errors.New("it's not exist in contract method blocked list")
+
+	ErrInnerTxDisabled = errors.New("inner tx is not enabled on this node")
 )
 
 const (
@@ -111,6 +114,28 @@ func ErrBlockedContractMethodIsNotExist(address sdk.Address, err error) sdk.Enve
 	}
 }
 
+// ErrCallPrecompile returns an error when a stateful precompile is called in a way it doesn't support
+func ErrCallPrecompile(descriptor string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{
+		Err: sdkerrors.New(
+			DefaultParamspace,
+			21,
+			descriptor,
+		),
+	}
+}
+
+// ErrLockedCoins returns an error when the coins locked by the vesting schedule of an account are moved
+func ErrLockedCoins(addr ethcmn.Address, amount, spendable *big.Int) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{
+		Err: sdkerrors.New(
+			DefaultParamspace,
+			22,
+			fmt.Sprintf("failed. the amount %s to move from %s is more than its spendable balance %s",
+				amount, addr, spendable,
+			))}
+}
+
 type ErrContractBlockedVerify struct {
 	Descriptor string
 }
@@ -0,0 +1,195 @@
+package types
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	dbm "github.com/okex/exchain/libs/tm-db"
+	"github.com/spf13/viper"
+)
+
+const (
+	FlagEnableInnerTx = "enable-inner-tx"
+
+	innerTxDir = "innertx"
+)
+
+var (
+	innerTxKeyPrefix      = []byte{0x01}
+	blockInnerTxKeyPrefix = []byte{0x02}
+
+	enableInnerTx   bool
+	innerTxFlagOnce sync.Once
+
+	innerTxDB     dbm.DB
+	innerTxDBErr  error
+	innerTxDBOnce sync.Once
+)
+
+// InnerTx is a message call, contract creation or selfdestruct made during the execution of an evm tx.
+// The tx itself is recorded as the inner tx of depth 0
+type InnerTx struct {
+	Depth    uint64          `json:"depth"`
+	Index    uint64          `json:"index"`
+	CallType string          `json:"callType"`
+	From     common.Address  `json:"from"`
+	To       *common.Address `json:"to,omitempty"`
+	Value    *hexutil.Big    `json:"value"`
+	Gas      hexutil.Uint64  `json:"gas"`
+	GasUsed  hexutil.Uint64  `json:"gasUsed"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// TxInnerTxs is the inner txs of an evm tx in a block
+type TxInnerTxs struct {
+	TxHash   common.Hash `json:"txHash"`
+	InnerTxs []*InnerTx  `json:"innerTxs"`
+}
+
+// GetEnableInnerTx returns true if the node records the inner txs of the evm txs it delivers
+func GetEnableInnerTx() bool {
+	innerTxFlagOnce.Do(func() {
+		enableInnerTx = viper.GetBool(FlagEnableInnerTx)
+	})
+	return enableInnerTx
+}
+
+// InitInnerTxDB opens the local db of the inner txs under the data dir of the node
+func InitInnerTxDB() error {
+	innerTxDBOnce.Do(func() {
+		dataDir := filepath.Join(viper.GetString("home"), "data")
+		innerTxDB, innerTxDBErr = sdk.NewLevelDB(innerTxDir, dataDir)
+	})
+	return innerTxDBErr
+}
+
+// CloseInnerTxDB closes the local db of the inner txs
+func CloseInnerTxDB() {
+	if innerTxDB != nil {
+		innerTxDB.Close()
+	}
+}
+
+func innerTxKey(txHash common.Hash) []byte {
+	return append(innerTxKeyPrefix, txHash.Bytes()...)
+}
+
+func blockInnerTxKey(height int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	return append(blockInnerTxKeyPrefix, bz...)
+}
+
+// WriteBlockInnerTxs saves the inner txs of every evm tx of the block, and the order of the txs in the block
+func WriteBlockInnerTxs(height int64, txs []*TxInnerTxs) error {
+	if innerTxDB == nil {
+		return ErrInnerTxDisabled
+	}
+
+	batch := innerTxDB.NewBatch()
+	defer batch.Close()
+
+	txHashes := make([]common.Hash, len(txs))
+	for i, tx := range txs {
+		bz, err := json.Marshal(tx.InnerTxs)
+		if err != nil {
+			return err
+		}
+		batch.Set(innerTxKey(tx.TxHash), bz)
+		txHashes[i] = tx.TxHash
+	}
+	bz, err := json.Marshal(txHashes)
+	if err != nil {
+		return err
+	}
+	batch.Set(blockInnerTxKey(height), bz)
+	return batch.Write()
+}
+
+// GetInnerTxs returns the inner txs of an evm tx, nil is returned if the tx is not recorded
+func GetInnerTxs(txHash common.Hash) ([]*InnerTx, error) {
+	if innerTxDB == nil {
+		return nil, ErrInnerTxDisabled
+	}
+
+	bz, err := innerTxDB.Get(innerTxKey(txHash))
+	if err != nil || bz == nil {
+		return nil, err
+	}
+	var innerTxs []*InnerTx
+	if err := json.Unmarshal(bz, &innerTxs); err != nil {
+		return nil, err
+	}
+	return innerTxs, nil
+}
+
+// GetBlockInnerTxs returns the inner txs of all the evm txs of the block at the height, in the order they were delivered
+func GetBlockInnerTxs(height int64) ([]*TxInnerTxs, error) {
+	if innerTxDB == nil {
+		return nil, ErrInnerTxDisabled
+	}
+
+	bz, err := innerTxDB.Get(blockInnerTxKey(height))
+	if err != nil || bz == nil {
+		return nil, err
+	}
+	var txHashes []common.Hash
+	if err := json.Unmarshal(bz, &txHashes); err != nil {
+		return nil, err
+	}
+
+	txs := make([]*TxInnerTxs, 0, len(txHashes))
+	for _, txHash := range txHashes {
+		innerTxs, err := GetInnerTxs(txHash)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, &TxInnerTxs{TxHash: txHash, InnerTxs: innerTxs})
+	}
+	return txs, nil
+}
+
+// newInnerTxTracer returns the tracer which collects the inner txs during the delivery of an evm tx
+func newInnerTxTracer(gasLimit uint64) *callTracer {
+	return &callTracer{
+		gasLimit:  gasLimit,
+		callstack: []*callFrame{{}},
+	}
+}
+
+// innerTxs flattens the call frames collected by the tracer in the order they were made
+func (t *callTracer) innerTxs() []*InnerTx {
+	// the vm returned before running the tx, e.g. the sender can't afford the value
+	if len(t.callstack) != 1 || t.callstack[0].Type == "" {
+		return nil
+	}
+	return appendInnerTxs(nil, t.callstack[0], 0)
+}
+
+func appendInnerTxs(innerTxs []*InnerTx, frame *callFrame, depth uint64) []*InnerTx {
+	innerTx := &InnerTx{
+		Depth:    depth,
+		Index:    uint64(len(innerTxs)),
+		CallType: strings.ToLower(frame.Type),
+		From:     frame.From,
+		To:       frame.To,
+		Value:    frame.Value,
+		Gas:      frame.Gas,
+		GasUsed:  frame.GasUsed,
+		Error:    frame.Error,
+	}
+	if innerTx.Value == nil {
+		innerTx.Value = (*hexutil.Big)(common.Big0)
+	}
+	innerTxs = append(innerTxs, innerTx)
+	for _, call := range frame.Calls {
+		innerTxs = appendInnerTxs(innerTxs, call, depth+1)
+	}
+	return innerTxs
+}
@@ -0,0 +1,82 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	dbm "github.com/okex/exchain/libs/tm-db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInnerTxs(t *testing.T) {
+	statedb, err := state.New(ethcmn.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	statedb.SetCode(tracerCallerAddr, tracerCallerCode)
+	statedb.SetCode(tracerCalleeAddr, tracerCalleeCode)
+	statedb.SetBalance(tracerOrigin, big.NewInt(1000))
+
+	tracer := newInnerTxTracer(100000)
+	_, _, err = runtime.Call(tracerCallerAddr, nil, &runtime.Config{
+		Origin:    tracerOrigin,
+		GasLimit:  100000,
+		Value:     big.NewInt(10),
+		State:     statedb,
+		EVMConfig: vm.Config{Debug: true, Tracer: tracer},
+	})
+	require.NoError(t, err)
+
+	innerTxs := tracer.innerTxs()
+	require.Len(t, innerTxs, 2)
+	require.Equal(t, uint64(0), innerTxs[0].Depth)
+	require.Equal(t, "call", innerTxs[0].CallType)
+	require.Equal(t, tracerOrigin, innerTxs[0].From)
+	require.Equal(t, tracerCallerAddr, *innerTxs[0].To)
+	require.Equal(t, big.NewInt(10), innerTxs[0].Value.ToInt())
+
+	require.Equal(t, uint64(1), innerTxs[1].Depth)
+	require.Equal(t, uint64(1), innerTxs[1].Index)
+	require.Equal(t, tracerCallerAddr, innerTxs[1].From)
+	require.Equal(t, tracerCalleeAddr, *innerTxs[1].To)
+	require.Zero(t, innerTxs[1].Value.ToInt().Sign())
+	require.NotZero(t, innerTxs[1].GasUsed)
+	require.Empty(t, innerTxs[1].Error)
+
+	// no call frame is captured if the vm never runs the tx
+	require.Nil(t, newInnerTxTracer(100000).innerTxs())
+}
+
+func TestInnerTxDB(t *testing.T) {
+	_, err := GetInnerTxs(ethcmn.Hash{})
+	require.Equal(t, ErrInnerTxDisabled, err)
+
+	innerTxDB = dbm.NewMemDB()
+	defer func() { innerTxDB = nil }()
+
+	txHash1, txHash2 := ethcmn.BytesToHash([]byte{1}), ethcmn.BytesToHash([]byte{2})
+	txs := []*TxInnerTxs{
+		{TxHash: txHash2, InnerTxs: []*InnerTx{{CallType: "call", From: tracerOrigin, To: &tracerCallerAddr}}},
+		{TxHash: txHash1, InnerTxs: []*InnerTx{{CallType: "create", From: tracerOrigin}, {Depth: 1, Index: 1, CallType: "selfdestruct"}}},
+	}
+	require.NoError(t, WriteBlockInnerTxs(10, txs))
+
+	innerTxs, err := GetInnerTxs(txHash1)
+	require.NoError(t, err)
+	require.Equal(t, txs[1].InnerTxs, innerTxs)
+
+	innerTxs, err = GetInnerTxs(ethcmn.BytesToHash([]byte{3}))
+	require.NoError(t, err)
+	require.Nil(t, innerTxs)
+
+	blockTxs, err := GetBlockInnerTxs(10)
+	require.NoError(t, err)
+	require.Equal(t, txs, blockTxs)
+
+	blockTxs, err = GetBlockInnerTxs(11)
+	require.NoError(t, err)
+	require.Nil(t, blockTxs)
+}
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"math/big"
+
 	"github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
@@ -15,6 +17,27 @@ type EvmHooks interface {
 	PostTxProcessing(ctx sdk.Context, from common.Address, to *common.Address, receipt *ethtypes.Receipt) error
 }
 
+// EvmTxContext is the context of an evm tx passed to the EvmTxHooks
+type EvmTxContext struct {
+	From     common.Address
+	To       *common.Address
+	Nonce    uint64
+	Value    *big.Int
+	GasPrice *big.Int
+	GasLimit uint64
+	Input    []byte
+	// StateDiff is only recorded when the evm stream is enabled, it's empty for the failed txs
+	StateDiff StateDiff
+}
+
+// EvmTxHooks event hooks for evm tx processing which need the context of the tx
+type EvmTxHooks interface {
+	EvmHooks
+	// PostTxProcessingWithContext is called after every delivered tx, the failed txs included, whose receipt status
+	// is failed. If return an error, the whole transaction is reverted.
+	PostTxProcessingWithContext(ctx sdk.Context, txCtx *EvmTxContext, receipt *ethtypes.Receipt) error
+}
+
 // EvmLogHandler defines the interface for evm log handler
 type EvmLogHandler interface {
 	// EventID Return the id of the log signature it handles
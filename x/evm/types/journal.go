@@ -2,6 +2,7 @@ package types
 
 import (
 	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethermint "github.com/okex/exchain/app/types"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 )
 
@@ -192,6 +193,13 @@ type (
 		address *ethcmn.Address
 		slot    *ethcmn.Hash
 	}
+
+	// Changes to the native state by a stateful precompile.
+	nativeChange struct {
+		prevCtx      sdk.Context
+		prevAccounts map[ethcmn.Address]*ethermint.EthAccount
+		reverts      []func()
+	}
 )
 
 func (ch createObjectChange) revert(s *CommitStateDB) {
@@ -357,3 +365,18 @@ func (ch accessListAddSlotChange) revert(s *CommitStateDB) {
 func (ch accessListAddSlotChange) dirtied() *ethcmn.Address {
 	return nil
 }
+
+func (ch nativeChange) revert(s *CommitStateDB) {
+	revertNative(ch.reverts)
+	s.ctx = ch.prevCtx
+	s.nativeWrites = s.nativeWrites[:len(s.nativeWrites)-1]
+	for addr, acc := range ch.prevAccounts {
+		if entry, ok := s.stateObjects[addr]; ok && entry.stateObject != nil {
+			entry.stateObject.account = acc
+		}
+	}
+}
+
+func (ch nativeChange) dirtied() *ethcmn.Address {
+	return nil
+}
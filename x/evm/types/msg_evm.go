@@ -8,6 +8,7 @@ import (
 	"math/big"
 
 	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/okex/exchain/app/types"
@@ -151,6 +152,32 @@ func (msg *MsgEthereumTx) ValidateBasic() error {
 		return sdkerrors.Wrapf(types.ErrInvalidValue, "amount cannot be negative %s", msg.Data.Amount)
 	}
 
+	switch msg.Data.Type {
+	case ethtypes.LegacyTxType:
+		return nil
+	case ethtypes.AccessListTxType:
+	case ethtypes.DynamicFeeTxType:
+		if msg.Data.GasTipCap == nil || msg.Data.GasFeeCap == nil {
+			return sdkerrors.Wrap(types.ErrInvalidValue, "gas fee cap and gas tip cap cannot be empty")
+		}
+		if msg.Data.GasTipCap.Sign() == -1 {
+			return sdkerrors.Wrapf(types.ErrInvalidValue, "gas tip cap cannot be negative %s", msg.Data.GasTipCap)
+		}
+		if msg.Data.GasFeeCap.Cmp(msg.Data.GasTipCap) < 0 {
+			return sdkerrors.Wrapf(types.ErrInvalidValue, "gas tip cap %s is higher than gas fee cap %s", msg.Data.GasTipCap, msg.Data.GasFeeCap)
+		}
+		// the gas price is charged as the fee cap, which is covered by the signature
+		if msg.Data.Price.Cmp(msg.Data.GasFeeCap) != 0 {
+			return sdkerrors.Wrapf(types.ErrInvalidValue, "gas price %s mismatches gas fee cap %s", msg.Data.Price, msg.Data.GasFeeCap)
+		}
+	default:
+		return sdkerrors.Wrapf(types.ErrInvalidValue, "unsupported tx type %d", msg.Data.Type)
+	}
+
+	if msg.Data.ChainID == nil || msg.Data.ChainID.Sign() <= 0 {
+		return sdkerrors.Wrapf(types.ErrInvalidValue, "chain id of the typed tx must be positive %s", msg.Data.ChainID)
+	}
+
 	return nil
 }
 
@@ -182,8 +209,17 @@ func (msg *MsgEthereumTx) GetSignBytes() []byte {
 }
 
 // RLPSignBytes returns the RLP hash of an Ethereum transaction message with a
-// given chainID used for signing.
+// given chainID used for signing. The typed transactions are hashed as the
+// EIP-2718 envelope with the chainID replaced.
 func (msg *MsgEthereumTx) RLPSignBytes(chainID *big.Int) ethcmn.Hash {
+	if msg.Data.IsTyped() {
+		tx, err := msg.Data.toEthTx()
+		if err != nil {
+			return ethcmn.Hash{}
+		}
+		return ethtypes.NewLondonSigner(chainID).Hash(tx)
+	}
+
 	return rlpHash([]interface{}{
 		msg.Data.AccountNonce,
 		msg.Data.Price,
@@ -209,23 +245,62 @@ func (msg *MsgEthereumTx) HomesteadSignHash() ethcmn.Hash {
 }
 
 // EncodeRLP implements the rlp.Encoder interface.
+// The typed transactions are encoded as an RLP string of the EIP-2718 envelope
 func (msg *MsgEthereumTx) EncodeRLP(w io.Writer) error {
+	if msg.Data.IsTyped() {
+		tx, err := msg.Data.toEthTx()
+		if err != nil {
+			return err
+		}
+		return rlp.Encode(w, tx)
+	}
 	return rlp.Encode(w, &msg.Data)
 }
 
 // DecodeRLP implements the rlp.Decoder interface.
 func (msg *MsgEthereumTx) DecodeRLP(s *rlp.Stream) error {
-	_, _, err := s.Kind()
+	kind, _, err := s.Kind()
 	if err != nil {
 		// return error if stream is too large
 		return err
 	}
 
-	if err := s.Decode(&msg.Data); err != nil {
+	if kind == rlp.List {
+		return s.Decode(&msg.Data)
+	}
+
+	var tx ethtypes.Transaction
+	if err := s.Decode(&tx); err != nil {
 		return err
 	}
+	return msg.Data.fromEthTx(&tx)
+}
 
-	return nil
+// MarshalBinary returns the canonical encoding of the transaction, which is the RLP
+// encoding for the legacy transactions and the EIP-2718 envelope for the typed ones.
+func (msg *MsgEthereumTx) MarshalBinary() ([]byte, error) {
+	if msg.Data.IsTyped() {
+		tx, err := msg.Data.toEthTx()
+		if err != nil {
+			return nil, err
+		}
+		return tx.MarshalBinary()
+	}
+	return rlp.EncodeToBytes(&msg.Data)
+}
+
+// UnmarshalBinary decodes the canonical encoding of the transaction
+func (msg *MsgEthereumTx) UnmarshalBinary(b []byte) error {
+	if len(b) > 0 && b[0] > 0x7f {
+		// legacy transaction is an RLP list
+		return rlp.DecodeBytes(b, &msg.Data)
+	}
+
+	var tx ethtypes.Transaction
+	if err := tx.UnmarshalBinary(b); err != nil {
+		return err
+	}
+	return msg.Data.fromEthTx(&tx)
 }
 
 // Sign calculates a secp256k1 ECDSA signature and signs the transaction. It
@@ -249,7 +324,10 @@ func (msg *MsgEthereumTx) Sign(chainID *big.Int, priv *ecdsa.PrivateKey) error {
 
 	var v *big.Int
 
-	if chainID.Sign() == 0 {
+	if msg.Data.IsTyped() {
+		// the typed transactions are signed with the y parity and the chain id in tx data
+		v = big.NewInt(int64(sig[64]))
+	} else if chainID.Sign() == 0 {
 		v = new(big.Int).SetBytes([]byte{sig[64] + 27})
 	} else {
 		v = big.NewInt(int64(sig[64] + 35))
@@ -267,7 +345,15 @@ func (msg *MsgEthereumTx) Sign(chainID *big.Int, priv *ecdsa.PrivateKey) error {
 func (msg *MsgEthereumTx) firstVerifySig(chainID *big.Int) (string, error) {
 	var V *big.Int
 	var sigHash ethcmn.Hash
-	if isProtectedV(msg.Data.V) {
+	if msg.Data.IsTyped() {
+		if msg.Data.ChainID == nil || msg.Data.ChainID.Cmp(chainID) != 0 {
+			return "", fmt.Errorf("invalid chain id for typed tx: have %s want %s", msg.Data.ChainID, chainID)
+		}
+		// the y parity of the typed transactions is 0 or 1
+		V = new(big.Int).Add(msg.Data.V, big.NewInt(27))
+
+		sigHash = msg.RLPSignBytes(chainID)
+	} else if isProtectedV(msg.Data.V) {
 		// do not allow recovery for transactions with an unprotected chainID
 		if chainID.Sign() == 0 {
 			return "", errors.New("chainID cannot be zero")
@@ -294,7 +380,7 @@ func (msg *MsgEthereumTx) firstVerifySig(chainID *big.Int) (string, error) {
 // VerifySig attempts to verify a Transaction's signature for a given chainID.
 // A derived address is returned upon success or an error if recovery fails.
 func (msg *MsgEthereumTx) VerifySig(chainID *big.Int, height int64) error {
-	if !isProtectedV(msg.Data.V) && tmtypes.HigherThanMercury(height) {
+	if !msg.Data.IsTyped() && !isProtectedV(msg.Data.V) && tmtypes.HigherThanMercury(height) {
 		return errors.New("deprecated support for homestead Signer")
 	}
 	if msg.BaseTx.GetFrom() != "" {
@@ -330,12 +416,19 @@ func (msg *MsgEthereumTx) GetGas() uint64 {
 }
 
 // Fee returns gasprice * gaslimit.
+// NOTE: the gasprice of the dynamic fee transaction is the fee cap as there is no base fee.
 func (msg *MsgEthereumTx) Fee() *big.Int {
 	return new(big.Int).Mul(msg.Data.Price, new(big.Int).SetUint64(msg.Data.GasLimit))
 }
 
 // ChainID returns which chain id this transaction was signed for (if at all)
 func (msg *MsgEthereumTx) ChainID() *big.Int {
+	if msg.Data.IsTyped() {
+		if msg.Data.ChainID == nil {
+			return new(big.Int)
+		}
+		return new(big.Int).Set(msg.Data.ChainID)
+	}
 	return deriveChainID(msg.Data.V)
 }
 
@@ -154,6 +154,80 @@ func TestMsgEthereumTxSig(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestMsgEthereumTxTyped(t *testing.T) {
+	chainID := big.NewInt(3)
+	priv, _ := ethsecp256k1.GenerateKey()
+	addr := ethcmn.BytesToAddress(priv.PubKey().Address().Bytes())
+	accesses := ethtypes.AccessList{{Address: addr, StorageKeys: []ethcmn.Hash{ethcmn.BigToHash(big.NewInt(1))}}}
+
+	testCases := []ethtypes.TxData{
+		&ethtypes.AccessListTx{
+			ChainID: chainID, Nonce: 1, GasPrice: big.NewInt(10), Gas: 100000, To: &addr,
+			Value: big.NewInt(5), Data: []byte("test"), AccessList: accesses,
+		},
+		&ethtypes.DynamicFeeTx{
+			ChainID: chainID, Nonce: 2, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(10), Gas: 100000,
+			Value: big.NewInt(5), Data: []byte("test"), AccessList: accesses,
+		},
+	}
+
+	for _, txData := range testCases {
+		ethTx, err := ethtypes.SignNewTx(priv.ToECDSA(), ethtypes.NewLondonSigner(chainID), txData)
+		require.NoError(t, err)
+		raw, err := ethTx.MarshalBinary()
+		require.NoError(t, err)
+
+		var msg MsgEthereumTx
+		require.NoError(t, authtypes.EthereumTxDecode(raw, &msg))
+		require.NoError(t, msg.ValidateBasic())
+		require.Equal(t, ethTx.Type(), msg.Data.Type)
+		require.Equal(t, ethTx.GasPrice(), msg.Data.Price)
+		require.Equal(t, accesses, msg.Data.Accesses)
+		require.Equal(t, chainID, msg.ChainID())
+
+		// the envelope is kept after re-encoding
+		bz, err := authtypes.EthereumTxEncode(&msg)
+		require.NoError(t, err)
+		require.Equal(t, raw, bz)
+
+		require.NoError(t, msg.VerifySig(chainID, 0))
+		require.Equal(t, addr, msg.EthereumAddress())
+
+		// the signature is bound to the chain id in tx data
+		_, err = msg.firstVerifySig(big.NewInt(4))
+		require.Error(t, err)
+
+		// amino
+		bz, err = msg.Data.MarshalAmino()
+		require.NoError(t, err)
+		var data1, data2 TxData
+		require.NoError(t, data1.UnmarshalAmino(bz))
+		require.NoError(t, data2.UnmarshalFromAmino(nil, bz))
+		require.Equal(t, msg.Data, data1)
+		require.Equal(t, msg.Data, data2)
+
+		// signed by Sign
+		msg.Data.V, msg.Data.R, msg.Data.S = nil, nil, nil
+		require.NoError(t, msg.Sign(chainID, priv.ToECDSA()))
+		signed, err := msg.Data.toEthTx()
+		require.NoError(t, err)
+		sender, err := ethtypes.Sender(ethtypes.NewLondonSigner(chainID), signed)
+		require.NoError(t, err)
+		require.Equal(t, addr, sender)
+	}
+
+	// the gas price of dynamic fee tx must be the fee cap
+	var msg MsgEthereumTx
+	ethTx, err := ethtypes.SignNewTx(priv.ToECDSA(), ethtypes.NewLondonSigner(chainID), testCases[1])
+	require.NoError(t, err)
+	require.NoError(t, msg.Data.fromEthTx(ethTx))
+	msg.Data.Price = big.NewInt(11)
+	require.Error(t, msg.ValidateBasic())
+	msg.Data.Price = big.NewInt(10)
+	msg.Data.GasTipCap = big.NewInt(11)
+	require.Error(t, msg.ValidateBasic())
+}
+
 func TestMsgEthereumTx_ChainID(t *testing.T) {
 	chainID := big.NewInt(3)
 	priv, _ := ethsecp256k1.GenerateKey()
@@ -0,0 +1,269 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+)
+
+// NativePrecompileAddress is the address of the precompile which exposes the native modules to the contracts
+var NativePrecompileAddress = common.HexToAddress("0x0000000000000000000000000000000000000100")
+
+// revertSelector is the selector of `Error(string)`, the revert reason solidity decodes
+var revertSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+
+// StatefulPrecompiledContract is a precompiled contract which runs on the native state. The evm only passes the
+// input to a precompile, the caller and the state of the call are handed over by the ContractVerifier, which
+// verifies every call right before the evm runs it.
+type StatefulPrecompiledContract interface {
+	// RequiredGas returns the gas charged for the call, it is also the gas limit of the native execution
+	RequiredGas(input []byte) uint64
+	// Run runs the call of caller. ctx is a branch of the native state which is reverted along with the evm
+	// call, and readOnly is set when the precompile is called by STATICCALL.
+	Run(ctx sdk.Context, stateDB *CommitStateDB, caller common.Address, input []byte, readOnly bool) ([]byte, error)
+}
+
+// statefulPrecompiles are the registered stateful precompiles by address
+var statefulPrecompiles = make(map[common.Address]StatefulPrecompiledContract)
+
+// RegisterStatefulPrecompile installs p at addr for the berlin rules of the evm, which are enabled since the Venus2
+// height. Before it the evm runs on the istanbul rules and the address behaves as an empty account.
+func RegisterStatefulPrecompile(addr common.Address, p StatefulPrecompiledContract) {
+	if _, ok := statefulPrecompiles[addr]; !ok {
+		vm.PrecompiledAddressesBerlin = append(vm.PrecompiledAddressesBerlin, addr)
+	}
+	vm.PrecompiledContractsBerlin[addr] = &precompileAdapter{contract: p}
+	statefulPrecompiles[addr] = p
+}
+
+// precompileCall is a call to a stateful precompile, it is held by the state db of the tx from the verification of
+// the call until the evm runs it
+type precompileCall struct {
+	caller   common.Address
+	input    []byte
+	readOnly bool
+}
+
+// pendingPrecompileCalls are the state dbs holding a verified call which isn't run yet. The evm passes nothing but
+// the input to a precompile, so the call is found as the pending one with the very same input slice. A state db only
+// holds the call it verified last, and the input held by the call can't be reused by the call of another tx.
+var pendingPrecompileCalls = struct {
+	sync.Mutex
+	stateDBs map[*CommitStateDB]struct{}
+}{stateDBs: make(map[*CommitStateDB]struct{})}
+
+// verifyPrecompileCall makes the call to a stateful precompile the pending call of csdb. Only CALL and STATICCALL
+// without value can call a stateful precompile.
+func verifyPrecompileCall(csdb *CommitStateDB, op vm.OpCode, from, to common.Address, input []byte,
+	value *big.Int) error {
+	// the call verified before is dropped whether it was run or not, e.g. for the lack of gas
+	setPrecompileCall(csdb, nil)
+	if _, ok := statefulPrecompiles[to]; !ok || len(input) == 0 || !tmtypes.HigherThanVenus2(csdb.ctx.BlockHeight()) {
+		return nil
+	}
+
+	if op != vm.CALL && op != vm.STATICCALL {
+		return ErrCallPrecompile(fmt.Sprintf("precompile %s can only be called by CALL or STATICCALL", to))
+	}
+	if value != nil && value.Sign() != 0 {
+		return ErrCallPrecompile(fmt.Sprintf("precompile %s can't receive value", to))
+	}
+	setPrecompileCall(csdb, &precompileCall{caller: from, input: input, readOnly: op == vm.STATICCALL})
+	return nil
+}
+
+// setPrecompileCall sets the pending call of csdb, a nil call clears it
+func setPrecompileCall(csdb *CommitStateDB, call *precompileCall) {
+	if call == nil && csdb.precompileCall == nil {
+		return
+	}
+	pendingPrecompileCalls.Lock()
+	defer pendingPrecompileCalls.Unlock()
+	csdb.precompileCall = call
+	if call == nil {
+		delete(pendingPrecompileCalls.stateDBs, csdb)
+	} else {
+		pendingPrecompileCalls.stateDBs[csdb] = struct{}{}
+	}
+}
+
+// pendingPrecompileCall returns the pending call with input and its state db, the call is no longer pending if take
+func pendingPrecompileCall(input []byte, take bool) (*CommitStateDB, *precompileCall) {
+	if len(input) == 0 {
+		return nil, nil
+	}
+	pendingPrecompileCalls.Lock()
+	defer pendingPrecompileCalls.Unlock()
+	for csdb := range pendingPrecompileCalls.stateDBs {
+		call := csdb.precompileCall
+		if len(call.input) != len(input) || &call.input[0] != &input[0] {
+			continue
+		}
+		if take {
+			csdb.precompileCall = nil
+			delete(pendingPrecompileCalls.stateDBs, csdb)
+		}
+		return csdb, call
+	}
+	return nil, nil
+}
+
+var _ vm.PrecompiledContract = (*precompileAdapter)(nil)
+
+// precompileAdapter runs a StatefulPrecompiledContract as a precompile of the evm
+type precompileAdapter struct {
+	contract StatefulPrecompiledContract
+}
+
+// RequiredGas implements vm.PrecompiledContract
+func (a *precompileAdapter) RequiredGas(input []byte) uint64 {
+	if csdb, _ := pendingPrecompileCall(input, false); csdb != nil {
+		return a.contract.RequiredGas(input)
+	}
+	return 0
+}
+
+// Run implements vm.PrecompiledContract. A failed call reverts with the error as the reason and keeps the gas left.
+func (a *precompileAdapter) Run(input []byte) ([]byte, error) {
+	csdb, call := pendingPrecompileCall(input, true)
+	if csdb == nil {
+		if len(input) == 0 {
+			return nil, nil
+		}
+		return revertReason(errors.New("unverified precompile call")), vm.ErrExecutionReverted
+	}
+
+	ret, err := csdb.CallNative(a.contract.RequiredGas(input), call.readOnly, func(ctx sdk.Context) ([]byte, error) {
+		return a.contract.Run(ctx, csdb, call.caller, input, call.readOnly)
+	})
+	if err != nil && err != vm.ErrOutOfGas {
+		return revertReason(err), vm.ErrExecutionReverted
+	}
+	return ret, err
+}
+
+// revertReason encodes err as `Error(string)`
+func revertReason(err error) []byte {
+	stringType, _ := abi.NewType("string", "", nil)
+	data, _ := abi.Arguments{{Type: stringType}}.Pack(err.Error())
+	return append(append([]byte{}, revertSelector...), data...)
+}
+
+// PrecompileMethod is a method of a NativePrecompile. The method is a view method if it is constant, only the view
+// methods can be called within a static call.
+type PrecompileMethod struct {
+	Method   abi.Method
+	Gas      uint64
+	run      func(ctx sdk.Context, caller sdk.AccAddress, args []interface{}) ([]interface{}, error)
+	snapshot func(ctx sdk.Context, args []interface{}) (restore func())
+}
+
+// WithSnapshot returns the method which snapshots what it changes out of the store before it runs, the snapshot is
+// restored if the call fails or is reverted
+func (m PrecompileMethod) WithSnapshot(snapshot func(ctx sdk.Context, args []interface{}) (restore func())) PrecompileMethod {
+	m.snapshot = snapshot
+	return m
+}
+
+// NewPrecompileMethod returns a method which runs on the native state as the caller, a view method must not write
+func NewPrecompileMethod(method abi.Method, gas uint64,
+	run func(ctx sdk.Context, caller sdk.AccAddress, args []interface{}) ([]interface{}, error)) PrecompileMethod {
+	return PrecompileMethod{Method: method, Gas: gas, run: run}
+}
+
+// NewMsgPrecompileMethod returns a method which handles the msg built from its arguments as sent by the caller, so
+// that a contract can only act on its own account. toOutputs builds the return values from the result of the msg, it
+// can be nil if the method returns nothing.
+func NewMsgPrecompileMethod(method abi.Method, gas uint64, handler sdk.Handler,
+	toMsg func(sender sdk.AccAddress, args []interface{}) (sdk.Msg, error),
+	toOutputs func(result *sdk.Result) ([]interface{}, error)) PrecompileMethod {
+	return NewPrecompileMethod(method, gas, func(ctx sdk.Context, caller sdk.AccAddress, args []interface{}) ([]interface{}, error) {
+		msg, err := toMsg(caller, args)
+		if err != nil {
+			return nil, err
+		}
+		result, err := HandlePrecompileMsg(ctx, handler, msg)
+		if err != nil || toOutputs == nil {
+			return nil, err
+		}
+		return toOutputs(result)
+	})
+}
+
+// HandlePrecompileMsg validates msg and handles it on the native state of a precompile call, the events of the
+// result are emitted along with the call
+func HandlePrecompileMsg(ctx sdk.Context, handler sdk.Handler, msg sdk.Msg) (*sdk.Result, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	result, err := handler(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	ctx.EventManager().EmitEvents(result.Events)
+	return result, nil
+}
+
+var _ StatefulPrecompiledContract = (*NativePrecompile)(nil)
+
+// NativePrecompile is a StatefulPrecompiledContract which dispatches the calls to its methods by the method id
+type NativePrecompile struct {
+	methods map[string]PrecompileMethod
+}
+
+// NewNativePrecompile returns a NativePrecompile with methods
+func NewNativePrecompile(methods ...PrecompileMethod) *NativePrecompile {
+	p := &NativePrecompile{methods: make(map[string]PrecompileMethod, len(methods))}
+	for _, m := range methods {
+		p.methods[string(m.Method.ID)] = m
+	}
+	return p
+}
+
+func (p *NativePrecompile) method(input []byte) (PrecompileMethod, bool) {
+	if len(input) < 4 {
+		return PrecompileMethod{}, false
+	}
+	m, ok := p.methods[string(input[:4])]
+	return m, ok
+}
+
+// RequiredGas implements StatefulPrecompiledContract
+func (p *NativePrecompile) RequiredGas(input []byte) uint64 {
+	m, _ := p.method(input)
+	return m.Gas
+}
+
+// Run implements StatefulPrecompiledContract
+func (p *NativePrecompile) Run(ctx sdk.Context, stateDB *CommitStateDB, caller common.Address, input []byte,
+	readOnly bool) ([]byte, error) {
+	m, ok := p.method(input)
+	if !ok {
+		return nil, errors.New("unknown precompile method")
+	}
+	if readOnly && !m.Method.IsConstant() {
+		return nil, fmt.Errorf("method %s can't be called within a static call", m.Method.Name)
+	}
+	args, err := m.Method.Inputs.Unpack(input[4:])
+	if err != nil {
+		return nil, err
+	}
+	if m.snapshot != nil && stateDB != nil {
+		if restore := m.snapshot(ctx, args); restore != nil {
+			stateDB.OnNativeRevert(restore)
+		}
+	}
+	outputs, err := m.run(ctx, sdk.AccAddress(caller.Bytes()), args)
+	if err != nil {
+		return nil, err
+	}
+	return m.Method.Outputs.Pack(outputs...)
+}
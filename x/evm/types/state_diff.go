@@ -0,0 +1,113 @@
+package types
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+	"sync"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethermint "github.com/okex/exchain/app/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/spf13/viper"
+)
+
+const (
+	FlagEvmStream = "evm-stream"
+)
+
+var (
+	enableEvmStream   bool
+	evmStreamFlagOnce sync.Once
+)
+
+// GetEnableEvmStream returns true if the node streams the evm txs it commits to external indexers,
+// the state diffs of the txs are only recorded in this case
+func GetEnableEvmStream() bool {
+	evmStreamFlagOnce.Do(func() {
+		enableEvmStream = viper.GetBool(FlagEvmStream)
+	})
+	return enableEvmStream
+}
+
+// StorageDiff is a storage slot of a contract changed by an evm tx
+type StorageDiff struct {
+	Key    ethcmn.Hash `json:"key"`
+	Before ethcmn.Hash `json:"before"`
+	After  ethcmn.Hash `json:"after"`
+}
+
+// AccountDiff is the change of an account made by an evm tx
+type AccountDiff struct {
+	Address       ethcmn.Address `json:"address"`
+	BalanceBefore *hexutil.Big   `json:"balanceBefore"`
+	BalanceAfter  *hexutil.Big   `json:"balanceAfter"`
+	NonceBefore   hexutil.Uint64 `json:"nonceBefore"`
+	NonceAfter    hexutil.Uint64 `json:"nonceAfter"`
+	// Code is only set if the code of the account was deployed by the tx
+	Code    hexutil.Bytes `json:"code,omitempty"`
+	Deleted bool          `json:"deleted,omitempty"`
+	Storage []StorageDiff `json:"storage,omitempty"`
+}
+
+// StateDiff is the accounts changed by an evm tx, sorted by address
+type StateDiff []*AccountDiff
+
+// StateDiff returns the changes of the dirty accounts made since the last Finalise. It must be called
+// before the state is finalised, as the state before the tx is read from the underlying store.
+func (csdb *CommitStateDB) StateDiff() StateDiff {
+	diff := make(StateDiff, 0, len(csdb.journal.dirties))
+	for _, dirty := range csdb.journal.dirties {
+		stateEntry, exist := csdb.stateObjects[dirty.address]
+		if !exist {
+			// see the ripeMD case of Finalise
+			continue
+		}
+		so := stateEntry.stateObject
+
+		accDiff := &AccountDiff{
+			Address:       dirty.address,
+			BalanceBefore: (*hexutil.Big)(new(big.Int)),
+			BalanceAfter:  (*hexutil.Big)(so.Balance()),
+			NonceAfter:    hexutil.Uint64(so.Nonce()),
+			Deleted:       so.suicided || so.empty(),
+		}
+		acc := csdb.accountKeeper.GetAccount(csdb.ctx, sdk.AccAddress(dirty.address.Bytes()))
+		if ethAcc, ok := acc.(*ethermint.EthAccount); ok {
+			if balance := ethAcc.Balance(sdk.DefaultBondDenom).BigInt(); balance != nil {
+				accDiff.BalanceBefore = (*hexutil.Big)(balance)
+			}
+			accDiff.NonceBefore = hexutil.Uint64(ethAcc.Sequence)
+		}
+		if so.dirtyCode {
+			accDiff.Code = hexutil.Bytes(so.code)
+		}
+
+		for _, state := range so.dirtyStorage {
+			var before ethcmn.Hash
+			if idx, ok := so.keyToOriginStorageIndex[state.Key]; ok {
+				before = so.originStorage[idx].Value
+			}
+			if before == state.Value {
+				continue
+			}
+			// the storage is keyed by the hash of the address and the slot, report the slot if it's known
+			key := state.Key
+			if rawKey, ok := so.rawStorageKeys[state.Key]; ok {
+				key = rawKey
+			}
+			accDiff.Storage = append(accDiff.Storage, StorageDiff{Key: key, Before: before, After: state.Value})
+		}
+		sort.Slice(accDiff.Storage, func(i, j int) bool {
+			return bytes.Compare(accDiff.Storage[i].Key.Bytes(), accDiff.Storage[j].Key.Bytes()) < 0
+		})
+
+		diff = append(diff, accDiff)
+	}
+
+	sort.Slice(diff, func(i, j int) bool {
+		return bytes.Compare(diff[i].Address.Bytes(), diff[j].Address.Bytes()) < 0
+	})
+	return diff
+}
@@ -125,6 +125,9 @@ type stateObject struct {
 
 	keyToOriginStorageIndex map[ethcmn.Hash]int
 	keyToDirtyStorageIndex  map[ethcmn.Hash]int
+	// rawStorageKeys maps the prefixed keys of the dirty storage to the storage slots,
+	// it's only recorded when the state diffs of the txs are streamed
+	rawStorageKeys map[ethcmn.Hash]ethcmn.Hash
 
 	address ethcmn.Address
 
@@ -173,6 +176,12 @@ func (so *stateObject) SetState(db ethstate.Database, key, value ethcmn.Hash) {
 	}
 
 	prefixKey := so.GetStorageByAddressKey(key.Bytes())
+	if GetEnableEvmStream() {
+		if so.rawStorageKeys == nil {
+			so.rawStorageKeys = make(map[ethcmn.Hash]ethcmn.Hash)
+		}
+		so.rawStorageKeys[prefixKey] = key
+	}
 
 	// since the new value is different, update and journal the change
 	so.stateDB.journal.append(storageChange{
@@ -341,6 +350,7 @@ func (so *stateObject) commitState() {
 	}
 	// clean storage as all entries are dirty
 	so.dirtyStorage = Storage{}
+	so.rawStorageKeys = nil
 }
 
 // commitCode persists the state object's code to the KVStore.
@@ -490,6 +500,12 @@ func (so *stateObject) deepCopy(db *CommitStateDB) *stateObject {
 	newStateObj.suicided = so.suicided
 	newStateObj.dirtyCode = so.dirtyCode
 	newStateObj.deleted = so.deleted
+	if so.rawStorageKeys != nil {
+		newStateObj.rawStorageKeys = make(map[ethcmn.Hash]ethcmn.Hash, len(so.rawStorageKeys))
+		for prefixKey, key := range so.rawStorageKeys {
+			newStateObj.rawStorageKeys[prefixKey] = key
+		}
+	}
 
 	return newStateObj
 }
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// OverrideAccount indicates the overriding fields of an account during the execution of a simulated call.
+// Note, state and stateDiff can't be specified at the same time. If state is set, the execution will use
+// the given storage only, while stateDiff only replaces the given slots.
+type OverrideAccount struct {
+	Nonce     *hexutil.Uint64              `json:"nonce"`
+	Code      *hexutil.Bytes               `json:"code"`
+	Balance   **hexutil.Big                `json:"balance"`
+	State     *map[ethcmn.Hash]ethcmn.Hash `json:"state"`
+	StateDiff *map[ethcmn.Hash]ethcmn.Hash `json:"stateDiff"`
+}
+
+// StateOverride is the collection of the overridden accounts
+type StateOverride map[ethcmn.Address]OverrideAccount
+
+// Apply overrides the fields of the specified accounts into the state db
+func (diff *StateOverride) Apply(csdb *CommitStateDB) error {
+	if diff == nil {
+		return nil
+	}
+	for addr, account := range *diff {
+		if account.Nonce != nil {
+			csdb.SetNonce(addr, uint64(*account.Nonce))
+		}
+		if account.Code != nil {
+			csdb.SetCode(addr, *account.Code)
+		}
+		if account.Balance != nil {
+			balance := new(big.Int)
+			if *account.Balance != nil {
+				balance = (*account.Balance).ToInt()
+			}
+			csdb.SetBalance(addr, balance)
+		}
+		if account.State != nil && account.StateDiff != nil {
+			return fmt.Errorf("account %s has both 'state' and 'stateDiff'", addr.Hex())
+		}
+		if account.State != nil {
+			csdb.SetStorage(addr, *account.State)
+		}
+		if account.StateDiff != nil {
+			for key, value := range *account.StateDiff {
+				csdb.SetState(addr, key, value)
+			}
+		}
+	}
+	return nil
+}
@@ -15,7 +15,6 @@ import (
 	"github.com/ethereum/go-ethereum/core/vm"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
-	"github.com/okex/exchain/libs/cosmos-sdk/types/innertx"
 	"github.com/okex/exchain/x/common/analyzer"
 )
 
@@ -28,6 +27,7 @@ type StateTransition struct {
 	Recipient    *common.Address
 	Amount       *big.Int
 	Payload      []byte
+	AccessList   ethtypes.AccessList
 
 	ChainID    *big.Int
 	Csdb       *CommitStateDB
@@ -53,6 +53,8 @@ type ExecutionResult struct {
 	Result    *sdk.Result
 	GasInfo   GasInfo
 	TraceLogs []byte
+	// StateDiff is only recorded when the evm txs are streamed
+	StateDiff StateDiff
 }
 
 // GetHashFn implements vm.GetHashFunc for Ethermint. It handles 3 cases:
@@ -79,6 +81,15 @@ func GetHashFn(ctx sdk.Context, csdb *CommitStateDB) vm.GetHashFunc {
 	}
 }
 
+// CanTransfer checks whether the spendable balance of the account covers the amount,
+// so that the coins locked by the vesting schedule cannot be transferred in the evm
+func CanTransfer(db vm.StateDB, addr common.Address, amount *big.Int) bool {
+	if csdb, ok := db.(*CommitStateDB); ok {
+		return csdb.GetSpendableBalance(addr).Cmp(amount) >= 0
+	}
+	return core.CanTransfer(db, addr, amount)
+}
+
 func (st *StateTransition) newEVM(
 	ctx sdk.Context,
 	csdb *CommitStateDB,
@@ -89,7 +100,7 @@ func (st *StateTransition) newEVM(
 ) *vm.EVM {
 	// Create context for evm
 	blockCtx := vm.BlockContext{
-		CanTransfer: core.CanTransfer,
+		CanTransfer: CanTransfer,
 		Transfer:    core.Transfer,
 		GetHash:     GetHashFn(ctx, csdb),
 		Coinbase:    common.BytesToAddress(ctx.BlockProposerAddress()),
@@ -126,7 +137,7 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 
 	contractCreation := st.Recipient == nil
 
-	cost, err := core.IntrinsicGas(st.Payload, []ethtypes.AccessTuple{}, contractCreation, config.IsHomestead(), config.IsIstanbul())
+	cost, err := core.IntrinsicGas(st.Payload, st.AccessList, contractCreation, config.IsHomestead(), config.IsIstanbul())
 	if err != nil {
 		return exeRes, resData, sdkerrors.Wrap(err, "invalid intrinsic gas for transaction"), innerTxs, erc20Contracts
 	}
@@ -168,15 +179,23 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 		to = EthAddressToString(st.Recipient)
 		recipientStr = to
 	}
-	tracer := newTracer(ctx, st.TxHash)
+	tracer := newTracer(ctx, st.TxHash, st.GasLimit)
+	// the inner txs are only recorded when the tx is delivered, and are rebuilt from the call frames of the tx
+	var innerTxTracer *callTracer
+	if GetEnableInnerTx() && !st.Simulate && !st.TraceTx && !st.TraceTxLog {
+		innerTxTracer = newInnerTxTracer(st.GasLimit)
+		tracer = innerTxTracer
+	}
 	vmConfig := vm.Config{
 		ExtraEips:        params.ExtraEIPs,
-		Debug:            st.TraceTxLog,
+		Debug:            st.TraceTxLog || innerTxTracer != nil,
 		Tracer:           tracer,
 		ContractVerifier: NewContractVerifier(params),
 	}
 
 	evm := st.newEVM(ctx, csdb, gasLimit, st.Price, &config, vmConfig)
+	// drop the call to a stateful precompile which is verified but never run
+	defer setPrecompileCall(csdb, nil)
 
 	var (
 		ret             []byte
@@ -186,14 +205,16 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 		senderRef       = vm.AccountRef(st.Sender)
 	)
 
+	// warm up the addresses and slots of the access list since berlin
+	if rules := evm.ChainConfig().Rules(evm.Context.BlockNumber); rules.IsBerlin {
+		csdb.PrepareAccessList(st.Sender, st.Recipient, vm.ActivePrecompiles(rules), st.AccessList)
+	}
+
 	// Get nonce of account outside of the EVM
 	currentNonce := csdb.GetNonce(st.Sender)
 	// Set nonce of sender account before evm state transition for usage in generating Create address
 	csdb.SetNonce(st.Sender, st.AccountNonce)
 
-	//add InnerTx
-	callTx := innertx.AddDefaultInnerTx(evm, innertx.CosmosDepth, senderStr, "", "", "", st.Amount, nil)
-
 	// create contract or execute call
 	switch contractCreation {
 	case true:
@@ -213,8 +234,6 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 
 		contractAddressStr := EthAddressToString(&contractAddress)
 		recipientLog = strings.Join([]string{"contract address ", contractAddressStr}, "")
-
-		innertx.UpdateDefaultInnerTx(callTx, contractAddressStr, innertx.CosmosCallType, innertx.EvmCreateName, gasLimit-leftOverGas)
 	default:
 		if !params.EnableCall {
 			return exeRes, resData, ErrCallDisabled, innerTxs, erc20Contracts
@@ -231,13 +250,13 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 		}
 
 		recipientLog = strings.Join([]string{"recipient address ", recipientStr}, "")
-
-		innertx.UpdateDefaultInnerTx(callTx, recipientStr, innertx.CosmosCallType, innertx.EvmCallName, gasLimit-leftOverGas)
 	}
 
 	gasConsumed := gasLimit - leftOverGas
 
-	innerTxs, erc20Contracts = innertx.ParseInnerTxAndContract(evm, err != nil)
+	if innerTxTracer != nil {
+		innerTxs = innerTxTracer.innerTxs()
+	}
 
 	defer func() {
 		// Consume gas from evm execution
@@ -289,6 +308,12 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 		bloomFilter = ethtypes.BytesToBloom(bloomInt.Bytes())
 	}
 
+	// the state diff must be taken before the state is finalised into the store
+	var stateDiff StateDiff
+	if GetEnableEvmStream() && !st.Simulate && !st.TraceTx && !st.TraceTxLog {
+		stateDiff = csdb.StateDiff()
+	}
+
 	if !st.Simulate || st.TraceTx {
 		// Finalise state if not a simulated transaction or a trace tx
 		// TODO: change to depend on config
@@ -331,6 +356,7 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 			GasLimit:    gasLimit,
 			GasRefunded: leftOverGas,
 		},
+		StateDiff: stateDiff,
 	}
 	return
 }
@@ -129,6 +129,22 @@ type CommitStateDB struct {
 
 	// Amino codec
 	cdc *codec.Codec
+
+	// Branches of the native state written by the stateful precompiles, they
+	// are written into the context of the tx when the state is finalised.
+	nativeWrites []nativeWrite
+	// Functions undoing what the running native call changes out of the store.
+	nativeReverts []func()
+	// The call to a stateful precompile verified right before the evm runs it.
+	precompileCall *precompileCall
+}
+
+// nativeWrite is a branch of the native state written by a stateful precompile
+type nativeWrite struct {
+	parent sdk.Context
+	write  func()
+	cache  *sdk.Cache
+	events sdk.Events
 }
 
 type StoreProxy interface {
@@ -212,6 +228,7 @@ func (csdb *CommitStateDB) SetInternalDb(dba DbAdapter) {
 // WithContext returns a Database with an updated SDK context
 func (csdb *CommitStateDB) WithContext(ctx sdk.Context) *CommitStateDB {
 	csdb.ctx = ctx
+	csdb.nativeWrites = nil
 	return csdb
 }
 
@@ -324,6 +341,25 @@ func (csdb *CommitStateDB) SetState(addr ethcmn.Address, key, value ethcmn.Hash)
 	}
 }
 
+// SetStorage replaces the entire storage of an account with the given one. It's only used to override
+// the state of the simulated calls, the cleared slots are not journaled.
+func (csdb *CommitStateDB) SetStorage(addr ethcmn.Address, storage map[ethcmn.Hash]ethcmn.Hash) {
+	csdb.GetOrNewStateObject(addr)
+	so := csdb.getStateObject(addr)
+	if so == nil {
+		return
+	}
+
+	// the keys iterated are already prefixed by GetStorageByAddressKey
+	_ = csdb.ForEachStorage(addr, func(key, _ ethcmn.Hash) bool {
+		so.setState(key, ethcmn.Hash{})
+		return false
+	})
+	for key, value := range storage {
+		so.SetState(nil, key, value)
+	}
+}
+
 // SetCode sets the code for a given account.
 func (csdb *CommitStateDB) SetCode(addr ethcmn.Address, code []byte) {
 	if !csdb.ctx.IsCheckTx() {
@@ -471,7 +507,7 @@ func (csdb *CommitStateDB) PrepareAccessList(sender ethcmn.Address, dest *ethcmn
 	}
 
 	csdb.AddAddressToAccessList(sender)
-	if csdb != nil {
+	if dest != nil {
 		csdb.AddAddressToAccessList(*dest)
 		// If it's a create-tx, the destination will be added inside evm.create
 	}
@@ -560,6 +596,36 @@ func (csdb *CommitStateDB) GetBalance(addr ethcmn.Address) *big.Int {
 	return zeroBalance
 }
 
+// GetSpendableBalance returns the balance of the account excluding the coins locked by its vesting schedule
+func (csdb *CommitStateDB) GetSpendableBalance(addr ethcmn.Address) *big.Int {
+	so := csdb.getStateObject(addr)
+	if so == nil {
+		return zeroBalance
+	}
+
+	balance := so.Balance()
+	if so.account.VestingSchedule == nil {
+		return balance
+	}
+	locked := so.account.LockedCoins(csdb.ctx.BlockTime()).AmountOf(sdk.DefaultBondDenom).BigInt()
+	if locked.Cmp(balance) >= 0 {
+		return zeroBalance
+	}
+	return new(big.Int).Sub(balance, locked)
+}
+
+// verifyVestingLock checks whether the amount subtracted from the balance of the account leaves the
+// coins locked by its vesting schedule in place
+func (csdb *CommitStateDB) verifyVestingLock(addr ethcmn.Address, amount *big.Int) error {
+	if amount == nil || amount.Sign() == 0 {
+		return nil
+	}
+	if spendable := csdb.GetSpendableBalance(addr); spendable.Cmp(amount) < 0 {
+		return ErrLockedCoins(addr, amount, spendable)
+	}
+	return nil
+}
+
 // GetNonce returns the nonce (sequence number) for a given account.
 func (csdb *CommitStateDB) GetNonce(addr ethcmn.Address) uint64 {
 	if !csdb.ctx.IsCheckTx() {
@@ -758,6 +824,7 @@ func (csdb *CommitStateDB) StorageTrie(addr ethcmn.Address) ethstate.Trie {
 // be written. Finally, the root hash (version) will be returned.
 func (csdb *CommitStateDB) Commit(deleteEmptyObjects bool) (ethcmn.Hash, error) {
 	defer csdb.clearJournalAndRefund()
+	csdb.flushNativeWrites()
 
 	// remove dirty state object entries based on the journal
 	for _, dirty := range csdb.journal.dirties {
@@ -800,6 +867,7 @@ func (csdb *CommitStateDB) Commit(deleteEmptyObjects bool) (ethcmn.Hash, error)
 // removing the csdb destructed objects and clearing the journal as well as the
 // refunds.
 func (csdb *CommitStateDB) Finalise(deleteEmptyObjects bool) error {
+	csdb.flushNativeWrites()
 	for _, dirty := range csdb.journal.dirties {
 		stateEntry, exist := csdb.stateObjects[dirty.address]
 		if !exist {
@@ -889,6 +957,101 @@ func (csdb *CommitStateDB) deleteStateObject(so *stateObject) {
 	csdb.accountKeeper.RemoveAccount(csdb.ctx, so.account)
 }
 
+// CallNative runs fn on a branch of the native state with gas as the gas limit,
+// it is how a stateful precompile reaches the native modules. The live accounts
+// are written into the branch before fn and read back after it, and the branch
+// is journaled so that it is reverted along with the evm call. The branch of a
+// read only call is dropped.
+func (csdb *CommitStateDB) CallNative(gas uint64, readOnly bool, fn func(ctx sdk.Context) ([]byte, error)) ([]byte, error) {
+	ctx, write := csdb.ctx.CacheContext()
+	cache := sdk.NewCache(csdb.ctx.Cache(), true)
+	ctx.SetCache(cache)
+	for _, stateEntry := range csdb.stateObjects {
+		if so := stateEntry.stateObject; so != nil && !so.deleted && !so.suicided {
+			csdb.accountKeeper.SetAccount(ctx, so.account)
+		}
+	}
+
+	gasMeter := ctx.GasMeter()
+	ctx.SetGasMeter(sdk.NewGasMeter(gas))
+	csdb.nativeReverts = nil
+	ret, err := runNative(ctx, fn)
+	reverts := csdb.nativeReverts
+	csdb.nativeReverts = nil
+	ctx.SetGasMeter(gasMeter)
+	if err != nil || readOnly {
+		revertNative(reverts)
+		return ret, err
+	}
+
+	prevAccounts := make(map[ethcmn.Address]*ethermint.EthAccount)
+	for addr, stateEntry := range csdb.stateObjects {
+		so := stateEntry.stateObject
+		if so == nil || so.deleted || so.suicided {
+			continue
+		}
+		if acc, ok := csdb.accountKeeper.GetAccount(ctx, addr.Bytes()).(*ethermint.EthAccount); ok {
+			prevAccounts[addr] = so.account
+			so.account = acc
+		}
+	}
+
+	csdb.journal.append(nativeChange{prevCtx: csdb.ctx, prevAccounts: prevAccounts, reverts: reverts})
+	csdb.nativeWrites = append(csdb.nativeWrites, nativeWrite{
+		parent: csdb.ctx,
+		write:  write,
+		cache:  cache,
+		events: ctx.EventManager().Events(),
+	})
+	csdb.ctx = ctx
+	return ret, nil
+}
+
+// OnNativeRevert registers revert to undo what the running native call changes
+// out of the store, e.g. the memory caches of a keeper. It is called if the
+// call fails, or is reverted along with the evm call.
+func (csdb *CommitStateDB) OnNativeRevert(revert func()) {
+	csdb.nativeReverts = append(csdb.nativeReverts, revert)
+}
+
+// revertNative calls reverts in the reverse order they are registered
+func revertNative(reverts []func()) {
+	for i := len(reverts) - 1; i >= 0; i-- {
+		reverts[i]()
+	}
+}
+
+// runNative runs fn and turns running out of the gas limit of ctx into ErrOutOfGas
+func runNative(ctx sdk.Context, fn func(ctx sdk.Context) ([]byte, error)) (ret []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(sdk.ErrorOutOfGas); !ok {
+				panic(r)
+			}
+			ret, err = nil, ethvm.ErrOutOfGas
+		}
+	}()
+	return fn(ctx)
+}
+
+// flushNativeWrites writes the branches of the native state into the context
+// they are branched from, and emits their events in order.
+func (csdb *CommitStateDB) flushNativeWrites() {
+	if len(csdb.nativeWrites) == 0 {
+		return
+	}
+
+	for i := len(csdb.nativeWrites) - 1; i >= 0; i-- {
+		csdb.nativeWrites[i].write()
+		csdb.nativeWrites[i].cache.Write(true)
+	}
+	csdb.ctx = csdb.nativeWrites[0].parent
+	for _, w := range csdb.nativeWrites {
+		csdb.ctx.EventManager().EmitEvents(w.events)
+	}
+	csdb.nativeWrites = nil
+}
+
 // ----------------------------------------------------------------------------
 // Snapshotting
 // ----------------------------------------------------------------------------
@@ -1087,6 +1250,8 @@ func (csdb *CommitStateDB) CreateAccount(addr ethcmn.Address) {
 	newobj, prevobj := csdb.createObject(addr)
 	if prevobj != nil {
 		newobj.setBalance(sdk.DefaultBondDenom, sdk.NewDecFromBigIntWithPrec(prevobj.Balance(), sdk.Precision)) // int2dec
+		// a contract created at a vesting address keeps the coins locked
+		newobj.account.VestingSchedule = prevobj.account.VestingSchedule
 	}
 }
 
@@ -6,13 +6,16 @@ import (
 	"testing"
 
 	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethvm "github.com/ethereum/go-ethereum/core/vm"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/okex/exchain/app"
 	"github.com/okex/exchain/app/crypto/ethsecp256k1"
 	ethermint "github.com/okex/exchain/app/types"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/mint"
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
 	"github.com/okex/exchain/x/evm/types"
 	"github.com/stretchr/testify/suite"
@@ -604,6 +607,53 @@ func (suite *StateDBTestSuite) TestCommitStateDB_Snapshot() {
 	}, "invalid revision should panic")
 }
 
+func (suite *StateDBTestSuite) TestCommitStateDB_CallNative() {
+	coins := sdk.SysCoins{sdk.NewDecCoinFromDec("usdk", sdk.NewDec(10))}
+	mintTo := func(ctx sdk.Context) ([]byte, error) {
+		if err := suite.app.SupplyKeeper.MintCoins(ctx, mint.ModuleName, coins); err != nil {
+			return nil, err
+		}
+		return []byte{1}, suite.app.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, mint.ModuleName, suite.address.Bytes(), coins)
+	}
+	balanceOf := func() sdk.Dec {
+		return suite.app.AccountKeeper.GetAccount(suite.ctx, suite.address.Bytes()).GetCoins().AmountOf("usdk")
+	}
+	reverted := 0
+	onRevert := func(ctx sdk.Context) ([]byte, error) {
+		suite.stateDB.OnNativeRevert(func() { reverted++ })
+		return mintTo(ctx)
+	}
+	suite.stateDB.SetBalance(suite.address, big.NewInt(100))
+
+	// the branch is reverted along with the snapshot
+	id := suite.stateDB.Snapshot()
+	ret, err := suite.stateDB.CallNative(100000, false, onRevert)
+	suite.Require().NoError(err)
+	suite.Require().Equal([]byte{1}, ret)
+	suite.stateDB.RevertToSnapshot(id)
+	suite.Require().Equal(1, reverted)
+
+	// a read only or failing call is dropped at once
+	_, err = suite.stateDB.CallNative(100000, true, onRevert)
+	suite.Require().NoError(err)
+	_, err = suite.stateDB.CallNative(10, false, onRevert)
+	suite.Require().Equal(ethvm.ErrOutOfGas, err)
+	suite.Require().Equal(3, reverted)
+	suite.Require().NoError(suite.stateDB.Finalise(true))
+	suite.Require().True(balanceOf().IsZero())
+
+	// the account written by the evm is kept along with the coins written by the native call
+	suite.stateDB.SetBalance(suite.address, big.NewInt(200))
+	_, err = suite.stateDB.CallNative(100000, false, mintTo)
+	suite.Require().NoError(err)
+	suite.Require().Equal(big.NewInt(200), suite.stateDB.GetBalance(suite.address))
+	suite.Require().True(balanceOf().IsZero())
+	suite.Require().NoError(suite.stateDB.Finalise(true))
+	suite.Require().Equal(sdk.NewDec(10), balanceOf())
+	suite.Require().Equal(big.NewInt(200), suite.stateDB.GetBalance(suite.address))
+	suite.Require().Equal(3, reverted)
+}
+
 func (suite *StateDBTestSuite) TestCommitStateDB_ForEachStorage() {
 	var storage types.Storage
 
@@ -672,6 +722,48 @@ func (suite *StateDBTestSuite) TestCommitStateDB_ForEachStorage() {
 	}
 }
 
+func (suite *StateDBTestSuite) TestCommitStateDB_StateOverride() {
+	key1 := ethcmn.BytesToHash([]byte("key1"))
+	key2 := ethcmn.BytesToHash([]byte("key2"))
+	value := ethcmn.BytesToHash([]byte("value"))
+	newValue := ethcmn.BytesToHash([]byte("newValue"))
+
+	suite.stateDB.SetState(suite.address, key1, value)
+	suite.stateDB.SetState(suite.address, key2, value)
+	suite.Require().NoError(suite.stateDB.Finalise(false))
+	_, err := suite.stateDB.Commit(false)
+	suite.Require().NoError(err)
+
+	nonce := hexutil.Uint64(10)
+	balance := (*hexutil.Big)(big.NewInt(100))
+	code := hexutil.Bytes("code")
+	stateDiff := map[ethcmn.Hash]ethcmn.Hash{key1: newValue}
+	override := types.StateOverride{
+		suite.address: types.OverrideAccount{
+			Nonce:     &nonce,
+			Balance:   &balance,
+			Code:      &code,
+			StateDiff: &stateDiff,
+		},
+	}
+	suite.Require().NoError(override.Apply(suite.stateDB))
+	suite.Require().Equal(uint64(10), suite.stateDB.GetNonce(suite.address))
+	suite.Require().Equal(big.NewInt(100), suite.stateDB.GetBalance(suite.address))
+	suite.Require().Equal([]byte("code"), suite.stateDB.GetCode(suite.address))
+	suite.Require().Equal(newValue, suite.stateDB.GetState(suite.address, key1))
+	suite.Require().Equal(value, suite.stateDB.GetState(suite.address, key2))
+
+	// state replaces the whole storage
+	state := map[ethcmn.Hash]ethcmn.Hash{key2: newValue}
+	override = types.StateOverride{suite.address: types.OverrideAccount{State: &state}}
+	suite.Require().NoError(override.Apply(suite.stateDB))
+	suite.Require().Equal(ethcmn.Hash{}, suite.stateDB.GetState(suite.address, key1))
+	suite.Require().Equal(newValue, suite.stateDB.GetState(suite.address, key2))
+
+	override = types.StateOverride{suite.address: types.OverrideAccount{State: &state, StateDiff: &stateDiff}}
+	suite.Require().Error(override.Apply(suite.stateDB))
+}
+
 func (suite *StateDBTestSuite) TestCommitStateDB_AccessList() {
 	addr := ethcmn.Address([20]byte{77})
 	hash := ethcmn.Hash([32]byte{99})
@@ -688,6 +780,14 @@ func (suite *StateDBTestSuite) TestCommitStateDB_AccessList() {
 	addrIn, slotIn = suite.stateDB.SlotInAccessList(addr, hash)
 	suite.Require().True(addrIn)
 	suite.Require().True(slotIn)
+
+	// a contract creation has no destination to warm up
+	sender, precompile := ethcmn.Address([20]byte{78}), ethcmn.Address([20]byte{1})
+	suite.Require().NotPanics(func() {
+		suite.stateDB.PrepareAccessList(sender, nil, []ethcmn.Address{precompile}, nil)
+	})
+	suite.Require().True(suite.stateDB.AddressInAccessList(sender))
+	suite.Require().True(suite.stateDB.AddressInAccessList(precompile))
 }
 
 func (suite *StateDBTestSuite) TestCommitStateDB_ContractDeploymentWhitelist() {
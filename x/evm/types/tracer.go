@@ -1,6 +1,7 @@
 package types
 
 import (
+	stdjson "encoding/json"
 	"fmt"
 	"math/big"
 	"time"
@@ -14,8 +15,10 @@ import (
 )
 
 type TraceConfig struct {
-	// custom javascript tracer
+	// custom javascript tracer, or the name of a native tracer
 	Tracer string `json:"tracer"`
+	// config specific to the native tracer
+	TracerConfig stdjson.RawMessage `json:"tracerConfig,omitempty"`
 	// disable stack capture
 	DisableStack bool `json:"disableStack"`
 	// disable storage capture
@@ -28,6 +31,12 @@ type TraceConfig struct {
 	DisableReturnData bool `json:"disableReturnData"`
 }
 
+// TraceCallConfig is the config for tracing a call, the state overrides are applied before the execution
+type TraceCallConfig struct {
+	TraceConfig
+	StateOverrides *StateOverride `json:"stateOverrides,omitempty"`
+}
+
 func GetTracerResult(tracer vm.Tracer, result *core.ExecutionResult) ([]byte, error) {
 	var (
 		res []byte
@@ -49,6 +58,8 @@ func GetTracerResult(tracer vm.Tracer, result *core.ExecutionResult) ([]byte, er
 		})
 	case *tracers.Tracer:
 		res, err = tracer.GetResult()
+	case nativeTracer:
+		res, err = tracer.GetResult()
 	default:
 		res = []byte(fmt.Sprintf("bad tracer type %T", tracer))
 	}
@@ -132,6 +143,10 @@ func defaultTracerConfig() *TraceConfig {
 	}
 }
 func TestTracerConfig(traceConfig *TraceConfig) error {
+	if IsNativeTracer(traceConfig.Tracer) {
+		_, err := newNativeTracer(traceConfig.Tracer, &nativeTracerContext{}, traceConfig.TracerConfig)
+		return err
+	}
 	if traceConfig.Tracer != "" {
 		_, err := tracers.New(traceConfig.Tracer, &tracers.Context{})
 		if err != nil {
@@ -140,7 +155,7 @@ func TestTracerConfig(traceConfig *TraceConfig) error {
 	}
 	return nil
 }
-func newTracer(ctx sdk.Context, txHash *common.Hash) (tracer vm.Tracer) {
+func newTracer(ctx sdk.Context, txHash *common.Hash, gasLimit uint64) (tracer vm.Tracer) {
 	if ctx.IsTraceTxLog() {
 		var err error
 		configBytes := ctx.TraceTxLogConfig()
@@ -164,6 +179,17 @@ func newTracer(ctx sdk.Context, txHash *common.Hash) (tracer vm.Tracer) {
 			}
 			return vm.NewStructLogger(&logConfig)
 		}
+		if IsNativeTracer(traceConfig.Tracer) {
+			nCtx := &nativeTracerContext{
+				TxHash:   *txHash,
+				GasLimit: gasLimit,
+			}
+			tracer, err = newNativeTracer(traceConfig.Tracer, nCtx, traceConfig.TracerConfig)
+			if err != nil {
+				return NewNoOpTracer()
+			}
+			return tracer
+		}
 		// Json-based tracer
 		tCtx := &tracers.Context{
 			TxHash: *txHash,
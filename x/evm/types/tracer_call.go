@@ -0,0 +1,278 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const callTracerInternalFailure = "internal failure"
+
+// callFrame is a call (or create) made during the tx, in the json format of geth's native callTracer
+type callFrame struct {
+	Type         string          `json:"type"`
+	From         common.Address  `json:"from"`
+	Gas          hexutil.Uint64  `json:"gas"`
+	GasUsed      hexutil.Uint64  `json:"gasUsed"`
+	To           *common.Address `json:"to,omitempty"`
+	Input        hexutil.Bytes   `json:"input"`
+	Output       hexutil.Bytes   `json:"output,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	RevertReason string          `json:"revertReason,omitempty"`
+	Calls        []*callFrame    `json:"calls,omitempty"`
+	Value        *hexutil.Big    `json:"value,omitempty"`
+
+	// gasIn and gasCost are the gas left and the cost of the opcode which opened the frame
+	gasIn   uint64
+	gasCost uint64
+	// gasKnown is false until the frame executed its first opcode, calls to accounts without code never do
+	gasKnown bool
+}
+
+func (f *callFrame) setRevert(output []byte) {
+	f.Error = vm.ErrExecutionReverted.Error()
+	f.Output = common.CopyBytes(output)
+	if reason, err := abi.UnpackRevert(output); err == nil {
+		f.RevertReason = reason
+	}
+}
+
+type callTracerConfig struct {
+	// OnlyTopCall skips the sub-calls and only reports the top-level call
+	OnlyTopCall bool `json:"onlyTopCall"`
+}
+
+// callTracer reports all the internal calls made by a tx. The vm of this chain only notifies the tracer about
+// the top-level call, so the sub-calls are rebuilt from the CALL/CREATE opcodes and the depth changes,
+// the same way the javascript callTracer does.
+type callTracer struct {
+	config      callTracerConfig
+	gasLimit    uint64
+	startGas    uint64
+	callstack   []*callFrame
+	descended   bool
+	precompiles map[common.Address]struct{}
+}
+
+func newCallTracer(tCtx *nativeTracerContext, cfg json.RawMessage) (nativeTracer, error) {
+	var config callTracerConfig
+	if err := unmarshalTracerConfig(cfg, &config); err != nil {
+		return nil, err
+	}
+	return &callTracer{
+		config:    config,
+		gasLimit:  tCtx.GasLimit,
+		callstack: []*callFrame{{}},
+	}, nil
+}
+
+// CaptureStart implements vm.Tracer interface
+func (t *callTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	t.precompiles = make(map[common.Address]struct{})
+	for _, addr := range vm.ActivePrecompiles(env.ChainConfig().Rules(env.Context.BlockNumber)) {
+		t.precompiles[addr] = struct{}{}
+	}
+
+	top := t.callstack[0]
+	top.Type = vm.CALL.String()
+	if create {
+		top.Type = vm.CREATE.String()
+	}
+	top.From = from
+	top.To = &to
+	top.Input = common.CopyBytes(input)
+	t.startGas = gas
+	top.Gas = hexutil.Uint64(gas)
+	if t.gasLimit > gas {
+		// report the gas of the whole tx, intrinsic gas included
+		top.Gas = hexutil.Uint64(t.gasLimit)
+	}
+	if value != nil {
+		top.Value = (*hexutil.Big)(new(big.Int).Set(value))
+	}
+}
+
+// CaptureState implements vm.Tracer interface
+func (t *callTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+	if t.config.OnlyTopCall {
+		return
+	}
+	if err != nil {
+		t.fault(err)
+		return
+	}
+
+	stack := scope.Stack
+	caller := scope.Contract.Address()
+	switch op {
+	case vm.CREATE, vm.CREATE2:
+		t.callstack = append(t.callstack, &callFrame{
+			Type:    op.String(),
+			From:    caller,
+			Input:   memoryCopy(scope.Memory, stackUint64(stack, 1), stackUint64(stack, 2)),
+			Value:   (*hexutil.Big)(stackBig(stack, 0)),
+			gasIn:   gas,
+			gasCost: cost,
+		})
+		t.descended = true
+		return
+	case vm.SELFDESTRUCT:
+		to := stackAddress(stack, 0)
+		t.appendCall(&callFrame{
+			Type:  op.String(),
+			From:  caller,
+			To:    &to,
+			Value: (*hexutil.Big)(env.StateDB.GetBalance(caller)),
+		})
+		return
+	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+		to := stackAddress(stack, 1)
+		// precompiles are just fancy opcodes
+		if _, ok := t.precompiles[to]; ok {
+			return
+		}
+		off := 1
+		if op == vm.DELEGATECALL || op == vm.STATICCALL {
+			off = 0
+		}
+		call := &callFrame{
+			Type:    op.String(),
+			From:    caller,
+			To:      &to,
+			Input:   memoryCopy(scope.Memory, stackUint64(stack, 2+off), stackUint64(stack, 3+off)),
+			Gas:     hexutil.Uint64(stackUint64(stack, 0)),
+			gasIn:   gas,
+			gasCost: cost,
+		}
+		if op == vm.CALL || op == vm.CALLCODE {
+			call.Value = (*hexutil.Big)(stackBig(stack, 2))
+		}
+		t.callstack = append(t.callstack, call)
+		t.descended = true
+		return
+	}
+
+	// the first opcode of the inner call tells its true allowance (2300 stipend, 63/64 rule)
+	if t.descended {
+		if depth >= len(t.callstack) {
+			top := t.callstack[len(t.callstack)-1]
+			top.Gas = hexutil.Uint64(gas)
+			top.gasKnown = true
+		}
+		t.descended = false
+	}
+
+	if op == vm.REVERT {
+		t.callstack[len(t.callstack)-1].setRevert(memoryCopy(scope.Memory, stackUint64(stack, 0), stackUint64(stack, 1)))
+		return
+	}
+
+	// the inner call returned to its caller
+	if depth == len(t.callstack)-1 {
+		call := t.callstack[len(t.callstack)-1]
+		t.callstack = t.callstack[:len(t.callstack)-1]
+
+		if call.Type == vm.CREATE.String() || call.Type == vm.CREATE2.String() {
+			// all but one 64th of the gas left is passed to the created contract
+			allowance := call.gasIn - call.gasCost
+			call.Gas = hexutil.Uint64(allowance - allowance/64)
+			call.GasUsed = hexutil.Uint64(allowance - gas)
+			if !stackIsZero(stack, 0) {
+				to := stackAddress(stack, 0)
+				call.To = &to
+				call.Output = env.StateDB.GetCode(to)
+			} else if call.Error == "" {
+				call.Error = callTracerInternalFailure
+			}
+		} else {
+			if call.gasKnown {
+				call.GasUsed = hexutil.Uint64(call.gasIn - call.gasCost + uint64(call.Gas) - gas)
+			} else {
+				// the callee has no code, its allowance is the requested gas capped by the opcode cost
+				if uint64(call.Gas) > call.gasCost {
+					call.Gas = hexutil.Uint64(call.gasCost)
+				}
+				if call.Value != nil && call.Value.ToInt().Sign() != 0 {
+					call.Gas += hexutil.Uint64(params.CallStipend)
+				}
+			}
+			if !stackIsZero(stack, 0) {
+				call.Output = common.CopyBytes(rData)
+			} else if call.Error == "" {
+				call.Error = callTracerInternalFailure
+			}
+		}
+		t.appendCall(call)
+	}
+}
+
+// CaptureFault implements vm.Tracer interface
+func (t *callTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+	if t.config.OnlyTopCall {
+		return
+	}
+	t.fault(err)
+}
+
+// CaptureEnd implements vm.Tracer interface
+func (t *callTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) {
+	top := t.callstack[0]
+	intrinsicGas := uint64(0)
+	if t.gasLimit > t.startGas {
+		intrinsicGas = t.gasLimit - t.startGas
+	}
+	top.GasUsed = hexutil.Uint64(intrinsicGas + gasUsed)
+	top.Output = common.CopyBytes(output)
+	if err == nil {
+		top.Error = ""
+		top.RevertReason = ""
+		return
+	}
+	if errors.Is(err, vm.ErrExecutionReverted) {
+		top.setRevert(output)
+		return
+	}
+	top.Error = err.Error()
+	top.RevertReason = ""
+	top.Output = nil
+}
+
+// fault pops the failed call off the stack and flattens it into its caller
+func (t *callTracer) fault(err error) {
+	// the failed call has already reverted, don't handle the additional fault again
+	if t.callstack[len(t.callstack)-1].Error != "" {
+		return
+	}
+	call := t.callstack[len(t.callstack)-1]
+	t.callstack = t.callstack[:len(t.callstack)-1]
+	call.Error = err.Error()
+	if call.gasKnown {
+		call.GasUsed = call.Gas
+	}
+	if len(t.callstack) == 0 {
+		// the top-level call failed, leave it in the stack
+		t.callstack = append(t.callstack, call)
+		return
+	}
+	t.appendCall(call)
+}
+
+func (t *callTracer) appendCall(call *callFrame) {
+	parent := t.callstack[len(t.callstack)-1]
+	parent.Calls = append(parent.Calls, call)
+}
+
+// GetResult returns the json encoded top-level call frame
+func (t *callTracer) GetResult() (json.RawMessage, error) {
+	if len(t.callstack) != 1 {
+		return nil, errors.New("incorrect number of top-level calls")
+	}
+	return json.Marshal(t.callstack[0])
+}
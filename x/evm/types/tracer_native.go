@@ -0,0 +1,103 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// nativeTracer is a vm.Tracer implemented in Go, which is selectable by name in TraceConfig.Tracer
+// and produces the same JSON output as the corresponding geth native tracer
+type nativeTracer interface {
+	vm.Tracer
+	GetResult() (json.RawMessage, error)
+}
+
+// nativeTracerContext contains the tx information that a native tracer can't learn from the evm hooks
+type nativeTracerContext struct {
+	TxHash common.Hash
+	// GasLimit is the gas limit of the whole tx, including the intrinsic gas
+	GasLimit uint64
+}
+
+type nativeTracerConstructor func(tCtx *nativeTracerContext, cfg json.RawMessage) (nativeTracer, error)
+
+var nativeTracers = map[string]nativeTracerConstructor{
+	"callTracer":     newCallTracer,
+	"prestateTracer": newPrestateTracer,
+	"parityTracer":   newParityTracer,
+}
+
+// IsNativeTracer returns true if the name is one of the built-in native tracers
+func IsNativeTracer(name string) bool {
+	_, ok := nativeTracers[name]
+	return ok
+}
+
+func newNativeTracer(name string, tCtx *nativeTracerContext, cfg json.RawMessage) (nativeTracer, error) {
+	constructor, ok := nativeTracers[name]
+	if !ok {
+		return nil, fmt.Errorf("native tracer %s not found", name)
+	}
+	return constructor(tCtx, cfg)
+}
+
+// unmarshalTracerConfig decodes the tracer-specific config, an empty config keeps the defaults
+func unmarshalTracerConfig(cfg json.RawMessage, v interface{}) error {
+	if len(cfg) == 0 || string(cfg) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(cfg, v); err != nil {
+		return fmt.Errorf("invalid tracer config: %s", err.Error())
+	}
+	return nil
+}
+
+// the stack helpers below read the n-th item counted from the top of the stack, as stack.peek of the js tracers does
+func stackAddress(stack *vm.Stack, n int) common.Address {
+	data := stack.Data()
+	return common.Address(data[len(data)-1-n].Bytes20())
+}
+
+func stackHash(stack *vm.Stack, n int) common.Hash {
+	data := stack.Data()
+	return common.Hash(data[len(data)-1-n].Bytes32())
+}
+
+func stackBig(stack *vm.Stack, n int) *big.Int {
+	data := stack.Data()
+	return data[len(data)-1-n].ToBig()
+}
+
+func stackUint64(stack *vm.Stack, n int) uint64 {
+	data := stack.Data()
+	item := &data[len(data)-1-n]
+	if !item.IsUint64() {
+		return ^uint64(0)
+	}
+	return item.Uint64()
+}
+
+func stackIsZero(stack *vm.Stack, n int) bool {
+	data := stack.Data()
+	return data[len(data)-1-n].IsZero()
+}
+
+// memoryCopy returns a copy of memory[offset:offset+size], the part beyond the memory size is zero-padded
+func memoryCopy(memory *vm.Memory, offset, size uint64) []byte {
+	data := memory.Data()
+	if size > uint64(len(data)) {
+		size = uint64(len(data))
+	}
+	if size == 0 {
+		return nil
+	}
+	cpy := make([]byte, size)
+	if offset < uint64(len(data)) {
+		copy(cpy, data[offset:])
+	}
+	return cpy
+}
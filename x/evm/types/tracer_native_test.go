@@ -0,0 +1,142 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	tracerCallerAddr = ethcmn.HexToAddress("0x1000000000000000000000000000000000000001")
+	tracerCalleeAddr = ethcmn.HexToAddress("0x2000000000000000000000000000000000000002")
+	tracerOrigin     = ethcmn.HexToAddress("0x3000000000000000000000000000000000000003")
+	// SSTORE(0, 42) and return 42 as a 32 bytes word
+	tracerCalleeCode = hexutil.MustDecode("0x602a600055602a60005260206000f3")
+	// CALL(gas, callee, 0, 0, 0, 0, 32) and STOP
+	tracerCallerCode = hexutil.MustDecode("0x6020600060006000600073" + tracerCalleeAddr.Hex()[2:] + "5af100")
+)
+
+func runNativeTracer(t *testing.T, name string, cfg string) json.RawMessage {
+	statedb, err := state.New(ethcmn.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	statedb.SetCode(tracerCallerAddr, tracerCallerCode)
+	statedb.SetCode(tracerCalleeAddr, tracerCalleeCode)
+	statedb.SetBalance(tracerOrigin, big.NewInt(1000))
+	statedb.SetNonce(tracerOrigin, 1)
+
+	tracer, err := newNativeTracer(name, &nativeTracerContext{GasLimit: 100000}, json.RawMessage(cfg))
+	require.NoError(t, err)
+
+	_, _, err = runtime.Call(tracerCallerAddr, nil, &runtime.Config{
+		Origin:    tracerOrigin,
+		GasLimit:  100000,
+		State:     statedb,
+		EVMConfig: vm.Config{Debug: true, Tracer: tracer},
+	})
+	require.NoError(t, err)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+	return res
+}
+
+func TestCallTracer(t *testing.T) {
+	var top callFrame
+	require.NoError(t, json.Unmarshal(runNativeTracer(t, "callTracer", ""), &top))
+	require.Equal(t, "CALL", top.Type)
+	require.Equal(t, tracerOrigin, top.From)
+	require.Equal(t, tracerCallerAddr, *top.To)
+	require.Empty(t, top.Error)
+	require.Len(t, top.Calls, 1)
+
+	inner := top.Calls[0]
+	require.Equal(t, "CALL", inner.Type)
+	require.Equal(t, tracerCallerAddr, inner.From)
+	require.Equal(t, tracerCalleeAddr, *inner.To)
+	require.Equal(t, ethcmn.BigToHash(big.NewInt(42)).Bytes(), []byte(inner.Output))
+	require.NotZero(t, inner.GasUsed)
+	require.True(t, inner.Gas > inner.GasUsed)
+
+	var onlyTop callFrame
+	require.NoError(t, json.Unmarshal(runNativeTracer(t, "callTracer", `{"onlyTopCall":true}`), &onlyTop))
+	require.Empty(t, onlyTop.Calls)
+
+	_, err := newNativeTracer("callTracer", &nativeTracerContext{}, json.RawMessage(`{"onlyTopCall":1}`))
+	require.Error(t, err)
+}
+
+func TestPrestateTracer(t *testing.T) {
+	var pre prestateState
+	require.NoError(t, json.Unmarshal(runNativeTracer(t, "prestateTracer", ""), &pre))
+	require.Len(t, pre, 3)
+	require.Equal(t, uint64(0), pre[tracerOrigin].Nonce)
+	require.Equal(t, big.NewInt(1000), pre[tracerOrigin].Balance.ToInt())
+	require.Equal(t, tracerCalleeCode, []byte(pre[tracerCalleeAddr].Code))
+	require.Equal(t, ethcmn.Hash{}, pre[tracerCalleeAddr].Storage[ethcmn.Hash{}])
+
+	var diff struct {
+		Post prestateState `json:"post"`
+		Pre  prestateState `json:"pre"`
+	}
+	require.NoError(t, json.Unmarshal(runNativeTracer(t, "prestateTracer", `{"diffMode":true}`), &diff))
+	// the runtime doesn't increment the nonce of the sender, which the tracer reverts for the pre state
+	require.Len(t, diff.Post, 2)
+	require.Equal(t, uint64(1), diff.Post[tracerOrigin].Nonce)
+	require.Equal(t, ethcmn.BigToHash(big.NewInt(42)), diff.Post[tracerCalleeAddr].Storage[ethcmn.Hash{}])
+	require.Contains(t, diff.Pre, tracerCalleeAddr)
+}
+
+func TestParityTracer(t *testing.T) {
+	var result struct {
+		Output    hexutil.Bytes `json:"output"`
+		StateDiff map[ethcmn.Address]struct {
+			Balance json.RawMessage                 `json:"balance"`
+			Nonce   json.RawMessage                 `json:"nonce"`
+			Storage map[ethcmn.Hash]json.RawMessage `json:"storage"`
+		} `json:"stateDiff"`
+		Trace   []*ParityTrace  `json:"trace"`
+		VMTrace json.RawMessage `json:"vmTrace"`
+	}
+	require.NoError(t, json.Unmarshal(runNativeTracer(t, "parityTracer", `{"traceTypes":["trace","stateDiff"]}`), &result))
+	require.Equal(t, "null", string(result.VMTrace))
+
+	require.Len(t, result.Trace, 2)
+	require.Equal(t, "call", result.Trace[0].Type)
+	require.Equal(t, 1, result.Trace[0].Subtraces)
+	require.Equal(t, []int{}, result.Trace[0].TraceAddress)
+	require.Equal(t, "call", result.Trace[1].Type)
+	require.Equal(t, []int{0}, result.Trace[1].TraceAddress)
+	action := result.Trace[1].Action.(map[string]interface{})
+	require.Equal(t, "call", action["callType"])
+	require.Equal(t, tracerCalleeAddr.Hex(), ethcmn.HexToAddress(action["to"].(string)).Hex())
+	require.NotNil(t, result.Trace[1].Result)
+
+	require.Len(t, result.StateDiff, 2)
+	require.JSONEq(t, `{"*":{"from":"0x0","to":"0x1"}}`, string(result.StateDiff[tracerOrigin].Nonce))
+	require.JSONEq(t, `"="`, string(result.StateDiff[tracerCalleeAddr].Balance))
+	storageDiff := `{"*":{"from":"` + ethcmn.Hash{}.Hex() + `","to":"` + ethcmn.BigToHash(big.NewInt(42)).Hex() + `"}}`
+	require.JSONEq(t, storageDiff, string(result.StateDiff[tracerCalleeAddr].Storage[ethcmn.Hash{}]))
+
+	result.StateDiff = nil
+	require.NoError(t, json.Unmarshal(runNativeTracer(t, "parityTracer", `{"traceTypes":["trace"]}`), &result))
+	require.Nil(t, result.StateDiff)
+
+	_, err := newNativeTracer("parityTracer", &nativeTracerContext{}, json.RawMessage(`{"traceTypes":["vmTrace"]}`))
+	require.Error(t, err)
+	_, err = newNativeTracer("parityTracer", &nativeTracerContext{}, json.RawMessage(`{"traceTypes":["unknown"]}`))
+	require.Error(t, err)
+}
+
+func TestTestTracerConfigNative(t *testing.T) {
+	require.NoError(t, TestTracerConfig(&TraceConfig{Tracer: "callTracer"}))
+	require.NoError(t, TestTracerConfig(&TraceConfig{Tracer: "prestateTracer", TracerConfig: json.RawMessage(`{"diffMode":true}`)}))
+	require.Error(t, TestTracerConfig(&TraceConfig{Tracer: "prestateTracer", TracerConfig: json.RawMessage(`[]`)}))
+}
@@ -0,0 +1,346 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+const (
+	ParityTraceTypeTrace     = "trace"
+	ParityTraceTypeStateDiff = "stateDiff"
+	ParityTraceTypeVMTrace   = "vmTrace"
+
+	parityTraceTypeCall    = "call"
+	parityTraceTypeCreate  = "create"
+	parityTraceTypeSuicide = "suicide"
+)
+
+// ParityTraceResult is the result of a replayed tx, in the json format of OpenEthereum's trace_replayTransaction
+type ParityTraceResult struct {
+	Output    hexutil.Bytes   `json:"output"`
+	StateDiff ParityStateDiff `json:"stateDiff"`
+	Trace     []*ParityTrace  `json:"trace"`
+	// VMTrace is not supported, it's always null
+	VMTrace interface{} `json:"vmTrace"`
+}
+
+// ParityTrace is a call, create or suicide made by a tx, flattened in the order of execution
+type ParityTrace struct {
+	Action       interface{} `json:"action"`
+	Result       interface{} `json:"result"`
+	Error        string      `json:"error,omitempty"`
+	Subtraces    int         `json:"subtraces"`
+	TraceAddress []int       `json:"traceAddress"`
+	Type         string      `json:"type"`
+}
+
+type parityCallAction struct {
+	CallType string          `json:"callType"`
+	From     common.Address  `json:"from"`
+	Gas      hexutil.Uint64  `json:"gas"`
+	Input    hexutil.Bytes   `json:"input"`
+	To       *common.Address `json:"to"`
+	Value    *hexutil.Big    `json:"value"`
+}
+
+type parityCallResult struct {
+	GasUsed hexutil.Uint64 `json:"gasUsed"`
+	Output  hexutil.Bytes  `json:"output"`
+}
+
+type parityCreateAction struct {
+	From  common.Address `json:"from"`
+	Gas   hexutil.Uint64 `json:"gas"`
+	Init  hexutil.Bytes  `json:"init"`
+	Value *hexutil.Big   `json:"value"`
+}
+
+type parityCreateResult struct {
+	Address *common.Address `json:"address"`
+	Code    hexutil.Bytes   `json:"code"`
+	GasUsed hexutil.Uint64  `json:"gasUsed"`
+}
+
+type paritySuicideAction struct {
+	Address       common.Address  `json:"address"`
+	Balance       *hexutil.Big    `json:"balance"`
+	RefundAddress *common.Address `json:"refundAddress"`
+}
+
+// ParityStateDiff is the accounts modified by a tx, in the json format of OpenEthereum's stateDiff.
+// Every value is "=" if unchanged, {"+": new} if born, {"-": old} if died or {"*": {"from": old, "to": new}} if changed
+type ParityStateDiff map[common.Address]*ParityAccountDiff
+
+// ParityAccountDiff is the diff of an account modified by a tx
+type ParityAccountDiff struct {
+	Balance interface{}                 `json:"balance"`
+	Code    interface{}                 `json:"code"`
+	Nonce   interface{}                 `json:"nonce"`
+	Storage map[common.Hash]interface{} `json:"storage"`
+}
+
+type parityChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+const parityUnchanged = "="
+
+func parityBorn(v interface{}) interface{} {
+	return map[string]interface{}{"+": v}
+}
+
+func parityDied(v interface{}) interface{} {
+	return map[string]interface{}{"-": v}
+}
+
+func parityChanged(from, to interface{}) interface{} {
+	return map[string]interface{}{"*": parityChange{From: from, To: to}}
+}
+
+type parityTracerConfig struct {
+	// TraceTypes is the parts of the result to report, "trace" and "stateDiff" are supported
+	TraceTypes []string `json:"traceTypes"`
+}
+
+// parityTracer reports the flat call traces and the state diff of a tx in the format of OpenEthereum's trace module.
+// It runs the callTracer and the prestateTracer in diff mode, and converts their results
+type parityTracer struct {
+	call     *callTracer
+	prestate *prestateTracer
+	output   []byte
+}
+
+func newParityTracer(tCtx *nativeTracerContext, cfg json.RawMessage) (nativeTracer, error) {
+	var config parityTracerConfig
+	if err := unmarshalTracerConfig(cfg, &config); err != nil {
+		return nil, err
+	}
+	t := &parityTracer{}
+	for _, traceType := range config.TraceTypes {
+		switch traceType {
+		case ParityTraceTypeTrace:
+			tracer, _ := newCallTracer(tCtx, nil)
+			t.call = tracer.(*callTracer)
+		case ParityTraceTypeStateDiff:
+			tracer, _ := newPrestateTracer(tCtx, json.RawMessage(`{"diffMode":true}`))
+			t.prestate = tracer.(*prestateTracer)
+		case ParityTraceTypeVMTrace:
+			return nil, fmt.Errorf("trace type %s is not supported", traceType)
+		default:
+			return nil, fmt.Errorf("invalid trace type %s", traceType)
+		}
+	}
+	return t, nil
+}
+
+// CaptureStart implements vm.Tracer interface
+func (t *parityTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	if t.call != nil {
+		t.call.CaptureStart(env, from, to, create, input, gas, value)
+	}
+	if t.prestate != nil {
+		t.prestate.CaptureStart(env, from, to, create, input, gas, value)
+	}
+}
+
+// CaptureState implements vm.Tracer interface
+func (t *parityTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+	if t.call != nil {
+		t.call.CaptureState(env, pc, op, gas, cost, scope, rData, depth, err)
+	}
+	if t.prestate != nil {
+		t.prestate.CaptureState(env, pc, op, gas, cost, scope, rData, depth, err)
+	}
+}
+
+// CaptureFault implements vm.Tracer interface
+func (t *parityTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+	if t.call != nil {
+		t.call.CaptureFault(env, pc, op, gas, cost, scope, depth, err)
+	}
+	if t.prestate != nil {
+		t.prestate.CaptureFault(env, pc, op, gas, cost, scope, depth, err)
+	}
+}
+
+// CaptureEnd implements vm.Tracer interface
+func (t *parityTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) {
+	t.output = common.CopyBytes(output)
+	if t.call != nil {
+		t.call.CaptureEnd(output, gasUsed, d, err)
+	}
+	if t.prestate != nil {
+		t.prestate.CaptureEnd(output, gasUsed, d, err)
+	}
+}
+
+// GetResult returns the json encoded ParityTraceResult
+func (t *parityTracer) GetResult() (json.RawMessage, error) {
+	result := &ParityTraceResult{Output: t.output}
+	if result.Output == nil {
+		result.Output = []byte{}
+	}
+	if t.call != nil {
+		if len(t.call.callstack) != 1 {
+			return nil, errors.New("incorrect number of top-level calls")
+		}
+		top := t.call.callstack[0]
+		// unlike the callTracer, the intrinsic gas is not a part of the top-level trace
+		if t.call.gasLimit > t.call.startGas {
+			top.Gas = hexutil.Uint64(t.call.startGas)
+			top.GasUsed -= hexutil.Uint64(t.call.gasLimit - t.call.startGas)
+		}
+		result.Trace = flattenParityTraces(top, []int{}, nil)
+	}
+	if t.prestate != nil {
+		result.StateDiff = t.prestate.parityStateDiff()
+	}
+	return json.Marshal(result)
+}
+
+// flattenParityTraces appends the frame and its sub-calls in depth-first order
+func flattenParityTraces(frame *callFrame, traceAddress []int, traces []*ParityTrace) []*ParityTrace {
+	trace := &ParityTrace{
+		Subtraces:    len(frame.Calls),
+		TraceAddress: traceAddress,
+	}
+	value := frame.Value
+	if value == nil {
+		value = (*hexutil.Big)(new(big.Int))
+	}
+	switch frame.Type {
+	case vm.CREATE.String(), vm.CREATE2.String():
+		trace.Type = parityTraceTypeCreate
+		trace.Action = &parityCreateAction{From: frame.From, Gas: frame.Gas, Init: frame.Input, Value: value}
+		trace.Result = &parityCreateResult{Address: frame.To, Code: frame.Output, GasUsed: frame.GasUsed}
+	case vm.SELFDESTRUCT.String():
+		trace.Type = parityTraceTypeSuicide
+		trace.Action = &paritySuicideAction{Address: frame.From, Balance: value, RefundAddress: frame.To}
+	default:
+		trace.Type = parityTraceTypeCall
+		trace.Action = &parityCallAction{
+			CallType: strings.ToLower(frame.Type),
+			From:     frame.From,
+			Gas:      frame.Gas,
+			Input:    frame.Input,
+			To:       frame.To,
+			Value:    value,
+		}
+		trace.Result = &parityCallResult{GasUsed: frame.GasUsed, Output: frame.Output}
+	}
+	if frame.Error != "" {
+		trace.Error = parityError(frame.Error)
+		trace.Result = nil
+	}
+
+	traces = append(traces, trace)
+	for i, call := range frame.Calls {
+		subAddress := make([]int, len(traceAddress)+1)
+		copy(subAddress, traceAddress)
+		subAddress[len(traceAddress)] = i
+		traces = flattenParityTraces(call, subAddress, traces)
+	}
+	return traces
+}
+
+// parityError converts the common vm errors to the messages of OpenEthereum
+func parityError(err string) string {
+	switch err {
+	case vm.ErrExecutionReverted.Error():
+		return "Reverted"
+	case vm.ErrOutOfGas.Error(), vm.ErrCodeStoreOutOfGas.Error():
+		return "Out of gas"
+	case vm.ErrDepth.Error():
+		return "Out of stack"
+	case vm.ErrInsufficientBalance.Error():
+		return "Insufficient balance"
+	case vm.ErrInvalidJump.Error():
+		return "Bad jump destination"
+	default:
+		return err
+	}
+}
+
+// parityStateDiff converts the pre and the post state of the modified accounts to the diff of OpenEthereum
+func (t *prestateTracer) parityStateDiff() ParityStateDiff {
+	post := t.postState()
+	diff := ParityStateDiff{}
+	for addr, preAccount := range t.pre {
+		postAccount, ok := post[addr]
+		if !ok {
+			// the account is destructed
+			accDiff := &ParityAccountDiff{
+				Balance: parityDied(preAccount.Balance),
+				Code:    parityDied(preAccount.Code),
+				Nonce:   parityDied(hexutil.Uint64(preAccount.Nonce)),
+				Storage: make(map[common.Hash]interface{}),
+			}
+			for key, val := range preAccount.Storage {
+				accDiff.Storage[key] = parityDied(val)
+			}
+			diff[addr] = accDiff
+			continue
+		}
+
+		accDiff := &ParityAccountDiff{
+			Balance: parityUnchanged,
+			Code:    parityUnchanged,
+			Nonce:   parityUnchanged,
+			Storage: make(map[common.Hash]interface{}),
+		}
+		if postAccount.Balance != nil {
+			accDiff.Balance = parityChanged(preAccount.Balance, postAccount.Balance)
+		}
+		if postAccount.Nonce != 0 {
+			accDiff.Nonce = parityChanged(hexutil.Uint64(preAccount.Nonce), hexutil.Uint64(postAccount.Nonce))
+		}
+		if len(postAccount.Code) != 0 {
+			accDiff.Code = parityChanged(preAccount.Code, postAccount.Code)
+		}
+		// the zero values are dropped from both of the states
+		for key, val := range preAccount.Storage {
+			accDiff.Storage[key] = parityChanged(val, postAccount.Storage[key])
+		}
+		for key, val := range postAccount.Storage {
+			if _, ok := preAccount.Storage[key]; !ok {
+				accDiff.Storage[key] = parityChanged(common.Hash{}, val)
+			}
+		}
+		diff[addr] = accDiff
+	}
+
+	// the accounts created by the tx are only in the post state
+	for addr, postAccount := range post {
+		if _, ok := t.pre[addr]; ok {
+			continue
+		}
+		balance := postAccount.Balance
+		if balance == nil {
+			balance = (*hexutil.Big)(new(big.Int))
+		}
+		code := postAccount.Code
+		if code == nil {
+			code = []byte{}
+		}
+		accDiff := &ParityAccountDiff{
+			Balance: parityBorn(balance),
+			Code:    parityBorn(code),
+			Nonce:   parityBorn(hexutil.Uint64(postAccount.Nonce)),
+			Storage: make(map[common.Hash]interface{}),
+		}
+		for key, val := range postAccount.Storage {
+			accDiff.Storage[key] = parityBorn(val)
+		}
+		diff[addr] = accDiff
+	}
+	return diff
+}
@@ -0,0 +1,252 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// prestateAccount is the state of an account touched by the tx, in the json format of geth's native prestateTracer
+type prestateAccount struct {
+	Balance *hexutil.Big                `json:"balance,omitempty"`
+	Code    hexutil.Bytes               `json:"code,omitempty"`
+	Nonce   uint64                      `json:"nonce,omitempty"`
+	Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
+}
+
+func (a *prestateAccount) exists() bool {
+	return a.Nonce > 0 || len(a.Code) > 0 || len(a.Storage) > 0 || (a.Balance != nil && a.Balance.ToInt().Sign() != 0)
+}
+
+type prestateState map[common.Address]*prestateAccount
+
+type prestateTracerConfig struct {
+	// DiffMode reports the pre and the post state of the modified accounts only
+	DiffMode bool `json:"diffMode"`
+}
+
+// prestateTracer reports the state of all the accounts touched by a tx before its execution,
+// which is enough to rebuild the tx on a custom genesis
+type prestateTracer struct {
+	env      *vm.EVM
+	config   prestateTracerConfig
+	gasLimit uint64
+	startGas uint64
+	from     common.Address
+	to       common.Address
+	create   bool
+	// refund is the fee paid for the gas left, which is only given back to the sender after the tracer ended
+	refund *big.Int
+
+	pre     prestateState
+	created map[common.Address]bool
+	deleted map[common.Address]bool
+}
+
+func newPrestateTracer(tCtx *nativeTracerContext, cfg json.RawMessage) (nativeTracer, error) {
+	var config prestateTracerConfig
+	if err := unmarshalTracerConfig(cfg, &config); err != nil {
+		return nil, err
+	}
+	return &prestateTracer{
+		config:   config,
+		gasLimit: tCtx.GasLimit,
+		refund:   new(big.Int),
+		pre:      prestateState{},
+		created:  make(map[common.Address]bool),
+		deleted:  make(map[common.Address]bool),
+	}, nil
+}
+
+// CaptureStart implements vm.Tracer interface
+func (t *prestateTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	t.env = env
+	t.startGas = gas
+	t.from = from
+	t.to = to
+	t.create = create
+
+	t.lookupAccount(from)
+	t.lookupAccount(to)
+
+	// the vm is started after the value transfer, the nonce increment and the fee deduction of the sender,
+	// so revert them to get the state before the tx
+	if value == nil {
+		value = new(big.Int)
+	}
+	toAcc := t.pre[to]
+	toAcc.Balance = (*hexutil.Big)(new(big.Int).Sub(toAcc.Balance.ToInt(), value))
+
+	fee := new(big.Int).Mul(env.TxContext.GasPrice, new(big.Int).SetUint64(t.gasLimit))
+	fromAcc := t.pre[from]
+	fromBal := new(big.Int).Add(fromAcc.Balance.ToInt(), value)
+	fromAcc.Balance = (*hexutil.Big)(fromBal.Add(fromBal, fee))
+	if fromAcc.Nonce > 0 {
+		fromAcc.Nonce--
+	}
+
+	if create && t.config.DiffMode {
+		t.created[to] = true
+	}
+}
+
+// CaptureState implements vm.Tracer interface
+func (t *prestateTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+	if err != nil {
+		return
+	}
+	stack := scope.Stack
+	caller := scope.Contract.Address()
+	switch op {
+	case vm.SLOAD, vm.SSTORE:
+		if len(stack.Data()) >= 1 {
+			t.lookupStorage(caller, stackHash(stack, 0))
+		}
+	case vm.EXTCODECOPY, vm.EXTCODESIZE, vm.EXTCODEHASH, vm.BALANCE:
+		if len(stack.Data()) >= 1 {
+			t.lookupAccount(stackAddress(stack, 0))
+		}
+	case vm.SELFDESTRUCT:
+		if len(stack.Data()) >= 1 {
+			t.lookupAccount(stackAddress(stack, 0))
+			t.deleted[caller] = true
+		}
+	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+		if len(stack.Data()) >= 5 {
+			t.lookupAccount(stackAddress(stack, 1))
+		}
+	case vm.CREATE:
+		addr := crypto.CreateAddress(caller, env.StateDB.GetNonce(caller))
+		t.lookupAccount(addr)
+		t.created[addr] = true
+	case vm.CREATE2:
+		if len(stack.Data()) >= 4 {
+			initCode := memoryCopy(scope.Memory, stackUint64(stack, 1), stackUint64(stack, 2))
+			addr := crypto.CreateAddress2(caller, stackHash(stack, 3), crypto.Keccak256(initCode))
+			t.lookupAccount(addr)
+			t.created[addr] = true
+		}
+	}
+}
+
+// CaptureFault implements vm.Tracer interface
+func (t *prestateTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+}
+
+// CaptureEnd implements vm.Tracer interface
+func (t *prestateTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) {
+	if t.env == nil {
+		return
+	}
+	if t.startGas > gasUsed {
+		t.refund.Mul(t.env.TxContext.GasPrice, new(big.Int).SetUint64(t.startGas-gasUsed))
+	}
+	if t.config.DiffMode {
+		return
+	}
+	if t.create {
+		// any existing state of the created contract would have made the tx fail
+		delete(t.pre, t.to)
+	}
+}
+
+// GetResult returns the json encoded pre state, or the pre and the post state in diff mode
+func (t *prestateTracer) GetResult() (json.RawMessage, error) {
+	if !t.config.DiffMode {
+		return json.Marshal(t.pre)
+	}
+	post := t.postState()
+	return json.Marshal(struct {
+		Post prestateState `json:"post"`
+		Pre  prestateState `json:"pre"`
+	}{post, t.pre})
+}
+
+// postState reads the final state of the touched accounts and drops the unmodified ones from the pre state
+func (t *prestateTracer) postState() prestateState {
+	post := prestateState{}
+	if t.env == nil {
+		return post
+	}
+	for addr, state := range t.pre {
+		// the state of a destructed account is pruned from the post state but kept in the pre state
+		if t.deleted[addr] {
+			continue
+		}
+		modified := false
+		postAccount := &prestateAccount{Storage: make(map[common.Hash]common.Hash)}
+		newBalance := new(big.Int).Set(t.env.StateDB.GetBalance(addr))
+		if addr == t.from {
+			newBalance.Add(newBalance, t.refund)
+		}
+		newNonce := t.env.StateDB.GetNonce(addr)
+		newCode := t.env.StateDB.GetCode(addr)
+
+		if newBalance.Cmp(state.Balance.ToInt()) != 0 {
+			modified = true
+			postAccount.Balance = (*hexutil.Big)(newBalance)
+		}
+		if newNonce != state.Nonce {
+			modified = true
+			postAccount.Nonce = newNonce
+		}
+		if !bytes.Equal(newCode, state.Code) {
+			modified = true
+			postAccount.Code = newCode
+		}
+		for key, val := range state.Storage {
+			newVal := t.env.StateDB.GetState(addr, key)
+			if val == newVal {
+				delete(state.Storage, key)
+				continue
+			}
+			if val == (common.Hash{}) {
+				delete(state.Storage, key)
+			}
+			modified = true
+			if newVal != (common.Hash{}) {
+				postAccount.Storage[key] = newVal
+			}
+		}
+
+		if modified {
+			post[addr] = postAccount
+		} else {
+			// an unmodified account is not part of the diff
+			delete(t.pre, addr)
+		}
+	}
+	// the pre state of the created contracts was empty
+	for addr := range t.created {
+		if state, ok := t.pre[addr]; ok && !state.exists() {
+			delete(t.pre, addr)
+		}
+	}
+	return post
+}
+
+func (t *prestateTracer) lookupAccount(addr common.Address) {
+	if _, ok := t.pre[addr]; ok {
+		return
+	}
+	t.pre[addr] = &prestateAccount{
+		Balance: (*hexutil.Big)(new(big.Int).Set(t.env.StateDB.GetBalance(addr))),
+		Nonce:   t.env.StateDB.GetNonce(addr),
+		Code:    t.env.StateDB.GetCode(addr),
+		Storage: make(map[common.Hash]common.Hash),
+	}
+}
+
+func (t *prestateTracer) lookupStorage(addr common.Address, key common.Hash) {
+	t.lookupAccount(addr)
+	if _, ok := t.pre[addr].Storage[key]; ok {
+		return
+	}
+	t.pre[addr].Storage[key] = t.env.StateDB.GetState(addr, key)
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 
 	"github.com/tendermint/go-amino"
@@ -10,6 +11,7 @@ import (
 	"github.com/okex/exchain/app/utils"
 
 	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 // TxData implements the Ethereum transaction data structure. It is used
@@ -29,6 +31,14 @@ type TxData struct {
 
 	// hash is only used when marshaling to JSON
 	Hash *ethcmn.Hash `json:"hash" rlp:"-"`
+
+	// typed transaction fields (EIP-2718), they are left empty in the legacy transactions.
+	// NOTE: Price is the same as GasFeeCap in the dynamic fee transactions as there is no base fee
+	Type      uint8               `json:"type" rlp:"-"`
+	ChainID   *big.Int            `json:"chainId" rlp:"-"`
+	GasTipCap *big.Int            `json:"maxPriorityFeePerGas" rlp:"-"`
+	GasFeeCap *big.Int            `json:"maxFeePerGas" rlp:"-"`
+	Accesses  ethtypes.AccessList `json:"accessList" rlp:"-"`
 }
 
 // encodableTxData implements the Ethereum transaction data structure. It is used
@@ -48,6 +58,13 @@ type encodableTxData struct {
 
 	// hash is only used when marshaling to JSON
 	Hash *ethcmn.Hash `json:"hash" rlp:"-"`
+
+	// typed transaction fields
+	Type      uint8               `json:"type"`
+	ChainID   string              `json:"chainId"`
+	GasTipCap string              `json:"maxPriorityFeePerGas"`
+	GasFeeCap string              `json:"maxFeePerGas"`
+	Accesses  ethtypes.AccessList `json:"accessList"`
 }
 
 func (tx *encodableTxData) UnmarshalFromAmino(_ *amino.Codec, data []byte) error {
@@ -80,6 +97,12 @@ func (tx *encodableTxData) UnmarshalFromAmino(_ *amino.Codec, data []byte) error
 			subData = data[:dataLen]
 		}
 
+		// the typed transaction fields are checked strictly, so that the length prefixed data can't be
+		// mistaken for them
+		if pos > 10 && pbType != typedTxFieldTyp3(pos) {
+			return fmt.Errorf("unexpect type %d of feild num %d", pbType, pos)
+		}
+
 		switch pos {
 		case 1:
 			var n int
@@ -120,6 +143,30 @@ func (tx *encodableTxData) UnmarshalFromAmino(_ *amino.Codec, data []byte) error
 			}
 			tx.Hash = new(ethcmn.Hash)
 			copy(tx.Hash[:], subData)
+		case 11:
+			var n int
+			var txType uint64
+			txType, n, err = amino.DecodeUvarint(data)
+			if err != nil {
+				return err
+			}
+			if txType > math.MaxUint8 {
+				return errors.New("tx type overflow")
+			}
+			tx.Type = uint8(txType)
+			dataLen = uint64(n)
+		case 12:
+			tx.ChainID = string(subData)
+		case 13:
+			tx.GasTipCap = string(subData)
+		case 14:
+			tx.GasFeeCap = string(subData)
+		case 15:
+			var tuple ethtypes.AccessTuple
+			if err := unmarshalAccessTupleFromAmino(subData, &tuple); err != nil {
+				return err
+			}
+			tx.Accesses = append(tx.Accesses, tuple)
 		default:
 			return fmt.Errorf("unexpect feild num %d", pos)
 		}
@@ -127,6 +174,135 @@ func (tx *encodableTxData) UnmarshalFromAmino(_ *amino.Codec, data []byte) error
 	return nil
 }
 
+func typedTxFieldTyp3(pos int) amino.Typ3 {
+	if pos == 11 {
+		return amino.Typ3_Varint
+	}
+	return amino.Typ3_ByteLength
+}
+
+func unmarshalAccessTupleFromAmino(data []byte, tuple *ethtypes.AccessTuple) error {
+	for len(data) > 0 {
+		pos, pbType, err := amino.ParseProtoPosAndTypeMustOneByte(data[0])
+		if err != nil {
+			return err
+		}
+		if pbType != amino.Typ3_ByteLength {
+			return fmt.Errorf("unexpect type %d of access tuple", pbType)
+		}
+		data = data[1:]
+
+		dataLen, n, err := amino.DecodeUvarint(data)
+		if err != nil {
+			return err
+		}
+		data = data[n:]
+		if len(data) < int(dataLen) {
+			return fmt.Errorf("invalid access tuple")
+		}
+		subData := data[:dataLen]
+		data = data[dataLen:]
+
+		switch pos {
+		case 1:
+			if dataLen != ethcmn.AddressLength {
+				return errors.New("eth addr len error")
+			}
+			copy(tuple.Address[:], subData)
+		case 2:
+			if dataLen != ethcmn.HashLength {
+				return errors.New("hash len error")
+			}
+			tuple.StorageKeys = append(tuple.StorageKeys, ethcmn.BytesToHash(subData))
+		default:
+			return fmt.Errorf("unexpect feild num %d", pos)
+		}
+	}
+	return nil
+}
+
+func marshalOptionalBigInt(i *big.Int) (string, error) {
+	if i == nil {
+		return "", nil
+	}
+	return utils.MarshalBigInt(i)
+}
+
+func unmarshalOptionalBigInt(s string) (*big.Int, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	return utils.UnmarshalBigInt(s)
+}
+
+// IsTyped returns true if the tx data belongs to an EIP-2718 typed transaction
+func (td TxData) IsTyped() bool {
+	return td.Type != ethtypes.LegacyTxType
+}
+
+// toEthTx converts the typed tx data to the go-ethereum transaction, which is used for the envelope
+// encoding and the signing hash
+func (td *TxData) toEthTx() (*ethtypes.Transaction, error) {
+	switch td.Type {
+	case ethtypes.AccessListTxType:
+		return ethtypes.NewTx(&ethtypes.AccessListTx{
+			ChainID:    td.ChainID,
+			Nonce:      td.AccountNonce,
+			GasPrice:   td.Price,
+			Gas:        td.GasLimit,
+			To:         td.Recipient,
+			Value:      td.Amount,
+			Data:       td.Payload,
+			AccessList: td.Accesses,
+			V:          td.V,
+			R:          td.R,
+			S:          td.S,
+		}), nil
+	case ethtypes.DynamicFeeTxType:
+		return ethtypes.NewTx(&ethtypes.DynamicFeeTx{
+			ChainID:    td.ChainID,
+			Nonce:      td.AccountNonce,
+			GasTipCap:  td.GasTipCap,
+			GasFeeCap:  td.GasFeeCap,
+			Gas:        td.GasLimit,
+			To:         td.Recipient,
+			Value:      td.Amount,
+			Data:       td.Payload,
+			AccessList: td.Accesses,
+			V:          td.V,
+			R:          td.R,
+			S:          td.S,
+		}), nil
+	default:
+		return nil, ethtypes.ErrTxTypeNotSupported
+	}
+}
+
+// fromEthTx fills the tx data with the decoded typed go-ethereum transaction
+func (td *TxData) fromEthTx(tx *ethtypes.Transaction) error {
+	switch tx.Type() {
+	case ethtypes.AccessListTxType:
+	case ethtypes.DynamicFeeTxType:
+		td.GasTipCap = tx.GasTipCap()
+		td.GasFeeCap = tx.GasFeeCap()
+	default:
+		return ethtypes.ErrTxTypeNotSupported
+	}
+
+	td.Type = tx.Type()
+	td.ChainID = tx.ChainId()
+	td.AccountNonce = tx.Nonce()
+	// the gas price of the dynamic fee transaction is the fee cap
+	td.Price = tx.GasPrice()
+	td.GasLimit = tx.Gas()
+	td.Recipient = tx.To()
+	td.Amount = tx.Value()
+	td.Payload = tx.Data()
+	td.Accesses = tx.AccessList()
+	td.V, td.R, td.S = tx.RawSignatureValues()
+	return nil
+}
+
 func (td TxData) String() string {
 	if td.Recipient != nil {
 		return fmt.Sprintf("nonce=%d price=%s gasLimit=%d recipient=%s amount=%s data=0x%x v=%s r=%s s=%s",
@@ -164,6 +340,21 @@ func (td TxData) MarshalAmino() ([]byte, error) {
 		return nil, err
 	}
 
+	chainID, err := marshalOptionalBigInt(td.ChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	gasTipCap, err := marshalOptionalBigInt(td.GasTipCap)
+	if err != nil {
+		return nil, err
+	}
+
+	gasFeeCap, err := marshalOptionalBigInt(td.GasFeeCap)
+	if err != nil {
+		return nil, err
+	}
+
 	e := encodableTxData{
 		AccountNonce: td.AccountNonce,
 		Price:        gasPrice,
@@ -175,6 +366,11 @@ func (td TxData) MarshalAmino() ([]byte, error) {
 		R:            r,
 		S:            s,
 		Hash:         td.Hash,
+		Type:         td.Type,
+		ChainID:      chainID,
+		GasTipCap:    gasTipCap,
+		GasFeeCap:    gasFeeCap,
+		Accesses:     td.Accesses,
 	}
 
 	return ModuleCdc.MarshalBinaryBare(e)
@@ -249,7 +445,7 @@ func (td *TxData) UnmarshalAmino(data []byte) error {
 		td.S = s
 	}
 
-	return nil
+	return td.setTypedFields(&e)
 }
 
 func (td *TxData) unmarshalFromAmino(cdc *amino.Codec, data []byte) error {
@@ -319,7 +515,21 @@ func (td *TxData) unmarshalFromAmino(cdc *amino.Codec, data []byte) error {
 		td.S = s
 	}
 
-	return nil
+	return td.setTypedFields(&e)
+}
+
+func (td *TxData) setTypedFields(e *encodableTxData) (err error) {
+	td.Type = e.Type
+	td.Accesses = e.Accesses
+
+	if td.ChainID, err = unmarshalOptionalBigInt(e.ChainID); err != nil {
+		return err
+	}
+	if td.GasTipCap, err = unmarshalOptionalBigInt(e.GasTipCap); err != nil {
+		return err
+	}
+	td.GasFeeCap, err = unmarshalOptionalBigInt(e.GasFeeCap)
+	return err
 }
 
 func (td *TxData) UnmarshalFromAmino(cdc *amino.Codec, data []byte) error {
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 func TestMarshalAndUnmarshalData(t *testing.T) {
@@ -92,6 +93,23 @@ func TestTxDataAmino(t *testing.T) {
 			R:            big.NewInt(math.MaxInt64),
 			S:            big.NewInt(math.MaxInt64),
 		},
+		{
+			AccountNonce: 2,
+			Price:        big.NewInt(3),
+			GasLimit:     1,
+			Amount:       big.NewInt(4),
+			V:            big.NewInt(1),
+			R:            big.NewInt(6),
+			S:            big.NewInt(7),
+			Type:         ethtypes.DynamicFeeTxType,
+			ChainID:      big.NewInt(65),
+			GasTipCap:    big.NewInt(1),
+			GasFeeCap:    big.NewInt(3),
+			Accesses: ethtypes.AccessList{
+				{Address: addr, StorageKeys: []ethcmn.Hash{hash, {}}},
+				{Address: ethcmn.Address{}},
+			},
+		},
 	}
 
 	cdc := amino.NewCodec()
@@ -478,6 +478,7 @@ type TransactionReceipt struct {
 	TransactionIndex  hexutil.Uint64  `json:"transactionIndex"`
 	From              string          `json:"from"`
 	To                *common.Address `json:"to"`
+	Type              hexutil.Uint64  `json:"type"`
 }
 
 func NewMsgTransactionReceipt(status uint32, tx *types.MsgEthereumTx, txHash, blockHash common.Hash, txIndex, height uint64, data *types.ResultData, cumulativeGas, GasUsed uint64) *MsgTransactionReceipt {
@@ -495,6 +496,7 @@ func NewMsgTransactionReceipt(status uint32, tx *types.MsgEthereumTx, txHash, bl
 		TransactionIndex:  hexutil.Uint64(txIndex),
 		From:              types.EthAddressStringer(common.BytesToAddress(tx.AccountAddress().Bytes())).String(),
 		To:                tx.To(),
+		Type:              hexutil.Uint64(tx.Data.Type),
 	}
 
 	//contract address will be set to 0x0000000000000000000000000000000000000000 if contract deploy failed
@@ -585,6 +587,27 @@ type Transaction struct {
 	V                *hexutil.Big    `json:"v"`
 	R                *hexutil.Big    `json:"r"`
 	S                *hexutil.Big    `json:"s"`
+
+	// typed transaction fields
+	Type       hexutil.Uint64       `json:"type"`
+	ChainID    *hexutil.Big         `json:"chainId,omitempty"`
+	GasFeeCap  *hexutil.Big         `json:"maxFeePerGas,omitempty"`
+	GasTipCap  *hexutil.Big         `json:"maxPriorityFeePerGas,omitempty"`
+	AccessList *ethtypes.AccessList `json:"accessList,omitempty"`
+}
+
+// SetTypedTxData sets the typed transaction fields from the tx data
+func (tx *Transaction) SetTypedTxData(data *types.TxData) {
+	tx.Type = hexutil.Uint64(data.Type)
+	if !data.IsTyped() {
+		return
+	}
+
+	accesses := data.Accesses
+	tx.AccessList = &accesses
+	tx.ChainID = (*hexutil.Big)(data.ChainID)
+	tx.GasFeeCap = (*hexutil.Big)(data.GasFeeCap)
+	tx.GasTipCap = (*hexutil.Big)(data.GasTipCap)
 }
 
 func NewMsgBlock(height uint64, blockBloom ethtypes.Bloom, blockHash common.Hash, header abci.Header, gasLimit uint64, gasUsed *big.Int, txs interface{}) *MsgBlock {
@@ -30,6 +30,7 @@ func NewTransaction(tx *evmtypes.MsgEthereumTx, txHash, blockHash common.Hash, b
 		R:        (*hexutil.Big)(tx.Data.R),
 		S:        (*hexutil.Big)(tx.Data.S),
 	}
+	rpcTx.SetTypedTxData(&tx.Data)
 
 	if blockHash != (common.Hash{}) {
 		rpcTx.BlockHash = &blockHash
@@ -5,6 +5,7 @@ import (
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/okex/exchain/x/farm/keeper"
 	"github.com/okex/exchain/x/farm/types"
 )
@@ -74,9 +75,37 @@ func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k keeper.Keeper)
 	}
 }
 
-// EndBlocker called every block, process inflation, update validator set.
+// EndBlocker called every block, it reinvests the rewards of the auto-compounded lock infos. A round of
+// auto-compounding starts every AutoCompoundInterval blocks and compounds at most MaxAutoCompoundsPerBlock
+// lock infos a block, going on from where the last block stops
 func EndBlocker(ctx sdk.Context, k keeper.Keeper) {
+	if !tmtypes.HigherThanVenus2(ctx.BlockHeight()) {
+		return
+	}
+	cursor := k.GetAutoCompoundCursor(ctx)
+	if len(cursor) == 0 && ctx.BlockHeight()%types.AutoCompoundInterval != 0 {
+		return
+	}
+
+	logger := k.Logger(ctx)
+	addrs, poolNames, next := k.GetAutoCompoundLockInfos(ctx, cursor, types.MaxAutoCompoundsPerBlock)
+	k.SetAutoCompoundCursor(ctx, next)
+	for i, addr := range addrs {
+		pool, found := k.GetFarmPool(ctx, poolNames[i])
+		if !found {
+			panic("should not happen")
+		}
 
+		// the failure of one lock info shouldn't affect the others
+		cacheCtx, write := ctx.CacheContext()
+		rewards, err := claimRewards(cacheCtx, k, pool, addr)
+		if err != nil {
+			logger.Error(fmt.Sprintf("failed to auto-compound the rewards of %s in pool %s: %s", addr, pool.Name, err))
+			continue
+		}
+		write()
+		logger.Debug(fmt.Sprintf("auto-compound the rewards %s of %s in pool %s", rewards, addr, pool.Name))
+	}
 }
 
 // calculateAllocateInfo gets all pools in PoolsYieldNativeToken
@@ -13,6 +13,7 @@ import (
 	"github.com/okex/exchain/libs/cosmos-sdk/x/auth/client/utils"
 	"github.com/okex/exchain/x/gov"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	client "github.com/okex/exchain/libs/cosmos-sdk/client/flags"
 	"github.com/okex/exchain/libs/cosmos-sdk/codec"
@@ -20,6 +21,13 @@ import (
 	"github.com/okex/exchain/x/farm/types"
 )
 
+// flags for the lock tiers
+const (
+	FlagLockTiers    = "lock-tiers"
+	FlagLockDuration = "lock-duration"
+	FlagAutoCompound = "auto-compound"
+)
+
 // GetTxCmd returns the transaction commands for this module
 func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 	farmTxCmd := &cobra.Command{
@@ -49,7 +57,11 @@ func GetCmdCreatePool(cdc *codec.Codec) *cobra.Command {
 Example:
 $ %s tx farm create-pool pool-eth-xxb 10eth xxb --from mykey
 $ %s tx farm create-pool pool-ammswap_eth_usdk-xxb 10ammswap_eth_usdk xxb --from mykey
-`, version.ClientName, version.ClientName),
+
+The tokens locked with a lock tier can't be unlocked until the lock duration passes, and their rewards are
+weighted by the multiplier of the tier:
+$ %s tx farm create-pool pool-xxb-xxb 10xxb xxb --lock-tiers 168h:1.2,720h:1.5,2160h:2 --from mykey
+`, version.ClientName, version.ClientName, version.ClientName),
 		),
 		Args: cobra.ExactArgs(3),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -63,11 +75,17 @@ $ %s tx farm create-pool pool-ammswap_eth_usdk-xxb 10ammswap_eth_usdk xxb --from
 				return err
 			}
 			yieldToken := args[2]
+			lockTiers, err := types.ParseLockTiers(viper.GetString(FlagLockTiers))
+			if err != nil {
+				return err
+			}
 			msg := types.NewMsgCreatePool(cliCtx.GetFromAddress(), poolName, minLockAmount, yieldToken)
+			msg.LockTiers = lockTiers
 
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
+	cmd.Flags().String(FlagLockTiers, "", "lock tiers of the pool as duration:multiplier pairs, e.g. 168h:1.2,720h:1.5")
 	return cmd
 }
 
@@ -144,7 +162,10 @@ func GetCmdLock(cdc *codec.Codec) *cobra.Command {
 
 Example:
 $ %s tx farm lock pool-eth-xxb 5eth --from mykey
-`, version.ClientName),
+
+Lock with a lock tier of the pool, and reinvest the rewards in the locked token automatically:
+$ %s tx farm lock pool-xxb-xxb 5xxb --lock-duration 720h --auto-compound --from mykey
+`, version.ClientName, version.ClientName),
 		),
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -159,9 +180,13 @@ $ %s tx farm lock pool-eth-xxb 5eth --from mykey
 
 			poolName := args[0]
 			msg := types.NewMsgLock(poolName, cliCtx.GetFromAddress(), amount)
+			msg.LockDuration = viper.GetDuration(FlagLockDuration)
+			msg.AutoCompound = viper.GetBool(FlagAutoCompound)
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
+	cmd.Flags().Duration(FlagLockDuration, 0, "lock duration of a lock tier of the pool")
+	cmd.Flags().Bool(FlagAutoCompound, false, "reinvest the rewards in the locked token into the locked tokens")
 	return cmd
 }
 
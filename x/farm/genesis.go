@@ -3,6 +3,7 @@ package farm
 import (
 	"fmt"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/okex/exchain/x/farm/keeper"
 	"github.com/okex/exchain/x/farm/types"
 )
@@ -11,8 +12,13 @@ import (
 func InitGenesis(ctx sdk.Context, k keeper.Keeper, data types.GenesisState) {
 	var yieldModuleAccHoldings sdk.SysCoins
 	var moduleAccHoldings sdk.SysCoins
+	// the lock tiers and the auto-compounding are only enabled since the Venus2 height
+	lockTierEnabled := tmtypes.HigherThanVenus2(tmtypes.GetStartBlockHeight() + 1)
 
 	for _, pool := range data.Pools {
+		if len(pool.LockTiers) != 0 && !lockTierEnabled {
+			panic(fmt.Sprintf("pool %s can't have lock tiers before the Venus2 height", pool.Name))
+		}
 		moduleAccHoldings = moduleAccHoldings.Add2(sdk.SysCoins{pool.TotalValueLocked})
 		moduleAccHoldings = moduleAccHoldings.Add2(sdk.SysCoins{pool.DepositAmount})
 		yieldModuleAccHoldings = yieldModuleAccHoldings.Add2(pool.TotalAccumulatedRewards)
@@ -20,6 +26,10 @@ func InitGenesis(ctx sdk.Context, k keeper.Keeper, data types.GenesisState) {
 	}
 
 	for _, lockInfo := range data.LockInfos {
+		if (lockInfo.LockDuration != 0 || lockInfo.AutoCompound) && !lockTierEnabled {
+			panic(fmt.Sprintf("lock info of %s in pool %s can't have a lock tier or be auto-compounded before the Venus2 height",
+				lockInfo.Owner, lockInfo.PoolName))
+		}
 		k.SetLockInfo(ctx, lockInfo)
 	}
 
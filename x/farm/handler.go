@@ -102,7 +102,7 @@ func handleMsgProvide(ctx sdk.Context, k keeper.Keeper, msg types.MsgProvide) (*
 	}
 
 	// 3. Terminate pool current period
-	k.IncrementPoolPeriod(ctx, pool.Name, pool.TotalValueLocked, yieldedTokens)
+	k.IncrementPoolPeriod(ctx, pool.Name, pool.WeightedValueLocked(), yieldedTokens)
 
 	// 4. Transfer coin to farm module account
 	if err := k.SupplyKeeper().SendCoinsFromAccountToModule(
@@ -135,32 +135,46 @@ func handleMsgClaim(ctx sdk.Context, k keeper.Keeper, msg types.MsgClaim) (*sdk.
 		return types.ErrNoFarmPoolFound(msg.PoolName).Result()
 	}
 
-	// 2. Calculate how many provided token & native token could be yielded in current period
+	// 2. Claim rewards
+	if _, err := claimRewards(ctx, k, pool, msg.Address); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeClaim,
+		sdk.NewAttribute(types.AttributeKeyAddress, msg.Address.String()),
+		sdk.NewAttribute(types.AttributeKeyPool, msg.PoolName),
+	))
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// claimRewards withdraws the rewards of the lock info in the pool, the rewards in the locked token are reinvested
+// into the lock info if it's auto-compounded
+func claimRewards(ctx sdk.Context, k keeper.Keeper, pool types.FarmPool, addr sdk.AccAddress) (sdk.SysCoins, error) {
+	// 1. Calculate how many provided token & native token could be yielded in current period
 	updatedPool, yieldedTokens := k.CalculateAmountYieldedBetween(ctx, pool)
 
-	// 3. Withdraw rewards
-	rewards, err := k.WithdrawRewards(ctx, pool.Name, pool.TotalValueLocked, yieldedTokens, msg.Address)
+	// 2. Withdraw rewards
+	rewards, compounded, err := k.WithdrawAndCompoundRewards(
+		ctx, pool.Name, pool.WeightedValueLocked(), yieldedTokens, addr,
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	// 4. Update the lock_info data
-	k.UpdateLockInfo(ctx, msg.Address, pool.Name, sdk.ZeroDec())
+	// 3. Update the lock_info data with the compounded rewards
+	weightedChangedAmount := k.UpdateLockInfo(ctx, addr, pool.Name, compounded)
 
-	// 5. Update farm pool
+	// 4. Update farm pool
 	if updatedPool.TotalAccumulatedRewards.IsAllLT(rewards) {
 		panic("should not happen")
 	}
 	updatedPool.TotalAccumulatedRewards = updatedPool.TotalAccumulatedRewards.Sub(rewards)
+	updatedPool.TotalValueLocked.Amount = updatedPool.TotalValueLocked.Amount.Add(compounded)
+	updatedPool.AddWeightedValueLocked(weightedChangedAmount)
 	k.SetFarmPool(ctx, updatedPool)
 
-	// 6. notify backend
-	k.OnClaim(ctx, msg.Address, pool.Name, rewards)
-
-	ctx.EventManager().EmitEvent(sdk.NewEvent(
-		types.EventTypeClaim,
-		sdk.NewAttribute(types.AttributeKeyAddress, msg.Address.String()),
-		sdk.NewAttribute(types.AttributeKeyPool, msg.PoolName),
-	))
-	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+	// 5. notify backend
+	k.OnClaim(ctx, addr, pool.Name, rewards)
+	return rewards, nil
 }
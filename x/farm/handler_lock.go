@@ -2,6 +2,7 @@ package farm
 
 import (
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/okex/exchain/x/farm/keeper"
 	"github.com/okex/exchain/x/farm/types"
 )
@@ -22,15 +23,39 @@ func handleMsgLock(ctx sdk.Context, k keeper.Keeper, msg types.MsgLock) (*sdk.Re
 		return types.ErrLockAmountBelowMinimum(pool.MinLockAmount.Amount, msg.Amount.Amount).Result()
 	}
 
+	// 1.3 check lock tier and auto-compounding
+	if (msg.LockDuration != 0 || msg.AutoCompound) && !tmtypes.HigherThanVenus2(ctx.BlockHeight()) {
+		return types.ErrLockTierNotEnabled().Result()
+	}
+	lockTier, found := pool.LockTiers.Get(msg.LockDuration)
+	if !found {
+		return types.ErrInvalidLockTier(msg.PoolName, msg.LockDuration).Result()
+	}
+	if msg.AutoCompound && !pool.CanAutoCompound() {
+		return types.ErrAutoCompoundNotSupported(msg.PoolName, pool.MinLockAmount.Denom).Result()
+	}
+
 	// 2. Calculate how many provided token & native token could be yielded in current period
 	updatedPool, yieldedTokens := k.CalculateAmountYieldedBetween(ctx, pool)
 
 	// 3. Lock info
 	var rewards sdk.SysCoins
+	compounded := sdk.ZeroDec()
 	if hasLocked {
-		// If it exists, withdraw money
+		// If it exists, the lock tier can't be changed, and the unlock time is extended for all the locked tokens
+		lockInfo, _ := k.GetLockInfo(ctx, msg.Address, msg.PoolName)
+		if lockInfo.LockDuration != lockTier.Duration {
+			return types.ErrLockTierMismatch(msg.PoolName, msg.LockDuration, lockInfo.LockDuration).Result()
+		}
+		lockInfo.SetLockTier(lockTier, ctx.BlockTime())
+		lockInfo.AutoCompound = msg.AutoCompound
+		k.SetLockInfo(ctx, lockInfo)
+
+		// withdraw money
 		var err error
-		rewards, err = k.WithdrawRewards(ctx, pool.Name, pool.TotalValueLocked, yieldedTokens, msg.Address)
+		rewards, compounded, err = k.WithdrawAndCompoundRewards(
+			ctx, pool.Name, pool.WeightedValueLocked(), yieldedTokens, msg.Address,
+		)
 		if err != nil {
 			return nil, err
 		}
@@ -41,19 +66,21 @@ func handleMsgLock(ctx sdk.Context, k keeper.Keeper, msg types.MsgLock) (*sdk.Re
 
 	} else {
 		// If it doesn't exist, only increase period
-		k.IncrementPoolPeriod(ctx, pool.Name, pool.TotalValueLocked, yieldedTokens)
+		k.IncrementPoolPeriod(ctx, pool.Name, pool.WeightedValueLocked(), yieldedTokens)
 
 		// Create new lock info
 		lockInfo := types.NewLockInfo(
 			msg.Address, pool.Name, sdk.NewDecCoinFromDec(pool.MinLockAmount.Denom, sdk.ZeroDec()),
 			ctx.BlockHeight(), 0,
 		)
+		lockInfo.SetLockTier(lockTier, ctx.BlockTime())
+		lockInfo.AutoCompound = msg.AutoCompound
 		k.SetLockInfo(ctx, lockInfo)
 		k.SetAddressInFarmPool(ctx, msg.PoolName, msg.Address)
 	}
 
 	// 4. Update lock info
-	k.UpdateLockInfo(ctx, msg.Address, msg.PoolName, msg.Amount.Amount)
+	weightedChangedAmount := k.UpdateLockInfo(ctx, msg.Address, msg.PoolName, msg.Amount.Amount.Add(compounded))
 
 	// 5. Send the locked-tokens from its own account to farm module account
 	if err := k.SupplyKeeper().SendCoinsFromAccountToModule(
@@ -64,6 +91,8 @@ func handleMsgLock(ctx sdk.Context, k keeper.Keeper, msg types.MsgLock) (*sdk.Re
 
 	// 6. Update farm pool
 	updatedPool.TotalValueLocked = updatedPool.TotalValueLocked.Add(msg.Amount)
+	updatedPool.TotalValueLocked.Amount = updatedPool.TotalValueLocked.Amount.Add(compounded)
+	updatedPool.AddWeightedValueLocked(weightedChangedAmount)
 	k.SetFarmPool(ctx, updatedPool)
 
 	// 7. notify backend
@@ -76,6 +105,7 @@ func handleMsgLock(ctx sdk.Context, k keeper.Keeper, msg types.MsgLock) (*sdk.Re
 		sdk.NewAttribute(types.AttributeKeyAddress, msg.Address.String()),
 		sdk.NewAttribute(types.AttributeKeyPool, msg.PoolName),
 		sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.String()),
+		sdk.NewAttribute(types.AttributeKeyLockDuration, msg.LockDuration.String()),
 	))
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
@@ -103,6 +133,9 @@ func handleMsgUnlock(ctx sdk.Context, k keeper.Keeper, msg types.MsgUnlock) (*sd
 	if pool.MinLockAmount.Denom != msg.Amount.Denom {
 		return types.ErrInvalidDenom(pool.MinLockAmount.Denom, msg.Amount.Denom).Result()
 	}
+	if !lockInfo.IsUnlocked(ctx.BlockTime()) {
+		return types.ErrLockNotExpired(msg.PoolName, lockInfo.GetUnlockTime()).Result()
+	}
 	remainAmount := lockInfo.Amount.Amount.Sub(msg.Amount.Amount)
 	if !remainAmount.IsZero() && remainAmount.LT(pool.MinLockAmount.Amount) {
 		return types.ErrLockAmountBelowMinimum(pool.MinLockAmount.Amount, remainAmount).Result()
@@ -112,13 +145,13 @@ func handleMsgUnlock(ctx sdk.Context, k keeper.Keeper, msg types.MsgUnlock) (*sd
 	updatedPool, yieldedTokens := k.CalculateAmountYieldedBetween(ctx, pool)
 
 	// 3. Withdraw money
-	rewards, err := k.WithdrawRewards(ctx, pool.Name, pool.TotalValueLocked, yieldedTokens, msg.Address)
+	rewards, err := k.WithdrawRewards(ctx, pool.Name, pool.WeightedValueLocked(), yieldedTokens, msg.Address)
 	if err != nil {
 		return nil, err
 	}
 
 	// 4. Update the lock info
-	k.UpdateLockInfo(ctx, msg.Address, msg.PoolName, msg.Amount.Amount.Neg())
+	weightedChangedAmount := k.UpdateLockInfo(ctx, msg.Address, msg.PoolName, msg.Amount.Amount.Neg())
 
 	// 5. Send the locked-tokens from farm module account to its own account
 	if err = k.SupplyKeeper().SendCoinsFromModuleToAccount(ctx, ModuleName, msg.Address, msg.Amount.ToCoins()); err != nil {
@@ -127,6 +160,7 @@ func handleMsgUnlock(ctx sdk.Context, k keeper.Keeper, msg types.MsgUnlock) (*sd
 
 	// 6. Update farm pool
 	updatedPool.TotalValueLocked = updatedPool.TotalValueLocked.Sub(msg.Amount)
+	updatedPool.AddWeightedValueLocked(weightedChangedAmount)
 	if updatedPool.TotalAccumulatedRewards.IsAllLT(rewards) {
 		panic("should not happen")
 	}
@@ -0,0 +1,240 @@
+package farm
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/farm/keeper"
+	"github.com/okex/exchain/x/farm/types"
+	"github.com/okex/exchain/x/token"
+	"github.com/stretchr/testify/require"
+)
+
+const testLockTierTokenName = "xxb"
+
+func initLockTierEnvironment(t *testing.T) (sdk.Context, keeper.MockFarmKeeper, sdk.Handler, string) {
+	ctx, mk := keeper.GetKeeper(t)
+	ctx.SetBlockHeight(10)
+	ctx.SetBlockTime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	token.NewTestToken(t, ctx, mk.TokenKeeper, mk.BankKeeper, testLockTierTokenName, keeper.Addrs)
+	handler := NewHandler(mk.Keeper)
+
+	// the pool locks and yields the same token, with a lock tier doubling the rewards
+	poolName := "pool-xxb-xxb"
+	owner := keeper.Addrs[0]
+	createPoolMsg := types.NewMsgCreatePool(
+		owner, poolName, sdk.NewDecCoinFromDec(testLockTierTokenName, sdk.NewDec(1)), testLockTierTokenName,
+	)
+	createPoolMsg.LockTiers = types.LockTiers{types.NewLockTier(24*time.Hour, sdk.NewDec(2))}
+	_, err := handler(ctx, createPoolMsg)
+	require.Nil(t, err)
+
+	provideMsg := types.NewMsgProvide(
+		poolName, owner, sdk.NewDecCoinFromDec(testLockTierTokenName, sdk.NewDec(10000)), sdk.NewDec(10), ctx.BlockHeight()+1,
+	)
+	_, err = handler(ctx, provideMsg)
+	require.Nil(t, err)
+	return ctx, mk, handler, poolName
+}
+
+func TestHandlerLockTier(t *testing.T) {
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(1)
+	defer tmtypes.UnittestOnlySetMilestoneVenus2Height(0)
+	ctx, mk, handler, poolName := initLockTierEnvironment(t)
+	k := mk.Keeper
+	addr1, addr2 := keeper.Addrs[1], keeper.Addrs[2]
+	lockAmount := sdk.NewDecCoinFromDec(testLockTierTokenName, sdk.NewDec(10))
+
+	// lock with an unknown lock tier
+	lockMsg := types.NewMsgLock(poolName, addr1, lockAmount)
+	lockMsg.LockDuration = time.Hour
+	_, err := handler(ctx, lockMsg)
+	require.Equal(t, types.ErrInvalidLockTier(poolName, time.Hour).Error(), err.Error())
+
+	// addr1 locks with the lock tier, addr2 locks without any lock tier
+	lockMsg.LockDuration = 24 * time.Hour
+	_, err = handler(ctx, lockMsg)
+	require.Nil(t, err)
+	_, err = handler(ctx, types.NewMsgLock(poolName, addr2, lockAmount))
+	require.Nil(t, err)
+
+	lockInfo, found := k.GetLockInfo(ctx, addr1, poolName)
+	require.True(t, found)
+	require.Equal(t, ctx.BlockTime().Add(24*time.Hour), lockInfo.GetUnlockTime())
+	pool, found := k.GetFarmPool(ctx, poolName)
+	require.True(t, found)
+	require.Equal(t, sdk.NewDec(20), pool.TotalValueLocked.Amount)
+	require.Equal(t, sdk.NewDec(30), pool.WeightedValueLocked().Amount)
+
+	// the lock tier of the locked tokens can't be changed
+	_, err = handler(ctx, types.NewMsgLock(poolName, addr1, lockAmount))
+	require.Equal(t, types.ErrLockTierMismatch(poolName, 0, 24*time.Hour).Error(), err.Error())
+
+	// 90 tokens are yielded from height 11 to 20, and addr1 gets the double rewards of addr2
+	ctx.SetBlockHeight(20)
+	preCoins1 := k.TokenKeeper().GetCoins(ctx, addr1)
+	preCoins2 := k.TokenKeeper().GetCoins(ctx, addr2)
+	_, err = handler(ctx, types.NewMsgClaim(poolName, addr1))
+	require.Nil(t, err)
+	_, err = handler(ctx, types.NewMsgClaim(poolName, addr2))
+	require.Nil(t, err)
+	rewards1 := k.TokenKeeper().GetCoins(ctx, addr1).Sub(preCoins1)
+	rewards2 := k.TokenKeeper().GetCoins(ctx, addr2).Sub(preCoins2)
+	require.Equal(t, sdk.NewDec(60), rewards1.AmountOf(testLockTierTokenName))
+	require.Equal(t, sdk.NewDec(30), rewards2.AmountOf(testLockTierTokenName))
+
+	// the locked tokens can't be unlocked until the lock duration passes
+	unlockMsg := types.NewMsgUnlock(poolName, addr1, lockAmount)
+	_, err = handler(ctx, unlockMsg)
+	require.Equal(t, types.ErrLockNotExpired(poolName, lockInfo.GetUnlockTime()).Error(), err.Error())
+
+	ctx.SetBlockTime(lockInfo.GetUnlockTime())
+	_, err = handler(ctx, unlockMsg)
+	require.Nil(t, err)
+	_, err = handler(ctx, types.NewMsgUnlock(poolName, addr2, lockAmount))
+	require.Nil(t, err)
+
+	pool, found = k.GetFarmPool(ctx, poolName)
+	require.True(t, found)
+	require.True(t, pool.TotalValueLocked.IsZero())
+	require.True(t, pool.WeightedValueLocked().IsZero())
+}
+
+func TestHandlerAutoCompound(t *testing.T) {
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(1)
+	defer tmtypes.UnittestOnlySetMilestoneVenus2Height(0)
+	ctx, mk, handler, poolName := initLockTierEnvironment(t)
+	k := mk.Keeper
+	addr1, addr2 := keeper.Addrs[1], keeper.Addrs[2]
+	lockAmount := sdk.NewDecCoinFromDec(testLockTierTokenName, sdk.NewDec(10))
+
+	// addr1 reinvests the rewards, addr2 doesn't
+	lockMsg := types.NewMsgLock(poolName, addr1, lockAmount)
+	lockMsg.AutoCompound = true
+	_, err := handler(ctx, lockMsg)
+	require.Nil(t, err)
+	_, err = handler(ctx, types.NewMsgLock(poolName, addr2, lockAmount))
+	require.Nil(t, err)
+
+	// the claimed rewards of addr1 are added to its locked tokens
+	ctx.SetBlockHeight(20)
+	preCoins := k.TokenKeeper().GetCoins(ctx, addr1)
+	_, err = handler(ctx, types.NewMsgClaim(poolName, addr1))
+	require.Nil(t, err)
+	require.Equal(t, preCoins, k.TokenKeeper().GetCoins(ctx, addr1))
+	lockInfo, found := k.GetLockInfo(ctx, addr1, poolName)
+	require.True(t, found)
+	require.Equal(t, sdk.NewDec(55), lockInfo.Amount.Amount)
+
+	// the rewards are reinvested every AutoCompoundInterval blocks
+	ctx.SetBlockHeight(types.AutoCompoundInterval)
+	EndBlocker(ctx, k)
+	require.Equal(t, preCoins, k.TokenKeeper().GetCoins(ctx, addr1))
+	compoundedLockInfo, found := k.GetLockInfo(ctx, addr1, poolName)
+	require.True(t, found)
+	require.True(t, compoundedLockInfo.Amount.Amount.GT(lockInfo.Amount.Amount))
+	lockInfo2, found := k.GetLockInfo(ctx, addr2, poolName)
+	require.True(t, found)
+	require.Equal(t, lockAmount, lockInfo2.Amount)
+
+	// the locked tokens in the farm module account include the compounded rewards
+	pool, found := k.GetFarmPool(ctx, poolName)
+	require.True(t, found)
+	require.Equal(t, compoundedLockInfo.Amount.Amount.Add(lockAmount.Amount), pool.TotalValueLocked.Amount)
+	moduleAcc := k.SupplyKeeper().GetModuleAccount(ctx, ModuleName)
+	require.Equal(t, pool.TotalValueLocked.Amount, moduleAcc.GetCoins().AmountOf(testLockTierTokenName))
+}
+
+func TestHandlerLockTierBeforeVenus2(t *testing.T) {
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(100)
+	defer tmtypes.UnittestOnlySetMilestoneVenus2Height(0)
+	ctx, mk := keeper.GetKeeper(t)
+	ctx.SetBlockHeight(10)
+	token.NewTestToken(t, ctx, mk.TokenKeeper, mk.BankKeeper, testLockTierTokenName, keeper.Addrs)
+	handler := NewHandler(mk.Keeper)
+	poolName := "pool-xxb-xxb"
+	owner := keeper.Addrs[0]
+
+	// the pool can't have lock tiers
+	createPoolMsg := types.NewMsgCreatePool(
+		owner, poolName, sdk.NewDecCoinFromDec(testLockTierTokenName, sdk.NewDec(1)), testLockTierTokenName,
+	)
+	createPoolMsg.LockTiers = types.LockTiers{types.NewLockTier(24*time.Hour, sdk.NewDec(2))}
+	_, err := handler(ctx, createPoolMsg)
+	require.Equal(t, types.ErrLockTierNotEnabled().Error(), err.Error())
+	createPoolMsg.LockTiers = nil
+	_, err = handler(ctx, createPoolMsg)
+	require.Nil(t, err)
+	pool, found := mk.Keeper.GetFarmPool(ctx, poolName)
+	require.True(t, found)
+	require.Nil(t, pool.TotalWeightedValueLocked)
+
+	// the tokens can't be locked with a lock tier or auto-compounded
+	lockAmount := sdk.NewDecCoinFromDec(testLockTierTokenName, sdk.NewDec(10))
+	lockMsg := types.NewMsgLock(poolName, owner, lockAmount)
+	lockMsg.AutoCompound = true
+	_, err = handler(ctx, lockMsg)
+	require.Equal(t, types.ErrLockTierNotEnabled().Error(), err.Error())
+
+	// the lock info without lock tier leaves the lock tier fields unset
+	_, err = handler(ctx, types.NewMsgLock(poolName, owner, lockAmount))
+	require.Nil(t, err)
+	lockInfo, found := mk.Keeper.GetLockInfo(ctx, owner, poolName)
+	require.True(t, found)
+	require.Nil(t, lockInfo.Multiplier)
+	require.Zero(t, lockInfo.UnlockTime)
+	pool, found = mk.Keeper.GetFarmPool(ctx, poolName)
+	require.True(t, found)
+	require.Nil(t, pool.TotalWeightedValueLocked)
+	require.Equal(t, lockAmount, pool.WeightedValueLocked())
+}
+
+func TestAutoCompoundRound(t *testing.T) {
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(1)
+	defer tmtypes.UnittestOnlySetMilestoneVenus2Height(0)
+	ctx, mk, handler, poolName := initLockTierEnvironment(t)
+	k := mk.Keeper
+	lockAmount := sdk.NewDecCoinFromDec(testLockTierTokenName, sdk.NewDec(10))
+	for _, addr := range keeper.Addrs[1:4] {
+		lockMsg := types.NewMsgLock(poolName, addr, lockAmount)
+		lockMsg.AutoCompound = true
+		_, err := handler(ctx, lockMsg)
+		require.Nil(t, err)
+	}
+
+	// the lock infos are got page by page
+	addrs, _, cursor := k.GetAutoCompoundLockInfos(ctx, nil, 2)
+	require.Len(t, addrs, 2)
+	require.NotNil(t, cursor)
+	lastAddrs, _, next := k.GetAutoCompoundLockInfos(ctx, cursor, 2)
+	require.Len(t, lastAddrs, 1)
+	require.Nil(t, next)
+	firstAddrs, _, _ := k.GetAutoCompoundLockInfos(ctx, nil, 1)
+
+	// no round starts out of the interval
+	ctx.SetBlockHeight(types.AutoCompoundInterval + 1)
+	EndBlocker(ctx, k)
+	for _, addr := range keeper.Addrs[1:4] {
+		lockInfo, found := k.GetLockInfo(ctx, addr, poolName)
+		require.True(t, found)
+		require.Equal(t, lockAmount, lockInfo.Amount)
+	}
+
+	// a round going on resumes after the cursor and ends with the last lock info
+	firstKey := types.GetAutoCompoundLockInfoKey(firstAddrs[0], poolName)
+	k.SetAutoCompoundCursor(ctx, firstKey)
+	ctx.SetBlockHeight(types.AutoCompoundInterval + 2)
+	EndBlocker(ctx, k)
+	require.Nil(t, k.GetAutoCompoundCursor(ctx))
+	for _, addr := range keeper.Addrs[1:4] {
+		lockInfo, found := k.GetLockInfo(ctx, addr, poolName)
+		require.True(t, found)
+		if addr.Equals(firstAddrs[0]) {
+			require.Equal(t, lockAmount, lockInfo.Amount)
+		} else {
+			require.True(t, lockInfo.Amount.Amount.GT(lockAmount.Amount))
+		}
+	}
+}
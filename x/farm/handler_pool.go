@@ -2,12 +2,17 @@ package farm
 
 import (
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/okex/exchain/x/common"
 	"github.com/okex/exchain/x/farm/keeper"
 	"github.com/okex/exchain/x/farm/types"
 )
 
 func handleMsgCreatePool(ctx sdk.Context, k keeper.Keeper, msg types.MsgCreatePool) (*sdk.Result, error) {
+	if len(msg.LockTiers) != 0 && !tmtypes.HigherThanVenus2(ctx.BlockHeight()) {
+		return types.ErrLockTierNotEnabled().Result()
+	}
+
 	if _, found := k.GetFarmPool(ctx, msg.PoolName); found {
 		return types.ErrPoolAlreadyExist(msg.PoolName).Result()
 	}
@@ -44,6 +49,11 @@ func handleMsgCreatePool(ctx sdk.Context, k keeper.Keeper, msg types.MsgCreatePo
 		msg.Owner, msg.PoolName, msg.MinLockAmount, depositAmount, sdk.NewDecCoin(msg.MinLockAmount.Denom, sdk.ZeroInt()),
 		[]types.YieldedTokenInfo{yieldedTokenInfo}, sdk.SysCoins{},
 	)
+	if len(msg.LockTiers) != 0 {
+		totalWeightedValueLocked := sdk.ZeroDec()
+		pool.LockTiers = msg.LockTiers
+		pool.TotalWeightedValueLocked = &totalWeightedValueLocked
+	}
 	k.SetFarmPool(ctx, pool)
 
 	// initial pool period
@@ -60,6 +70,7 @@ func handleMsgCreatePool(ctx sdk.Context, k keeper.Keeper, msg types.MsgCreatePo
 		sdk.NewAttribute(types.AttributeKeyYieldToken, msg.YieldedSymbol),
 		sdk.NewAttribute(sdk.AttributeKeyFee, feeAmount.String()),
 		sdk.NewAttribute(types.AttributeKeyDeposit, depositAmount.String()),
+		sdk.NewAttribute(types.AttributeKeyLockTiers, msg.LockTiers.String()),
 	))
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
@@ -46,31 +46,64 @@ func (k Keeper) CalculateAmountYieldedBetween(ctx sdk.Context, pool types.FarmPo
 	return pool, totalYieldedTokens
 }
 
+// WithdrawRewards ends the current period of the pool and transfers the rewards of the lock info to its owner
 func (k Keeper) WithdrawRewards(
 	ctx sdk.Context, poolName string, totalValueLocked sdk.SysCoin, yieldedTokens sdk.SysCoins, addr sdk.AccAddress,
 ) (sdk.SysCoins, sdk.Error) {
+	rewards, _, err := k.withdrawRewards(ctx, poolName, totalValueLocked, yieldedTokens, addr, false)
+	return rewards, err
+}
+
+// WithdrawAndCompoundRewards withdraws the rewards as WithdrawRewards, but the rewards in the locked token are
+// reinvested into the farm module account if the lock info is auto-compounded. It returns all the rewards and
+// the compounded amount, which should be added to the lock info by UpdateLockInfo
+func (k Keeper) WithdrawAndCompoundRewards(
+	ctx sdk.Context, poolName string, totalValueLocked sdk.SysCoin, yieldedTokens sdk.SysCoins, addr sdk.AccAddress,
+) (sdk.SysCoins, sdk.Dec, sdk.Error) {
+	return k.withdrawRewards(ctx, poolName, totalValueLocked, yieldedTokens, addr, true)
+}
+
+func (k Keeper) withdrawRewards(
+	ctx sdk.Context, poolName string, totalValueLocked sdk.SysCoin, yieldedTokens sdk.SysCoins, addr sdk.AccAddress,
+	compound bool,
+) (sdk.SysCoins, sdk.Dec, sdk.Error) {
+	compounded := sdk.ZeroDec()
 	// 0. check existence of lock info
 	lockInfo, found := k.GetLockInfo(ctx, addr, poolName)
 	if !found {
-		return nil, types.ErrNoLockInfoFound(addr.String(), poolName)
+		return nil, compounded, types.ErrNoLockInfoFound(addr.String(), poolName)
 	}
 
 	// 1. end current period and calculate rewards
 	endingPeriod := k.IncrementPoolPeriod(ctx, poolName, totalValueLocked, yieldedTokens)
 	rewards := k.calculateRewards(ctx, poolName, addr, endingPeriod, lockInfo)
 
-	// 2. transfer rewards to user account
-	if !rewards.IsZero() {
-		err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.YieldFarmingAccount, addr, rewards)
+	// 2. reinvest the rewards in the locked token
+	paidRewards := rewards
+	if compound && lockInfo.AutoCompound {
+		compounded = rewards.AmountOf(lockInfo.Amount.Denom)
+		if compounded.IsPositive() {
+			compoundedCoins := sdk.NewDecCoinsFromDec(lockInfo.Amount.Denom, compounded)
+			err := k.supplyKeeper.SendCoinsFromModuleToModule(ctx, types.YieldFarmingAccount, types.ModuleName, compoundedCoins)
+			if err != nil {
+				return nil, compounded, err
+			}
+			paidRewards = rewards.Sub(compoundedCoins)
+		}
+	}
+
+	// 3. transfer rewards to user account
+	if !paidRewards.IsZero() {
+		err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.YieldFarmingAccount, addr, paidRewards)
 		if err != nil {
-			return nil, err
+			return nil, compounded, err
 		}
 	}
 
-	// 3. decrement reference count of lock info
+	// 4. decrement reference count of lock info
 	k.decrementReferenceCount(ctx, poolName, lockInfo.ReferencePeriod)
 
-	return rewards, nil
+	return rewards, compounded, nil
 }
 
 // IncrementPoolPeriod increments pool period, returning the period just ended
@@ -137,8 +170,9 @@ func (k Keeper) calculateRewards(
 	}
 
 	startingPeriod := lockInfo.ReferencePeriod
-	// calculate rewards for final period
-	return k.calculateLockRewardsBetween(ctx, poolName, startingPeriod, endingPeriod, lockInfo.Amount)
+	// calculate rewards for final period, weighted by the lock tier
+	weightedAmount := sdk.NewDecCoinFromDec(lockInfo.Amount.Denom, lockInfo.WeightedAmount())
+	return k.calculateLockRewardsBetween(ctx, poolName, startingPeriod, endingPeriod, weightedAmount)
 }
 
 // calculateLockRewardsBetween calculate the rewards accrued by a pool between two periods
@@ -162,8 +196,11 @@ func (k Keeper) calculateLockRewardsBetween(ctx sdk.Context, poolName string, st
 	return
 }
 
-// UpdateLockInfo updates lock info for the modified lock info
-func (k Keeper) UpdateLockInfo(ctx sdk.Context, addr sdk.AccAddress, poolName string, changedAmount sdk.Dec) {
+// UpdateLockInfo updates lock info for the modified lock info, returning the changed weighted amount
+// which should be added to the pool by FarmPool.AddWeightedValueLocked
+func (k Keeper) UpdateLockInfo(
+	ctx sdk.Context, addr sdk.AccAddress, poolName string, changedAmount sdk.Dec,
+) (weightedChangedAmount sdk.Dec) {
 	// period has already been incremented - we want to store the period ended by this lock action
 	previousPeriod := k.GetPoolCurrentRewards(ctx, poolName).Period - 1
 
@@ -174,7 +211,9 @@ func (k Keeper) UpdateLockInfo(ctx sdk.Context, addr sdk.AccAddress, poolName st
 	}
 	lockInfo.StartBlockHeight = ctx.BlockHeight()
 	lockInfo.ReferencePeriod = previousPeriod
+	previousWeightedAmount := lockInfo.WeightedAmount()
 	lockInfo.Amount.Amount = lockInfo.Amount.Amount.Add(changedAmount)
+	weightedChangedAmount = lockInfo.WeightedAmount().Sub(previousWeightedAmount)
 	if lockInfo.Amount.IsZero() {
 		k.DeleteLockInfo(ctx, lockInfo.Owner, lockInfo.PoolName)
 		k.DeleteAddressInFarmPool(ctx, lockInfo.PoolName, lockInfo.Owner)
@@ -186,4 +225,5 @@ func (k Keeper) UpdateLockInfo(ctx sdk.Context, addr sdk.AccAddress, poolName st
 		k.SetLockInfo(ctx, lockInfo)
 		k.SetAddressInFarmPool(ctx, lockInfo.PoolName, lockInfo.Owner)
 	}
+	return weightedChangedAmount
 }
@@ -108,6 +108,12 @@ func (k Keeper) DeleteAddressInFarmPool(ctx sdk.Context, poolName string, addr s
 func (k Keeper) SetLockInfo(ctx sdk.Context, lockInfo types.LockInfo) {
 	store := ctx.KVStore(k.storeKey)
 	store.Set(types.GetLockInfoKey(lockInfo.Owner, lockInfo.PoolName), k.cdc.MustMarshalBinaryLengthPrefixed(lockInfo))
+	// index the lock infos to be auto-compounded
+	if lockInfo.AutoCompound {
+		store.Set(types.GetAutoCompoundLockInfoKey(lockInfo.Owner, lockInfo.PoolName), []byte(""))
+	} else {
+		store.Delete(types.GetAutoCompoundLockInfoKey(lockInfo.Owner, lockInfo.PoolName))
+	}
 }
 
 func (k Keeper) GetLockInfo(ctx sdk.Context, addr sdk.AccAddress, poolName string) (info types.LockInfo, found bool) {
@@ -129,6 +135,7 @@ func (k Keeper) HasLockInfo(ctx sdk.Context, addr sdk.AccAddress, poolName strin
 func (k Keeper) DeleteLockInfo(ctx sdk.Context, addr sdk.AccAddress, poolName string) {
 	store := ctx.KVStore(k.storeKey)
 	store.Delete(types.GetLockInfoKey(addr, poolName))
+	store.Delete(types.GetAutoCompoundLockInfoKey(addr, poolName))
 }
 
 // GetPoolLockedValue gets the value of locked tokens in pool priced in quote symbol
@@ -203,3 +210,43 @@ func (k Keeper) IterateAllLockInfos(
 		}
 	}
 }
+
+// GetAutoCompoundLockInfos gets the owners and pool names of at most limit lock infos to be auto-compounded,
+// starting after the cursor key. The returned cursor is the key of the last lock info got, and it's nil if
+// there are no more lock infos after it
+func (k Keeper) GetAutoCompoundLockInfos(ctx sdk.Context, cursor []byte, limit int) (
+	addrs types.AccAddrList, poolNames types.PoolNameList, next []byte) {
+	store := ctx.KVStore(k.storeKey)
+	start := types.AutoCompoundLockInfoPrefix
+	if len(cursor) != 0 {
+		start = append(append([]byte{}, cursor...), 0x00)
+	}
+	iter := store.Iterator(start, sdk.PrefixEndBytes(types.AutoCompoundLockInfoPrefix))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if len(addrs) == limit {
+			return addrs, poolNames, next
+		}
+		addr, poolName := types.SplitAutoCompoundLockInfoKey(iter.Key())
+		addrs = append(addrs, addr)
+		poolNames = append(poolNames, poolName)
+		next = iter.Key()
+	}
+	return addrs, poolNames, nil
+}
+
+// GetAutoCompoundCursor gets the key of the last lock info auto-compounded in the round going on,
+// it's nil if no round is going on
+func (k Keeper) GetAutoCompoundCursor(ctx sdk.Context) []byte {
+	return ctx.KVStore(k.storeKey).Get(types.AutoCompoundCursorKey)
+}
+
+// SetAutoCompoundCursor sets the key of the last lock info auto-compounded, the nil cursor ends the round
+func (k Keeper) SetAutoCompoundCursor(ctx sdk.Context, cursor []byte) {
+	store := ctx.KVStore(k.storeKey)
+	if len(cursor) == 0 {
+		store.Delete(types.AutoCompoundCursorKey)
+		return
+	}
+	store.Set(types.AutoCompoundCursorKey, cursor)
+}
@@ -0,0 +1,51 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+// legacyLockInfo and legacyFarmPool are the encodings of LockInfo and FarmPool before the Venus2 height
+type legacyLockInfo struct {
+	Owner            sdk.AccAddress `json:"owner"`
+	PoolName         string         `json:"pool_name"`
+	Amount           sdk.SysCoin    `json:"amount"`
+	StartBlockHeight int64          `json:"start_block_height"`
+	ReferencePeriod  uint64         `json:"reference_period"`
+}
+
+type legacyFarmPool struct {
+	Owner                   sdk.AccAddress    `json:"owner"`
+	Name                    string            `json:"name"`
+	MinLockAmount           sdk.SysCoin       `json:"min_lock_amount"`
+	DepositAmount           sdk.SysCoin       `json:"deposit_amount"`
+	TotalValueLocked        sdk.SysCoin       `json:"total_value_locked"`
+	YieldedTokenInfos       YieldedTokenInfos `json:"yielded_token_infos"`
+	TotalAccumulatedRewards sdk.SysCoins      `json:"total_accumulated_rewards"`
+}
+
+func TestEncodingWithoutLockTier(t *testing.T) {
+	owner := sdk.AccAddress([]byte("owner_______________"))
+	amount := sdk.NewDecCoinFromDec("xxb", sdk.NewDec(10))
+
+	lockInfo := NewLockInfo(owner, "pool", amount, 10, 2)
+	lockInfo.SetLockTier(NewLockTier(0, sdk.OneDec()), time.Now())
+	legacyInfo := legacyLockInfo{owner, "pool", amount, 10, 2}
+	bz := ModuleCdc.MustMarshalBinaryLengthPrefixed(lockInfo)
+	require.Equal(t, ModuleCdc.MustMarshalBinaryLengthPrefixed(legacyInfo), bz)
+	var decodedInfo LockInfo
+	ModuleCdc.MustUnmarshalBinaryLengthPrefixed(bz, &decodedInfo)
+	require.Equal(t, bz, ModuleCdc.MustMarshalBinaryLengthPrefixed(decodedInfo))
+
+	yieldedTokenInfos := YieldedTokenInfos{NewYieldedTokenInfo(sdk.NewDecCoin("xxb", sdk.ZeroInt()), 0, sdk.ZeroDec())}
+	pool := NewFarmPool(owner, "pool", amount, amount, amount, yieldedTokenInfos, sdk.SysCoins{})
+	legacyPool := legacyFarmPool{owner, "pool", amount, amount, amount, yieldedTokenInfos, sdk.SysCoins{}}
+	bz = ModuleCdc.MustMarshalBinaryLengthPrefixed(pool)
+	require.Equal(t, ModuleCdc.MustMarshalBinaryLengthPrefixed(legacyPool), bz)
+	var decodedPool FarmPool
+	ModuleCdc.MustUnmarshalBinaryLengthPrefixed(bz, &decodedPool)
+	require.Equal(t, bz, ModuleCdc.MustMarshalBinaryLengthPrefixed(decodedPool))
+}
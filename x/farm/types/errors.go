@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"time"
 
 	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
 
@@ -35,6 +36,10 @@ const (
 	CodeLockAmountBelowMinimum             uint32 = 66019
 	CodeSendCoinsFromModuleToAccountFailed uint32 = 66020
 	CodeSwapTokenPairNotExist              uint32 = 66021
+	CodeInvalidLockTier                    uint32 = 66022
+	CodeLockNotExpired                     uint32 = 66023
+	CodeAutoCompoundNotSupported           uint32 = 66024
+	CodeLockTierNotEnabled                 uint32 = 66025
 )
 
 // ErrInvalidInput returns an error when an input parameter is invalid
@@ -149,3 +154,33 @@ func ErrSendCoinsFromModuleToAccountFailed(content string) sdk.EnvelopedErr {
 func ErrSwapTokenPairNotExist(tokenName string) sdk.EnvelopedErr {
 	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultParamspace, CodeSwapTokenPairNotExist, fmt.Sprintf("failed. swap token pair %s does not exist", tokenName))}
 }
+
+// ErrInvalidLockTier returns an error when the lock duration is not a lock tier of the pool
+func ErrInvalidLockTier(poolName string, duration time.Duration) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultParamspace, CodeInvalidLockTier,
+		fmt.Sprintf("failed. lock duration %s is not a lock tier of pool %s", duration, poolName))}
+}
+
+// ErrLockTierMismatch returns an error when the lock duration differs from the one of the existing lock info
+func ErrLockTierMismatch(poolName string, duration, lockedDuration time.Duration) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultParamspace, CodeInvalidLockTier,
+		fmt.Sprintf("failed. lock duration %s differs from the duration %s already locked in pool %s", duration, lockedDuration, poolName))}
+}
+
+// ErrLockNotExpired returns an error when unlocking tokens before the unlock time
+func ErrLockNotExpired(poolName string, unlockTime time.Time) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultParamspace, CodeLockNotExpired,
+		fmt.Sprintf("failed. the tokens locked in pool %s can't be unlocked until %s", poolName, unlockTime))}
+}
+
+// ErrAutoCompoundNotSupported returns an error when the pool doesn't yield the token it locks
+func ErrAutoCompoundNotSupported(poolName string, lockedSymbol string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultParamspace, CodeAutoCompoundNotSupported,
+		fmt.Sprintf("failed. pool %s doesn't yield the locked token %s, so it can't be auto-compounded", poolName, lockedSymbol))}
+}
+
+// ErrLockTierNotEnabled returns an error when the lock tiers or the auto-compounding are used before the Venus2 height
+func ErrLockTierNotEnabled() sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultParamspace, CodeLockTierNotEnabled,
+		"failed. lock tiers and auto-compounding are not enabled before the Venus2 height")}
+}
@@ -18,6 +18,8 @@ const (
 	AttributeKeyDeposit             = "deposit"
 	AttributeKeyWithdraw            = "withdraw"
 	AttributeKeyClaimed             = "claimed"
+	AttributeKeyLockTiers           = "lock_tiers"
+	AttributeKeyLockDuration        = "lock_duration"
 
 	AttributeValueCategory = ModuleName
 )
@@ -17,6 +17,11 @@ type FarmPool struct {
 	TotalValueLocked        sdk.SysCoin       `json:"total_value_locked"`
 	YieldedTokenInfos       YieldedTokenInfos `json:"yielded_token_infos"`
 	TotalAccumulatedRewards sdk.SysCoins      `json:"total_accumulated_rewards"`
+	// optional lock durations whose rewards are weighted by their multipliers, only allowed since the Venus2 height
+	LockTiers LockTiers `json:"lock_tiers,omitempty"`
+	// sum of LockInfo.WeightedAmount, only tracked when the pool has lock tiers. It's nil otherwise, so that the
+	// pools without lock tiers keep the encoding they had before the Venus2 height
+	TotalWeightedValueLocked *sdk.Dec `json:"total_weighted_value_locked,omitempty"`
 }
 
 // NewFarmPool creates a new instance of FarmPool
@@ -44,6 +49,42 @@ func (fp FarmPool) Finished() bool {
 	return fp.TotalValueLocked.IsZero()
 }
 
+// WeightedValueLocked returns the sum of the weighted amounts of all the lock infos, which the rewards of the pool
+// are shared by. It equals to the total value locked if the pool has no lock tiers
+func (fp FarmPool) WeightedValueLocked() sdk.SysCoin {
+	if len(fp.LockTiers) == 0 || fp.TotalWeightedValueLocked == nil {
+		return fp.TotalValueLocked
+	}
+	return sdk.NewDecCoinFromDec(fp.TotalValueLocked.Denom, *fp.TotalWeightedValueLocked)
+}
+
+// AddWeightedValueLocked adds the changed weighted amount of a lock info to the pool
+func (fp *FarmPool) AddWeightedValueLocked(changedAmount sdk.Dec) {
+	if len(fp.LockTiers) == 0 {
+		return
+	}
+	total := changedAmount
+	if fp.TotalWeightedValueLocked != nil {
+		total = fp.TotalWeightedValueLocked.Add(changedAmount)
+	}
+	fp.TotalWeightedValueLocked = &total
+}
+
+// CanAutoCompound returns whether the rewards of the pool can be reinvested into the locked tokens,
+// which needs the pool to yield the token it locks
+func (fp FarmPool) CanAutoCompound() bool {
+	lockedSymbol := fp.MinLockAmount.Denom
+	if lockedSymbol == sdk.DefaultBondDenom {
+		return true
+	}
+	for _, yieldedTokenInfo := range fp.YieldedTokenInfos {
+		if yieldedTokenInfo.RemainingAmount.Denom == lockedSymbol {
+			return true
+		}
+	}
+	return false
+}
+
 // String returns a human readable string representation of FarmPool
 func (fp FarmPool) String() string {
 	return fmt.Sprintf(`FarmPool:
@@ -53,8 +94,11 @@ func (fp FarmPool) String() string {
   Deposit Amount:                   %s
   Total Value Locked:               %s
   Yielded Token Infos:			    %s
-  Total Accumulated Rewards:        %s`,
-		fp.Name, fp.Owner, fp.MinLockAmount.String(), fp.DepositAmount, fp.TotalValueLocked, fp.YieldedTokenInfos, fp.TotalAccumulatedRewards)
+  Total Accumulated Rewards:        %s
+  Lock Tiers:                       %s
+  Total Weighted Value Locked:      %s`,
+		fp.Name, fp.Owner, fp.MinLockAmount.String(), fp.DepositAmount, fp.TotalValueLocked, fp.YieldedTokenInfos, fp.TotalAccumulatedRewards,
+		fp.LockTiers, fp.WeightedValueLocked())
 }
 
 // FarmPools is a collection of FarmPool
@@ -72,6 +72,12 @@ func ValidateGenesis(data GenesisState) error {
 		expectedReferenceCount += h.Rewards.ReferenceCount
 	}
 
+	for _, pool := range data.Pools {
+		if err := pool.LockTiers.Validate(); err != nil {
+			return fmt.Errorf("invalid lock tiers of pool %s: %s", pool.Name, err)
+		}
+	}
+
 	actualReferenceCount := len(data.LockInfos) + len(data.PoolCurrentRewards)
 	if actualReferenceCount != int(expectedReferenceCount) {
 		return fmt.Errorf("actual reference count(%d) is not equal to expected reference count(%d)",
@@ -4,6 +4,7 @@ import sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 
 // PoolHistoricalRewards records the reward ratio of one user in a pool
 type PoolHistoricalRewards struct {
+	// cumulative rewards per weighted locked token, see FarmPool.WeightedValueLocked
 	CumulativeRewardRatio sdk.SysCoins
 	ReferenceCount        uint16
 }
@@ -39,6 +39,8 @@ var (
 	PoolsYieldNativeTokenPrefix = []byte{0x04}
 	PoolHistoricalRewardsPrefix = []byte{0x05}
 	PoolCurrentRewardsPrefix    = []byte{0x06}
+	AutoCompoundLockInfoPrefix  = []byte{0x07}
+	AutoCompoundCursorKey       = []byte{0x08}
 )
 
 const (
@@ -87,3 +89,13 @@ func GetPoolHistoricalRewardsPrefix(poolName string) []byte {
 func GetPoolCurrentRewardsKey(poolName string) []byte {
 	return append(PoolCurrentRewardsPrefix, []byte(poolName)...)
 }
+
+// GetAutoCompoundLockInfoKey gets the key for a lock info whose rewards are auto-compounded
+func GetAutoCompoundLockInfoKey(addr sdk.AccAddress, poolName string) []byte {
+	return append(AutoCompoundLockInfoPrefix, append(addr.Bytes(), []byte(poolName)...)...)
+}
+
+// SplitAutoCompoundLockInfoKey splits the address and the pool name out from an AutoCompoundLockInfoKey
+func SplitAutoCompoundLockInfoKey(key []byte) (sdk.AccAddress, string) {
+	return sdk.AccAddress(key[1:poolNameFromLockInfoKeyIndex]), string(key[poolNameFromLockInfoKeyIndex:])
+}
@@ -2,6 +2,8 @@ package types
 
 import (
 	"fmt"
+	"time"
+
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 )
 
@@ -12,6 +14,15 @@ type LockInfo struct {
 	Amount           sdk.SysCoin    `json:"amount"`
 	StartBlockHeight int64          `json:"start_block_height"`
 	ReferencePeriod  uint64         `json:"reference_period"`
+	// lock tier of the locked tokens, the zero duration and the nil multiplier mean no lock tier.
+	// The lock tier fields are only set since the Venus2 height and are omitted from the encoding
+	// while unset, so that the lock infos without lock tier keep their encoding
+	LockDuration time.Duration `json:"lock_duration,omitempty"`
+	Multiplier   *sdk.Dec      `json:"multiplier,omitempty"`
+	// the locked tokens can't be unlocked before the unlock time in unix nanoseconds, 0 means unlocked at any time
+	UnlockTime int64 `json:"unlock_time,omitempty"`
+	// whether the rewards in the locked token are reinvested into the locked tokens
+	AutoCompound bool `json:"auto_compound,omitempty"`
 }
 
 // NewLockInfo creates a new instance of LockInfo
@@ -25,6 +36,44 @@ func NewLockInfo(owner sdk.AccAddress, poolName string, amount sdk.SysCoin, star
 	}
 }
 
+// SetLockTier sets the lock tier of the lock info, locking the tokens until the duration passes from the lock time.
+// The zero duration leaves the lock info without any lock tier
+func (li *LockInfo) SetLockTier(lockTier LockTier, lockTime time.Time) {
+	if lockTier.Duration == 0 {
+		return
+	}
+	multiplier := lockTier.Multiplier
+	li.LockDuration = lockTier.Duration
+	li.Multiplier = &multiplier
+	li.UnlockTime = lockTime.Add(lockTier.Duration).UnixNano()
+}
+
+// GetMultiplier returns the multiplier of the lock tier, which is 1 without any lock tier
+func (li LockInfo) GetMultiplier() sdk.Dec {
+	if li.Multiplier == nil {
+		return sdk.OneDec()
+	}
+	return *li.Multiplier
+}
+
+// WeightedAmount returns the locked amount weighted by the multiplier of the lock tier
+func (li LockInfo) WeightedAmount() sdk.Dec {
+	if li.Multiplier == nil {
+		return li.Amount.Amount
+	}
+	return li.Amount.Amount.MulTruncate(*li.Multiplier)
+}
+
+// GetUnlockTime returns the time since which the locked tokens can be unlocked
+func (li LockInfo) GetUnlockTime() time.Time {
+	return time.Unix(0, li.UnlockTime).UTC()
+}
+
+// IsUnlocked returns whether the locked tokens can be unlocked at the block time
+func (li LockInfo) IsUnlocked(blockTime time.Time) bool {
+	return li.UnlockTime == 0 || blockTime.UnixNano() >= li.UnlockTime
+}
+
 // String returns a human readable string representation of LockInfo
 func (li LockInfo) String() string {
 	return fmt.Sprintf(`Lock Info:
@@ -32,6 +81,11 @@ func (li LockInfo) String() string {
   Pool Name:					%s
   Locked Amount:      			%s
   Start Block Height:           %d
-  Reference Period:             %d`,
-		li.Owner, li.PoolName, li.Amount, li.StartBlockHeight, li.ReferencePeriod)
+  Reference Period:             %d
+  Lock Duration:                %s
+  Multiplier:                   %s
+  Unlock Time:                  %s
+  Auto Compound:                %v`,
+		li.Owner, li.PoolName, li.Amount, li.StartBlockHeight, li.ReferencePeriod,
+		li.LockDuration, li.GetMultiplier(), li.GetUnlockTime(), li.AutoCompound)
 }
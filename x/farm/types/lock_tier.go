@@ -0,0 +1,120 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+const (
+	// MaxLockTiers is the max number of lock tiers of a pool
+	MaxLockTiers = 10
+
+	// MaxLockTierMultiplier is the max multiplier of a lock tier
+	MaxLockTierMultiplier int64 = 10
+
+	// AutoCompoundInterval is the number of blocks between the starts of two auto-compounding rounds
+	AutoCompoundInterval int64 = 100
+
+	// MaxAutoCompoundsPerBlock bounds the lock infos auto-compounded in a block, a round of auto-compounding
+	// goes on in the following blocks until all the lock infos are compounded
+	MaxAutoCompoundsPerBlock = 100
+)
+
+// LockTier is a lock duration of a pool, the tokens locked with it can't be unlocked until the duration passes
+// and their rewards are weighted by the multiplier
+type LockTier struct {
+	Duration   time.Duration `json:"duration" yaml:"duration"`
+	Multiplier sdk.Dec       `json:"multiplier" yaml:"multiplier"`
+}
+
+// NewLockTier creates a new instance of LockTier
+func NewLockTier(duration time.Duration, multiplier sdk.Dec) LockTier {
+	return LockTier{
+		Duration:   duration,
+		Multiplier: multiplier,
+	}
+}
+
+// String returns a human readable string representation of LockTier
+func (lt LockTier) String() string {
+	return fmt.Sprintf("%s:%s", lt.Duration, lt.Multiplier)
+}
+
+// LockTiers is a collection of LockTier
+type LockTiers []LockTier
+
+// Validate checks the lock tiers of a pool
+func (lts LockTiers) Validate() error {
+	if len(lts) > MaxLockTiers {
+		return fmt.Errorf("the number of lock tiers %d exceeds the max %d", len(lts), MaxLockTiers)
+	}
+	durations := make(map[time.Duration]bool, len(lts))
+	for _, lt := range lts {
+		if lt.Duration <= 0 {
+			return fmt.Errorf("lock duration %s must be positive", lt.Duration)
+		}
+		if durations[lt.Duration] {
+			return fmt.Errorf("duplicated lock duration %s", lt.Duration)
+		}
+		durations[lt.Duration] = true
+		if lt.Multiplier.IsNil() || lt.Multiplier.LT(sdk.OneDec()) {
+			return fmt.Errorf("multiplier of lock duration %s must not be less than 1", lt.Duration)
+		}
+		if lt.Multiplier.GT(sdk.NewDec(MaxLockTierMultiplier)) {
+			return fmt.Errorf("multiplier of lock duration %s must not be greater than %d", lt.Duration, MaxLockTierMultiplier)
+		}
+	}
+	return nil
+}
+
+// Get returns the lock tier of the duration. The zero duration means locking without any tier,
+// whose multiplier is 1 and tokens can be unlocked at any time
+func (lts LockTiers) Get(duration time.Duration) (LockTier, bool) {
+	if duration == 0 {
+		return NewLockTier(0, sdk.OneDec()), true
+	}
+	for _, lt := range lts {
+		if lt.Duration == duration {
+			return lt, true
+		}
+	}
+	return LockTier{}, false
+}
+
+// String returns a human readable string representation of LockTiers
+func (lts LockTiers) String() string {
+	tiers := make([]string, len(lts))
+	for i, lt := range lts {
+		tiers[i] = lt.String()
+	}
+	return strings.Join(tiers, ",")
+}
+
+// ParseLockTiers parses the lock tiers from a string like "168h:1.2,720h:1.5,2160h:2"
+func ParseLockTiers(tiersStr string) (LockTiers, error) {
+	tiersStr = strings.TrimSpace(tiersStr)
+	if len(tiersStr) == 0 {
+		return nil, nil
+	}
+
+	var lts LockTiers
+	for _, tierStr := range strings.Split(tiersStr, ",") {
+		parts := strings.Split(strings.TrimSpace(tierStr), ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid lock tier %s, it should be like 168h:1.2", tierStr)
+		}
+		duration, err := time.ParseDuration(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		multiplier, err := sdk.NewDecFromStr(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		lts = append(lts, NewLockTier(duration, multiplier))
+	}
+	return lts, lts.Validate()
+}
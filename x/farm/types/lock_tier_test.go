@@ -0,0 +1,53 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLockTiers(t *testing.T) {
+	lockTiers, err := ParseLockTiers("168h:1.2, 720h:2")
+	require.Nil(t, err)
+	require.Equal(t, LockTiers{
+		NewLockTier(168*time.Hour, sdk.MustNewDecFromStr("1.2")),
+		NewLockTier(720*time.Hour, sdk.NewDec(2)),
+	}, lockTiers)
+
+	lockTier, found := lockTiers.Get(720 * time.Hour)
+	require.True(t, found)
+	require.Equal(t, sdk.NewDec(2), lockTier.Multiplier)
+	lockTier, found = lockTiers.Get(0)
+	require.True(t, found)
+	require.Equal(t, sdk.OneDec(), lockTier.Multiplier)
+	_, found = lockTiers.Get(time.Hour)
+	require.False(t, found)
+
+	lockTiers, err = ParseLockTiers("")
+	require.Nil(t, err)
+	require.Nil(t, lockTiers)
+
+	for _, tiersStr := range []string{"168h", "1x:2", "168h:x", "168h:0.5", "168h:10.1", "0s:2", "168h:2,168h:3"} {
+		_, err = ParseLockTiers(tiersStr)
+		require.Error(t, err, tiersStr)
+	}
+}
+
+func TestLockInfoWeightedAmount(t *testing.T) {
+	lockInfo := NewLockInfo(nil, "pool", sdk.NewDecCoinFromDec("xxb", sdk.NewDec(10)), 1, 0)
+	require.Equal(t, sdk.NewDec(10), lockInfo.WeightedAmount())
+	require.True(t, lockInfo.IsUnlocked(time.Now()))
+
+	// locking without any lock tier leaves the lock tier fields unset
+	lockInfo.SetLockTier(NewLockTier(0, sdk.OneDec()), time.Now())
+	require.Nil(t, lockInfo.Multiplier)
+	require.Zero(t, lockInfo.UnlockTime)
+
+	now := time.Now()
+	lockInfo.SetLockTier(NewLockTier(time.Hour, sdk.MustNewDecFromStr("1.5")), now)
+	require.Equal(t, sdk.NewDec(15), lockInfo.WeightedAmount())
+	require.False(t, lockInfo.IsUnlocked(now))
+	require.True(t, lockInfo.IsUnlocked(now.Add(time.Hour)))
+}
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"time"
+
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 )
 
@@ -20,6 +22,7 @@ type MsgCreatePool struct {
 	PoolName      string         `json:"pool_name" yaml:"pool_name"`
 	MinLockAmount sdk.SysCoin    `json:"min_lock_amount" yaml:"min_lock_amount"`
 	YieldedSymbol string         `json:"yielded_symbol"  yaml:"yielded_symbol"`
+	LockTiers     LockTiers      `json:"lock_tiers,omitempty" yaml:"lock_tiers,omitempty"`
 }
 
 var _ sdk.Msg = MsgCreatePool{}
@@ -54,6 +57,9 @@ func (m MsgCreatePool) ValidateBasic() sdk.Error {
 	if m.YieldedSymbol == "" {
 		return ErrInvalidInput("yielded symbol is empty")
 	}
+	if err := m.LockTiers.Validate(); err != nil {
+		return ErrInvalidInput(err.Error())
+	}
 	return nil
 }
 
@@ -171,6 +177,9 @@ type MsgLock struct {
 	PoolName string         `json:"pool_name" yaml:"pool_name"`
 	Address  sdk.AccAddress `json:"address" yaml:"address"`
 	Amount   sdk.SysCoin    `json:"amount" yaml:"amount"`
+	// lock tier of the pool to lock with, the zero duration means no lock tier
+	LockDuration time.Duration `json:"lock_duration,omitempty" yaml:"lock_duration,omitempty"`
+	AutoCompound bool          `json:"auto_compound,omitempty" yaml:"auto_compound,omitempty"`
 }
 
 func NewMsgLock(poolName string, address sdk.AccAddress, amount sdk.SysCoin) MsgLock {
@@ -201,6 +210,9 @@ func (m MsgLock) ValidateBasic() sdk.Error {
 	if m.Amount.Amount.LTE(sdk.ZeroDec()) || !m.Amount.IsValid() {
 		return ErrInvalidInputAmount(m.Amount.Amount.String())
 	}
+	if m.LockDuration < 0 {
+		return ErrInvalidInput("lock duration is negative")
+	}
 	return nil
 }
 
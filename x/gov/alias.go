@@ -44,6 +44,7 @@ var (
 	NewMsgSubmitProposal       = types.NewMsgSubmitProposal
 	NewMsgDeposit              = types.NewMsgDeposit
 	NewMsgVote                 = types.NewMsgVote
+	NewMsgWeightedVote         = types.NewMsgWeightedVote
 	ParamKeyTable              = types.ParamKeyTable
 	NewDepositParams           = types.NewDepositParams
 	NewTallyParams             = types.NewTallyParams
@@ -86,6 +87,7 @@ type (
 	MsgSubmitProposal = types.MsgSubmitProposal
 	MsgDeposit        = types.MsgDeposit
 	MsgVote           = types.MsgVote
+	MsgWeightedVote   = types.MsgWeightedVote
 	DepositParams     = types.DepositParams
 	TallyParams       = types.TallyParams
 	VotingParams      = types.VotingParams
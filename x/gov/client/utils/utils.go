@@ -1,6 +1,12 @@
 package utils
 
-import "github.com/okex/exchain/x/gov/types"
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/gov/types"
+)
 
 // NormalizeVoteOption - normalize user specified vote option
 func NormalizeVoteOption(option string) string {
@@ -22,6 +28,28 @@ func NormalizeVoteOption(option string) string {
 	}
 }
 
+// ParseWeightedVoteOptions parses user specified weighted vote options like "yes=0.6,no=0.3,abstain=0.1"
+func ParseWeightedVoteOptions(str string) (types.WeightedVoteOptions, error) {
+	var options types.WeightedVoteOptions
+	for _, part := range strings.Split(strings.TrimSpace(str), ",") {
+		fields := strings.Split(strings.TrimSpace(part), "=")
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("'%s' is not a valid weighted vote option, use the form option=weight", part)
+		}
+
+		option, err := types.VoteOptionFromString(NormalizeVoteOption(strings.TrimSpace(fields[0])))
+		if err != nil {
+			return nil, err
+		}
+		weight, err := sdk.NewDecFromStr(strings.TrimSpace(fields[1]))
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not a valid weight: %s", fields[1], err)
+		}
+		options = append(options, types.NewWeightedVoteOption(option, weight))
+	}
+	return options, nil
+}
+
 //NormalizeProposalType - normalize user specified proposal type
 func NormalizeProposalType(proposalType string) string {
 	switch proposalType {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 
 	"github.com/okex/exchain/x/common"
 	"github.com/okex/exchain/x/gov/keeper"
@@ -25,6 +26,9 @@ func NewHandler(keeper Keeper) sdk.Handler {
 		case MsgVote:
 			return handleMsgVote(ctx, keeper, msg)
 
+		case MsgWeightedVote:
+			return handleMsgWeightedVote(ctx, keeper, msg)
+
 		default:
 			errMsg := fmt.Sprintf("unrecognized gov message type: %T", msg)
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -113,6 +117,31 @@ func handleMsgVote(ctx sdk.Context, k keeper.Keeper, msg MsgVote) (*sdk.Result,
 		return sdk.EnvelopedErr{err}.Result()
 	}
 
+	return handleProposalAfterVote(ctx, k, proposal, msg.Voter)
+}
+
+func handleMsgWeightedVote(ctx sdk.Context, k keeper.Keeper, msg MsgWeightedVote) (*sdk.Result, error) {
+	if !tmtypes.HigherThanVenus2(ctx.BlockHeight()) {
+		return sdk.EnvelopedErr{types.ErrWeightedVoteNotEnabled()}.Result()
+	}
+
+	proposal, ok := k.GetProposal(ctx, msg.ProposalID)
+	if !ok {
+		return sdk.EnvelopedErr{types.ErrUnknownProposal(msg.ProposalID)}.Result()
+	}
+
+	err, _ := k.AddWeightedVote(ctx, msg.ProposalID, msg.Voter, msg.Options)
+	if err != nil {
+		return sdk.EnvelopedErr{err}.Result()
+	}
+
+	return handleProposalAfterVote(ctx, k, proposal, msg.Voter)
+}
+
+// handleProposalAfterVote tallies the proposal after a vote and ends its voting period if the result is final
+func handleProposalAfterVote(
+	ctx sdk.Context, k keeper.Keeper, proposal types.Proposal, voter sdk.AccAddress,
+) (*sdk.Result, error) {
 	status, distribute, tallyResults := keeper.Tally(ctx, k, proposal, false)
 	// update tally results after vote every time
 	proposal.FinalTallyResult = tallyResults
@@ -138,7 +167,7 @@ func handleMsgVote(ctx sdk.Context, k keeper.Keeper, msg MsgVote) (*sdk.Result,
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
 			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
-			sdk.NewAttribute(sdk.AttributeKeySender, msg.Voter.String()),
+			sdk.NewAttribute(sdk.AttributeKeySender, voter.String()),
 			sdk.NewAttribute(types.AttributeKeyProposalStatus, proposal.Status.String()),
 		),
 	)
@@ -5,6 +5,7 @@ import (
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/libs/tendermint/libs/cli/flags"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/okex/exchain/x/staking"
 	"github.com/stretchr/testify/require"
 
@@ -52,6 +53,7 @@ func TestHandleMsgDeposit(t *testing.T) {
 
 func TestHandleMsgVote(t *testing.T) {
 	ctx, _, gk, _, _ := keeper.CreateTestInput(t, false, 1000)
+	ctx.SetBlockHeight(1)
 	govHandler := NewHandler(gk)
 
 	proposalCoins := sdk.SysCoins{sdk.NewInt64DecCoin(sdk.DefaultBondDenom, 500)}
@@ -161,3 +163,47 @@ func TestHandleMsgSubmitProposal(t *testing.T) {
 	//res = handler(ctx, newProposalMsg)
 	//require.NotNil(t, err)
 }
+
+func TestHandleMsgWeightedVote(t *testing.T) {
+	ctx, _, gk, _, _ := keeper.CreateTestInput(t, false, 1000)
+	ctx.SetBlockHeight(1)
+	govHandler := NewHandler(gk)
+
+	proposalCoins := sdk.SysCoins{sdk.NewInt64DecCoin(sdk.DefaultBondDenom, 500)}
+	content := types.NewTextProposal("Test", "description")
+	newProposalMsg := NewMsgSubmitProposal(content, proposalCoins, keeper.Addrs[0])
+	res, err := govHandler(ctx, newProposalMsg)
+	require.Nil(t, err)
+	var proposalID uint64
+	gk.Cdc().MustUnmarshalBinaryLengthPrefixed(res.Data, &proposalID)
+
+	options := types.WeightedVoteOptions{
+		types.NewWeightedVoteOption(types.OptionYes, sdk.NewDecWithPrec(7, 1)),
+		types.NewWeightedVoteOption(types.OptionNo, sdk.NewDecWithPrec(3, 1)),
+	}
+	newVoteMsg := NewMsgWeightedVote(keeper.Addrs[4], proposalID, options)
+	_, err = govHandler(ctx, newVoteMsg)
+	require.NotNil(t, err)
+
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(1)
+	defer tmtypes.UnittestOnlySetMilestoneVenus2Height(0)
+	res, err = govHandler(ctx, newVoteMsg)
+	require.Nil(t, err)
+
+	var optionAttr string
+	for _, event := range res.Events {
+		if event.Type != types.EventTypeProposalVote {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if string(attr.Key) == types.AttributeKeyOption {
+				optionAttr = string(attr.Value)
+			}
+		}
+	}
+	require.Equal(t, options.String(), optionAttr)
+
+	newVoteMsg = NewMsgWeightedVote(keeper.Addrs[4], 0, options)
+	res, err = govHandler(ctx, newVoteMsg)
+	require.NotNil(t, err)
+}
@@ -313,3 +313,63 @@ func TestTallyDelegatorOverride(t *testing.T) {
 	require.Equal(t, types.StatusPassed, status)
 	require.Equal(t, expectedTallyResult, tallyResults)
 }
+
+func TestTallyWeightedVotes(t *testing.T) {
+	ctx, _, keeper, sk, _ := CreateTestInput(t, false, 100000)
+	ctx.SetBlockHeight(int64(sk.GetEpoch(ctx)))
+	ctx.SetBlockTime(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+	stakingHandler := staking.NewHandler(sk)
+	valAddrs := make([]sdk.ValAddress, len(Addrs[:2]))
+	for i, addr := range Addrs[:2] {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	CreateValidators(t, stakingHandler, ctx, valAddrs, []int64{5, 5})
+	staking.EndBlocker(ctx, sk)
+
+	coin, err := sdk.ParseDecCoin("1.0" + common.NativeToken)
+	require.Nil(t, err)
+	delegator1Msg := staking.NewMsgDeposit(Addrs[2], coin)
+	stakingHandler(ctx, delegator1Msg)
+
+	addSharesMsg := staking.NewMsgAddShares(Addrs[2], []sdk.ValAddress{sdk.ValAddress(Addrs[1])})
+	stakingHandler(ctx, addSharesMsg)
+
+	content := types.NewTextProposal("Test", "description")
+	proposal, err := keeper.SubmitProposal(ctx, content)
+	require.Nil(t, err)
+	proposal.Status = types.StatusVotingPeriod
+	keeper.SetProposal(ctx, proposal)
+	proposalID := proposal.ProposalID
+
+	err, _ = keeper.AddWeightedVote(ctx, proposalID, Addrs[0], types.WeightedVoteOptions{
+		types.NewWeightedVoteOption(types.OptionYes, sdk.NewDecWithPrec(5, 1)),
+		types.NewWeightedVoteOption(types.OptionNo, sdk.NewDecWithPrec(5, 1)),
+	})
+	require.Nil(t, err)
+	err, _ = keeper.AddWeightedVote(ctx, proposalID, Addrs[1], types.WeightedVoteOptions{
+		types.NewWeightedVoteOption(types.OptionYes, sdk.NewDecWithPrec(75, 2)),
+		types.NewWeightedVoteOption(types.OptionAbstain, sdk.NewDecWithPrec(25, 2)),
+	})
+	require.Nil(t, err)
+	// the delegator overrides the inherited weighted vote of its validator
+	err, _ = keeper.AddWeightedVote(ctx, proposalID, Addrs[2], types.WeightedVoteOptions{
+		types.NewWeightedVoteOption(types.OptionNo, sdk.NewDecWithPrec(6, 1)),
+		types.NewWeightedVoteOption(types.OptionNoWithVeto, sdk.NewDecWithPrec(4, 1)),
+	})
+	require.Nil(t, err)
+
+	// validator 0 with 1 voting power -> 0.5 Yes, 0.5 No
+	// validator 1 with 1 voting power -> 0.75 Yes, 0.25 Abstain
+	// delegator with 1 voting power -> 0.6 No, 0.4 NoWithVeto
+	expectedTallyResult := newTallyResult(t, "3", "1.25", "0.25", "1.1", "0.4", "3")
+	status, dist, tallyResults := Tally(ctx, keeper, proposal, true)
+	require.False(t, dist)
+	require.Equal(t, types.StatusRejected, status)
+	require.True(t, tallyResults.Equals(expectedTallyResult))
+
+	// invalid weighted votes are rejected
+	err, _ = keeper.AddWeightedVote(ctx, proposalID, Addrs[0], types.WeightedVoteOptions{
+		types.NewWeightedVoteOption(types.OptionYes, sdk.NewDecWithPrec(5, 1)),
+	})
+	require.NotNil(t, err)
+}
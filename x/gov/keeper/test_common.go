@@ -237,6 +237,7 @@ func MakeTestCodec() *codec.Codec {
 	cdc.RegisterConcrete(types.MsgSubmitProposal{}, "test/gov/MsgSubmitProposal", nil)
 	cdc.RegisterConcrete(types.MsgDeposit{}, "test/gov/MsgDeposit", nil)
 	cdc.RegisterConcrete(types.MsgVote{}, "test/gov/MsgVote", nil)
+	cdc.RegisterConcrete(types.MsgWeightedVote{}, "test/gov/MsgWeightedVote", nil)
 
 	cdc.RegisterInterface((*types.Content)(nil), nil)
 	cdc.RegisterConcrete(types.TextProposal{}, "test/gov/TextProposal", nil)
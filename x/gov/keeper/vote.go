@@ -10,6 +10,18 @@ import (
 func (keeper Keeper) AddVote(
 	ctx sdk.Context, proposalID uint64, voterAddr sdk.AccAddress, option types.VoteOption,
 ) (sdk.Error, string) {
+	return keeper.addVote(ctx, types.NewVote(proposalID, voterAddr, option))
+}
+
+// AddWeightedVote adds a vote on a specific proposal which splits the voting power among several options
+func (keeper Keeper) AddWeightedVote(
+	ctx sdk.Context, proposalID uint64, voterAddr sdk.AccAddress, options types.WeightedVoteOptions,
+) (sdk.Error, string) {
+	return keeper.addVote(ctx, types.NewWeightedVote(proposalID, voterAddr, options))
+}
+
+func (keeper Keeper) addVote(ctx sdk.Context, vote types.Vote) (sdk.Error, string) {
+	proposalID := vote.ProposalID
 	proposal, ok := keeper.GetProposal(ctx, proposalID)
 	if !ok {
 		return types.ErrUnknownProposal(proposalID), ""
@@ -18,14 +30,17 @@ func (keeper Keeper) AddVote(
 		return types.ErrInvalidateProposalStatus(), ""
 	}
 
-	if !types.ValidVoteOption(option) {
-		return types.ErrInvalidVote(option), ""
+	optionAttr := vote.Option.String()
+	if len(vote.Options) != 0 {
+		if err := types.ValidWeightedVoteOptions(vote.Options); err != nil {
+			return types.ErrInvalidWeightedVote(err.Error()), ""
+		}
+		optionAttr = vote.Options.String()
+	} else if !types.ValidVoteOption(vote.Option) {
+		return types.ErrInvalidVote(vote.Option), ""
 	}
 
 	voteFeeStr := ""
-	vote := types.Vote{
-		ProposalID: proposalID, Voter: voterAddr, Option: option,
-	}
 	if keeper.ProposalHandlerRouter().HasRoute(proposal.ProposalRoute()) {
 		var err sdk.Error
 		voteFeeStr, err = keeper.ProposalHandlerRouter().GetRoute(proposal.ProposalRoute()).VoteHandler(ctx, proposal, vote)
@@ -39,7 +54,7 @@ func (keeper Keeper) AddVote(
 	ctx.EventManager().EmitEvent(
 		sdk.NewEvent(
 			types.EventTypeProposalVote,
-			sdk.NewAttribute(types.AttributeKeyOption, option.String()),
+			sdk.NewAttribute(types.AttributeKeyOption, optionAttr),
 			sdk.NewAttribute(types.AttributeKeyProposalID, fmt.Sprintf("%d", proposalID)),
 		),
 	)
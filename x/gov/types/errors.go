@@ -24,6 +24,8 @@ const (
 	CodeInvalidHeight            uint32 = BaseGovError + 10
 	CodeInvalidCoins             uint32 = BaseGovError + 11
 	CodeUnknownParamType         uint32 = BaseGovError + 12
+	CodeInvalidWeightedVote      uint32 = BaseGovError + 13
+	CodeWeightedVoteNotEnabled   uint32 = BaseGovError + 14
 )
 
 func ErrInvalidAddress(address string) sdk.Error {
@@ -46,6 +48,14 @@ func ErrInvalidVote(voteOption VoteOption) sdk.Error {
 	return sdkerrors.New(DefaultCodespace, CodeInvalidVote, fmt.Sprintf("'%v' is not a valid voting option", voteOption.String()))
 }
 
+func ErrInvalidWeightedVote(msg string) sdk.Error {
+	return sdkerrors.New(DefaultCodespace, CodeInvalidWeightedVote, fmt.Sprintf("invalid weighted vote: %s", msg))
+}
+
+func ErrWeightedVoteNotEnabled() sdk.Error {
+	return sdkerrors.New(DefaultCodespace, CodeWeightedVoteNotEnabled, "weighted vote is not enabled before the Venus2 height")
+}
+
 func ErrInvalidGenesis() sdk.Error {
 	return sdkerrors.New(DefaultCodespace, CodeInvalidGenesis, "initial proposal ID hasn't been set")
 }
@@ -10,10 +10,11 @@ import (
 const (
 	TypeMsgDeposit        = "deposit"
 	TypeMsgVote           = "vote"
+	TypeMsgWeightedVote   = "weighted_vote"
 	TypeMsgSubmitProposal = "submit_proposal"
 )
 
-var _, _, _ sdk.Msg = MsgSubmitProposal{}, MsgDeposit{}, MsgVote{}
+var _, _, _, _ sdk.Msg = MsgSubmitProposal{}, MsgDeposit{}, MsgVote{}, MsgWeightedVote{}
 
 // MsgSubmitProposal
 type MsgSubmitProposal struct {
@@ -176,3 +177,49 @@ func (msg MsgVote) GetSignBytes() []byte {
 func (msg MsgVote) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Voter}
 }
+
+// MsgWeightedVote
+type MsgWeightedVote struct {
+	ProposalID uint64              `json:"proposal_id" yaml:"proposal_id"` // ID of the proposal
+	Voter      sdk.AccAddress      `json:"voter" yaml:"voter"`             //  address of the voter
+	Options    WeightedVoteOptions `json:"options" yaml:"options"`         //  weighted options chosen by the voter
+}
+
+func NewMsgWeightedVote(voter sdk.AccAddress, proposalID uint64, options WeightedVoteOptions) MsgWeightedVote {
+	return MsgWeightedVote{proposalID, voter, options}
+}
+
+// Implements Msg.
+// nolint
+func (msg MsgWeightedVote) Route() string { return RouterKey }
+func (msg MsgWeightedVote) Type() string  { return TypeMsgWeightedVote }
+
+// Implements Msg.
+func (msg MsgWeightedVote) ValidateBasic() sdk.Error {
+	if msg.Voter.Empty() {
+		return ErrInvalidAddress(msg.Voter.String())
+	}
+	if err := ValidWeightedVoteOptions(msg.Options); err != nil {
+		return ErrInvalidWeightedVote(err.Error())
+	}
+
+	return nil
+}
+
+func (msg MsgWeightedVote) String() string {
+	return fmt.Sprintf(`Weighted Vote Message:
+  Proposal ID: %d
+  Options:     %s
+`, msg.ProposalID, msg.Options)
+}
+
+// Implements Msg.
+func (msg MsgWeightedVote) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// Implements Msg.
+func (msg MsgWeightedVote) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Voter}
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 )
@@ -12,14 +13,33 @@ type Vote struct {
 	ProposalID uint64         `json:"proposal_id" yaml:"proposal_id"` //  proposalID of the proposal
 	Voter      sdk.AccAddress `json:"voter" yaml:"voter"`             //  address of the voter
 	Option     VoteOption     `json:"option" yaml:"option"`           //  option from OptionSet chosen by the voter
+	// weighted options of a split vote, Option is OptionEmpty if they are set
+	Options WeightedVoteOptions `json:"options,omitempty" yaml:"options,omitempty"`
 }
 
 // NewVote creates a new Vote instance
 func NewVote(proposalID uint64, voter sdk.AccAddress, option VoteOption) Vote {
-	return Vote{proposalID, voter, option}
+	return Vote{ProposalID: proposalID, Voter: voter, Option: option}
+}
+
+// NewWeightedVote creates a new Vote instance which splits the voting power among several options
+func NewWeightedVote(proposalID uint64, voter sdk.AccAddress, options WeightedVoteOptions) Vote {
+	return Vote{ProposalID: proposalID, Voter: voter, Option: OptionEmpty, Options: options}
+}
+
+// WeightedOptions returns the weighted options of the vote, a single option vote is
+// treated as a vote with the whole weight on that option
+func (v Vote) WeightedOptions() WeightedVoteOptions {
+	if len(v.Options) != 0 {
+		return v.Options
+	}
+	return NewNonSplitVoteOption(v.Option)
 }
 
 func (v Vote) String() string {
+	if len(v.Options) != 0 {
+		return fmt.Sprintf("voter %s voted with options %s on proposal %d", v.Voter, v.Options, v.ProposalID)
+	}
 	return fmt.Sprintf("voter %s voted with option %s on proposal %d", v.Voter, v.Option, v.ProposalID)
 }
 
@@ -32,6 +52,10 @@ func (v Votes) String() string {
 	}
 	out := fmt.Sprintf("Votes for Proposal %d:", v[0].ProposalID)
 	for _, vot := range v {
+		if len(vot.Options) != 0 {
+			out += fmt.Sprintf("\n  %s: %s", vot.Voter, vot.Options)
+			continue
+		}
 		out += fmt.Sprintf("\n  %s: %s", vot.Voter, vot.Option)
 	}
 	return out
@@ -41,7 +65,8 @@ func (v Votes) String() string {
 func (v Vote) Equals(comp Vote) bool {
 	return v.Voter.Equals(comp.Voter) &&
 		v.ProposalID == comp.ProposalID &&
-		v.Option == comp.Option
+		v.Option == comp.Option &&
+		v.Options.Equals(comp.Options)
 }
 
 // Empty returns whether a vote is empty.
@@ -49,6 +74,80 @@ func (v Vote) Empty() bool {
 	return v.Equals(Vote{})
 }
 
+// WeightedVoteOption defines a vote option with the share of the voting power put on it
+type WeightedVoteOption struct {
+	Option VoteOption `json:"option" yaml:"option"`
+	Weight sdk.Dec    `json:"weight" yaml:"weight"`
+}
+
+// NewWeightedVoteOption creates a new WeightedVoteOption instance
+func NewWeightedVoteOption(option VoteOption, weight sdk.Dec) WeightedVoteOption {
+	return WeightedVoteOption{Option: option, Weight: weight}
+}
+
+func (w WeightedVoteOption) String() string {
+	return fmt.Sprintf("%s:%s", w.Option, w.Weight)
+}
+
+// WeightedVoteOptions is a collection of WeightedVoteOption objects
+type WeightedVoteOptions []WeightedVoteOption
+
+// NewNonSplitVoteOption creates the weighted options of a vote with the whole weight on a single option
+func NewNonSplitVoteOption(option VoteOption) WeightedVoteOptions {
+	return WeightedVoteOptions{{Option: option, Weight: sdk.OneDec()}}
+}
+
+func (w WeightedVoteOptions) String() string {
+	out := make([]string, len(w))
+	for i, option := range w {
+		out[i] = option.String()
+	}
+	return strings.Join(out, ",")
+}
+
+// Equals returns whether two collections of weighted options are equal.
+func (w WeightedVoteOptions) Equals(comp WeightedVoteOptions) bool {
+	if len(w) != len(comp) {
+		return false
+	}
+	for i := range w {
+		if w[i].Option != comp[i].Option || !w[i].Weight.Equal(comp[i].Weight) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidWeightedVoteOptions returns an error if an option is invalid or repeated, a weight is not
+// in (0, 1] or the weights don't sum up to 1.
+func ValidWeightedVoteOptions(options WeightedVoteOptions) error {
+	if len(options) == 0 {
+		return fmt.Errorf("no vote options")
+	}
+
+	totalWeight := sdk.ZeroDec()
+	usedOptions := make(map[VoteOption]bool)
+	for _, option := range options {
+		if !ValidVoteOption(option.Option) {
+			return fmt.Errorf("'%v' is not a valid voting option", option.Option.String())
+		}
+		if usedOptions[option.Option] {
+			return fmt.Errorf("duplicated voting option %s", option.Option)
+		}
+		usedOptions[option.Option] = true
+
+		if option.Weight.IsNil() || !option.Weight.IsPositive() || option.Weight.GT(sdk.OneDec()) {
+			return fmt.Errorf("weight %s of option %s must be in (0, 1]", option.Weight, option.Option)
+		}
+		totalWeight = totalWeight.Add(option.Weight)
+	}
+
+	if !totalWeight.Equal(sdk.OneDec()) {
+		return fmt.Errorf("total weight %s of the options must be 1", totalWeight)
+	}
+	return nil
+}
+
 // VoteOption defines a vote option
 type VoteOption byte
 
@@ -0,0 +1,66 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidWeightedVoteOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		options WeightedVoteOptions
+		success bool
+	}{
+		{"no options", WeightedVoteOptions{}, false},
+		{"single option", NewNonSplitVoteOption(OptionYes), true},
+		{"split options", WeightedVoteOptions{
+			NewWeightedVoteOption(OptionYes, sdk.NewDecWithPrec(6, 1)),
+			NewWeightedVoteOption(OptionNo, sdk.NewDecWithPrec(3, 1)),
+			NewWeightedVoteOption(OptionAbstain, sdk.NewDecWithPrec(1, 1)),
+		}, true},
+		{"invalid option", WeightedVoteOptions{NewWeightedVoteOption(OptionEmpty, sdk.OneDec())}, false},
+		{"duplicated option", WeightedVoteOptions{
+			NewWeightedVoteOption(OptionYes, sdk.NewDecWithPrec(5, 1)),
+			NewWeightedVoteOption(OptionYes, sdk.NewDecWithPrec(5, 1)),
+		}, false},
+		{"zero weight", WeightedVoteOptions{
+			NewWeightedVoteOption(OptionYes, sdk.OneDec()),
+			NewWeightedVoteOption(OptionNo, sdk.ZeroDec()),
+		}, false},
+		{"negative weight", WeightedVoteOptions{
+			NewWeightedVoteOption(OptionYes, sdk.NewDecWithPrec(15, 1)),
+			NewWeightedVoteOption(OptionNo, sdk.NewDecWithPrec(-5, 1)),
+		}, false},
+		{"weights less than 1", WeightedVoteOptions{
+			NewWeightedVoteOption(OptionYes, sdk.NewDecWithPrec(5, 1)),
+			NewWeightedVoteOption(OptionNo, sdk.NewDecWithPrec(4, 1)),
+		}, false},
+		{"nil weight", WeightedVoteOptions{{Option: OptionYes}}, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidWeightedVoteOptions(tt.options)
+			if tt.success {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestVoteWeightedOptions(t *testing.T) {
+	vote := NewVote(1, sdk.AccAddress("voter"), OptionNo)
+	require.True(t, vote.WeightedOptions().Equals(NewNonSplitVoteOption(OptionNo)))
+
+	options := WeightedVoteOptions{
+		NewWeightedVoteOption(OptionYes, sdk.NewDecWithPrec(5, 1)),
+		NewWeightedVoteOption(OptionNo, sdk.NewDecWithPrec(5, 1)),
+	}
+	weightedVote := NewWeightedVote(1, sdk.AccAddress("voter"), options)
+	require.True(t, weightedVote.WeightedOptions().Equals(options))
+	require.False(t, weightedVote.Equals(vote))
+}
@@ -1,19 +1,79 @@
 package order
 
 import (
+	"fmt"
+
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 
 	"github.com/okex/exchain/x/common/perf"
 	"github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/match/continuousauction"
 	"github.com/okex/exchain/x/order/types"
 	//"github.com/okex/exchain/x/common/version"
 )
 
 // BeginBlocker runs the logic of BeginBlocker with version 0.
-// BeginBlocker resets keeper cache.
+// BeginBlocker resets keeper cache, and activates the trigger orders.
 func BeginBlocker(ctx sdk.Context, keeper keeper.Keeper) {
 	seq := perf.GetPerf().OnBeginBlockEnter(ctx, types.ModuleName)
 	defer perf.GetPerf().OnBeginBlockExit(ctx, types.ModuleName, seq)
 
 	keeper.ResetCache(ctx)
+	activateTriggerOrders(ctx, keeper)
+}
+
+// activateTriggerOrders activates the pending trigger orders whose trigger prices are crossed by the last match
+// prices. The activated orders are inserted into the depth book as limit orders, and they are matched immediately
+// in continuous auction, or by the end of block in periodic auction. Only the crossed orders are visited through
+// the trigger price index, and the ones crossed by the prices they make are activated in the next block.
+func activateTriggerOrders(ctx sdk.Context, keeper keeper.Keeper) {
+	// the trigger orders are only placed since the Venus2 height
+	if !tmtypes.HigherThanVenus2(ctx.BlockHeight()) {
+		return
+	}
+	products := keeper.GetTriggerOrderProducts(ctx)
+	if len(products) == 0 {
+		return
+	}
+
+	logger := ctx.Logger().With("module", "order")
+	isContinuous := keeper.GetAuctionType(ctx) == types.AuctionTypeContinuous
+	for _, product := range products {
+		// the trigger orders of the delisted products can't be cleaned up from the depth book
+		if keeper.GetDexKeeper().GetTokenPair(ctx, product) == nil {
+			for _, orderID := range keeper.GetTriggerOrderIDsByProduct(ctx, product) {
+				if order := keeper.GetOrder(ctx, orderID); order != nil {
+					keeper.CancelOrder(ctx, order, logger)
+				}
+			}
+			continue
+		}
+		if keeper.IsProductLocked(ctx, product) || keeper.IsProductPaused(ctx, product) {
+			continue
+		}
+
+		for _, orderID := range keeper.GetTriggeredOrderIDs(ctx, product, keeper.GetLastPrice(ctx, product)) {
+			order := keeper.GetOrder(ctx, orderID)
+			if order == nil {
+				logger.Error(fmt.Sprintf("trigger order(%s) not found", orderID))
+				continue
+			}
+			if !order.IsPendingTrigger() || order.Status != types.OrderStatusOpen {
+				keeper.DropTriggerOrder(ctx, order)
+				continue
+			}
+			// the trigger orders of the frozen accounts aren't cancelled with their orders in the depth book
+			if keeper.IsSenderFrozen(ctx, order) {
+				keeper.CancelOrder(ctx, order, logger)
+				continue
+			}
+
+			keeper.ActivateTriggerOrder(ctx, order)
+			logger.Info(fmt.Sprintf("BlockHeight<%d> trigger order(%s) activated", ctx.BlockHeight(), orderID))
+			if isContinuous {
+				continuousauction.MatchNewOrder(ctx, keeper, order)
+			}
+		}
+	}
 }
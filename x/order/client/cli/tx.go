@@ -37,6 +37,8 @@ func getCmdNewOrder(cdc *codec.Codec) *cobra.Command {
 	var side string
 	var price string
 	var quantity string
+	var orderType string
+	var triggerPrice string
 	cmd := &cobra.Command{
 		Use:   "new",
 		Short: "place a new order",
@@ -53,7 +55,7 @@ func getCmdNewOrder(cdc *codec.Codec) *cobra.Command {
 				return errors.New("invalid param counts")
 			}
 
-			err := handleNewOrder(cmd, cdc, product, side, price, quantity)
+			err := handleNewOrder(cmd, cdc, product, side, price, quantity, orderType, triggerPrice)
 			return err
 
 		},
@@ -63,15 +65,26 @@ func getCmdNewOrder(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().StringVarP(&side, "side", "s", "", "BUY or SELL (default \"SELL\")")
 	cmd.Flags().StringVarP(&price, "price", "p", "", "The price of the order")
 	cmd.Flags().StringVarP(&quantity, "quantity", "q", "", "The quantity of the order")
+	cmd.Flags().StringVarP(&orderType, "type", "t", "", "LIMIT, IOC, FOK, POST_ONLY, STOP or TAKE_PROFIT (default \"LIMIT\")")
+	cmd.Flags().StringVarP(&triggerPrice, "trigger-price", "", "", "The trigger price of the STOP or TAKE_PROFIT order")
 	return cmd
 }
 
-func handleNewOrder(cmd *cobra.Command, cdc *codec.Codec, product string, side string, price string, quantity string) error {
+func handleNewOrder(cmd *cobra.Command, cdc *codec.Codec, product string, side string, price string, quantity string,
+	orderType string, triggerPrice string) error {
 	var items []types.OrderItem
 	productArr := strings.Split(product, ",")
 	sideArr := strings.Split(side, ",")
 	priceArr := strings.Split(price, ",")
 	quantityArr := strings.Split(quantity, ",")
+	typeArr := make([]string, len(productArr))
+	if len(orderType) > 0 {
+		typeArr = strings.Split(orderType, ",")
+	}
+	triggerPriceArr := make([]string, len(productArr))
+	if len(triggerPrice) > 0 {
+		triggerPriceArr = strings.Split(triggerPrice, ",")
+	}
 	if len(productArr) != len(sideArr) {
 		return errors.New("invalid param side counts")
 	}
@@ -84,6 +97,14 @@ func handleNewOrder(cmd *cobra.Command, cdc *codec.Codec, product string, side s
 		return errors.New("invalid param quantity counts")
 	}
 
+	if len(productArr) != len(typeArr) {
+		return errors.New("invalid param type counts")
+	}
+
+	if len(productArr) != len(triggerPriceArr) {
+		return errors.New("invalid param trigger price counts")
+	}
+
 	for i := 0; i < len(productArr); i++ {
 		product := productArr[i]
 		side := sideArr[i]
@@ -95,12 +116,21 @@ func handleNewOrder(cmd *cobra.Command, cdc *codec.Codec, product string, side s
 		if err != nil {
 			return errors.New(err.Error())
 		}
-		items = append(items, types.OrderItem{
+		item := types.OrderItem{
 			Product:  product,
 			Side:     side,
 			Price:    price,
 			Quantity: quantity,
-		})
+			Type:     typeArr[i],
+		}
+		if len(triggerPriceArr[i]) > 0 {
+			trigger, err := sdk.NewDecFromStr(triggerPriceArr[i])
+			if err != nil {
+				return errors.New(err.Error())
+			}
+			item.TriggerPrice = &trigger
+		}
+		items = append(items, item)
 	}
 	inBuf := bufio.NewReader(cmd.InOrStdin())
 	txBldr := authtxb.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
@@ -0,0 +1,97 @@
+package order
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+	"github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/types"
+)
+
+// gas charged for the precompile methods, it is also the gas limit of the native execution
+const (
+	PlaceOrderMethodGas  uint64 = 300000
+	CancelOrderMethodGas uint64 = 200000
+)
+
+var (
+	// PlaceOrderMethod represent the signature of
+	// `function placeOrder(string product, string side, uint256 price, uint256 quantity) returns (string orderId)`
+	PlaceOrderMethod abi.Method
+	// CancelOrderMethod represent the signature of
+	// `function cancelOrder(string orderId)`
+	CancelOrderMethod abi.Method
+)
+
+func init() {
+	uint256Type, _ := abi.NewType("uint256", "", nil)
+	stringType, _ := abi.NewType("string", "", nil)
+
+	PlaceOrderMethod = abi.NewMethod("placeOrder", "placeOrder", abi.Function, "nonpayable", false, false,
+		abi.Arguments{
+			abi.Argument{Name: "product", Type: stringType},
+			abi.Argument{Name: "side", Type: stringType},
+			abi.Argument{Name: "price", Type: uint256Type},
+			abi.Argument{Name: "quantity", Type: uint256Type},
+		},
+		abi.Arguments{abi.Argument{Name: "orderId", Type: stringType}})
+	CancelOrderMethod = abi.NewMethod("cancelOrder", "cancelOrder", abi.Function, "nonpayable", false, false,
+		abi.Arguments{abi.Argument{Name: "orderId", Type: stringType}}, nil)
+}
+
+// NewPrecompileMethods returns the methods of the native precompile which let a contract place and cancel orders as
+// msg.sender. The msgs are handled on the metered native state of the call, and the caches of the order keeper are
+// restored along with the store when a call is reverted.
+func NewPrecompileMethods(k keeper.Keeper) []evmtypes.PrecompileMethod {
+	handler := NewMeteredOrderHandler(k)
+	return []evmtypes.PrecompileMethod{
+		evmtypes.NewMsgPrecompileMethod(PlaceOrderMethod, PlaceOrderMethodGas, handler, placeOrderMsg, placedOrderID).
+			WithSnapshot(func(ctx sdk.Context, args []interface{}) func() {
+				return k.SnapshotCache(args[0].(string))
+			}),
+		evmtypes.NewMsgPrecompileMethod(CancelOrderMethod, CancelOrderMethodGas, handler, cancelOrderMsg, nil).
+			WithSnapshot(func(ctx sdk.Context, args []interface{}) func() {
+				order := k.GetOrder(ctx, args[0].(string))
+				if order == nil {
+					return nil
+				}
+				return k.SnapshotCache(order.Product)
+			}),
+	}
+}
+
+// placedOrderID returns the id of the order placed by the msg
+func placedOrderID(result *sdk.Result) ([]interface{}, error) {
+	for _, event := range result.Events {
+		for _, attr := range event.Attributes {
+			if string(attr.Key) != "orders" {
+				continue
+			}
+			var results []types.OrderResult
+			if err := json.Unmarshal(attr.Value, &results); err != nil {
+				return nil, err
+			}
+			if len(results) > 0 {
+				return []interface{}{results[0].OrderID}, nil
+			}
+		}
+	}
+	// the order isn't placed when the tx is checked
+	return []interface{}{""}, nil
+}
+
+// placeOrderMsg builds the msg placing an order from the arguments of PlaceOrderMethod
+func placeOrderMsg(sender sdk.AccAddress, args []interface{}) (sdk.Msg, error) {
+	price := sdk.NewDecFromBigIntWithPrec(args[2].(*big.Int), sdk.Precision)
+	quantity := sdk.NewDecFromBigIntWithPrec(args[3].(*big.Int), sdk.Precision)
+	return types.NewMsgNewOrder(sender, args[0].(string), args[1].(string), price.String(), quantity.String()), nil
+}
+
+// cancelOrderMsg builds the msg cancelling an order from the arguments of CancelOrderMethod
+func cancelOrderMsg(sender sdk.AccAddress, args []interface{}) (sdk.Msg, error) {
+	return types.NewMsgCancelOrder(sender, args[0].(string)), nil
+}
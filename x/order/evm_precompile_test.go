@@ -0,0 +1,152 @@
+package order_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/okex/exchain/app"
+	"github.com/okex/exchain/app/crypto/ethsecp256k1"
+	ethermint "github.com/okex/exchain/app/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/mint"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/dex"
+	"github.com/okex/exchain/x/evm"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+	"github.com/okex/exchain/x/order"
+	"github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/types"
+	"github.com/stretchr/testify/require"
+)
+
+// proxyCode forwards calldata[32:] to the address in calldata[:32] and returns or reverts with the result of the call,
+// revertingProxyCode always reverts and tryProxyCode always succeeds whatever the call results in
+const (
+	proxyCode = "0x6029600c60003960296000f3" +
+		"3660006000376000600060203603602060006000515af13d600060003e6024573d6000fd5b3d6000f3"
+	revertingProxyCode = "0x601d600c600039601d6000f3" +
+		"3660006000376000600060203603602060006000515af15060006000fd"
+	tryProxyCode = "0x6019600c60003960196000f3" +
+		"3660006000376000600060203603602060006000515af15000"
+)
+
+func TestPrecompilePlaceOrderReverted(t *testing.T) {
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(1)
+	defer tmtypes.UnittestOnlySetMilestoneVenus2Height(0)
+	mapp := app.Setup(false)
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Height: 2})
+	ctx.SetChainID("ethermint-3")
+	ctx.SetDeliver()
+	require.Nil(t, ethermint.SetChainId("ethermint-3"))
+	params := evmtypes.DefaultParams()
+	params.EnableCreate = true
+	params.EnableCall = true
+	mapp.EvmKeeper.SetParams(ctx, params)
+	require.Nil(t, mapp.DexKeeper.SaveTokenPair(ctx, dex.GetBuiltInTokenPair()))
+	k := mapp.OrderKeeper
+	k.ResetCache(ctx)
+
+	// sendTx sends the evm tx of one account, the state of a failed tx is dropped as the deliver tx does
+	handler := evm.NewHandler(mapp.EvmKeeper)
+	priv, err := ethsecp256k1.GenerateKey()
+	require.Nil(t, err)
+	nonce := uint64(0)
+	sendTx := func(to *common.Address, payload []byte) (*sdk.Result, error) {
+		tx := evmtypes.NewMsgEthereumTx(nonce, to, big.NewInt(0), 3000000, big.NewInt(1), payload)
+		require.Nil(t, tx.Sign(big.NewInt(3), priv.ToECDSA()))
+		nonce++
+		cacheCtx, write := ctx.CacheContext()
+		res, err := handler(cacheCtx, tx)
+		if err == nil {
+			write()
+		}
+		return res, err
+	}
+	deployContract := func(code string) common.Address {
+		res, err := sendTx(nil, common.FromHex(code))
+		require.Nil(t, err)
+		resultData, err := evmtypes.DecodeResultData(res.Data)
+		require.Nil(t, err)
+		return resultData.ContractAddress
+	}
+	proxy := deployContract(proxyCode)
+	revertingProxy := deployContract(revertingProxyCode)
+	tryProxy := deployContract(tryProxyCode)
+
+	// the proxies pay for the orders placed as them
+	coins := sdk.SysCoins{sdk.NewDecCoinFromDec(sdk.DefaultBondDenom, sdk.NewDec(100))}
+	require.Nil(t, mapp.SupplyKeeper.MintCoins(ctx, mint.ModuleName, coins.Add(coins...)))
+	require.Nil(t, mapp.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, mint.ModuleName, proxy.Bytes(), coins))
+	require.Nil(t, mapp.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, mint.ModuleName, revertingProxy.Bytes(), coins))
+
+	oneToken := new(big.Int).Exp(big.NewInt(10), big.NewInt(sdk.Precision), nil)
+	args, err := order.PlaceOrderMethod.Inputs.Pack(types.TestTokenPair, types.BuyOrder, new(big.Int).Mul(big.NewInt(10),
+		oneToken), oneToken)
+	require.Nil(t, err)
+	placeOrderVia := func(via ...common.Address) error {
+		payload := append(order.PlaceOrderMethod.ID, args...)
+		hops := append(via, evmtypes.NativePrecompileAddress)
+		for i := len(hops) - 1; i > 0; i-- {
+			payload = append(common.LeftPadBytes(hops[i].Bytes(), 32), payload...)
+		}
+		_, err := sendTx(&hops[0], payload)
+		return err
+	}
+
+	// the order placed by a reverted call is dropped out of the store and the depth book, even if the tx succeeds
+	require.Nil(t, placeOrderVia(tryProxy, revertingProxy))
+	require.Equal(t, coins, mapp.AccountKeeper.GetAccount(ctx, revertingProxy.Bytes()).GetCoins())
+	require.Nil(t, k.GetOrder(ctx, types.FormatOrderID(2, 1)))
+	require.EqualValues(t, 0, k.GetBlockOrderNum(ctx, 2))
+	require.Nil(t, k.GetDepthBookCopy(types.TestTokenPair).Items)
+
+	// the order placed by a committed call takes the first order id of the block
+	require.Nil(t, placeOrderVia(proxy))
+	placed := k.GetOrder(ctx, types.FormatOrderID(2, 1))
+	require.NotNil(t, placed)
+	require.Equal(t, sdk.AccAddress(proxy.Bytes()), placed.Sender)
+	require.EqualValues(t, 1, len(k.GetDepthBookCopy(types.TestTokenPair).Items))
+	require.True(t, mapp.AccountKeeper.GetAccount(ctx, proxy.Bytes()).GetCoins().IsAllLT(coins))
+}
+
+func TestPrecompilePlaceOrderMetered(t *testing.T) {
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(1)
+	defer tmtypes.UnittestOnlySetMilestoneVenus2Height(0)
+	testInput := keeper.CreateTestInput(t)
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	require.Nil(t, testInput.DexKeeper.SaveTokenPair(ctx, dex.GetBuiltInTokenPair()))
+	p := evmtypes.NewNativePrecompile(order.NewPrecompileMethods(testInput.OrderKeeper)...)
+	sender := testInput.TestAddrs[0]
+
+	oneToken := new(big.Int).Exp(big.NewInt(10), big.NewInt(sdk.Precision), nil)
+	args, err := order.PlaceOrderMethod.Inputs.Pack(types.TestTokenPair, types.BuyOrder, new(big.Int).Mul(big.NewInt(10),
+		oneToken), oneToken)
+	require.Nil(t, err)
+	input := append(order.PlaceOrderMethod.ID, args...)
+
+	// the handler of the cosmos txs only charges the flat gas of the msg
+	ctx.SetGasMeter(sdk.NewInfiniteGasMeter())
+	msg := types.NewMsgNewOrder(sender, types.TestTokenPair, types.BuyOrder, "10", "1")
+	_, err = order.NewOrderHandler(testInput.OrderKeeper)(ctx, msg)
+	require.Nil(t, err)
+	unmeteredGas := ctx.GasMeter().GasConsumed()
+
+	// the store work of placing the order is charged to the contract on top of it
+	ctx.SetGasMeter(sdk.NewGasMeter(order.PlaceOrderMethodGas))
+	ret, err := p.Run(ctx, nil, common.BytesToAddress(sender), input, false)
+	require.Nil(t, err)
+	outputs, err := order.PlaceOrderMethod.Outputs.Unpack(ret)
+	require.Nil(t, err)
+	placed := testInput.OrderKeeper.GetOrder(ctx, outputs[0].(string))
+	require.NotNil(t, placed)
+	require.Equal(t, sender, placed.Sender)
+	require.Greater(t, ctx.GasMeter().GasConsumed(), unmeteredGas)
+
+	// the gas charged by the handler of the cosmos txs doesn't cover placing the order
+	ctx.SetGasMeter(sdk.NewGasMeter(unmeteredGas))
+	require.Panics(t, func() {
+		_, _ = p.Run(ctx, nil, common.BytesToAddress(sender), input, false)
+	})
+}
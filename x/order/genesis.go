@@ -12,6 +12,8 @@ import (
 type GenesisState struct {
 	Params     types.Params   `json:"params"`
 	OpenOrders []*types.Order `json:"open_orders"`
+	// auction type of the match engine, the periodic auction if it's empty
+	AuctionType string `json:"auction_type,omitempty"`
 }
 
 // DefaultGenesisState - default GenesisState used by Cosmos Hub
@@ -24,13 +26,19 @@ func DefaultGenesisState() GenesisState {
 
 // ValidateGenesis validates the slashing genesis parameters
 func ValidateGenesis(data GenesisState) error {
-	return nil
+	if data.AuctionType == "" {
+		return nil
+	}
+	return types.ValidateAuctionType(data.AuctionType)
 }
 
 // InitGenesis initialize default parameters
 // and the keeper's address to pubkey map
 func InitGenesis(ctx sdk.Context, keeper keeper.Keeper, data GenesisState) {
 	keeper.SetParams(ctx, &data.Params)
+	if data.AuctionType != "" {
+		keeper.SetAuctionType(ctx, data.AuctionType)
+	}
 
 	// reset open order& depth book
 	for _, order := range data.OpenOrders {
@@ -48,6 +56,11 @@ func InitGenesis(ctx sdk.Context, keeper keeper.Keeper, data GenesisState) {
 		keeper.SetBlockOrderNum(ctx, height, orderNum+1)
 		keeper.SetOrder(ctx, order.OrderID, order)
 
+		// the trigger order stays out of the depth book until being triggered
+		if order.IsPendingTrigger() {
+			keeper.InsertPendingTriggerOrder(ctx, order)
+			continue
+		}
 		// update depth book and orderIDsMap in cache
 		keeper.InsertOrderIntoDepthBook(order)
 	}
@@ -109,8 +122,14 @@ func ExportGenesis(ctx sdk.Context, keeper keeper.Keeper) (data GenesisState) {
 		}
 	}
 
+	// the pending trigger orders are out of the depth book
+	for _, orderID := range keeper.GetTriggerOrderIDs(ctx) {
+		openOrders = append(openOrders, keeper.GetOrder(ctx, orderID))
+	}
+
 	return GenesisState{
-		Params:     *params,
-		OpenOrders: openOrders,
+		Params:      *params,
+		OpenOrders:  openOrders,
+		AuctionType: keeper.GetAuctionTypeParam(ctx),
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"strings"
 
 	storetypes "github.com/okex/exchain/libs/cosmos-sdk/store/types"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
@@ -13,6 +14,7 @@ import (
 	"github.com/okex/exchain/x/common"
 	"github.com/okex/exchain/x/common/perf"
 	"github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/match/continuousauction"
 	"github.com/okex/exchain/x/order/types"
 	"github.com/willf/bitset"
 )
@@ -32,9 +34,21 @@ func CalculateGas(msg sdk.Msg, params *types.Params) (gas uint64) {
 
 // NewOrderHandler returns the handler with version 0.
 func NewOrderHandler(keeper keeper.Keeper) sdk.Handler {
+	return newOrderHandler(keeper, false)
+}
+
+// NewMeteredOrderHandler returns the handler which handles the msg on the gas meter of ctx rather than an infinite
+// one, so that the store work of placing and matching the orders is charged to the sender as well
+func NewMeteredOrderHandler(keeper keeper.Keeper) sdk.Handler {
+	return newOrderHandler(keeper, true)
+}
+
+func newOrderHandler(keeper keeper.Keeper, metered bool) sdk.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
-		// disable order tx handler
-		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "Order messages are not allowd.")
+		// order tx handler is disabled before the Venus2 height
+		if !types2.HigherThanVenus2(ctx.BlockHeight()) {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "Order messages are not allowd.")
+		}
 
 		gas := CalculateGas(msg, keeper.GetParams(ctx))
 
@@ -43,7 +57,7 @@ func NewOrderHandler(keeper keeper.Keeper) sdk.Handler {
 
 		if ctx.IsCheckTx() {
 			return &sdk.Result{}, nil
-		} else {
+		} else if !metered {
 			// set an infinite gas meter and recovery it when return
 			gasMeter := ctx.GasMeter()
 			ctx.SetGasMeter(sdk.NewInfiniteGasMeter())
@@ -94,6 +108,13 @@ func checkOrderNewMsg(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgNewOrd
 		return types.ErrTradingPairIsDelisting(msg.Product)
 	}
 
+	// the sender can't trade the paused tokens or the tokens it's frozen for
+	for _, symbol := range strings.Split(msg.Product, "_") {
+		if err := keeper.GetTokenKeeper().CheckSymbolTransferable(ctx, symbol, msg.Sender); err != nil {
+			return err
+		}
+	}
+
 	priceDigit := tokenPair.MaxPriceDigit
 	quantityDigit := tokenPair.MaxQuantityDigit
 	roundedPrice := msg.Price.RoundDecimal(priceDigit)
@@ -115,7 +136,7 @@ func getOrderFromMsg(ctx sdk.Context, k keeper.Keeper, msg types.MsgNewOrder, ra
 	feeParams := k.GetParams(ctx)
 	feePerBlockAmount := feeParams.FeePerBlock.Amount.Mul(sdk.MustNewDecFromStr(ratio))
 	feePerBlock := sdk.NewDecCoinFromDec(feeParams.FeePerBlock.Denom, feePerBlockAmount)
-	return types.NewOrder(
+	order := types.NewOrder(
 		fmt.Sprintf("%X", types2.Tx(ctx.TxBytes()).Hash(ctx.BlockHeight())),
 		msg.Sender,
 		msg.Product,
@@ -126,6 +147,53 @@ func getOrderFromMsg(ctx sdk.Context, k keeper.Keeper, msg types.MsgNewOrder, ra
 		feeParams.OrderExpireBlocks,
 		feePerBlock,
 	)
+	order.Type = msg.Type
+	if msg.TriggerPrice != nil {
+		triggerPrice := *msg.TriggerPrice
+		order.TriggerPrice = &triggerPrice
+	}
+	return order
+}
+
+// checkOrderType checks whether the order can be placed by its type
+func checkOrderType(ctx sdk.Context, k keeper.Keeper, order *types.Order) error {
+	switch order.Type {
+	case types.OrderTypePostOnly:
+		if k.GetDepthBookCopy(order.Product).BestCrossIndex(order.Side, order.Price) >= 0 {
+			return types.ErrPostOnlyOrderWouldMatch(order.Product)
+		}
+	case types.OrderTypeFOK:
+		auctionType := k.GetAuctionType(ctx)
+		if auctionType != types.AuctionTypeContinuous {
+			return types.ErrOrderTypeNotSupported(order.Type, auctionType)
+		}
+		if !continuousauction.CanFillOrder(ctx, k, order) {
+			return types.ErrFOKOrderCannotBeFilled(order.Product)
+		}
+	}
+	return nil
+}
+
+// matchNewOrder matches the new order immediately in continuous auction, and cancels the unfilled part of
+// the immediate-or-cancel order. In periodic auction, the immediate-or-cancel order is cancelled after
+// matching at the end of block.
+func matchNewOrder(ctx sdk.Context, k keeper.Keeper, order *types.Order, logger log.Logger) {
+	if order.IsPendingTrigger() {
+		return
+	}
+
+	if k.GetAuctionType(ctx) != types.AuctionTypeContinuous {
+		if order.Type == types.OrderTypeIOC {
+			k.SetImmediateOrder(ctx, order.OrderID)
+		}
+		return
+	}
+
+	continuousauction.MatchNewOrder(ctx, k, order)
+	if (order.Type == types.OrderTypeIOC || order.Type == types.OrderTypeFOK) &&
+		order.Status == types.OrderStatusOpen {
+		k.CancelOrder(ctx, order, logger)
+	}
 }
 
 func handleNewOrder(ctx sdk.Context, k Keeper, sender sdk.AccAddress,
@@ -135,11 +203,13 @@ func handleNewOrder(ctx sdk.Context, k Keeper, sender sdk.AccAddress,
 	ctxItem := ctx
 	ctxItem.SetMultiStore(cacheItem)
 	msg := MsgNewOrder{
-		Sender:   sender,
-		Product:  item.Product,
-		Side:     item.Side,
-		Price:    item.Price,
-		Quantity: item.Quantity,
+		Sender:       sender,
+		Product:      item.Product,
+		Side:         item.Side,
+		Price:        item.Price,
+		Quantity:     item.Quantity,
+		Type:         item.Type,
+		TriggerPrice: item.TriggerPrice,
 	}
 	order := getOrderFromMsg(ctxItem, k, msg, ratio)
 	err := checkOrderNewMsg(ctxItem, k, msg)
@@ -147,11 +217,15 @@ func handleNewOrder(ctx sdk.Context, k Keeper, sender sdk.AccAddress,
 	if err == nil {
 		if k.IsProductLocked(ctx, msg.Product) {
 			err = types.ErrIsProductLocked(order.Product)
-		} else {
+		} else if err = checkOrderType(ctxItem, k, order); err == nil {
 			err = k.PlaceOrder(ctxItem, order)
 		}
 	}
 
+	if err == nil {
+		matchNewOrder(ctxItem, k, order, logger)
+	}
+
 	res := types.OrderResult{
 		Error:   err,
 		OrderID: order.OrderID,
@@ -218,11 +292,13 @@ func ValidateMsgNewOrders(ctx sdk.Context, k keeper.Keeper, msg types.MsgNewOrde
 
 	for _, item := range msg.OrderItems {
 		msg := MsgNewOrder{
-			Sender:   msg.Sender,
-			Product:  item.Product,
-			Side:     item.Side,
-			Price:    item.Price,
-			Quantity: item.Quantity,
+			Sender:       msg.Sender,
+			Product:      item.Product,
+			Side:         item.Side,
+			Price:        item.Price,
+			Quantity:     item.Quantity,
+			Type:         item.Type,
+			TriggerPrice: item.TriggerPrice,
 		}
 		err := checkOrderNewMsg(ctx, k, msg)
 		if err != nil {
@@ -233,6 +309,9 @@ func ValidateMsgNewOrders(ctx sdk.Context, k keeper.Keeper, msg types.MsgNewOrde
 		}
 
 		order := getOrderFromMsg(ctx, k, msg, ratio)
+		if err := checkOrderType(ctx, k, order); err != nil {
+			return nil, err
+		}
 		_, err = k.TryPlaceOrder(ctx, order)
 		if err != nil {
 			return common.ErrInsufficientCoins(DefaultParamspace, err.Error()).Result()
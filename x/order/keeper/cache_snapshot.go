@@ -0,0 +1,156 @@
+package keeper
+
+import (
+	"strings"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/order/types"
+)
+
+// SnapshotCache snapshots the caches of product and returns the function restoring them. The caches aren't reverted
+// along with the store, so a caller which may revert the store after an order msg is handled restores them.
+func (k Keeper) SnapshotCache(product string) (restore func()) {
+	diskSnapshot := k.diskCache.snapshot(product)
+	cacheSnapshot := k.cache.snapshot()
+	return func() {
+		k.diskCache.restore(diskSnapshot)
+		k.cache.restore(cacheSnapshot)
+	}
+}
+
+// diskCacheSnapshot is the state of the disk cache for a product
+type diskCacheSnapshot struct {
+	product            string
+	depthBook          *types.DepthBook
+	depthBookUpdated   bool
+	depthBookNew       bool
+	orderIDs           map[string][]string
+	updatedOrderIDKeys []string
+	price              *sdk.Dec
+	storeOrderNum      int64
+	openNum            int64
+	dealsNum           int64
+	closedOrderIDsLen  int
+}
+
+// snapshot copies the depth book, order ids and last price of product along with the order and deal nums
+func (c *DiskCache) snapshot(product string) diskCacheSnapshot {
+	s := diskCacheSnapshot{
+		product:           product,
+		orderIDs:          make(map[string][]string),
+		storeOrderNum:     c.storeOrderNum,
+		openNum:           c.openNum,
+		dealsNum:          c.dealsNum,
+		closedOrderIDsLen: len(c.closedOrderIDs),
+	}
+	if book, ok := c.depthBookMap.data[product]; ok {
+		s.depthBook = book.Copy()
+	}
+	_, s.depthBookUpdated = c.depthBookMap.updatedItems[product]
+	_, s.depthBookNew = c.depthBookMap.newItems[product]
+	prefix := product + ":"
+	for key, orderIDs := range c.orderIDsMap.Data {
+		if strings.HasPrefix(key, prefix) {
+			s.orderIDs[key] = append([]string{}, orderIDs...)
+		}
+	}
+	for key := range c.orderIDsMap.updatedItems {
+		if strings.HasPrefix(key, prefix) {
+			s.updatedOrderIDKeys = append(s.updatedOrderIDKeys, key)
+		}
+	}
+	if price, ok := c.priceMap[product]; ok {
+		s.price = &price
+	}
+	return s
+}
+
+// restore brings the disk cache of the product back to the snapshot
+func (c *DiskCache) restore(s diskCacheSnapshot) {
+	if s.depthBook != nil {
+		c.depthBookMap.data[s.product] = s.depthBook
+	} else {
+		delete(c.depthBookMap.data, s.product)
+	}
+	if !s.depthBookUpdated {
+		delete(c.depthBookMap.updatedItems, s.product)
+	}
+	if !s.depthBookNew {
+		delete(c.depthBookMap.newItems, s.product)
+	}
+	prefix := s.product + ":"
+	for key := range c.orderIDsMap.Data {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.orderIDsMap.Data, key)
+		}
+	}
+	for key, orderIDs := range s.orderIDs {
+		c.orderIDsMap.Data[key] = orderIDs
+	}
+	for key := range c.orderIDsMap.updatedItems {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.orderIDsMap.updatedItems, key)
+		}
+	}
+	for _, key := range s.updatedOrderIDKeys {
+		c.orderIDsMap.updatedItems[key] = struct{}{}
+	}
+	if s.price != nil {
+		c.priceMap[s.product] = *s.price
+	} else {
+		delete(c.priceMap, s.product)
+	}
+	c.storeOrderNum = s.storeOrderNum
+	c.openNum = s.openNum
+	c.dealsNum = s.dealsNum
+	c.closedOrderIDs = c.closedOrderIDs[:s.closedOrderIDsLen]
+}
+
+// cacheSnapshot is the state of the cache
+type cacheSnapshot struct {
+	updatedOrderIDsLen    int
+	blockMatchResult      *types.BlockMatchResult
+	handlerTxMsgResultLen int
+
+	cancelNum      int64
+	expireNum      int64
+	partialFillNum int64
+	fullFillNum    int64
+}
+
+// snapshot copies the cache, the block match result is copied as it is updated in place
+func (c *Cache) snapshot() cacheSnapshot {
+	s := cacheSnapshot{
+		updatedOrderIDsLen:    len(c.updatedOrderIDs),
+		handlerTxMsgResultLen: len(c.handlerTxMsgResult),
+		cancelNum:             c.cancelNum,
+		expireNum:             c.expireNum,
+		partialFillNum:        c.partialFillNum,
+		fullFillNum:           c.fullFillNum,
+	}
+	if c.blockMatchResult != nil {
+		result := *c.blockMatchResult
+		if c.blockMatchResult.ResultMap != nil {
+			result.ResultMap = make(map[string]types.MatchResult, len(c.blockMatchResult.ResultMap))
+			for product, matchResult := range c.blockMatchResult.ResultMap {
+				result.ResultMap[product] = matchResult
+			}
+		}
+		s.blockMatchResult = &result
+	}
+	return s
+}
+
+// restore brings the cache back to the snapshot
+func (c *Cache) restore(s cacheSnapshot) {
+	c.updatedOrderIDs = c.updatedOrderIDs[:s.updatedOrderIDsLen]
+	c.blockMatchResult = s.blockMatchResult
+	// the backend may have taken the results of the txs meanwhile
+	if len(c.handlerTxMsgResult) > s.handlerTxMsgResultLen {
+		c.handlerTxMsgResult = c.handlerTxMsgResult[:s.handlerTxMsgResultLen]
+	}
+	c.cancelNum = s.cancelNum
+	c.expireNum = s.expireNum
+	c.partialFillNum = s.partialFillNum
+	c.fullFillNum = s.fullFillNum
+}
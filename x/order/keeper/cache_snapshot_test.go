@@ -0,0 +1,55 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/dex"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/x/order/types"
+)
+
+func TestSnapshotCache(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	order := mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0")
+	order.Sender = testInput.TestAddrs[0]
+	require.Nil(t, keeper.PlaceOrder(ctx, order))
+	priceKey := types.FormatOrderIDsKey(order.Product, order.Price, order.Side)
+
+	// the order placed after the snapshot is dropped out of the caches by the restore
+	updatedOrderIDs := keeper.GetUpdatedOrderIDs()
+	restore := keeper.SnapshotCache(types.TestTokenPair)
+	cacheCtx, _ := ctx.CacheContext()
+	order2 := mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "2.0")
+	order2.Sender = testInput.TestAddrs[0]
+	require.Nil(t, keeper.PlaceOrder(cacheCtx, order2))
+	keeper.AddBlockDeals(1)
+	require.EqualValues(t, sdk.MustNewDecFromStr("3.0"), keeper.GetDepthBookCopy(order.Product).Items[0].BuyQuantity)
+	require.EqualValues(t, 2, keeper.diskCache.openNum)
+	require.EqualValues(t, 2, len(keeper.GetProductPriceOrderIDs(priceKey)))
+
+	restore()
+	depthBook := keeper.GetDepthBookCopy(order.Product)
+	require.EqualValues(t, 1, len(depthBook.Items))
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.0"), depthBook.Items[0].BuyQuantity)
+	require.EqualValues(t, []string{order.OrderID}, keeper.GetProductPriceOrderIDs(priceKey))
+	require.EqualValues(t, 1, keeper.diskCache.openNum)
+	require.EqualValues(t, 1, keeper.diskCache.storeOrderNum)
+	require.EqualValues(t, 0, keeper.diskCache.dealsNum)
+	require.EqualValues(t, updatedOrderIDs, keeper.GetUpdatedOrderIDs())
+
+	// the order placed after the restore takes the next order id of the block
+	order3 := mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "3.0")
+	order3.Sender = testInput.TestAddrs[0]
+	require.Nil(t, keeper.PlaceOrder(ctx, order3))
+	require.Equal(t, order2.OrderID, order3.OrderID)
+	require.EqualValues(t, sdk.MustNewDecFromStr("4.0"), keeper.GetDepthBookCopy(order.Product).Items[0].BuyQuantity)
+}
@@ -30,6 +30,7 @@ type DiskCache struct {
 
 	storeOrderNum  int64 // current stored order num
 	openNum        int64 // current open orders num
+	dealsNum       int64 // deals made by the new orders in the current block
 	closedOrderIDs []string
 }
 
@@ -46,6 +47,7 @@ func newDiskCache() *DiskCache {
 // reset is invoked in begin block
 func (c *DiskCache) reset() {
 	c.closedOrderIDs = []string{}
+	c.dealsNum = 0
 	c.orderIDsMap.updatedItems = make(map[string]struct{})
 	c.depthBookMap.updatedItems = make(map[string]struct{})
 	c.depthBookMap.newItems = make(map[string]struct{})
@@ -179,6 +181,20 @@ func (c *DiskCache) GetNewDepthbookKeys() []string {
 
 // insertOrder inserts a new order into orderIDsMap
 func (c *DiskCache) insertOrder(order *types.Order) {
+	c.insertOrderIntoDepthBook(order)
+
+	c.openNum++
+	c.storeOrderNum++
+}
+
+// addPendingOrder counts a new order which stays out of the depth book
+func (c *DiskCache) addPendingOrder() {
+	c.openNum++
+	c.storeOrderNum++
+}
+
+// insertOrderIntoDepthBook inserts an order into depthBookMap and orderIDsMap
+func (c *DiskCache) insertOrderIntoDepthBook(order *types.Order) {
 	// 1. update depthBookMap
 	depthBook, ok := c.depthBookMap.data[order.Product]
 	if !ok {
@@ -199,9 +215,6 @@ func (c *DiskCache) insertOrder(order *types.Order) {
 	orderIDs = append(orderIDs, order.OrderID)
 	orderIDsMap.Data[key] = orderIDs
 	c.orderIDsMap.updatedItems[key] = struct{}{}
-
-	c.openNum++
-	c.storeOrderNum++
 }
 
 func (c *DiskCache) closeOrder(orderID string) {
@@ -17,6 +17,10 @@ type TokenKeeper interface {
 	UnlockCoins(ctx sdk.Context, addr sdk.AccAddress, coins sdk.SysCoins, lockCoinsType int) error
 	BalanceAccount(ctx sdk.Context, addr sdk.AccAddress, outputCoins sdk.SysCoins, inputCoins sdk.SysCoins) error
 	SendCoinsFromAccountToAccount(ctx sdk.Context, from, to sdk.AccAddress, amt sdk.SysCoins) error
+	// Token freeze and pause
+	IsTokenPaused(ctx sdk.Context, symbol string) bool
+	IsAccountFrozen(ctx sdk.Context, symbol string, addr sdk.AccAddress) bool
+	CheckSymbolTransferable(ctx sdk.Context, symbol string, addrs ...sdk.AccAddress) error
 	// Fee detail
 	AddFeeDetail(ctx sdk.Context, from string, fee sdk.SysCoins, feeType string, receiver string)
 	GetAllLockedCoins(ctx sdk.Context) (locks []token.AccCoins)
@@ -0,0 +1,24 @@
+package keeper
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+
+	token "github.com/okex/exchain/x/token/types"
+)
+
+var _ token.TokenHooks = Hooks{}
+
+// Hooks wraps the order keeper to receive the token hooks
+type Hooks struct {
+	k Keeper
+}
+
+// Hooks returns the wrapper struct of the token hooks
+func (k Keeper) Hooks() Hooks {
+	return Hooks{k}
+}
+
+// AfterAccountFrozen cancels the open orders of the account trading the token it's frozen for
+func (h Hooks) AfterAccountFrozen(ctx sdk.Context, symbol string, addr sdk.AccAddress) {
+	h.k.CancelFrozenAccountOrders(ctx, symbol, addr)
+}
@@ -0,0 +1,64 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/common"
+	"github.com/okex/exchain/x/dex"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/x/order/types"
+)
+
+func TestCancelFrozenAccountOrders(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	orders := []*types.Order{
+		mockOrder("", types.TestTokenPair, types.BuyOrder, "9.9", "1.0"),
+		mockOrder("", types.TestTokenPair, types.SellOrder, "10.1", "1.0"),
+		mockOrder("", types.TestTokenPair, types.SellOrder, "10.2", "1.0"),
+	}
+	orders[0].Sender = testInput.TestAddrs[0]
+	orders[1].Sender = testInput.TestAddrs[1]
+	orders[2].Sender = testInput.TestAddrs[1]
+	for _, order := range orders {
+		require.Nil(t, keeper.PlaceOrder(ctx, order))
+	}
+
+	// freezing the account for another token doesn't cancel its orders
+	testInput.TokenKeeper.SetAccountFrozen(ctx, "usdk", testInput.TestAddrs[1], true)
+	keeper.Hooks().AfterAccountFrozen(ctx, "usdk", testInput.TestAddrs[1])
+	require.False(t, keeper.IsSenderFrozen(ctx, orders[1]))
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, orders[1].OrderID).Status)
+
+	// all the open orders of the frozen account on the products of the token are cancelled
+	testInput.TokenKeeper.SetAccountFrozen(ctx, common.TestToken, testInput.TestAddrs[1], true)
+	keeper.Hooks().AfterAccountFrozen(ctx, common.TestToken, testInput.TestAddrs[1])
+	require.True(t, keeper.IsSenderFrozen(ctx, orders[1]))
+	require.False(t, keeper.IsSenderFrozen(ctx, orders[0]))
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, orders[0].OrderID).Status)
+	require.EqualValues(t, types.OrderStatusCancelled, keeper.GetOrder(ctx, orders[1].OrderID).Status)
+	require.EqualValues(t, types.OrderStatusCancelled, keeper.GetOrder(ctx, orders[2].OrderID).Status)
+	depthBook := keeper.GetDepthBookCopy(types.TestTokenPair)
+	require.EqualValues(t, 1, len(depthBook.Items))
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.0"), depthBook.Items[0].BuyQuantity)
+	require.EqualValues(t, 0, len(keeper.GetFrozenOrderIDs(ctx)))
+
+	// the orders on the locked product are recorded to be cancelled after the product is unlocked
+	keeper.SetProductLock(ctx, types.TestTokenPair, &types.ProductLock{BlockHeight: 9, Price: sdk.ZeroDec(),
+		Quantity: sdk.ZeroDec(), BuyExecuted: sdk.ZeroDec(), SellExecuted: sdk.ZeroDec()})
+	testInput.TokenKeeper.SetAccountFrozen(ctx, common.TestToken, testInput.TestAddrs[0], true)
+	keeper.Hooks().AfterAccountFrozen(ctx, common.TestToken, testInput.TestAddrs[0])
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, orders[0].OrderID).Status)
+	require.EqualValues(t, []string{orders[0].OrderID}, keeper.GetFrozenOrderIDs(ctx))
+
+	keeper.DropFrozenOrder(ctx, orders[0].OrderID)
+	require.EqualValues(t, 0, len(keeper.GetFrozenOrderIDs(ctx)))
+}
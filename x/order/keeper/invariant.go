@@ -44,6 +44,11 @@ func ModuleAccountInvariant(keeper Keeper) sdk.Invariant {
 				}
 			}
 		}
+		// the pending trigger orders are out of the depth book
+		for _, orderID := range keeper.GetTriggerOrderIDs(ctx) {
+			order := keeper.GetOrder(ctx, orderID)
+			orderLockedFees = orderLockedFees.Add2(GetOrderNewFee(order))
+		}
 
 		if !lockedFees.IsEqual(orderLockedFees) {
 			return sdk.FormatInvariant(types.ModuleName, "locks",
@@ -2,6 +2,8 @@ package keeper
 
 import (
 	"log"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/willf/bitset"
@@ -10,6 +12,7 @@ import (
 
 	"github.com/okex/exchain/libs/cosmos-sdk/codec"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/okex/exchain/x/params"
 
 	"github.com/okex/exchain/x/common"
@@ -231,6 +234,16 @@ func (k Keeper) SetDepthBook(product string, book *types.DepthBook) {
 	k.diskCache.setDepthBook(product, book)
 }
 
+// GetBlockRemainDeals gets the number of deals the new orders can still make in the current block
+func (k Keeper) GetBlockRemainDeals(ctx sdk.Context) int64 {
+	return k.GetParams(ctx).MaxDealsPerBlock - k.diskCache.dealsNum
+}
+
+// AddBlockDeals adds the deals made by a new order to the deals of the current block
+func (k Keeper) AddBlockDeals(num int64) {
+	k.diskCache.dealsNum += num
+}
+
 // GetDepthBookFromDB gets depthBook from KVStore
 func (k Keeper) GetDepthBookFromDB(ctx sdk.Context, product string) *types.DepthBook {
 	store := ctx.KVStore(k.orderStoreKey)
@@ -456,6 +469,27 @@ func (k Keeper) SetParams(ctx sdk.Context, params *types.Params) {
 	k.paramSpace.SetParamSet(ctx, params)
 }
 
+// GetAuctionType gets the auction type of the match engine, the periodic auction is used if it's not set
+// or before the Venus2 height
+func (k Keeper) GetAuctionType(ctx sdk.Context) string {
+	if !tmtypes.HigherThanVenus2(ctx.BlockHeight()) {
+		return types.DefaultAuctionType
+	}
+	return k.GetAuctionTypeParam(ctx)
+}
+
+// GetAuctionTypeParam gets the auction type set in the global param store, the periodic auction if it's not set
+func (k Keeper) GetAuctionTypeParam(ctx sdk.Context) string {
+	auctionType := types.DefaultAuctionType
+	k.paramSpace.GetIfExists(ctx, types.KeyAuctionType, &auctionType)
+	return auctionType
+}
+
+// SetAuctionType sets the auction type of the match engine to the global param store
+func (k Keeper) SetAuctionType(ctx sdk.Context, auctionType string) {
+	k.paramSpace.Set(ctx, types.KeyAuctionType, auctionType)
+}
+
 // nolint
 func (k Keeper) GetMetric() *monitor.OrderMetric {
 	return k.metric
@@ -534,6 +568,78 @@ func (k Keeper) FilterDelistedProducts(ctx sdk.Context, products []string) []str
 	return cleanProducts
 }
 
+// IsProductPaused checks whether the base or quote token of the product is paused by its owner,
+// the orders of the paused product can't be placed or matched until it's unpaused
+func (k Keeper) IsProductPaused(ctx sdk.Context, product string) bool {
+	for _, symbol := range strings.Split(product, "_") {
+		if k.tokenKeeper.IsTokenPaused(ctx, symbol) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSenderFrozen checks whether the sender of the order is frozen for the base or quote token of the product
+func (k Keeper) IsSenderFrozen(ctx sdk.Context, order *types.Order) bool {
+	for _, symbol := range strings.Split(order.Product, "_") {
+		if k.tokenKeeper.IsAccountFrozen(ctx, symbol, order.Sender) {
+			return true
+		}
+	}
+	return false
+}
+
+// CancelFrozenAccountOrders cancels the open orders of the account on the products of the token it's frozen for,
+// so that they can't be filled any more. The orders on the locked products are recorded and cancelled after
+// the products are unlocked, since the match of a locked product is being executed.
+func (k Keeper) CancelFrozenAccountOrders(ctx sdk.Context, symbol string, addr sdk.AccAddress) {
+	logger := ctx.Logger().With("module", "order")
+	products := k.GetProductsFromDepthBookMap()
+	sort.Strings(products)
+	for _, product := range products {
+		if !productHasToken(product, symbol) {
+			continue
+		}
+		locked := k.IsProductLocked(ctx, product)
+		book := k.GetDepthBookCopy(product)
+		for _, item := range book.Items {
+			for _, side := range []string{types.BuyOrder, types.SellOrder} {
+				for _, orderID := range k.GetProductPriceOrderIDs(types.FormatOrderIDsKey(product, item.Price, side)) {
+					order := k.GetOrder(ctx, orderID)
+					if order == nil || order.Status != types.OrderStatusOpen || !order.Sender.Equals(addr) {
+						continue
+					}
+					if locked {
+						k.SetFrozenOrder(ctx, orderID)
+					} else {
+						k.CancelOrder(ctx, order, logger)
+					}
+				}
+			}
+		}
+	}
+}
+
+func productHasToken(product, symbol string) bool {
+	for _, s := range strings.Split(product, "_") {
+		if s == symbol {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterPausedProducts filters out the paused products
+func (k Keeper) FilterPausedProducts(ctx sdk.Context, products []string) []string {
+	var cleanProducts []string
+	for _, product := range products {
+		if !k.IsProductPaused(ctx, product) {
+			cleanProducts = append(cleanProducts, product)
+		}
+	}
+	return cleanProducts
+}
+
 // nolint
 func (k Keeper) AddTxHandlerMsgResult(resultSet bitset.BitSet) {
 	if k.enableBackend {
@@ -49,6 +49,12 @@ func (k Keeper) PlaceOrder(ctx sdk.Context, order *types.Order) error {
 	k.SetBlockOrderNum(ctx, blockHeight, orderNum+1)
 	k.SetOrder(ctx, order.OrderID, order)
 
+	// the trigger order stays out of the depth book until being triggered
+	if order.IsPendingTrigger() {
+		k.InsertPendingTriggerOrder(ctx, order)
+		return nil
+	}
+
 	// update depth book and orderIDsMap in cache
 	k.InsertOrderIntoDepthBook(order)
 	return nil
@@ -97,7 +103,11 @@ func (k Keeper) quitOrder(ctx sdk.Context, order *types.Order, feeType string, l
 	k.SetOrder(ctx, order.OrderID, order)
 
 	// remove order from depth book cache
-	k.RemoveOrderFromDepthBook(order, feeType)
+	if order.IsPendingTrigger() {
+		k.RemovePendingTriggerOrder(ctx, order, feeType)
+	} else {
+		k.RemoveOrderFromDepthBook(order, feeType)
+	}
 	return fee
 }
 
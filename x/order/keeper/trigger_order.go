@@ -0,0 +1,168 @@
+package keeper
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+
+	"github.com/okex/exchain/x/order/types"
+)
+
+// InsertPendingTriggerOrder keeps the trigger order out of the depth book until being triggered
+func (k Keeper) InsertPendingTriggerOrder(ctx sdk.Context, order *types.Order) {
+	store := ctx.KVStore(k.orderStoreKey)
+	store.Set(types.GetTriggerOrderKey(order), []byte{})
+	k.diskCache.addPendingOrder()
+}
+
+// RemovePendingTriggerOrder removes the pending trigger order when it's cancelled/expired,
+// and updates cancelNum, expireNum, updatedOrderIDs from cache
+func (k Keeper) RemovePendingTriggerOrder(ctx sdk.Context, order *types.Order, feeType string) {
+	k.addUpdatedOrderID(order.OrderID)
+	if feeType == types.FeeTypeOrderCancel {
+		k.cache.IncreaseCancelNum()
+	} else if feeType == types.FeeTypeOrderExpire {
+		k.cache.IncreaseExpireNum()
+	}
+
+	k.DropTriggerOrder(ctx, order)
+	k.diskCache.closeOrder(order.OrderID)
+}
+
+// ActivateTriggerOrder activates the pending trigger order, and inserts it into the depth book as a limit order
+func (k Keeper) ActivateTriggerOrder(ctx sdk.Context, order *types.Order) {
+	order.Triggered = true
+	k.SetOrder(ctx, order.OrderID, order)
+	k.addUpdatedOrderID(order.OrderID)
+
+	k.DropTriggerOrder(ctx, order)
+	k.diskCache.insertOrderIntoDepthBook(order)
+}
+
+// GetTriggerOrderIDs gets the order ids of all the pending trigger orders from KVStore
+func (k Keeper) GetTriggerOrderIDs(ctx sdk.Context) []string {
+	return k.getTriggerOrderIDsByPrefix(ctx, types.TriggerOrderKey)
+}
+
+// GetTriggerOrderIDsByProduct gets the order ids of the pending trigger orders of the product from KVStore
+func (k Keeper) GetTriggerOrderIDsByProduct(ctx sdk.Context, product string) []string {
+	return k.getTriggerOrderIDsByPrefix(ctx, types.GetTriggerOrderProductPrefix(product))
+}
+
+// GetTriggerOrderProducts gets the products which have pending trigger orders from KVStore,
+// it seeks to the next product instead of iterating over the orders of a product
+func (k Keeper) GetTriggerOrderProducts(ctx sdk.Context) []string {
+	store := ctx.KVStore(k.orderStoreKey)
+	end := sdk.PrefixEndBytes(types.TriggerOrderKey)
+
+	var products []string
+	start := types.TriggerOrderKey
+	for {
+		iter := store.Iterator(start, end)
+		if !iter.Valid() {
+			iter.Close()
+			return products
+		}
+		product, _, _ := types.SplitTriggerOrderKey(iter.Key())
+		iter.Close()
+
+		products = append(products, product)
+		start = sdk.PrefixEndBytes(types.GetTriggerOrderProductPrefix(product))
+	}
+}
+
+// GetTriggeredOrderIDs gets the order ids of the pending trigger orders of the product whose trigger prices
+// are crossed by the last price from KVStore. The orders triggered on rise are iterated from the lowest trigger
+// price, and the ones triggered on fall from the highest, so the orders not triggered yet aren't visited.
+func (k Keeper) GetTriggeredOrderIDs(ctx sdk.Context, product string, lastPrice sdk.Dec) []string {
+	if !lastPrice.IsPositive() {
+		return nil
+	}
+	store := ctx.KVStore(k.orderStoreKey)
+	var orderIDs []string
+
+	iter := sdk.KVStorePrefixIterator(store, types.GetTriggerOrderDirectionPrefix(product, true))
+	for ; iter.Valid(); iter.Next() {
+		_, triggerPrice, orderID := types.SplitTriggerOrderKey(iter.Key())
+		if triggerPrice.GT(lastPrice) {
+			break
+		}
+		orderIDs = append(orderIDs, orderID)
+	}
+	iter.Close()
+
+	iter = sdk.KVStoreReversePrefixIterator(store, types.GetTriggerOrderDirectionPrefix(product, false))
+	for ; iter.Valid(); iter.Next() {
+		_, triggerPrice, orderID := types.SplitTriggerOrderKey(iter.Key())
+		if triggerPrice.LT(lastPrice) {
+			break
+		}
+		orderIDs = append(orderIDs, orderID)
+	}
+	iter.Close()
+
+	return orderIDs
+}
+
+// DropTriggerOrder removes the pending trigger order from KVStore
+func (k Keeper) DropTriggerOrder(ctx sdk.Context, order *types.Order) {
+	store := ctx.KVStore(k.orderStoreKey)
+	store.Delete(types.GetTriggerOrderKey(order))
+}
+
+func (k Keeper) getTriggerOrderIDsByPrefix(ctx sdk.Context, prefix []byte) []string {
+	store := ctx.KVStore(k.orderStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+
+	var orderIDs []string
+	for ; iter.Valid(); iter.Next() {
+		_, _, orderID := types.SplitTriggerOrderKey(iter.Key())
+		orderIDs = append(orderIDs, orderID)
+	}
+	return orderIDs
+}
+
+// SetImmediateOrder records the immediate-or-cancel order to cancel its remain part after matching
+func (k Keeper) SetImmediateOrder(ctx sdk.Context, orderID string) {
+	store := ctx.KVStore(k.orderStoreKey)
+	store.Set(types.GetImmediateOrderKey(orderID), []byte{})
+}
+
+// GetImmediateOrderIDs gets the order ids of the immediate-or-cancel orders waiting for matching from KVStore
+func (k Keeper) GetImmediateOrderIDs(ctx sdk.Context) []string {
+	return k.getOrderIDsByPrefix(ctx, types.ImmediateOrderKey)
+}
+
+// DropImmediateOrder removes the immediate-or-cancel order id from KVStore
+func (k Keeper) DropImmediateOrder(ctx sdk.Context, orderID string) {
+	store := ctx.KVStore(k.orderStoreKey)
+	store.Delete(types.GetImmediateOrderKey(orderID))
+}
+
+// SetFrozenOrder records the order of a frozen account on a locked product to cancel it after the product is unlocked
+func (k Keeper) SetFrozenOrder(ctx sdk.Context, orderID string) {
+	store := ctx.KVStore(k.orderStoreKey)
+	store.Set(types.GetFrozenOrderKey(orderID), []byte{})
+}
+
+// GetFrozenOrderIDs gets the order ids of the frozen accounts waiting for the products to be unlocked from KVStore
+func (k Keeper) GetFrozenOrderIDs(ctx sdk.Context) []string {
+	return k.getOrderIDsByPrefix(ctx, types.FrozenOrderKey)
+}
+
+// DropFrozenOrder removes the order id of the frozen account from KVStore
+func (k Keeper) DropFrozenOrder(ctx sdk.Context, orderID string) {
+	store := ctx.KVStore(k.orderStoreKey)
+	store.Delete(types.GetFrozenOrderKey(orderID))
+}
+
+func (k Keeper) getOrderIDsByPrefix(ctx sdk.Context, prefix []byte) []string {
+	store := ctx.KVStore(k.orderStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+
+	var orderIDs []string
+	for ; iter.Valid(); iter.Next() {
+		orderIDs = append(orderIDs, types.GetKey(iter))
+	}
+	return orderIDs
+}
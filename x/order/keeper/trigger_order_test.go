@@ -0,0 +1,153 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/dex"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/x/order/types"
+)
+
+func TestPlaceAndActivateTriggerOrder(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	order := mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0")
+	order.Sender = testInput.TestAddrs[0]
+	order.Type = types.OrderTypeStop
+	triggerPrice := sdk.MustNewDecFromStr("9.5")
+	order.TriggerPrice = &triggerPrice
+	err = keeper.PlaceOrder(ctx, order)
+	require.Nil(t, err)
+
+	// the pending trigger order is out of the depth book
+	require.EqualValues(t, []string{order.OrderID}, keeper.GetTriggerOrderIDs(ctx))
+	require.EqualValues(t, 0, len(keeper.GetDepthBookCopy(order.Product).Items))
+	require.EqualValues(t, 1, keeper.diskCache.openNum)
+	require.EqualValues(t, 1, keeper.diskCache.storeOrderNum)
+
+	// activate the trigger order
+	keeper.ActivateTriggerOrder(ctx, order)
+	require.EqualValues(t, 0, len(keeper.GetTriggerOrderIDs(ctx)))
+	require.True(t, keeper.GetOrder(ctx, order.OrderID).Triggered)
+	depthBook := keeper.GetDepthBookCopy(order.Product)
+	require.EqualValues(t, 1, len(depthBook.Items))
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.0"), depthBook.Items[0].BuyQuantity)
+	require.EqualValues(t, []string{order.OrderID},
+		keeper.GetProductPriceOrderIDs(types.FormatOrderIDsKey(order.Product, order.Price, order.Side)))
+	require.EqualValues(t, 1, keeper.diskCache.openNum)
+}
+
+func TestCancelPendingTriggerOrder(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	order := mockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0")
+	order.Sender = testInput.TestAddrs[0]
+	order.Type = types.OrderTypeTakeProfit
+	triggerPrice := sdk.MustNewDecFromStr("10.5")
+	order.TriggerPrice = &triggerPrice
+	err = keeper.PlaceOrder(ctx, order)
+	require.Nil(t, err)
+
+	keeper.CancelOrder(ctx, order, ctx.Logger())
+	require.EqualValues(t, types.OrderStatusCancelled, keeper.GetOrder(ctx, order.OrderID).Status)
+	require.EqualValues(t, 0, len(keeper.GetTriggerOrderIDs(ctx)))
+	require.EqualValues(t, 0, len(keeper.GetDepthBookCopy(order.Product).Items))
+	require.EqualValues(t, []string{order.OrderID}, keeper.GetDiskCache().GetClosedOrderIDs())
+	require.EqualValues(t, 0, keeper.diskCache.openNum)
+	require.EqualValues(t, 1, keeper.cache.cancelNum)
+}
+
+func TestTriggeredOrderIDs(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	orders := []*types.Order{
+		mockTriggerOrder(types.BuyOrder, types.OrderTypeStop, "10.5"),
+		mockTriggerOrder(types.BuyOrder, types.OrderTypeStop, "9.5"),
+		mockTriggerOrder(types.SellOrder, types.OrderTypeStop, "9.0"),
+		mockTriggerOrder(types.SellOrder, types.OrderTypeTakeProfit, "11.0"),
+		mockTriggerOrder(types.BuyOrder, types.OrderTypeTakeProfit, "8.0"),
+	}
+	for _, order := range orders {
+		order.Sender = testInput.TestAddrs[0]
+		require.Nil(t, keeper.PlaceOrder(ctx, order))
+	}
+	require.EqualValues(t, []string{types.TestTokenPair}, keeper.GetTriggerOrderProducts(ctx))
+	require.EqualValues(t, 5, len(keeper.GetTriggerOrderIDsByProduct(ctx, types.TestTokenPair)))
+
+	// the orders triggered on rise come first from the lowest trigger price, then the ones on fall from the highest
+	testCases := []struct {
+		lastPrice string
+		triggered []*types.Order
+	}{
+		{"0", nil},
+		{"10.0", []*types.Order{orders[1]}},
+		{"9.0", []*types.Order{orders[2]}},
+		{"11.0", []*types.Order{orders[1], orders[0], orders[3]}},
+		{"7.0", []*types.Order{orders[2], orders[4]}},
+	}
+	for _, tc := range testCases {
+		lastPrice := sdk.MustNewDecFromStr(tc.lastPrice)
+		var expected []string
+		for _, order := range tc.triggered {
+			require.True(t, order.ShouldTrigger(lastPrice))
+			expected = append(expected, order.OrderID)
+		}
+		require.EqualValues(t, expected, keeper.GetTriggeredOrderIDs(ctx, types.TestTokenPair, lastPrice), tc.lastPrice)
+	}
+
+	// the products are listed once however many orders they have
+	other := mockTriggerOrder(types.SellOrder, types.OrderTypeStop, "1.0")
+	other.Product = types.TestTokenPair + "x"
+	other.OrderID = types.FormatOrderID(10, 6)
+	keeper.InsertPendingTriggerOrder(ctx, other)
+	require.EqualValues(t, []string{types.TestTokenPair, other.Product}, keeper.GetTriggerOrderProducts(ctx))
+	require.EqualValues(t, []string{other.OrderID}, keeper.GetTriggerOrderIDsByProduct(ctx, other.Product))
+
+	keeper.DropTriggerOrder(ctx, other)
+	keeper.ActivateTriggerOrder(ctx, orders[1])
+	require.EqualValues(t, []string{types.TestTokenPair}, keeper.GetTriggerOrderProducts(ctx))
+	require.EqualValues(t, []string{orders[0].OrderID},
+		keeper.GetTriggeredOrderIDs(ctx, types.TestTokenPair, sdk.MustNewDecFromStr("10.5")))
+}
+
+func mockTriggerOrder(side, orderType, triggerPrice string) *types.Order {
+	order := mockOrder("", types.TestTokenPair, side, "10.0", "1.0")
+	order.Type = orderType
+	price := sdk.MustNewDecFromStr(triggerPrice)
+	order.TriggerPrice = &price
+	return order
+}
+
+func TestImmediateOrderIDs(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx
+
+	keeper.SetImmediateOrder(ctx, types.FormatOrderID(10, 1))
+	keeper.SetImmediateOrder(ctx, types.FormatOrderID(10, 2))
+	require.EqualValues(t, []string{types.FormatOrderID(10, 1), types.FormatOrderID(10, 2)},
+		keeper.GetImmediateOrderIDs(ctx))
+
+	keeper.DropImmediateOrder(ctx, types.FormatOrderID(10, 1))
+	require.EqualValues(t, []string{types.FormatOrderID(10, 2)}, keeper.GetImmediateOrderIDs(ctx))
+}
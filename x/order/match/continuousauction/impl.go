@@ -4,13 +4,19 @@ import (
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 
 	"github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/match/periodicauction"
 )
 
-// nolint
+// CaEngine is the continuous auction match engine.
+// The new orders are matched immediately when they are placed, see MatchNewOrder,
+// so only the expired, delisted and frozen orders are cleaned up at the end of the block.
 type CaEngine struct {
 }
 
 // nolint
 func (e *CaEngine) Run(ctx sdk.Context, keeper keeper.Keeper) {
-	// TODO
+	periodicauction.CleanupExpiredOrders(ctx, keeper)
+	periodicauction.CleanupOrdersWhoseTokenPairHaveBeenDelisted(ctx, keeper)
+	// the products locked by the periodic auction before switching to continuous auction
+	periodicauction.CancelFrozenOrders(ctx, keeper)
 }
@@ -0,0 +1,194 @@
+package continuousauction
+
+import (
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/common"
+	"github.com/okex/exchain/x/dex"
+	orderkeeper "github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchNewOrder(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	// resting sell orders
+	orders := []*types.Order{
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.1", "1.0"),
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "0.5"),
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+	}
+	for _, order := range orders {
+		order.Sender = testInput.TestAddrs[1]
+		require.NoError(t, keeper.PlaceOrder(ctx, order))
+		require.Nil(t, MatchNewOrder(ctx, keeper, order))
+	}
+
+	// the buy order crossing the lowest sell price is filled at the resting prices by time priority
+	buyOrder := types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "2.0")
+	buyOrder.Sender = testInput.TestAddrs[0]
+	require.NoError(t, keeper.PlaceOrder(ctx, buyOrder))
+	matchResult := MatchNewOrder(ctx, keeper, buyOrder)
+	require.NotNil(t, matchResult)
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.0"), matchResult.Price)
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.5"), matchResult.Quantity)
+	require.EqualValues(t, 3, len(matchResult.Deals))
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.0"), keeper.GetLastPrice(ctx, types.TestTokenPair))
+
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, orders[0].OrderID).Status)
+	require.EqualValues(t, types.OrderStatusFilled, keeper.GetOrder(ctx, orders[1].OrderID).Status)
+	require.EqualValues(t, types.OrderStatusFilled, keeper.GetOrder(ctx, orders[2].OrderID).Status)
+	order := keeper.GetOrder(ctx, buyOrder.OrderID)
+	require.EqualValues(t, types.OrderStatusOpen, order.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.5"), order.RemainQuantity)
+
+	// the remain part of the buy order rests in the depth book
+	book := keeper.GetDepthBookCopy(types.TestTokenPair)
+	require.EqualValues(t, 2, len(book.Items))
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.0"), book.Items[0].SellQuantity)
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.5"), book.Items[1].BuyQuantity)
+	require.EqualValues(t, sdk.ZeroDec(), book.Items[1].SellQuantity)
+	require.EqualValues(t, []string{buyOrder.OrderID},
+		keeper.GetProductPriceOrderIDs(types.FormatOrderIDsKey(types.TestTokenPair, buyOrder.Price, types.BuyOrder)))
+
+	// the sell order is filled at the resting buy price, and removed from the depth book
+	sellOrder := types.MockOrder("", types.TestTokenPair, types.SellOrder, "9.9", "0.5")
+	sellOrder.Sender = testInput.TestAddrs[1]
+	require.NoError(t, keeper.PlaceOrder(ctx, sellOrder))
+	matchResult = MatchNewOrder(ctx, keeper, sellOrder)
+	require.NotNil(t, matchResult)
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.0"), matchResult.Price)
+	require.EqualValues(t, types.OrderStatusFilled, keeper.GetOrder(ctx, sellOrder.OrderID).Status)
+	require.EqualValues(t, types.OrderStatusFilled, keeper.GetOrder(ctx, buyOrder.OrderID).Status)
+
+	book = keeper.GetDepthBookCopy(types.TestTokenPair)
+	require.EqualValues(t, 1, len(book.Items))
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.1"), book.Items[0].Price)
+	require.EqualValues(t, 0,
+		len(keeper.GetProductPriceOrderIDs(types.FormatOrderIDsKey(types.TestTokenPair, sellOrder.Price, types.SellOrder))))
+}
+
+func TestMatchNewOrderBlockDealsLimit(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	params := keeper.GetParams(ctx)
+	params.MaxDealsPerBlock = 2
+	keeper.SetParams(ctx, params)
+
+	for i := 0; i < 3; i++ {
+		order := types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "0.5")
+		order.Sender = testInput.TestAddrs[1]
+		require.NoError(t, keeper.PlaceOrder(ctx, order))
+	}
+	newBuyOrder := func(quantity string) *types.Order {
+		order := types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", quantity)
+		order.Sender = testInput.TestAddrs[0]
+		require.NoError(t, keeper.PlaceOrder(ctx, order))
+		return order
+	}
+
+	// the deals of all the new orders in the block share the limit
+	matchResult := MatchNewOrder(ctx, keeper, newBuyOrder("0.5"))
+	require.NotNil(t, matchResult)
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.5"), matchResult.Quantity)
+	matchResult = MatchNewOrder(ctx, keeper, newBuyOrder("1.0"))
+	require.NotNil(t, matchResult)
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.5"), matchResult.Quantity)
+	require.EqualValues(t, 0, keeper.GetBlockRemainDeals(ctx))
+	require.False(t, CanFillOrder(ctx, keeper, types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "0.5")))
+	buyOrder := newBuyOrder("0.5")
+	require.Nil(t, MatchNewOrder(ctx, keeper, buyOrder))
+
+	// the limit is renewed in the next block
+	keeper.ResetCache(ctx)
+	require.EqualValues(t, 2, keeper.GetBlockRemainDeals(ctx))
+	require.True(t, CanFillOrder(ctx, keeper, types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "0.5")))
+}
+
+func TestCaEngine_Run(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	orders := []*types.Order{
+		types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0"),
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "0.5"),
+	}
+	orders[0].Sender = testInput.TestAddrs[0]
+	orders[1].Sender = testInput.TestAddrs[1]
+	for _, order := range orders {
+		require.NoError(t, keeper.PlaceOrder(ctx, order))
+	}
+
+	// no batch matching at the end of block
+	engine := &CaEngine{}
+	engine.Run(ctx, keeper)
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, orders[0].OrderID).Status)
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, orders[1].OrderID).Status)
+}
+
+func TestCanFillOrder(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	orders := []*types.Order{
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "0.5"),
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.1", "1.0"),
+		types.MockOrder("", types.TestTokenPair, types.BuyOrder, "9.9", "1.0"),
+	}
+	for _, order := range orders {
+		order.Sender = testInput.TestAddrs[1]
+		require.NoError(t, keeper.PlaceOrder(ctx, order))
+	}
+
+	require.True(t, CanFillOrder(ctx, keeper, types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "0.5")))
+	require.False(t, CanFillOrder(ctx, keeper, types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "0.6")))
+	require.True(t, CanFillOrder(ctx, keeper, types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.1", "1.5")))
+	require.False(t, CanFillOrder(ctx, keeper, types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.1", "1.6")))
+	require.True(t, CanFillOrder(ctx, keeper, types.MockOrder("", types.TestTokenPair, types.SellOrder, "9.9", "1.0")))
+	require.False(t, CanFillOrder(ctx, keeper, types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "0.1")))
+}
+
+func TestMatchNewOrderAfterMakerFrozen(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	sellOrder := types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0")
+	sellOrder.Sender = testInput.TestAddrs[1]
+	require.NoError(t, keeper.PlaceOrder(ctx, sellOrder))
+	require.Nil(t, MatchNewOrder(ctx, keeper, sellOrder))
+
+	// the resting order of the maker frozen for the token is cancelled, so it can't be filled any more
+	testInput.TokenKeeper.SetAccountFrozen(ctx, common.TestToken, testInput.TestAddrs[1], true)
+	keeper.Hooks().AfterAccountFrozen(ctx, common.TestToken, testInput.TestAddrs[1])
+	require.EqualValues(t, types.OrderStatusCancelled, keeper.GetOrder(ctx, sellOrder.OrderID).Status)
+
+	buyOrder := types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0")
+	buyOrder.Sender = testInput.TestAddrs[0]
+	require.NoError(t, keeper.PlaceOrder(ctx, buyOrder))
+	require.Nil(t, MatchNewOrder(ctx, keeper, buyOrder))
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, buyOrder.OrderID).Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.0"), keeper.GetOrder(ctx, buyOrder.OrderID).RemainQuantity)
+}
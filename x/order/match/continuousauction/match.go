@@ -0,0 +1,195 @@
+package continuousauction
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+
+	"github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/match/periodicauction"
+	"github.com/okex/exchain/x/order/types"
+)
+
+// MatchNewOrder matches a placed order against the resting orders on the opposite side of the depth book
+// with price-time priority. The resting orders are filled at their own prices one by one, in the order of
+// being placed at the same price, and the unfilled part of the new order keeps resting in the depth book.
+// The deals of all the new orders in a block are limited by MaxDealsPerBlock. It returns nil if nothing is filled.
+func MatchNewOrder(ctx sdk.Context, k keeper.Keeper, order *types.Order) *types.MatchResult {
+	feeParams := k.GetParams(ctx)
+	remainDeals := k.GetBlockRemainDeals(ctx)
+
+	book := k.GetDepthBookCopy(order.Product)
+	// take the new order out of the depth book while matching, and put the remain part back at last
+	book.RemoveOrder(order)
+
+	matchResult := &types.MatchResult{
+		BlockHeight: ctx.BlockHeight(),
+		Price:       sdk.ZeroDec(),
+		Quantity:    sdk.ZeroDec(),
+		Deals:       []types.Deal{},
+	}
+	for remainDeals > 0 && order.RemainQuantity.IsPositive() {
+		index := book.BestCrossIndex(order.Side, order.Price)
+		if index < 0 {
+			break
+		}
+
+		price := book.Items[index].Price
+		deals, filledAmount := fillOppositeOrdersByPrice(ctx, k, order, price, remainDeals, feeParams)
+		if filledAmount.IsZero() {
+			break
+		}
+		remainDeals -= int64(len(deals))
+		k.AddBlockDeals(int64(len(deals)))
+
+		if deal := periodicauction.FillOrder(order, ctx, k, price, filledAmount, feeParams); deal != nil {
+			deals = append(deals, *deal)
+		}
+		book.Sub(index, filledAmount, oppositeSide(order.Side))
+		book.RemoveIfEmpty(index)
+
+		matchResult.Price = price
+		matchResult.Quantity = matchResult.Quantity.Add(filledAmount)
+		matchResult.Deals = append(matchResult.Deals, deals...)
+	}
+
+	if order.RemainQuantity.IsPositive() {
+		book.InsertOrder(order)
+	} else {
+		removeOrderID(k, order)
+	}
+	k.SetDepthBook(order.Product, book)
+
+	if matchResult.Quantity.IsZero() {
+		return nil
+	}
+	k.SetLastPrice(ctx, order.Product, matchResult.Price)
+	addBlockMatchResult(ctx, k, order.Product, matchResult)
+
+	ctx.Logger().With("module", "order").Info(fmt.Sprintf("matchResult(%d-%s): order: %s, price: %v, "+
+		"quantity: %v, dealsNum: %d", matchResult.BlockHeight, order.Product, order.OrderID,
+		matchResult.Price, matchResult.Quantity, len(matchResult.Deals)))
+	return matchResult
+}
+
+// CanFillOrder checks whether the order to be placed can be fully filled by the resting orders immediately,
+// within the deals left to the block by MatchNewOrder
+func CanFillOrder(ctx sdk.Context, k keeper.Keeper, order *types.Order) bool {
+	remainDeals := k.GetBlockRemainDeals(ctx)
+	needFillAmount := order.RemainQuantity
+	book := k.GetDepthBookCopy(order.Product)
+	side := oppositeSide(order.Side)
+
+	for {
+		index := book.BestCrossIndex(order.Side, order.Price)
+		if index < 0 {
+			return false
+		}
+
+		price := book.Items[index].Price
+		for _, orderID := range k.GetProductPriceOrderIDs(types.FormatOrderIDsKey(order.Product, price, side)) {
+			if remainDeals <= 0 {
+				return false
+			}
+			opposite := k.GetOrder(ctx, orderID)
+			if opposite == nil {
+				continue
+			}
+			needFillAmount = needFillAmount.Sub(opposite.RemainQuantity)
+			remainDeals--
+			if !needFillAmount.IsPositive() {
+				return true
+			}
+		}
+		// move on to the next price on the opposite side
+		if side == types.BuyOrder {
+			book.Sub(index, book.Items[index].BuyQuantity, side)
+		} else {
+			book.Sub(index, book.Items[index].SellQuantity, side)
+		}
+		book.RemoveIfEmpty(index)
+	}
+}
+
+// fillOppositeOrdersByPrice fills the resting orders on the opposite side at the price by time priority,
+// and returns the deals of the resting orders and the filled amount
+func fillOppositeOrdersByPrice(ctx sdk.Context, k keeper.Keeper, order *types.Order, price sdk.Dec,
+	remainDeals int64, feeParams *types.Params) ([]types.Deal, sdk.Dec) {
+
+	deals := []types.Deal{}
+	filledAmount := sdk.ZeroDec()
+	needFillAmount := order.RemainQuantity
+
+	key := types.FormatOrderIDsKey(order.Product, price, oppositeSide(order.Side))
+	orderIDs := k.GetProductPriceOrderIDs(key)
+
+	index := 0
+	for index < len(orderIDs) && int64(len(deals)) < remainDeals && filledAmount.LT(needFillAmount) {
+		opposite := k.GetOrder(ctx, orderIDs[index])
+		if opposite == nil {
+			ctx.Logger().Error("[Order] Not exist orderID: ", orderIDs[index])
+			index++
+			continue
+		}
+
+		fillAmount := sdk.MinDec(opposite.RemainQuantity, needFillAmount.Sub(filledAmount))
+		if deal := periodicauction.FillOrder(opposite, ctx, k, price, fillAmount, feeParams); deal != nil {
+			deals = append(deals, *deal)
+		}
+		filledAmount = filledAmount.Add(fillAmount)
+		if opposite.Status == types.OrderStatusFilled {
+			index++
+		}
+	}
+
+	// Note: orderIDs cannot be nil, we will use empty slice to remove Data on keeper
+	unFilledOrderIDs := append([]string{}, orderIDs[index:]...)
+	k.SetOrderIDs(key, unFilledOrderIDs)
+
+	return deals, filledAmount
+}
+
+// removeOrderID removes the fully filled new order from the orderIDsMap
+func removeOrderID(k keeper.Keeper, order *types.Order) {
+	key := types.FormatOrderIDsKey(order.Product, order.Price, order.Side)
+	orderIDs := k.GetProductPriceOrderIDs(key)
+	unFilledOrderIDs := make([]string, 0, len(orderIDs))
+	for _, orderID := range orderIDs {
+		if orderID != order.OrderID {
+			unFilledOrderIDs = append(unFilledOrderIDs, orderID)
+		}
+	}
+	k.SetOrderIDs(key, unFilledOrderIDs)
+}
+
+// addBlockMatchResult merges the match result of a new order into the match results of the block for querying
+func addBlockMatchResult(ctx sdk.Context, k keeper.Keeper, product string, matchResult *types.MatchResult) {
+	blockMatchResult := k.GetBlockMatchResult()
+	if blockMatchResult == nil || blockMatchResult.BlockHeight != ctx.BlockHeight() {
+		blockMatchResult = &types.BlockMatchResult{
+			BlockHeight: ctx.BlockHeight(),
+			TimeStamp:   ctx.BlockHeader().Time.Unix(),
+		}
+	}
+	if blockMatchResult.ResultMap == nil {
+		blockMatchResult.ResultMap = make(map[string]types.MatchResult)
+	}
+
+	result, ok := blockMatchResult.ResultMap[product]
+	if ok {
+		result.Price = matchResult.Price
+		result.Quantity = result.Quantity.Add(matchResult.Quantity)
+		result.Deals = append(result.Deals, matchResult.Deals...)
+	} else {
+		result = *matchResult
+	}
+	blockMatchResult.ResultMap[product] = result
+	k.SetBlockMatchResult(blockMatchResult)
+}
+
+func oppositeSide(side string) string {
+	if side == types.BuyOrder {
+		return types.SellOrder
+	}
+	return types.BuyOrder
+}
@@ -1,35 +1,29 @@
 package match
 
 import (
-	"sync"
-
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 
 	"github.com/okex/exchain/x/order/keeper"
 	"github.com/okex/exchain/x/order/match/continuousauction"
 	"github.com/okex/exchain/x/order/match/periodicauction"
+	"github.com/okex/exchain/x/order/types"
 )
 
 // nolint
-const DefaultAuctionType = "periodicauction"
+const DefaultAuctionType = types.DefaultAuctionType
 
 // nolint
 var (
-	once        sync.Once
-	engine      Engine
-	auctionType = DefaultAuctionType
+	paEngine = &periodicauction.PaEngine{}
+	caEngine = &continuousauction.CaEngine{}
 )
 
-// GetEngine : periodic auction only today
-func GetEngine() Engine {
-	once.Do(func() {
-		if auctionType == DefaultAuctionType {
-			engine = &periodicauction.PaEngine{}
-		} else {
-			engine = &continuousauction.CaEngine{}
-		}
-	})
-	return engine
+// GetEngine returns the match engine of the auction type in order params, periodic auction by default
+func GetEngine(auctionType string) Engine {
+	if auctionType == types.AuctionTypeContinuous {
+		return caEngine
+	}
+	return paEngine
 }
 
 // nolint
@@ -156,14 +156,14 @@ func fillOrderByKey(ctx sdk.Context, keeper orderkeeper.Keeper, key string,
 		}
 		if filledAmount.Add(order.RemainQuantity).LTE(needFillAmount) {
 			filledAmount = filledAmount.Add(order.RemainQuantity)
-			if deal := fillOrder(order, ctx, keeper, fillPrice, order.RemainQuantity, feeParams); deal != nil {
+			if deal := FillOrder(order, ctx, keeper, fillPrice, order.RemainQuantity, feeParams); deal != nil {
 				deals = append(deals, *deal)
 			}
 
 			filledDealsCnt++
 			index++
 		} else {
-			if deal := fillOrder(order, ctx, keeper, fillPrice, needFillAmount.Sub(filledAmount), feeParams); deal != nil {
+			if deal := FillOrder(order, ctx, keeper, fillPrice, needFillAmount.Sub(filledAmount), feeParams); deal != nil {
 				deals = append(deals, *deal)
 			}
 			filledAmount = needFillAmount
@@ -225,9 +225,9 @@ func chargeFee(order *types.Order, ctx sdk.Context, keeper orderkeeper.Keeper, f
 	return
 }
 
-// Fill an order. Update order, charge fee and transfer tokens. Return a deal.
+// FillOrder fills an order. Update order, charge fee and transfer tokens. Return a deal.
 // If an order is fully filled but still lock some coins, unlock it.
-func fillOrder(order *types.Order, ctx sdk.Context, keeper orderkeeper.Keeper,
+func FillOrder(order *types.Order, ctx sdk.Context, keeper orderkeeper.Keeper,
 	fillPrice, fillQuantity sdk.Dec, feeParams *types.Params) *types.Deal {
 
 	// update order
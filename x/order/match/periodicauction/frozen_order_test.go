@@ -0,0 +1,48 @@
+package periodicauction
+
+import (
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/common"
+	"github.com/okex/exchain/x/dex"
+	orderkeeper "github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancelFrozenOrders(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	buyOrder := types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0")
+	buyOrder.Sender = testInput.TestAddrs[0]
+	sellOrder := types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0")
+	sellOrder.Sender = testInput.TestAddrs[1]
+	require.NoError(t, keeper.PlaceOrder(ctx, buyOrder))
+	require.NoError(t, keeper.PlaceOrder(ctx, sellOrder))
+
+	// the order of the account frozen on the locked product is kept until the product is unlocked
+	keeper.SetProductLock(ctx, types.TestTokenPair, &types.ProductLock{BlockHeight: 9, Price: sdk.ZeroDec(),
+		Quantity: sdk.ZeroDec(), BuyExecuted: sdk.ZeroDec(), SellExecuted: sdk.ZeroDec()})
+	testInput.TokenKeeper.SetAccountFrozen(ctx, common.TestToken, testInput.TestAddrs[1], true)
+	keeper.Hooks().AfterAccountFrozen(ctx, common.TestToken, testInput.TestAddrs[1])
+	CancelFrozenOrders(ctx, keeper)
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, sellOrder.OrderID).Status)
+	require.EqualValues(t, []string{sellOrder.OrderID}, keeper.GetFrozenOrderIDs(ctx))
+
+	keeper.UnlockProduct(ctx, types.TestTokenPair)
+	CancelFrozenOrders(ctx, keeper)
+	require.EqualValues(t, types.OrderStatusCancelled, keeper.GetOrder(ctx, sellOrder.OrderID).Status)
+	require.EqualValues(t, 0, len(keeper.GetFrozenOrderIDs(ctx)))
+
+	// the cancelled order of the frozen account isn't filled by the periodic auction
+	engine := &PaEngine{}
+	engine.Run(ctx, keeper)
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, buyOrder.OrderID).Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.0"), keeper.GetOrder(ctx, buyOrder.OrderID).RemainQuantity)
+}
@@ -12,7 +12,9 @@ type PaEngine struct {
 
 // nolint
 func (e *PaEngine) Run(ctx sdk.Context, keeper keeper.Keeper) {
-	cleanupExpiredOrders(ctx, keeper)
-	cleanupOrdersWhoseTokenPairHaveBeenDelisted(ctx, keeper)
+	CleanupExpiredOrders(ctx, keeper)
+	CleanupOrdersWhoseTokenPairHaveBeenDelisted(ctx, keeper)
 	matchOrders(ctx, keeper)
+	cancelImmediateOrders(ctx, keeper)
+	CancelFrozenOrders(ctx, keeper)
 }
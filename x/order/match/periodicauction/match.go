@@ -221,7 +221,8 @@ func cacheExpiredBlockToCurrentHeight(ctx sdk.Context, keeper keeper.Keeper) {
 	}
 }
 
-func cleanupOrdersWhoseTokenPairHaveBeenDelisted(ctx sdk.Context, keeper keeper.Keeper) {
+// CleanupOrdersWhoseTokenPairHaveBeenDelisted cancels all the open orders of the delisted products
+func CleanupOrdersWhoseTokenPairHaveBeenDelisted(ctx sdk.Context, keeper keeper.Keeper) {
 	products := keeper.GetProductsFromDepthBookMap()
 	for _, product := range products {
 		tokenPair := keeper.GetDexKeeper().GetTokenPair(ctx, product)
@@ -250,7 +251,8 @@ func cleanOrdersByOrderIDList(ctx sdk.Context, keeper keeper.Keeper, orderIDList
 	}
 }
 
-func cleanupExpiredOrders(ctx sdk.Context, keeper keeper.Keeper) {
+// CleanupExpiredOrders expires the orders placed OrderExpireBlocks ago, and drops the closed orders of the last block
+func CleanupExpiredOrders(ctx sdk.Context, keeper keeper.Keeper) {
 
 	// Look forward to see what height will this block expired
 	markCurBlockToFutureExpireBlockList(ctx, keeper)
@@ -264,15 +266,17 @@ func cleanupExpiredOrders(ctx sdk.Context, keeper keeper.Keeper) {
 
 func matchOrders(ctx sdk.Context, keeper keeper.Keeper) {
 	blockHeight := ctx.BlockHeight()
-	orderNum := keeper.GetBlockOrderNum(ctx, blockHeight)
-	// no new orders in this block & no product lock in previous blocks, skip match
-	if orderNum == 0 && !keeper.AnyProductLocked(ctx) {
+	// step0: get active products
+	products := keeper.GetDiskCache().GetNewDepthbookKeys()
+
+	// no new orders or triggered orders in this block & no product lock in previous blocks, skip match
+	if len(products) == 0 && !keeper.AnyProductLocked(ctx) {
 		return
 	}
 
-	// step0: get active products
-	products := keeper.GetDiskCache().GetNewDepthbookKeys()
 	products = keeper.FilterDelistedProducts(ctx, products)
+	// the paused products are matched after being unpaused and receiving new orders
+	products = keeper.FilterPausedProducts(ctx, products)
 	keeper.GetDexKeeper().SortProducts(ctx, products) // sort products
 
 	// step1: calc best price and max execution for every active product, save latest price
@@ -431,3 +435,37 @@ func executeMatch(ctx sdk.Context, k keeper.Keeper, products []string,
 		}
 	}
 }
+
+// cancelImmediateOrders cancels the unfilled part of the immediate-or-cancel orders after matching,
+// the orders of the locked products are kept until the products are unlocked
+func cancelImmediateOrders(ctx sdk.Context, keeper keeper.Keeper) {
+	logger := ctx.Logger().With("module", "order")
+	for _, orderID := range keeper.GetImmediateOrderIDs(ctx) {
+		order := keeper.GetOrder(ctx, orderID)
+		if order != nil && order.Status == types.OrderStatusOpen {
+			if keeper.IsProductLocked(ctx, order.Product) {
+				continue
+			}
+			keeper.CancelOrder(ctx, order, logger)
+			logger.Info(fmt.Sprintf("immediate-or-cancel order (%s) cancelled", order.OrderID))
+		}
+		keeper.DropImmediateOrder(ctx, orderID)
+	}
+}
+
+// CancelFrozenOrders cancels the orders of the frozen accounts which are recorded while their products are locked,
+// after the products are unlocked
+func CancelFrozenOrders(ctx sdk.Context, keeper keeper.Keeper) {
+	logger := ctx.Logger().With("module", "order")
+	for _, orderID := range keeper.GetFrozenOrderIDs(ctx) {
+		order := keeper.GetOrder(ctx, orderID)
+		if order != nil && order.Status == types.OrderStatusOpen {
+			if keeper.IsProductLocked(ctx, order.Product) {
+				continue
+			}
+			keeper.CancelOrder(ctx, order, logger)
+			logger.Info(fmt.Sprintf("order (%s) of frozen account cancelled", order.OrderID))
+		}
+		keeper.DropFrozenOrder(ctx, orderID)
+	}
+}
@@ -443,7 +443,7 @@ func TestCleanupExpiredOrders(t *testing.T) {
 	keeper.SetLastClosedOrderIDs(ctx, []string{orders[0].OrderID})
 	keeper.ExpireOrder(ctx, orders[1], ctx.Logger())
 
-	cleanupExpiredOrders(ctx, keeper)
+	CleanupExpiredOrders(ctx, keeper)
 
 	expiredBlocks := keeper.GetExpireBlockHeight(ctx, ctx.BlockHeight()+
 		feeParams.OrderExpireBlocks)
@@ -870,7 +870,7 @@ func TestCleanupOrdersWhoseTokenPairHaveBeenDelisted(t *testing.T) {
 		depthBook.InsertOrder(orders[i])
 	}
 
-	cleanupOrdersWhoseTokenPairHaveBeenDelisted(ctx, keeper)
+	CleanupOrdersWhoseTokenPairHaveBeenDelisted(ctx, keeper)
 
 	depthBook = keeper.GetDepthBookCopy(types.TestTokenPair)
 	require.EqualValues(t, 0, len(depthBook.Items))
@@ -16,3 +16,13 @@ const (
 	BuyOrder            = "BUY"
 	SellOrder           = "SELL"
 )
+
+// nolint : order types, the order without type is a limit order
+const (
+	OrderTypeLimit      = "LIMIT"
+	OrderTypeIOC        = "IOC"         // immediate-or-cancel
+	OrderTypeFOK        = "FOK"         // fill-or-kill
+	OrderTypePostOnly   = "POST_ONLY"   // maker only
+	OrderTypeStop       = "STOP"        // triggered when the last price moves to the trigger price adversely
+	OrderTypeTakeProfit = "TAKE_PROFIT" // triggered when the last price moves to the trigger price favorably
+)
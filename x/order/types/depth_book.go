@@ -102,6 +102,33 @@ func (depthBook *DepthBook) RemoveOrder(order *Order) {
 	}
 }
 
+// BestCrossIndex : find the best price on the opposite side of the order, return its index if the price
+// crosses the price of the order, or -1 if no one crosses
+func (depthBook *DepthBook) BestCrossIndex(side string, price sdk.Dec) int {
+	if side == BuyOrder {
+		// items are sorted by price desc, the lowest sell price is at the rear
+		for i := len(depthBook.Items) - 1; i >= 0; i-- {
+			if depthBook.Items[i].SellQuantity.IsPositive() {
+				if depthBook.Items[i].Price.GT(price) {
+					return -1
+				}
+				return i
+			}
+		}
+		return -1
+	}
+
+	for i := 0; i < len(depthBook.Items); i++ {
+		if depthBook.Items[i].BuyQuantity.IsPositive() {
+			if depthBook.Items[i].Price.LT(price) {
+				return -1
+			}
+			return i
+		}
+	}
+	return -1
+}
+
 // Sub : subtract the buy or sell quantity
 func (depthBook *DepthBook) Sub(index int, num sdk.Dec, side string) {
 	if side == BuyOrder {
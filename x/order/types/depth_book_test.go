@@ -98,3 +98,20 @@ func TestCopy(t *testing.T) {
 	require.EqualValues(t, 1, len(depthBook.Items))
 	require.EqualValues(t, sdk.MustNewDecFromStr("0.5"), depthBook.Items[0].Price)
 }
+
+func TestBestCrossIndex(t *testing.T) {
+	depthBook := DepthBook{}
+	require.Equal(t, -1, depthBook.BestCrossIndex(BuyOrder, sdk.MustNewDecFromStr("10.0")))
+
+	depthBook.InsertOrder(MockOrder("", TestTokenPair, SellOrder, "10.2", "1.0"))
+	depthBook.InsertOrder(MockOrder("", TestTokenPair, SellOrder, "10.1", "1.0"))
+	depthBook.InsertOrder(MockOrder("", TestTokenPair, BuyOrder, "9.9", "1.0"))
+	depthBook.InsertOrder(MockOrder("", TestTokenPair, BuyOrder, "9.8", "1.0"))
+
+	require.Equal(t, -1, depthBook.BestCrossIndex(BuyOrder, sdk.MustNewDecFromStr("10.0")))
+	require.Equal(t, 1, depthBook.BestCrossIndex(BuyOrder, sdk.MustNewDecFromStr("10.1")))
+	require.Equal(t, 1, depthBook.BestCrossIndex(BuyOrder, sdk.MustNewDecFromStr("10.2")))
+	require.Equal(t, -1, depthBook.BestCrossIndex(SellOrder, sdk.MustNewDecFromStr("10.0")))
+	require.Equal(t, 2, depthBook.BestCrossIndex(SellOrder, sdk.MustNewDecFromStr("9.9")))
+	require.Equal(t, 2, depthBook.BestCrossIndex(SellOrder, sdk.MustNewDecFromStr("9.0")))
+}
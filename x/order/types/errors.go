@@ -38,6 +38,11 @@ const (
 	CodeNotOrderOwner                         uint32 = 63026
 	CodeProductIsEmpty                        uint32 = 63027
 	CodeAllOrderFailedToExecute               uint32 = 63028
+	CodeOrderItemTypeIsInvalid                uint32 = 63029
+	CodeOrderItemTriggerPriceIsInvalid        uint32 = 63030
+	CodeOrderTypeNotSupported                 uint32 = 63031
+	CodePostOnlyOrderWouldMatch               uint32 = 63032
+	CodeFOKOrderCannotBeFilled                uint32 = 63033
 )
 
 func ErrInvalidAddress(address string) sdk.EnvelopedErr {
@@ -152,3 +157,23 @@ func ErrNotOrderOwner(orderID string) sdk.EnvelopedErr {
 func ErrAllOrderFailedToExecute() sdk.EnvelopedErr {
 	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeAllOrderFailedToExecute, "all order items failed to execute")}
 }
+
+func ErrOrderItemTypeIsInvalid(orderType string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeOrderItemTypeIsInvalid, fmt.Sprintf("order item's type(%s) is invalid", orderType))}
+}
+
+func ErrOrderItemTriggerPriceIsInvalid() sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeOrderItemTriggerPriceIsInvalid, "order item's trigger price should be positive for trigger orders only")}
+}
+
+func ErrOrderTypeNotSupported(orderType string, auctionType string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeOrderTypeNotSupported, fmt.Sprintf("order type(%s) is not supported by %s", orderType, auctionType))}
+}
+
+func ErrPostOnlyOrderWouldMatch(product string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodePostOnlyOrderWouldMatch, fmt.Sprintf("post-only order would match the depth book of %s immediately", product))}
+}
+
+func ErrFOKOrderCannotBeFilled(product string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeFOKOrderCannotBeFilled, fmt.Sprintf("fill-or-kill order can't be fully filled by the depth book of %s", product))}
+}
@@ -1,7 +1,9 @@
 package types
 
 import (
+	"bytes"
 	"fmt"
+	"math/big"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 )
@@ -40,6 +42,9 @@ var (
 	PriceKey             = []byte{0x14}
 	ExpireBlockHeightKey = []byte{0x15}
 	OrderNumPerBlockKey  = []byte{0x16}
+	TriggerOrderKey      = []byte{0x21}
+	ImmediateOrderKey    = []byte{0x22}
+	FrozenOrderKey       = []byte{0x23}
 
 	// none iterator keys
 	RecentlyClosedOrderIDsKey = []byte{0x17}
@@ -68,6 +73,69 @@ func GetPriceKey(key string) []byte {
 	return append(PriceKey, []byte(key)...)
 }
 
+// the pending trigger orders are indexed by the product, the trigger direction and the trigger price,
+// so that only the ones crossed by the last price are visited:
+// TriggerOrderKey | product | 0x00 | direction | trigger price | orderID
+const (
+	triggerProductEnd byte = 0x00
+	triggerOnFall     byte = 0x00
+	triggerOnRise     byte = 0x01
+
+	triggerPriceLen = 32
+)
+
+// GetTriggerOrderKey gets the index key of the pending trigger order
+func GetTriggerOrderKey(order *Order) []byte {
+	key := GetTriggerOrderDirectionPrefix(order.Product, order.TriggersOnRise())
+	key = append(key, triggerPriceBytes(*order.TriggerPrice)...)
+	return append(key, []byte(order.OrderID)...)
+}
+
+// GetTriggerOrderProductPrefix gets the prefix of the pending trigger orders of the product
+func GetTriggerOrderProductPrefix(product string) []byte {
+	key := make([]byte, 0, len(TriggerOrderKey)+len(product)+2)
+	key = append(key, TriggerOrderKey...)
+	key = append(key, []byte(product)...)
+	return append(key, triggerProductEnd)
+}
+
+// GetTriggerOrderDirectionPrefix gets the prefix of the pending trigger orders of the product
+// which are triggered by the last price rising or falling to the trigger prices
+func GetTriggerOrderDirectionPrefix(product string, onRise bool) []byte {
+	direction := triggerOnFall
+	if onRise {
+		direction = triggerOnRise
+	}
+	return append(GetTriggerOrderProductPrefix(product), direction)
+}
+
+// SplitTriggerOrderKey splits the index key of the pending trigger order into the product, the trigger price
+// and the order id
+func SplitTriggerOrderKey(key []byte) (product string, triggerPrice sdk.Dec, orderID string) {
+	key = key[len(TriggerOrderKey):]
+	productLen := bytes.IndexByte(key, triggerProductEnd)
+	product = string(key[:productLen])
+	key = key[productLen+2:]
+	triggerPrice = sdk.NewDecFromBigIntWithPrec(new(big.Int).SetBytes(key[:triggerPriceLen]), sdk.Precision)
+	orderID = string(key[triggerPriceLen:])
+	return product, triggerPrice, orderID
+}
+
+// triggerPriceBytes encodes the positive trigger price in fixed length big endian to keep the keys in price order
+func triggerPriceBytes(price sdk.Dec) []byte {
+	return price.BigInt().FillBytes(make([]byte, triggerPriceLen))
+}
+
+// nolint
+func GetImmediateOrderKey(orderID string) []byte {
+	return append(ImmediateOrderKey, []byte(orderID)...)
+}
+
+// nolint
+func GetFrozenOrderKey(orderID string) []byte {
+	return append(FrozenOrderKey, []byte(orderID)...)
+}
+
 // nolint
 func GetOrderNumPerBlockKey(blockHeight int64) []byte {
 	return append(OrderNumPerBlockKey, sdk.Uint64ToBigEndian(uint64(blockHeight))...)
@@ -14,11 +14,14 @@ const (
 
 // nolint
 type MsgNewOrder struct {
-	Sender   sdk.AccAddress `json:"sender"`   // order maker address
-	Product  string         `json:"product"`  // product for trading pair in full name of the tokens
-	Side     string         `json:"side"`     // BUY/SELL
-	Price    sdk.Dec        `json:"price"`    // price of the order
-	Quantity sdk.Dec        `json:"quantity"` // quantity of the order
+	Sender   sdk.AccAddress `json:"sender"`         // order maker address
+	Product  string         `json:"product"`        // product for trading pair in full name of the tokens
+	Side     string         `json:"side"`           // BUY/SELL
+	Price    sdk.Dec        `json:"price"`          // price of the order
+	Quantity sdk.Dec        `json:"quantity"`       // quantity of the order
+	Type     string         `json:"type,omitempty"` // order type, see OrderTypeXXX
+	// trigger price of the stop and take-profit order, omitted to keep the sign bytes of the limit order unchanged
+	TriggerPrice *sdk.Dec `json:"trigger_price,omitempty"`
 }
 
 // NewMsgNewOrder is a constructor function for MsgNewOrder
@@ -62,10 +65,13 @@ type MsgNewOrders struct {
 
 // nolint
 type OrderItem struct {
-	Product  string  `json:"product"`  // product for trading pair in full name of the tokens
-	Side     string  `json:"side"`     // BUY/SELL
-	Price    sdk.Dec `json:"price"`    // price of the order
-	Quantity sdk.Dec `json:"quantity"` // quantity of the order
+	Product  string  `json:"product"`        // product for trading pair in full name of the tokens
+	Side     string  `json:"side"`           // BUY/SELL
+	Price    sdk.Dec `json:"price"`          // price of the order
+	Quantity sdk.Dec `json:"quantity"`       // quantity of the order
+	Type     string  `json:"type,omitempty"` // order type, LIMIT by default
+	// trigger price of the stop and take-profit order, omitted to keep the sign bytes of the limit order unchanged
+	TriggerPrice *sdk.Dec `json:"trigger_price,omitempty"`
 }
 
 // nolint
@@ -79,6 +85,32 @@ func NewOrderItem(product string, side string, price string,
 	}
 }
 
+// NewTriggerOrderItem creates an order item of the stop or take-profit order
+func NewTriggerOrderItem(product string, side string, price string,
+	quantity string, orderType string, triggerPrice string) OrderItem {
+	item := NewOrderItem(product, side, price, quantity)
+	item.Type = orderType
+	trigger := sdk.MustNewDecFromStr(triggerPrice)
+	item.TriggerPrice = &trigger
+	return item
+}
+
+func (item OrderItem) validateType() sdk.Error {
+	switch item.Type {
+	case "", OrderTypeLimit, OrderTypeIOC, OrderTypeFOK, OrderTypePostOnly:
+		if item.TriggerPrice != nil {
+			return ErrOrderItemTriggerPriceIsInvalid()
+		}
+	case OrderTypeStop, OrderTypeTakeProfit:
+		if item.TriggerPrice == nil || item.TriggerPrice.IsNil() || !item.TriggerPrice.IsPositive() {
+			return ErrOrderItemTriggerPriceIsInvalid()
+		}
+	default:
+		return ErrOrderItemTypeIsInvalid(item.Type)
+	}
+	return nil
+}
+
 // NewMsgNewOrders is a constructor function for MsgNewOrder
 func NewMsgNewOrders(sender sdk.AccAddress, orderItems []OrderItem) MsgNewOrders {
 	return MsgNewOrders{
@@ -121,6 +153,9 @@ func (msg MsgNewOrders) ValidateBasic() sdk.Error {
 		if !(item.Price.IsPositive() && item.Quantity.IsPositive()) {
 			return ErrOrderItemPriceOrQuantityIsNotPositive()
 		}
+		if err := item.validateType(); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"testing"
 
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/x/common"
 
 	"github.com/stretchr/testify/require"
@@ -315,3 +316,44 @@ func TestHasDuplicatedID(t *testing.T) {
 	result2 := hasDuplicatedID(ids2)
 	require.EqualValues(t, true, result2)
 }
+
+func TestMsgNewOrdersOrderType(t *testing.T) {
+	addr, err := hex.DecodeString("1212121212121212123412121212121212121234")
+	require.Nil(t, err)
+	product := "btc_" + common.NativeToken
+
+	validItems := []OrderItem{
+		NewOrderItem(product, BuyOrder, testPrice, testQuantity),
+		{Product: product, Side: BuyOrder, Price: sdk.MustNewDecFromStr(testPrice),
+			Quantity: sdk.MustNewDecFromStr(testQuantity), Type: OrderTypeIOC},
+		{Product: product, Side: SellOrder, Price: sdk.MustNewDecFromStr(testPrice),
+			Quantity: sdk.MustNewDecFromStr(testQuantity), Type: OrderTypePostOnly},
+		NewTriggerOrderItem(product, SellOrder, testPrice, testQuantity, OrderTypeStop, "0.09"),
+		NewTriggerOrderItem(product, SellOrder, testPrice, testQuantity, OrderTypeTakeProfit, "0.11"),
+	}
+	for _, item := range validItems {
+		require.Nil(t, NewMsgNewOrders(addr, []OrderItem{item}).ValidateBasic())
+	}
+
+	invalidType := NewOrderItem(product, BuyOrder, testPrice, testQuantity)
+	invalidType.Type = "MARKET"
+	limitWithTrigger := NewOrderItem(product, BuyOrder, testPrice, testQuantity)
+	triggerPrice := sdk.OneDec()
+	limitWithTrigger.TriggerPrice = &triggerPrice
+	invalidItems := []OrderItem{
+		invalidType,
+		limitWithTrigger,
+		{Product: product, Side: BuyOrder, Price: sdk.MustNewDecFromStr(testPrice),
+			Quantity: sdk.MustNewDecFromStr(testQuantity), Type: OrderTypeStop},
+		NewTriggerOrderItem(product, SellOrder, testPrice, testQuantity, OrderTypeTakeProfit, "0"),
+	}
+	for _, item := range invalidItems {
+		require.NotNil(t, NewMsgNewOrders(addr, []OrderItem{item}).ValidateBasic())
+	}
+
+	// the sign bytes of the limit order are unchanged
+	msg := NewMsgNewOrder(addr, product, BuyOrder, testPrice, testQuantity)
+	require.Equal(t, `{"type":"okexchain/order/MsgNew","value":{"order_items":[{"price":"0.100000000000000000",`+
+		`"product":"btc_okt","quantity":"1.000000000000000000","side":"BUY"}],`+
+		`"sender":"cosmos1zgfpyysjzgfpyy35zgfpyysjzgfpyy35endkk8"}}`, string(msg.GetSignBytes()))
+}
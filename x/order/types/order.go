@@ -78,6 +78,11 @@ type Order struct {
 	OrderExpireBlocks int64          `json:"order_expire_blocks"`
 	FeePerBlock       sdk.SysCoin    `json:"fee_per_block"`
 	ExtraInfo         string         `json:"extra_info"` // extra info of order in json format
+	// the fields of the order types are omitted from the encoding while unset, so that the limit orders
+	// keep the encoding they had before the Venus2 height
+	Type         string   `json:"type,omitempty"`          // order type, see OrderTypeXXX
+	TriggerPrice *sdk.Dec `json:"trigger_price,omitempty"` // trigger price of the stop and take-profit order
+	Triggered    bool     `json:"triggered,omitempty"`     // whether the trigger order has been activated
 }
 
 // nolint
@@ -182,6 +187,35 @@ func (order *Order) RecordOrderDealFee(fee sdk.SysCoins) {
 	order.setExtraInfoWithKeyValue(OrderExtraInfoKeyDealFee, newFee.String())
 }
 
+// IsTriggerOrder returns true if the order is a stop or take-profit order
+func (order *Order) IsTriggerOrder() bool {
+	return order.Type == OrderTypeStop || order.Type == OrderTypeTakeProfit
+}
+
+// IsPendingTrigger returns true if the order is a trigger order which hasn't been activated,
+// it isn't in the depth book until being triggered
+func (order *Order) IsPendingTrigger() bool {
+	return order.IsTriggerOrder() && !order.Triggered
+}
+
+// ShouldTrigger returns true if the last price crosses the trigger price of the order.
+// A buy stop order or a sell take-profit order is triggered when the last price rises to the trigger price,
+// and a sell stop order or a buy take-profit order is triggered when the last price falls to the trigger price.
+func (order *Order) ShouldTrigger(lastPrice sdk.Dec) bool {
+	if !order.IsPendingTrigger() || order.TriggerPrice == nil || !lastPrice.IsPositive() {
+		return false
+	}
+	if order.TriggersOnRise() {
+		return lastPrice.GTE(*order.TriggerPrice)
+	}
+	return lastPrice.LTE(*order.TriggerPrice)
+}
+
+// TriggersOnRise returns true if the trigger order is triggered by the last price rising to the trigger price
+func (order *Order) TriggersOnRise() bool {
+	return (order.Type == OrderTypeStop) == (order.Side == BuyOrder)
+}
+
 // nolint
 func (order *Order) Fill(price, fillAmount sdk.Dec) {
 	filledSum := order.FilledAvgPrice.Mul(order.Quantity.Sub(order.RemainQuantity))
@@ -0,0 +1,63 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+// legacyOrder is the encoding of Order before the Venus2 height
+type legacyOrder struct {
+	TxHash            string         `json:"txhash"`
+	OrderID           string         `json:"order_id"`
+	Sender            sdk.AccAddress `json:"sender"`
+	Product           string         `json:"product"`
+	Side              string         `json:"side"`
+	Price             sdk.Dec        `json:"price"`
+	Quantity          sdk.Dec        `json:"quantity"`
+	Status            int64          `json:"status"`
+	FilledAvgPrice    sdk.Dec        `json:"filled_avg_price"`
+	RemainQuantity    sdk.Dec        `json:"remain_quantity"`
+	RemainLocked      sdk.Dec        `json:"remain_locked"`
+	Timestamp         int64          `json:"timestamp"`
+	OrderExpireBlocks int64          `json:"order_expire_blocks"`
+	FeePerBlock       sdk.SysCoin    `json:"fee_per_block"`
+	ExtraInfo         string         `json:"extra_info"`
+}
+
+func TestLimitOrderEncoding(t *testing.T) {
+	order := MockOrder("ID0000000010-1", TestTokenPair, BuyOrder, "10.0", "1.0")
+	order.Sender = sdk.AccAddress([]byte("sender______________"))
+	legacy := legacyOrder{
+		OrderID:           order.OrderID,
+		Sender:            order.Sender,
+		Product:           order.Product,
+		Side:              order.Side,
+		Price:             order.Price,
+		Quantity:          order.Quantity,
+		Status:            order.Status,
+		FilledAvgPrice:    order.FilledAvgPrice,
+		RemainQuantity:    order.RemainQuantity,
+		RemainLocked:      order.RemainLocked,
+		OrderExpireBlocks: order.OrderExpireBlocks,
+		FeePerBlock:       order.FeePerBlock,
+	}
+
+	// the limit order keeps its encoding
+	bz := ModuleCdc.MustMarshalBinaryBare(order)
+	require.Equal(t, ModuleCdc.MustMarshalBinaryBare(legacy), bz)
+	var decoded Order
+	ModuleCdc.MustUnmarshalBinaryBare(bz, &decoded)
+	require.Equal(t, bz, ModuleCdc.MustMarshalBinaryBare(decoded))
+	require.Nil(t, decoded.TriggerPrice)
+
+	// the trigger order keeps its trigger fields
+	triggerPrice := sdk.MustNewDecFromStr("9.5")
+	order.Type = OrderTypeStop
+	order.TriggerPrice = &triggerPrice
+	ModuleCdc.MustUnmarshalBinaryBare(ModuleCdc.MustMarshalBinaryBare(order), &decoded)
+	require.Equal(t, OrderTypeStop, decoded.Type)
+	require.Equal(t, triggerPrice, *decoded.TriggerPrice)
+	require.True(t, decoded.IsPendingTrigger())
+}
@@ -168,3 +168,42 @@ func TestGetBlockHeightFromOrderID(t *testing.T) {
 	num = GetBlockHeightFromOrderID(orderID)
 	require.Equal(t, blockHeight, num)
 }
+
+func TestOrderShouldTrigger(t *testing.T) {
+	order := MockOrder("", TestTokenPair, BuyOrder, "10.0", "1.0")
+	require.False(t, order.IsTriggerOrder())
+	require.False(t, order.ShouldTrigger(sdk.MustNewDecFromStr("10.0")))
+
+	tests := []struct {
+		orderType string
+		side      string
+		lastPrice string
+		expected  bool
+	}{
+		{OrderTypeStop, BuyOrder, "9.9", false},
+		{OrderTypeStop, BuyOrder, "10.0", true},
+		{OrderTypeStop, SellOrder, "10.1", false},
+		{OrderTypeStop, SellOrder, "10.0", true},
+		{OrderTypeTakeProfit, BuyOrder, "10.1", false},
+		{OrderTypeTakeProfit, BuyOrder, "9.9", true},
+		{OrderTypeTakeProfit, SellOrder, "9.9", false},
+		{OrderTypeTakeProfit, SellOrder, "10.1", true},
+		{OrderTypeStop, BuyOrder, "0", false},
+	}
+	for _, test := range tests {
+		order := MockOrder("", TestTokenPair, test.side, "10.0", "1.0")
+		order.Type = test.orderType
+		triggerPrice := sdk.MustNewDecFromStr("10.0")
+		order.TriggerPrice = &triggerPrice
+		require.True(t, order.IsPendingTrigger())
+		require.Equal(t, test.expected, order.ShouldTrigger(sdk.MustNewDecFromStr(test.lastPrice)),
+			"%s %s at %s", test.orderType, test.side, test.lastPrice)
+	}
+
+	order.Type = OrderTypeStop
+	triggerPrice := sdk.MustNewDecFromStr("9.0")
+	order.TriggerPrice = &triggerPrice
+	order.Triggered = true
+	require.False(t, order.IsPendingTrigger())
+	require.False(t, order.ShouldTrigger(sdk.MustNewDecFromStr("10.0")))
+}
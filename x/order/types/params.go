@@ -48,7 +48,7 @@ type Params struct {
 
 // ParamKeyTable for auth module
 func ParamKeyTable() params.KeyTable {
-	return params.NewKeyTable().RegisterParamSet(&Params{})
+	return params.NewKeyTable().RegisterParamSet(&Params{}).RegisterParamSet(&AuctionTypeParamsSet{})
 }
 
 // TODO: to supplement the validate function for every pair of param
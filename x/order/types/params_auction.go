@@ -0,0 +1,53 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/okex/exchain/x/params"
+)
+
+// nolint
+const (
+	AuctionTypePeriodic   = "periodicauction"
+	AuctionTypeContinuous = "continuousauction"
+	DefaultAuctionType    = AuctionTypePeriodic
+)
+
+// KeyAuctionType is the parameter key of the match engine. It isn't a member of Params, so that
+// the chains without it in the param store still work with the default periodic auction.
+var KeyAuctionType = []byte("AuctionType")
+
+var (
+	_ params.ParamSet = &AuctionTypeParamsSet{}
+)
+
+// AuctionTypeParamsSet is the param set of the match engine selected by order params
+type AuctionTypeParamsSet struct {
+	AuctionType string `json:"auction_type"`
+}
+
+// ParamSetPairs implements the ParamSet interface
+func (p *AuctionTypeParamsSet) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		{Key: KeyAuctionType, Value: &p.AuctionType, ValidatorFn: validateAuctionType},
+	}
+}
+
+// ValidateAuctionType checks whether the auction type is a known match engine
+func ValidateAuctionType(auctionType string) error {
+	return validateAuctionType(auctionType)
+}
+
+func validateAuctionType(i interface{}) error {
+	v, ok := i.(string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	switch v {
+	case AuctionTypePeriodic, AuctionTypeContinuous:
+		return nil
+	default:
+		return fmt.Errorf("invalid auction type: %s", v)
+	}
+}
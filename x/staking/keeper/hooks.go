@@ -49,3 +49,24 @@ func (k Keeper) AfterValidatorDestroyed(ctx sdk.Context, consAddr sdk.ConsAddres
 		k.hooks.AfterValidatorDestroyed(ctx, consAddr, valAddr)
 	}
 }
+
+// BeforeDelegationCreated - call hook if registered
+func (k Keeper) BeforeDelegationCreated(ctx sdk.Context, delAddr sdk.AccAddress, valAddrs []sdk.ValAddress) {
+	if k.hooks != nil {
+		k.hooks.BeforeDelegationCreated(ctx, delAddr, valAddrs)
+	}
+}
+
+// BeforeDelegationSharesModified - call hook if registered
+func (k Keeper) BeforeDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddrs []sdk.ValAddress) {
+	if k.hooks != nil {
+		k.hooks.BeforeDelegationSharesModified(ctx, delAddr, valAddrs)
+	}
+}
+
+// AfterDelegationModified - call hook if registered
+func (k Keeper) AfterDelegationModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddrs []sdk.ValAddress) {
+	if k.hooks != nil {
+		k.hooks.AfterDelegationModified(ctx, delAddr, valAddrs)
+	}
+}
@@ -21,6 +21,9 @@ func (k Keeper) WithdrawMinSelfDelegation(ctx sdk.Context, delAddr sdk.AccAddres
 		return completionTime, types.ErrMoreMinSelfDelegation(validator.OperatorAddress.String())
 	}
 
+	// the shares of validator are to be modified
+	k.BeforeValidatorModified(ctx, validator.OperatorAddress)
+
 	// 2.unbond msd
 	k.bondedTokensToNotBonded(ctx, sdk.NewDecCoinFromDec(sdk.DefaultBondDenom, validator.MinSelfDelegation))
 	completionTime = ctx.BlockHeader().Time.Add(k.UnbondingTime(ctx))
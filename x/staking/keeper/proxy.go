@@ -69,11 +69,16 @@ func (k Keeper) UpdateShares(ctx sdk.Context, delAddr sdk.AccAddress, tokens sdk
 		return types.ErrNoDelegatorExisted(delAddr.String())
 	}
 
+	valAddrs := make([]sdk.ValAddress, lenVals)
 	for i := 0; i < lenVals; i++ {
 		if vals[i].MinSelfDelegation.IsZero() {
 			return types.ErrAddSharesToDismission(vals[i].OperatorAddress.String())
 		}
+		valAddrs[i] = vals[i].OperatorAddress
+	}
 
+	k.BeforeDelegationSharesModified(ctx, delAddr, valAddrs)
+	for i := 0; i < lenVals; i++ {
 		// 1.delete related store
 		k.DeleteValidatorByPowerIndex(ctx, vals[i])
 
@@ -89,6 +94,7 @@ func (k Keeper) UpdateShares(ctx sdk.Context, delAddr sdk.AccAddress, tokens sdk
 	// update the delegator struct
 	delegator.Shares = shares
 	k.SetDelegator(ctx, delegator)
+	k.AfterDelegationModified(ctx, delAddr, valAddrs)
 
 	return nil
 }
@@ -101,9 +107,16 @@ func (k Keeper) AddSharesToValidators(ctx sdk.Context, delAddr sdk.AccAddress, v
 	if sdkErr != nil {
 		return
 	}
+	valAddrs := make([]sdk.ValAddress, lenVals)
+	for i := 0; i < lenVals; i++ {
+		valAddrs[i] = vals[i].OperatorAddress
+	}
+
+	k.BeforeDelegationCreated(ctx, delAddr, valAddrs)
 	for i := 0; i < lenVals; i++ {
 		k.addShares(ctx, delAddr, vals[i], shares)
 	}
+	k.AfterDelegationModified(ctx, delAddr, valAddrs)
 	return
 }
 
@@ -111,6 +124,12 @@ func (k Keeper) AddSharesToValidators(ctx sdk.Context, delAddr sdk.AccAddress, v
 func (k Keeper) WithdrawLastShares(ctx sdk.Context, delAddr sdk.AccAddress, lastValsAddedSharesTo types.Validators,
 	lastShares types.Shares) {
 	lenLastVals := len(lastValsAddedSharesTo)
+	valAddrs := make([]sdk.ValAddress, lenLastVals)
+	for i := 0; i < lenLastVals; i++ {
+		valAddrs[i] = lastValsAddedSharesTo[i].OperatorAddress
+	}
+
+	k.BeforeDelegationSharesModified(ctx, delAddr, valAddrs)
 	for i := 0; i < lenLastVals; i++ {
 		k.withdrawShares(ctx, delAddr, lastValsAddedSharesTo[i], lastShares)
 	}
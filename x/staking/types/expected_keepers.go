@@ -86,4 +86,10 @@ type StakingHooks interface {
 	// required by okexchain
 	// Must be called when a validator is destroyed by tx
 	AfterValidatorDestroyed(ctx sdk.Context, consAddr sdk.ConsAddress, valAddr sdk.ValAddress)
+	// Must be called when a delegator adds shares to validators for the first time
+	BeforeDelegationCreated(ctx sdk.Context, delAddr sdk.AccAddress, valAddrs []sdk.ValAddress)
+	// Must be called when the shares of a delegator on validators are to be modified
+	BeforeDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddrs []sdk.ValAddress)
+	// Must be called when the shares of a delegator on validators were modified
+	AfterDelegationModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddrs []sdk.ValAddress)
 }
@@ -55,3 +55,25 @@ func (h MultiStakingHooks) AfterValidatorDestroyed(ctx sdk.Context, consAddr sdk
 		h[i].AfterValidatorDestroyed(ctx, consAddr, valAddr)
 	}
 }
+
+// BeforeDelegationCreated handles the hooks before the delegation created
+func (h MultiStakingHooks) BeforeDelegationCreated(ctx sdk.Context, delAddr sdk.AccAddress, valAddrs []sdk.ValAddress) {
+	for i := range h {
+		h[i].BeforeDelegationCreated(ctx, delAddr, valAddrs)
+	}
+}
+
+// BeforeDelegationSharesModified handles the hooks before the delegation shares modified
+func (h MultiStakingHooks) BeforeDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddress,
+	valAddrs []sdk.ValAddress) {
+	for i := range h {
+		h[i].BeforeDelegationSharesModified(ctx, delAddr, valAddrs)
+	}
+}
+
+// AfterDelegationModified handles the hooks after the delegation modified
+func (h MultiStakingHooks) AfterDelegationModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddrs []sdk.ValAddress) {
+	for i := range h {
+		h[i].AfterDelegationModified(ctx, delAddr, valAddrs)
+	}
+}
@@ -34,9 +34,10 @@ type (
 	// CoinInfo coin info for query token
 	CoinInfo = types.CoinInfo
 	// nolint
-	FeeDetail = types.FeeDetail
-	CoinsInfo = types.CoinsInfo
-	Token     = types.Token
+	FeeDetail  = types.FeeDetail
+	CoinsInfo  = types.CoinsInfo
+	Token      = types.Token
+	TokenHooks = types.TokenHooks
 )
 
 var (
@@ -8,6 +8,7 @@ import (
 	"github.com/okex/exchain/libs/cosmos-sdk/client"
 	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
 	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
 	"github.com/okex/exchain/x/token/types"
 	"github.com/spf13/cobra"
@@ -26,6 +27,7 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	queryCmd.AddCommand(flags.GetCommands(
 		getCmdQueryParams(queryRoute, cdc),
 		getCmdTokenInfo(queryRoute, cdc),
+		getCmdFrozenAccounts(queryRoute, cdc),
 		//getAccountCmd(queryRoute, cdc),
 	)...)
 
@@ -79,6 +81,32 @@ func getCmdTokenInfo(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+// getCmdFrozenAccounts queries the frozen accounts of the token
+func getCmdFrozenAccounts(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "frozen [symbol]",
+		Short: "query the frozen accounts of the token",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			route := fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryFrozen, args[0])
+			bz, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var addrs []sdk.AccAddress
+			cdc.MustUnmarshalJSON(bz, &addrs)
+			frozen := make(Strings, len(addrs))
+			for i, addr := range addrs {
+				frozen[i] = addr.String()
+			}
+			return cliCtx.PrintOutput(frozen)
+		},
+	}
+}
+
 // getCmdQueryParams implements the query params command.
 func getCmdQueryParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
@@ -29,6 +29,8 @@ const (
 	WholeName     = "whole-name"
 	TokenDesc     = "desc"
 	Mintable      = "mintable"
+	Freezable     = "freezable"
+	Pausable      = "pausable"
 	Transfers     = "transfers"
 	TransfersFile = "transfers-file"
 )
@@ -48,7 +50,7 @@ var (
 	errTransfersNotValid      = errors.New("transfers not valid")
 	errTransfersFileNotValid  = errors.New("transfers file not valid")
 	errSign                   = errors.New("sign not succeed")
-	errParam                  = errors.New("can't get token desc or whole name")
+	errParam                  = errors.New("can't get token desc, whole name, freezable or pausable")
 )
 
 // GetTxCmd returns the transaction commands for this module
@@ -69,6 +71,10 @@ func GetTxCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 		getCmdTransferOwnership(cdc),
 		getCmdConfirmOwnership(cdc),
 		getCmdTokenEdit(cdc),
+		getCmdTokenFreeze(cdc),
+		getCmdTokenUnfreeze(cdc),
+		getCmdTokenPause(cdc),
+		getCmdTokenUnpause(cdc),
 	)...)
 
 	return distTxCmd
@@ -127,10 +133,21 @@ func getCmdTokenIssue(cdc *codec.Codec) *cobra.Command {
 				return errMintableNotValid
 			}
 
+			freezable, err := flags.GetBool(Freezable)
+			if err != nil {
+				return err
+			}
+			pausable, err := flags.GetBool(Pausable)
+			if err != nil {
+				return err
+			}
+
 			var symbol string
 
 			// totalSupply int64 ,coins bigint
 			msg := types.NewMsgTokenIssue(tokenDesc, symbol, originalSymbol, wholeName, totalSupply, cliCtx.FromAddress, mintable)
+			msg.Freezable = freezable
+			msg.Pausable = pausable
 
 			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
 		},
@@ -141,6 +158,8 @@ func getCmdTokenIssue(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().String(TokenDesc, "", "describe of the token")
 	cmd.Flags().StringP(TotalSupply, "n", "0", "total supply of the new token")
 	cmd.Flags().Bool(Mintable, false, "whether the token can be minted")
+	cmd.Flags().Bool(Freezable, false, "whether the owner can freeze the accounts of the token")
+	cmd.Flags().Bool(Pausable, false, "whether the owner can pause the transfers of the token")
 
 	return cmd
 }
@@ -412,17 +431,34 @@ func getCmdTokenEdit(cdc *codec.Codec) *cobra.Command {
 					return errTokenWholeNameNotValid
 				}
 			}
-			if !isWholeNameEdit && !isDescEdit {
+			var isFreezableEdit, isPausableEdit, freezable, pausable bool
+			if fzEditFlag := flags.Lookup(Freezable); fzEditFlag != nil && fzEditFlag.Changed {
+				isFreezableEdit = true
+				if freezable, err = flags.GetBool(Freezable); err != nil {
+					return err
+				}
+			}
+			if psEditFlag := flags.Lookup(Pausable); psEditFlag != nil && psEditFlag.Changed {
+				isPausableEdit = true
+				if pausable, err = flags.GetBool(Pausable); err != nil {
+					return err
+				}
+			}
+			if !isWholeNameEdit && !isDescEdit && !isFreezableEdit && !isPausableEdit {
 				return errParam
 			}
 
 			msg := types.NewMsgTokenModify(symbol, tokenDesc, wholeName, isDescEdit, isWholeNameEdit, cliCtx.FromAddress)
+			msg.Freezable, msg.IsFreezableModified = freezable, isFreezableEdit
+			msg.Pausable, msg.IsPausableModified = pausable, isPausableEdit
 			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
 		},
 	}
 	cmd.Flags().StringP(Symbol, "s", "", "symbol of the token")
 	cmd.Flags().StringP(WholeName, "w", "", "whole name of the token")
 	cmd.Flags().String(TokenDesc, "", "description of the token")
+	cmd.Flags().Bool(Freezable, false, "whether the owner can freeze the accounts of the token")
+	cmd.Flags().Bool(Pausable, false, "whether the owner can pause the transfers of the token")
 
 	return cmd
 }
@@ -460,3 +496,85 @@ func getCmdConfirmOwnership(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().StringP("symbol", "s", "", "symbol of the token to be transferred")
 	return cmd
 }
+
+// getCmdTokenFreeze is the CLI command for sending a FreezeToken transaction
+func getCmdTokenFreeze(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "freeze [symbol] [address]",
+		Short: "freeze the transfers of the token from and to an account",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			addr, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgTokenFreeze(args[0], addr, cliCtx.GetFromAddress())
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+	return cmd
+}
+
+// getCmdTokenUnfreeze is the CLI command for sending an UnfreezeToken transaction
+func getCmdTokenUnfreeze(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unfreeze [symbol] [address]",
+		Short: "unfreeze a frozen account of the token",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			addr, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgTokenUnfreeze(args[0], addr, cliCtx.GetFromAddress())
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+	return cmd
+}
+
+// getCmdTokenPause is the CLI command for sending a PauseToken transaction
+func getCmdTokenPause(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pause [symbol]",
+		Short: "pause all the transfers of the token",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			msg := types.NewMsgTokenPause(args[0], cliCtx.GetFromAddress())
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+	return cmd
+}
+
+// getCmdTokenUnpause is the CLI command for sending an UnpauseToken transaction
+func getCmdTokenUnpause(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unpause [symbol]",
+		Short: "resume the transfers of the paused token",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			msg := types.NewMsgTokenUnpause(args[0], cliCtx.GetFromAddress())
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+	return cmd
+}
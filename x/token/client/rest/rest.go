@@ -22,6 +22,7 @@ import (
 func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, storeName string) {
 	r.HandleFunc(fmt.Sprintf("/token/{symbol}"), tokenHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/tokens"), tokensHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/token/{symbol}/frozen"), frozenAccountsHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/currency/describe"), currencyDescribeHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/accounts/{address}"), spotAccountsHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/upload"), uploadAccountsHandler(cliCtx, storeName)).Methods("GET")
@@ -49,6 +50,28 @@ func tokenHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc
 	}
 }
 
+func frozenAccountsHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		symbol := mux.Vars(r)["symbol"]
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", storeName, types.QueryFrozen, symbol), nil)
+		if err != nil {
+			sdkErr := common.ParseSDKError(err.Error())
+			common.HandleErrorMsg(w, cliCtx, sdkErr.Code, err.Error())
+			return
+		}
+
+		result := common.GetBaseResponse("hello")
+		result2, err2 := json.Marshal(result)
+		if err2 != nil {
+			common.HandleErrorMsg(w, cliCtx, common.CodeMarshalJSONFailed, err2.Error())
+			return
+		}
+		result2 = []byte(strings.Replace(string(result2), "\"hello\"", string(res), 1))
+		rest.PostProcessResponse(w, cliCtx, result2)
+	}
+}
+
 func tokensHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ownerAddress := r.URL.Query().Get("address")
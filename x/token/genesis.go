@@ -18,6 +18,9 @@ type GenesisState struct {
 	Tokens       []types.Token    `json:"tokens"`
 	LockedAssets []types.AccCoins `json:"locked_assets"`
 	LockedFees   []types.AccCoins `json:"locked_fees"`
+
+	PausedTokens   []string              `json:"paused_tokens,omitempty"`
+	FrozenAccounts []types.FrozenAccount `json:"frozen_accounts,omitempty"`
 }
 
 // default GenesisState used by Cosmos Hub
@@ -64,6 +67,19 @@ func validateGenesis(data GenesisState) error {
 			return errors.New(err.Error())
 		}
 	}
+	for _, symbol := range data.PausedTokens {
+		if err := sdk.ValidateDenom(symbol); err != nil {
+			return fmt.Errorf("invalid paused token %s: %s", symbol, err)
+		}
+	}
+	for _, frozen := range data.FrozenAccounts {
+		if err := sdk.ValidateDenom(frozen.Symbol); err != nil {
+			return fmt.Errorf("invalid symbol of frozen account %s: %s", frozen.Address, err)
+		}
+		if frozen.Address.Empty() {
+			return fmt.Errorf("empty frozen account of token %s", frozen.Symbol)
+		}
+	}
 	return nil
 }
 
@@ -93,6 +109,13 @@ func initGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
 			panic(err)
 		}
 	}
+
+	for _, symbol := range data.PausedTokens {
+		keeper.SetTokenPaused(ctx, symbol, true)
+	}
+	for _, frozen := range data.FrozenAccounts {
+		keeper.SetAccountFrozen(ctx, frozen.Symbol, frozen.Address, true)
+	}
 }
 
 // ExportGenesis writes the current store values
@@ -113,10 +136,18 @@ func ExportGenesis(ctx sdk.Context, keeper Keeper) (data GenesisState) {
 		return false
 	})
 
+	var frozenAccounts []types.FrozenAccount
+	keeper.IterateFrozenAccounts(ctx, func(frozen types.FrozenAccount) bool {
+		frozenAccounts = append(frozenAccounts, frozen)
+		return false
+	})
+
 	return GenesisState{
-		Params:       params,
-		Tokens:       tokens,
-		LockedAssets: lockedAsset,
-		LockedFees:   lockedFees,
+		Params:         params,
+		Tokens:         tokens,
+		LockedAssets:   lockedAsset,
+		LockedFees:     lockedFees,
+		PausedTokens:   keeper.GetPausedTokens(ctx),
+		FrozenAccounts: frozenAccounts,
 	}
 }
@@ -7,6 +7,7 @@ import (
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/libs/tendermint/libs/log"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/okex/exchain/x/common/perf"
 	"github.com/okex/exchain/x/common/version"
 	"github.com/okex/exchain/x/token/types"
@@ -68,6 +69,30 @@ func NewTokenHandler(keeper Keeper, protocolVersion version.ProtocolVersionType)
 			handlerFun = func() (*sdk.Result, error) {
 				return handleMsgTokenModify(ctx, keeper, msg, logger)
 			}
+
+		case types.MsgTokenFreeze:
+			name = "handleMsgTokenFreeze"
+			handlerFun = func() (*sdk.Result, error) {
+				return handleMsgTokenFreeze(ctx, keeper, msg, logger)
+			}
+
+		case types.MsgTokenUnfreeze:
+			name = "handleMsgTokenUnfreeze"
+			handlerFun = func() (*sdk.Result, error) {
+				return handleMsgTokenUnfreeze(ctx, keeper, msg, logger)
+			}
+
+		case types.MsgTokenPause:
+			name = "handleMsgTokenPause"
+			handlerFun = func() (*sdk.Result, error) {
+				return handleMsgTokenPause(ctx, keeper, msg, logger)
+			}
+
+		case types.MsgTokenUnpause:
+			name = "handleMsgTokenUnpause"
+			handlerFun = func() (*sdk.Result, error) {
+				return handleMsgTokenUnpause(ctx, keeper, msg, logger)
+			}
 		default:
 			errMsg := fmt.Sprintf("Unrecognized token Msg type: %v", msg.Type())
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -91,6 +116,9 @@ func handleMsgTokenIssue(ctx sdk.Context, keeper Keeper, msg types.MsgTokenIssue
 	if totalSupply.GT(sdk.NewDec(types.TotalSupplyUpperbound)) {
 		return types.ErrAmountBiggerThanTotalSupplyUpperbound().Result()
 	}
+	if (msg.Freezable || msg.Pausable) && !tmtypes.HigherThanVenus2(ctx.BlockHeight()) {
+		return types.ErrFreezeNotEnabled().Result()
+	}
 
 	token := types.Token{
 		Description:         msg.Description,
@@ -99,6 +127,8 @@ func handleMsgTokenIssue(ctx sdk.Context, keeper Keeper, msg types.MsgTokenIssue
 		OriginalTotalSupply: totalSupply,
 		Owner:               msg.Owner,
 		Mintable:            msg.Mintable,
+		Freezable:           msg.Freezable,
+		Pausable:            msg.Pausable,
 	}
 
 	// generate a random symbol
@@ -417,9 +447,13 @@ func handleMsgTokenModify(ctx sdk.Context, keeper Keeper, msg types.MsgTokenModi
 	if !token.Owner.Equals(msg.Owner) {
 		return types.ErrInputOwnerIsNotEqualTokenOwner(msg.Owner).Result()
 	}
-	if !msg.IsWholeNameModified && !msg.IsDescriptionModified {
+	if !msg.IsWholeNameModified && !msg.IsDescriptionModified &&
+		!msg.IsFreezableModified && !msg.IsPausableModified {
 		return types.ErrWholeNameAndDescriptionIsNotModified().Result()
 	}
+	if (msg.IsFreezableModified || msg.IsPausableModified) && !tmtypes.HigherThanVenus2(ctx.BlockHeight()) {
+		return types.ErrFreezeNotEnabled().Result()
+	}
 	// modify
 	if msg.IsWholeNameModified {
 		token.WholeName = msg.WholeName
@@ -427,6 +461,14 @@ func handleMsgTokenModify(ctx sdk.Context, keeper Keeper, msg types.MsgTokenModi
 	if msg.IsDescriptionModified {
 		token.Description = msg.Description
 	}
+	// the frozen accounts and the paused state are kept after disabling the flags,
+	// and they can still be unfrozen and unpaused by the owner
+	if msg.IsFreezableModified {
+		token.Freezable = msg.Freezable
+	}
+	if msg.IsPausableModified {
+		token.Pausable = msg.Pausable
+	}
 
 	keeper.UpdateToken(ctx, token)
 
@@ -456,3 +498,159 @@ func handleMsgTokenModify(ctx sdk.Context, keeper Keeper, msg types.MsgTokenModi
 	)
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
+
+func handleMsgTokenFreeze(ctx sdk.Context, keeper Keeper, msg types.MsgTokenFreeze, logger log.Logger) (*sdk.Result, error) {
+	if !tmtypes.HigherThanVenus2(ctx.BlockHeight()) {
+		return types.ErrFreezeNotEnabled().Result()
+	}
+
+	token := keeper.GetTokenInfo(ctx, msg.Symbol)
+	// check owner
+	if !token.Owner.Equals(msg.Owner) {
+		return types.ErrInputOwnerIsNotEqualTokenOwner(msg.Owner).Result()
+	}
+	if !token.Freezable {
+		return types.ErrTokenIsNotFreezable(msg.Symbol).Result()
+	}
+	if msg.Address.Equals(token.Owner) {
+		return types.ErrFreezeTokenOwner(msg.Symbol).Result()
+	}
+	if keeper.IsAccountFrozen(ctx, msg.Symbol, msg.Address) {
+		return types.ErrAccountFrozen(msg.Symbol, msg.Address).Result()
+	}
+
+	keeper.SetAccountFrozen(ctx, msg.Symbol, msg.Address, true)
+	keeper.AfterAccountFrozen(ctx, msg.Symbol, msg.Address)
+
+	name := "handleMsgTokenFreeze"
+	if logger != nil {
+		logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
+			"                           msg<Owner:%s,Symbol:%s,Address:%s>\n"+
+			"                           result<Owner froze the account of %s>\n",
+			ctx.BlockHeight(), name,
+			msg.Owner, msg.Symbol, msg.Address,
+			msg.Symbol))
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute("symbol", msg.Symbol),
+			sdk.NewAttribute("address", msg.Address.String()),
+		),
+	)
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgTokenUnfreeze(ctx sdk.Context, keeper Keeper, msg types.MsgTokenUnfreeze, logger log.Logger) (*sdk.Result, error) {
+	if !tmtypes.HigherThanVenus2(ctx.BlockHeight()) {
+		return types.ErrFreezeNotEnabled().Result()
+	}
+
+	token := keeper.GetTokenInfo(ctx, msg.Symbol)
+	// check owner
+	if !token.Owner.Equals(msg.Owner) {
+		return types.ErrInputOwnerIsNotEqualTokenOwner(msg.Owner).Result()
+	}
+	if !keeper.IsAccountFrozen(ctx, msg.Symbol, msg.Address) {
+		return types.ErrAccountNotFrozen(msg.Symbol, msg.Address).Result()
+	}
+
+	keeper.SetAccountFrozen(ctx, msg.Symbol, msg.Address, false)
+
+	name := "handleMsgTokenUnfreeze"
+	if logger != nil {
+		logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
+			"                           msg<Owner:%s,Symbol:%s,Address:%s>\n"+
+			"                           result<Owner unfroze the account of %s>\n",
+			ctx.BlockHeight(), name,
+			msg.Owner, msg.Symbol, msg.Address,
+			msg.Symbol))
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute("symbol", msg.Symbol),
+			sdk.NewAttribute("address", msg.Address.String()),
+		),
+	)
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgTokenPause(ctx sdk.Context, keeper Keeper, msg types.MsgTokenPause, logger log.Logger) (*sdk.Result, error) {
+	if !tmtypes.HigherThanVenus2(ctx.BlockHeight()) {
+		return types.ErrFreezeNotEnabled().Result()
+	}
+
+	token := keeper.GetTokenInfo(ctx, msg.Symbol)
+	// check owner
+	if !token.Owner.Equals(msg.Owner) {
+		return types.ErrInputOwnerIsNotEqualTokenOwner(msg.Owner).Result()
+	}
+	if !token.Pausable {
+		return types.ErrTokenIsNotPausable(msg.Symbol).Result()
+	}
+	if keeper.IsTokenPaused(ctx, msg.Symbol) {
+		return types.ErrTokenPaused(msg.Symbol).Result()
+	}
+
+	keeper.SetTokenPaused(ctx, msg.Symbol, true)
+
+	name := "handleMsgTokenPause"
+	if logger != nil {
+		logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
+			"                           msg<Owner:%s,Symbol:%s>\n"+
+			"                           result<Owner paused %s>\n",
+			ctx.BlockHeight(), name,
+			msg.Owner, msg.Symbol,
+			msg.Symbol))
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute("symbol", msg.Symbol),
+		),
+	)
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgTokenUnpause(ctx sdk.Context, keeper Keeper, msg types.MsgTokenUnpause, logger log.Logger) (*sdk.Result, error) {
+	if !tmtypes.HigherThanVenus2(ctx.BlockHeight()) {
+		return types.ErrFreezeNotEnabled().Result()
+	}
+
+	token := keeper.GetTokenInfo(ctx, msg.Symbol)
+	// check owner
+	if !token.Owner.Equals(msg.Owner) {
+		return types.ErrInputOwnerIsNotEqualTokenOwner(msg.Owner).Result()
+	}
+	if !keeper.IsTokenPaused(ctx, msg.Symbol) {
+		return types.ErrTokenNotPaused(msg.Symbol).Result()
+	}
+
+	keeper.SetTokenPaused(ctx, msg.Symbol, false)
+
+	name := "handleMsgTokenUnpause"
+	if logger != nil {
+		logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
+			"                           msg<Owner:%s,Symbol:%s>\n"+
+			"                           result<Owner unpaused %s>\n",
+			ctx.BlockHeight(), name,
+			msg.Owner, msg.Symbol,
+			msg.Symbol))
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute("symbol", msg.Symbol),
+		),
+	)
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
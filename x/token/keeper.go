@@ -36,6 +36,8 @@ type Keeper struct {
 	// cache data in memory to avoid marshal/unmarshal too frequently
 	// reset cache data in BeginBlock
 	cache *Cache
+
+	hooks types.TokenHooks
 }
 
 // NewKeeper creates a new token keeper
@@ -58,6 +60,15 @@ func NewKeeper(bankKeeper bank.Keeper, paramSpace params.Subspace,
 	return k
 }
 
+// SetHooks sets the token hooks
+func (k *Keeper) SetHooks(hooks types.TokenHooks) *Keeper {
+	if k.hooks != nil {
+		panic("cannot set token hooks twice")
+	}
+	k.hooks = hooks
+	return k
+}
+
 // nolint
 func (k Keeper) ResetCache(ctx sdk.Context) {
 	k.cache.reset()
@@ -187,6 +198,10 @@ func (k Keeper) SendCoinsFromAccountToAccount(ctx sdk.Context, from, to sdk.AccA
 		return types.ErrBlockedContractRecipient(to.String())
 	}
 
+	if err := k.CheckTransferable(ctx, amt, from, to); err != nil {
+		return err
+	}
+
 	return k.bankKeeper.SendCoins(ctx, from, to, amt)
 }
 
@@ -0,0 +1,107 @@
+package token
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/token/types"
+)
+
+// IsTokenPaused checks whether the transfers of the token are paused by its owner
+func (k Keeper) IsTokenPaused(ctx sdk.Context, symbol string) bool {
+	return ctx.KVStore(k.tokenStoreKey).Has(types.GetPausedTokenKey(symbol))
+}
+
+// SetTokenPaused pauses or resumes the transfers of the token
+func (k Keeper) SetTokenPaused(ctx sdk.Context, symbol string, paused bool) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	if paused {
+		store.Set(types.GetPausedTokenKey(symbol), []byte{})
+	} else {
+		store.Delete(types.GetPausedTokenKey(symbol))
+	}
+}
+
+// GetPausedTokens gets the symbols of all the paused tokens
+func (k Keeper) GetPausedTokens(ctx sdk.Context) (symbols []string) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.PrefixPausedTokenKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		symbols = append(symbols, string(iter.Key()[len(types.PrefixPausedTokenKey):]))
+	}
+	return symbols
+}
+
+// IsAccountFrozen checks whether the account is frozen for the token by the token owner
+func (k Keeper) IsAccountFrozen(ctx sdk.Context, symbol string, addr sdk.AccAddress) bool {
+	return ctx.KVStore(k.tokenStoreKey).Has(types.GetFrozenAccountKey(symbol, addr))
+}
+
+// SetAccountFrozen freezes or unfreezes the account for the token
+func (k Keeper) SetAccountFrozen(ctx sdk.Context, symbol string, addr sdk.AccAddress, frozen bool) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	if frozen {
+		store.Set(types.GetFrozenAccountKey(symbol, addr), []byte{})
+	} else {
+		store.Delete(types.GetFrozenAccountKey(symbol, addr))
+	}
+}
+
+// AfterAccountFrozen calls the hooks after the account is frozen for the token
+func (k Keeper) AfterAccountFrozen(ctx sdk.Context, symbol string, addr sdk.AccAddress) {
+	if k.hooks != nil {
+		k.hooks.AfterAccountFrozen(ctx, symbol, addr)
+	}
+}
+
+// GetFrozenAccounts gets all the frozen accounts of the token
+func (k Keeper) GetFrozenAccounts(ctx sdk.Context, symbol string) (addrs []sdk.AccAddress) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.GetFrozenAccountsPrefix(symbol))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		_, addr := types.SplitFrozenAccountKey(iter.Key())
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+// IterateFrozenAccounts iterates over the frozen accounts of all the tokens and performs a callback function
+func (k Keeper) IterateFrozenAccounts(ctx sdk.Context, cb func(frozen types.FrozenAccount) (stop bool)) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.PrefixFrozenAccountKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if cb(types.NewFrozenAccount(types.SplitFrozenAccountKey(iter.Key()))) {
+			break
+		}
+	}
+}
+
+// CheckTransferable checks whether the coins can be transferred between the accounts,
+// it fails if any of the tokens is paused or any of the accounts is frozen for the token
+func (k Keeper) CheckTransferable(ctx sdk.Context, coins sdk.SysCoins, addrs ...sdk.AccAddress) error {
+	for _, coin := range coins {
+		if err := k.CheckSymbolTransferable(ctx, coin.Denom, addrs...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckSymbolTransferable checks whether the token is paused or any of the accounts is frozen for it.
+// The tokens can't be frozen or paused before the Venus2 height, so nothing is checked until then.
+func (k Keeper) CheckSymbolTransferable(ctx sdk.Context, symbol string, addrs ...sdk.AccAddress) error {
+	if !tmtypes.HigherThanVenus2(ctx.BlockHeight()) {
+		return nil
+	}
+	if k.IsTokenPaused(ctx, symbol) {
+		return types.ErrTokenPaused(symbol)
+	}
+	for _, addr := range addrs {
+		if k.IsAccountFrozen(ctx, symbol, addr) {
+			return types.ErrAccountFrozen(symbol, addr)
+		}
+	}
+	return nil
+}
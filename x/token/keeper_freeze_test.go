@@ -0,0 +1,157 @@
+package token
+
+import (
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/bank"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/common"
+	"github.com/okex/exchain/x/common/version"
+	"github.com/okex/exchain/x/token/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleFreezeAndPause(t *testing.T) {
+	mapp, keeper, addrs := getMockDexApp(t, 3)
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Height: 2})
+	keeper.SetParams(ctx, types.DefaultParams())
+	keeper.bankKeeper.SetSendEnabled(ctx, true)
+	handler := NewTokenHandler(keeper, version.CurrentProtocolVersion)
+	bankKeeper := keeper.bankKeeper.(bank.BaseKeeper)
+	bankKeeper.SetSendCoinsChecker(keeper.CheckTransferable)
+	bankHandler := bank.NewHandler(bankKeeper)
+
+	symbol := common.TestToken
+	owner, addr1, addr2 := addrs[0], addrs[1], addrs[2]
+	keeper.NewToken(ctx, InitTestTokenWithOwner(symbol, owner))
+	amount := sdk.SysCoins{sdk.NewDecCoinFromDec(symbol, sdk.NewDec(1))}
+
+	// freeze and pause are not enabled before the Venus2 height
+	_, err := handler(ctx, types.NewMsgTokenFreeze(symbol, addr1, owner))
+	require.Equal(t, types.ErrFreezeNotEnabled().Error(), err.Error())
+	_, err = handler(ctx, types.NewMsgTokenPause(symbol, owner))
+	require.Equal(t, types.ErrFreezeNotEnabled().Error(), err.Error())
+	modifyMsg := types.NewMsgTokenModify(symbol, "", "", false, false, owner)
+	modifyMsg.Freezable, modifyMsg.IsFreezableModified = true, true
+	_, err = handler(ctx, modifyMsg)
+	require.Equal(t, types.ErrFreezeNotEnabled().Error(), err.Error())
+	keeper.SetAccountFrozen(ctx, symbol, addr1, true)
+	_, err = bankHandler(ctx, bank.NewMsgSend(owner, addr1, amount))
+	require.Nil(t, err)
+	keeper.SetAccountFrozen(ctx, symbol, addr1, false)
+
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(2)
+	defer tmtypes.UnittestOnlySetMilestoneVenus2Height(0)
+
+	// the token is issued without freezable and pausable
+	_, err = handler(ctx, types.NewMsgTokenFreeze(symbol, addr1, owner))
+	require.Equal(t, types.ErrTokenIsNotFreezable(symbol).Error(), err.Error())
+	_, err = handler(ctx, types.NewMsgTokenPause(symbol, owner))
+	require.Equal(t, types.ErrTokenIsNotPausable(symbol).Error(), err.Error())
+
+	modifyMsg.Pausable, modifyMsg.IsPausableModified = true, true
+	_, err = handler(ctx, modifyMsg)
+	require.Nil(t, err)
+	token := keeper.GetTokenInfo(ctx, symbol)
+	require.True(t, token.Freezable)
+	require.True(t, token.Pausable)
+
+	// only the owner can freeze the accounts except itself
+	_, err = handler(ctx, types.NewMsgTokenFreeze(symbol, addr2, addr1))
+	require.Equal(t, types.ErrInputOwnerIsNotEqualTokenOwner(addr1).Error(), err.Error())
+	_, err = handler(ctx, types.NewMsgTokenFreeze(symbol, owner, owner))
+	require.Equal(t, types.ErrFreezeTokenOwner(symbol).Error(), err.Error())
+
+	_, err = handler(ctx, types.NewMsgTokenFreeze(symbol, addr1, owner))
+	require.Nil(t, err)
+	_, err = handler(ctx, types.NewMsgTokenFreeze(symbol, addr1, owner))
+	require.Equal(t, types.ErrAccountFrozen(symbol, addr1).Error(), err.Error())
+
+	// the frozen account can neither send nor receive the token
+	_, err = handler(ctx, types.NewMsgTokenSend(addr1, addr2, amount))
+	require.Contains(t, err.Error(), types.ErrAccountFrozen(symbol, addr1).Error())
+	_, err = handler(ctx, types.NewMsgTokenSend(owner, addr1, amount))
+	require.Contains(t, err.Error(), types.ErrAccountFrozen(symbol, addr1).Error())
+	_, err = handler(ctx, types.NewMsgMultiSend(owner, []types.TransferUnit{{To: addr1, Coins: amount}}))
+	require.Contains(t, err.Error(), types.ErrAccountFrozen(symbol, addr1).Error())
+	_, err = handler(ctx, types.NewMsgTokenSend(owner, addr2, amount))
+	require.Nil(t, err)
+
+	// neither through the bank msgs
+	_, err = bankHandler(ctx, bank.NewMsgSend(addr1, addr2, amount))
+	require.Equal(t, types.ErrAccountFrozen(symbol, addr1).Error(), err.Error())
+	_, err = bankHandler(ctx, bank.NewMsgSend(owner, addr1, amount))
+	require.Equal(t, types.ErrAccountFrozen(symbol, addr1).Error(), err.Error())
+	_, err = bankHandler(ctx, bank.NewMsgMultiSend(
+		[]bank.Input{bank.NewInput(addr1, amount)}, []bank.Output{bank.NewOutput(addr2, amount)}))
+	require.Equal(t, types.ErrAccountFrozen(symbol, addr1).Error(), err.Error())
+	_, err = bankHandler(ctx, bank.NewMsgMultiSend(
+		[]bank.Input{bank.NewInput(owner, amount)}, []bank.Output{bank.NewOutput(addr1, amount)}))
+	require.Equal(t, types.ErrAccountFrozen(symbol, addr1).Error(), err.Error())
+	_, err = bankHandler(ctx, bank.NewMsgSend(owner, addr2, amount))
+	require.Nil(t, err)
+
+	querier := NewQuerier(keeper)
+	bz, err := querier(ctx, []string{types.QueryFrozen, symbol}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var frozen []sdk.AccAddress
+	keeper.cdc.MustUnmarshalJSON(bz, &frozen)
+	require.Equal(t, []sdk.AccAddress{addr1}, frozen)
+
+	_, err = handler(ctx, types.NewMsgTokenUnfreeze(symbol, addr1, owner))
+	require.Nil(t, err)
+	_, err = handler(ctx, types.NewMsgTokenUnfreeze(symbol, addr1, owner))
+	require.Equal(t, types.ErrAccountNotFrozen(symbol, addr1).Error(), err.Error())
+	_, err = handler(ctx, types.NewMsgTokenSend(addr1, addr2, amount))
+	require.Nil(t, err)
+	require.Empty(t, keeper.GetFrozenAccounts(ctx, symbol))
+
+	// all the transfers of the paused token are rejected
+	_, err = handler(ctx, types.NewMsgTokenPause(symbol, owner))
+	require.Nil(t, err)
+	_, err = handler(ctx, types.NewMsgTokenSend(owner, addr1, amount))
+	require.Contains(t, err.Error(), types.ErrTokenPaused(symbol).Error())
+	_, err = bankHandler(ctx, bank.NewMsgSend(owner, addr1, amount))
+	require.Equal(t, types.ErrTokenPaused(symbol).Error(), err.Error())
+
+	bz, err = querier(ctx, []string{types.QueryInfo, symbol}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var tokenResp types.TokenResp
+	keeper.cdc.MustUnmarshalJSON(bz, &tokenResp)
+	require.True(t, tokenResp.Paused)
+
+	_, err = handler(ctx, types.NewMsgTokenUnpause(symbol, owner))
+	require.Nil(t, err)
+	_, err = handler(ctx, types.NewMsgTokenUnpause(symbol, owner))
+	require.Equal(t, types.ErrTokenNotPaused(symbol).Error(), err.Error())
+	_, err = handler(ctx, types.NewMsgTokenSend(owner, addr1, amount))
+	require.Nil(t, err)
+}
+
+func TestFreezeAndPauseGenesis(t *testing.T) {
+	ctx, keeper, _, _ := CreateParam(t, false)
+	addr := sdk.AccAddress([]byte("token-holder-addr-01"))
+	keeper.SetTokenPaused(ctx, "usdx", true)
+	keeper.SetAccountFrozen(ctx, "usdx", addr, true)
+	keeper.SetAccountFrozen(ctx, "usdxy", addr, true)
+
+	// the frozen accounts of a token don't include the ones of another token prefixed by its symbol
+	require.Equal(t, []sdk.AccAddress{addr}, keeper.GetFrozenAccounts(ctx, "usdx"))
+
+	genesis := ExportGenesis(ctx, keeper)
+	require.Nil(t, validateGenesis(genesis))
+	require.Equal(t, []string{"usdx"}, genesis.PausedTokens)
+	require.Equal(t, []types.FrozenAccount{
+		types.NewFrozenAccount("usdx", addr),
+		types.NewFrozenAccount("usdxy", addr),
+	}, genesis.FrozenAccounts)
+
+	ctx2, keeper2, _, _ := CreateParam(t, false)
+	initGenesis(ctx2, keeper2, genesis)
+	require.True(t, keeper2.IsTokenPaused(ctx2, "usdx"))
+	require.False(t, keeper2.IsTokenPaused(ctx2, "usdxy"))
+	require.True(t, keeper2.IsAccountFrozen(ctx2, "usdxy", addr))
+}
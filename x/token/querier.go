@@ -26,6 +26,8 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryAccount(ctx, path[1:], req, keeper)
 		case types.QueryKeysNum:
 			return queryKeysNum(ctx, keeper)
+		case types.QueryFrozen:
+			return queryFrozenAccounts(ctx, path[1:], keeper)
 		case types.QueryAccountV2:
 			return queryAccountV2(ctx, path[1:], req, keeper)
 		case types.QueryTokensV2:
@@ -52,6 +54,7 @@ func queryInfo(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Kee
 
 	tokenResp := types.GenTokenResp(token)
 	tokenResp.TotalSupply = keeper.GetTokenTotalSupply(ctx, name)
+	tokenResp.Paused = keeper.IsTokenPaused(ctx, name)
 	bz, err := codec.MarshalJSONIndent(keeper.cdc, tokenResp)
 	if err != nil {
 		return nil, common.ErrMarshalJSONFailed(err.Error())
@@ -75,6 +78,7 @@ func queryTokens(ctx sdk.Context, path []string, req abci.RequestQuery, keeper K
 	for _, token := range tokens {
 		tokenResp := types.GenTokenResp(token)
 		tokenResp.TotalSupply = keeper.GetTokenTotalSupply(ctx, token.Symbol)
+		tokenResp.Paused = keeper.IsTokenPaused(ctx, token.Symbol)
 		tokensResp = append(tokensResp, tokenResp)
 	}
 	bz, err := codec.MarshalJSONIndent(keeper.cdc, tokensResp)
@@ -171,6 +175,27 @@ func queryKeysNum(ctx sdk.Context, keeper Keeper) ([]byte, sdk.Error) {
 	return res, nil
 }
 
+// queryFrozenAccounts lists the frozen accounts of the token
+func queryFrozenAccounts(ctx sdk.Context, path []string, keeper Keeper) ([]byte, sdk.Error) {
+	if len(path) == 0 || path[0] == "" {
+		return nil, types.ErrUserInputSymbolIsEmpty()
+	}
+	symbol := path[0]
+	if !keeper.TokenExist(ctx, symbol) {
+		return nil, types.ErrInvalidCoins(symbol)
+	}
+
+	addrs := keeper.GetFrozenAccounts(ctx, symbol)
+	if addrs == nil {
+		addrs = []sdk.AccAddress{}
+	}
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, addrs)
+	if err != nil {
+		return nil, common.ErrMarshalJSONFailed(err.Error())
+	}
+	return bz, nil
+}
+
 func uploadAccount(ctx sdk.Context, keeper Keeper) (res []byte, err sdk.Error) {
 	if !viper.GetBool(FlagOSSEnable) {
 		return []byte("This API is not enabled"), nil
@@ -70,6 +70,7 @@ func queryTokensV2(ctx sdk.Context, path []string, req abci.RequestQuery, keeper
 	for _, token := range tokens {
 		tokenResp := types.GenTokenResp(token)
 		tokenResp.TotalSupply = keeper.GetTokenTotalSupply(ctx, token.Symbol)
+		tokenResp.Paused = keeper.IsTokenPaused(ctx, token.Symbol)
 		tokensResp = append(tokensResp, tokenResp)
 	}
 	res, err := common.JSONMarshalV2(tokensResp)
@@ -89,6 +90,7 @@ func queryTokenV2(ctx sdk.Context, path []string, req abci.RequestQuery, keeper
 
 	tokenResp := types.GenTokenResp(token)
 	tokenResp.TotalSupply = keeper.GetTokenTotalSupply(ctx, name)
+	tokenResp.Paused = keeper.IsTokenPaused(ctx, name)
 	res, err := common.JSONMarshalV2(tokenResp)
 	if err != nil {
 		return nil, sdk.ErrInternal(err.Error())
@@ -14,6 +14,10 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgTransferOwnership{}, "okexchain/token/MsgTransferOwnership", nil)
 	cdc.RegisterConcrete(MsgConfirmOwnership{}, "okexchain/token/MsgConfirmOwnership", nil)
 	cdc.RegisterConcrete(MsgTokenModify{}, "okexchain/token/MsgModify", nil)
+	cdc.RegisterConcrete(MsgTokenFreeze{}, "okexchain/token/MsgFreeze", nil)
+	cdc.RegisterConcrete(MsgTokenUnfreeze{}, "okexchain/token/MsgUnfreeze", nil)
+	cdc.RegisterConcrete(MsgTokenPause{}, "okexchain/token/MsgPause", nil)
+	cdc.RegisterConcrete(MsgTokenUnpause{}, "okexchain/token/MsgUnpause", nil)
 
 	// for test
 	//cdc.RegisterConcrete(MsgTokenDestroy{}, "okexchain/token/MsgDestroy", nil)
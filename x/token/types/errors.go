@@ -43,6 +43,14 @@ const (
 	CodeTotalsupplyExceedsTheUpperLimit            uint32 = 61032
 	CodeBlockedContractRecipient                   uint32 = 61033
 	CodeSendCoinsFromAccountToAccountFailed        uint32 = 61034
+	CodeTokenIsNotFreezable                        uint32 = 61035
+	CodeTokenIsNotPausable                         uint32 = 61036
+	CodeAccountFrozen                              uint32 = 61037
+	CodeAccountNotFrozen                           uint32 = 61038
+	CodeTokenPaused                                uint32 = 61039
+	CodeTokenNotPaused                             uint32 = 61040
+	CodeFreezeTokenOwner                           uint32 = 61041
+	CodeFreezeNotEnabled                           uint32 = 61042
 )
 
 var (
@@ -81,6 +89,14 @@ var (
 	errCodeConfirmOwnershipAddressNotEqualsMsgAddress = sdkerrors.Register(DefaultCodespace, CodeConfirmOwnershipAddressNotEqualsMsgAddress, "input address is not equal confirm ownership address")
 	errCodeGetDecimalFromDecimalStringFailed          = sdkerrors.Register(DefaultCodespace, CodeGetDecimalFromDecimalStringFailed, "create a decimal from an input decimal string failed")
 	errCodeTotalsupplyExceedsTheUpperLimit            = sdkerrors.Register(DefaultCodespace, CodeTotalsupplyExceedsTheUpperLimit, "total-supply exceeds the upper limit")
+	errCodeTokenIsNotFreezable                        = sdkerrors.Register(DefaultCodespace, CodeTokenIsNotFreezable, "token is not freezable")
+	errCodeTokenIsNotPausable                         = sdkerrors.Register(DefaultCodespace, CodeTokenIsNotPausable, "token is not pausable")
+	errCodeAccountFrozen                              = sdkerrors.Register(DefaultCodespace, CodeAccountFrozen, "account is frozen")
+	errCodeAccountNotFrozen                           = sdkerrors.Register(DefaultCodespace, CodeAccountNotFrozen, "account is not frozen")
+	errCodeTokenPaused                                = sdkerrors.Register(DefaultCodespace, CodeTokenPaused, "token is paused")
+	errCodeTokenNotPaused                             = sdkerrors.Register(DefaultCodespace, CodeTokenNotPaused, "token is not paused")
+	errCodeFreezeTokenOwner                           = sdkerrors.Register(DefaultCodespace, CodeFreezeTokenOwner, "token owner can't be frozen")
+	errCodeFreezeNotEnabled                           = sdkerrors.Register(DefaultCodespace, CodeFreezeNotEnabled, "freeze and pause are not enabled")
 )
 
 // ErrBlockedContractRecipient returns an error when a transfer is tried on a blocked contract recipient
@@ -213,3 +229,43 @@ func ErrGetDecimalFromDecimalStringFailed(msg string) sdk.EnvelopedErr {
 func ErrCodeTotalsupplyExceedsTheUpperLimit(totalSupplyAfterMint sdk.Dec, TotalSupplyUpperbound int64) sdk.EnvelopedErr {
 	return sdk.EnvelopedErr{Err: sdkerrors.Wrapf(errCodeTotalsupplyExceedsTheUpperLimit, fmt.Sprintf("total-supply(%s) exceeds the upper limit(%d)", totalSupplyAfterMint, TotalSupplyUpperbound))}
 }
+
+// ErrTokenIsNotFreezable returns an error when freezing an account of the token issued without freezable
+func ErrTokenIsNotFreezable(symbol string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.Wrapf(errCodeTokenIsNotFreezable, "token %s is not freezable", symbol)}
+}
+
+// ErrTokenIsNotPausable returns an error when pausing the token issued without pausable
+func ErrTokenIsNotPausable(symbol string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.Wrapf(errCodeTokenIsNotPausable, "token %s is not pausable", symbol)}
+}
+
+// ErrAccountFrozen returns an error when the account is frozen for the token
+func ErrAccountFrozen(symbol string, addr sdk.AccAddress) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.Wrapf(errCodeAccountFrozen, "account %s is frozen for token %s", addr, symbol)}
+}
+
+// ErrAccountNotFrozen returns an error when unfreezing an account which is not frozen for the token
+func ErrAccountNotFrozen(symbol string, addr sdk.AccAddress) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.Wrapf(errCodeAccountNotFrozen, "account %s is not frozen for token %s", addr, symbol)}
+}
+
+// ErrTokenPaused returns an error when the transfers of the token are paused
+func ErrTokenPaused(symbol string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.Wrapf(errCodeTokenPaused, "token %s is paused", symbol)}
+}
+
+// ErrTokenNotPaused returns an error when unpausing the token which is not paused
+func ErrTokenNotPaused(symbol string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.Wrapf(errCodeTokenNotPaused, "token %s is not paused", symbol)}
+}
+
+// ErrFreezeTokenOwner returns an error when the token owner tries to freeze itself
+func ErrFreezeTokenOwner(symbol string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.Wrapf(errCodeFreezeTokenOwner, "the owner of token %s can't be frozen", symbol)}
+}
+
+// ErrFreezeNotEnabled returns an error when the tokens are frozen or paused before the Venus2 height
+func ErrFreezeNotEnabled() sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.Wrap(errCodeFreezeNotEnabled, "freeze and pause are not enabled before the Venus2 height")}
+}
@@ -9,3 +9,9 @@ type AccountKeeper interface {
 	GetAccount(ctx sdk.Context, addr sdk.AccAddress) authexported.Account
 	IterateAccounts(ctx sdk.Context, cb func(account authexported.Account) bool)
 }
+
+// TokenHooks defines the hooks of the token module for the modules holding the tokens of the accounts
+type TokenHooks interface {
+	// Must be called when an account is frozen for a token
+	AfterAccountFrozen(ctx sdk.Context, symbol string, addr sdk.AccAddress)
+}
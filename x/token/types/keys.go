@@ -30,6 +30,7 @@ const (
 	QueryCurrency   = "currency"
 	QueryAccount    = "accounts"
 	QueryKeysNum    = "store"
+	QueryFrozen     = "frozen"
 
 	QueryAccountV2 = "accountsV2"
 	QueryTokensV2  = "tokensV2"
@@ -45,6 +46,8 @@ var (
 	PrefixUserTokenKey        = []byte{0x03} // the address prefix of the user-token relationship
 	LockedFeeKey              = []byte{0x04} // the address prefix of the locked order fee coins
 	PrefixConfirmOwnershipKey = []byte{0x05} // the prefix of the confirm ownership key
+	PrefixPausedTokenKey      = []byte{0x06} // the prefix of the paused token's symbol
+	PrefixFrozenAccountKey    = []byte{0x07} // the prefix of the token-frozen account relationship
 )
 
 func GetUserTokenPrefix(owner sdk.AccAddress) []byte {
@@ -71,3 +74,25 @@ func GetLockFeeAddress(addr sdk.AccAddress) []byte {
 func GetConfirmOwnershipKey(symbol string) []byte {
 	return append(PrefixConfirmOwnershipKey, []byte(symbol)...)
 }
+
+// GetPausedTokenKey gets the key of the paused token
+func GetPausedTokenKey(symbol string) []byte {
+	return append(PrefixPausedTokenKey, []byte(symbol)...)
+}
+
+// GetFrozenAccountsPrefix gets the prefix of the frozen accounts of the token.
+// The symbol is prefixed by its length, so that it won't be the prefix of another symbol
+func GetFrozenAccountsPrefix(symbol string) []byte {
+	return append(append(PrefixFrozenAccountKey, byte(len(symbol))), []byte(symbol)...)
+}
+
+// GetFrozenAccountKey gets the key of the frozen account of the token
+func GetFrozenAccountKey(symbol string, addr sdk.AccAddress) []byte {
+	return append(GetFrozenAccountsPrefix(symbol), addr.Bytes()...)
+}
+
+// SplitFrozenAccountKey splits the frozen account key to the symbol and the address
+func SplitFrozenAccountKey(key []byte) (string, sdk.AccAddress) {
+	symbolLen := int(key[1])
+	return string(key[2 : 2+symbolLen]), key[2+symbolLen:]
+}
@@ -23,6 +23,8 @@ type MsgTokenIssue struct {
 	TotalSupply    string         `json:"total_supply"`
 	Owner          sdk.AccAddress `json:"owner"`
 	Mintable       bool           `json:"mintable"`
+	Freezable      bool           `json:"freezable,omitempty"`
+	Pausable       bool           `json:"pausable,omitempty"`
 }
 
 func NewMsgTokenIssue(tokenDescription, symbol, originalSymbol, wholeName, totalSupply string, owner sdk.AccAddress, mintable bool) MsgTokenIssue {
@@ -304,6 +306,10 @@ type MsgTokenModify struct {
 	WholeName             string         `json:"whole_name"`
 	IsDescriptionModified bool           `json:"description_modified"`
 	IsWholeNameModified   bool           `json:"whole_name_modified"`
+	Freezable             bool           `json:"freezable,omitempty"`
+	Pausable              bool           `json:"pausable,omitempty"`
+	IsFreezableModified   bool           `json:"freezable_modified,omitempty"`
+	IsPausableModified    bool           `json:"pausable_modified,omitempty"`
 }
 
 func NewMsgTokenModify(symbol, desc, wholeName string, isDescEdit, isWholeNameEdit bool, owner sdk.AccAddress) MsgTokenModify {
@@ -398,3 +404,147 @@ func (msg MsgConfirmOwnership) GetSignBytes() []byte {
 func (msg MsgConfirmOwnership) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Address}
 }
+
+// MsgTokenFreeze freezes the transfers of the token from and to the account
+type MsgTokenFreeze struct {
+	Owner   sdk.AccAddress `json:"owner"`
+	Symbol  string         `json:"symbol"`
+	Address sdk.AccAddress `json:"address"`
+}
+
+func NewMsgTokenFreeze(symbol string, addr, owner sdk.AccAddress) MsgTokenFreeze {
+	return MsgTokenFreeze{
+		Owner:   owner,
+		Symbol:  symbol,
+		Address: addr,
+	}
+}
+
+func (msg MsgTokenFreeze) Route() string { return RouterKey }
+
+func (msg MsgTokenFreeze) Type() string { return "freeze" }
+
+func (msg MsgTokenFreeze) ValidateBasic() sdk.Error {
+	return validateFreezeMsg(msg.Owner, msg.Symbol, msg.Address)
+}
+
+func (msg MsgTokenFreeze) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg MsgTokenFreeze) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgTokenUnfreeze unfreezes the frozen account of the token
+type MsgTokenUnfreeze struct {
+	Owner   sdk.AccAddress `json:"owner"`
+	Symbol  string         `json:"symbol"`
+	Address sdk.AccAddress `json:"address"`
+}
+
+func NewMsgTokenUnfreeze(symbol string, addr, owner sdk.AccAddress) MsgTokenUnfreeze {
+	return MsgTokenUnfreeze{
+		Owner:   owner,
+		Symbol:  symbol,
+		Address: addr,
+	}
+}
+
+func (msg MsgTokenUnfreeze) Route() string { return RouterKey }
+
+func (msg MsgTokenUnfreeze) Type() string { return "unfreeze" }
+
+func (msg MsgTokenUnfreeze) ValidateBasic() sdk.Error {
+	return validateFreezeMsg(msg.Owner, msg.Symbol, msg.Address)
+}
+
+func (msg MsgTokenUnfreeze) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg MsgTokenUnfreeze) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgTokenPause pauses all the transfers of the token
+type MsgTokenPause struct {
+	Owner  sdk.AccAddress `json:"owner"`
+	Symbol string         `json:"symbol"`
+}
+
+func NewMsgTokenPause(symbol string, owner sdk.AccAddress) MsgTokenPause {
+	return MsgTokenPause{
+		Owner:  owner,
+		Symbol: symbol,
+	}
+}
+
+func (msg MsgTokenPause) Route() string { return RouterKey }
+
+func (msg MsgTokenPause) Type() string { return "pause" }
+
+func (msg MsgTokenPause) ValidateBasic() sdk.Error {
+	return validatePauseMsg(msg.Owner, msg.Symbol)
+}
+
+func (msg MsgTokenPause) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg MsgTokenPause) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgTokenUnpause resumes the transfers of the paused token
+type MsgTokenUnpause struct {
+	Owner  sdk.AccAddress `json:"owner"`
+	Symbol string         `json:"symbol"`
+}
+
+func NewMsgTokenUnpause(symbol string, owner sdk.AccAddress) MsgTokenUnpause {
+	return MsgTokenUnpause{
+		Owner:  owner,
+		Symbol: symbol,
+	}
+}
+
+func (msg MsgTokenUnpause) Route() string { return RouterKey }
+
+func (msg MsgTokenUnpause) Type() string { return "unpause" }
+
+func (msg MsgTokenUnpause) ValidateBasic() sdk.Error {
+	return validatePauseMsg(msg.Owner, msg.Symbol)
+}
+
+func (msg MsgTokenUnpause) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg MsgTokenUnpause) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+func validateFreezeMsg(owner sdk.AccAddress, symbol string, addr sdk.AccAddress) sdk.Error {
+	if addr.Empty() {
+		return ErrAddressIsRequired()
+	}
+	return validatePauseMsg(owner, symbol)
+}
+
+func validatePauseMsg(owner sdk.AccAddress, symbol string) sdk.Error {
+	if owner.Empty() {
+		return ErrAddressIsRequired()
+	}
+	if len(symbol) == 0 {
+		return ErrMsgSymbolIsEmpty()
+	}
+	if sdk.ValidateDenom(symbol) != nil {
+		return ErrNotAllowedOriginalSymbol(symbol)
+	}
+	return nil
+}
@@ -375,3 +375,43 @@ bnbbbbbbbbbbbnbbbbbbbbbbnbbbbbbbbbbbnbbbbbbbbb1234`, "bnbbbbbbbbbb", false, fals
 	err := tokenEditMsg.ValidateBasic()
 	require.NoError(t, err)
 }
+
+func TestNewMsgTokenFreezeAndPause(t *testing.T) {
+	common.InitConfig()
+
+	priKey := secp256k1.GenPrivKey()
+	pubKey := priKey.PubKey()
+	addr := sdk.AccAddress(pubKey.Address())
+
+	testCase := []struct {
+		msg sdk.Msg
+		err sdk.Error
+	}{
+		{NewMsgTokenFreeze("bnb", addr, addr), nil},
+		{NewMsgTokenFreeze("", addr, addr), ErrMsgSymbolIsEmpty()},
+		{NewMsgTokenFreeze("bnb", sdk.AccAddress{}, addr), ErrAddressIsRequired()},
+		{NewMsgTokenUnfreeze("bnb", addr, sdk.AccAddress{}), ErrAddressIsRequired()},
+		{NewMsgTokenUnfreeze("bn-b*", addr, addr), ErrNotAllowedOriginalSymbol("bn-b*")},
+		{NewMsgTokenPause("bnb", addr), nil},
+		{NewMsgTokenPause("bnb", sdk.AccAddress{}), ErrAddressIsRequired()},
+		{NewMsgTokenUnpause("", addr), ErrMsgSymbolIsEmpty()},
+	}
+	for _, msgCase := range testCase {
+		err := msgCase.msg.ValidateBasic()
+		if msgCase.err != nil {
+			require.EqualValues(t, msgCase.err.Error(), err.Error())
+		} else {
+			require.NoError(t, err)
+		}
+		require.EqualValues(t, "token", msgCase.msg.Route())
+	}
+
+	freezeMsg := NewMsgTokenFreeze("bnb", addr, addr)
+	require.EqualValues(t, []sdk.AccAddress{addr}, freezeMsg.GetSigners())
+	bz := ModuleCdc.MustMarshalJSON(freezeMsg)
+	require.EqualValues(t, sdk.MustSortJSON(bz), freezeMsg.GetSignBytes())
+	require.EqualValues(t, "freeze", freezeMsg.Type())
+	require.EqualValues(t, "unfreeze", NewMsgTokenUnfreeze("bnb", addr, addr).Type())
+	require.EqualValues(t, "pause", NewMsgTokenPause("bnb", addr).Type())
+	require.EqualValues(t, "unpause", NewMsgTokenUnpause("bnb", addr).Type())
+}
@@ -15,6 +15,8 @@ type Token struct {
 	Type                int            `json:"type"`                                             //e.g. 1 common token, 2 interest token
 	Owner               sdk.AccAddress `json:"owner" v2:"owner"`                                 // e.g. ex1cftp8q8g4aa65nw9s5trwexe77d9t6cr8ndu02
 	Mintable            bool           `json:"mintable" v2:"mintable"`                           // e.g. false
+	Freezable           bool           `json:"freezable,omitempty" v2:"freezable"`               // whether the owner can freeze accounts
+	Pausable            bool           `json:"pausable,omitempty" v2:"pausable"`                 // whether the owner can pause transfers
 }
 
 func (token Token) String() string {
@@ -35,6 +37,9 @@ type TokenResp struct {
 	Owner               sdk.AccAddress `json:"owner" v2:"owner"`
 	Mintable            bool           `json:"mintable" v2:"mintable"`
 	TotalSupply         sdk.Dec        `json:"total_supply" v2:"total_supply"`
+	Freezable           bool           `json:"freezable" v2:"freezable"`
+	Pausable            bool           `json:"pausable" v2:"pausable"`
+	Paused              bool           `json:"paused" v2:"paused"`
 }
 
 func (token TokenResp) String() string {
@@ -55,6 +60,20 @@ func (tokens Tokens) String() string {
 	return string(b)
 }
 
+// FrozenAccount is an account whose transfers of the token are frozen by the token owner
+type FrozenAccount struct {
+	Symbol  string         `json:"symbol"`
+	Address sdk.AccAddress `json:"address"`
+}
+
+// NewFrozenAccount creates a new instance of FrozenAccount
+func NewFrozenAccount(symbol string, addr sdk.AccAddress) FrozenAccount {
+	return FrozenAccount{
+		Symbol:  symbol,
+		Address: addr,
+	}
+}
+
 type Currency struct {
 	Description string  `json:"description"`
 	Symbol      string  `json:"symbol"`
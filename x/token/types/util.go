@@ -181,5 +181,7 @@ func GenTokenResp(token Token) TokenResp {
 		Owner:               token.Owner,
 		Type:                token.Type,
 		Mintable:            token.Mintable,
+		Freezable:           token.Freezable,
+		Pausable:            token.Pausable,
 	}
 }
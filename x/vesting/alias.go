@@ -0,0 +1,33 @@
+package vesting
+
+import (
+	"github.com/okex/exchain/x/vesting/keeper"
+	"github.com/okex/exchain/x/vesting/types"
+)
+
+// nolint
+const (
+	ModuleName   = types.ModuleName
+	RouterKey    = types.RouterKey
+	QuerierRoute = types.QuerierRoute
+)
+
+// nolint
+var (
+	NewKeeper                          = keeper.NewKeeper
+	NewQuerier                         = keeper.NewQuerier
+	NewMsgCreateVestingAccount         = types.NewMsgCreateVestingAccount
+	NewMsgCreatePeriodicVestingAccount = types.NewMsgCreatePeriodicVestingAccount
+	RegisterCodec                      = types.RegisterCodec
+	ModuleCdc                          = types.ModuleCdc
+	ErrAccountExists                   = types.ErrAccountExists
+	ErrNoVestingAccount                = types.ErrNoVestingAccount
+)
+
+// nolint
+type (
+	Keeper                          = keeper.Keeper
+	MsgCreateVestingAccount         = types.MsgCreateVestingAccount
+	MsgCreatePeriodicVestingAccount = types.MsgCreatePeriodicVestingAccount
+	VestingBalances                 = types.VestingBalances
+)
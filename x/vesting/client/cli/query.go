@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client"
+	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	"github.com/okex/exchain/libs/cosmos-sdk/client/flags"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/version"
+	"github.com/okex/exchain/x/vesting/types"
+)
+
+// GetQueryCmd returns the CLI command with all vesting module query commands mounted.
+func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "Querying commands for the vesting module",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	cmd.AddCommand(flags.GetCommands(
+		GetCmdQueryBalances(queryRoute, cdc),
+	)...)
+	return cmd
+}
+
+// GetCmdQueryBalances implements the command to query the vested and locked coins of a vesting account
+func GetCmdQueryBalances(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "balances [address]",
+		Short: "Query the vested and locked coins of a vesting account",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the vested and locked coins of a vesting account at the latest block time.
+
+Example:
+$ %s query %s balances ex1...
+`,
+				version.ClientName, types.ModuleName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			addr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryBalancesParams(addr))
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryBalances)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var balances types.VestingBalances
+			if err := cdc.UnmarshalJSON(res, &balances); err != nil {
+				return fmt.Errorf("failed to unmarshal vesting balances: %w", err)
+			}
+			return cliCtx.PrintOutput(balances)
+		},
+	}
+}
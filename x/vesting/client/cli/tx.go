@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client"
+	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	"github.com/okex/exchain/libs/cosmos-sdk/client/flags"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/version"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth/client/utils"
+	vestingtypes "github.com/okex/exchain/libs/cosmos-sdk/x/auth/vesting/types"
+	"github.com/okex/exchain/x/vesting/types"
+)
+
+// flags for the vesting accounts
+const (
+	FlagStartTime = "start-time"
+	FlagDelayed   = "delayed"
+)
+
+// GetTxCmd returns the transaction commands for the vesting module
+func GetTxCmd(cdc *codec.Codec) *cobra.Command {
+	txCmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "Vesting transactions subcommands",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	txCmd.AddCommand(flags.PostCommands(
+		GetCmdCreateVestingAccount(cdc),
+		GetCmdCreatePeriodicVestingAccount(cdc),
+	)...)
+	return txCmd
+}
+
+// GetCmdCreateVestingAccount implements the command to create a continuous or delayed vesting account
+func GetCmdCreateVestingAccount(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-vesting-account [to_address] [amount] [end_time]",
+		Short: "Create a new vesting account funded with the coins of the sender",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Create a new vesting account funded with the coins of the sender given by --from.
+The coins are vested linearly from the unix time given by --%s to the unix end time,
+or all at the end time if --%s is set. The account must not exist before.
+
+Example:
+$ %s tx %s create-vesting-account ex1... 1000okt 1735689600 --start-time=1704067200 --from=mykey
+$ %s tx %s create-vesting-account ex1... 1000okt 1735689600 --delayed --from=mykey
+`,
+				FlagStartTime, FlagDelayed,
+				version.ClientName, types.ModuleName, version.ClientName, types.ModuleName,
+			),
+		),
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			to, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			amount, err := sdk.ParseDecCoins(args[1])
+			if err != nil {
+				return err
+			}
+			endTime, err := strconv.ParseInt(args[2], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid end time %s: %w", args[2], err)
+			}
+
+			msg := types.NewMsgCreateVestingAccount(cliCtx.GetFromAddress(), to, amount,
+				viper.GetInt64(FlagStartTime), endTime, viper.GetBool(FlagDelayed))
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().Int64(FlagStartTime, 0, "The unix time when the coins start to vest, required unless delayed")
+	cmd.Flags().Bool(FlagDelayed, false, "Vest all the coins at the end time instead of linearly")
+	return cmd
+}
+
+// GetCmdCreatePeriodicVestingAccount implements the command to create a periodic vesting account
+func GetCmdCreatePeriodicVestingAccount(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "create-periodic-vesting-account [to_address] [periods_json_file]",
+		Short: "Create a new periodic vesting account funded with the coins of the sender",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Create a new periodic vesting account funded with the coins of the sender given by --from.
+The coins of each period are vested at its end, the periods follow each other from the start time.
+The account must not exist before.
+
+The periods file is in the format of:
+{
+  "start_time": 1704067200,
+  "periods": [
+    {"length": 2592000, "amount": [{"denom": "okt", "amount": "100"}]},
+    {"length": 2592000, "amount": [{"denom": "okt", "amount": "100"}]}
+  ]
+}
+
+Example:
+$ %s tx %s create-periodic-vesting-account ex1... periods.json --from=mykey
+`,
+				version.ClientName, types.ModuleName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			to, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			bz, err := ioutil.ReadFile(args[1])
+			if err != nil {
+				return err
+			}
+			var schedule struct {
+				StartTime int64                `json:"start_time"`
+				Periods   vestingtypes.Periods `json:"periods"`
+			}
+			if err := json.Unmarshal(bz, &schedule); err != nil {
+				return fmt.Errorf("failed to parse periods file: %w", err)
+			}
+
+			msg := types.NewMsgCreatePeriodicVestingAccount(cliCtx.GetFromAddress(), to, schedule.StartTime, schedule.Periods)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
@@ -0,0 +1,17 @@
+package rest
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
+)
+
+// REST query parameter values
+const (
+	RestAddress = "address"
+)
+
+// RegisterRoutes registers the vesting module's REST service handlers
+func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router) {
+	registerQueryRoutes(cliCtx, r)
+}
@@ -0,0 +1,46 @@
+package vesting
+
+import (
+	ethermint "github.com/okex/exchain/app/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/vesting/keeper"
+	"github.com/okex/exchain/x/vesting/types"
+)
+
+// NewHandler returns a handler for vesting type messages.
+func NewHandler(k keeper.Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		ctx.SetEventManager(sdk.NewEventManager())
+
+		if !tmtypes.HigherThanVenus2(ctx.BlockHeight()) {
+			return nil, types.ErrVestingDisabled
+		}
+
+		switch msg := msg.(type) {
+		case types.MsgCreateVestingAccount:
+			return handleMsgCreateVestingAccount(ctx, k, msg.FromAddress, msg.ToAddress, msg.Schedule())
+		case types.MsgCreatePeriodicVestingAccount:
+			return handleMsgCreateVestingAccount(ctx, k, msg.FromAddress, msg.ToAddress, msg.Schedule())
+		default:
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized %s message type: %T", types.ModuleName, msg)
+		}
+	}
+}
+
+func handleMsgCreateVestingAccount(ctx sdk.Context, k keeper.Keeper, from, to sdk.AccAddress,
+	schedule *ethermint.VestingSchedule) (*sdk.Result, error) {
+	if err := k.CreateVestingAccount(ctx, from, to, schedule); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, from.String()),
+		),
+	)
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
@@ -0,0 +1,87 @@
+package keeper
+
+import (
+	"fmt"
+
+	ethermint "github.com/okex/exchain/app/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/okex/exchain/x/vesting/types"
+)
+
+// Keeper creates the vesting accounts, whose vesting schedules are kept by the accounts themselves
+type Keeper struct {
+	accountKeeper types.AccountKeeper
+	bankKeeper    types.BankKeeper
+}
+
+// NewKeeper creates a new vesting Keeper instance
+func NewKeeper(ak types.AccountKeeper, bk types.BankKeeper) Keeper {
+	return Keeper{
+		accountKeeper: ak,
+		bankKeeper:    bk,
+	}
+}
+
+// Logger returns a module-specific logger.
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
+}
+
+// CreateVestingAccount creates a new account with the vesting schedule, and sends the original vesting
+// coins of the schedule to it from the creator
+func (k Keeper) CreateVestingAccount(ctx sdk.Context, from, to sdk.AccAddress, schedule *ethermint.VestingSchedule) error {
+	if k.bankKeeper.BlacklistedAddr(to) {
+		return sdkerrors.Wrap(types.ErrBlockedAddress, to.String())
+	}
+	if k.accountKeeper.GetAccount(ctx, to) != nil {
+		return sdkerrors.Wrap(types.ErrAccountExists, to.String())
+	}
+
+	ethAcc, ok := k.accountKeeper.NewAccountWithAddress(ctx, to).(*ethermint.EthAccount)
+	if !ok {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidType, "account %s is not an eth account", to)
+	}
+	ethAcc.VestingSchedule = schedule
+	k.accountKeeper.SetAccount(ctx, ethAcc)
+
+	if err := k.bankKeeper.SendCoins(ctx, from, to, schedule.OriginalVesting); err != nil {
+		return err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeCreateVestingAccount,
+			sdk.NewAttribute(types.AttributeKeyAddress, to.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, schedule.OriginalVesting.String()),
+		),
+	)
+	return nil
+}
+
+// GetVestingBalances returns the vested and locked coins of the vesting account at the block time
+func (k Keeper) GetVestingBalances(ctx sdk.Context, addr sdk.AccAddress) (types.VestingBalances, error) {
+	acc := k.accountKeeper.GetAccount(ctx, addr)
+	if acc == nil {
+		return types.VestingBalances{}, sdkerrors.Wrapf(sdkerrors.ErrUnknownAddress, "account %s does not exist", addr)
+	}
+	ethAcc, ok := acc.(*ethermint.EthAccount)
+	if !ok || ethAcc.VestingSchedule == nil {
+		return types.VestingBalances{}, sdkerrors.Wrap(types.ErrNoVestingAccount, addr.String())
+	}
+
+	blockTime := ctx.BlockTime()
+	return types.VestingBalances{
+		Address:          addr,
+		OriginalVesting:  ethAcc.GetOriginalVesting(),
+		Vested:           ethAcc.GetVestedCoins(blockTime),
+		Vesting:          ethAcc.GetVestingCoins(blockTime),
+		Locked:           ethAcc.LockedCoins(blockTime),
+		Spendable:        ethAcc.SpendableCoins(blockTime),
+		DelegatedFree:    ethAcc.GetDelegatedFree(),
+		DelegatedVesting: ethAcc.GetDelegatedVesting(),
+		StartTime:        ethAcc.GetStartTime(),
+		EndTime:          ethAcc.GetEndTime(),
+	}, nil
+}
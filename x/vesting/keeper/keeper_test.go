@@ -0,0 +1,174 @@
+package keeper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/okex/exchain/app"
+	ethermint "github.com/okex/exchain/app/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	minttypes "github.com/okex/exchain/libs/cosmos-sdk/x/mint"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/libs/tendermint/crypto/secp256k1"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+	stakingtypes "github.com/okex/exchain/x/staking/types"
+	"github.com/okex/exchain/x/vesting"
+	"github.com/okex/exchain/x/vesting/keeper"
+	"github.com/okex/exchain/x/vesting/types"
+)
+
+type KeeperTestSuite struct {
+	suite.Suite
+
+	ctx     sdk.Context
+	app     *app.OKExChainApp
+	querier sdk.Querier
+	creator sdk.AccAddress
+	addrs   []sdk.AccAddress
+}
+
+func (suite *KeeperTestSuite) SetupTest() {
+	checkTx := false
+
+	suite.app = app.Setup(checkTx)
+	suite.ctx = suite.app.NewContext(checkTx, abci.Header{
+		Height:  1,
+		ChainID: "ethermint-3",
+		Time:    time.Now().UTC(),
+	})
+	suite.querier = keeper.NewQuerier(suite.app.VestingKeeper)
+
+	suite.creator = sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	coins := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000))
+	suite.Require().NoError(suite.app.SupplyKeeper.MintCoins(suite.ctx, minttypes.ModuleName, coins))
+	suite.Require().NoError(suite.app.SupplyKeeper.SendCoinsFromModuleToAccount(suite.ctx, minttypes.ModuleName, suite.creator, coins))
+
+	suite.addrs = make([]sdk.AccAddress, 2)
+	for i := range suite.addrs {
+		suite.addrs[i] = sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	}
+}
+
+func TestKeeperTestSuite(t *testing.T) {
+	suite.Run(t, new(KeeperTestSuite))
+}
+
+func (suite *KeeperTestSuite) halfVestedSchedule(amount int64) *ethermint.VestingSchedule {
+	now := suite.ctx.BlockTime()
+	coins := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, amount))
+	return ethermint.NewContinuousVestingSchedule(coins, now.Add(-time.Hour).Unix(), now.Add(time.Hour).Unix())
+}
+
+func (suite *KeeperTestSuite) TestCreateVestingAccount() {
+	to := suite.addrs[0]
+	schedule := suite.halfVestedSchedule(100)
+	suite.Require().NoError(suite.app.VestingKeeper.CreateVestingAccount(suite.ctx, suite.creator, to, schedule))
+
+	acc, ok := suite.app.AccountKeeper.GetAccount(suite.ctx, to).(*ethermint.EthAccount)
+	suite.Require().True(ok)
+	suite.Require().Equal(schedule, acc.VestingSchedule)
+	suite.Require().Equal(schedule.OriginalVesting, acc.GetCoins())
+
+	// the account already exists
+	err := suite.app.VestingKeeper.CreateVestingAccount(suite.ctx, suite.creator, to, schedule)
+	suite.Require().True(types.ErrAccountExists.Is(err))
+
+	// insufficient funds of the creator
+	err = suite.app.VestingKeeper.CreateVestingAccount(suite.ctx, suite.creator, suite.addrs[1], suite.halfVestedSchedule(10000))
+	suite.Require().Error(err)
+}
+
+func (suite *KeeperTestSuite) TestLockedCoins() {
+	to := suite.addrs[0]
+	suite.Require().NoError(suite.app.VestingKeeper.CreateVestingAccount(suite.ctx, suite.creator, to, suite.halfVestedSchedule(100)))
+
+	// only the vested coins can be sent
+	locked := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 51))
+	suite.Require().Error(suite.app.BankKeeper.SendCoins(suite.ctx, to, suite.addrs[1], locked))
+	vested := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 30))
+	suite.Require().NoError(suite.app.BankKeeper.SendCoins(suite.ctx, to, suite.addrs[1], vested))
+
+	// the locked coins can be deposited to staking
+	deposit := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 60))
+	suite.Require().NoError(suite.app.SupplyKeeper.DelegateCoinsFromAccountToModule(suite.ctx, to, stakingtypes.BondedPoolName, deposit))
+	acc := suite.app.AccountKeeper.GetAccount(suite.ctx, to).(*ethermint.EthAccount)
+	suite.Require().Equal(sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 50)), acc.GetDelegatedVesting())
+	suite.Require().Equal(sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 10)), acc.GetDelegatedFree())
+	suite.Require().Equal(sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 10)), acc.SpendableCoins(suite.ctx.BlockTime()))
+}
+
+func (suite *KeeperTestSuite) TestEvmCanTransfer() {
+	to := suite.addrs[0]
+	suite.Require().NoError(suite.app.VestingKeeper.CreateVestingAccount(suite.ctx, suite.creator, to, suite.halfVestedSchedule(100)))
+
+	csdb := evmtypes.CreateEmptyCommitStateDB(suite.app.EvmKeeper.GenerateCSDBParams(), suite.ctx)
+	addr := common.BytesToAddress(to.Bytes())
+	spendable := sdk.NewDec(50).BigInt()
+	suite.Require().Equal(spendable, csdb.GetSpendableBalance(addr))
+	suite.Require().True(evmtypes.CanTransfer(csdb, addr, spendable))
+	suite.Require().False(evmtypes.CanTransfer(csdb, addr, sdk.NewDec(51).BigInt()))
+}
+
+func (suite *KeeperTestSuite) TestEvmLockedBalance() {
+	to := suite.addrs[0]
+	suite.Require().NoError(suite.app.VestingKeeper.CreateVestingAccount(suite.ctx, suite.creator, to, suite.halfVestedSchedule(100)))
+
+	csdb := evmtypes.CreateEmptyCommitStateDB(suite.app.EvmKeeper.GenerateCSDBParams(), suite.ctx)
+	addr := common.BytesToAddress(to.Bytes())
+	beneficiary := common.BytesToAddress(suite.addrs[1].Bytes())
+	spendable := sdk.NewDec(50).BigInt()
+
+	// a contract created at the vesting address keeps the coins locked
+	csdb.CreateAccount(addr)
+	suite.Require().Equal(sdk.NewDec(100).BigInt(), csdb.GetBalance(addr))
+	suite.Require().Equal(spendable, csdb.GetSpendableBalance(addr))
+
+	// the contract can't selfdestruct with the locked coins
+	verifier := evmtypes.NewContractVerifier(suite.app.EvmKeeper.GetParams(suite.ctx))
+	suite.Require().Error(verifier.Verify(csdb, vm.SELFDESTRUCT, addr, beneficiary, nil, csdb.GetBalance(addr)))
+	suite.Require().NoError(verifier.Verify(csdb, vm.SELFDESTRUCT, addr, beneficiary, nil, spendable))
+}
+
+func (suite *KeeperTestSuite) TestHandlerVenus2() {
+	handler := vesting.NewHandler(suite.app.VestingKeeper)
+	amount := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100))
+	now := suite.ctx.BlockTime()
+	msg := types.NewMsgCreateVestingAccount(suite.creator, suite.addrs[0], amount,
+		now.Add(-time.Hour).Unix(), now.Add(time.Hour).Unix(), false)
+
+	_, err := handler(suite.ctx, msg)
+	suite.Require().True(types.ErrVestingDisabled.Is(err))
+
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(1)
+	defer tmtypes.UnittestOnlySetMilestoneVenus2Height(0)
+	_, err = handler(suite.ctx, msg)
+	suite.Require().NoError(err)
+	acc := suite.app.AccountKeeper.GetAccount(suite.ctx, suite.addrs[0]).(*ethermint.EthAccount)
+	suite.Require().NotNil(acc.VestingSchedule)
+}
+
+func (suite *KeeperTestSuite) TestQueryBalances() {
+	to := suite.addrs[0]
+	suite.Require().NoError(suite.app.VestingKeeper.CreateVestingAccount(suite.ctx, suite.creator, to, suite.halfVestedSchedule(100)))
+
+	req := abci.RequestQuery{Data: types.ModuleCdc.MustMarshalJSON(types.NewQueryBalancesParams(to))}
+	bz, err := suite.querier(suite.ctx, []string{types.QueryBalances}, req)
+	suite.Require().NoError(err)
+
+	var balances types.VestingBalances
+	suite.Require().NoError(types.ModuleCdc.UnmarshalJSON(bz, &balances))
+	half := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 50))
+	suite.Require().Equal(half, balances.Vested)
+	suite.Require().Equal(half, balances.Locked)
+	suite.Require().Equal(half, balances.Spendable)
+
+	// not a vesting account
+	req = abci.RequestQuery{Data: types.ModuleCdc.MustMarshalJSON(types.NewQueryBalancesParams(suite.creator))}
+	_, err = suite.querier(suite.ctx, []string{types.QueryBalances}, req)
+	suite.Require().True(types.ErrNoVestingAccount.Is(err))
+}
@@ -0,0 +1,20 @@
+package types
+
+import (
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+)
+
+// ModuleCdc defines the vesting module's codec
+var ModuleCdc = codec.New()
+
+// RegisterCodec registers all the necessary types and interfaces for the vesting module
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgCreateVestingAccount{}, "okexchain/vesting/MsgCreateVestingAccount", nil)
+	cdc.RegisterConcrete(MsgCreatePeriodicVestingAccount{}, "okexchain/vesting/MsgCreatePeriodicVestingAccount", nil)
+}
+
+func init() {
+	RegisterCodec(ModuleCdc)
+	codec.RegisterCrypto(ModuleCdc)
+	ModuleCdc.Seal()
+}
@@ -0,0 +1,19 @@
+package types
+
+import (
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+)
+
+// x/vesting module sentinel errors
+var (
+	// ErrAccountExists is returned if the vesting account to create already exists
+	ErrAccountExists = sdkerrors.Register(ModuleName, 1, "account already exists")
+	// ErrInvalidSchedule is returned if the vesting schedule is malformed
+	ErrInvalidSchedule = sdkerrors.Register(ModuleName, 2, "invalid vesting schedule")
+	// ErrNoVestingAccount is returned if the account has no vesting schedule
+	ErrNoVestingAccount = sdkerrors.Register(ModuleName, 3, "not a vesting account")
+	// ErrBlockedAddress is returned if the vesting account to create is not allowed to receive coins
+	ErrBlockedAddress = sdkerrors.Register(ModuleName, 4, "address is not allowed to receive coins")
+	// ErrVestingDisabled is returned if the msg is handled before the Venus2 height
+	ErrVestingDisabled = sdkerrors.Register(ModuleName, 5, "vesting is not enabled before the Venus2 height")
+)
@@ -0,0 +1,11 @@
+package types
+
+// vesting module event types
+const (
+	EventTypeCreateVestingAccount = "create_vesting_account"
+
+	AttributeKeyAddress = "address"
+	AttributeKeyAmount  = "amount"
+
+	AttributeValueCategory = ModuleName
+)
@@ -0,0 +1,10 @@
+package types
+
+const (
+	// ModuleName is the name of the vesting module
+	ModuleName = "vesting"
+	// RouterKey is the msg router key for the vesting module
+	RouterKey = ModuleName
+	// QuerierRoute is the querier route for the vesting module
+	QuerierRoute = ModuleName
+)
@@ -0,0 +1,140 @@
+package types
+
+import (
+	ethermint "github.com/okex/exchain/app/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	vestingtypes "github.com/okex/exchain/libs/cosmos-sdk/x/auth/vesting/types"
+)
+
+// vesting module msg types
+const (
+	TypeMsgCreateVestingAccount         = "create_vesting_account"
+	TypeMsgCreatePeriodicVestingAccount = "create_periodic_vesting_account"
+)
+
+var (
+	_ sdk.Msg = MsgCreateVestingAccount{}
+	_ sdk.Msg = MsgCreatePeriodicVestingAccount{}
+)
+
+// MsgCreateVestingAccount creates a new account whose coins sent by the creator are vested
+// linearly from the start time to the end time, or all at the end time if delayed
+type MsgCreateVestingAccount struct {
+	FromAddress sdk.AccAddress `json:"from_address" yaml:"from_address"`
+	ToAddress   sdk.AccAddress `json:"to_address" yaml:"to_address"`
+	Amount      sdk.SysCoins   `json:"amount" yaml:"amount"`
+	StartTime   int64          `json:"start_time" yaml:"start_time"`
+	EndTime     int64          `json:"end_time" yaml:"end_time"`
+	Delayed     bool           `json:"delayed" yaml:"delayed"`
+}
+
+// NewMsgCreateVestingAccount creates a new MsgCreateVestingAccount instance
+func NewMsgCreateVestingAccount(from, to sdk.AccAddress, amount sdk.SysCoins, startTime, endTime int64, delayed bool) MsgCreateVestingAccount {
+	return MsgCreateVestingAccount{
+		FromAddress: from,
+		ToAddress:   to,
+		Amount:      amount,
+		StartTime:   startTime,
+		EndTime:     endTime,
+		Delayed:     delayed,
+	}
+}
+
+// Route implements sdk.Msg
+func (msg MsgCreateVestingAccount) Route() string { return RouterKey }
+
+// Type implements sdk.Msg
+func (msg MsgCreateVestingAccount) Type() string { return TypeMsgCreateVestingAccount }
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgCreateVestingAccount) ValidateBasic() error {
+	if msg.FromAddress.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing from address")
+	}
+	if msg.ToAddress.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing to address")
+	}
+	if !msg.Amount.IsValid() || !msg.Amount.IsAllPositive() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, msg.Amount.String())
+	}
+	if err := msg.Schedule().Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidSchedule, err.Error())
+	}
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgCreateVestingAccount) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners implements sdk.Msg
+func (msg MsgCreateVestingAccount) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.FromAddress}
+}
+
+// Schedule returns the vesting schedule of the account to create
+func (msg MsgCreateVestingAccount) Schedule() *ethermint.VestingSchedule {
+	if msg.Delayed {
+		return ethermint.NewDelayedVestingSchedule(msg.Amount, msg.EndTime)
+	}
+	return ethermint.NewContinuousVestingSchedule(msg.Amount, msg.StartTime, msg.EndTime)
+}
+
+// MsgCreatePeriodicVestingAccount creates a new account whose coins sent by the creator are vested
+// by the periods from the start time
+type MsgCreatePeriodicVestingAccount struct {
+	FromAddress sdk.AccAddress       `json:"from_address" yaml:"from_address"`
+	ToAddress   sdk.AccAddress       `json:"to_address" yaml:"to_address"`
+	StartTime   int64                `json:"start_time" yaml:"start_time"`
+	Periods     vestingtypes.Periods `json:"periods" yaml:"periods"`
+}
+
+// NewMsgCreatePeriodicVestingAccount creates a new MsgCreatePeriodicVestingAccount instance
+func NewMsgCreatePeriodicVestingAccount(from, to sdk.AccAddress, startTime int64, periods vestingtypes.Periods) MsgCreatePeriodicVestingAccount {
+	return MsgCreatePeriodicVestingAccount{
+		FromAddress: from,
+		ToAddress:   to,
+		StartTime:   startTime,
+		Periods:     periods,
+	}
+}
+
+// Route implements sdk.Msg
+func (msg MsgCreatePeriodicVestingAccount) Route() string { return RouterKey }
+
+// Type implements sdk.Msg
+func (msg MsgCreatePeriodicVestingAccount) Type() string { return TypeMsgCreatePeriodicVestingAccount }
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgCreatePeriodicVestingAccount) ValidateBasic() error {
+	if msg.FromAddress.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing from address")
+	}
+	if msg.ToAddress.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing to address")
+	}
+	if len(msg.Periods) == 0 {
+		return sdkerrors.Wrap(ErrInvalidSchedule, "vesting periods cannot be empty")
+	}
+	if err := msg.Schedule().Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidSchedule, err.Error())
+	}
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgCreatePeriodicVestingAccount) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners implements sdk.Msg
+func (msg MsgCreatePeriodicVestingAccount) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.FromAddress}
+}
+
+// Schedule returns the vesting schedule of the account to create
+func (msg MsgCreatePeriodicVestingAccount) Schedule() *ethermint.VestingSchedule {
+	return ethermint.NewPeriodicVestingSchedule(msg.StartTime, msg.Periods)
+}
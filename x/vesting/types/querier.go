@@ -0,0 +1,34 @@
+package types
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// Querier routes for the vesting module
+const (
+	QueryBalances = "balances"
+)
+
+// QueryBalancesParams defines the parameters necessary for querying the vesting balances of an account
+type QueryBalancesParams struct {
+	Address sdk.AccAddress `json:"address" yaml:"address"`
+}
+
+// NewQueryBalancesParams creates a new QueryBalancesParams instance
+func NewQueryBalancesParams(addr sdk.AccAddress) QueryBalancesParams {
+	return QueryBalancesParams{Address: addr}
+}
+
+// VestingBalances are the vested and locked coins of a vesting account at the block time
+type VestingBalances struct {
+	Address          sdk.AccAddress `json:"address" yaml:"address"`
+	OriginalVesting  sdk.Coins      `json:"original_vesting" yaml:"original_vesting"`
+	Vested           sdk.Coins      `json:"vested" yaml:"vested"`
+	Vesting          sdk.Coins      `json:"vesting" yaml:"vesting"`
+	Locked           sdk.Coins      `json:"locked" yaml:"locked"`
+	Spendable        sdk.Coins      `json:"spendable" yaml:"spendable"`
+	DelegatedFree    sdk.Coins      `json:"delegated_free" yaml:"delegated_free"`
+	DelegatedVesting sdk.Coins      `json:"delegated_vesting" yaml:"delegated_vesting"`
+	StartTime        int64          `json:"start_time" yaml:"start_time"`
+	EndTime          int64          `json:"end_time" yaml:"end_time"`
+}